@@ -73,6 +73,11 @@ func resolveBuildInfo() BuildInfo {
 	return info
 }
 
+// userAgent is the default User-Agent the server sends to backends
+func (info BuildInfo) userAgent() string {
+	return "cloud-native-mcp-server/" + info.Version
+}
+
 func normalizeBuildValue(value, fallback string) string {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
@@ -90,14 +95,21 @@ func buildSettingValue(settings []debug.BuildSetting, key string) string {
 	return ""
 }
 
-// printStartupBuildInfo writes a startup banner to stdout as the first output.
-func printStartupBuildInfo(info BuildInfo) {
+// printStartupBuildInfo writes a startup banner to stderr as the first output. A plain banner is a
+// single undecorated line.
+func printStartupBuildInfo(info BuildInfo, plain bool) {
 	// Parse build time for display
 	buildTimeDisplay := info.BuildTime
 	if t, err := time.Parse(time.RFC3339, info.BuildTime); err == nil {
 		buildTimeDisplay = t.UTC().Format("2006-01-02 15:04:05 UTC")
 	}
 
+	if plain {
+		_, _ = fmt.Fprintf(os.Stderr, "Cloud Native MCP Server %s (commit %s, built %s, %s %s/%s)\n",
+			info.Version, info.ShortCommit, buildTimeDisplay, info.GoVersion, info.OS, info.Arch)
+		return
+	}
+
 	banner := fmt.Sprintf(`
 ╔══════════════════════════════════════════════════════════════╗
 ║  ☸  Cloud Native MCP Server
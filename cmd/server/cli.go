@@ -2,6 +2,8 @@ package main
 
 import (
 	"flag"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -16,6 +18,7 @@ import (
 type CLIConfig struct {
 	Addr         string
 	Kubeconfig   string
+	K8sMode      string // Kubernetes connection mode: auto, in-cluster or kubeconfig
 	LogLevel     string
 	LogJSON      bool
 	ReadTimeout  time.Duration
@@ -27,9 +30,13 @@ type CLIConfig struct {
 	Format       string // text, json, table, csv
 	ServiceName  string // filter by service name
 	Verbose      bool   // verbose output
+	Probe        bool   // check backend connectivity of listed services
+	ProbeHeader  http.Header
+	NoColor      bool // plain output without colors or decoration, also set by NO_COLOR
 	// Flags to track which parameters were explicitly set
 	addrSet         bool
 	kubeconfigSet   bool
+	k8sModeSet      bool
 	logLevelSet     bool
 	readTimeoutSet  bool
 	writeTimeoutSet bool
@@ -42,6 +49,7 @@ func parseFlags() *CLIConfig {
 	var (
 		addr         string
 		kubeconfig   string
+		k8sMode      string
 		logLevel     string
 		readTimeout  int
 		writeTimeout int
@@ -53,6 +61,9 @@ func parseFlags() *CLIConfig {
 		format       string
 		serviceName  string
 		verbose      bool
+		probe        bool
+		probeHeader  = headerFlag{}
+		noColor      bool
 	)
 
 	// Set default kubeconfig path
@@ -60,6 +71,7 @@ func parseFlags() *CLIConfig {
 
 	flag.StringVar(&addr, "addr", "0.0.0.0:8080", "address to listen on")
 	flag.StringVar(&kubeconfig, "kubeconfig", defaultKubeconfig, "path to kubeconfig file")
+	flag.StringVar(&k8sMode, "k8s-connection-mode", "", "Kubernetes connection mode: auto | in-cluster | kubeconfig (env MCP_K8S_CONNECTION_MODE)")
 	flag.StringVar(&logLevel, "log-level", "info", "log level (debug, info, warn, error, fatal)")
 	flag.IntVar(&readTimeout, "read-timeout", 0, "HTTP server read timeout in seconds (0 disables timeout)")
 	flag.IntVar(&writeTimeout, "write-timeout", 0, "HTTP server write timeout in seconds (0 disables timeout; recommended for SSE)")
@@ -70,6 +82,9 @@ func parseFlags() *CLIConfig {
 	flag.StringVar(&format, "output", "text", "output format for list command: text, json, table, csv")
 	flag.StringVar(&serviceName, "service", "", "filter by service name")
 	flag.BoolVar(&verbose, "verbose", false, "verbose output for tools descriptions")
+	flag.BoolVar(&probe, "probe", false, "with --list services, check each service can reach its backend and show latency")
+	flag.Var(probeHeader, "probe-header", "backend credential header sent by --probe, as \"Name: value\" (repeatable)")
+	flag.BoolVar(&noColor, "no-color", false, "plain output: no log colors or banner decoration, and tab-separated --list columns (also set by NO_COLOR)")
 	flag.BoolVar(&help, "help", false, "show help message")
 	flag.Parse()
 
@@ -81,6 +96,7 @@ func parseFlags() *CLIConfig {
 	cfg := &CLIConfig{
 		Addr:         addr,
 		Kubeconfig:   kubeconfig,
+		K8sMode:      k8sMode,
 		LogLevel:     logLevel,
 		ReadTimeout:  time.Duration(readTimeout) * time.Second,
 		WriteTimeout: time.Duration(writeTimeout) * time.Second,
@@ -91,6 +107,9 @@ func parseFlags() *CLIConfig {
 		Format:       format,
 		ServiceName:  serviceName,
 		Verbose:      verbose,
+		Probe:        probe,
+		ProbeHeader:  http.Header(probeHeader),
+		NoColor:      noColor || noColorEnv(),
 	}
 
 	// Track which flags were explicitly set
@@ -100,6 +119,8 @@ func parseFlags() *CLIConfig {
 			cfg.addrSet = true
 		case "kubeconfig":
 			cfg.kubeconfigSet = true
+		case "k8s-connection-mode":
+			cfg.k8sModeSet = true
 		case "log-level":
 			cfg.logLevelSet = true
 		case "read-timeout":
@@ -161,6 +182,20 @@ func applyAppConfig(c *CLIConfig, ac *appconfig.AppConfig) {
 	}
 }
 
+// applyKubernetesFlags passes explicitly set Kubernetes flags on to the service configuration.
+// The kubeconfig flag's default is not passed on so that auto mode can still prefer in-cluster credentials.
+func applyKubernetesFlags(c *CLIConfig, ac *appconfig.AppConfig) {
+	if ac == nil {
+		return
+	}
+	if c.kubeconfigSet {
+		ac.Kubernetes.Kubeconfig = c.Kubeconfig
+	}
+	if c.k8sModeSet {
+		ac.Kubernetes.ConnectionMode = c.K8sMode
+	}
+}
+
 // getDefaultKubeconfig returns the default kubeconfig path
 func getDefaultKubeconfig() string {
 	if envConfig := os.Getenv("KUBECONFIG"); envConfig != "" {
@@ -169,12 +204,19 @@ func getDefaultKubeconfig() string {
 	return filepath.Join(os.Getenv("HOME"), ".kube", "config")
 }
 
-// setupLogging initializes logging with the specified level
-func setupLogging(logLevel string, jsonFormat bool) {
-	logging.InitStdoutLogger()
-	if jsonFormat {
-		logging.EnableJSONFormat()
-	}
+// noColorEnv reports whether NO_COLOR asks for uncolored output; any non-empty value counts (https://no-color.org)
+func noColorEnv() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// setupLogging initializes logging with the specified level, writing to output. Text logs are
+// colored unless noColor is set.
+func setupLogging(logLevel string, jsonFormat, noColor bool, output io.Writer) {
+	config := logging.DefaultConfig()
+	config.Output = output
+	config.UseJSONFormat = jsonFormat
+	config.EnableColors = !noColor
+	logging.InitLoggerWithConfig(config)
 
 	level, err := logrus.ParseLevel(logLevel)
 	if err != nil {
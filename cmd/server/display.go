@@ -2,9 +2,12 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"sort"
 	"strings"
@@ -18,13 +21,35 @@ import (
 
 // ListDisplayOptions holds options for listing
 type ListDisplayOptions struct {
-	Format        string // output format: text, json, table, csv
-	ServiceFilter string // Filter by service name
-	Verbose       bool   // Include full descriptions
+	Format        string      // output format: text, json, table, csv
+	ServiceFilter string      // Filter by service name
+	Verbose       bool        // Include full descriptions
+	Probe         bool        // Check each service can reach its backend
+	ProbeHeader   http.Header // Backend credential headers used by the probe
+	Plain         bool        // Tab-separated text and table output without rulers, for scripts
+}
+
+// listFormats are the supported --output values
+var listFormats = []string{"text", "json", "table", "csv"}
+
+// validateListFormat rejects unknown output formats instead of silently falling back to text
+func validateListFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	for _, supported := range listFormats {
+		if format == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q (expected %s)", format, strings.Join(listFormats, ", "))
 }
 
 // DisplayServices displays all services
 func DisplayServices(enabledServices map[string]services.Service, opts ListDisplayOptions) error {
+	if err := validateListFormat(opts.Format); err != nil {
+		return err
+	}
 	serviceList := make([]map[string]any, 0)
 
 	for name, service := range enabledServices {
@@ -43,12 +68,26 @@ func DisplayServices(enabledServices map[string]services.Service, opts ListDispl
 		return serviceList[i]["name"].(string) < serviceList[j]["name"].(string)
 	})
 
-	switch opts.Format {
-	case "json":
+	if opts.Probe {
+		listed := make(map[string]services.Service, len(serviceList))
+		for _, svc := range serviceList {
+			name := svc["name"].(string)
+			listed[name] = enabledServices[name]
+		}
+		probes := probeServices(context.Background(), listed, opts.ProbeHeader)
+		for _, svc := range serviceList {
+			svc["probe"] = probes[svc["name"].(string)]
+		}
+	}
+
+	switch {
+	case opts.Format == "json":
 		return displayServicesJSON(serviceList)
-	case "csv":
+	case opts.Format == "csv":
 		return displayServicesCSV(serviceList)
-	case "table":
+	case opts.Plain:
+		return displayServicesPlain(os.Stdout, serviceList)
+	case opts.Format == "table":
 		return displayServicesTable(serviceList)
 	default:
 		return displayServicesText(serviceList)
@@ -57,6 +96,9 @@ func DisplayServices(enabledServices map[string]services.Service, opts ListDispl
 
 // DisplayTools displays all tools
 func DisplayTools(tools []mcp.Tool, opts ListDisplayOptions) error {
+	if err := validateListFormat(opts.Format); err != nil {
+		return err
+	}
 	// Filter tools by service if specified
 	filteredTools := tools
 	if opts.ServiceFilter != "" {
@@ -68,12 +110,14 @@ func DisplayTools(tools []mcp.Tool, opts ListDisplayOptions) error {
 		return filteredTools[i].Name < filteredTools[j].Name
 	})
 
-	switch opts.Format {
-	case "json":
+	switch {
+	case opts.Format == "json":
 		return displayToolsJSON(filteredTools)
-	case "csv":
+	case opts.Format == "csv":
 		return displayToolsCSV(filteredTools)
-	case "table":
+	case opts.Plain:
+		return displayToolsPlain(os.Stdout, filteredTools, opts.Verbose)
+	case opts.Format == "table":
 		return displayToolsTable(filteredTools)
 	default:
 		return displayToolsText(filteredTools, opts.Verbose)
@@ -89,11 +133,37 @@ func displayServicesText(services []map[string]any) error {
 		if !svc["enabled"].(bool) {
 			status = "disabled"
 		}
+		if probe, ok := svc["probe"].(ServiceProbe); ok {
+			fmt.Printf("  %s (%s) - %d tools - %s\n", svc["name"], status, svc["tools"], formatProbe(probe))
+			continue
+		}
 		fmt.Printf("  %s (%s) - %d tools\n", svc["name"], status, svc["tools"])
 	}
 	return nil
 }
 
+// formatProbe renders a probe result as its status, latency and error
+func formatProbe(probe ServiceProbe) string {
+	text := probe.Status
+	if probe.LatencyMs > 0 || probe.Status == probeReachable {
+		text += fmt.Sprintf(" (%dms)", probe.LatencyMs)
+	}
+	if probe.Error != "" {
+		text += ": " + probe.Error
+	}
+	return text
+}
+
+// hasProbes reports whether the services were probed
+func hasProbes(services []map[string]any) bool {
+	for _, svc := range services {
+		if _, ok := svc["probe"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // displayServicesJSON displays services in JSON format
 func displayServicesJSON(services []map[string]any) error {
 	data := map[string]any{
@@ -113,7 +183,12 @@ func displayServicesCSV(services []map[string]any) error {
 	writer := csv.NewWriter(os.Stdout)
 	defer writer.Flush()
 
-	if err := writer.Write([]string{"Service Name", "Status", "Tools Count"}); err != nil {
+	probed := hasProbes(services)
+	header := []string{"Service Name", "Status", "Tools Count"}
+	if probed {
+		header = append(header, "Connectivity", "Latency Ms", "Error")
+	}
+	if err := writer.Write(header); err != nil {
 		return err
 	}
 	for _, svc := range services {
@@ -121,11 +196,16 @@ func displayServicesCSV(services []map[string]any) error {
 		if !svc["enabled"].(bool) {
 			status = "disabled"
 		}
-		if err := writer.Write([]string{
+		record := []string{
 			svc["name"].(string),
 			status,
 			fmt.Sprintf("%d", svc["tools"]),
-		}); err != nil {
+		}
+		if probed {
+			probe, _ := svc["probe"].(ServiceProbe)
+			record = append(record, probe.Status, fmt.Sprintf("%d", probe.LatencyMs), probe.Error)
+		}
+		if err := writer.Write(record); err != nil {
 			return err
 		}
 	}
@@ -135,10 +215,15 @@ func displayServicesCSV(services []map[string]any) error {
 // displayServicesTable displays services in table format
 func displayServicesTable(services []map[string]any) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	if _, err := fmt.Fprintln(w, "SERVICE\tSTATUS\tTOOLS"); err != nil {
+	probed := hasProbes(services)
+	header, width := "SERVICE\tSTATUS\tTOOLS", 40
+	if probed {
+		header, width = header+"\tCONNECTIVITY\tLATENCY", 70
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintln(w, strings.Repeat("-", 40)); err != nil {
+	if _, err := fmt.Fprintln(w, strings.Repeat("-", width)); err != nil {
 		return err
 	}
 
@@ -147,13 +232,77 @@ func displayServicesTable(services []map[string]any) error {
 		if !svc["enabled"].(bool) {
 			status = "disabled"
 		}
-		if _, err := fmt.Fprintf(w, "%s\t%s\t%d\n", svc["name"], status, svc["tools"]); err != nil {
+		if !probed {
+			if _, err := fmt.Fprintf(w, "%s\t%s\t%d\n", svc["name"], status, svc["tools"]); err != nil {
+				return err
+			}
+			continue
+		}
+		probe, _ := svc["probe"].(ServiceProbe)
+		latency := "-"
+		if probe.LatencyMs > 0 || probe.Status == probeReachable {
+			latency = fmt.Sprintf("%dms", probe.LatencyMs)
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", svc["name"], status, svc["tools"], probe.Status, latency); err != nil {
 			return err
 		}
 	}
 	return w.Flush()
 }
 
+// displayServicesPlain writes services as tab-separated columns under a single header line
+func displayServicesPlain(w io.Writer, services []map[string]any) error {
+	probed := hasProbes(services)
+	header := "SERVICE\tSTATUS\tTOOLS"
+	if probed {
+		header += "\tCONNECTIVITY\tLATENCY_MS\tERROR"
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+	for _, svc := range services {
+		status := "enabled"
+		if !svc["enabled"].(bool) {
+			status = "disabled"
+		}
+		line := fmt.Sprintf("%s\t%s\t%d", svc["name"], status, svc["tools"])
+		if probed {
+			probe, _ := svc["probe"].(ServiceProbe)
+			line += fmt.Sprintf("\t%s\t%d\t%s", probe.Status, probe.LatencyMs, plainField(probe.Error))
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// displayToolsPlain writes one tool per line, followed by its full description when verbose
+func displayToolsPlain(w io.Writer, tools []mcp.Tool, verbose bool) error {
+	header := "TOOL"
+	if verbose {
+		header += "\tDESCRIPTION"
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+	for _, tool := range tools {
+		line := tool.Name
+		if verbose {
+			line += "\t" + plainField(tool.Description)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plainField collapses tabs, newlines and repeated spaces so a value stays within its column
+func plainField(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
 // displayToolsText displays tools in text format
 func displayToolsText(tools []mcp.Tool, verbose bool) error {
 	fmt.Println("Available Tools:")
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDisplayServicesPlain(t *testing.T) {
+	var output bytes.Buffer
+	services := []map[string]any{
+		{"name": "kibana", "enabled": true, "tools": 100, "probe": ServiceProbe{Status: "unreachable", Error: "dial tcp:\n connection refused"}},
+		{"name": "kubernetes", "enabled": false, "tools": 59, "probe": ServiceProbe{Status: probeReachable, LatencyMs: 12}},
+	}
+	if err := displayServicesPlain(&output, services); err != nil {
+		t.Fatalf("displayServicesPlain() error = %v", err)
+	}
+
+	want := "SERVICE\tSTATUS\tTOOLS\tCONNECTIVITY\tLATENCY_MS\tERROR\n" +
+		"kibana\tenabled\t100\tunreachable\t0\tdial tcp: connection refused\n" +
+		"kubernetes\tdisabled\t59\treachable\t12\t\n"
+	if output.String() != want {
+		t.Fatalf("unexpected output:\n%q\nwant:\n%q", output.String(), want)
+	}
+}
+
+func TestDisplayToolsPlain(t *testing.T) {
+	tools := []mcp.Tool{
+		{Name: "kubernetes_list_resources", Description: "List resources.\n\tSupports\tlabel selectors."},
+		{Name: "kubernetes_get_events"},
+	}
+
+	var output bytes.Buffer
+	if err := displayToolsPlain(&output, tools, false); err != nil {
+		t.Fatalf("displayToolsPlain() error = %v", err)
+	}
+	if output.String() != "TOOL\nkubernetes_list_resources\nkubernetes_get_events\n" {
+		t.Fatalf("unexpected output: %q", output.String())
+	}
+
+	output.Reset()
+	if err := displayToolsPlain(&output, tools, true); err != nil {
+		t.Fatalf("displayToolsPlain() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	if len(lines) != 3 || lines[1] != "kubernetes_list_resources\tList resources. Supports label selectors." || lines[2] != "kubernetes_get_events\t" {
+		t.Fatalf("unexpected verbose output: %q", output.String())
+	}
+}
+
+func TestValidateListFormat(t *testing.T) {
+	for _, format := range []string{"", "text", "json", "table", "csv"} {
+		if err := validateListFormat(format); err != nil {
+			t.Errorf("validateListFormat(%q) error = %v", format, err)
+		}
+	}
+	if err := validateListFormat("yaml"); err == nil || !strings.Contains(err.Error(), "text, json, table, csv") {
+		t.Fatalf("expected an invalid format error, got %v", err)
+	}
+	if err := DisplayTools(nil, ListDisplayOptions{Format: "xml"}); err == nil {
+		t.Fatal("expected DisplayTools to reject an unknown format")
+	}
+}
@@ -4,17 +4,18 @@ import (
 	"os"
 
 	appconfig "github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/manager"
 	"github.com/sirupsen/logrus"
 )
 
 func main() {
 	buildInfo := resolveBuildInfo()
-	printStartupBuildInfo(buildInfo)
 
 	// Set reasonable defaults for GOMAXPROCS
 	setupGOMAXPROCS()
 
 	config := parseFlags()
+	printStartupBuildInfo(buildInfo, config.NoColor)
 
 	// Load configuration once (file or environment/defaults).
 	var appConfig *appconfig.AppConfig
@@ -60,10 +61,26 @@ func main() {
 		logrus.Debug("No mode specified, defaulting to 'sse'")
 	}
 
-	setupLogging(config.LogLevel, config.LogJSON)
+	applyKubernetesFlags(config, appConfig)
+	if appConfig != nil && appConfig.Server.UserAgent == "" {
+		appConfig.Server.UserAgent = buildInfo.userAgent()
+	}
+
+	// A listing owns stdout so that it can be piped; its logs go to stderr
+	logOutput := os.Stdout
+	if config.ListMode != "" {
+		logOutput = os.Stderr
+	}
+	setupLogging(config.LogLevel, config.LogJSON, config.NoColor, logOutput)
 
 	// Initialize metrics system
 	initMetrics(buildInfo, config.Mode, config.Addr)
+	manager.SetBuildInfo(manager.BuildInfo{
+		Version:   buildInfo.Version,
+		Commit:    buildInfo.Commit,
+		BuildTime: buildInfo.BuildTime,
+		GoVersion: buildInfo.GoVersion,
+	})
 
 	// Initialize MCP server
 	mcpServer, sc := initMCPServer(appConfig)
@@ -74,6 +91,9 @@ func main() {
 			Format:        config.Format,
 			ServiceFilter: config.ServiceName,
 			Verbose:       config.Verbose,
+			Probe:         config.Probe,
+			ProbeHeader:   config.ProbeHeader,
+			Plain:         config.NoColor,
 		}
 
 		switch config.ListMode {
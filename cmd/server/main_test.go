@@ -1,8 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -138,6 +140,8 @@ func TestApplyAppConfigWithValidConfig(t *testing.T) {
 			ReadTimeoutSec  int    `yaml:"readTimeoutSec"`
 			WriteTimeoutSec int    `yaml:"writeTimeoutSec"`
 			IdleTimeoutSec  int    `yaml:"idleTimeoutSec"`
+			ToolTimeoutSec  int    `yaml:"toolTimeoutSec"`
+			UserAgent       string `yaml:"userAgent"`
 			SSEPaths        struct {
 				Kubernetes    string `yaml:"kubernetes"`
 				Grafana       string `yaml:"grafana"`
@@ -153,6 +157,7 @@ func TestApplyAppConfigWithValidConfig(t *testing.T) {
 				OpenTelemetry string `yaml:"opentelemetry"`
 				Langfuse      string `yaml:"langfuse"`
 				Sentry        string `yaml:"sentry"`
+				Dify          string `yaml:"dify"`
 				Aggregate     string `yaml:"aggregate"`
 				Utilities     string `yaml:"utilities"`
 			} `yaml:"ssePaths"`
@@ -171,6 +176,7 @@ func TestApplyAppConfigWithValidConfig(t *testing.T) {
 				OpenTelemetry string `yaml:"opentelemetry"`
 				Langfuse      string `yaml:"langfuse"`
 				Sentry        string `yaml:"sentry"`
+				Dify          string `yaml:"dify"`
 				Aggregate     string `yaml:"aggregate"`
 				Utilities     string `yaml:"utilities"`
 			} `yaml:"streamableHttpPaths"`
@@ -245,6 +251,8 @@ func TestApplyAppConfigCLITakesPrecedence(t *testing.T) {
 			ReadTimeoutSec  int    `yaml:"readTimeoutSec"`
 			WriteTimeoutSec int    `yaml:"writeTimeoutSec"`
 			IdleTimeoutSec  int    `yaml:"idleTimeoutSec"`
+			ToolTimeoutSec  int    `yaml:"toolTimeoutSec"`
+			UserAgent       string `yaml:"userAgent"`
 			SSEPaths        struct {
 				Kubernetes    string `yaml:"kubernetes"`
 				Grafana       string `yaml:"grafana"`
@@ -260,6 +268,7 @@ func TestApplyAppConfigCLITakesPrecedence(t *testing.T) {
 				OpenTelemetry string `yaml:"opentelemetry"`
 				Langfuse      string `yaml:"langfuse"`
 				Sentry        string `yaml:"sentry"`
+				Dify          string `yaml:"dify"`
 				Aggregate     string `yaml:"aggregate"`
 				Utilities     string `yaml:"utilities"`
 			} `yaml:"ssePaths"`
@@ -278,6 +287,7 @@ func TestApplyAppConfigCLITakesPrecedence(t *testing.T) {
 				OpenTelemetry string `yaml:"opentelemetry"`
 				Langfuse      string `yaml:"langfuse"`
 				Sentry        string `yaml:"sentry"`
+				Dify          string `yaml:"dify"`
 				Aggregate     string `yaml:"aggregate"`
 				Utilities     string `yaml:"utilities"`
 			} `yaml:"streamableHttpPaths"`
@@ -315,8 +325,27 @@ func TestApplyAppConfigCLITakesPrecedence(t *testing.T) {
 	}
 }
 
+func TestApplyKubernetesFlags(t *testing.T) {
+	appConfig := &config.AppConfig{}
+	appConfig.Kubernetes.Kubeconfig = "/from/config"
+	appConfig.Kubernetes.ConnectionMode = "auto"
+
+	// Flags left at their defaults keep the configured values
+	applyKubernetesFlags(&CLIConfig{Kubeconfig: "/home/user/.kube/config"}, appConfig)
+	if appConfig.Kubernetes.Kubeconfig != "/from/config" || appConfig.Kubernetes.ConnectionMode != "auto" {
+		t.Fatalf("unset flags should not override the config, got %+v", appConfig.Kubernetes)
+	}
+
+	applyKubernetesFlags(&CLIConfig{Kubeconfig: "/from/flag", K8sMode: "kubeconfig", kubeconfigSet: true, k8sModeSet: true}, appConfig)
+	if appConfig.Kubernetes.Kubeconfig != "/from/flag" || appConfig.Kubernetes.ConnectionMode != "kubeconfig" {
+		t.Fatalf("explicit flags should override the config, got %+v", appConfig.Kubernetes)
+	}
+
+	applyKubernetesFlags(&CLIConfig{kubeconfigSet: true}, nil)
+}
+
 func TestSetupLoggingWithJSONFormat(t *testing.T) {
-	setupLogging("info", true)
+	setupLogging("info", true, false, os.Stdout)
 
 	if _, ok := logrus.StandardLogger().Formatter.(*logrus.JSONFormatter); !ok {
 		t.Fatalf("expected JSON formatter when jsonFormat=true, got %T", logrus.StandardLogger().Formatter)
@@ -324,13 +353,29 @@ func TestSetupLoggingWithJSONFormat(t *testing.T) {
 }
 
 func TestSetupLoggingWithTextFormat(t *testing.T) {
-	setupLogging("info", false)
+	setupLogging("info", false, false, os.Stdout)
 
 	if _, ok := logrus.StandardLogger().Formatter.(*logrus.TextFormatter); !ok {
 		t.Fatalf("expected Text formatter when jsonFormat=false, got %T", logrus.StandardLogger().Formatter)
 	}
 }
 
+func TestSetupLoggingWithoutColor(t *testing.T) {
+	defer setupLogging("info", false, false, os.Stdout)
+
+	var output bytes.Buffer
+	setupLogging("info", false, true, &output)
+	logrus.Info("listing tools")
+
+	formatter, ok := logrus.StandardLogger().Formatter.(*logrus.TextFormatter)
+	if !ok || formatter.ForceColors || !formatter.DisableColors {
+		t.Fatalf("expected an uncolored text formatter, got %+v", logrus.StandardLogger().Formatter)
+	}
+	if !strings.Contains(output.String(), "listing tools") || strings.Contains(output.String(), "\x1b[") {
+		t.Fatalf("expected plain log output on the given writer, got %q", output.String())
+	}
+}
+
 func TestGetDefaultKubeconfig(t *testing.T) {
 	// Save original env
 	originalKubeconfig := os.Getenv("KUBECONFIG")
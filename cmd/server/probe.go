@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/middleware"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services"
+)
+
+// probeTimeout bounds the connectivity check of a single service
+const probeTimeout = 5 * time.Second
+
+// Probe results shown per service by --list services --probe
+const (
+	probeReachable     = "reachable"
+	probeUnreachable   = "unreachable"
+	probeNoCredentials = "no_credentials"
+	probeNotSupported  = "not_supported"
+)
+
+// ServiceProbe is the result of checking one service can reach its backend
+type ServiceProbe struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// headerFlag collects repeated "Name: value" flags into HTTP headers
+type headerFlag http.Header
+
+func (h headerFlag) String() string {
+	pairs := make([]string, 0, len(h))
+	for name, values := range h {
+		for _, value := range values {
+			pairs = append(pairs, name+": "+value)
+		}
+	}
+	return strings.Join(pairs, ", ")
+}
+
+func (h headerFlag) Set(value string) error {
+	name, val, ok := strings.Cut(value, ":")
+	if !ok {
+		name, val, ok = strings.Cut(value, "=")
+	}
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return fmt.Errorf("expected Name: value, got %q", value)
+	}
+	http.Header(h).Add(name, strings.TrimSpace(val))
+	return nil
+}
+
+// probeServices checks every service in enabledServices can reach its backend, concurrently and each
+// bounded by probeTimeout. Backend clients are built from header by the same handlers that serve
+// tool calls, so a service whose credentials only come from headers reports no_credentials without them.
+func probeServices(ctx context.Context, enabledServices map[string]services.Service, header http.Header) map[string]ServiceProbe {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		probes = make(map[string]ServiceProbe, len(enabledServices))
+	)
+	for name, svc := range enabledServices {
+		checker, ok := svc.(services.ConnectivityChecker)
+		if !ok || !svc.IsEnabled() {
+			probes[name] = ServiceProbe{Status: probeNotSupported}
+			continue
+		}
+		wg.Add(1)
+		go func(name string, checker services.ConnectivityChecker) {
+			defer wg.Done()
+			probe := probeService(ctx, name, checker, header)
+			mu.Lock()
+			probes[name] = probe
+			mu.Unlock()
+		}(name, checker)
+	}
+	wg.Wait()
+	return probes
+}
+
+func probeService(ctx context.Context, name string, checker services.ConnectivityChecker, header http.Header) ServiceProbe {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	if err != nil {
+		return ServiceProbe{Status: probeUnreachable, Error: err.Error()}
+	}
+	req.Header = header.Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	// A handler that finds no credentials in the headers fails here or leaves no client behind;
+	// both surface below as ErrNoBackendClient, reported with the handler's reason when it has one
+	authed, authErr := middleware.ApplyBackendAuth(name, req)
+	if authErr == nil {
+		req = authed
+	}
+
+	start := time.Now()
+	err = checker.CheckConnectivity(req.Context())
+	latency := time.Since(start).Milliseconds()
+	switch {
+	case err == nil:
+		return ServiceProbe{Status: probeReachable, LatencyMs: latency}
+	case errors.Is(err, services.ErrNoBackendClient):
+		if authErr != nil {
+			err = authErr
+		}
+		return ServiceProbe{Status: probeNoCredentials, Error: err.Error()}
+	default:
+		return ServiceProbe{Status: probeUnreachable, LatencyMs: latency, Error: err.Error()}
+	}
+}
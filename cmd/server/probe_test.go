@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	server "github.com/mark3labs/mcp-go/server"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/middleware"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services"
+)
+
+type probeContextKey struct{}
+
+// probedService is a service without a connectivity check
+type probedService struct {
+	name string
+}
+
+func (s *probedService) Name() string                                   { return s.name }
+func (s *probedService) GetTools() []mcp.Tool                           { return nil }
+func (s *probedService) GetHandlers() map[string]server.ToolHandlerFunc { return nil }
+func (s *probedService) Initialize(cfg interface{}) error               { return nil }
+func (s *probedService) IsEnabled() bool                                { return true }
+
+// checkedService checks connectivity with the token its backend auth handler put in the context
+type checkedService struct{ probedService }
+
+func (s *checkedService) CheckConnectivity(ctx context.Context) error {
+	token, ok := ctx.Value(probeContextKey{}).(string)
+	switch {
+	case !ok:
+		return fmt.Errorf("%w: client not found in context", services.ErrNoBackendClient)
+	case token != "valid":
+		return errors.New("401 Unauthorized")
+	}
+	return nil
+}
+
+func TestProbeServices(t *testing.T) {
+	for _, name := range []string{"probe-ok", "probe-denied", "probe-missing"} {
+		middleware.RegisterBackendAuthHandler(name, func(r *http.Request) (*http.Request, error) {
+			token := r.Header.Get("X-Mcp-Backend-" + name + "-Token")
+			if token == "" {
+				return r, fmt.Errorf("no %s token in headers", name)
+			}
+			return r.WithContext(context.WithValue(r.Context(), probeContextKey{}, token)), nil
+		})
+	}
+
+	header := headerFlag{}
+	for _, value := range []string{"X-Mcp-Backend-probe-ok-Token: valid", "X-Mcp-Backend-probe-denied-Token=stale"} {
+		if err := header.Set(value); err != nil {
+			t.Fatalf("Set(%q) error = %v", value, err)
+		}
+	}
+	if err := header.Set("no separator"); err == nil {
+		t.Fatal("expected an error for a header without a value")
+	}
+
+	enabled := map[string]services.Service{
+		"probe-ok":      &checkedService{probedService{name: "probe-ok"}},
+		"probe-denied":  &checkedService{probedService{name: "probe-denied"}},
+		"probe-missing": &checkedService{probedService{name: "probe-missing"}},
+		"probe-none":    &probedService{name: "probe-none"},
+	}
+	probes := probeServices(context.Background(), enabled, http.Header(header))
+
+	want := map[string]string{
+		"probe-ok":      probeReachable,
+		"probe-denied":  probeUnreachable,
+		"probe-missing": probeNoCredentials,
+		"probe-none":    probeNotSupported,
+	}
+	for name, status := range want {
+		if probes[name].Status != status {
+			t.Errorf("%s: status = %q, want %q (%+v)", name, probes[name].Status, status, probes[name])
+		}
+	}
+	if got := probes["probe-denied"].Error; got != "401 Unauthorized" {
+		t.Errorf("expected the check error, got %q", got)
+	}
+	if got := probes["probe-missing"].Error; got != "no probe-missing token in headers" {
+		t.Errorf("expected the backend auth handler's reason, got %q", got)
+	}
+}
@@ -80,8 +80,14 @@ func startHTTPServer(config *CLIConfig, appConfig *appconfig.AppConfig, mcpServe
 	}
 	mux.Handle("/metrics", metricsHandler)
 
+	var handler http.Handler = mux
+	if appConfig != nil && appConfig.Compression.Enabled {
+		handler = middleware.CompressionMiddleware(appConfig.Compression.MinSizeBytes)(handler)
+		logrus.Info("Gzip response compression enabled")
+	}
+
 	// Wrap mux with metrics middleware
-	handler := middleware.MetricsMiddleware("cloud-native-mcp-server")(mux)
+	handler = middleware.MetricsMiddleware("cloud-native-mcp-server")(handler)
 
 	srv := createServer(config, handler)
 
@@ -13,6 +13,8 @@ type AppConfig struct {
 		ReadTimeoutSec  int    `yaml:"readTimeoutSec"`  // 0 disables
 		WriteTimeoutSec int    `yaml:"writeTimeoutSec"` // 0 disables
 		IdleTimeoutSec  int    `yaml:"idleTimeoutSec"`  // default 60
+		ToolTimeoutSec  int    `yaml:"toolTimeoutSec"`  // deadline for a single tool call, default 600
+		UserAgent       string `yaml:"userAgent"`       // User-Agent sent to the Kubernetes API server and Kibana, default cloud-native-mcp-server/<version>
 		SSEPaths        struct {
 			Kubernetes    string `yaml:"kubernetes"`    // SSE path for Kubernetes service
 			Grafana       string `yaml:"grafana"`       // SSE path for Grafana service
@@ -64,6 +66,17 @@ type AppConfig struct {
 		JSON  bool   `yaml:"json"`
 	} `yaml:"logging"`
 
+	Compression struct {
+		Enabled      bool `yaml:"enabled"`      // Gzip HTTP responses for clients sending Accept-Encoding: gzip
+		MinSizeBytes int  `yaml:"minSizeBytes"` // HTTP responses smaller than this are not compressed
+		ToolResults  bool `yaml:"toolResults"`  // Return large tool results as base64-encoded gzip
+	} `yaml:"compression"`
+
+	ResponseLimits struct {
+		MaxBytes int            `yaml:"maxBytes"` // Hard cap on tool result size in bytes (0 disables)
+		Services map[string]int `yaml:"services"` // Per-service caps keyed by service name, overriding maxBytes
+	} `yaml:"responseLimits"`
+
 	RateLimit struct {
 		Enabled           bool    `yaml:"enabled"`             // Enable request rate limiting
 		RequestsPerSecond float64 `yaml:"requests_per_second"` // Allowed requests per second
@@ -71,10 +84,18 @@ type AppConfig struct {
 	} `yaml:"ratelimit"`
 
 	Kubernetes struct {
-		Kubeconfig string  `yaml:"kubeconfig"`
-		TimeoutSec int     `yaml:"timeoutSec"`
-		QPS        float32 `yaml:"qps"`
-		Burst      int     `yaml:"burst"`
+		Kubeconfig           string  `yaml:"kubeconfig"`
+		ConnectionMode       string  `yaml:"connectionMode"` // auto (default), in-cluster or kubeconfig
+		TimeoutSec           int     `yaml:"timeoutSec"`
+		QPS                  float32 `yaml:"qps"`
+		Burst                int     `yaml:"burst"`
+		MaxIdleConns         int     `yaml:"maxIdleConns"`         // Idle API server connections kept open in total
+		MaxIdleConnsPerHost  int     `yaml:"maxIdleConnsPerHost"`  // Idle connections kept open per API server
+		RateLimitDiscovery   bool    `yaml:"rateLimitDiscovery"`   // Apply qps/burst to discovery requests too; they bypass it by default
+		DiscoveryCacheTTLSec int     `yaml:"discoveryCacheTTLSec"` // How long discovered API groups and resources are reused
+		MaxToolTimeoutSec    int     `yaml:"maxToolTimeoutSec"`    // Upper bound for the per-call timeoutSeconds tool argument
+		RetryMaxAttempts     int     `yaml:"retryMaxAttempts"`     // Attempts for reads failing transiently and updates hitting a conflict; 1 disables retries
+		RetryBackoffMs       int     `yaml:"retryBackoffMs"`       // Wait before the first retry, doubled for each further one
 	} `yaml:"kubernetes"`
 
 	Prometheus struct {
@@ -113,14 +134,20 @@ type AppConfig struct {
 	} `yaml:"grafana"`
 
 	Kibana struct {
-		Enabled    bool   `yaml:"enabled"`    // Enable Kibana service
-		URL        string `yaml:"url"`        // Kibana URL
-		APIKey     string `yaml:"apiKey"`     // Kibana API key
-		Username   string `yaml:"username"`   // Kibana username for basic auth
-		Password   string `yaml:"password"`   // Kibana password for basic auth
-		TimeoutSec int    `yaml:"timeoutSec"` // Request timeout in seconds
-		SkipVerify bool   `yaml:"skipVerify"` // Skip TLS certificate verification
-		Space      string `yaml:"space"`      // Kibana space (default: default)
+		Enabled     bool              `yaml:"enabled"`     // Enable Kibana service
+		URL         string            `yaml:"url"`         // Kibana URL
+		APIKey      string            `yaml:"apiKey"`      // Kibana API key
+		Username    string            `yaml:"username"`    // Kibana username for basic auth
+		Password    string            `yaml:"password"`    // Kibana password for basic auth
+		TimeoutSec  int               `yaml:"timeoutSec"`  // Request timeout in seconds
+		SkipVerify  bool              `yaml:"skipVerify"`  // Skip TLS certificate verification
+		Space       string            `yaml:"space"`       // Kibana space (default: default)
+		Headers     map[string]string `yaml:"headers"`     // Extra headers sent on every Kibana request
+		TLSCertFile string            `yaml:"tlsCertFile"` // PEM client certificate for mutual TLS
+		TLSKeyFile  string            `yaml:"tlsKeyFile"`  // PEM private key matching tlsCertFile
+		TLSCAFile   string            `yaml:"tlsCAFile"`   // PEM CA bundle used to verify the Kibana server certificate
+		ExportDir   string            `yaml:"exportDir"`   // Directory for saved object exports and reports written to file (default: <tmp>/kibana-exports)
+		ImportDir   string            `yaml:"importDir"`   // Only directory saved object imports may read files from (default: exportDir)
 	} `yaml:"kibana"`
 
 	Helm struct {
@@ -330,7 +357,7 @@ type AppConfig struct {
 //
 // Environment variables:
 //
-//	MCP_MODE, MCP_ADDR, MCP_READ_TIMEOUT, MCP_WRITE_TIMEOUT, MCP_IDLE_TIMEOUT,
+//	MCP_MODE, MCP_ADDR, MCP_READ_TIMEOUT, MCP_WRITE_TIMEOUT, MCP_IDLE_TIMEOUT, MCP_TOOL_TIMEOUT,
 //	MCP_SSE_PATH_KUBERNETES, MCP_SSE_PATH_GRAFANA, MCP_SSE_PATH_PROMETHEUS, MCP_SSE_PATH_LOKI,
 //	MCP_SSE_PATH_KIBANA, MCP_SSE_PATH_HELM, MCP_SSE_PATH_ELASTICSEARCH, MCP_SSE_PATH_ALERTMANAGER,
 //	MCP_SSE_PATH_JAEGER, MCP_SSE_PATH_OPENTELEMETRY, MCP_SSE_PATH_LANGFUSE, MCP_SSE_PATH_SENTRY,
@@ -343,7 +370,9 @@ type AppConfig struct {
 //	MCP_STREAMABLE_HTTP_PATH_SENTRY, MCP_STREAMABLE_HTTP_PATH_AGGREGATE,
 //	MCP_STREAMABLE_HTTP_PATH_UTILITIES,
 //	MCP_LOG_LEVEL, MCP_LOG_JSON,
-//	MCP_KUBECONFIG, MCP_K8S_TIMEOUT, MCP_K8S_QPS, MCP_K8S_BURST,
+//	MCP_KUBECONFIG, MCP_K8S_CONNECTION_MODE, MCP_K8S_TIMEOUT, MCP_K8S_QPS, MCP_K8S_BURST, MCP_K8S_MAX_TOOL_TIMEOUT,
+//	MCP_K8S_MAX_IDLE_CONNS, MCP_K8S_MAX_IDLE_CONNS_PER_HOST, MCP_K8S_RATE_LIMIT_DISCOVERY,
+//	MCP_K8S_DISCOVERY_CACHE_TTL, MCP_K8S_RETRY_MAX_ATTEMPTS, MCP_K8S_RETRY_BACKOFF_MS,
 //	MCP_PROM_ENABLED, MCP_PROM_ADDRESS, MCP_PROM_TIMEOUT, MCP_PROM_USERNAME, MCP_PROM_PASSWORD,
 //	MCP_PROM_BEARER_TOKEN, MCP_PROM_TLS_SKIP_VERIFY, MCP_PROM_TLS_CERT_FILE,
 //	MCP_PROM_TLS_KEY_FILE, MCP_PROM_TLS_CA_FILE,
@@ -354,7 +383,8 @@ type AppConfig struct {
 //	MCP_GRAFANA_USERNAME, MCP_GRAFANA_PASSWORD, MCP_GRAFANA_TIMEOUT,
 //	MCP_KIBANA_ENABLED, MCP_KIBANA_URL, MCP_KIBANA_API_KEY,
 //	MCP_KIBANA_USERNAME, MCP_KIBANA_PASSWORD, MCP_KIBANA_TIMEOUT,
-//	MCP_KIBANA_SKIP_VERIFY, MCP_KIBANA_SPACE,
+//	MCP_KIBANA_SKIP_VERIFY, MCP_KIBANA_SPACE, MCP_KIBANA_HEADERS,
+//	MCP_KIBANA_EXPORT_DIR, MCP_KIBANA_IMPORT_DIR,
 //	MCP_HELM_ENABLED, MCP_HELM_KUBECONFIG, MCP_HELM_NAMESPACE, MCP_HELM_DEBUG,
 //	MCP_HELM_TIMEOUT, MCP_HELM_MAX_RETRIES, MCP_HELM_HTTP_PROXY,
 //	MCP_ELASTICSEARCH_ENABLED, MCP_ELASTICSEARCH_ADDRESSES, MCP_ELASTICSEARCH_ADDRESS,
@@ -394,6 +424,8 @@ type AppConfig struct {
 //	MCP_OTEL_METRICS_ENABLED, MCP_OTEL_METRICS_EXPORT_INTERVAL, MCP_OTEL_METRICS_EXPORT_TIMEOUT,
 //	MCP_OTEL_METRICS_TEMPORALITY,
 //	MCP_RATELIMIT_ENABLED, MCP_RATELIMIT_REQUESTS_PER_SECOND, MCP_RATELIMIT_BURST,
+//	MCP_COMPRESSION_ENABLED, MCP_COMPRESSION_MIN_SIZE, MCP_COMPRESSION_TOOL_RESULTS,
+//	MCP_RESPONSE_MAX_BYTES, MCP_RESPONSE_MAX_BYTES_SERVICES,
 //	MCP_DISABLED_SERVICES, MCP_ENABLED_SERVICES, MCP_DISABLED_TOOLS
 func Load(path string) (*AppConfig, error) {
 	loader := NewConfigLoader()
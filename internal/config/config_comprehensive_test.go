@@ -117,6 +117,30 @@ func TestKubernetesConfig(t *testing.T) {
 	}
 }
 
+func TestKubernetesConnectionModeFromEnv(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Kubernetes.ConnectionMode != "auto" {
+		t.Errorf("Expected default connection mode 'auto', got %q", cfg.Kubernetes.ConnectionMode)
+	}
+
+	t.Setenv("MCP_K8S_CONNECTION_MODE", "in-cluster")
+	cfg, err = Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Kubernetes.ConnectionMode != "in-cluster" {
+		t.Errorf("Expected connection mode 'in-cluster', got %q", cfg.Kubernetes.ConnectionMode)
+	}
+
+	t.Setenv("MCP_K8S_CONNECTION_MODE", "service-account")
+	if _, err := Load(""); err == nil {
+		t.Fatal("Expected error for an unknown kubernetes connection mode")
+	}
+}
+
 func TestPrometheusConfig(t *testing.T) {
 	originalAddr := os.Getenv("MCP_PROM_ADDRESS")
 	originalEnabled := os.Getenv("MCP_PROM_ENABLED")
@@ -232,6 +256,54 @@ func TestKibanaConfig(t *testing.T) {
 	}
 }
 
+func TestKibanaHeadersConfig(t *testing.T) {
+	t.Setenv("MCP_KIBANA_HEADERS", "X-Forwarded-For=10.0.0.1, X-Gateway-Key = secret=1,malformed")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(cfg.Kibana.Headers) != 2 {
+		t.Fatalf("Expected 2 headers, got %v", cfg.Kibana.Headers)
+	}
+	if cfg.Kibana.Headers["X-Forwarded-For"] != "10.0.0.1" || cfg.Kibana.Headers["X-Gateway-Key"] != "secret=1" {
+		t.Errorf("Unexpected headers: %v", cfg.Kibana.Headers)
+	}
+}
+
+func TestKibanaTLSFilesConfig(t *testing.T) {
+	t.Setenv("MCP_KIBANA_TLS_CERT_FILE", "/etc/mcp/kibana.crt")
+	t.Setenv("MCP_KIBANA_TLS_KEY_FILE", "/etc/mcp/kibana.key")
+	t.Setenv("MCP_KIBANA_TLS_CA_FILE", "/etc/mcp/ca.crt")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Kibana.TLSCertFile != "/etc/mcp/kibana.crt" || cfg.Kibana.TLSKeyFile != "/etc/mcp/kibana.key" || cfg.Kibana.TLSCAFile != "/etc/mcp/ca.crt" {
+		t.Errorf("Unexpected TLS files: %q, %q, %q", cfg.Kibana.TLSCertFile, cfg.Kibana.TLSKeyFile, cfg.Kibana.TLSCAFile)
+	}
+}
+
+func TestKibanaExportImportDirConfig(t *testing.T) {
+	t.Setenv("MCP_KIBANA_EXPORT_DIR", "/var/lib/mcp/exports")
+	t.Setenv("MCP_KIBANA_IMPORT_DIR", "/var/lib/mcp/imports")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Kibana.ExportDir != "/var/lib/mcp/exports" {
+		t.Errorf("Expected export dir '/var/lib/mcp/exports', got '%s'", cfg.Kibana.ExportDir)
+	}
+	if cfg.Kibana.ImportDir != "/var/lib/mcp/imports" {
+		t.Errorf("Expected import dir '/var/lib/mcp/imports', got '%s'", cfg.Kibana.ImportDir)
+	}
+}
+
 func TestHelmConfig(t *testing.T) {
 	originalNamespace := os.Getenv("MCP_HELM_NAMESPACE")
 	originalDebug := os.Getenv("MCP_HELM_DEBUG")
@@ -692,3 +764,20 @@ func assertStringSliceEqual(t *testing.T, got, want []string) {
 		}
 	}
 }
+
+func TestResponseLimitsConfig(t *testing.T) {
+	t.Setenv("MCP_RESPONSE_MAX_BYTES", "262144")
+	t.Setenv("MCP_RESPONSE_MAX_BYTES_SERVICES", "Kubernetes=524288,kibana=131072")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.ResponseLimits.MaxBytes != 262144 {
+		t.Errorf("Expected MaxBytes 262144, got %d", cfg.ResponseLimits.MaxBytes)
+	}
+	if cfg.ResponseLimits.Services["kubernetes"] != 524288 || cfg.ResponseLimits.Services["kibana"] != 131072 {
+		t.Errorf("Unexpected per-service limits: %v", cfg.ResponseLimits.Services)
+	}
+}
@@ -41,6 +41,8 @@ func (p *EnvParser) Parse(cfg *AppConfig) *AppConfig {
 	p.parseOpenTelemetryConfig(cfg, over)
 	p.parseServerOTELConfig(cfg, over)
 	p.parseRateLimitConfig(cfg, over)
+	p.parseCompressionConfig(cfg, over)
+	p.parseResponseLimitsConfig(cfg, over)
 	p.parseAuditConfig(cfg, over)
 	p.parseAuthConfig(cfg, over)
 	p.parseEnableDisableConfig(cfg, over)
@@ -64,6 +66,12 @@ func (p *EnvParser) parseServerConfig(cfg *AppConfig, over func(string) (string,
 	if v, ok := over("MCP_IDLE_TIMEOUT"); ok {
 		cfg.Server.IdleTimeoutSec = atoiDefault(v, cfg.Server.IdleTimeoutSec)
 	}
+	if v, ok := over("MCP_TOOL_TIMEOUT"); ok {
+		cfg.Server.ToolTimeoutSec = atoiDefault(v, cfg.Server.ToolTimeoutSec)
+	}
+	if v, ok := over("MCP_USER_AGENT"); ok {
+		cfg.Server.UserAgent = v
+	}
 
 	// SSE paths configuration
 	if v, ok := over("MCP_SSE_PATH_KUBERNETES"); ok {
@@ -179,6 +187,9 @@ func (p *EnvParser) parseKubernetesConfig(cfg *AppConfig, over func(string) (str
 	if v, ok := over("MCP_KUBECONFIG"); ok {
 		cfg.Kubernetes.Kubeconfig = v
 	}
+	if v, ok := over("MCP_K8S_CONNECTION_MODE"); ok {
+		cfg.Kubernetes.ConnectionMode = v
+	}
 	if v, ok := over("MCP_K8S_TIMEOUT"); ok {
 		cfg.Kubernetes.TimeoutSec = atoiDefault(v, cfg.Kubernetes.TimeoutSec)
 	}
@@ -188,6 +199,27 @@ func (p *EnvParser) parseKubernetesConfig(cfg *AppConfig, over func(string) (str
 	if v, ok := over("MCP_K8S_BURST"); ok {
 		cfg.Kubernetes.Burst = atoiDefault(v, cfg.Kubernetes.Burst)
 	}
+	if v, ok := over("MCP_K8S_MAX_IDLE_CONNS"); ok {
+		cfg.Kubernetes.MaxIdleConns = atoiDefault(v, cfg.Kubernetes.MaxIdleConns)
+	}
+	if v, ok := over("MCP_K8S_MAX_IDLE_CONNS_PER_HOST"); ok {
+		cfg.Kubernetes.MaxIdleConnsPerHost = atoiDefault(v, cfg.Kubernetes.MaxIdleConnsPerHost)
+	}
+	if v, ok := over("MCP_K8S_RATE_LIMIT_DISCOVERY"); ok {
+		cfg.Kubernetes.RateLimitDiscovery = isTrue(v)
+	}
+	if v, ok := over("MCP_K8S_DISCOVERY_CACHE_TTL"); ok {
+		cfg.Kubernetes.DiscoveryCacheTTLSec = atoiDefault(v, cfg.Kubernetes.DiscoveryCacheTTLSec)
+	}
+	if v, ok := over("MCP_K8S_MAX_TOOL_TIMEOUT"); ok {
+		cfg.Kubernetes.MaxToolTimeoutSec = atoiDefault(v, cfg.Kubernetes.MaxToolTimeoutSec)
+	}
+	if v, ok := over("MCP_K8S_RETRY_MAX_ATTEMPTS"); ok {
+		cfg.Kubernetes.RetryMaxAttempts = atoiDefault(v, cfg.Kubernetes.RetryMaxAttempts)
+	}
+	if v, ok := over("MCP_K8S_RETRY_BACKOFF_MS"); ok {
+		cfg.Kubernetes.RetryBackoffMs = atoiDefault(v, cfg.Kubernetes.RetryBackoffMs)
+	}
 }
 
 func (p *EnvParser) parsePrometheusConfig(cfg *AppConfig, over func(string) (string, bool)) {
@@ -302,6 +334,24 @@ func (p *EnvParser) parseKibanaConfig(cfg *AppConfig, over func(string) (string,
 	if v, ok := over("MCP_KIBANA_SPACE"); ok {
 		cfg.Kibana.Space = v
 	}
+	if v, ok := over("MCP_KIBANA_HEADERS"); ok {
+		cfg.Kibana.Headers = parseKeyValueList(v)
+	}
+	if v, ok := over("MCP_KIBANA_TLS_CERT_FILE"); ok {
+		cfg.Kibana.TLSCertFile = v
+	}
+	if v, ok := over("MCP_KIBANA_TLS_KEY_FILE"); ok {
+		cfg.Kibana.TLSKeyFile = v
+	}
+	if v, ok := over("MCP_KIBANA_TLS_CA_FILE"); ok {
+		cfg.Kibana.TLSCAFile = v
+	}
+	if v, ok := over("MCP_KIBANA_EXPORT_DIR"); ok {
+		cfg.Kibana.ExportDir = v
+	}
+	if v, ok := over("MCP_KIBANA_IMPORT_DIR"); ok {
+		cfg.Kibana.ImportDir = v
+	}
 }
 
 func (p *EnvParser) parseHelmConfig(cfg *AppConfig, over func(string) (string, bool)) {
@@ -614,6 +664,31 @@ func (p *EnvParser) parseRateLimitConfig(cfg *AppConfig, over func(string) (stri
 	}
 }
 
+func (p *EnvParser) parseCompressionConfig(cfg *AppConfig, over func(string) (string, bool)) {
+	if v, ok := over("MCP_COMPRESSION_ENABLED"); ok {
+		cfg.Compression.Enabled = isTrue(v)
+	}
+	if v, ok := over("MCP_COMPRESSION_MIN_SIZE"); ok {
+		cfg.Compression.MinSizeBytes = atoiDefault(v, cfg.Compression.MinSizeBytes)
+	}
+	if v, ok := over("MCP_COMPRESSION_TOOL_RESULTS"); ok {
+		cfg.Compression.ToolResults = isTrue(v)
+	}
+}
+
+func (p *EnvParser) parseResponseLimitsConfig(cfg *AppConfig, over func(string) (string, bool)) {
+	if v, ok := over("MCP_RESPONSE_MAX_BYTES"); ok {
+		cfg.ResponseLimits.MaxBytes = atoiDefault(v, cfg.ResponseLimits.MaxBytes)
+	}
+	if v, ok := over("MCP_RESPONSE_MAX_BYTES_SERVICES"); ok {
+		services := make(map[string]int)
+		for service, limit := range parseKeyValueList(v) {
+			services[strings.ToLower(service)] = atoiDefault(limit, 0)
+		}
+		cfg.ResponseLimits.Services = services
+	}
+}
+
 func (p *EnvParser) parseAuditConfig(cfg *AppConfig, over func(string) (string, bool)) {
 	if v, ok := over("MCP_AUDIT_ENABLED"); ok {
 		cfg.Audit.Enabled = isTrue(v)
@@ -800,6 +875,20 @@ func isTrue(s string) bool {
 	return s == "1" || s == "true" || s == "yes" || s == "on"
 }
 
+// parseKeyValueList parses "name=value,other=value" into a map, skipping malformed entries
+func parseKeyValueList(s string) map[string]string {
+	headers := map[string]string{}
+	for _, part := range splitAndTrimCSV(s) {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
 func splitAndTrimCSV(s string) []string {
 	if strings.TrimSpace(s) == "" {
 		return []string{}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/constants"
 	"gopkg.in/yaml.v3"
@@ -67,6 +68,9 @@ func (l *ConfigLoader) setDefaults(cfg *AppConfig) {
 	if cfg.Server.IdleTimeoutSec == 0 {
 		cfg.Server.IdleTimeoutSec = 60
 	}
+	if cfg.Server.ToolTimeoutSec == 0 {
+		cfg.Server.ToolTimeoutSec = int(constants.DefaultToolTimeout / time.Second)
+	}
 
 	// Logging defaults
 	if cfg.Logging.Level == "" {
@@ -82,14 +86,35 @@ func (l *ConfigLoader) setDefaults(cfg *AppConfig) {
 	}
 
 	// Kubernetes defaults
+	if cfg.Kubernetes.ConnectionMode == "" {
+		cfg.Kubernetes.ConnectionMode = "auto"
+	}
 	if cfg.Kubernetes.TimeoutSec == 0 {
 		cfg.Kubernetes.TimeoutSec = 30
 	}
 	if cfg.Kubernetes.QPS == 0 {
-		cfg.Kubernetes.QPS = 50
+		cfg.Kubernetes.QPS = 100
 	}
 	if cfg.Kubernetes.Burst == 0 {
-		cfg.Kubernetes.Burst = 100
+		cfg.Kubernetes.Burst = 200
+	}
+	if cfg.Kubernetes.MaxIdleConns == 0 {
+		cfg.Kubernetes.MaxIdleConns = 200
+	}
+	if cfg.Kubernetes.MaxIdleConnsPerHost == 0 {
+		cfg.Kubernetes.MaxIdleConnsPerHost = 100
+	}
+	if cfg.Kubernetes.DiscoveryCacheTTLSec == 0 {
+		cfg.Kubernetes.DiscoveryCacheTTLSec = 600
+	}
+	if cfg.Kubernetes.MaxToolTimeoutSec == 0 {
+		cfg.Kubernetes.MaxToolTimeoutSec = int(constants.DefaultMaxToolTimeout / time.Second)
+	}
+	if cfg.Kubernetes.RetryMaxAttempts == 0 {
+		cfg.Kubernetes.RetryMaxAttempts = 3
+	}
+	if cfg.Kubernetes.RetryBackoffMs == 0 {
+		cfg.Kubernetes.RetryBackoffMs = 200
 	}
 
 	// Prometheus defaults
@@ -40,6 +40,11 @@ type ServerConfig struct {
 	allowedOrigins []string
 	corsMaxAge     int
 	rateLimiter    *middleware.RateLimiter
+
+	compressToolResults   bool
+	maxResponseBytes      int
+	serviceResponseLimits map[string]int
+	toolTimeout           time.Duration
 }
 
 func (s *ServerConfig) InitHooks() *server.Hooks {
@@ -90,12 +95,30 @@ func (s *ServerConfig) createConfiguredMCPServer(name string, hooks *server.Hook
 			return s.isServiceEnabled(serviceName)
 		})),
 		server.WithPromptFilter(s.promptFilter()),
+		server.WithToolFilter(hook.CompressParamToolFilter()),
 	)
 	mcpServer.Use(hook.NormalizeToolErrorMiddleware())
+	mcpServer.Use(hook.ToolTimeoutMiddleware(func() time.Duration {
+		return s.toolTimeout
+	}))
+	mcpServer.Use(hook.CompressToolResultMiddleware(func() bool {
+		return s.compressToolResults
+	}))
+	mcpServer.Use(hook.TruncateToolResultMiddleware(s.responseLimitFor))
 
 	return mcpServer
 }
 
+// responseLimitFor returns the tool result size cap for a tool, looked up by the
+// service prefix of its name (e.g. "kibana" for kibana_get_dashboards).
+func (s *ServerConfig) responseLimitFor(toolName string) int {
+	service, _, _ := strings.Cut(toolName, "_")
+	if limit, ok := s.serviceResponseLimits[service]; ok {
+		return limit
+	}
+	return s.maxResponseBytes
+}
+
 func (s *ServerConfig) currentDisabledTools() map[string]bool {
 	disabled := make(map[string]bool)
 	for name, isDisabled := range s.disabledTools {
@@ -125,6 +148,15 @@ func (s *ServerConfig) registerTools(target *server.MCPServer, tools []mcp.Tool,
 	}
 }
 
+// registerDiagnostics adds the server_diagnostics tool, which every endpoint exposes so it can
+// check the backends with the credentials sent to that endpoint, and the server_describe_tools catalog
+func (s *ServerConfig) registerDiagnostics(target *server.MCPServer) {
+	s.registerTools(target, []mcp.Tool{manager.DiagnosticsTool(), manager.DescribeToolsTool()}, map[string]server.ToolHandlerFunc{
+		manager.DiagnosticsToolName:   s.serviceManager.HandleServerDiagnostics,
+		manager.DescribeToolsToolName: s.serviceManager.HandleDescribeTools,
+	})
+}
+
 func (s *ServerConfig) registerResources(target *server.MCPServer, resources []mcp.Resource, handlers map[string]server.ResourceHandlerFunc) {
 	for _, resource := range resources {
 		if handler, exists := handlers[resource.URI]; exists {
@@ -217,6 +249,10 @@ func (s *ServerConfig) InitializeServices(appConfig *config.AppConfig) error {
 		if s.corsMaxAge == 0 {
 			s.corsMaxAge = 86400 // Default 24 hours
 		}
+		s.compressToolResults = appConfig.Compression.ToolResults
+		s.maxResponseBytes = appConfig.ResponseLimits.MaxBytes
+		s.serviceResponseLimits = appConfig.ResponseLimits.Services
+		s.toolTimeout = time.Duration(appConfig.Server.ToolTimeoutSec) * time.Second
 	} else {
 		// Default to empty list (deny all origins) for security
 		s.allowedOrigins = []string{}
@@ -899,6 +935,7 @@ func (s *ServerConfig) createServiceMCPServer(serviceName string) *server.MCPSer
 				s.addServicePrompts(serviceServer, "utilities")
 			}
 		}
+		s.registerDiagnostics(serviceServer)
 	}
 
 	return serviceServer
@@ -998,6 +1035,8 @@ func (s *ServerConfig) createAggregateMCPServer() *server.MCPServer {
 			s.registerTools(aggregateServer, opentelemetryService.GetTools(), opentelemetryService.GetHandlers())
 			s.registerResources(aggregateServer, opentelemetryService.GetResources(), opentelemetryService.GetResourceHandlers())
 		}
+
+		s.registerDiagnostics(aggregateServer)
 	}
 
 	return aggregateServer
@@ -2,11 +2,15 @@ package serverConfig
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
+
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/middleware/hook"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/manager"
 )
 
@@ -131,6 +135,36 @@ func TestCreateServiceMCPServerSkipsDisabledTools(t *testing.T) {
 	}
 }
 
+func TestServiceMCPServerListsCompressParam(t *testing.T) {
+	sc := &ServerConfig{}
+	cfg := &config.AppConfig{}
+	cfg.EnableDisable.EnabledServices = []string{"utilities"}
+	if err := sc.InitializeServices(cfg); err != nil {
+		t.Fatalf("InitializeServices returned error: %v", err)
+	}
+
+	srv := sc.createServiceMCPServer("utilities")
+	response := srv.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	payload, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("failed to marshal tools/list response: %v", err)
+	}
+	var listed struct {
+		Result mcp.ListToolsResult `json:"result"`
+	}
+	if err := json.Unmarshal(payload, &listed); err != nil {
+		t.Fatalf("failed to decode tools/list response: %v", err)
+	}
+	if len(listed.Result.Tools) == 0 {
+		t.Fatalf("expected tools to be listed, got %s", payload)
+	}
+	for _, tool := range listed.Result.Tools {
+		if _, ok := tool.InputSchema.Properties[hook.CompressParam]; !ok {
+			t.Fatalf("expected %s to declare the compress argument, got %+v", tool.Name, tool.InputSchema.Properties)
+		}
+	}
+}
+
 // TestHealthCheckResponseFormat tests health check response format
 func TestHealthCheckResponseFormat(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
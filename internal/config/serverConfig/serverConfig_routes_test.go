@@ -68,7 +68,7 @@ func TestInitSSEServers(t *testing.T) {
 	sseServers := sc.InitSSEServers(mcpServer, "127.0.0.1:8080", appConfig)
 
 	assert.NotNil(t, sseServers)
-	assert.Len(t, sseServers, 16) // kubernetes, grafana, prometheus, loki, kibana, helm, argocd, elasticsearch, alertmanager, jaeger, nacos, langfuse, sentry, opentelemetry, aggregate, utilities
+	assert.Len(t, sseServers, 17) // kubernetes, grafana, prometheus, loki, kibana, helm, argocd, elasticsearch, alertmanager, jaeger, nacos, langfuse, sentry, dify, opentelemetry, aggregate, utilities
 	assert.Contains(t, sseServers, "kubernetes")
 	assert.Contains(t, sseServers, "grafana")
 	assert.Contains(t, sseServers, "prometheus")
@@ -98,6 +98,8 @@ func TestInitSSEServersWithCustomPaths(t *testing.T) {
 			ReadTimeoutSec  int    `yaml:"readTimeoutSec"`
 			WriteTimeoutSec int    `yaml:"writeTimeoutSec"`
 			IdleTimeoutSec  int    `yaml:"idleTimeoutSec"`
+			ToolTimeoutSec  int    `yaml:"toolTimeoutSec"`
+			UserAgent       string `yaml:"userAgent"`
 			SSEPaths        struct {
 				Kubernetes    string `yaml:"kubernetes"`
 				Grafana       string `yaml:"grafana"`
@@ -172,7 +174,7 @@ func TestInitSSEServersWithCustomPaths(t *testing.T) {
 	sseServers := sc.InitSSEServers(mcpServer, "127.0.0.1:8080", appConfig)
 
 	assert.NotNil(t, sseServers)
-	assert.Len(t, sseServers, 16)
+	assert.Len(t, sseServers, 17)
 }
 
 // Test InitStreamableHTTPServers
@@ -184,7 +186,7 @@ func TestInitStreamableHTTPServers(t *testing.T) {
 	httpServers := sc.InitStreamableHTTPServers(mcpServer, "127.0.0.1:8080", appConfig)
 
 	assert.NotNil(t, httpServers)
-	assert.Len(t, httpServers, 16) // Same services as SSE
+	assert.Len(t, httpServers, 17) // Same services as SSE
 	assert.Contains(t, httpServers, "kubernetes")
 	assert.Contains(t, httpServers, "grafana")
 	assert.Contains(t, httpServers, "prometheus")
@@ -214,6 +216,8 @@ func TestInitStreamableHTTPServersWithCustomPaths(t *testing.T) {
 			ReadTimeoutSec  int    `yaml:"readTimeoutSec"`
 			WriteTimeoutSec int    `yaml:"writeTimeoutSec"`
 			IdleTimeoutSec  int    `yaml:"idleTimeoutSec"`
+			ToolTimeoutSec  int    `yaml:"toolTimeoutSec"`
+			UserAgent       string `yaml:"userAgent"`
 			SSEPaths        struct {
 				Kubernetes    string `yaml:"kubernetes"`
 				Grafana       string `yaml:"grafana"`
@@ -288,7 +292,7 @@ func TestInitStreamableHTTPServersWithCustomPaths(t *testing.T) {
 	httpServers := sc.InitStreamableHTTPServers(mcpServer, "127.0.0.1:8080", appConfig)
 
 	assert.NotNil(t, httpServers)
-	assert.Len(t, httpServers, 16)
+	assert.Len(t, httpServers, 17)
 }
 
 // Test SetupMultipleRoutes with SSE mode - only test mux creation, not actual HTTP handling
@@ -131,6 +131,12 @@ func (v *ConfigValidator) validateServerConfig(cfg *AppConfig) error {
 	if cfg.Server.IdleTimeoutSec > 3600 {
 		return fmt.Errorf("idle timeout too large: %d (max: 3600 seconds)", cfg.Server.IdleTimeoutSec)
 	}
+	if cfg.Server.ToolTimeoutSec < 0 {
+		return fmt.Errorf("tool timeout must be non-negative")
+	}
+	if cfg.Server.ToolTimeoutSec > 3600 {
+		return fmt.Errorf("tool timeout too large: %d (max: 3600 seconds)", cfg.Server.ToolTimeoutSec)
+	}
 
 	// Validate address format if specified
 	if cfg.Server.Addr != "" {
@@ -164,6 +170,16 @@ func (v *ConfigValidator) validateLoggingConfig(cfg *AppConfig) error {
 }
 
 func (v *ConfigValidator) validateKubernetesConfig(cfg *AppConfig) error {
+	validConnectionModes := map[string]bool{
+		"":           true,
+		"auto":       true,
+		"in-cluster": true,
+		"kubeconfig": true,
+	}
+	if !validConnectionModes[cfg.Kubernetes.ConnectionMode] {
+		return fmt.Errorf("invalid kubernetes connection mode: %s, must be one of: auto, in-cluster, kubeconfig", cfg.Kubernetes.ConnectionMode)
+	}
+
 	if cfg.Kubernetes.TimeoutSec < 0 {
 		return fmt.Errorf("kubernetes timeout must be non-negative")
 	}
@@ -176,6 +192,26 @@ func (v *ConfigValidator) validateKubernetesConfig(cfg *AppConfig) error {
 		return fmt.Errorf("kubernetes burst must be non-negative")
 	}
 
+	if cfg.Kubernetes.MaxIdleConns < 0 || cfg.Kubernetes.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("kubernetes idle connection limits must be non-negative")
+	}
+
+	if cfg.Kubernetes.MaxIdleConnsPerHost > cfg.Kubernetes.MaxIdleConns && cfg.Kubernetes.MaxIdleConns > 0 {
+		return fmt.Errorf("kubernetes maxIdleConnsPerHost (%d) must not exceed maxIdleConns (%d)", cfg.Kubernetes.MaxIdleConnsPerHost, cfg.Kubernetes.MaxIdleConns)
+	}
+
+	if cfg.Kubernetes.DiscoveryCacheTTLSec < 0 {
+		return fmt.Errorf("kubernetes discovery cache TTL must be non-negative")
+	}
+
+	if cfg.Kubernetes.MaxToolTimeoutSec < 0 {
+		return fmt.Errorf("kubernetes max tool timeout must be non-negative")
+	}
+
+	if cfg.Kubernetes.RetryMaxAttempts < 0 || cfg.Kubernetes.RetryBackoffMs < 0 {
+		return fmt.Errorf("kubernetes retry attempts and backoff must be non-negative")
+	}
+
 	return nil
 }
 
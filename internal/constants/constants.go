@@ -50,6 +50,15 @@ const (
 	// WarningLimit is the limit at which a warning is logged for large requests
 	WarningLimit = 40
 
+	// MaxDetailBatchSize is the maximum number of names fetched by one resource detail batch
+	MaxDetailBatchSize = 20
+
+	// MaxDetailAutoBatchNames is the maximum number of names fetched by one auto-batched detail request
+	MaxDetailAutoBatchNames = 100
+
+	// DetailFetchConcurrency bounds the concurrent requests of a resource detail batch
+	DetailFetchConcurrency = 5
+
 	// MaxLogLines is the maximum number of log lines that can be requested
 	MaxLogLines = 200
 
@@ -61,6 +70,25 @@ const (
 
 	// MaxLogCharacters is the maximum number of characters in log output
 	MaxLogCharacters = 50000 // 50KB
+
+	// MaxWorkloadLogPods is the maximum number of pods whose logs are fetched for one workload
+	MaxWorkloadLogPods = 20
+
+	// DefaultWorkloadLogTailLines is the default per-container tail when fetching logs for a workload
+	DefaultWorkloadLogTailLines = 20
+
+	// DefaultEventFollowTimeout is how long events are followed when no timeout is given
+	DefaultEventFollowTimeout = 60 * time.Second
+
+	// MaxEventFollowTimeout is the longest a single call may follow events
+	MaxEventFollowTimeout = 5 * time.Minute
+
+	// DefaultMaxToolTimeout caps the timeoutSeconds a caller may give a Kubernetes tool
+	DefaultMaxToolTimeout = 5 * time.Minute
+
+	// DefaultToolTimeout is the server-wide deadline for a single tool call. It sits above the
+	// longest bounded waits (event follow, sleep, per-call timeoutSeconds) so those still complete.
+	DefaultToolTimeout = 10 * time.Minute
 )
 
 // HTTP constants
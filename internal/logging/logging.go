@@ -94,6 +94,7 @@ func InitLoggerWithConfig(config *LogConfig) {
 			TimestampFormat: config.TimestampFormat,
 			FullTimestamp:   true,
 			ForceColors:     config.EnableColors,
+			DisableColors:   !config.EnableColors,
 			PadLevelText:    true,
 		})
 	}
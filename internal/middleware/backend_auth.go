@@ -41,17 +41,7 @@ func RegisterBackendAuthHandler(serviceName string, handler BackendAuthHandler)
 func BackendAuthMiddleware(serviceName string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			backendHandlersMu.RLock()
-			handler, ok := backendHandlers[serviceName]
-			backendHandlersMu.RUnlock()
-
-			if !ok {
-				// No backend auth handler registered for this service
-				next.ServeHTTP(w, r)
-				return
-			}
-
-			newReq, err := handler(r)
+			newReq, err := ApplyBackendAuth(serviceName, r)
 			if err != nil {
 				log.Printf("[backend-auth] %s handler failed: %v", serviceName, err)
 				next.ServeHTTP(w, r)
@@ -62,3 +52,19 @@ func BackendAuthMiddleware(serviceName string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// ApplyBackendAuth runs the BackendAuthHandler registered for serviceName on r
+// and returns the request carrying the injected client. The request is returned
+// unchanged when no handler is registered. It lets callers outside the HTTP
+// server, such as the CLI, build clients the same way tool calls do.
+func ApplyBackendAuth(serviceName string, r *http.Request) (*http.Request, error) {
+	backendHandlersMu.RLock()
+	handler, ok := backendHandlers[serviceName]
+	backendHandlersMu.RUnlock()
+
+	if !ok {
+		// No backend auth handler registered for this service
+		return r, nil
+	}
+	return handler(r)
+}
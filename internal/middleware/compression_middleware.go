@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"sync"
+
+	optimize "github.com/mahmut-Abi/cloud-native-mcp-server/internal/util/performance"
+)
+
+// DefaultCompressionMinSize is the response size below which bodies are sent uncompressed
+const DefaultCompressionMinSize = 1024
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// CompressionMiddleware gzip-encodes responses for clients that send Accept-Encoding: gzip.
+// Bodies smaller than minSize, event streams, already-encoded responses and content types
+// that do not benefit from compression are passed through unchanged. A handler that flushes
+// before minSize bytes are written is treated as streaming and is not compressed.
+func CompressionMiddleware(minSize int) func(http.Handler) http.Handler {
+	if minSize <= 0 {
+		minSize = DefaultCompressionMinSize
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead || !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Accept-Encoding")
+			cw := &compressResponseWriter{
+				ResponseWriter: w,
+				minSize:        minSize,
+				statusCode:     http.StatusOK,
+			}
+			defer cw.finish()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether the request allows a gzip-encoded response
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+	}
+	return false
+}
+
+type compressionMode int
+
+const (
+	compressionUndecided compressionMode = iota
+	compressionPassthrough
+	compressionGzip
+)
+
+// compressResponseWriter buffers the start of a response until it knows whether to compress it
+type compressResponseWriter struct {
+	http.ResponseWriter
+	minSize     int
+	statusCode  int
+	wroteHeader bool
+	mode        compressionMode
+	buf         []byte
+	gz          *gzip.Writer
+}
+
+func (w *compressResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.statusCode = statusCode
+
+	header := w.Header()
+	if statusCode < http.StatusOK || statusCode == http.StatusNoContent || statusCode == http.StatusNotModified ||
+		header.Get("Content-Encoding") != "" ||
+		strings.HasPrefix(header.Get("Content-Type"), "text/event-stream") {
+		w.startPassthrough()
+	}
+}
+
+func (w *compressResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	switch w.mode {
+	case compressionPassthrough:
+		return w.ResponseWriter.Write(data)
+	case compressionGzip:
+		return w.gz.Write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+	if len(w.buf) >= w.minSize {
+		contentType := w.Header().Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType(w.buf)
+			w.Header().Set("Content-Type", contentType)
+		}
+		if optimize.ShouldCompress(contentType) {
+			w.startGzip()
+		} else {
+			w.startPassthrough()
+		}
+	}
+	return len(data), nil
+}
+
+// Flush implements http.Flusher. Flushing an undecided response switches it to passthrough
+// so streamed responses reach the client immediately.
+func (w *compressResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	switch w.mode {
+	case compressionUndecided:
+		w.startPassthrough()
+	case compressionGzip:
+		_ = w.gz.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressResponseWriter) startPassthrough() {
+	w.mode = compressionPassthrough
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	if len(w.buf) > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf)
+	}
+	w.buf = nil
+}
+
+func (w *compressResponseWriter) startGzip() {
+	w.mode = compressionGzip
+	header := w.Header()
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", "gzip")
+	w.ResponseWriter.WriteHeader(w.statusCode)
+
+	w.gz = gzipWriterPool.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+	_, _ = w.gz.Write(w.buf)
+	w.buf = nil
+}
+
+// finish writes out a response that stayed below the threshold and closes the gzip stream
+func (w *compressResponseWriter) finish() {
+	switch w.mode {
+	case compressionUndecided:
+		if w.wroteHeader || len(w.buf) > 0 {
+			w.startPassthrough()
+		}
+	case compressionGzip:
+		_ = w.gz.Close()
+		gzipWriterPool.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompressionMiddleware(t *testing.T) {
+	largeBody := `{"items":"` + strings.Repeat("pod-", 1000) + `"}`
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		flush          bool
+		wantGzip       bool
+	}{
+		{name: "large JSON is compressed", acceptEncoding: "gzip, deflate", contentType: "application/json", body: largeBody, wantGzip: true},
+		{name: "small body is not compressed", acceptEncoding: "gzip", contentType: "application/json", body: `{"ok":true}`},
+		{name: "client without gzip support", acceptEncoding: "", contentType: "application/json", body: largeBody},
+		{name: "gzip explicitly refused", acceptEncoding: "gzip;q=0", contentType: "application/json", body: largeBody},
+		{name: "event stream is not compressed", acceptEncoding: "gzip", contentType: "text/event-stream", body: largeBody},
+		{name: "already compressed content type", acceptEncoding: "gzip", contentType: "image/png", body: largeBody},
+		{name: "flushed response streams uncompressed", acceptEncoding: "gzip", contentType: "application/json", body: largeBody, flush: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CompressionMiddleware(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(http.StatusOK)
+				if tt.flush {
+					_, _ = w.Write([]byte(tt.body[:10]))
+					w.(http.Flusher).Flush()
+					_, _ = w.Write([]byte(tt.body[10:]))
+					return
+				}
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/kubernetes/http", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			gotGzip := rec.Header().Get("Content-Encoding") == "gzip"
+			if gotGzip != tt.wantGzip {
+				t.Fatalf("Content-Encoding gzip = %v, want %v", gotGzip, tt.wantGzip)
+			}
+
+			var body []byte
+			if gotGzip {
+				reader, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("invalid gzip body: %v", err)
+				}
+				body, err = io.ReadAll(reader)
+				if err != nil {
+					t.Fatalf("failed to read gzip body: %v", err)
+				}
+			} else {
+				body = rec.Body.Bytes()
+			}
+			if string(body) != tt.body {
+				t.Fatalf("body mismatch: got %d bytes, want %d bytes", len(body), len(tt.body))
+			}
+		})
+	}
+}
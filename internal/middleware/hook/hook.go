@@ -13,6 +13,44 @@ import (
 
 var toolCallStartTimes = make(map[any]time.Time)
 
+// credentialArguments are tool arguments carrying credentials, masked before requests are logged
+var credentialArguments = map[string]bool{
+	"authtoken": true,
+}
+
+const redactedArgument = "********"
+
+// redactedRequest returns a copy of message with credential arguments masked, or message itself when
+// it carries none
+func redactedRequest(message *mcp.CallToolRequest) *mcp.CallToolRequest {
+	if message == nil {
+		return nil
+	}
+	args, ok := message.Params.Arguments.(map[string]any)
+	if !ok {
+		return message
+	}
+	var masked map[string]any
+	for key, value := range args {
+		if !credentialArguments[strings.ToLower(key)] || value == nil || value == "" {
+			continue
+		}
+		if masked == nil {
+			masked = make(map[string]any, len(args))
+			for k, v := range args {
+				masked[k] = v
+			}
+		}
+		masked[key] = redactedArgument
+	}
+	if masked == nil {
+		return message
+	}
+	redacted := *message
+	redacted.Params.Arguments = masked
+	return &redacted
+}
+
 // SessionRegisterHookFunc creates a hook function for session registration events
 func SessionRegisterHookFunc() server.OnRegisterSessionHookFunc {
 	return func(ctx context.Context, session server.ClientSession) {
@@ -35,6 +73,7 @@ func LogRequestHookFunc() server.OnBeforeCallToolFunc {
 	return func(ctx context.Context, id any, message *mcp.CallToolRequest) {
 		// Record start time for metrics
 		toolCallStartTimes[id] = time.Now()
+		message = redactedRequest(message)
 
 		fields := logrus.Fields{
 			"id":     id,
@@ -132,7 +171,7 @@ func LogResponseHookFunc() server.OnAfterCallToolFunc {
 			"id":              id,
 			"hasError":        hasError,
 			"full_result":     result,
-			"request_message": message,
+			"request_message": redactedRequest(message),
 			"context_values":  ctx,
 		}
 
@@ -1,12 +1,22 @@
 package hook
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/observability/metrics"
 	mcp "github.com/mark3labs/mcp-go/mcp"
 	dto "github.com/prometheus/client_model/go"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 )
 
 func init() {
@@ -493,3 +503,276 @@ func hasLabels(labels []*dto.LabelPair, name, value string) bool {
 	}
 	return false
 }
+
+func TestCompressToolResultMiddleware(t *testing.T) {
+	largeText := strings.Repeat("x", LargeToolResultBytes+1)
+	handlerFor := func(text string) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		}
+	}
+	requestWith := func(args map[string]any) mcp.CallToolRequest {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "test-tool"
+		req.Params.Arguments = args
+		return req
+	}
+
+	tests := []struct {
+		name         string
+		auto         bool
+		text         string
+		args         map[string]any
+		wantCompress bool
+	}{
+		{name: "explicit compress", text: "small result", args: map[string]any{"compress": true}, wantCompress: true},
+		{name: "explicit compress as string", text: "small result", args: map[string]any{"compress": "true"}, wantCompress: true},
+		{name: "off by default", text: largeText},
+		{name: "automatic above threshold", auto: true, text: largeText, wantCompress: true},
+		{name: "automatic skips small results", auto: true, text: "small result"},
+		{name: "explicit opt out wins over automatic", auto: true, text: largeText, args: map[string]any{"compress": false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CompressToolResultMiddleware(func() bool { return tt.auto })(handlerFor(tt.text))
+			result, err := handler(context.Background(), requestWith(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+
+			var payload CompressedToolResult
+			compressed := json.Unmarshal([]byte(text), &payload) == nil && payload.Compressed
+			if compressed != tt.wantCompress {
+				t.Fatalf("compressed = %v, want %v", compressed, tt.wantCompress)
+			}
+			if !compressed {
+				if text != tt.text {
+					t.Fatal("expected result to be returned unchanged")
+				}
+				return
+			}
+
+			raw, err := base64.StdEncoding.DecodeString(payload.Data)
+			if err != nil {
+				t.Fatalf("invalid base64 data: %v", err)
+			}
+			reader, err := gzip.NewReader(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("invalid gzip data: %v", err)
+			}
+			decoded, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("failed to decompress: %v", err)
+			}
+			if string(decoded) != tt.text || payload.OriginalBytes != len(tt.text) || payload.Encoding != "gzip+base64" {
+				t.Fatalf("unexpected payload: encoding=%s originalBytes=%d", payload.Encoding, payload.OriginalBytes)
+			}
+		})
+	}
+}
+
+func TestWithCompressParam(t *testing.T) {
+	tool := mcp.NewTool("test-tool", mcp.WithString("name", mcp.Required()))
+
+	decorated := WithCompressParam(tool)
+	property, ok := decorated.InputSchema.Properties[CompressParam].(map[string]any)
+	if !ok || property["type"] != "boolean" || property["description"] == "" {
+		t.Fatalf("expected a boolean compress property, got %+v", decorated.InputSchema.Properties)
+	}
+	if _, ok := decorated.InputSchema.Properties["name"]; !ok || len(decorated.InputSchema.Required) != 1 {
+		t.Fatalf("expected the tool's own arguments to be kept, got %+v", decorated.InputSchema)
+	}
+	if _, ok := tool.InputSchema.Properties[CompressParam]; ok {
+		t.Fatal("expected the original tool to be left untouched")
+	}
+
+	listed := CompressParamToolFilter()(context.Background(), []mcp.Tool{tool, decorated})
+	for _, listedTool := range listed {
+		if _, ok := listedTool.InputSchema.Properties[CompressParam]; !ok || len(listedTool.InputSchema.Properties) != 2 {
+			t.Fatalf("expected every listed tool to declare compress once, got %+v", listedTool.InputSchema.Properties)
+		}
+	}
+}
+
+func TestTruncateToolResultMiddleware(t *testing.T) {
+	limits := map[string]int{"kubernetes": 9, "kibana": 0}
+	limitFor := func(toolName string) int {
+		service, _, _ := strings.Cut(toolName, "_")
+		return limits[service]
+	}
+	handler := func(text string) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		}
+	}
+	call := func(toolName, text string) *mcp.CallToolResult {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = toolName
+		result, err := TruncateToolResultMiddleware(limitFor)(handler(text))(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	if result := call("kubernetes_list_resources", "short"); len(result.Content) != 1 {
+		t.Fatalf("expected results under the limit to be unchanged, got %d contents", len(result.Content))
+	}
+	if result := call("kibana_get_dashboards", strings.Repeat("x", 100)); len(result.Content) != 1 {
+		t.Fatal("expected a zero limit to disable truncation")
+	}
+
+	result := call("kubernetes_list_resources", "ääääääääää")
+	if len(result.Content) != 2 {
+		t.Fatalf("expected truncated text and a note, got %d contents", len(result.Content))
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if text != "ääää" {
+		t.Fatalf("expected truncation at a character boundary, got %q", text)
+	}
+
+	var note ToolResultTruncation
+	if err := json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &note); err != nil {
+		t.Fatalf("invalid truncation note: %v", err)
+	}
+	if !note.Truncated || note.OriginalSizeBytes != 20 || note.ReturnedSizeBytes != 8 || note.LimitBytes != 9 || note.Guidance == "" {
+		t.Fatalf("unexpected truncation note: %+v", note)
+	}
+}
+
+func TestToolTimeoutMiddleware(t *testing.T) {
+	call := func(ctx context.Context, timeout time.Duration, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)) (*mcp.CallToolResult, error) {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "kubernetes_list_resources"
+		return ToolTimeoutMiddleware(func() time.Duration { return timeout })(handler)(ctx, req)
+	}
+	resultText := func(result *mcp.CallToolResult) string {
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return text.Text
+	}
+
+	// A disabled timeout passes the caller's context through untouched
+	result, err := call(context.Background(), 0, func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("expected no deadline when the timeout is disabled")
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+	if err != nil || result.IsError || resultText(result) != "ok" {
+		t.Fatalf("unexpected pass-through result: %+v, %v", result, err)
+	}
+
+	// A handler that ignores its context must not hold the call past the deadline
+	previousGrace := abandonedHandlerGrace
+	abandonedHandlerGrace = 10 * time.Millisecond
+	defer func() { abandonedHandlerGrace = previousGrace }()
+	previousHooks := logrus.StandardLogger().ReplaceHooks(make(logrus.LevelHooks))
+	defer logrus.StandardLogger().ReplaceHooks(previousHooks)
+	logs := logtest.NewLocal(logrus.StandardLogger())
+	release := make(chan struct{})
+	start := time.Now()
+	result, err = call(context.Background(), 20*time.Millisecond, func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-release
+		return mcp.NewToolResultText("late"), nil
+	})
+	if err != nil || !result.IsError || resultText(result) != "tool kubernetes_list_resources timed out after 20ms" {
+		t.Fatalf("unexpected timeout result: %+v, %v", result, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the call to return at the deadline, took %s", elapsed)
+	}
+	// and is logged once it finally returns past the grace period
+	time.Sleep(3 * abandonedHandlerGrace)
+	close(release)
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(5 * time.Millisecond) {
+		if entry := logs.LastEntry(); entry != nil && strings.HasPrefix(entry.Message, "Abandoned tool handler returned") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the abandoned handler to be logged when it returned")
+		}
+	}
+
+	// The handler's context is cancelled once the deadline passes
+	logs.Reset()
+	ctxDone := make(chan error, 1)
+	result, err = call(context.Background(), 20*time.Millisecond, func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-ctx.Done()
+		ctxDone <- ctx.Err()
+		return nil, ctx.Err()
+	})
+	if err != nil || !result.IsError {
+		t.Fatalf("unexpected timeout result: %+v, %v", result, err)
+	}
+	select {
+	case ctxErr := <-ctxDone:
+		if !errors.Is(ctxErr, context.DeadlineExceeded) {
+			t.Fatalf("expected the handler's context to exceed its deadline, got %v", ctxErr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the handler's context to be cancelled at the deadline")
+	}
+	// and a handler returning promptly is not reported as abandoned
+	time.Sleep(3 * abandonedHandlerGrace)
+	for _, entry := range logs.AllEntries() {
+		if strings.HasPrefix(entry.Message, "Abandoned tool handler returned") {
+			t.Fatal("expected no abandoned handler warning for a handler honoring ctx")
+		}
+	}
+
+	// Cancelling mid-call reaches the handler and returns promptly
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	go func() {
+		<-started
+		cancel()
+	}()
+	handlerDone := make(chan struct{})
+	result, err = call(ctx, time.Minute, func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		defer close(handlerDone)
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil || !result.IsError || resultText(result) != "tool kubernetes_list_resources was cancelled" {
+		t.Fatalf("unexpected cancellation result: %+v, %v", result, err)
+	}
+	select {
+	case <-handlerDone:
+	case <-time.After(time.Second):
+		t.Fatal("expected the handler to observe the cancellation")
+	}
+
+	// Panics on the handler goroutine become errors instead of crashing the server
+	_, err = call(context.Background(), time.Minute, func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		panic("boom")
+	})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected the panic to be returned as an error, got %v", err)
+	}
+}
+
+func TestRedactedRequest(t *testing.T) {
+	req := &mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "kubernetes_list_resources",
+			Arguments: map[string]any{"kind": "Pod", "authToken": "caller-token"},
+		},
+	}
+
+	redacted := redactedRequest(req)
+	args := redacted.Params.Arguments.(map[string]any)
+	if args["authToken"] != redactedArgument || args["kind"] != "Pod" {
+		t.Fatalf("expected only the token to be masked, got %v", args)
+	}
+	if req.Params.Arguments.(map[string]any)["authToken"] != "caller-token" {
+		t.Fatal("expected the request handed to the tool to keep its token")
+	}
+
+	plain := &mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"kind": "Pod"}}}
+	if redactedRequest(plain) != plain {
+		t.Fatal("expected a request without credentials to be logged as is")
+	}
+}
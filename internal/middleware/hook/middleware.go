@@ -2,10 +2,17 @@ package hook
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/prompts"
+	optimize "github.com/mahmut-Abi/cloud-native-mcp-server/internal/util/performance"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/sirupsen/logrus"
@@ -30,6 +37,290 @@ func NormalizeToolErrorMiddleware() server.ToolHandlerMiddleware {
 	}
 }
 
+// abandonedHandlerGrace is how long a handler may take to return after its context ends before it is
+// logged as not honoring ctx
+var abandonedHandlerGrace = time.Second
+
+// ToolTimeoutMiddleware bounds every tool call by the deadline returned by timeout (0 disables it).
+// The handler receives the derived context so client calls abort their in-flight requests, and the
+// middleware returns as soon as the context ends even if a handler does not observe it.
+//
+// Handlers must honor ctx: one that ignores it keeps running, along with its backend calls, after
+// the call has returned its timeout error. Handlers still running abandonedHandlerGrace after the
+// context ended are logged when they finally return.
+func ToolTimeoutMiddleware(timeout func() time.Duration) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var limit time.Duration
+			if timeout != nil {
+				limit = timeout()
+			}
+			if limit > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, limit)
+				defer cancel()
+			}
+
+			type outcome struct {
+				result *mcp.CallToolResult
+				err    error
+			}
+			// Buffered so a handler that finishes after the deadline does not block forever
+			done := make(chan outcome, 1)
+			go func() {
+				// The server's recovery middleware cannot see panics raised on this goroutine
+				defer func() {
+					if r := recover(); r != nil {
+						done <- outcome{err: fmt.Errorf("panic recovered in %s tool handler: %v", request.Params.Name, r)}
+					}
+				}()
+				result, err := next(ctx, request)
+				done <- outcome{result: result, err: err}
+			}()
+
+			logger := logrus.WithFields(logrus.Fields{"tool": request.Params.Name, "timeout": limit})
+			select {
+			case out := <-done:
+				// Results that completed despite the context ending are still worth returning
+				succeeded := out.err == nil && out.result != nil && !out.result.IsError
+				if ctx.Err() == nil || succeeded {
+					return out.result, out.err
+				}
+			case <-ctx.Done():
+				ended, grace := time.Now(), abandonedHandlerGrace
+				go func() {
+					<-done
+					if overrun := time.Since(ended); overrun > grace {
+						logger.WithField("overrun", overrun).Warn("Abandoned tool handler returned after its call ended; it does not honor ctx")
+					}
+				}()
+			}
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				logger.Warn("Tool call exceeded its deadline")
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s timed out after %s", request.Params.Name, limit)), nil
+			}
+			logger.Debug("Tool call cancelled")
+			return mcp.NewToolResultError(fmt.Sprintf("tool %s was cancelled", request.Params.Name)), nil
+		}
+	}
+}
+
+// LargeToolResultBytes is the size at which handlers warn about large responses and at which
+// tool results are compressed automatically when enabled.
+const LargeToolResultBytes = 100 * 1024
+
+// CompressedToolResult replaces the text of a compressed tool result.
+// Data is the original text, gzip-compressed and base64-encoded.
+type CompressedToolResult struct {
+	Compressed      bool   `json:"compressed"`
+	Encoding        string `json:"encoding"`
+	OriginalBytes   int    `json:"originalBytes"`
+	CompressedBytes int    `json:"compressedBytes"`
+	Data            string `json:"data"`
+}
+
+var toolResultCompressor = func() *optimize.GzipCompressor {
+	gc := optimize.NewGzipCompressor()
+	gc.SetMinimumSize(0)
+	return gc
+}()
+
+// CompressToolResultMiddleware gzip-compresses tool results when the caller passes
+// "compress": true, or automatically for results above LargeToolResultBytes when
+// autoCompress reports true. Passing "compress": false always returns plain text.
+// Only successful results with a single text content are compressed.
+func CompressToolResultMiddleware(autoCompress func() bool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError || len(result.Content) != 1 {
+				return result, err
+			}
+			text, ok := mcp.AsTextContent(result.Content[0])
+			if !ok {
+				return result, nil
+			}
+
+			compress, explicit := compressArgument(request)
+			if !explicit {
+				compress = autoCompress != nil && autoCompress() && len(text.Text) > LargeToolResultBytes
+			}
+			if !compress {
+				return result, nil
+			}
+
+			compressed, cerr := toolResultCompressor.Compress([]byte(text.Text))
+			if cerr != nil {
+				logrus.WithError(cerr).WithField("tool", request.Params.Name).Warn("Failed to compress tool result")
+				return result, nil
+			}
+			payload, merr := json.Marshal(CompressedToolResult{
+				Compressed:      true,
+				Encoding:        "gzip+base64",
+				OriginalBytes:   len(text.Text),
+				CompressedBytes: len(compressed),
+				Data:            base64.StdEncoding.EncodeToString(compressed),
+			})
+			if merr != nil {
+				return result, nil
+			}
+
+			logrus.WithFields(logrus.Fields{
+				"tool":            request.Params.Name,
+				"originalBytes":   len(text.Text),
+				"compressedBytes": len(compressed),
+			}).Debug("Compressed tool result")
+
+			return &mcp.CallToolResult{
+				Result:  result.Result,
+				Content: []mcp.Content{mcp.NewTextContent(string(payload))},
+			}, nil
+		}
+	}
+}
+
+// CompressParam is the argument every tool accepts to control CompressToolResultMiddleware
+const CompressParam = "compress"
+
+// WithCompressParam adds the optional compress argument to tool. The tool's property map is copied,
+// so tools shared between servers are left untouched.
+func WithCompressParam(tool mcp.Tool) mcp.Tool {
+	if tool.RawInputSchema != nil {
+		return tool
+	}
+	if _, ok := tool.InputSchema.Properties[CompressParam]; ok {
+		return tool
+	}
+	properties := make(map[string]any, len(tool.InputSchema.Properties)+1)
+	maps.Copy(properties, tool.InputSchema.Properties)
+	tool.InputSchema.Properties = properties
+	mcp.WithBoolean(CompressParam,
+		mcp.Description("Return the result as base64-encoded gzip ({\"compressed\": true, \"encoding\": \"gzip+base64\", \"data\": \"...\"}) to save tokens on large outputs. false always returns plain text; if omitted the server's automatic compression setting applies."))(&tool)
+	return tool
+}
+
+// CompressParamToolFilter declares the compress argument on every listed tool, so that clients can
+// discover the option CompressToolResultMiddleware reads.
+func CompressParamToolFilter() server.ToolFilterFunc {
+	return func(_ context.Context, tools []mcp.Tool) []mcp.Tool {
+		for i := range tools {
+			tools[i] = WithCompressParam(tools[i])
+		}
+		return tools
+	}
+}
+
+// compressArgument reads the optional "compress" argument and reports whether it was set
+func compressArgument(request mcp.CallToolRequest) (bool, bool) {
+	value, ok := request.GetArguments()[CompressParam]
+	if !ok {
+		return false, false
+	}
+	switch typed := value.(type) {
+	case bool:
+		return typed, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(typed)) {
+		case "true", "1", "yes", "on":
+			return true, true
+		case "false", "0", "no", "off":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// ToolResultTruncation is appended to a tool result that was cut to its response limit.
+type ToolResultTruncation struct {
+	Truncated         bool   `json:"truncated"`
+	OriginalSizeBytes int    `json:"originalSizeBytes"`
+	ReturnedSizeBytes int    `json:"returnedSizeBytes"`
+	LimitBytes        int    `json:"limitBytes"`
+	Guidance          string `json:"guidance"`
+}
+
+// TruncateToolResultMiddleware caps the text of tool results at the limit returned by limitFor
+// for the called tool (0 disables the cap). Oversized results are cut to the limit and followed
+// by a ToolResultTruncation note telling the caller to paginate instead of receiving the whole blob.
+func TruncateToolResultMiddleware(limitFor func(toolName string) int) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil || result == nil || limitFor == nil {
+				return result, err
+			}
+			limit := limitFor(request.Params.Name)
+			if limit <= 0 {
+				return result, nil
+			}
+
+			originalSize := 0
+			for _, content := range result.Content {
+				if text, ok := mcp.AsTextContent(content); ok {
+					originalSize += len(text.Text)
+				}
+			}
+			if originalSize <= limit {
+				return result, nil
+			}
+
+			remaining := limit
+			contents := make([]mcp.Content, 0, len(result.Content)+1)
+			for _, content := range result.Content {
+				text, ok := mcp.AsTextContent(content)
+				if !ok {
+					contents = append(contents, content)
+					continue
+				}
+				if remaining <= 0 {
+					continue
+				}
+				cut := truncateUTF8(text.Text, remaining)
+				remaining -= len(cut)
+				contents = append(contents, mcp.NewTextContent(cut))
+			}
+
+			note, merr := json.Marshal(ToolResultTruncation{
+				Truncated:         true,
+				OriginalSizeBytes: originalSize,
+				ReturnedSizeBytes: limit - remaining,
+				LimitBytes:        limit,
+				Guidance: fmt.Sprintf("The result of %s exceeded the %d byte response limit and was truncated. "+
+					"Request smaller pages (limit, page, continueToken) or narrower filters to retrieve the rest.",
+					request.Params.Name, limit),
+			})
+			if merr == nil {
+				contents = append(contents, mcp.NewTextContent(string(note)))
+			}
+
+			logrus.WithFields(logrus.Fields{
+				"tool":         request.Params.Name,
+				"originalSize": originalSize,
+				"limit":        limit,
+			}).Warn("Tool result truncated to response limit")
+
+			return &mcp.CallToolResult{
+				Result:  result.Result,
+				Content: contents,
+				IsError: result.IsError,
+			}, nil
+		}
+	}
+}
+
+// truncateUTF8 cuts s to at most maxBytes without splitting a multi-byte character
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
 // PromptLoggingMiddleware logs prompt requests and results.
 func PromptLoggingMiddleware() server.PromptHandlerMiddleware {
 	return func(next server.PromptHandlerFunc) server.PromptHandlerFunc {
@@ -3,11 +3,14 @@
 package grafana
 
 import (
+	"context"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	server "github.com/mark3labs/mcp-go/server"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/cache"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/framework"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/grafana/client"
@@ -262,3 +265,12 @@ func (s *Service) IsEnabled() bool {
 func (s *Service) GetClient() *client.Client {
 	return nil // Backend client is created per-request from HTTP headers
 }
+
+// CheckConnectivity checks the Grafana API is reachable with the client injected into the request context.
+func (s *Service) CheckConnectivity(ctx context.Context) error {
+	c, err := client.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", services.ErrNoBackendClient, err)
+	}
+	return c.TestConnection(ctx)
+}
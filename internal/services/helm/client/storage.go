@@ -0,0 +1,152 @@
+// Package client provides Helm client operations for the MCP server.
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/release"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// releaseSecretType is the Secret type Helm 3 uses to store release records
+	releaseSecretType = "helm.sh/release.v1"
+	// releaseSecretSelector matches the labels Helm 3 sets on its release Secrets
+	releaseSecretSelector = "owner=helm"
+	// releaseDataKey is the Secret data key holding the encoded release record
+	releaseDataKey = "release"
+)
+
+// gzipMagic is the header Helm checks for before decompressing a release payload
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// decodeReleaseData decodes a release record the way the Helm storage driver writes it:
+// base64 on top of an optionally gzip-compressed JSON document.
+func decodeReleaseData(data []byte) (*release.Release, error) {
+	raw, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to base64-decode release payload: %w", err)
+	}
+
+	if bytes.HasPrefix(raw, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip release payload: %w", err)
+		}
+		defer func() { _ = reader.Close() }()
+		if raw, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("failed to decompress release payload: %w", err)
+		}
+	}
+
+	var rel release.Release
+	if err := json.Unmarshal(raw, &rel); err != nil {
+		return nil, fmt.Errorf("failed to parse release payload: %w", err)
+	}
+	return &rel, nil
+}
+
+// releaseRecordSummary converts a decoded release record into a ReleaseSummary.
+// Unlike ExtractReleaseSummary it tolerates records with missing info or chart metadata.
+func releaseRecordSummary(rel *release.Release) *ReleaseSummary {
+	summary := &ReleaseSummary{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Version:   rel.Version,
+	}
+	if rel.Info != nil {
+		summary.Status = rel.Info.Status.String()
+		summary.Updated = rel.Info.LastDeployed.Time
+		summary.Description = rel.Info.Description
+		if len(summary.Description) > 200 {
+			summary.Description = summary.Description[:200]
+		}
+	}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		summary.Chart = fmt.Sprintf("%s:%s", rel.Chart.Metadata.Name, rel.Chart.Metadata.Version)
+		summary.AppVersion = rel.Chart.Metadata.AppVersion
+	}
+	return summary
+}
+
+// latestReleaseRecords keeps the highest revision of each release and applies the status filter
+// to it, mirroring what `helm list` shows. Results are sorted by namespace and name.
+func latestReleaseRecords(records []*ReleaseSummary, status string) []*ReleaseSummary {
+	latest := make(map[string]*ReleaseSummary, len(records))
+	for _, record := range records {
+		key := record.Namespace + "/" + record.Name
+		if current, ok := latest[key]; !ok || record.Version > current.Version {
+			latest[key] = record
+		}
+	}
+
+	result := make([]*ReleaseSummary, 0, len(latest))
+	for _, record := range latest {
+		if status != "" && !strings.EqualFold(record.Status, status) {
+			continue
+		}
+		result = append(result, record)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// ListReleasesFromSecrets reads Helm 3 release records directly from the sh.helm.release.v1.*
+// Secrets in a namespace (all namespaces when empty) and returns the latest revision of each
+// release. It does not go through the Helm action layer, so it only needs read access to Secrets.
+func (c *Client) ListReleasesFromSecrets(ctx context.Context, namespace, status string) ([]*ReleaseSummary, error) {
+	logrus.WithFields(logrus.Fields{"namespace": namespace, "status": status}).Debug("ListReleasesFromSecrets called")
+
+	restConfig := c.restConfig
+	if restConfig == nil {
+		var err error
+		if restConfig, err = c.settings.RESTClientGetter().ToRESTConfig(); err != nil {
+			return nil, fmt.Errorf("failed to build Kubernetes configuration: %w", err)
+		}
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	secrets, err := clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: releaseSecretSelector,
+		FieldSelector: "type=" + releaseSecretType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Helm release secrets: %w", err)
+	}
+
+	records := make([]*ReleaseSummary, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		rel, err := decodeReleaseData(secret.Data[releaseDataKey])
+		if err != nil {
+			logrus.WithError(err).Warnf("Skipping undecodable Helm release secret %s/%s", secret.Namespace, secret.Name)
+			continue
+		}
+		if rel.Namespace == "" {
+			rel.Namespace = secret.Namespace
+		}
+		records = append(records, releaseRecordSummary(rel))
+	}
+
+	releases := latestReleaseRecords(records, status)
+	logrus.WithField("count", len(releases)).Debug("ListReleasesFromSecrets succeeded")
+	return releases, nil
+}
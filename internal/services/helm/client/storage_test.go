@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+func encodeTestRelease(t *testing.T, rel *release.Release, compress bool) []byte {
+	t.Helper()
+	payload, err := json.Marshal(rel)
+	if err != nil {
+		t.Fatalf("failed to marshal release: %v", err)
+	}
+	if compress {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(payload); err != nil {
+			t.Fatalf("failed to gzip release: %v", err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("failed to close gzip writer: %v", err)
+		}
+		payload = buf.Bytes()
+	}
+	return []byte(base64.StdEncoding.EncodeToString(payload))
+}
+
+func TestDecodeReleaseData(t *testing.T) {
+	deployed := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rel := &release.Release{
+		Name:      "web",
+		Namespace: "apps",
+		Version:   3,
+		Info:      &release.Info{Status: release.StatusDeployed, LastDeployed: helmtime.Time{Time: deployed}},
+		Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: "nginx", Version: "1.2.3", AppVersion: "1.27"}},
+	}
+
+	for _, compress := range []bool{true, false} {
+		decoded, err := decodeReleaseData(encodeTestRelease(t, rel, compress))
+		if err != nil {
+			t.Fatalf("compress=%v: unexpected error: %v", compress, err)
+		}
+		summary := releaseRecordSummary(decoded)
+		if summary.Name != "web" || summary.Version != 3 || summary.Status != "deployed" || summary.Chart != "nginx:1.2.3" {
+			t.Fatalf("compress=%v: unexpected summary: %+v", compress, summary)
+		}
+		if !summary.Updated.Equal(deployed) {
+			t.Fatalf("compress=%v: expected updated %v, got %v", compress, deployed, summary.Updated)
+		}
+	}
+
+	if _, err := decodeReleaseData([]byte("not base64!")); err == nil {
+		t.Fatal("expected error for invalid payload")
+	}
+}
+
+func TestLatestReleaseRecordsKeepsHighestRevision(t *testing.T) {
+	records := []*ReleaseSummary{
+		{Name: "web", Namespace: "apps", Version: 1, Status: "superseded"},
+		{Name: "web", Namespace: "apps", Version: 2, Status: "deployed"},
+		{Name: "db", Namespace: "apps", Version: 1, Status: "failed"},
+		{Name: "web", Namespace: "staging", Version: 1, Status: "deployed"},
+	}
+
+	all := latestReleaseRecords(records, "")
+	if len(all) != 3 || all[0].Name != "db" || all[1].Version != 2 || all[2].Namespace != "staging" {
+		t.Fatalf("unexpected latest releases: %+v", all)
+	}
+
+	failed := latestReleaseRecords(records, "FAILED")
+	if len(failed) != 1 || failed[0].Name != "db" {
+		t.Fatalf("unexpected filtered releases: %+v", failed)
+	}
+
+	if superseded := latestReleaseRecords(records, "superseded"); len(superseded) != 0 {
+		t.Fatalf("expected older revisions to be ignored by the status filter, got %+v", superseded)
+	}
+}
@@ -43,6 +43,36 @@ func HandleListReleases() func(ctx context.Context, request mcp.CallToolRequest)
 	}
 }
 
+// HandleListReleasesFromSecrets returns a handler function for listing Helm releases from their storage Secrets.
+func HandleListReleasesFromSecrets() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := client.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		logrus.WithField("tool", "helm_list_releases_from_secrets").Debug("Handler invoked")
+
+		namespace := getOptionalStringParam(request, "namespace")
+		status := getOptionalStringParam(request, "status")
+
+		releases, err := c.ListReleasesFromSecrets(ctx, namespace, status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list releases from secrets: %w", err)
+		}
+
+		logrus.WithField("count", len(releases)).Debug("helm_list_releases_from_secrets succeeded")
+
+		jsonData, err := marshalIndentJSON(map[string]interface{}{
+			"releases": releases,
+			"count":    len(releases),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize results: %w", err)
+		}
+		return mcp.NewToolResultText(string(jsonData)), nil
+	}
+}
+
 // HandleGetRelease returns a handler function for getting a Helm release.
 func HandleGetRelease() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
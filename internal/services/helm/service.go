@@ -61,6 +61,7 @@ func (s *Service) GetTools() []mcp.Tool {
 
 			// Standard tools (for specific use cases)
 			tools.ListReleasesTool(),
+			tools.ListReleasesFromSecretsTool(),
 			tools.GetReleaseTool(),
 			tools.ListRepositoriesTool(),
 			tools.GetReleaseValuesTool(),
@@ -108,19 +109,20 @@ func (s *Service) GetHandlers() map[string]server.ToolHandlerFunc {
 		"helm_cluster_overview":        handlers.HandleGetClusterOverview(),    // ⚠️ Recommended for overview
 
 		// Standard tools (for specific use cases)
-		"helm_list_releases":        handlers.HandleListReleases(),
-		"helm_get_release":          handlers.HandleGetRelease(),
-		"helm_list_repos":           handlers.HandleListRepositories(),
-		"helm_get_release_values":   handlers.HandleGetReleaseValues(),
-		"helm_get_release_manifest": handlers.HandleGetReleaseManifest(),
-		"helm_get_release_history":  handlers.HandleGetReleaseHistory(),
-		"helm_search_charts":        handlers.HandleSearchCharts(),
-		"helm_get_chart_info":       handlers.HandleGetChartInfo(),
-		"helm_template_chart":       handlers.HandleTemplateChart(),
-		"helm_compare_revisions":    handlers.HandleCompareRevisions(),
-		"helm_add_repository":       handlers.HandleAddRepository(),
-		"helm_remove_repository":    handlers.HandleRemoveRepository(),
-		"helm_update_repositories":  handlers.HandleUpdateRepositories(),
+		"helm_list_releases":              handlers.HandleListReleases(),
+		"helm_list_releases_from_secrets": handlers.HandleListReleasesFromSecrets(),
+		"helm_get_release":                handlers.HandleGetRelease(),
+		"helm_list_repos":                 handlers.HandleListRepositories(),
+		"helm_get_release_values":         handlers.HandleGetReleaseValues(),
+		"helm_get_release_manifest":       handlers.HandleGetReleaseManifest(),
+		"helm_get_release_history":        handlers.HandleGetReleaseHistory(),
+		"helm_search_charts":              handlers.HandleSearchCharts(),
+		"helm_get_chart_info":             handlers.HandleGetChartInfo(),
+		"helm_template_chart":             handlers.HandleTemplateChart(),
+		"helm_compare_revisions":          handlers.HandleCompareRevisions(),
+		"helm_add_repository":             handlers.HandleAddRepository(),
+		"helm_remove_repository":          handlers.HandleRemoveRepository(),
+		"helm_update_repositories":        handlers.HandleUpdateRepositories(),
 
 		// Additional specialized tools
 		"helm_get_release_history_paginated": handlers.HandleGetReleaseHistoryPaginated(),
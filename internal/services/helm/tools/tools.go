@@ -19,6 +19,18 @@ func ListReleasesTool() mcp.Tool {
 	)
 }
 
+// ListReleasesFromSecretsTool returns a tool definition for listing Helm releases from their storage Secrets.
+func ListReleasesFromSecretsTool() mcp.Tool {
+	logrus.Debug("Creating ListReleasesFromSecretsTool")
+	return mcp.NewTool("helm_list_releases_from_secrets",
+		mcp.WithDescription("List Helm 3 releases by decoding the sh.helm.release.v1.* Secrets in the cluster directly, without the Helm binary or action layer. Returns the latest revision of each release with name, namespace, revision, status, chart and updated time. Only requires read access to Secrets."),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to read release Secrets from. If not specified, all namespaces are searched.")),
+		mcp.WithString("status",
+			mcp.Description("Only return releases whose latest revision has this status (e.g. deployed, failed, pending-upgrade, superseded).")),
+	)
+}
+
 // GetReleaseTool returns a tool definition for getting a Helm release.
 func GetReleaseTool() mcp.Tool {
 	logrus.Debug("Creating GetReleaseTool")
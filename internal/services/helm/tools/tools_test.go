@@ -14,6 +14,18 @@ func TestListReleasesTool(t *testing.T) {
 	}
 }
 
+func TestListReleasesFromSecretsTool(t *testing.T) {
+	tool := ListReleasesFromSecretsTool()
+	if tool.Name != "helm_list_releases_from_secrets" {
+		t.Errorf("Expected tool name 'helm_list_releases_from_secrets', got '%s'", tool.Name)
+	}
+	for _, param := range []string{"namespace", "status"} {
+		if _, ok := tool.InputSchema.Properties[param]; !ok {
+			t.Errorf("Expected parameter '%s' to be defined", param)
+		}
+	}
+}
+
 func TestGetReleaseTool(t *testing.T) {
 	tool := GetReleaseTool()
 	if tool.Name != "helm_get_release" {
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxAlertRuleScanPages bounds the fallback scan of GetAlertRulesAggregate (100 rules per page).
+const maxAlertRuleScanPages = 50
+
+// AlertRulesAggregate counts alert rules by last execution status and by enabled state.
+type AlertRulesAggregate struct {
+	Total           int            `json:"total"`
+	Enabled         int            `json:"enabled"`
+	Disabled        int            `json:"disabled"`
+	ExecutionStatus map[string]int `json:"executionStatus"`
+	// Source is "aggregate" when Kibana computed the counts and "scan" when the rules were paged through
+	Source string `json:"source"`
+	// Truncated is set when the scan stopped before reaching every rule
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// alertRulesAggregateResponse is the body of the alerting _aggregate API.
+type alertRulesAggregateResponse struct {
+	EnabledStatus struct {
+		Enabled  int `json:"enabled"`
+		Disabled int `json:"disabled"`
+	} `json:"rule_enabled_status"`
+	ExecutionStatus map[string]int `json:"rule_execution_status"`
+}
+
+// GetAlertRulesAggregate counts alert rules by execution status (ok, active, error, pending, ...)
+// and by enabled state. It uses the alerting _aggregate API and, on Kibana versions that do not
+// expose it, pages through every rule instead.
+func (c *Client) GetAlertRulesAggregate(ctx context.Context) (*AlertRulesAggregate, error) {
+	logrus.Debug("Aggregating alert rules")
+
+	resp, err := c.makeRequest(ctx, "GET", "alerting/rules/_aggregate", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		logrus.Debug("Alerting _aggregate API not available, scanning alert rules")
+		return c.scanAlertRules(ctx)
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw alertRulesAggregateResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alert rules aggregate: %w", err)
+	}
+
+	aggregate := &AlertRulesAggregate{
+		Enabled:         raw.EnabledStatus.Enabled,
+		Disabled:        raw.EnabledStatus.Disabled,
+		ExecutionStatus: raw.ExecutionStatus,
+		Source:          "aggregate",
+	}
+	if aggregate.ExecutionStatus == nil {
+		aggregate.ExecutionStatus = map[string]int{}
+	}
+	aggregate.Total = aggregate.Enabled + aggregate.Disabled
+
+	logrus.WithField("total", aggregate.Total).Debug("Aggregated alert rules")
+	return aggregate, nil
+}
+
+// scanAlertRules computes the aggregate by paging through the rules.
+func (c *Client) scanAlertRules(ctx context.Context) (*AlertRulesAggregate, error) {
+	aggregate := &AlertRulesAggregate{ExecutionStatus: map[string]int{}, Source: "scan"}
+
+	for page := 1; ; page++ {
+		list, err := c.GetAlertRules(ctx, page, 100, "", nil, nil, "", "")
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range list.Data {
+			aggregate.Total++
+			if rule.Enabled {
+				aggregate.Enabled++
+			} else {
+				aggregate.Disabled++
+			}
+			status, _ := rule.ExecutionStatus["status"].(string)
+			if status == "" {
+				status = "unknown"
+			}
+			aggregate.ExecutionStatus[status]++
+		}
+
+		if len(list.Data) == 0 || aggregate.Total >= list.Total {
+			break
+		}
+		if page == maxAlertRuleScanPages {
+			aggregate.Truncated = true
+			break
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{"total": aggregate.Total, "truncated": aggregate.Truncated}).Debug("Scanned alert rules")
+	return aggregate, nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetAlertRulesAggregate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/alerting/rules/_aggregate" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"rule_enabled_status":{"enabled":5,"disabled":2},"rule_execution_status":{"ok":3,"active":1,"error":2,"pending":1}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	aggregate, err := client.GetAlertRulesAggregate(context.Background())
+	if err != nil {
+		t.Fatalf("GetAlertRulesAggregate() error = %v", err)
+	}
+	if aggregate.Source != "aggregate" || aggregate.Total != 7 || aggregate.Enabled != 5 || aggregate.Disabled != 2 {
+		t.Fatalf("unexpected aggregate: %+v", aggregate)
+	}
+	if aggregate.ExecutionStatus["error"] != 2 || aggregate.ExecutionStatus["ok"] != 3 {
+		t.Fatalf("unexpected execution status counts: %+v", aggregate.ExecutionStatus)
+	}
+}
+
+func TestGetAlertRulesAggregateScansWithoutAggregateAPI(t *testing.T) {
+	pages := map[string]string{
+		"1": `[{"id":"r1","enabled":true,"executionStatus":{"status":"ok"}},{"id":"r2","enabled":true,"executionStatus":{"status":"error"}}]`,
+		"2": `[{"id":"r3","enabled":false}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/alerting/rules/_aggregate":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"statusCode":404,"error":"Not Found"}`))
+		case "/api/alerting/rules/_find":
+			page := r.URL.Query().Get("page")
+			data, ok := pages[page]
+			if !ok {
+				t.Fatalf("unexpected page %s", page)
+			}
+			_, _ = fmt.Fprintf(w, `{"page":%s,"per_page":100,"total":3,"data":%s}`, page, data)
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	aggregate, err := client.GetAlertRulesAggregate(context.Background())
+	if err != nil {
+		t.Fatalf("GetAlertRulesAggregate() error = %v", err)
+	}
+	if aggregate.Source != "scan" || aggregate.Total != 3 || aggregate.Enabled != 2 || aggregate.Disabled != 1 || aggregate.Truncated {
+		t.Fatalf("unexpected aggregate: %+v", aggregate)
+	}
+	if aggregate.ExecutionStatus["ok"] != 1 || aggregate.ExecutionStatus["error"] != 1 || aggregate.ExecutionStatus["unknown"] != 1 {
+		t.Fatalf("unexpected execution status counts: %+v", aggregate.ExecutionStatus)
+	}
+}
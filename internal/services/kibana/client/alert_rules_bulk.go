@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// alertRuleBulkConcurrency bounds the rule requests a bulk operation has in flight.
+	alertRuleBulkConcurrency = 5
+	// MaxAlertRuleBulkSize is the largest number of rules a single bulk operation may touch.
+	MaxAlertRuleBulkSize = 500
+)
+
+// AlertRuleBulkResult is the outcome of a bulk operation for one rule.
+type AlertRuleBulkResult struct {
+	RuleID  string `json:"ruleId"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ResolveAlertRuleIDs returns the IDs of the rules carrying any of the given tags.
+func (c *Client) ResolveAlertRuleIDs(ctx context.Context, tags []string) ([]string, error) {
+	var ids []string
+	for page := 1; ; page++ {
+		list, err := c.GetAlertRules(ctx, page, 100, "", nil, tags, "", "")
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range list.Data {
+			ids = append(ids, rule.ID)
+		}
+		if len(list.Data) == 0 || len(ids) >= list.Total {
+			break
+		}
+		if len(ids) > MaxAlertRuleBulkSize {
+			return nil, fmt.Errorf("tags %v match more than %d rules", tags, MaxAlertRuleBulkSize)
+		}
+	}
+	return ids, nil
+}
+
+// BulkEnableAlertRules enables each of the given rules.
+func (c *Client) BulkEnableAlertRules(ctx context.Context, ruleIDs []string) []AlertRuleBulkResult {
+	return c.bulkAlertRuleAction(ctx, "enable", ruleIDs, c.EnableAlertRule)
+}
+
+// BulkDisableAlertRules disables each of the given rules.
+func (c *Client) BulkDisableAlertRules(ctx context.Context, ruleIDs []string) []AlertRuleBulkResult {
+	return c.bulkAlertRuleAction(ctx, "disable", ruleIDs, c.DisableAlertRule)
+}
+
+// BulkMuteAlertRules mutes each of the given rules for duration.
+func (c *Client) BulkMuteAlertRules(ctx context.Context, ruleIDs []string, duration string) []AlertRuleBulkResult {
+	return c.bulkAlertRuleAction(ctx, "mute", ruleIDs, func(ctx context.Context, ruleID string) error {
+		return c.MuteAlertRule(ctx, ruleID, duration)
+	})
+}
+
+// bulkAlertRuleAction applies action to every rule with bounded concurrency. Kibana's bulk rule
+// endpoints are internal-only, so the public per-rule API is used. Results keep the order of
+// ruleIDs, duplicates are applied once, and one failing rule does not stop the others.
+func (c *Client) bulkAlertRuleAction(ctx context.Context, name string, ruleIDs []string, action func(context.Context, string) error) []AlertRuleBulkResult {
+	seen := make(map[string]bool, len(ruleIDs))
+	unique := make([]string, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{"action": name, "rules": len(unique)}).Debug("Applying bulk alert rule action")
+
+	results := make([]AlertRuleBulkResult, len(unique))
+	sem := make(chan struct{}, alertRuleBulkConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range unique {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = AlertRuleBulkResult{RuleID: id, Success: true}
+			if err := ctx.Err(); err != nil {
+				results[i] = AlertRuleBulkResult{RuleID: id, Error: err.Error()}
+				return
+			}
+			if err := action(ctx, id); err != nil {
+				results[i] = AlertRuleBulkResult{RuleID: id, Error: err.Error()}
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBulkMuteAlertRules(t *testing.T) {
+	var mu sync.Mutex
+	muted := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/alerting/rules/"), "/_mute")
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/_mute") {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"statusCode":404,"error":"Not Found"}`))
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		muted[id] = body["duration"]
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	results := client.BulkMuteAlertRules(context.Background(), []string{"r1", "missing", "r2", "r1"}, "1h")
+	if len(results) != 3 {
+		t.Fatalf("expected duplicates to be applied once, got %+v", results)
+	}
+	if results[0].RuleID != "r1" || !results[0].Success || results[2].RuleID != "r2" || !results[2].Success {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if results[1].RuleID != "missing" || results[1].Success || results[1].Error == "" {
+		t.Fatalf("expected the missing rule to fail, got %+v", results[1])
+	}
+	if len(muted) != 2 || muted["r1"] != "1h" || muted["r2"] != "1h" {
+		t.Fatalf("unexpected muted rules: %+v", muted)
+	}
+}
+
+func TestResolveAlertRuleIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/alerting/rules/_find" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("filter"); got != `alert.attributes.tags:("noisy")` {
+			t.Fatalf("unexpected filter %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"page":1,"per_page":100,"total":2,"data":[{"id":"r1"},{"id":"r2"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ids, err := client.ResolveAlertRuleIDs(context.Background(), []string{"noisy"})
+	if err != nil {
+		t.Fatalf("ResolveAlertRuleIDs() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "r1" || ids[1] != "r2" {
+		t.Fatalf("unexpected rule IDs: %v", ids)
+	}
+}
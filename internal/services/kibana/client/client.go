@@ -6,11 +6,17 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,23 +26,33 @@ import (
 	optimize "github.com/mahmut-Abi/cloud-native-mcp-server/internal/util/performance"
 )
 
+// DefaultUserAgent identifies the server to Kibana when no user agent is configured
+const DefaultUserAgent = "cloud-native-mcp-server"
+
 // ClientOptions holds configuration parameters for creating a Kibana client.
 type ClientOptions struct {
-	URL            string        // Kibana server URL
-	APIKey         string        // Kibana API key for authentication
-	Username       string        // Username for basic authentication
-	Password       string        // Password for basic authentication
-	Timeout        time.Duration // HTTP request timeout
-	SkipVerify     bool          // Skip TLS certificate verification
-	Space          string        // Kibana space (default: default)
-	MaxRetries     int           // Retries for idempotent requests
-	RetryBaseDelay time.Duration // Base delay for exponential backoff
-	RetryMaxDelay  time.Duration // Maximum delay between retries
+	URL            string            // Kibana server URL
+	APIKey         string            // Kibana API key for authentication
+	Username       string            // Username for basic authentication
+	Password       string            // Password for basic authentication
+	Timeout        time.Duration     // HTTP request timeout
+	SkipVerify     bool              // Skip TLS certificate verification
+	Space          string            // Kibana space (default: default)
+	MaxRetries     int               // Retries for idempotent requests
+	RetryBaseDelay time.Duration     // Base delay for exponential backoff
+	RetryMaxDelay  time.Duration     // Maximum delay between retries
+	FleetURL       string            // Optional separate base URL for the Fleet API (default: URL)
+	ClientCertFile string            // PEM client certificate for mutual TLS
+	ClientKeyFile  string            // PEM private key matching ClientCertFile
+	CACertFile     string            // PEM CA bundle used to verify the Kibana server certificate
+	Headers        map[string]string // Extra headers sent on every request; may override the defaults
+	UserAgent      string            // User-Agent sent on every request (default: DefaultUserAgent)
 }
 
 // Client provides operations for interacting with Kibana API.
 type Client struct {
 	baseURL        string            // Base URL for Kibana API
+	fleetBaseURL   string            // Base URL for the Fleet API when served separately
 	httpClient     *http.Client      // HTTP client for API requests
 	apiKey         string            // API key for authentication
 	username       string            // Username for basic auth
@@ -165,6 +181,19 @@ func NewClient(opts *ClientOptions) (*Client, error) {
 	}
 	baseURL.Path += "api/"
 
+	fleetBaseURL := ""
+	if opts.FleetURL != "" {
+		fleetURL, err := url.Parse(opts.FleetURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fleet URL: %w", err)
+		}
+		if !strings.HasSuffix(fleetURL.Path, "/") {
+			fleetURL.Path += "/"
+		}
+		fleetURL.Path += "api/"
+		fleetBaseURL = fleetURL.String()
+	}
+
 	// Create optimized HTTP client with timeout
 	timeout := opts.Timeout
 	if timeout == 0 {
@@ -173,6 +202,14 @@ func NewClient(opts *ClientOptions) (*Client, error) {
 
 	httpClient := optimize.NewOptimizedHTTPClientWithTimeout(timeout)
 
+	tlsConfig, err := buildTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		httpClient.Transport.(*http.Transport).TLSClientConfig = tlsConfig
+	}
+
 	// Set default space if not provided
 	space := opts.Space
 	if space == "" {
@@ -182,10 +219,25 @@ func NewClient(opts *ClientOptions) (*Client, error) {
 	// Prepare headers
 	headers := make(map[string]string)
 	headers["Content-Type"] = "application/json"
-	headers["kbn-xsrf"] = "true" // Required by Kibana API
+	headers["Kbn-Xsrf"] = "true" // Required by Kibana API
+	headers["User-Agent"] = DefaultUserAgent
+	if opts.UserAgent != "" {
+		headers["User-Agent"] = opts.UserAgent
+	}
+	for key, value := range opts.Headers {
+		key = http.CanonicalHeaderKey(strings.TrimSpace(key))
+		if key == "" || value == "" {
+			continue
+		}
+		if _, reserved := headers[key]; reserved {
+			logrus.WithField("header", key).Debug("Overriding default Kibana header")
+		}
+		headers[key] = value
+	}
 
 	client := &Client{
 		baseURL:        baseURL.String(),
+		fleetBaseURL:   fleetBaseURL,
 		httpClient:     httpClient,
 		apiKey:         opts.APIKey,
 		username:       opts.Username,
@@ -201,8 +253,53 @@ func NewClient(opts *ClientOptions) (*Client, error) {
 	return client, nil
 }
 
+// buildTLSConfig builds the TLS configuration for the Kibana transport.
+// It returns nil when no TLS option is set so the transport defaults apply.
+func buildTLSConfig(opts *ClientOptions) (*tls.Config, error) {
+	if !opts.SkipVerify && opts.ClientCertFile == "" && opts.ClientKeyFile == "" && opts.CACertFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if opts.CACertFile != "" {
+		if opts.SkipVerify {
+			logrus.Warn("Both Kibana skipVerify and a CA certificate are set; verifying the server against the CA")
+		}
+		caCert, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", opts.CACertFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	} else {
+		tlsConfig.InsecureSkipVerify = opts.SkipVerify
+	}
+
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		if opts.ClientCertFile == "" || opts.ClientKeyFile == "" {
+			return nil, fmt.Errorf("both client certificate and client key files are required for mutual TLS")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
 // makeRequest performs an HTTP request to the Kibana API.
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	return c.makeRequestWithBase(ctx, c.baseURL, method, endpoint, body)
+}
+
+// makeRequestWithBase performs an HTTP request against an API base URL, applying the space prefix.
+func (c *Client) makeRequestWithBase(ctx context.Context, baseURL, method, endpoint string, body interface{}) (*http.Response, error) {
 	var requestBody []byte
 	if body != nil {
 		jsonData, err := json.Marshal(body)
@@ -212,13 +309,7 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 		requestBody = jsonData
 	}
 
-	// Build URL with space prefix if not default
-	var reqURL string
-	if c.space != "default" {
-		reqURL = c.baseURL + "spaces/" + c.space + "/" + endpoint
-	} else {
-		reqURL = c.baseURL + endpoint
-	}
+	reqURL := c.requestURL(baseURL, endpoint)
 
 	return optimize.DoWithHTTPRetry(
 		ctx,
@@ -237,17 +328,7 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 				return nil, fmt.Errorf("failed to create request: %w", err)
 			}
 
-			// Set headers
-			for key, value := range c.headers {
-				req.Header.Set(key, value)
-			}
-
-			// Set authentication
-			if c.apiKey != "" {
-				req.Header.Set("Authorization", "ApiKey "+c.apiKey)
-			} else if c.username != "" && c.password != "" {
-				req.SetBasicAuth(c.username, c.password)
-			}
+			c.setRequestHeaders(req)
 
 			logrus.WithFields(logrus.Fields{
 				"method":   method,
@@ -276,6 +357,27 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 	)
 }
 
+// requestURL builds the URL of an API endpoint, with the space prefix if not default.
+func (c *Client) requestURL(baseURL, endpoint string) string {
+	if c.space != "default" {
+		return baseURL + "spaces/" + c.space + "/" + endpoint
+	}
+	return baseURL + endpoint
+}
+
+// setRequestHeaders sets the configured headers and authentication on a request.
+func (c *Client) setRequestHeaders(req *http.Request) {
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+c.apiKey)
+	} else if c.username != "" && c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+}
+
 // handleResponse processes the HTTP response and returns the body.
 func (c *Client) handleResponse(resp *http.Response) ([]byte, error) {
 	defer func() { _ = resp.Body.Close() }()
@@ -292,6 +394,38 @@ func (c *Client) handleResponse(resp *http.Response) ([]byte, error) {
 	return body, nil
 }
 
+// errUnsupportedSavedObjectType reports a saved object type _find rejects as unknown to the target
+// Kibana (e.g. map without the Maps plugin), as opposed to an authorization or server error
+var errUnsupportedSavedObjectType = errors.New("unsupported saved object type")
+
+// handleFindResponse handles a saved objects _find response like handleResponse, wrapping the 400
+// Kibana returns for an unknown type in errUnsupportedSavedObjectType.
+func (c *Client) handleFindResponse(resp *http.Response) ([]byte, error) {
+	status := resp.StatusCode
+	body, err := c.handleResponse(resp)
+	if err != nil && status == http.StatusBadRequest && strings.Contains(strings.ToLower(err.Error()), "unsupported saved object type") {
+		return nil, fmt.Errorf("%w: %v", errUnsupportedSavedObjectType, err)
+	}
+	return body, err
+}
+
+// skipUnsupportedType reports whether err only means objectType is unknown to this Kibana, so that
+// callers covering several types can skip it and keep going. Every other error must be returned.
+func skipUnsupportedType(err error, objectType string) bool {
+	if !errors.Is(err, errUnsupportedSavedObjectType) {
+		return false
+	}
+	logrus.WithError(err).WithField("type", objectType).Debug("Skipping saved object type")
+	return true
+}
+
+// elasticsearchRequest sends a request to Elasticsearch through the Kibana console proxy.
+// The proxy is always called with POST; method is the verb forwarded to Elasticsearch.
+func (c *Client) elasticsearchRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	endpoint := "console/proxy?" + url.Values{"path": {path}, "method": {method}}.Encode()
+	return c.makeRequest(ctx, "POST", endpoint, body)
+}
+
 // GetSpaces retrieves all Kibana spaces.
 func (c *Client) GetSpaces(ctx context.Context) ([]Space, error) {
 	logrus.Debug("Getting Kibana spaces")
@@ -531,7 +665,7 @@ func (c *Client) SearchSavedObjects(ctx context.Context, objectType, search stri
 		return nil, err
 	}
 
-	body, err := c.handleResponse(resp)
+	body, err := c.handleFindResponse(resp)
 	if err != nil {
 		return nil, err
 	}
@@ -567,6 +701,7 @@ func (c *Client) TestConnection(ctx context.Context) error {
 type KibanaStatus struct {
 	State   string                 `json:"state"`
 	Version map[string]interface{} `json:"version,omitempty"`
+	Status  map[string]interface{} `json:"status,omitempty"`
 	Metrics map[string]interface{} `json:"metrics,omitempty"`
 }
 
@@ -753,14 +888,41 @@ const (
 	defaultLimitNodes = constants.DefaultPageSizeNodes
 )
 
-// PaginationInfo represents pagination metadata for Kibana responses
+// PaginationInfo represents pagination metadata for Kibana responses.
+// HasMore, ContinueToken and ReturnedCount mirror the pagination object returned by the
+// Kubernetes list tools; ContinueToken is the next page number and is empty on the last page.
 type PaginationInfo struct {
-	CurrentPage     int   `json:"currentPage"`
-	PerPage         int   `json:"perPage"`
-	TotalCount      int64 `json:"totalCount"`
-	TotalPages      int   `json:"totalPages"`
-	HasNextPage     bool  `json:"hasNextPage"`
-	HasPreviousPage bool  `json:"hasPreviousPage"`
+	HasMore         bool   `json:"hasMore"`
+	ContinueToken   string `json:"continueToken"`
+	ReturnedCount   int    `json:"returnedCount"`
+	CurrentPage     int    `json:"currentPage"`
+	PerPage         int    `json:"perPage"`
+	TotalCount      int64  `json:"totalCount"`
+	TotalPages      int    `json:"totalPages"`
+	HasNextPage     bool   `json:"hasNextPage"`
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+}
+
+// NewPaginationInfo builds pagination metadata for a page of a page-numbered Kibana listing
+func NewPaginationInfo(page, perPage, total, returnedCount int) *PaginationInfo {
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = (total + perPage - 1) / perPage
+	}
+	info := &PaginationInfo{
+		ReturnedCount:   returnedCount,
+		CurrentPage:     page,
+		PerPage:         perPage,
+		TotalCount:      int64(total),
+		TotalPages:      totalPages,
+		HasNextPage:     page < totalPages,
+		HasPreviousPage: page > 1,
+	}
+	if info.HasNextPage {
+		info.HasMore = true
+		info.ContinueToken = strconv.Itoa(page + 1)
+	}
+	return info
 }
 
 // SpacesSummary returns optimized spaces information
@@ -864,18 +1026,7 @@ func (c *Client) DashboardsPaginated(ctx context.Context, page, perPage int, sea
 		summaries = append(summaries, summary)
 	}
 
-	// Calculate pagination info
-	totalPages := (result.Total + perPage - 1) / perPage
-	pagination := &PaginationInfo{
-		CurrentPage:     page,
-		PerPage:         perPage,
-		TotalCount:      int64(result.Total),
-		TotalPages:      totalPages,
-		HasNextPage:     page < totalPages,
-		HasPreviousPage: page > 1,
-	}
-
-	return summaries, pagination, nil
+	return summaries, NewPaginationInfo(page, perPage, result.Total, len(summaries)), nil
 }
 
 // VisualizationsPaginated returns visualizations with pagination support and optimization
@@ -952,18 +1103,7 @@ func (c *Client) VisualizationsPaginated(ctx context.Context, page, perPage int,
 		summaries = append(summaries, summary)
 	}
 
-	// Calculate pagination info
-	totalPages := (result.Total + perPage - 1) / perPage
-	pagination := &PaginationInfo{
-		CurrentPage:     page,
-		PerPage:         perPage,
-		TotalCount:      int64(result.Total),
-		TotalPages:      totalPages,
-		HasNextPage:     page < totalPages,
-		HasPreviousPage: page > 1,
-	}
-
-	return summaries, pagination, nil
+	return summaries, NewPaginationInfo(page, perPage, result.Total, len(summaries)), nil
 }
 
 // SearchSavedObjectsAdvanced provides advanced saved objects search with enhanced filters
@@ -1118,7 +1258,7 @@ func (c *Client) GetHealthSummary(ctx context.Context, level string, includeSave
 	}
 
 	// Include detailed status for higher levels
-	if level == "detailed" || level == "metrics" {
+	if level == "detailed" || level == "metrics" || level == "deep" {
 		if status.Metrics != nil {
 			summary["metrics"] = status.Metrics
 		}
@@ -1144,6 +1284,16 @@ func (c *Client) GetHealthSummary(ctx context.Context, level string, includeSave
 		}
 	}
 
+	// Probe task manager, alerting/actions and Elasticsearch for a one-call health verdict
+	if level == "deep" {
+		components := c.deepHealthComponents(ctx, status)
+		overall, issues := overallHealth(components)
+		summary["components"] = components
+		summary["overall"] = overall
+		summary["healthy"] = overall == HealthOK
+		summary["issues"] = issues
+	}
+
 	return summary, nil
 }
 
@@ -1179,13 +1329,7 @@ func (c *Client) QueryLogs(ctx context.Context, indexPattern string, query strin
 	searchBody := map[string]interface{}{
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{
-				"must": []map[string]interface{}{
-					{
-						"query_string": map[string]interface{}{
-							"query": query,
-						},
-					},
-				},
+				"must": []map[string]interface{}{logQueryClause(query)},
 			},
 		},
 		"size": size,
@@ -1828,8 +1972,9 @@ func (c *Client) CloneDashboard(ctx context.Context, dashboardID string, newTitl
 
 // ============ Write Operations: Visualizations ============
 
-// CreateVisualization creates a new visualization
-func (c *Client) CreateVisualization(ctx context.Context, title string, visState map[string]interface{}, description string, savedSearchRefName string) (*Visualization, error) {
+// CreateVisualization creates a new visualization. A non-empty dataViewID is attached as the
+// visualization's search source data view.
+func (c *Client) CreateVisualization(ctx context.Context, title string, visState map[string]interface{}, description string, savedSearchRefName string, dataViewID string) (*Visualization, error) {
 	logrus.WithField("title", title).Debug("Creating visualization")
 
 	attributes := map[string]interface{}{
@@ -1852,6 +1997,11 @@ func (c *Client) CreateVisualization(ctx context.Context, title string, visState
 		Type:       "visualization",
 		Attributes: attributes,
 	}
+	if dataViewID != "" {
+		searchSource, _ := json.Marshal(map[string]interface{}{"indexRefName": dataViewSearchSourceRefName})
+		attributes["kibanaSavedObjectMeta"] = map[string]interface{}{"searchSourceJSON": string(searchSource)}
+		obj.References = []Reference{{Name: dataViewSearchSourceRefName, Type: dataViewSavedObjectType, ID: dataViewID}}
+	}
 
 	resp, err := c.makeRequest(ctx, "POST", "saved_objects/visualization", obj)
 	if err != nil {
@@ -2148,78 +2298,172 @@ func (c *Client) BulkDeleteSavedObjects(ctx context.Context, objects []SavedObje
 	return nil
 }
 
-// ExportSavedObjects exports saved objects
-func (c *Client) ExportSavedObjects(ctx context.Context, objects []SavedObject, includeReferences bool) ([]byte, error) {
-	logrus.WithField("count", len(objects)).Debug("Exporting saved objects")
+// SavedObjectError describes why a single saved object in a bulk request failed.
+type SavedObjectError struct {
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+}
+
+// BulkGetSavedObject is one entry of a bulk get result; Error is set when the object could not be read.
+type BulkGetSavedObject struct {
+	SavedObject
+	Error *SavedObjectError `json:"error,omitempty"`
+}
 
-	objectsToExport := make([]map[string]interface{}, 0, len(objects))
+// BulkGetSavedObjects retrieves multiple saved objects in one request.
+// Objects that cannot be read (e.g. not found) are returned with Error set instead of failing the batch.
+func (c *Client) BulkGetSavedObjects(ctx context.Context, objects []SavedObject) ([]BulkGetSavedObject, error) {
+	logrus.WithField("count", len(objects)).Debug("Bulk getting saved objects")
+
+	objectsToGet := make([]map[string]interface{}, 0, len(objects))
 	for _, obj := range objects {
-		objectsToExport = append(objectsToExport, map[string]interface{}{
+		objectsToGet = append(objectsToGet, map[string]interface{}{
 			"type": obj.Type,
 			"id":   obj.ID,
 		})
 	}
 
-	params := map[string]interface{}{
-		"objects": objectsToExport,
+	resp, err := c.makeRequest(ctx, "POST", "saved_objects/_bulk_get", objectsToGet)
+	if err != nil {
+		return nil, err
 	}
-	if includeReferences {
-		params["includeReferencesDeep"] = true
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk get saved objects: %w", err)
 	}
 
-	resp, err := c.makeRequest(ctx, "POST", "saved_objects/_export", params)
+	var result struct {
+		SavedObjects []BulkGetSavedObject `json:"saved_objects"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bulk get result: %w", err)
+	}
+
+	logrus.WithField("count", len(result.SavedObjects)).Debug("Bulk got saved objects")
+	return result.SavedObjects, nil
+}
+
+// SavedObjectUpdate is a partial update of one saved object in a bulk update. Attributes are merged
+// into the existing ones; References, when set, replace the existing references.
+type SavedObjectUpdate struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id"`
+	Attributes map[string]interface{} `json:"attributes"`
+	References []Reference            `json:"references,omitempty"`
+	Version    string                 `json:"version,omitempty"`
+}
+
+// BulkUpdateSavedObject is one entry of a bulk update result; Error is set when the object could not
+// be updated, with status code 409 for a version conflict.
+type BulkUpdateSavedObject struct {
+	SavedObject
+	Error *SavedObjectError `json:"error,omitempty"`
+}
+
+// Conflict reports whether the update was rejected because the object changed since its version was read.
+func (o BulkUpdateSavedObject) Conflict() bool {
+	return o.Error != nil && o.Error.StatusCode == http.StatusConflict
+}
+
+// BulkUpdateSavedObjects applies partial updates to multiple saved objects in one request.
+// Objects that cannot be updated (e.g. not found or a version conflict) are returned with Error set
+// instead of failing the batch.
+func (c *Client) BulkUpdateSavedObjects(ctx context.Context, updates []SavedObjectUpdate) ([]BulkUpdateSavedObject, error) {
+	logrus.WithField("count", len(updates)).Debug("Bulk updating saved objects")
+
+	objectsToUpdate := make([]SavedObjectUpdate, 0, len(updates))
+	for _, update := range updates {
+		if update.Type == "" || update.ID == "" {
+			return nil, fmt.Errorf("type and id are required for every update")
+		}
+		// Kibana requires attributes even when only the references change
+		if update.Attributes == nil {
+			update.Attributes = map[string]interface{}{}
+		}
+		objectsToUpdate = append(objectsToUpdate, update)
+	}
+
+	resp, err := c.makeRequest(ctx, "PUT", "saved_objects/_bulk_update", objectsToUpdate)
 	if err != nil {
 		return nil, err
 	}
 
 	body, err := c.handleResponse(resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to export saved objects: %w", err)
+		return nil, fmt.Errorf("failed to bulk update saved objects: %w", err)
 	}
 
-	logrus.WithField("count", len(objects)).Debug("Exported saved objects")
-	return body, nil
-}
+	var result struct {
+		SavedObjects []BulkUpdateSavedObject `json:"saved_objects"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bulk update result: %w", err)
+	}
 
-// ImportSavedObjects imports saved objects from JSON
-func (c *Client) ImportSavedObjects(ctx context.Context, fileContent string, createNewCopies bool) error {
-	logrus.Debug("Importing saved objects")
+	logrus.WithField("count", len(result.SavedObjects)).Debug("Bulk updated saved objects")
+	return result.SavedObjects, nil
+}
 
-	// fileContent can be either base64-encoded or raw JSON
-	fileData := []byte(fileContent)
+// ExportSavedObjects exports saved objects as NDJSON, either the given objects or every object of
+// the given types.
+func (c *Client) ExportSavedObjects(ctx context.Context, objects []SavedObject, types []string, includeReferences bool) ([]byte, error) {
+	logrus.WithFields(logrus.Fields{"count": len(objects), "types": types}).Debug("Exporting saved objects")
 
-	// Validate it's valid JSON
-	var exportData map[string]interface{}
-	if err := json.Unmarshal(fileData, &exportData); err != nil {
-		return fmt.Errorf("invalid import file format: %w", err)
+	if len(objects) > 0 && len(types) > 0 {
+		return nil, fmt.Errorf("export either objects or types, not both")
+	}
+	if len(objects) == 0 && len(types) == 0 {
+		return nil, fmt.Errorf("objects or types is required")
 	}
 
-	params := map[string]interface{}{
-		"file": fileContent,
+	params := map[string]interface{}{}
+	if len(types) > 0 {
+		params["type"] = types
+	} else {
+		objectsToExport := make([]map[string]interface{}, 0, len(objects))
+		for _, obj := range objects {
+			objectsToExport = append(objectsToExport, map[string]interface{}{
+				"type": obj.Type,
+				"id":   obj.ID,
+			})
+		}
+		params["objects"] = objectsToExport
 	}
-	if createNewCopies {
-		params["createNewCopies"] = true
+	if includeReferences {
+		params["includeReferencesDeep"] = true
 	}
 
-	resp, err := c.makeRequest(ctx, "POST", "saved_objects/_import", params)
+	resp, err := c.makeRequest(ctx, "POST", "saved_objects/_export", params)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	respBody, err := c.handleResponse(resp)
+	body, err := c.handleResponse(resp)
 	if err != nil {
-		return fmt.Errorf("failed to import saved objects: %w", err)
+		return nil, fmt.Errorf("failed to export saved objects: %w", err)
 	}
 
-	// Check for errors in import result
-	var result map[string]interface{}
-	if err := json.Unmarshal(respBody, &result); err == nil {
-		if errors, ok := result["errors"].([]interface{}); ok && len(errors) > 0 {
-			return fmt.Errorf("import completed with errors: %v", errors)
-		}
+	logrus.WithFields(logrus.Fields{"count": len(objects), "types": types, "bytes": len(body)}).Debug("Exported saved objects")
+	return body, nil
+}
+
+// ImportSavedObjects imports saved objects from an NDJSON export given as a string, either raw or
+// base64-encoded.
+func (c *Client) ImportSavedObjects(ctx context.Context, fileContent string, createNewCopies bool) error {
+	fileData := []byte(fileContent)
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(fileContent)); err == nil {
+		fileData = decoded
 	}
 
-	logrus.Debug("Imported saved objects successfully")
+	result, err := c.ImportSavedObjectsFrom(ctx, "import.ndjson", bytes.NewReader(fileData), createNewCopies)
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("import completed with errors: %v", result.Errors)
+	}
 	return nil
 }
 
@@ -2241,6 +2485,8 @@ type KibanaAlertRule struct {
 	NotifyWhen  string                   `json:"notifyWhen,omitempty"`
 	Throttle    string                   `json:"throttle,omitempty"`
 	MuteAll     bool                     `json:"muteAll"`
+
+	ExecutionStatus map[string]interface{} `json:"executionStatus,omitempty"`
 }
 
 // KibanaAlertRuleType represents an available alert rule type.
@@ -2285,13 +2531,35 @@ type KibDataView struct {
 	AllowNoIndex  bool                   `json:"allowNoIndex,omitempty"`
 }
 
-// GetAlertRules retrieves all alert rules with pagination.
-func (c *Client) GetAlertRules(ctx context.Context, page, perPage int, filter string, enabled *bool) ([]KibanaAlertRule, error) {
+// AlertRuleSortFields maps the sort fields accepted by GetAlertRules to the rule attributes
+// the alerting _find API sorts on.
+var AlertRuleSortFields = map[string]string{
+	"name":            "name",
+	"createdAt":       "createdAt",
+	"updatedAt":       "updatedAt",
+	"enabled":         "enabled",
+	"executionStatus": "executionStatus.status",
+}
+
+// AlertRuleList is a page of alert rules from the alerting _find API.
+type AlertRuleList struct {
+	Page    int               `json:"page"`
+	PerPage int               `json:"per_page"`
+	Total   int               `json:"total"`
+	Data    []KibanaAlertRule `json:"data"`
+}
+
+// GetAlertRules retrieves alert rules with pagination. search matches rule names and types, tags
+// keeps rules carrying any of the given tags, and sortField is one of AlertRuleSortFields.
+func (c *Client) GetAlertRules(ctx context.Context, page, perPage int, search string, enabled *bool, tags []string, sortField, sortOrder string) (*AlertRuleList, error) {
 	logrus.WithFields(logrus.Fields{
-		"page":    page,
-		"perPage": perPage,
-		"filter":  filter,
-		"enabled": enabled,
+		"page":      page,
+		"perPage":   perPage,
+		"search":    search,
+		"enabled":   enabled,
+		"tags":      tags,
+		"sortField": sortField,
+		"sortOrder": sortOrder,
 	}).Debug("Getting alert rules")
 
 	if page <= 0 {
@@ -2307,14 +2575,39 @@ func (c *Client) GetAlertRules(ctx context.Context, page, perPage int, filter st
 	params := url.Values{}
 	params.Set("page", fmt.Sprintf("%d", page))
 	params.Set("per_page", fmt.Sprintf("%d", perPage))
-	if filter != "" {
-		params.Set("search", filter)
+	if search != "" {
+		params.Set("search", search)
+	}
+	if sortField != "" {
+		field, ok := AlertRuleSortFields[sortField]
+		if !ok {
+			return nil, fmt.Errorf("invalid sort field %q: expected name, createdAt, updatedAt, enabled or executionStatus", sortField)
+		}
+		params.Set("sort_field", field)
+	}
+	if sortOrder != "" {
+		if sortOrder != "asc" && sortOrder != "desc" {
+			return nil, fmt.Errorf("invalid sort order %q: expected asc or desc", sortOrder)
+		}
+		params.Set("sort_order", sortOrder)
 	}
+
+	var filters []string
 	if enabled != nil {
-		params.Set("enabled", fmt.Sprintf("%t", *enabled))
+		filters = append(filters, fmt.Sprintf("alert.attributes.enabled:%t", *enabled))
+	}
+	if len(tags) > 0 {
+		quoted := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			quoted = append(quoted, strconv.Quote(tag))
+		}
+		filters = append(filters, fmt.Sprintf("alert.attributes.tags:(%s)", strings.Join(quoted, " or ")))
+	}
+	if len(filters) > 0 {
+		params.Set("filter", strings.Join(filters, " and "))
 	}
 
-	endpoint := "api/alerting/rules?" + params.Encode()
+	endpoint := "alerting/rules/_find?" + params.Encode()
 	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
@@ -2325,20 +2618,23 @@ func (c *Client) GetAlertRules(ctx context.Context, page, perPage int, filter st
 		return nil, err
 	}
 
-	var rules []KibanaAlertRule
-	if err := json.Unmarshal(respBody, &rules); err != nil {
+	var list AlertRuleList
+	if err := json.Unmarshal(respBody, &list); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal alert rules: %w", err)
 	}
+	if list.Data == nil {
+		list.Data = []KibanaAlertRule{}
+	}
 
-	logrus.WithField("count", len(rules)).Debug("Retrieved alert rules")
-	return rules, nil
+	logrus.WithFields(logrus.Fields{"count": len(list.Data), "total": list.Total}).Debug("Retrieved alert rules")
+	return &list, nil
 }
 
 // GetAlertRule retrieves a specific alert rule by ID.
 func (c *Client) GetAlertRule(ctx context.Context, ruleID string) (*KibanaAlertRule, error) {
 	logrus.WithField("rule_id", ruleID).Debug("Getting alert rule")
 
-	resp, err := c.makeRequest(ctx, "GET", "api/alerting/rules/"+ruleID, nil)
+	resp, err := c.makeRequest(ctx, "GET", "alerting/rules/"+ruleID, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2371,7 +2667,7 @@ func (c *Client) CreateAlertRule(ctx context.Context, name, alertTypeID string,
 		"enabled":     true,
 	}
 
-	resp, err := c.makeRequest(ctx, "POST", "api/alerting/rules", rule)
+	resp, err := c.makeRequest(ctx, "POST", "alerting/rules", rule)
 	if err != nil {
 		return nil, err
 	}
@@ -2411,7 +2707,7 @@ func (c *Client) UpdateAlertRule(ctx context.Context, ruleID string, name string
 		rule["tags"] = tags
 	}
 
-	resp, err := c.makeRequest(ctx, "PUT", "api/alerting/rules/"+ruleID, rule)
+	resp, err := c.makeRequest(ctx, "PUT", "alerting/rules/"+ruleID, rule)
 	if err != nil {
 		return nil, err
 	}
@@ -2434,7 +2730,7 @@ func (c *Client) UpdateAlertRule(ctx context.Context, ruleID string, name string
 func (c *Client) DeleteAlertRule(ctx context.Context, ruleID string) error {
 	logrus.WithField("rule_id", ruleID).Debug("Deleting alert rule")
 
-	resp, err := c.makeRequest(ctx, "DELETE", "api/alerting/rules/"+ruleID, nil)
+	resp, err := c.makeRequest(ctx, "DELETE", "alerting/rules/"+ruleID, nil)
 	if err != nil {
 		return err
 	}
@@ -2452,7 +2748,7 @@ func (c *Client) DeleteAlertRule(ctx context.Context, ruleID string) error {
 func (c *Client) EnableAlertRule(ctx context.Context, ruleID string) error {
 	logrus.WithField("rule_id", ruleID).Debug("Enabling alert rule")
 
-	resp, err := c.makeRequest(ctx, "POST", "api/alerting/rules/"+ruleID+"/_enable", nil)
+	resp, err := c.makeRequest(ctx, "POST", "alerting/rules/"+ruleID+"/_enable", nil)
 	if err != nil {
 		return err
 	}
@@ -2470,7 +2766,7 @@ func (c *Client) EnableAlertRule(ctx context.Context, ruleID string) error {
 func (c *Client) DisableAlertRule(ctx context.Context, ruleID string) error {
 	logrus.WithField("rule_id", ruleID).Debug("Disabling alert rule")
 
-	resp, err := c.makeRequest(ctx, "POST", "api/alerting/rules/"+ruleID+"/_disable", nil)
+	resp, err := c.makeRequest(ctx, "POST", "alerting/rules/"+ruleID+"/_disable", nil)
 	if err != nil {
 		return err
 	}
@@ -2491,7 +2787,7 @@ func (c *Client) MuteAlertRule(ctx context.Context, ruleID, duration string) err
 		"duration": duration,
 	}).Debug("Muting alert rule")
 
-	resp, err := c.makeRequest(ctx, "POST", "api/alerting/rules/"+ruleID+"/_mute", map[string]interface{}{
+	resp, err := c.makeRequest(ctx, "POST", "alerting/rules/"+ruleID+"/_mute", map[string]interface{}{
 		"duration": duration,
 	})
 	if err != nil {
@@ -2511,7 +2807,7 @@ func (c *Client) MuteAlertRule(ctx context.Context, ruleID, duration string) err
 func (c *Client) UnmuteAlertRule(ctx context.Context, ruleID string) error {
 	logrus.WithField("rule_id", ruleID).Debug("Unmuting alert rule")
 
-	resp, err := c.makeRequest(ctx, "POST", "api/alerting/rules/"+ruleID+"/_unmute", nil)
+	resp, err := c.makeRequest(ctx, "POST", "alerting/rules/"+ruleID+"/_unmute", nil)
 	if err != nil {
 		return err
 	}
@@ -2529,7 +2825,7 @@ func (c *Client) UnmuteAlertRule(ctx context.Context, ruleID string) error {
 func (c *Client) GetAlertRuleTypes(ctx context.Context) ([]KibanaAlertRuleType, error) {
 	logrus.Debug("Getting alert rule types")
 
-	resp, err := c.makeRequest(ctx, "GET", "api/alerting/rule_types", nil)
+	resp, err := c.makeRequest(ctx, "GET", "alerting/rule_types", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2570,7 +2866,7 @@ func (c *Client) GetAlertRuleHistory(ctx context.Context, ruleID string, page, p
 	params.Set("page", fmt.Sprintf("%d", page))
 	params.Set("per_page", fmt.Sprintf("%d", perPage))
 
-	endpoint := "api/alerting/rules/" + ruleID + "/execution?" + params.Encode()
+	endpoint := "alerting/rules/" + ruleID + "/execution?" + params.Encode()
 	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
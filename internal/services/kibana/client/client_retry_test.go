@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -80,3 +81,33 @@ func TestNoRetryForNonIdempotentMethod(t *testing.T) {
 		t.Fatalf("expected 1 attempt for non-idempotent request, got %d", attempts)
 	}
 }
+
+func TestCancelAbortsInFlightRequest(t *testing.T) {
+	requestStarted := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestStarted)
+		// Hold the request open until the client goes away
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 30 * time.Second, MaxRetries: 2})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requestStarted
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err = client.GetSpaces(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the cancelled request to return promptly, took %s", elapsed)
+	}
+}
@@ -0,0 +1,380 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewPaginationInfo(t *testing.T) {
+	first := NewPaginationInfo(1, 20, 45, 20)
+	if !first.HasMore || first.ContinueToken != "2" || first.ReturnedCount != 20 || first.TotalPages != 3 {
+		t.Fatalf("unexpected first page pagination: %+v", first)
+	}
+
+	last := NewPaginationInfo(3, 20, 45, 5)
+	if last.HasMore || last.ContinueToken != "" || !last.HasPreviousPage {
+		t.Fatalf("unexpected last page pagination: %+v", last)
+	}
+
+	if empty := NewPaginationInfo(1, 0, 0, 0); empty.HasMore || empty.TotalPages != 0 {
+		t.Fatalf("unexpected empty pagination: %+v", empty)
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and its key as PEM files
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kibana-mcp-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return certFile, keyFile
+}
+
+func TestNewClientLoadsClientCertificate(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	client, err := NewClient(&ClientOptions{
+		URL:            "https://kibana.example.com",
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+		CACertFile:     certFile,
+		SkipVerify:     true,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig == nil {
+		t.Fatalf("expected transport with TLS config, got %T", client.httpClient.Transport)
+	}
+	tlsConfig := transport.TLSClientConfig
+	if len(tlsConfig.Certificates) != 1 {
+		t.Fatalf("expected 1 client certificate, got %d", len(tlsConfig.Certificates))
+	}
+	if tlsConfig.RootCAs == nil {
+		t.Fatal("expected CA pool to be loaded")
+	}
+	if tlsConfig.InsecureSkipVerify {
+		t.Fatal("expected CA file to take precedence over SkipVerify")
+	}
+}
+
+func TestNewClientRequiresCertificateAndKey(t *testing.T) {
+	certFile, _ := writeTestCertificate(t, t.TempDir())
+
+	if _, err := NewClient(&ClientOptions{URL: "https://kibana.example.com", ClientCertFile: certFile}); err == nil {
+		t.Fatal("expected error when the client key file is missing")
+	}
+}
+
+func TestParseRequestHeadersMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) != 1 || r.TLS.PeerCertificates[0].Subject.CommonName != "kibana-mcp-client" {
+			t.Errorf("expected the configured client certificate, got %+v", r.TLS)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := filepath.Join(dir, "server-ca.crt")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	// The key and CA come from the defaults, the certificate from the request
+	SetDefaultTLSFiles("/missing/client.crt", keyFile, caFile)
+	defer SetDefaultTLSFiles("", "", "")
+	h := http.Header{}
+	h.Set(hdrKibanaURL, server.URL)
+	h.Set(hdrKibanaTLSCert, certFile)
+	opts := parseRequestHeaders(h)
+	if opts.ClientCertFile != certFile || opts.ClientKeyFile != keyFile || opts.CACertFile != caFile {
+		t.Fatalf("unexpected TLS files: %+v", opts)
+	}
+
+	client, err := NewClient(opts)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.GetSpaces(context.Background()); err != nil {
+		t.Fatalf("GetSpaces() error = %v", err)
+	}
+}
+
+func TestNewClientCustomHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Forwarded-For"); got != "10.0.0.1" {
+			t.Errorf("expected X-Forwarded-For header, got %q", got)
+		}
+		if got := r.Header.Get("kbn-xsrf"); got != "reporting" {
+			t.Errorf("expected overridden kbn-xsrf header, got %q", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("expected default Content-Type header, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{
+		URL:     server.URL,
+		Timeout: 2 * time.Second,
+		Headers: map[string]string{
+			"x-forwarded-for": "10.0.0.1",
+			"kbn-xsrf":        "reporting",
+			"Content-Type":    "",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetSpaces(context.Background()); err != nil {
+		t.Fatalf("GetSpaces() error = %v", err)
+	}
+}
+
+func TestParseRequestHeadersAppliesDefaultHeaders(t *testing.T) {
+	SetDefaultHeaders(map[string]string{"X-Gateway-Key": "secret"})
+	defer SetDefaultHeaders(nil)
+
+	opts := parseRequestHeaders(http.Header{})
+	if opts.Headers["X-Gateway-Key"] != "secret" {
+		t.Fatalf("expected default headers on client options, got %v", opts.Headers)
+	}
+}
+
+func TestNewClientUserAgent(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.UserAgent())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	SetUserAgent("cloud-native-mcp-server/1.2.3")
+	defer SetUserAgent("")
+	configured := parseRequestHeaders(http.Header{})
+	configured.URL = server.URL
+	for _, opts := range []*ClientOptions{{URL: server.URL}, configured} {
+		client, err := NewClient(opts)
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		if _, err := client.GetSpaces(context.Background()); err != nil {
+			t.Fatalf("GetSpaces() error = %v", err)
+		}
+	}
+	if len(got) != 2 || got[0] != DefaultUserAgent || got[1] != "cloud-native-mcp-server/1.2.3" {
+		t.Fatalf("expected the default then the configured User-Agent, got %v", got)
+	}
+}
+
+func TestBulkGetSavedObjectsReportsPerObjectErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/saved_objects/_bulk_get" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"saved_objects":[
+			{"id":"d1","type":"dashboard","attributes":{"title":"Ops"}},
+			{"id":"missing","type":"dashboard","error":{"statusCode":404,"error":"Not Found","message":"Saved object [dashboard/missing] not found"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	results, err := client.BulkGetSavedObjects(context.Background(), []SavedObject{
+		{Type: "dashboard", ID: "d1"},
+		{Type: "dashboard", ID: "missing"},
+	})
+	if err != nil {
+		t.Fatalf("BulkGetSavedObjects() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Error != nil || results[0].Attributes["title"] != "Ops" {
+		t.Fatalf("unexpected first result: %+v", results[0])
+	}
+	if results[1].Error == nil || results[1].Error.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected not found error on second result, got %+v", results[1])
+	}
+}
+
+func TestBulkUpdateSavedObjectsReportsConflicts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/saved_objects/_bulk_update" || r.Method != http.MethodPut {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var updates []map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if len(updates) != 2 || updates[1]["attributes"] == nil || updates[1]["references"] == nil || updates[0]["version"] != "WzEsMV0=" {
+			t.Fatalf("unexpected updates %v", updates)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"saved_objects":[
+			{"id":"v1","type":"visualization","version":"WzIsMV0=","attributes":{"title":"Errors"}},
+			{"id":"v2","type":"visualization","error":{"statusCode":409,"error":"Conflict","message":"Saved object [visualization/v2] conflict"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	results, err := client.BulkUpdateSavedObjects(context.Background(), []SavedObjectUpdate{
+		{Type: "visualization", ID: "v1", Attributes: map[string]interface{}{"title": "Errors"}, Version: "WzEsMV0="},
+		{Type: "visualization", ID: "v2", References: []Reference{{Name: "kibanaSavedObjectMeta.searchSourceJSON.index", Type: "index-pattern", ID: "logs-new"}}},
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdateSavedObjects() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Error != nil || results[0].Version != "WzIsMV0=" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if !results[1].Conflict() {
+		t.Fatalf("expected a version conflict on the second result, got %+v", results[1])
+	}
+
+	if _, err := client.BulkUpdateSavedObjects(context.Background(), []SavedObjectUpdate{{Type: "visualization"}}); err == nil {
+		t.Fatal("expected an update without id to be rejected")
+	}
+}
+
+func TestGetAlertRulesSortAndTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/alerting/rules/_find" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("sort_field") != "executionStatus.status" || query.Get("sort_order") != "desc" {
+			t.Fatalf("unexpected sort %q %q", query.Get("sort_field"), query.Get("sort_order"))
+		}
+		if got := query.Get("filter"); got != `alert.attributes.enabled:true and alert.attributes.tags:("prod" or "on-call")` {
+			t.Fatalf("unexpected filter %q", got)
+		}
+		if query.Get("search") != "cpu" || query.Get("page") != "2" || query.Get("per_page") != "1" {
+			t.Fatalf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"page":2,"per_page":1,"total":3,"data":[{"id":"r2","name":"High CPU","enabled":true,"tags":["prod"],"executionStatus":{"status":"error"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	enabled := true
+	list, err := client.GetAlertRules(context.Background(), 2, 1, "cpu", &enabled, []string{"prod", "on-call"}, "executionStatus", "desc")
+	if err != nil {
+		t.Fatalf("GetAlertRules() error = %v", err)
+	}
+	if list.Total != 3 || list.Page != 2 || len(list.Data) != 1 || list.Data[0].ExecutionStatus["status"] != "error" {
+		t.Fatalf("unexpected alert rule list: %+v", list)
+	}
+
+	if _, err := client.GetAlertRules(context.Background(), 1, 20, "", nil, nil, "severity", ""); err == nil {
+		t.Fatal("expected an unknown sort field to be rejected")
+	}
+	if _, err := client.GetAlertRules(context.Background(), 1, 20, "", nil, nil, "name", "up"); err == nil {
+		t.Fatal("expected an unknown sort order to be rejected")
+	}
+}
+
+func TestExportSavedObjectsByType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/saved_objects/_export" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode export body: %v", err)
+		}
+		types, _ := body["type"].([]interface{})
+		if len(types) != 1 || types[0] != "dashboard" || body["objects"] != nil || body["includeReferencesDeep"] != true {
+			t.Fatalf("unexpected export body: %v", body)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte("{\"id\":\"d1\",\"type\":\"dashboard\"}\n{\"exportedCount\":1,\"missingRefCount\":0}\n"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	data, err := client.ExportSavedObjects(context.Background(), nil, []string{"dashboard"}, true)
+	if err != nil {
+		t.Fatalf("ExportSavedObjects() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"exportedCount":1`) {
+		t.Fatalf("unexpected export data: %s", data)
+	}
+
+	if _, err := client.ExportSavedObjects(context.Background(), []SavedObject{{Type: "dashboard", ID: "d1"}}, []string{"dashboard"}, true); err == nil {
+		t.Fatal("expected objects and types together to be rejected")
+	}
+}
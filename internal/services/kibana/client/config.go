@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/middleware"
@@ -19,10 +20,55 @@ const (
 	hdrKibanaSpace      = "X-Mcp-Backend-Kibana-Space"
 	hdrKibanaSkipVerify = "X-Mcp-Backend-Kibana-Skip-Verify"
 	hdrKibanaTimeoutSec = "X-Mcp-Backend-Kibana-Timeout-Sec"
+	hdrKibanaFleetURL   = "X-Mcp-Backend-Kibana-Fleet-Url"
+	hdrKibanaTLSCert    = "X-Mcp-Backend-Kibana-Tls-Cert-File"
+	hdrKibanaTLSKey     = "X-Mcp-Backend-Kibana-Tls-Key-File"
+	hdrKibanaTLSCA      = "X-Mcp-Backend-Kibana-Tls-Ca-File"
 )
 
 type kibanaContextKey struct{}
 
+var (
+	defaultHeadersMu sync.RWMutex
+	defaultHeaders   map[string]string
+	userAgent        string
+	defaultTLSFiles  tlsFiles
+)
+
+// tlsFiles are the PEM files used for mutual TLS with Kibana
+type tlsFiles struct {
+	certFile, keyFile, caFile string
+}
+
+// SetDefaultHeaders sets extra headers, typically from the kibana.headers config,
+// that are sent on every request of clients created from request headers.
+func SetDefaultHeaders(headers map[string]string) {
+	copied := make(map[string]string, len(headers))
+	for key, value := range headers {
+		copied[key] = value
+	}
+	defaultHeadersMu.Lock()
+	defaultHeaders = copied
+	defaultHeadersMu.Unlock()
+}
+
+// SetUserAgent sets the User-Agent, typically from the server.userAgent config, of clients
+// created from request headers. Empty keeps DefaultUserAgent.
+func SetUserAgent(agent string) {
+	defaultHeadersMu.Lock()
+	userAgent = agent
+	defaultHeadersMu.Unlock()
+}
+
+// SetDefaultTLSFiles sets the client certificate, key and CA files, typically from the
+// kibana.tlsCertFile, tlsKeyFile and tlsCAFile config, of clients created from request headers.
+// Request headers naming other files take precedence.
+func SetDefaultTLSFiles(certFile, keyFile, caFile string) {
+	defaultHeadersMu.Lock()
+	defaultTLSFiles = tlsFiles{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	defaultHeadersMu.Unlock()
+}
+
 func init() {
 	middleware.RegisterBackendAuthHandler("kibana", parseHeadersAndInjectClient)
 }
@@ -42,6 +88,18 @@ func parseHeadersAndInjectClient(r *http.Request) (*http.Request, error) {
 
 func parseRequestHeaders(h http.Header) *ClientOptions {
 	opts := &ClientOptions{Timeout: 30 * time.Second}
+	defaultHeadersMu.RLock()
+	opts.UserAgent = userAgent
+	opts.ClientCertFile = defaultTLSFiles.certFile
+	opts.ClientKeyFile = defaultTLSFiles.keyFile
+	opts.CACertFile = defaultTLSFiles.caFile
+	if len(defaultHeaders) > 0 {
+		opts.Headers = make(map[string]string, len(defaultHeaders))
+		for key, value := range defaultHeaders {
+			opts.Headers[key] = value
+		}
+	}
+	defaultHeadersMu.RUnlock()
 	if v := h.Get(hdrKibanaURL); v != "" {
 		opts.URL = v
 	}
@@ -57,6 +115,18 @@ func parseRequestHeaders(h http.Header) *ClientOptions {
 	if v := h.Get(hdrKibanaSpace); v != "" {
 		opts.Space = v
 	}
+	if v := h.Get(hdrKibanaFleetURL); v != "" {
+		opts.FleetURL = v
+	}
+	if v := h.Get(hdrKibanaTLSCert); v != "" {
+		opts.ClientCertFile = v
+	}
+	if v := h.Get(hdrKibanaTLSKey); v != "" {
+		opts.ClientKeyFile = v
+	}
+	if v := h.Get(hdrKibanaTLSCA); v != "" {
+		opts.CACertFile = v
+	}
 	if v := h.Get(hdrKibanaSkipVerify); v != "" {
 		opts.SkipVerify, _ = strconv.ParseBool(v)
 	}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Authentication methods reported by GetConnectionInfo.
+const (
+	AuthMethodAPIKey = "apiKey"
+	AuthMethodBasic  = "basic"
+	AuthMethodHeader = "header"
+	AuthMethodNone   = "none"
+)
+
+// FeatureAvailability tells whether a Kibana API can be used with the configured credentials.
+type FeatureAvailability struct {
+	Available  bool   `json:"available"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ConnectionInfo describes the Kibana a client is connected to and what it can do there.
+type ConnectionInfo struct {
+	URL        string                         `json:"url"`
+	Version    string                         `json:"version,omitempty"`
+	Status     string                         `json:"status,omitempty"`
+	AuthMethod string                         `json:"authMethod"`
+	Space      string                         `json:"space"`
+	Features   map[string]FeatureAvailability `json:"features"`
+}
+
+// GetConnectionInfo reads the Kibana version and status and probes the alerting, actions and
+// Fleet APIs with a cheap request each. An error is only returned when Kibana cannot be reached
+// at all; an unavailable feature is reported in Features.
+func (c *Client) GetConnectionInfo(ctx context.Context) (*ConnectionInfo, error) {
+	logrus.Debug("Getting Kibana connection info")
+
+	status, err := c.GetKibanaStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ConnectionInfo{
+		URL:        strings.TrimSuffix(c.baseURL, "api/"),
+		Status:     kibanaCoreHealth(status).ReportedAs,
+		AuthMethod: c.authMethod(),
+		Space:      c.space,
+		Features:   map[string]FeatureAvailability{},
+	}
+	info.Version, _ = status.Version["number"].(string)
+
+	info.Features["alerting"] = c.probeFeature(c.makeRequest(ctx, "GET", "alerting/rules/_find?per_page=1", nil))
+	info.Features["actions"] = c.probeFeature(c.makeRequest(ctx, "GET", "actions/connector_types", nil))
+	info.Features["fleet"] = c.probeFeature(c.fleetRequest(ctx, "GET", "agent_policies?perPage=1"))
+
+	logrus.WithFields(logrus.Fields{"version": info.Version, "authMethod": info.AuthMethod}).Debug("Retrieved Kibana connection info")
+	return info, nil
+}
+
+// authMethod reports how requests are authenticated; an API key takes precedence over basic auth
+func (c *Client) authMethod() string {
+	switch {
+	case c.apiKey != "":
+		return AuthMethodAPIKey
+	case c.username != "" && c.password != "":
+		return AuthMethodBasic
+	}
+	for key := range c.headers {
+		if strings.EqualFold(key, "Authorization") {
+			return AuthMethodHeader
+		}
+	}
+	return AuthMethodNone
+}
+
+// probeFeature turns the response to a probe request into a FeatureAvailability
+func (c *Client) probeFeature(resp *http.Response, err error) FeatureAvailability {
+	if err != nil {
+		return FeatureAvailability{Error: err.Error()}
+	}
+	statusCode := resp.StatusCode
+	if _, err := c.handleResponse(resp); err != nil {
+		return FeatureAvailability{StatusCode: statusCode, Error: err.Error()}
+	}
+	return FeatureAvailability{Available: true, StatusCode: statusCode}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetConnectionInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "ApiKey secret" {
+			t.Fatalf("unexpected Authorization header %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/spaces/ops/status":
+			_, _ = w.Write([]byte(`{"version":{"number":"8.15.2"},"status":{"overall":{"level":"available"}}}`))
+		case "/api/spaces/ops/alerting/rules/_find":
+			_, _ = w.Write([]byte(`{"page":1,"per_page":1,"total":0,"data":[]}`))
+		case "/api/spaces/ops/actions/connector_types":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"statusCode":403,"error":"Forbidden"}`))
+		case "/api/spaces/ops/fleet/agent_policies":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"statusCode":404,"error":"Not Found"}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, APIKey: "secret", Space: "ops", Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	info, err := client.GetConnectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetConnectionInfo() error = %v", err)
+	}
+	if info.Version != "8.15.2" || info.Status != "available" || info.AuthMethod != AuthMethodAPIKey || info.Space != "ops" {
+		t.Fatalf("unexpected connection info: %+v", info)
+	}
+	if info.URL != server.URL+"/" {
+		t.Fatalf("expected the Kibana URL without the API path, got %q", info.URL)
+	}
+	if !info.Features["alerting"].Available {
+		t.Fatalf("expected alerting to be available, got %+v", info.Features["alerting"])
+	}
+	if actions := info.Features["actions"]; actions.Available || actions.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected actions to be forbidden, got %+v", actions)
+	}
+	if fleet := info.Features["fleet"]; fleet.Available || fleet.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected fleet to be missing, got %+v", fleet)
+	}
+}
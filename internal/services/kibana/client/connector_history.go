@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrEventLogNotAvailable is returned when the event log cannot be read: the credentials lack the
+// privileges to read connector executions, or the target Kibana does not expose the event log API.
+var ErrEventLogNotAvailable = errors.New("the Kibana event log is not available (access restricted by the user's privileges, or the event log API is not exposed)")
+
+// connectorExecutionFilter selects the events recording a connector run, including runs that timed out
+const connectorExecutionFilter = "event.provider:actions and event.action:(execute or execute-timeout)"
+
+// ConnectorExecution is one run of a connector as recorded in the event log.
+type ConnectorExecution struct {
+	Timestamp   string `json:"timestamp"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+	Message     string `json:"message,omitempty"`
+	DurationMs  int64  `json:"durationMs,omitempty"`
+	ExecutionID string `json:"executionId,omitempty"`
+	Source      string `json:"source,omitempty"`
+	RuleID      string `json:"ruleId,omitempty"`
+}
+
+// ConnectorExecutionHistory is a page of the most recent runs of a connector, newest first.
+type ConnectorExecutionHistory struct {
+	ConnectorID string               `json:"connectorId"`
+	Page        int                  `json:"page"`
+	PerPage     int                  `json:"perPage"`
+	Total       int                  `json:"total"`
+	Failures    int                  `json:"failures"`
+	Executions  []ConnectorExecution `json:"executions"`
+}
+
+// eventLogEvent is the part of an event log document describing an action execution
+type eventLogEvent struct {
+	Timestamp string `json:"@timestamp"`
+	Message   string `json:"message"`
+	Event     struct {
+		Action   string      `json:"action"`
+		Outcome  string      `json:"outcome"`
+		Duration json.Number `json:"duration"`
+	} `json:"event"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Kibana struct {
+		Action struct {
+			Execution struct {
+				UUID   string `json:"uuid"`
+				Source string `json:"source"`
+			} `json:"execution"`
+		} `json:"action"`
+		SavedObjects []struct {
+			Type string `json:"type"`
+			ID   string `json:"id"`
+		} `json:"saved_objects"`
+	} `json:"kibana"`
+}
+
+type eventLogFindResult struct {
+	Page    int             `json:"page"`
+	PerPage int             `json:"per_page"`
+	Total   int             `json:"total"`
+	Data    []eventLogEvent `json:"data"`
+}
+
+// GetConnectorExecutionHistory reads the recent executions of a connector from the event log, newest
+// first, with the outcome and error of each. Kibana 7.x serves the event log under /api; newer versions
+// only under /internal, which is tried when the public route does not exist. Credentials that may not
+// read the event log get ErrEventLogNotAvailable.
+func (c *Client) GetConnectorExecutionHistory(ctx context.Context, connectorID string, page, perPage int) (*ConnectorExecutionHistory, error) {
+	logrus.WithFields(logrus.Fields{
+		"connector_id": connectorID,
+		"page":         page,
+		"perPage":      perPage,
+	}).Debug("Getting connector execution history")
+
+	if connectorID == "" {
+		return nil, fmt.Errorf("connector ID is required")
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("per_page", fmt.Sprintf("%d", perPage))
+	params.Set("filter", connectorExecutionFilter)
+	endpoint := "event_log/action/" + url.PathEscape(connectorID) + "/_find?"
+
+	// The 7.x route sorts ascending unless told otherwise; the internal route sorts newest first by default
+	legacy := url.Values{"sort_field": {"@timestamp"}, "sort_order": {"desc"}}
+	for key, values := range params {
+		legacy[key] = values
+	}
+	body, err := c.eventLogRequest(ctx, c.baseURL, endpoint+legacy.Encode(), connectorID)
+	if errors.Is(err, errEventLogRouteNotFound) {
+		internalURL := strings.TrimSuffix(c.baseURL, "api/") + "internal/"
+		body, err = c.eventLogRequest(ctx, internalURL, endpoint+params.Encode(), connectorID)
+	}
+	if errors.Is(err, errEventLogRouteNotFound) {
+		return nil, fmt.Errorf("%w (status %d)", ErrEventLogNotAvailable, http.StatusNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var found eventLogFindResult
+	if err := json.Unmarshal(body, &found); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal connector execution history: %w", err)
+	}
+
+	history := &ConnectorExecutionHistory{
+		ConnectorID: connectorID,
+		Page:        page,
+		PerPage:     perPage,
+		Total:       found.Total,
+		Executions:  make([]ConnectorExecution, 0, len(found.Data)),
+	}
+	for _, event := range found.Data {
+		execution := connectorExecution(event)
+		if execution.Status == "failure" || execution.Status == "timeout" {
+			history.Failures++
+		}
+		history.Executions = append(history.Executions, execution)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"connector_id": connectorID,
+		"count":        len(history.Executions),
+		"failures":     history.Failures,
+	}).Debug("Retrieved connector execution history")
+	return history, nil
+}
+
+// errEventLogRouteNotFound reports an event log route missing from the target Kibana, as opposed to
+// a connector that does not exist
+var errEventLogRouteNotFound = errors.New("event log route not found")
+
+// eventLogRequest performs a GET against an event log route and returns the response body
+func (c *Client) eventLogRequest(ctx context.Context, baseURL, endpoint, connectorID string) ([]byte, error) {
+	resp, err := c.makeRequestWithBase(ctx, baseURL, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w (status %d)", ErrEventLogNotAvailable, resp.StatusCode)
+	case http.StatusNotFound:
+		_, err := c.handleResponse(resp)
+		// The event log checks the connector exists and names it in the error
+		if strings.Contains(err.Error(), "Saved object [action/") {
+			return nil, fmt.Errorf("connector %s not found: %w", connectorID, err)
+		}
+		logrus.WithError(err).WithField("base_url", baseURL).Debug("Event log route not found")
+		return nil, errEventLogRouteNotFound
+	}
+	return c.handleResponse(resp)
+}
+
+// connectorExecution extracts the outcome of one action execution event
+func connectorExecution(event eventLogEvent) ConnectorExecution {
+	execution := ConnectorExecution{
+		Timestamp:   event.Timestamp,
+		Status:      event.Event.Outcome,
+		Error:       event.Error.Message,
+		ExecutionID: event.Kibana.Action.Execution.UUID,
+		Source:      event.Kibana.Action.Execution.Source,
+	}
+	switch {
+	case event.Event.Action == "execute-timeout":
+		execution.Status = "timeout"
+	case execution.Status == "":
+		execution.Status = "unknown"
+	}
+	if execution.Status != "success" {
+		execution.Message = event.Message
+	}
+	// event.duration is recorded in nanoseconds
+	if nanos, err := event.Event.Duration.Int64(); err == nil {
+		execution.DurationMs = time.Duration(nanos).Milliseconds()
+	}
+	for _, so := range event.Kibana.SavedObjects {
+		if so.Type == "alert" {
+			execution.RuleID = so.ID
+		}
+	}
+	return execution
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetConnectorExecutionHistory(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/api/event_log/action/slack-1/_find" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"Not Found"}`))
+			return
+		}
+		if r.URL.Path != "/internal/event_log/action/slack-1/_find" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("filter"); got != connectorExecutionFilter {
+			t.Fatalf("unexpected filter %q", got)
+		}
+		if got := r.URL.Query().Get("per_page"); got != "5" {
+			t.Fatalf("unexpected per_page %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"page":1,"per_page":5,"total":3,"data":[
+			{"@timestamp":"2026-10-16T10:02:00.000Z","message":"action execution failure: .slack:slack-1: ops","event":{"action":"execute","outcome":"failure","duration":"250000000"},"error":{"message":"error posting slack message: invalid_auth"},"kibana":{"action":{"execution":{"uuid":"e-3","source":"ALERT"}},"saved_objects":[{"rel":"primary","type":"action","id":"slack-1"},{"type":"alert","id":"rule-9"}]}},
+			{"@timestamp":"2026-10-16T10:01:00.000Z","message":"action timed out","event":{"action":"execute-timeout"}},
+			{"@timestamp":"2026-10-16T10:00:00.000Z","message":"action executed: .slack:slack-1: ops","event":{"action":"execute","outcome":"success","duration":40000000}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	history, err := client.GetConnectorExecutionHistory(context.Background(), "slack-1", 1, 5)
+	if err != nil {
+		t.Fatalf("GetConnectorExecutionHistory() error = %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("expected the internal route to be tried after the public one, got %v", paths)
+	}
+	if history.Total != 3 || history.Failures != 2 || len(history.Executions) != 3 {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+	failed := history.Executions[0]
+	if failed.Status != "failure" || failed.Error != "error posting slack message: invalid_auth" || failed.DurationMs != 250 || failed.RuleID != "rule-9" || failed.Source != "ALERT" {
+		t.Fatalf("unexpected failed execution: %+v", failed)
+	}
+	if history.Executions[1].Status != "timeout" || history.Executions[1].Message != "action timed out" {
+		t.Fatalf("unexpected timed out execution: %+v", history.Executions[1])
+	}
+	succeeded := history.Executions[2]
+	if succeeded.Status != "success" || succeeded.Message != "" || succeeded.DurationMs != 40 {
+		t.Fatalf("unexpected successful execution: %+v", succeeded)
+	}
+}
+
+func TestGetConnectorExecutionHistoryRestricted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Unauthorized to get actions"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.GetConnectorExecutionHistory(context.Background(), "slack-1", 1, 20)
+	if !errors.Is(err, ErrEventLogNotAvailable) {
+		t.Fatalf("expected ErrEventLogNotAvailable, got %v", err)
+	}
+}
+
+func TestGetConnectorExecutionHistoryUnknownConnector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"Saved object [action/missing] not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.GetConnectorExecutionHistory(context.Background(), "missing", 1, 20)
+	if err == nil || errors.Is(err, ErrEventLogNotAvailable) {
+		t.Fatalf("expected a connector not found error, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// dashboardResolvePageSize bounds the candidates returned by one dashboard title search
+const dashboardResolvePageSize = 100
+
+// GetDashboardByTitle returns the dashboard whose title is exactly title. Titles are not unique in
+// Kibana, so it fails when no dashboard or more than one dashboard carries the title.
+func (c *Client) GetDashboardByTitle(ctx context.Context, title string) (*Dashboard, error) {
+	logrus.WithField("title", title).Debug("Getting Kibana dashboard by title")
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("dashboard title is required")
+	}
+
+	params := url.Values{}
+	params.Set("type", "dashboard")
+	params.Set("search_fields", "title")
+	params.Set("search", strconv.Quote(title))
+	params.Set("per_page", strconv.Itoa(dashboardResolvePageSize))
+
+	resp, err := c.makeRequest(ctx, "GET", "saved_objects/_find?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SearchResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dashboard search: %w", err)
+	}
+
+	// The search is analyzed and may return near matches; only an exact title counts
+	var matches []SavedObject
+	for _, obj := range result.SavedObjects {
+		if getStringField(obj.Attributes, "title") == title {
+			matches = append(matches, obj)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no dashboard found with title %q", title)
+	case 1:
+		obj := matches[0]
+		dashboard := &Dashboard{
+			ID:          obj.ID,
+			Type:        obj.Type,
+			Title:       getStringField(obj.Attributes, "title"),
+			Description: getStringField(obj.Attributes, "description"),
+			Attributes:  obj.Attributes,
+		}
+		logrus.WithFields(logrus.Fields{"title": title, "dashboard_id": dashboard.ID}).Debug("Retrieved Kibana dashboard by title")
+		return dashboard, nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, obj := range matches {
+			ids = append(ids, obj.ID)
+		}
+		return nil, fmt.Errorf("%d dashboards share the title %q (%s); pass the dashboard ID instead", len(ids), title, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetDashboardByTitle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/api/saved_objects/_find" || query.Get("type") != "dashboard" || query.Get("search_fields") != "title" {
+			t.Fatalf("unexpected request: %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// Simulate an analyzed search that also returns near matches
+		_, _ = w.Write([]byte(`{"saved_objects":[
+			{"id":"ops-id","type":"dashboard","attributes":{"title":"Ops Overview","description":"Cluster health"}},
+			{"id":"ops-old-id","type":"dashboard","attributes":{"title":"Ops Overview (old)"}},
+			{"id":"web-b","type":"dashboard","attributes":{"title":"Web"}},
+			{"id":"web-a","type":"dashboard","attributes":{"title":"Web"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	dashboard, err := client.GetDashboardByTitle(context.Background(), " Ops Overview ")
+	if err != nil {
+		t.Fatalf("GetDashboardByTitle() error = %v", err)
+	}
+	if dashboard.ID != "ops-id" || dashboard.Title != "Ops Overview" || dashboard.Description != "Cluster health" {
+		t.Fatalf("unexpected dashboard: %+v", dashboard)
+	}
+	if _, err := client.GetDashboardByTitle(context.Background(), "Web"); err == nil || !strings.Contains(err.Error(), "web-a, web-b") {
+		t.Fatalf("expected an ambiguity error listing both IDs, got %v", err)
+	}
+	if _, err := client.GetDashboardByTitle(context.Background(), "Billing"); err == nil || !strings.Contains(err.Error(), "no dashboard") {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if _, err := client.GetDashboardByTitle(context.Background(), "  "); err == nil {
+		t.Fatal("expected an error for an empty title")
+	}
+}
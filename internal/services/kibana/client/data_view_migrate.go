@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dataViewMigrateFindPageSize is the page size used to find the objects referencing a data view
+	dataViewMigrateFindPageSize = 1000
+	// dataViewMigrateBatchSize is the number of objects sent per _bulk_update request
+	dataViewMigrateBatchSize = 100
+)
+
+// DefaultDataViewReferencingTypes are the saved object types searched for data view references when no
+// type filter is given. The _find API requires explicit types, and types unknown to Kibana are skipped.
+var DefaultDataViewReferencingTypes = []string{
+	"search",
+	"visualization",
+	"lens",
+	"dashboard",
+	"map",
+	"event-annotation-group",
+}
+
+// DataViewMigrationObject is a saved object whose references to the old data view are rewritten.
+type DataViewMigrationObject struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Title      string   `json:"title,omitempty"`
+	References []string `json:"references"`
+	Error      string   `json:"error,omitempty"`
+	Conflict   bool     `json:"conflict,omitempty"`
+}
+
+// DataViewMigration summarizes repointing the saved objects referencing one data view to another.
+type DataViewMigration struct {
+	OldDataViewID string                    `json:"oldDataViewId"`
+	NewDataViewID string                    `json:"newDataViewId"`
+	DryRun        bool                      `json:"dryRun"`
+	Found         int                       `json:"found"`
+	Migrated      int                       `json:"migrated"`
+	Failed        int                       `json:"failed"`
+	Objects       []DataViewMigrationObject `json:"objects"`
+	SkippedTypes  []string                  `json:"skippedTypes,omitempty"`
+}
+
+// MigrateDataViewReferences rewrites every data view reference to oldDataViewID into a reference to
+// newDataViewID, in the saved objects of the given types (DefaultDataViewReferencingTypes when empty).
+// The new data view must exist. Objects are updated in batches with the version they were read at, so
+// an object changed in the meantime is reported as a conflict instead of being overwritten. With dryRun
+// the objects that would be migrated are only listed.
+func (c *Client) MigrateDataViewReferences(ctx context.Context, oldDataViewID, newDataViewID string, types []string, dryRun bool) (*DataViewMigration, error) {
+	logrus.WithFields(logrus.Fields{
+		"old_data_view_id": oldDataViewID,
+		"new_data_view_id": newDataViewID,
+		"types":            types,
+		"dry_run":          dryRun,
+	}).Debug("Migrating Kibana data view references")
+
+	if oldDataViewID == "" || newDataViewID == "" {
+		return nil, fmt.Errorf("old and new data view IDs are required")
+	}
+	if oldDataViewID == newDataViewID {
+		return nil, fmt.Errorf("old and new data view IDs must differ")
+	}
+	target, err := c.BulkGetSavedObjects(ctx, []SavedObject{{Type: dataViewSavedObjectType, ID: newDataViewID}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check new data view %s: %w", newDataViewID, err)
+	}
+	if len(target) != 1 || target[0].Error != nil {
+		return nil, fmt.Errorf("new data view %s does not exist", newDataViewID)
+	}
+	if len(types) == 0 {
+		types = DefaultDataViewReferencingTypes
+	}
+
+	result := &DataViewMigration{
+		OldDataViewID: oldDataViewID,
+		NewDataViewID: newDataViewID,
+		DryRun:        dryRun,
+		Objects:       []DataViewMigrationObject{},
+	}
+
+	var updates []SavedObjectUpdate
+	for _, objectType := range types {
+		found, err := c.findObjectsReferencing(ctx, objectType, dataViewSavedObjectType, oldDataViewID)
+		if skipUnsupportedType(err, objectType) {
+			result.SkippedTypes = append(result.SkippedTypes, objectType)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s objects referencing data view %s: %w", objectType, oldDataViewID, err)
+		}
+		for _, obj := range found {
+			references, rewritten := repointReferences(obj.References, oldDataViewID, newDataViewID)
+			if len(rewritten) == 0 {
+				continue
+			}
+			result.Objects = append(result.Objects, DataViewMigrationObject{
+				Type:       obj.Type,
+				ID:         obj.ID,
+				Title:      getStringField(obj.Attributes, "title"),
+				References: rewritten,
+			})
+			updates = append(updates, SavedObjectUpdate{
+				Type:       obj.Type,
+				ID:         obj.ID,
+				References: references,
+				Version:    obj.Version,
+			})
+		}
+	}
+	result.Found = len(result.Objects)
+	if dryRun {
+		return result, nil
+	}
+
+	for start := 0; start < len(updates); start += dataViewMigrateBatchSize {
+		end := min(start+dataViewMigrateBatchSize, len(updates))
+		outcomes, err := c.BulkUpdateSavedObjects(ctx, updates[start:end])
+		if err != nil {
+			return result, fmt.Errorf("failed to update objects %d-%d: %w", start+1, end, err)
+		}
+		for i, outcome := range outcomes {
+			if start+i >= len(result.Objects) {
+				break
+			}
+			object := &result.Objects[start+i]
+			if outcome.Error != nil {
+				object.Error = outcome.Error.Message
+				object.Conflict = outcome.Conflict()
+				result.Failed++
+				continue
+			}
+			result.Migrated++
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"found":    result.Found,
+		"migrated": result.Migrated,
+		"failed":   result.Failed,
+	}).Debug("Migrated Kibana data view references")
+	return result, nil
+}
+
+// findObjectsReferencing pages through _find and returns every object of objectType referencing the
+// object refType/refID
+func (c *Client) findObjectsReferencing(ctx context.Context, objectType, refType, refID string) ([]SavedObject, error) {
+	hasReference, err := json.Marshal(savedObjectRef{Type: refType, ID: refID})
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []SavedObject
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("type", objectType)
+		params.Set("has_reference", string(hasReference))
+		params.Set("page", strconv.Itoa(page))
+		params.Set("per_page", strconv.Itoa(dataViewMigrateFindPageSize))
+
+		resp, err := c.makeRequest(ctx, "GET", "saved_objects/_find?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.handleFindResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		var result SearchResult
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal saved objects: %w", err)
+		}
+		objects = append(objects, result.SavedObjects...)
+		if len(result.SavedObjects) == 0 || len(objects) >= result.Total {
+			return objects, nil
+		}
+	}
+}
+
+// repointReferences returns a copy of references with the data view references to oldID pointing to
+// newID, and the names of the references it rewrote
+func repointReferences(references []Reference, oldID, newID string) ([]Reference, []string) {
+	repointed := make([]Reference, len(references))
+	var rewritten []string
+	for i, ref := range references {
+		if ref.Type == dataViewSavedObjectType && ref.ID == oldID {
+			ref.ID = newID
+			rewritten = append(rewritten, ref.Name)
+		}
+		repointed[i] = ref
+	}
+	return repointed, rewritten
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrateDataViewReferences(t *testing.T) {
+	var updates []SavedObjectUpdate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/saved_objects/_bulk_get":
+			_, _ = w.Write([]byte(`{"saved_objects":[{"id":"logs-new","type":"index-pattern","attributes":{"title":"logs-*"}}]}`))
+		case "/api/saved_objects/_find":
+			query := r.URL.Query()
+			if query.Get("has_reference") != `{"type":"index-pattern","id":"logs-old"}` {
+				t.Fatalf("unexpected has_reference %q", query.Get("has_reference"))
+			}
+			switch query.Get("type") {
+			case "visualization":
+				_, _ = w.Write([]byte(`{"page":1,"per_page":1000,"total":2,"saved_objects":[
+					{"id":"v1","type":"visualization","version":"WzEsMV0=","attributes":{"title":"Errors"},"references":[
+						{"name":"kibanaSavedObjectMeta.searchSourceJSON.index","type":"index-pattern","id":"logs-old"},
+						{"name":"tag-ref","type":"tag","id":"logs-old"}]},
+					{"id":"v2","type":"visualization","version":"WzIsMV0=","attributes":{"title":"Latency"},"references":[
+						{"name":"kibanaSavedObjectMeta.searchSourceJSON.index","type":"index-pattern","id":"logs-old"}]}]}`))
+			case "map":
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"statusCode":400,"error":"Bad Request","message":"Unsupported saved object type(s): map: Bad Request"}`))
+			default:
+				_, _ = w.Write([]byte(`{"page":1,"per_page":1000,"total":0,"saved_objects":[]}`))
+			}
+		case "/api/saved_objects/_bulk_update":
+			if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+				t.Fatalf("failed to decode bulk update: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"saved_objects":[
+				{"id":"v1","type":"visualization","version":"WzMsMV0="},
+				{"id":"v2","type":"visualization","error":{"statusCode":409,"error":"Conflict","message":"version conflict"}}]}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second, MaxRetries: 1})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	preview, err := client.MigrateDataViewReferences(context.Background(), "logs-old", "logs-new", nil, true)
+	if err != nil {
+		t.Fatalf("MigrateDataViewReferences() dry run error = %v", err)
+	}
+	if preview.Found != 2 || preview.Migrated != 0 || updates != nil {
+		t.Fatalf("expected a preview of 2 objects without updates, got %+v", preview)
+	}
+	if len(preview.SkippedTypes) != 1 || preview.SkippedTypes[0] != "map" {
+		t.Fatalf("expected the unsupported type to be skipped, got %v", preview.SkippedTypes)
+	}
+
+	result, err := client.MigrateDataViewReferences(context.Background(), "logs-old", "logs-new", nil, false)
+	if err != nil {
+		t.Fatalf("MigrateDataViewReferences() error = %v", err)
+	}
+	if result.Migrated != 1 || result.Failed != 1 || !result.Objects[1].Conflict {
+		t.Fatalf("expected one migrated object and one conflict, got %+v", result)
+	}
+	if len(updates) != 2 || updates[0].Version != "WzEsMV0=" {
+		t.Fatalf("expected versioned updates, got %+v", updates)
+	}
+	refs := updates[0].References
+	if refs[0].ID != "logs-new" || refs[1].ID != "logs-old" {
+		t.Fatalf("expected only the data view reference to be repointed, got %+v", refs)
+	}
+
+	if _, err := client.MigrateDataViewReferences(context.Background(), "logs-old", "logs-old", nil, true); err == nil {
+		t.Fatal("expected identical data view IDs to be rejected")
+	}
+}
+
+func TestMigrateDataViewReferencesReturnsFindErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/saved_objects/_bulk_get":
+			_, _ = w.Write([]byte(`{"saved_objects":[{"id":"logs-new","type":"index-pattern","attributes":{"title":"logs-*"}}]}`))
+		case "/api/saved_objects/_find":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Unable to find visualization"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second, MaxRetries: 1})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	result, err := client.MigrateDataViewReferences(context.Background(), "logs-old", "logs-new", []string{"visualization"}, true)
+	if err == nil {
+		t.Fatalf("expected a forbidden _find to fail the migration, got %+v", result)
+	}
+	if !strings.Contains(err.Error(), "status 403") {
+		t.Fatalf("expected the 403 to be surfaced, got %v", err)
+	}
+}
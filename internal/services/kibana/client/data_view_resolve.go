@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dataViewSavedObjectType is the saved object type data views are stored as
+	dataViewSavedObjectType = "index-pattern"
+	// dataViewResolvePageSize bounds the candidates returned by one title search
+	dataViewResolvePageSize = 100
+	// dataViewSearchSourceRefName is the reference name Kibana uses for the data view of a visualization
+	dataViewSearchSourceRefName = "kibanaSavedObjectMeta.searchSourceJSON.index"
+)
+
+// ResolveDataViewByTitle returns the ID of the data view whose title is exactly title. Titles are not
+// unique in Kibana, so it fails when no data view or more than one data view carries the title.
+func (c *Client) ResolveDataViewByTitle(ctx context.Context, title string) (string, error) {
+	logrus.WithField("title", title).Debug("Resolving data view by title")
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", fmt.Errorf("data view title is required")
+	}
+
+	params := url.Values{}
+	params.Set("type", dataViewSavedObjectType)
+	params.Set("search_fields", "title")
+	params.Set("search", strconv.Quote(title))
+	params.Set("fields", "title")
+	params.Set("per_page", strconv.Itoa(dataViewResolvePageSize))
+
+	resp, err := c.makeRequest(ctx, "GET", "saved_objects/_find?"+params.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	var result SearchResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal data view search: %w", err)
+	}
+
+	// The search is analyzed and may return near matches; only an exact title counts
+	var ids []string
+	for _, obj := range result.SavedObjects {
+		if getStringField(obj.Attributes, "title") == title {
+			ids = append(ids, obj.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no data view found with title %q", title)
+	case 1:
+		logrus.WithFields(logrus.Fields{"title": title, "data_view_id": ids[0]}).Debug("Resolved data view by title")
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d data views share the title %q (%s); pass the data view ID instead", len(ids), title, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveDataViewByTitle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/saved_objects/_find":
+			query := r.URL.Query()
+			if query.Get("type") != "index-pattern" || query.Get("search_fields") != "title" {
+				t.Fatalf("unexpected find query: %s", r.URL.RawQuery)
+			}
+			// Simulate an analyzed search that also returns near matches
+			_, _ = w.Write([]byte(`{"saved_objects":[
+				{"id":"logs-id","type":"index-pattern","attributes":{"title":"logs-*"}},
+				{"id":"logs-app-id","type":"index-pattern","attributes":{"title":"logs-app-*"}},
+				{"id":"metrics-a","type":"index-pattern","attributes":{"title":"metrics-*"}},
+				{"id":"metrics-b","type":"index-pattern","attributes":{"title":"metrics-*"}}
+			]}`))
+		case "/api/saved_objects/visualization":
+			var obj SavedObject
+			if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+				t.Fatalf("failed to decode visualization: %v", err)
+			}
+			if len(obj.References) != 1 || obj.References[0].ID != "logs-id" || obj.References[0].Type != "index-pattern" {
+				t.Fatalf("expected a data view reference, got %+v", obj.References)
+			}
+			meta, _ := obj.Attributes["kibanaSavedObjectMeta"].(map[string]interface{})
+			if !strings.Contains(getStringField(meta, "searchSourceJSON"), obj.References[0].Name) {
+				t.Fatalf("expected searchSourceJSON to point at the reference, got %+v", obj.Attributes)
+			}
+			_, _ = w.Write([]byte(`{"id":"vis-1","type":"visualization","attributes":{"title":"Errors"}}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	id, err := client.ResolveDataViewByTitle(context.Background(), "logs-*")
+	if err != nil || id != "logs-id" {
+		t.Fatalf("ResolveDataViewByTitle() = %q, %v", id, err)
+	}
+	if _, err := client.ResolveDataViewByTitle(context.Background(), "metrics-*"); err == nil || !strings.Contains(err.Error(), "metrics-a, metrics-b") {
+		t.Fatalf("expected an ambiguity error listing both IDs, got %v", err)
+	}
+	if _, err := client.ResolveDataViewByTitle(context.Background(), "traces-*"); err == nil {
+		t.Fatal("expected an error for an unknown title")
+	}
+
+	if _, err := client.CreateVisualization(context.Background(), "Errors", nil, "", "", id); err != nil {
+		t.Fatalf("CreateVisualization() error = %v", err)
+	}
+}
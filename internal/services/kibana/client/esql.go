@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ES|QL became available (as technical preview) in Elastic Stack 8.11
+const (
+	esqlMinMajorVersion = 8
+	esqlMinMinorVersion = 11
+)
+
+// ESQLColumn describes a column of an ES|QL result.
+type ESQLColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ESQLResult represents an ES|QL query result in tabular form.
+type ESQLResult struct {
+	Columns  []ESQLColumn    `json:"columns"`
+	Rows     [][]interface{} `json:"rows"`
+	RowCount int             `json:"rowCount"`
+	Took     int64           `json:"took"`
+	Version  string          `json:"version,omitempty"`
+}
+
+// QueryESQL runs an ES|QL query through the Kibana console proxy to the Elasticsearch _query API.
+// The stack version is checked first so that older clusters get a clear error instead of a 404.
+func (c *Client) QueryESQL(ctx context.Context, query string) (*ESQLResult, error) {
+	logrus.WithField("query", query).Debug("Running ES|QL query through Kibana")
+
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("ES|QL query is required")
+	}
+
+	version := ""
+	if status, err := c.GetKibanaStatus(ctx); err != nil {
+		logrus.WithError(err).Debug("Failed to get Kibana status, skipping ES|QL version check")
+	} else {
+		version = kibanaVersionNumber(status)
+		if supported, known := supportsESQL(version); known && !supported {
+			return nil, fmt.Errorf("ES|QL requires Elastic Stack %d.%d or later, but Kibana reports version %s; use kibana_query_logs instead",
+				esqlMinMajorVersion, esqlMinMinorVersion, version)
+		}
+	}
+
+	resp, err := c.elasticsearchRequest(ctx, "POST", "_query", map[string]interface{}{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		Took    int64           `json:"took"`
+		Columns []ESQLColumn    `json:"columns"`
+		Values  [][]interface{} `json:"values"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ES|QL result: %w", err)
+	}
+
+	result := &ESQLResult{
+		Columns:  raw.Columns,
+		Rows:     raw.Values,
+		RowCount: len(raw.Values),
+		Took:     raw.Took,
+		Version:  version,
+	}
+	if result.Columns == nil {
+		result.Columns = []ESQLColumn{}
+	}
+	if result.Rows == nil {
+		result.Rows = [][]interface{}{}
+	}
+
+	logrus.WithField("rows", result.RowCount).Debug("ES|QL query completed")
+	return result, nil
+}
+
+// kibanaVersionNumber returns the version.number reported by the Kibana status API
+func kibanaVersionNumber(status *KibanaStatus) string {
+	if status == nil {
+		return ""
+	}
+	number, _ := status.Version["number"].(string)
+	return number
+}
+
+// supportsESQL reports whether a stack version supports ES|QL. known is false when the
+// version cannot be parsed, in which case callers should let the query through.
+func supportsESQL(version string) (supported bool, known bool) {
+	return versionAtLeast(version, map[int]int{esqlMinMajorVersion: esqlMinMinorVersion})
+}
+
+// versionAtLeast reports whether a stack version reaches the minimum minor version listed for its
+// major. Majors above every listed one are supported and majors below are not, which lets a feature
+// backported to an older line list one minimum per line. known is false when the version cannot be
+// parsed.
+func versionAtLeast(version string, minMinors map[int]int) (supported bool, known bool) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false, false
+	}
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return false, false
+	}
+	if minMinor, ok := minMinors[major]; ok {
+		return minor >= minMinor, true
+	}
+	for listed := range minMinors {
+		if listed > major {
+			return false, true
+		}
+	}
+	return true, true
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newESQLTestServer(t *testing.T, version string, queried *bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/status":
+			_, _ = w.Write([]byte(`{"version":{"number":"` + version + `"}}`))
+		case "/api/console/proxy":
+			*queried = true
+			if r.URL.Query().Get("path") != "_query" || r.URL.Query().Get("method") != "POST" {
+				t.Fatalf("unexpected proxy target: %s", r.URL.RawQuery)
+			}
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if body["query"] != "FROM logs-* | LIMIT 2" {
+				t.Fatalf("unexpected query %q", body["query"])
+			}
+			_, _ = w.Write([]byte(`{"took":7,"columns":[{"name":"host","type":"keyword"},{"name":"count","type":"long"}],"values":[["a",1],["b",2]]}`))
+		default:
+			t.Fatalf("unexpected request path %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestQueryESQL(t *testing.T) {
+	queried := false
+	server := newESQLTestServer(t, "8.15.1", &queried)
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	result, err := client.QueryESQL(context.Background(), "FROM logs-* | LIMIT 2")
+	if err != nil {
+		t.Fatalf("QueryESQL() error = %v", err)
+	}
+	if !queried {
+		t.Fatal("expected the console proxy to be called")
+	}
+	if result.RowCount != 2 || result.Took != 7 || result.Version != "8.15.1" {
+		t.Fatalf("unexpected result metadata: %+v", result)
+	}
+	if len(result.Columns) != 2 || result.Columns[1].Name != "count" || result.Columns[1].Type != "long" {
+		t.Fatalf("unexpected columns: %+v", result.Columns)
+	}
+	if result.Rows[1][0] != "b" {
+		t.Fatalf("unexpected rows: %+v", result.Rows)
+	}
+}
+
+func TestQueryESQLRejectsOldStack(t *testing.T) {
+	queried := false
+	server := newESQLTestServer(t, "7.17.9", &queried)
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.QueryESQL(context.Background(), "FROM logs-* | LIMIT 2")
+	if err == nil || !strings.Contains(err.Error(), "8.11") {
+		t.Fatalf("expected version error, got %v", err)
+	}
+	if queried {
+		t.Fatal("query should not be sent to an unsupported stack")
+	}
+}
+
+func TestSupportsESQL(t *testing.T) {
+	tests := []struct {
+		version   string
+		supported bool
+		known     bool
+	}{
+		{"8.11.0", true, true},
+		{"8.10.4", false, true},
+		{"9.0.0", true, true},
+		{"7.17.9", false, true},
+		{"8.12-SNAPSHOT", true, true},
+		{"", false, false},
+		{"unknown", false, false},
+	}
+	for _, tt := range tests {
+		supported, known := supportsESQL(tt.version)
+		if supported != tt.supported || known != tt.known {
+			t.Errorf("supportsESQL(%q) = (%v, %v), want (%v, %v)", tt.version, supported, known, tt.supported, tt.known)
+		}
+	}
+}
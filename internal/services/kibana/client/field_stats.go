@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultFieldStatsSize is the number of top values returned when no size is given
+	DefaultFieldStatsSize = 10
+	// MaxFieldStatsSize bounds the terms aggregation so a high-cardinality field stays cheap
+	MaxFieldStatsSize = 50
+)
+
+// FieldValueCount is one of the most frequent values of a field.
+type FieldValueCount struct {
+	Value interface{} `json:"value"`
+	Count int64       `json:"count"`
+}
+
+// FieldStats describes how a field is populated across an index pattern.
+type FieldStats struct {
+	IndexPattern  string            `json:"indexPattern"`
+	Field         string            `json:"field"`
+	Exists        bool              `json:"exists"`
+	TotalDocs     int64             `json:"totalDocs"`
+	DocsWithField int64             `json:"docsWithField"`
+	DistinctCount int64             `json:"distinctCount"`
+	TopValues     []FieldValueCount `json:"topValues"`
+	OtherCount    int64             `json:"otherCount"`
+	Note          string            `json:"note,omitempty"`
+}
+
+// ClampFieldStatsSize bounds the number of top values to 1..MaxFieldStatsSize, defaulting when unset.
+func ClampFieldStatsSize(size int) int {
+	if size <= 0 {
+		return DefaultFieldStatsSize
+	}
+	if size > MaxFieldStatsSize {
+		return MaxFieldStatsSize
+	}
+	return size
+}
+
+// GetFieldStats reports how many documents of an index pattern have a field, its approximate
+// distinct count and its most frequent values, using exists, cardinality and terms aggregations.
+func (c *Client) GetFieldStats(ctx context.Context, indexPattern, field string, size int) (*FieldStats, error) {
+	if indexPattern == "" {
+		return nil, fmt.Errorf("index pattern is required")
+	}
+	if field == "" {
+		return nil, fmt.Errorf("field is required")
+	}
+	size = ClampFieldStatsSize(size)
+
+	logrus.WithFields(logrus.Fields{
+		"indexPattern": indexPattern,
+		"field":        field,
+		"size":         size,
+	}).Debug("Getting field stats")
+
+	searchBody := map[string]interface{}{
+		"size":             0,
+		"track_total_hits": true,
+		"aggs": map[string]interface{}{
+			"with_field": map[string]interface{}{
+				"filter": map[string]interface{}{
+					"exists": map[string]interface{}{"field": field},
+				},
+			},
+			"distinct": map[string]interface{}{
+				"cardinality": map[string]interface{}{"field": field},
+			},
+			"top_values": map[string]interface{}{
+				"terms": map[string]interface{}{"field": field, "size": size},
+			},
+		},
+	}
+
+	resp, err := c.elasticsearchRequest(ctx, "POST", indexPattern+"/_search", searchBody)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "fielddata") {
+			return nil, fmt.Errorf("%s is a text field that cannot be aggregated; try %s.keyword: %w", field, field, err)
+		}
+		return nil, err
+	}
+
+	var result struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+		} `json:"hits"`
+		Aggregations struct {
+			WithField struct {
+				DocCount int64 `json:"doc_count"`
+			} `json:"with_field"`
+			Distinct struct {
+				Value int64 `json:"value"`
+			} `json:"distinct"`
+			TopValues struct {
+				SumOtherDocCount int64 `json:"sum_other_doc_count"`
+				Buckets          []struct {
+					Key         interface{} `json:"key"`
+					KeyAsString string      `json:"key_as_string"`
+					DocCount    int64       `json:"doc_count"`
+				} `json:"buckets"`
+			} `json:"top_values"`
+		} `json:"aggregations"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal field stats: %w", err)
+	}
+
+	stats := &FieldStats{
+		IndexPattern:  indexPattern,
+		Field:         field,
+		Exists:        result.Aggregations.WithField.DocCount > 0,
+		TotalDocs:     result.Hits.Total.Value,
+		DocsWithField: result.Aggregations.WithField.DocCount,
+		DistinctCount: result.Aggregations.Distinct.Value,
+		TopValues:     make([]FieldValueCount, 0, len(result.Aggregations.TopValues.Buckets)),
+		OtherCount:    result.Aggregations.TopValues.SumOtherDocCount,
+	}
+	for _, bucket := range result.Aggregations.TopValues.Buckets {
+		value := bucket.Key
+		// Dates and booleans come back as numbers; their string form is the readable one
+		if bucket.KeyAsString != "" {
+			value = bucket.KeyAsString
+		}
+		stats.TopValues = append(stats.TopValues, FieldValueCount{Value: value, Count: bucket.DocCount})
+	}
+	if !stats.Exists {
+		stats.Note = "no document in the index pattern has this field; check the name with kibana_get_index_pattern_fields"
+	} else if stats.DistinctCount > 0 {
+		stats.Note = "distinctCount is approximate (cardinality aggregation)"
+	}
+
+	logrus.WithFields(logrus.Fields{"field": field, "distinct": stats.DistinctCount}).Debug("Retrieved field stats")
+	return stats, nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetFieldStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/console/proxy" || r.URL.Query().Get("path") != "logs-*/_search" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.String())
+		}
+		var body struct {
+			Aggs struct {
+				TopValues struct {
+					Terms struct {
+						Field string `json:"field"`
+						Size  int    `json:"size"`
+					} `json:"terms"`
+				} `json:"top_values"`
+			} `json:"aggs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode search body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch body.Aggs.TopValues.Terms.Field {
+		case "level":
+			if body.Aggs.TopValues.Terms.Size != MaxFieldStatsSize {
+				t.Fatalf("expected the terms size to be bounded, got %d", body.Aggs.TopValues.Terms.Size)
+			}
+			_, _ = w.Write([]byte(`{"hits":{"total":{"value":120}},"aggregations":{
+				"with_field":{"doc_count":100},
+				"distinct":{"value":3},
+				"top_values":{"sum_other_doc_count":5,"buckets":[{"key":"info","doc_count":80},{"key":"error","doc_count":15}]}}}`))
+		case "message":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"type":"illegal_argument_exception","reason":"Text fields are not optimised for operations that require per-document field data... Fielddata is disabled on [message]"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"hits":{"total":{"value":120}},"aggregations":{"with_field":{"doc_count":0},"distinct":{"value":0},"top_values":{"buckets":[]}}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{
+		URL:            server.URL,
+		Timeout:        2 * time.Second,
+		MaxRetries:     1,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	stats, err := client.GetFieldStats(ctx, "logs-*", "level", 500)
+	if err != nil {
+		t.Fatalf("GetFieldStats() error = %v", err)
+	}
+	if !stats.Exists || stats.TotalDocs != 120 || stats.DocsWithField != 100 || stats.DistinctCount != 3 || stats.OtherCount != 5 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if len(stats.TopValues) != 2 || stats.TopValues[0].Value != "info" || stats.TopValues[0].Count != 80 {
+		t.Fatalf("unexpected top values: %+v", stats.TopValues)
+	}
+
+	stats, err = client.GetFieldStats(ctx, "logs-*", "lvl", 0)
+	if err != nil {
+		t.Fatalf("GetFieldStats() error = %v", err)
+	}
+	if stats.Exists || !strings.Contains(stats.Note, "no document") {
+		t.Fatalf("expected a missing field to be reported, got %+v", stats)
+	}
+
+	if _, err := client.GetFieldStats(ctx, "logs-*", "message", 10); err == nil || !strings.Contains(err.Error(), "message.keyword") {
+		t.Fatalf("expected a keyword hint for a text field, got %v", err)
+	}
+}
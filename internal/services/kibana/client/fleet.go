@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FleetAgent summarizes an Elastic Agent enrolled in Fleet.
+type FleetAgent struct {
+	ID                string   `json:"id"`
+	Hostname          string   `json:"hostname,omitempty"`
+	Status            string   `json:"status"`
+	Active            bool     `json:"active"`
+	LastCheckin       string   `json:"lastCheckin,omitempty"`
+	LastCheckinStatus string   `json:"lastCheckinStatus,omitempty"`
+	UnhealthyReason   []string `json:"unhealthyReason,omitempty"`
+	PolicyID          string   `json:"policyId,omitempty"`
+	PolicyName        string   `json:"policyName,omitempty"`
+	PolicyRevision    int      `json:"policyRevision,omitempty"`
+	Version           string   `json:"version,omitempty"`
+}
+
+// FleetAgentList is a page of Fleet agents.
+type FleetAgentList struct {
+	Page    int          `json:"page"`
+	PerPage int          `json:"perPage"`
+	Total   int          `json:"total"`
+	Agents  []FleetAgent `json:"agents"`
+}
+
+// FleetAgentPolicy summarizes a Fleet agent policy.
+type FleetAgentPolicy struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Revision  int    `json:"revision"`
+	Agents    int    `json:"agents"`
+	IsManaged bool   `json:"isManaged,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
+type fleetAgentResponse struct {
+	ID                string   `json:"id"`
+	Active            bool     `json:"active"`
+	Status            string   `json:"status"`
+	LastCheckin       string   `json:"last_checkin"`
+	LastCheckinStatus string   `json:"last_checkin_status"`
+	UnhealthyReason   []string `json:"unhealthy_reason"`
+	PolicyID          string   `json:"policy_id"`
+	PolicyRevision    int      `json:"policy_revision"`
+	LocalMetadata     struct {
+		Host struct {
+			Hostname string `json:"hostname"`
+		} `json:"host"`
+		Elastic struct {
+			Agent struct {
+				Version string `json:"version"`
+			} `json:"agent"`
+		} `json:"elastic"`
+	} `json:"local_metadata"`
+}
+
+// fleetRequest performs a request against the Fleet API, which some deployments serve from a
+// separate base URL (configured with FleetURL) instead of the Kibana URL.
+func (c *Client) fleetRequest(ctx context.Context, method, endpoint string) (*http.Response, error) {
+	baseURL := c.baseURL
+	if c.fleetBaseURL != "" {
+		baseURL = c.fleetBaseURL
+	}
+	return c.makeRequestWithBase(ctx, baseURL, method, "fleet/"+endpoint, nil)
+}
+
+// GetFleetAgents retrieves Fleet agents with pagination, optionally filtered by a KQL kuery.
+func (c *Client) GetFleetAgents(ctx context.Context, page, perPage int, kuery string) (*FleetAgentList, error) {
+	logrus.WithFields(logrus.Fields{
+		"page":    page,
+		"perPage": perPage,
+		"kuery":   kuery,
+	}).Debug("Getting Fleet agents")
+
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("perPage", fmt.Sprintf("%d", perPage))
+	if kuery != "" {
+		params.Set("kuery", kuery)
+	}
+
+	resp, err := c.fleetRequest(ctx, "GET", "agents?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items   []fleetAgentResponse `json:"items"`
+		List    []fleetAgentResponse `json:"list"` // Fleet < 8.0
+		Total   int                  `json:"total"`
+		Page    int                  `json:"page"`
+		PerPage int                  `json:"perPage"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Fleet agents: %w", err)
+	}
+	items := result.Items
+	if items == nil {
+		items = result.List
+	}
+
+	list := &FleetAgentList{
+		Page:    result.Page,
+		PerPage: result.PerPage,
+		Total:   result.Total,
+		Agents:  make([]FleetAgent, 0, len(items)),
+	}
+	for _, item := range items {
+		list.Agents = append(list.Agents, FleetAgent{
+			ID:                item.ID,
+			Hostname:          item.LocalMetadata.Host.Hostname,
+			Status:            item.Status,
+			Active:            item.Active,
+			LastCheckin:       item.LastCheckin,
+			LastCheckinStatus: item.LastCheckinStatus,
+			UnhealthyReason:   item.UnhealthyReason,
+			PolicyID:          item.PolicyID,
+			PolicyRevision:    item.PolicyRevision,
+			Version:           item.LocalMetadata.Elastic.Agent.Version,
+		})
+	}
+
+	logrus.WithField("count", len(list.Agents)).Debug("Retrieved Fleet agents")
+	return list, nil
+}
+
+// GetFleetAgentPolicies retrieves all Fleet agent policies.
+func (c *Client) GetFleetAgentPolicies(ctx context.Context) ([]FleetAgentPolicy, error) {
+	logrus.Debug("Getting Fleet agent policies")
+
+	resp, err := c.fleetRequest(ctx, "GET", "agent_policies?perPage=1000")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []struct {
+			ID        string `json:"id"`
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+			Revision  int    `json:"revision"`
+			Agents    int    `json:"agents"`
+			IsManaged bool   `json:"is_managed"`
+			Status    string `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Fleet agent policies: %w", err)
+	}
+
+	policies := make([]FleetAgentPolicy, 0, len(result.Items))
+	for _, item := range result.Items {
+		policies = append(policies, FleetAgentPolicy{
+			ID:        item.ID,
+			Name:      item.Name,
+			Namespace: item.Namespace,
+			Revision:  item.Revision,
+			Agents:    item.Agents,
+			IsManaged: item.IsManaged,
+			Status:    item.Status,
+		})
+	}
+
+	logrus.WithField("count", len(policies)).Debug("Retrieved Fleet agent policies")
+	return policies, nil
+}
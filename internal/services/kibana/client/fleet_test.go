@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetFleetAgents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/fleet/agents" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("kuery"); got != "status:offline" {
+			t.Fatalf("unexpected kuery %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"id":"a1","active":true,"status":"offline","last_checkin":"2024-05-01T10:00:00Z","policy_id":"p1","policy_revision":3,"local_metadata":{"host":{"hostname":"web-1"},"elastic":{"agent":{"version":"8.13.2"}}}}],"total":1,"page":1,"perPage":20}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	list, err := client.GetFleetAgents(context.Background(), 1, 20, "status:offline")
+	if err != nil {
+		t.Fatalf("GetFleetAgents() error = %v", err)
+	}
+	if list.Total != 1 || len(list.Agents) != 1 {
+		t.Fatalf("unexpected agent list: %+v", list)
+	}
+	agent := list.Agents[0]
+	if agent.Hostname != "web-1" || agent.Status != "offline" || agent.PolicyID != "p1" || agent.Version != "8.13.2" {
+		t.Fatalf("unexpected agent: %+v", agent)
+	}
+}
+
+func TestGetFleetAgentPoliciesUsesFleetURL(t *testing.T) {
+	kibana := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("Fleet request sent to Kibana URL: %s", r.URL.Path)
+	}))
+	defer kibana.Close()
+
+	fleet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fleet-proxy/api/fleet/agent_policies" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("kbn-xsrf") == "" {
+			t.Fatal("expected kbn-xsrf header on Fleet requests")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"id":"p1","name":"Default policy","namespace":"default","revision":3,"agents":12}],"total":1}`))
+	}))
+	defer fleet.Close()
+
+	client, err := NewClient(&ClientOptions{URL: kibana.URL, FleetURL: fleet.URL + "/fleet-proxy", Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	policies, err := client.GetFleetAgentPolicies(context.Background())
+	if err != nil {
+		t.Fatalf("GetFleetAgentPolicies() error = %v", err)
+	}
+	if len(policies) != 1 || policies[0].Name != "Default policy" || policies[0].Agents != 12 {
+		t.Fatalf("unexpected policies: %+v", policies)
+	}
+}
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Normalized component health states used by the deep health summary, from best to worst.
+const (
+	HealthOK          = "ok"
+	HealthUnknown     = "unknown"
+	HealthDegraded    = "degraded"
+	HealthUnavailable = "unavailable"
+)
+
+var healthSeverity = map[string]int{
+	HealthOK:          0,
+	HealthUnknown:     1,
+	HealthDegraded:    1,
+	HealthUnavailable: 2,
+}
+
+// HealthComponent is the health of one component checked by the deep health summary.
+// Status is normalized to ok/degraded/unavailable/unknown; ReportedAs keeps the component's own value.
+type HealthComponent struct {
+	Status     string                 `json:"status"`
+	ReportedAs string                 `json:"reportedAs,omitempty"`
+	Summary    string                 `json:"summary,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	CheckedVia string                 `json:"checkedVia"`
+}
+
+// normalizeHealth maps the status vocabularies of Kibana (available/degraded/unavailable/critical),
+// task manager and alerting (OK/warn/error) and Elasticsearch (green/yellow/red) to one scale
+func normalizeHealth(reported string) string {
+	switch strings.ToLower(strings.TrimSpace(reported)) {
+	case "ok", "available", "green":
+		return HealthOK
+	case "warn", "warning", "degraded", "yellow":
+		return HealthDegraded
+	case "error", "unavailable", "critical", "red":
+		return HealthUnavailable
+	default:
+		return HealthUnknown
+	}
+}
+
+// worseHealth returns the worse of two normalized states
+func worseHealth(a, b string) string {
+	if healthSeverity[b] > healthSeverity[a] {
+		return b
+	}
+	return a
+}
+
+// deepHealthComponents probes the Kibana core, task manager, alerting and actions frameworks and
+// the Elasticsearch cluster. A component whose check fails is reported as unknown with the error.
+func (c *Client) deepHealthComponents(ctx context.Context, status *KibanaStatus) map[string]*HealthComponent {
+	return map[string]*HealthComponent{
+		"kibana":        kibanaCoreHealth(status),
+		"taskManager":   c.taskManagerHealth(ctx),
+		"alerting":      c.alertingHealth(ctx, status),
+		"actions":       pluginHealth(status, "actions"),
+		"elasticsearch": c.elasticsearchClusterHealth(ctx),
+	}
+}
+
+// overallHealth returns the worst component state and the names of components that are not ok
+func overallHealth(components map[string]*HealthComponent) (string, []string) {
+	overall := HealthOK
+	issues := []string{}
+	for name, component := range components {
+		overall = worseHealth(overall, component.Status)
+		if component.Status != HealthOK {
+			issues = append(issues, name)
+		}
+	}
+	sort.Strings(issues)
+	return overall, issues
+}
+
+// kibanaCoreHealth reads the overall level from the status API, falling back to the legacy state field
+func kibanaCoreHealth(status *KibanaStatus) *HealthComponent {
+	component := &HealthComponent{CheckedVia: "status"}
+	if overall, ok := status.Status["overall"].(map[string]interface{}); ok {
+		level, _ := overall["level"].(string)
+		if level == "" {
+			level, _ = overall["state"].(string)
+		}
+		component.ReportedAs = level
+		component.Summary, _ = overall["summary"].(string)
+	} else {
+		component.ReportedAs = status.State
+	}
+	component.Status = normalizeHealth(component.ReportedAs)
+	return component
+}
+
+// pluginHealth reads a plugin's level from the status API
+func pluginHealth(status *KibanaStatus, plugin string) *HealthComponent {
+	component := &HealthComponent{Status: HealthUnknown, CheckedVia: "status"}
+	plugins, _ := status.Status["plugins"].(map[string]interface{})
+	entry, ok := plugins[plugin].(map[string]interface{})
+	if !ok {
+		component.Summary = fmt.Sprintf("plugin %s not reported by the status API", plugin)
+		return component
+	}
+	component.ReportedAs, _ = entry["level"].(string)
+	component.Summary, _ = entry["summary"].(string)
+	component.Status = normalizeHealth(component.ReportedAs)
+	return component
+}
+
+// taskManagerHealth summarizes api/task_manager/_health
+func (c *Client) taskManagerHealth(ctx context.Context) *HealthComponent {
+	component := &HealthComponent{Status: HealthUnknown, CheckedVia: "task_manager/_health"}
+
+	var result struct {
+		Status     string `json:"status"`
+		LastUpdate string `json:"last_update"`
+		Stats      map[string]struct {
+			Status string `json:"status"`
+		} `json:"stats"`
+	}
+	if err := c.getHealthJSON(ctx, "task_manager/_health", &result); err != nil {
+		component.Error = err.Error()
+		return component
+	}
+
+	component.ReportedAs = result.Status
+	component.Status = normalizeHealth(result.Status)
+	details := map[string]interface{}{}
+	if result.LastUpdate != "" {
+		details["lastUpdate"] = result.LastUpdate
+	}
+	for name, stat := range result.Stats {
+		if stat.Status == "" {
+			continue
+		}
+		details[name] = stat.Status
+		component.Status = worseHealth(component.Status, normalizeHealth(stat.Status))
+	}
+	if len(details) > 0 {
+		component.Details = details
+	}
+	return component
+}
+
+// alertingHealth summarizes api/alerting/_health together with the alerting plugin level
+func (c *Client) alertingHealth(ctx context.Context, status *KibanaStatus) *HealthComponent {
+	component := pluginHealth(status, "alerting")
+	component.CheckedVia = "alerting/_health"
+
+	var result struct {
+		IsSufficientlySecure      bool `json:"is_sufficiently_secure"`
+		HasPermanentEncryptionKey bool `json:"has_permanent_encryption_key"`
+		FrameworkHealth           map[string]struct {
+			Status    string `json:"status"`
+			Timestamp string `json:"timestamp"`
+		} `json:"alerting_framework_health"`
+	}
+	if err := c.getHealthJSON(ctx, "alerting/_health", &result); err != nil {
+		component.Error = err.Error()
+		return component
+	}
+
+	details := map[string]interface{}{
+		"isSufficientlySecure":      result.IsSufficientlySecure,
+		"hasPermanentEncryptionKey": result.HasPermanentEncryptionKey,
+	}
+	frameworkStatus := HealthOK
+	for name, check := range result.FrameworkHealth {
+		details[name] = check.Status
+		frameworkStatus = worseHealth(frameworkStatus, normalizeHealth(check.Status))
+	}
+	if !result.IsSufficientlySecure || !result.HasPermanentEncryptionKey {
+		// Rules cannot run without security and a permanent encryption key
+		frameworkStatus = worseHealth(frameworkStatus, HealthDegraded)
+	}
+	component.Details = details
+
+	if component.Status == HealthUnknown {
+		component.Status = frameworkStatus
+	} else {
+		component.Status = worseHealth(component.Status, frameworkStatus)
+	}
+	return component
+}
+
+// elasticsearchClusterHealth reads _cluster/health through the console proxy
+func (c *Client) elasticsearchClusterHealth(ctx context.Context) *HealthComponent {
+	component := &HealthComponent{Status: HealthUnknown, CheckedVia: "console/proxy _cluster/health"}
+
+	resp, err := c.elasticsearchRequest(ctx, "GET", "_cluster/health", nil)
+	if err != nil {
+		component.Error = err.Error()
+		return component
+	}
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		component.Error = err.Error()
+		return component
+	}
+
+	var result struct {
+		ClusterName         string  `json:"cluster_name"`
+		Status              string  `json:"status"`
+		NumberOfNodes       int     `json:"number_of_nodes"`
+		UnassignedShards    int     `json:"unassigned_shards"`
+		ActiveShardsPercent float64 `json:"active_shards_percent_as_number"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		component.Error = fmt.Sprintf("failed to unmarshal cluster health: %v", err)
+		return component
+	}
+
+	component.ReportedAs = result.Status
+	component.Status = normalizeHealth(result.Status)
+	component.Details = map[string]interface{}{
+		"clusterName":         result.ClusterName,
+		"numberOfNodes":       result.NumberOfNodes,
+		"unassignedShards":    result.UnassignedShards,
+		"activeShardsPercent": result.ActiveShardsPercent,
+	}
+	return component
+}
+
+// getHealthJSON performs a GET against a Kibana health endpoint and decodes the response
+func (c *Client) getHealthJSON(ctx context.Context, endpoint string, out interface{}) error {
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", endpoint, err)
+	}
+	logrus.WithField("endpoint", endpoint).Debug("Retrieved Kibana health endpoint")
+	return nil
+}
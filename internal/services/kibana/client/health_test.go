@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetHealthSummaryDeep(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/status":
+			_, _ = w.Write([]byte(`{"version":{"number":"8.14.0"},"status":{
+				"overall":{"level":"available","summary":"All services are available"},
+				"plugins":{"alerting":{"level":"available"},"actions":{"level":"available"}}}}`))
+		case "/api/task_manager/_health":
+			_, _ = w.Write([]byte(`{"status":"OK","last_update":"2024-05-01T10:00:00Z",
+				"stats":{"configuration":{"status":"OK"},"workload":{"status":"OK"},"runtime":{"status":"warn"}}}`))
+		case "/api/alerting/_health":
+			_, _ = w.Write([]byte(`{"is_sufficiently_secure":true,"has_permanent_encryption_key":true,
+				"alerting_framework_health":{"decryption_health":{"status":"ok"},"execution_health":{"status":"ok"},"read_health":{"status":"ok"}}}`))
+		case "/api/console/proxy":
+			if r.URL.Query().Get("path") != "_cluster/health" || r.URL.Query().Get("method") != "GET" {
+				t.Fatalf("unexpected proxy target: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"cluster_name":"prod","status":"green","number_of_nodes":3,"unassigned_shards":0,"active_shards_percent_as_number":100}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	summary, err := client.GetHealthSummary(context.Background(), "deep", false)
+	if err != nil {
+		t.Fatalf("GetHealthSummary() error = %v", err)
+	}
+
+	components := summary["components"].(map[string]*HealthComponent)
+	if components["kibana"].Status != HealthOK || components["alerting"].Status != HealthOK || components["elasticsearch"].Status != HealthOK {
+		t.Fatalf("unexpected component statuses: %+v", components)
+	}
+	if components["taskManager"].Status != HealthDegraded {
+		t.Fatalf("expected task manager runtime warning to degrade it, got %+v", components["taskManager"])
+	}
+	if summary["overall"] != HealthDegraded || summary["healthy"] != false {
+		t.Fatalf("expected degraded overall health, got overall=%v healthy=%v", summary["overall"], summary["healthy"])
+	}
+	if issues := summary["issues"].([]string); len(issues) != 1 || issues[0] != "taskManager" {
+		t.Fatalf("unexpected issues: %v", issues)
+	}
+}
+
+func TestGetHealthSummaryDeepReportsUnreachableComponents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/status":
+			_, _ = w.Write([]byte(`{"status":{"overall":{"level":"available"},"plugins":{"actions":{"level":"available"}}}}`))
+		case "/api/console/proxy":
+			_, _ = w.Write([]byte(`{"status":"red"}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	summary, err := client.GetHealthSummary(context.Background(), "deep", false)
+	if err != nil {
+		t.Fatalf("GetHealthSummary() error = %v", err)
+	}
+
+	components := summary["components"].(map[string]*HealthComponent)
+	if components["taskManager"].Status != HealthUnknown || components["taskManager"].Error == "" {
+		t.Fatalf("expected unreachable task manager to be unknown with an error, got %+v", components["taskManager"])
+	}
+	if summary["overall"] != HealthUnavailable || summary["healthy"] != false {
+		t.Fatalf("expected red cluster to make Kibana unavailable, got %v", summary["overall"])
+	}
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultLogVolumeFrom     = "now-1h"
+	defaultLogVolumeTo       = "now"
+	defaultLogVolumeInterval = "1m"
+	defaultLogTimeField      = "@timestamp"
+)
+
+// fixedIntervalPattern matches the units Elasticsearch accepts for a date_histogram fixed_interval
+var fixedIntervalPattern = regexp.MustCompile(`^[1-9][0-9]*(ms|s|m|h|d)$`)
+
+// LogVolumeOptions selects the logs counted by LogVolume and how they are bucketed.
+type LogVolumeOptions struct {
+	IndexPattern string
+	Query        string
+	TimeField    string
+	From         string
+	To           string
+	Interval     string
+}
+
+// LogVolumeBucket is the number of matching log entries in one interval.
+type LogVolumeBucket struct {
+	Timestamp string `json:"timestamp"`
+	Count     int64  `json:"count"`
+}
+
+// LogVolumeResult is a log count histogram over a time range.
+type LogVolumeResult struct {
+	IndexPattern string            `json:"indexPattern,omitempty"`
+	Query        string            `json:"query"`
+	TimeField    string            `json:"timeField"`
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	Interval     string            `json:"interval"`
+	Total        int64             `json:"total"`
+	Took         int64             `json:"took"`
+	Buckets      []LogVolumeBucket `json:"buckets"`
+}
+
+// logQueryClause is the Lucene query_string clause shared by log searches
+func logQueryClause(query string) map[string]interface{} {
+	return map[string]interface{}{
+		"query_string": map[string]interface{}{
+			"query": query,
+		},
+	}
+}
+
+// LogVolume counts log entries matching a query per fixed interval with a date_histogram
+// aggregation, without returning the documents. Empty intervals are returned with a zero count.
+func (c *Client) LogVolume(ctx context.Context, opts LogVolumeOptions) (*LogVolumeResult, error) {
+	if opts.Query == "" {
+		opts.Query = "*"
+	}
+	if opts.TimeField == "" {
+		opts.TimeField = defaultLogTimeField
+	}
+	if opts.From == "" {
+		opts.From = defaultLogVolumeFrom
+	}
+	if opts.To == "" {
+		opts.To = defaultLogVolumeTo
+	}
+	if opts.Interval == "" {
+		opts.Interval = defaultLogVolumeInterval
+	}
+	if !fixedIntervalPattern.MatchString(opts.Interval) {
+		return nil, fmt.Errorf("invalid interval %q: use a fixed interval such as 30s, 5m, 1h or 1d", opts.Interval)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"indexPattern": opts.IndexPattern,
+		"query":        opts.Query,
+		"from":         opts.From,
+		"to":           opts.To,
+		"interval":     opts.Interval,
+	}).Debug("Querying log volume through Kibana API")
+
+	searchBody := map[string]interface{}{
+		"size":             0,
+		"track_total_hits": true,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": []map[string]interface{}{logQueryClause(opts.Query)},
+				"filter": []map[string]interface{}{
+					{
+						"range": map[string]interface{}{
+							opts.TimeField: map[string]interface{}{
+								"gte": opts.From,
+								"lte": opts.To,
+							},
+						},
+					},
+				},
+			},
+		},
+		"aggs": map[string]interface{}{
+			"log_volume": map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":          opts.TimeField,
+					"fixed_interval": opts.Interval,
+					"min_doc_count":  0,
+					"extended_bounds": map[string]interface{}{
+						"min": opts.From,
+						"max": opts.To,
+					},
+				},
+			},
+		},
+	}
+
+	path := "_search"
+	if opts.IndexPattern != "" {
+		path = opts.IndexPattern + "/_search"
+	}
+	resp, err := c.elasticsearchRequest(ctx, "POST", path, searchBody)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Took int64 `json:"took"`
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+		} `json:"hits"`
+		Aggregations struct {
+			LogVolume struct {
+				Buckets []struct {
+					Key         int64  `json:"key"`
+					KeyAsString string `json:"key_as_string"`
+					DocCount    int64  `json:"doc_count"`
+				} `json:"buckets"`
+			} `json:"log_volume"`
+		} `json:"aggregations"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal log volume result: %w", err)
+	}
+
+	volume := &LogVolumeResult{
+		IndexPattern: opts.IndexPattern,
+		Query:        opts.Query,
+		TimeField:    opts.TimeField,
+		From:         opts.From,
+		To:           opts.To,
+		Interval:     opts.Interval,
+		Total:        result.Hits.Total.Value,
+		Took:         result.Took,
+		Buckets:      make([]LogVolumeBucket, 0, len(result.Aggregations.LogVolume.Buckets)),
+	}
+	for _, bucket := range result.Aggregations.LogVolume.Buckets {
+		timestamp := bucket.KeyAsString
+		if timestamp == "" {
+			timestamp = time.UnixMilli(bucket.Key).UTC().Format(time.RFC3339)
+		}
+		volume.Buckets = append(volume.Buckets, LogVolumeBucket{Timestamp: timestamp, Count: bucket.DocCount})
+	}
+
+	logrus.WithField("buckets", len(volume.Buckets)).Debug("Retrieved log volume")
+	return volume, nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLogVolume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/console/proxy" || r.URL.Query().Get("path") != "logs-*/_search" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.String())
+		}
+		var body struct {
+			Size  int `json:"size"`
+			Query struct {
+				Bool struct {
+					Must   []map[string]map[string]string            `json:"must"`
+					Filter []map[string]map[string]map[string]string `json:"filter"`
+				} `json:"bool"`
+			} `json:"query"`
+			Aggs struct {
+				LogVolume struct {
+					DateHistogram struct {
+						Field         string `json:"field"`
+						FixedInterval string `json:"fixed_interval"`
+					} `json:"date_histogram"`
+				} `json:"log_volume"`
+			} `json:"aggs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode search body: %v", err)
+		}
+		if body.Size != 0 || body.Query.Bool.Must[0]["query_string"]["query"] != "level:error" {
+			t.Fatalf("unexpected search body: %+v", body)
+		}
+		if body.Query.Bool.Filter[0]["range"]["@timestamp"]["gte"] != "now-1h" || body.Aggs.LogVolume.DateHistogram.FixedInterval != "5m" {
+			t.Fatalf("unexpected range or histogram: %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":4,"hits":{"total":{"value":7}},"aggregations":{"log_volume":{"buckets":[
+			{"key":1760000000000,"key_as_string":"2025-10-09T08:53:20.000Z","doc_count":7},
+			{"key":1760000300000,"doc_count":0}]}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{
+		URL:            server.URL,
+		Timeout:        2 * time.Second,
+		MaxRetries:     1,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	result, err := client.LogVolume(context.Background(), LogVolumeOptions{IndexPattern: "logs-*", Query: "level:error", Interval: "5m"})
+	if err != nil {
+		t.Fatalf("LogVolume() error = %v", err)
+	}
+	if result.Total != 7 || result.From != "now-1h" || result.To != "now" || len(result.Buckets) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Buckets[0].Timestamp != "2025-10-09T08:53:20.000Z" || result.Buckets[0].Count != 7 {
+		t.Fatalf("unexpected first bucket: %+v", result.Buckets[0])
+	}
+	if result.Buckets[1].Timestamp != "2025-10-09T08:58:20Z" || result.Buckets[1].Count != 0 {
+		t.Fatalf("expected the empty bucket to be kept with a formatted timestamp, got %+v", result.Buckets[1])
+	}
+
+	if _, err := client.LogVolume(context.Background(), LogVolumeOptions{Interval: "1M"}); err == nil {
+		t.Fatal("expected a calendar interval to be rejected")
+	}
+}
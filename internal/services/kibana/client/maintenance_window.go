@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maintenanceWindowMinVersions are the first releases of each line serving the public maintenance
+// window API; earlier releases only have an internal one
+var maintenanceWindowMinVersions = map[int]int{8: 19, 9: 1}
+
+// ErrMaintenanceWindowsNotAvailable is returned when the target Kibana does not serve the maintenance
+// window API, because it is too old, the license does not include it, or the user may not manage it.
+var ErrMaintenanceWindowsNotAvailable = errors.New("the maintenance window API is not available in this Kibana (requires 8.19 or 9.1 and later, a license including maintenance windows, and the Maintenance Windows privilege)")
+
+// MaintenanceWindowSchedule is when a maintenance window is active: from Start for Duration, repeated
+// as described by Recurring when set.
+type MaintenanceWindowSchedule struct {
+	Start     string                 `json:"start"`
+	Duration  string                 `json:"duration"`
+	Timezone  string                 `json:"timezone,omitempty"`
+	Recurring map[string]interface{} `json:"recurring,omitempty"`
+}
+
+// MaintenanceWindow is a period during which alerting rules keep running but send no notifications.
+type MaintenanceWindow struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Enabled  bool   `json:"enabled"`
+	Status   string `json:"status,omitempty"`
+	Schedule struct {
+		Custom MaintenanceWindowSchedule `json:"custom"`
+	} `json:"schedule"`
+	Scope     map[string]interface{} `json:"scope,omitempty"`
+	CreatedBy string                 `json:"created_by,omitempty"`
+	CreatedAt string                 `json:"created_at,omitempty"`
+	UpdatedBy string                 `json:"updated_by,omitempty"`
+	UpdatedAt string                 `json:"updated_at,omitempty"`
+}
+
+// MaintenanceWindowList is a page of maintenance windows.
+type MaintenanceWindowList struct {
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows"`
+	Total              int                 `json:"total"`
+	Page               int                 `json:"page"`
+	PerPage            int                 `json:"per_page"`
+}
+
+// MaintenanceWindowSpec describes a maintenance window to create. ScopeKQL limits the window to the
+// alerts matching the query; without it every rule of the space is covered.
+type MaintenanceWindowSpec struct {
+	Title    string
+	Enabled  *bool
+	Schedule MaintenanceWindowSchedule
+	ScopeKQL string
+}
+
+// GetMaintenanceWindows lists the maintenance windows of the space, optionally only those with status
+// (running, upcoming, finished, archived or disabled).
+func (c *Client) GetMaintenanceWindows(ctx context.Context, page, perPage int, status string) (*MaintenanceWindowList, error) {
+	logrus.WithFields(logrus.Fields{
+		"page":    page,
+		"perPage": perPage,
+		"status":  status,
+	}).Debug("Getting maintenance windows")
+
+	if err := c.checkMaintenanceWindowSupport(ctx); err != nil {
+		return nil, err
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("per_page", fmt.Sprintf("%d", perPage))
+	if status != "" {
+		params.Set("status", status)
+	}
+
+	body, err := c.maintenanceWindowRequest(ctx, "GET", "maintenance_window/_find?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list MaintenanceWindowList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal maintenance windows: %w", err)
+	}
+	if list.MaintenanceWindows == nil {
+		list.MaintenanceWindows = []MaintenanceWindow{}
+	}
+
+	logrus.WithField("count", len(list.MaintenanceWindows)).Debug("Retrieved maintenance windows")
+	return &list, nil
+}
+
+// CreateMaintenanceWindow creates a maintenance window. Start must be an RFC 3339 time and Duration a
+// Kibana duration such as 30m, 2h or 1d.
+func (c *Client) CreateMaintenanceWindow(ctx context.Context, spec MaintenanceWindowSpec) (*MaintenanceWindow, error) {
+	logrus.WithFields(logrus.Fields{
+		"title":    spec.Title,
+		"start":    spec.Schedule.Start,
+		"duration": spec.Schedule.Duration,
+	}).Debug("Creating maintenance window")
+
+	if strings.TrimSpace(spec.Title) == "" {
+		return nil, fmt.Errorf("maintenance window title is required")
+	}
+	if _, err := time.Parse(time.RFC3339, spec.Schedule.Start); err != nil {
+		return nil, fmt.Errorf("maintenance window start must be an RFC 3339 time such as 2026-01-02T15:00:00Z: %w", err)
+	}
+	if spec.Schedule.Duration == "" {
+		return nil, fmt.Errorf("maintenance window duration is required")
+	}
+	if err := c.checkMaintenanceWindowSupport(ctx); err != nil {
+		return nil, err
+	}
+
+	request := map[string]interface{}{
+		"title":    spec.Title,
+		"schedule": map[string]interface{}{"custom": spec.Schedule},
+	}
+	if spec.Enabled != nil {
+		request["enabled"] = *spec.Enabled
+	}
+	if spec.ScopeKQL != "" {
+		request["scope"] = map[string]interface{}{
+			"alerting": map[string]interface{}{"query": map[string]interface{}{"kql": spec.ScopeKQL}},
+		}
+	}
+
+	body, err := c.maintenanceWindowRequest(ctx, "POST", "maintenance_window", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var window MaintenanceWindow
+	if err := json.Unmarshal(body, &window); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal created maintenance window: %w", err)
+	}
+
+	logrus.WithField("maintenance_window_id", window.ID).Debug("Created maintenance window")
+	return &window, nil
+}
+
+// DeleteMaintenanceWindow deletes a maintenance window; notifications of the rules it covered resume
+// immediately.
+func (c *Client) DeleteMaintenanceWindow(ctx context.Context, windowID string) error {
+	logrus.WithField("maintenance_window_id", windowID).Debug("Deleting maintenance window")
+
+	if windowID == "" {
+		return fmt.Errorf("maintenance window ID is required")
+	}
+	if err := c.checkMaintenanceWindowSupport(ctx); err != nil {
+		return err
+	}
+
+	if _, err := c.maintenanceWindowRequest(ctx, "DELETE", "maintenance_window/"+url.PathEscape(windowID), nil); err != nil {
+		return fmt.Errorf("failed to delete maintenance window: %w", err)
+	}
+
+	logrus.WithField("maintenance_window_id", windowID).Debug("Deleted maintenance window")
+	return nil
+}
+
+// checkMaintenanceWindowSupport fails early on Kibana versions without the public maintenance window
+// API. A version that cannot be determined lets the request through.
+func (c *Client) checkMaintenanceWindowSupport(ctx context.Context) error {
+	status, err := c.GetKibanaStatus(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("Failed to get Kibana status, skipping maintenance window version check")
+		return nil
+	}
+	version := kibanaVersionNumber(status)
+	if supported, known := versionAtLeast(version, maintenanceWindowMinVersions); known && !supported {
+		return fmt.Errorf("%w; Kibana reports version %s", ErrMaintenanceWindowsNotAvailable, version)
+	}
+	return nil
+}
+
+// maintenanceWindowRequest performs a maintenance window API request and returns the response body.
+// A 403 means the feature is unlicensed or not permitted, and a 404 that the API is missing, except
+// when deleting, where it means the window does not exist.
+func (c *Client) maintenanceWindowRequest(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
+	resp, err := c.makeRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusForbidden || (resp.StatusCode == http.StatusNotFound && method != "DELETE") {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w (status %d)", ErrMaintenanceWindowsNotAvailable, resp.StatusCode)
+	}
+	return c.handleResponse(resp)
+}
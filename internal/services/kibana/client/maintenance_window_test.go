@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// maintenanceWindowServer serves the status API with version and hands the other requests to handler
+func maintenanceWindowServer(t *testing.T, version string, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/status" {
+			_, _ = w.Write([]byte(`{"version":{"number":"` + version + `"}}`))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestCreateMaintenanceWindow(t *testing.T) {
+	client := maintenanceWindowServer(t, "9.1.0", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/maintenance_window" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		custom := body["schedule"].(map[string]interface{})["custom"].(map[string]interface{})
+		if body["title"] != "Deploy checkout" || custom["start"] != "2026-10-16T20:00:00Z" || custom["duration"] != "2h" || custom["timezone"] != "UTC" {
+			t.Fatalf("unexpected body: %v", body)
+		}
+		if _, ok := custom["recurring"]; ok {
+			t.Fatalf("expected a one-off window, got %v", custom)
+		}
+		query := body["scope"].(map[string]interface{})["alerting"].(map[string]interface{})["query"].(map[string]interface{})
+		if query["kql"] != "kibana.alert.rule.tags: checkout" {
+			t.Fatalf("unexpected scope: %v", body["scope"])
+		}
+		_, _ = w.Write([]byte(`{"id":"mw-1","title":"Deploy checkout","enabled":true,"status":"upcoming","schedule":{"custom":{"start":"2026-10-16T20:00:00Z","duration":"2h","timezone":"UTC"}}}`))
+	})
+
+	window, err := client.CreateMaintenanceWindow(context.Background(), MaintenanceWindowSpec{
+		Title:    "Deploy checkout",
+		Schedule: MaintenanceWindowSchedule{Start: "2026-10-16T20:00:00Z", Duration: "2h", Timezone: "UTC"},
+		ScopeKQL: "kibana.alert.rule.tags: checkout",
+	})
+	if err != nil {
+		t.Fatalf("CreateMaintenanceWindow() error = %v", err)
+	}
+	if window.ID != "mw-1" || window.Status != "upcoming" || window.Schedule.Custom.Duration != "2h" {
+		t.Fatalf("unexpected maintenance window: %+v", window)
+	}
+
+	if _, err := client.CreateMaintenanceWindow(context.Background(), MaintenanceWindowSpec{
+		Title:    "Deploy checkout",
+		Schedule: MaintenanceWindowSchedule{Start: "tonight", Duration: "2h"},
+	}); err == nil || !strings.Contains(err.Error(), "RFC 3339") {
+		t.Fatalf("expected an invalid start error, got %v", err)
+	}
+}
+
+func TestGetMaintenanceWindows(t *testing.T) {
+	client := maintenanceWindowServer(t, "8.19.2", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/maintenance_window/_find" || r.URL.Query().Get("status") != "running" {
+			t.Fatalf("unexpected request %s", r.URL.String())
+		}
+		_, _ = w.Write([]byte(`{"page":1,"per_page":20,"total":1,"maintenanceWindows":[{"id":"mw-1","title":"Deploy checkout","enabled":true,"status":"running"}]}`))
+	})
+
+	list, err := client.GetMaintenanceWindows(context.Background(), 1, 20, "running")
+	if err != nil {
+		t.Fatalf("GetMaintenanceWindows() error = %v", err)
+	}
+	if list.Total != 1 || len(list.MaintenanceWindows) != 1 || list.MaintenanceWindows[0].Status != "running" {
+		t.Fatalf("unexpected maintenance windows: %+v", list)
+	}
+}
+
+func TestMaintenanceWindowsVersionGate(t *testing.T) {
+	client := maintenanceWindowServer(t, "9.0.3", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request %s %s on an unsupported version", r.Method, r.URL.Path)
+	})
+
+	err := client.DeleteMaintenanceWindow(context.Background(), "mw-1")
+	if !errors.Is(err, ErrMaintenanceWindowsNotAvailable) || !strings.Contains(err.Error(), "9.0.3") {
+		t.Fatalf("expected ErrMaintenanceWindowsNotAvailable naming the version, got %v", err)
+	}
+}
+
+func TestMaintenanceWindowsForbidden(t *testing.T) {
+	client := maintenanceWindowServer(t, "9.2.0", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Your license does not support maintenance windows"}`))
+	})
+
+	_, err := client.GetMaintenanceWindows(context.Background(), 1, 20, "")
+	if !errors.Is(err, ErrMaintenanceWindowsNotAvailable) {
+		t.Fatalf("expected ErrMaintenanceWindowsNotAvailable, got %v", err)
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version   string
+		supported bool
+		known     bool
+	}{
+		{"8.18.4", false, true},
+		{"8.19.0", true, true},
+		{"9.0.3", false, true},
+		{"9.1.0-SNAPSHOT", true, true},
+		{"10.0.0", true, true},
+		{"7.17.9", false, true},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		supported, known := versionAtLeast(tt.version, maintenanceWindowMinVersions)
+		if supported != tt.supported || known != tt.known {
+			t.Errorf("versionAtLeast(%q) = (%v, %v), want (%v, %v)", tt.version, supported, known, tt.supported, tt.known)
+		}
+	}
+}
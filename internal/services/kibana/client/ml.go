@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrMLNotLicensed is returned when the Elastic license does not include machine learning.
+var ErrMLNotLicensed = errors.New("machine learning is not available under the current Elastic license")
+
+// MLJob summarizes an anomaly detection job together with its datafeed.
+type MLJob struct {
+	JobID                 string `json:"jobId"`
+	State                 string `json:"state"`
+	DatafeedID            string `json:"datafeedId,omitempty"`
+	DatafeedState         string `json:"datafeedState,omitempty"`
+	ProcessedRecordCount  int64  `json:"processedRecordCount"`
+	BucketCount           int64  `json:"bucketCount"`
+	EmptyBucketCount      int64  `json:"emptyBucketCount"`
+	SparseBucketCount     int64  `json:"sparseBucketCount"`
+	LatestRecordTimestamp int64  `json:"latestRecordTimestamp,omitempty"`
+	LatestBucketTimestamp int64  `json:"latestBucketTimestamp,omitempty"`
+	MemoryStatus          string `json:"memoryStatus,omitempty"`
+	AssignmentExplanation string `json:"assignmentExplanation,omitempty"`
+}
+
+type mlJobStatsResponse struct {
+	Jobs []struct {
+		JobID      string `json:"job_id"`
+		State      string `json:"state"`
+		DataCounts struct {
+			ProcessedRecordCount  int64 `json:"processed_record_count"`
+			BucketCount           int64 `json:"bucket_count"`
+			EmptyBucketCount      int64 `json:"empty_bucket_count"`
+			SparseBucketCount     int64 `json:"sparse_bucket_count"`
+			LatestRecordTimestamp int64 `json:"latest_record_timestamp"`
+			LatestBucketTimestamp int64 `json:"latest_bucket_timestamp"`
+		} `json:"data_counts"`
+		ModelSizeStats struct {
+			MemoryStatus string `json:"memory_status"`
+		} `json:"model_size_stats"`
+		AssignmentExplanation string `json:"assignment_explanation"`
+	} `json:"jobs"`
+}
+
+type mlDatafeedsResponse struct {
+	Datafeeds []struct {
+		DatafeedID string `json:"datafeed_id"`
+		JobID      string `json:"job_id"`
+	} `json:"datafeeds"`
+}
+
+type mlDatafeedStatsResponse struct {
+	Datafeeds []struct {
+		DatafeedID string `json:"datafeed_id"`
+		State      string `json:"state"`
+	} `json:"datafeeds"`
+}
+
+// GetMLJobs retrieves all anomaly detection jobs with their state, datafeed state and data counts.
+func (c *Client) GetMLJobs(ctx context.Context) ([]MLJob, error) {
+	logrus.Debug("Getting ML anomaly detection jobs")
+
+	jobs, err := c.getMLJobs(ctx, "_all")
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithField("count", len(jobs)).Debug("Retrieved ML jobs")
+	return jobs, nil
+}
+
+// GetMLJobStats retrieves the state, datafeed state and data counts of a single anomaly detection job.
+func (c *Client) GetMLJobStats(ctx context.Context, jobID string) (*MLJob, error) {
+	logrus.WithField("job_id", jobID).Debug("Getting ML job stats")
+
+	jobs, err := c.getMLJobs(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("ML job %s not found", jobID)
+	}
+
+	logrus.WithField("job_id", jobID).Debug("Retrieved ML job stats")
+	return &jobs[0], nil
+}
+
+// getMLJobs combines job stats with datafeed configuration and stats for the given job expression
+func (c *Client) getMLJobs(ctx context.Context, jobExpr string) ([]MLJob, error) {
+	var stats mlJobStatsResponse
+	if err := c.mlRequest(ctx, "_ml/anomaly_detectors/"+url.PathEscape(jobExpr)+"/_stats", &stats); err != nil {
+		return nil, err
+	}
+
+	// Datafeeds are optional: a failure here should not hide the job states
+	datafeedByJob := map[string]string{}
+	datafeedState := map[string]string{}
+	var datafeeds mlDatafeedsResponse
+	if err := c.mlRequest(ctx, "_ml/datafeeds/_all", &datafeeds); err != nil {
+		logrus.WithError(err).Debug("Failed to get ML datafeeds")
+	} else {
+		for _, feed := range datafeeds.Datafeeds {
+			datafeedByJob[feed.JobID] = feed.DatafeedID
+		}
+		var feedStats mlDatafeedStatsResponse
+		if err := c.mlRequest(ctx, "_ml/datafeeds/_all/_stats", &feedStats); err != nil {
+			logrus.WithError(err).Debug("Failed to get ML datafeed stats")
+		} else {
+			for _, feed := range feedStats.Datafeeds {
+				datafeedState[feed.DatafeedID] = feed.State
+			}
+		}
+	}
+
+	jobs := make([]MLJob, 0, len(stats.Jobs))
+	for _, raw := range stats.Jobs {
+		job := MLJob{
+			JobID:                 raw.JobID,
+			State:                 raw.State,
+			ProcessedRecordCount:  raw.DataCounts.ProcessedRecordCount,
+			BucketCount:           raw.DataCounts.BucketCount,
+			EmptyBucketCount:      raw.DataCounts.EmptyBucketCount,
+			SparseBucketCount:     raw.DataCounts.SparseBucketCount,
+			LatestRecordTimestamp: raw.DataCounts.LatestRecordTimestamp,
+			LatestBucketTimestamp: raw.DataCounts.LatestBucketTimestamp,
+			MemoryStatus:          raw.ModelSizeStats.MemoryStatus,
+			AssignmentExplanation: raw.AssignmentExplanation,
+		}
+		if feedID, ok := datafeedByJob[raw.JobID]; ok {
+			job.DatafeedID = feedID
+			job.DatafeedState = datafeedState[feedID]
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].JobID < jobs[j].JobID })
+	return jobs, nil
+}
+
+// mlRequest performs a GET against the Elasticsearch ML API and decodes the response into out.
+// License failures are reported as ErrMLNotLicensed with the reason given by Elasticsearch.
+func (c *Client) mlRequest(ctx context.Context, path string, out interface{}) error {
+	resp, err := c.elasticsearchRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		if reason := mlLicenseReason(body); reason != "" {
+			return fmt.Errorf("%w: %s", ErrMLNotLicensed, reason)
+		}
+		return fmt.Errorf("kibana API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal ML response: %w", err)
+	}
+	return nil
+}
+
+// mlLicenseReason extracts the Elasticsearch error reason when it is a license failure
+func mlLicenseReason(body []byte) string {
+	var payload struct {
+		Error struct {
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	if strings.Contains(strings.ToLower(payload.Error.Reason), "license") {
+		return payload.Error.Reason
+	}
+	return ""
+}
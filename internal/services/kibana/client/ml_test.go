@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetMLJobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("path") {
+		case "_ml/anomaly_detectors/_all/_stats":
+			_, _ = w.Write([]byte(`{"count":2,"jobs":[
+				{"job_id":"web-latency","state":"opened","data_counts":{"processed_record_count":1200,"bucket_count":48,"empty_bucket_count":2,"latest_record_timestamp":1700000000000},"model_size_stats":{"memory_status":"ok"}},
+				{"job_id":"batch-errors","state":"closed","data_counts":{"processed_record_count":10,"bucket_count":3}}]}`))
+		case "_ml/datafeeds/_all":
+			_, _ = w.Write([]byte(`{"datafeeds":[{"datafeed_id":"datafeed-web-latency","job_id":"web-latency"}]}`))
+		case "_ml/datafeeds/_all/_stats":
+			_, _ = w.Write([]byte(`{"datafeeds":[{"datafeed_id":"datafeed-web-latency","state":"started"}]}`))
+		default:
+			t.Fatalf("unexpected proxy path %q", r.URL.Query().Get("path"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	jobs, err := client.GetMLJobs(context.Background())
+	if err != nil {
+		t.Fatalf("GetMLJobs() error = %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].JobID != "batch-errors" {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+	web := jobs[1]
+	if web.State != "opened" || web.DatafeedState != "started" || web.BucketCount != 48 || web.ProcessedRecordCount != 1200 || web.MemoryStatus != "ok" {
+		t.Fatalf("unexpected web-latency job: %+v", web)
+	}
+	if jobs[0].DatafeedID != "" {
+		t.Fatalf("expected no datafeed for batch-errors, got %+v", jobs[0])
+	}
+}
+
+func TestGetMLJobStatsLicenseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"type":"security_exception","reason":"current license is non-compliant for [ml]"},"status":403}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.GetMLJobStats(context.Background(), "web-latency")
+	if !errors.Is(err, ErrMLNotLicensed) || !strings.Contains(err.Error(), "non-compliant for [ml]") {
+		t.Fatalf("expected license error, got %v", err)
+	}
+}
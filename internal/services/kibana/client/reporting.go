@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrReportPending is returned by WaitForReport when the report job did not finish before the timeout.
+var ErrReportPending = errors.New("report is still being generated")
+
+// reportExportTypes maps the supported report formats to Kibana reporting export types
+var reportExportTypes = map[string]string{
+	"pdf": "printablePdfV2",
+	"png": "pngV2",
+}
+
+// ReportTimeRange is the dashboard time range a report is rendered with (e.g., now-24h to now).
+type ReportTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ReportJob describes a queued Kibana reporting job.
+type ReportJob struct {
+	JobID        string `json:"jobId"`
+	Format       string `json:"format"`
+	Status       string `json:"status,omitempty"`
+	DownloadPath string `json:"downloadPath"`
+}
+
+// ReportContent is a generated report file.
+type ReportContent struct {
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"-"`
+}
+
+// GenerateReport queues a PDF or PNG report of a dashboard and returns the reporting job.
+// Reports are generated asynchronously; use WaitForReport or DownloadReport to fetch the file.
+func (c *Client) GenerateReport(ctx context.Context, dashboardID, format string, timeRange ReportTimeRange) (*ReportJob, error) {
+	logrus.WithFields(logrus.Fields{
+		"dashboard_id": dashboardID,
+		"format":       format,
+		"from":         timeRange.From,
+		"to":           timeRange.To,
+	}).Debug("Generating Kibana report")
+
+	format = strings.ToLower(format)
+	exportType, ok := reportExportTypes[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported report format %q (expected pdf or png)", format)
+	}
+	if timeRange.From == "" {
+		timeRange.From = "now-15m"
+	}
+	if timeRange.To == "" {
+		timeRange.To = "now"
+	}
+
+	title := dashboardID
+	if dashboard, err := c.GetDashboard(ctx, dashboardID); err != nil {
+		logrus.WithError(err).Debug("Failed to get dashboard title for report")
+	} else if dashboard.Title != "" {
+		title = dashboard.Title
+	}
+
+	version := "8.0.0"
+	if status, err := c.GetKibanaStatus(ctx); err == nil {
+		if number := kibanaVersionNumber(status); number != "" {
+			version = number
+		}
+	}
+
+	endpoint := "reporting/generate/" + exportType + "?" +
+		url.Values{"jobParams": {reportJobParams(dashboardID, title, format, version, timeRange)}}.Encode()
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Path string `json:"path"`
+		Job  struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"job"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal report job: %w", err)
+	}
+	if result.Job.ID == "" {
+		return nil, fmt.Errorf("kibana did not return a report job ID")
+	}
+
+	job := &ReportJob{
+		JobID:        result.Job.ID,
+		Format:       format,
+		Status:       result.Job.Status,
+		DownloadPath: result.Path,
+	}
+	logrus.WithField("job_id", job.JobID).Debug("Queued Kibana report")
+	return job, nil
+}
+
+// DownloadReport fetches a finished report. ready is false while the job is still pending or processing.
+func (c *Client) DownloadReport(ctx context.Context, jobID string) (content *ReportContent, ready bool, err error) {
+	logrus.WithField("job_id", jobID).Debug("Downloading Kibana report")
+
+	resp, err := c.makeRequest(ctx, "GET", "reporting/jobs/download/"+url.PathEscape(jobID), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		_ = resp.Body.Close()
+		return nil, false, nil
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &ReportContent{ContentType: contentType, Data: body}, true, nil
+}
+
+// WaitForReport polls a report job until it is downloadable or the timeout elapses,
+// in which case ErrReportPending is returned so callers can resume later with the job ID.
+func (c *Client) WaitForReport(ctx context.Context, jobID string, timeout, interval time.Duration) (*ReportContent, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		content, ready, err := c.DownloadReport(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if ready {
+			return content, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return nil, ErrReportPending
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// reportJobParams builds the rison-encoded jobParams Kibana expects for a dashboard report
+func reportJobParams(dashboardID, title, format, version string, timeRange ReportTimeRange) string {
+	layout := "(id:preserve_layout)"
+	if format == "png" {
+		layout = "(dimensions:(height:1080,width:1920),id:preserve_layout)"
+	}
+	locator := fmt.Sprintf("(id:DASHBOARD_APP_LOCATOR,params:(dashboardId:%s,preserveSavedFilters:!t,timeRange:(from:%s,to:%s),useHash:!f,viewMode:view),version:%s)",
+		risonString(dashboardID), risonString(timeRange.From), risonString(timeRange.To), risonString(version))
+
+	locatorKey := "locatorParams:!(" + locator + ")"
+	if format == "png" {
+		// pngV2 takes a single locator instead of a list
+		locatorKey = "locatorParams:" + locator
+	}
+	return fmt.Sprintf("(browserTimezone:UTC,layout:%s,%s,objectType:dashboard,title:%s,version:%s)",
+		layout, locatorKey, risonString(title), risonString(version))
+}
+
+// risonString quotes a string value for rison, escaping ! and ' with !
+func risonString(value string) string {
+	escaped := strings.NewReplacer("!", "!!", "'", "!'").Replace(value)
+	return "'" + escaped + "'"
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateAndWaitForReport(t *testing.T) {
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/saved_objects/dashboard/dash-1":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"dash-1","attributes":{"title":"Ops Overview"}}`))
+		case r.URL.Path == "/api/status":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"version":{"number":"8.15.0"}}`))
+		case r.URL.Path == "/api/reporting/generate/printablePdfV2":
+			if r.Method != http.MethodPost {
+				t.Fatalf("expected POST, got %s", r.Method)
+			}
+			params := r.URL.Query().Get("jobParams")
+			if !strings.Contains(params, "dashboardId:'dash-1'") || !strings.Contains(params, "from:'now-24h'") {
+				t.Fatalf("unexpected jobParams %q", params)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"path":"/api/reporting/jobs/download/job-1","job":{"id":"job-1","status":"pending"}}`))
+		case r.URL.Path == "/api/reporting/jobs/download/job-1":
+			downloads++
+			if downloads == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-1.7"))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{
+		URL:            server.URL,
+		Timeout:        2 * time.Second,
+		MaxRetries:     1,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	job, err := client.GenerateReport(context.Background(), "dash-1", "PDF", ReportTimeRange{From: "now-24h"})
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if job.JobID != "job-1" || job.Format != "pdf" || job.DownloadPath != "/api/reporting/jobs/download/job-1" {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	content, err := client.WaitForReport(context.Background(), job.JobID, time.Second, time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForReport() error = %v", err)
+	}
+	if content.ContentType != "application/pdf" || string(content.Data) != "%PDF-1.7" {
+		t.Fatalf("unexpected content: %+v", content)
+	}
+}
+
+func TestWaitForReportTimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{
+		URL:            server.URL,
+		Timeout:        2 * time.Second,
+		MaxRetries:     1,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.WaitForReport(context.Background(), "job-1", 5*time.Millisecond, time.Millisecond)
+	if !errors.Is(err, ErrReportPending) {
+		t.Fatalf("expected ErrReportPending, got %v", err)
+	}
+}
+
+func TestReportJobParamsEscapesValues(t *testing.T) {
+	params := reportJobParams("dash-1", "Bob's dashboard!", "png", "8.15.0", ReportTimeRange{From: "now-1h", To: "now"})
+	if !strings.Contains(params, "title:'Bob!'s dashboard!!'") {
+		t.Fatalf("title not escaped: %s", params)
+	}
+	if !strings.Contains(params, "dimensions:(height:1080,width:1920)") {
+		t.Fatalf("png layout missing dimensions: %s", params)
+	}
+}
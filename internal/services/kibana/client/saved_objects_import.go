@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SavedObjectsImportResult is the response of the saved objects _import API.
+type SavedObjectsImportResult struct {
+	Success      bool                     `json:"success"`
+	SuccessCount int                      `json:"successCount"`
+	Errors       []map[string]interface{} `json:"errors,omitempty"`
+}
+
+// ImportSavedObjectsFrom imports an NDJSON export read from content, streaming it to Kibana as the
+// multipart file upload the _import API expects. fileName is the name reported for the upload and
+// must end in .ndjson. Objects that failed to import are listed in the result's Errors.
+func (c *Client) ImportSavedObjectsFrom(ctx context.Context, fileName string, content io.Reader, createNewCopies bool) (*SavedObjectsImportResult, error) {
+	logrus.WithFields(logrus.Fields{"file": fileName, "createNewCopies": createNewCopies}).Debug("Importing saved objects")
+
+	params := url.Values{}
+	if createNewCopies {
+		params.Set("createNewCopies", "true")
+	}
+	endpoint := "saved_objects/_import"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	// Stream the multipart body so large bundles are never held in memory
+	pipeReader, pipeWriter := io.Pipe()
+	form := multipart.NewWriter(pipeWriter)
+	go func() {
+		part, err := form.CreateFormFile("file", fileName)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		_ = pipeWriter.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.requestURL(c.baseURL, endpoint), pipeReader)
+	if err != nil {
+		_ = pipeReader.CloseWithError(err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setRequestHeaders(req)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		_ = pipeReader.CloseWithError(err)
+		return nil, err
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import saved objects: %w", err)
+	}
+
+	var result SavedObjectsImportResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal import result: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{"successCount": result.SuccessCount, "errors": len(result.Errors)}).Debug("Imported saved objects")
+	return &result, nil
+}
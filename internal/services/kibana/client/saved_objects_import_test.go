@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportSavedObjectsFromStreamsMultipart(t *testing.T) {
+	const bundle = "{\"id\":\"d1\",\"type\":\"dashboard\"}\n{\"exportedCount\":1}\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/spaces/ops/saved_objects/_import" || r.URL.Query().Get("createNewCopies") != "true" {
+			t.Fatalf("unexpected request %s", r.URL.String())
+		}
+		if r.Header.Get("Kbn-Xsrf") != "true" {
+			t.Fatal("expected the kbn-xsrf header on the import request")
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("expected a multipart file upload: %v", err)
+		}
+		content, _ := io.ReadAll(file)
+		if header.Filename != "dashboards.ndjson" || string(content) != bundle {
+			t.Fatalf("unexpected upload %s: %q", header.Filename, content)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":false,"successCount":1,"errors":[{"id":"v1","type":"visualization","error":{"type":"conflict"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Space: "ops", Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	result, err := client.ImportSavedObjectsFrom(context.Background(), "dashboards.ndjson", strings.NewReader(bundle), true)
+	if err != nil {
+		t.Fatalf("ImportSavedObjectsFrom() error = %v", err)
+	}
+	if result.Success || result.SuccessCount != 1 || len(result.Errors) != 1 {
+		t.Fatalf("unexpected import result: %+v", result)
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrSLONotAvailable is returned when the target Kibana does not expose the SLO API, typically
+// because the Observability plugin is disabled or the license does not include SLOs.
+var ErrSLONotAvailable = errors.New("the SLO API is not available in this Kibana (Observability SLOs disabled, unlicensed, or not permitted)")
+
+// KibanaSLO represents a service level objective defined in Kibana Observability.
+type KibanaSLO struct {
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description,omitempty"`
+	Enabled         bool                   `json:"enabled"`
+	Tags            []string               `json:"tags,omitempty"`
+	BudgetingMethod string                 `json:"budgetingMethod,omitempty"`
+	TimeWindow      map[string]interface{} `json:"timeWindow,omitempty"`
+	Indicator       map[string]interface{} `json:"indicator,omitempty"`
+	Objective       KibanaSLOObjective     `json:"objective"`
+	Summary         KibanaSLOSummary       `json:"summary"`
+	InstanceID      string                 `json:"instanceId,omitempty"`
+}
+
+// KibanaSLOObjective is the target of an SLO (for example 0.99 for 99%).
+type KibanaSLOObjective struct {
+	Target float64 `json:"target"`
+}
+
+// KibanaSLOSummary holds the computed state of an SLO.
+type KibanaSLOSummary struct {
+	Status      string               `json:"status"`
+	SLIValue    float64              `json:"sliValue"`
+	ErrorBudget KibanaSLOErrorBudget `json:"errorBudget"`
+}
+
+// KibanaSLOErrorBudget describes how much of the error budget has been consumed.
+type KibanaSLOErrorBudget struct {
+	Initial     float64 `json:"initial"`
+	Consumed    float64 `json:"consumed"`
+	Remaining   float64 `json:"remaining"`
+	IsEstimated bool    `json:"isEstimated"`
+}
+
+// SLOList is a page of SLOs returned by the SLO find API.
+type SLOList struct {
+	Page    int         `json:"page"`
+	PerPage int         `json:"perPage"`
+	Total   int         `json:"total"`
+	Results []KibanaSLO `json:"results"`
+}
+
+// GetSLOs retrieves SLOs with pagination, optionally filtered by a KQL query.
+func (c *Client) GetSLOs(ctx context.Context, page, perPage int, kqlQuery string) (*SLOList, error) {
+	logrus.WithFields(logrus.Fields{
+		"page":     page,
+		"perPage":  perPage,
+		"kqlQuery": kqlQuery,
+	}).Debug("Getting SLOs")
+
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("perPage", fmt.Sprintf("%d", perPage))
+	if kqlQuery != "" {
+		params.Set("kqlQuery", kqlQuery)
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "observability/slos?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w (status %d)", ErrSLONotAvailable, resp.StatusCode)
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var list SLOList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SLOs: %w", err)
+	}
+	if list.Results == nil {
+		list.Results = []KibanaSLO{}
+	}
+
+	logrus.WithField("count", len(list.Results)).Debug("Retrieved SLOs")
+	return &list, nil
+}
+
+// GetSLO retrieves a specific SLO by ID.
+func (c *Client) GetSLO(ctx context.Context, sloID string) (*KibanaSLO, error) {
+	logrus.WithField("slo_id", sloID).Debug("Getting SLO")
+
+	resp, err := c.makeRequest(ctx, "GET", "observability/slos/"+url.PathEscape(sloID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w (status %d)", ErrSLONotAvailable, resp.StatusCode)
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var slo KibanaSLO
+	if err := json.Unmarshal(body, &slo); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SLO: %w", err)
+	}
+
+	logrus.WithField("slo_id", sloID).Debug("Retrieved SLO")
+	return &slo, nil
+}
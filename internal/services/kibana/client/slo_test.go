@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetSLOs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/observability/slos" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("kqlQuery"); got != "slo.tags: checkout" {
+			t.Fatalf("unexpected kqlQuery %q", got)
+		}
+		if got := r.URL.Query().Get("perPage"); got != "10" {
+			t.Fatalf("unexpected perPage %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"page":1,"perPage":10,"total":1,"results":[{"id":"slo-1","name":"Checkout availability","enabled":true,"objective":{"target":0.99},"summary":{"status":"HEALTHY","sliValue":0.995,"errorBudget":{"initial":0.01,"consumed":0.5,"remaining":0.5,"isEstimated":false}}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	list, err := client.GetSLOs(context.Background(), 1, 10, "slo.tags: checkout")
+	if err != nil {
+		t.Fatalf("GetSLOs() error = %v", err)
+	}
+	if list.Total != 1 || len(list.Results) != 1 {
+		t.Fatalf("unexpected SLO list: %+v", list)
+	}
+	slo := list.Results[0]
+	if slo.Objective.Target != 0.99 || slo.Summary.SLIValue != 0.995 || slo.Summary.ErrorBudget.Remaining != 0.5 {
+		t.Fatalf("unexpected SLO: %+v", slo)
+	}
+}
+
+func TestGetSLOsNotAvailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"statusCode":404,"error":"Not Found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.GetSLOs(context.Background(), 1, 20, "")
+	if !errors.Is(err, ErrSLONotAvailable) {
+		t.Fatalf("expected ErrSLONotAvailable, got %v", err)
+	}
+}
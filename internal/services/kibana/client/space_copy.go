@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// spaceCopyFindPageSize is the page size used to enumerate saved objects in the source space
+	spaceCopyFindPageSize = 1000
+	// spaceCopyBatchSize is the number of objects sent per _copy_saved_objects request
+	spaceCopyBatchSize = 100
+)
+
+// DefaultSpaceCopyTypes are the saved object types copied when no type filter is given.
+// The _find API requires explicit types, and types unknown to the target Kibana are skipped.
+var DefaultSpaceCopyTypes = []string{
+	"config",
+	"index-pattern",
+	"search",
+	"visualization",
+	"lens",
+	"dashboard",
+	"map",
+	"canvas-workpad",
+	"tag",
+	"query",
+	"links",
+	"event-annotation-group",
+}
+
+// SpaceCopyTypeCount counts the objects of one type found in the source space and copied.
+type SpaceCopyTypeCount struct {
+	Found  int `json:"found"`
+	Copied int `json:"copied"`
+}
+
+// SpaceCopyError describes an object that could not be copied.
+type SpaceCopyError struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Title     string `json:"title,omitempty"`
+	ErrorType string `json:"errorType"`
+}
+
+// SpaceCopyResult summarizes copying all saved objects of a space to another space.
+type SpaceCopyResult struct {
+	SourceSpace  string                         `json:"sourceSpace"`
+	TargetSpace  string                         `json:"targetSpace"`
+	Overwrite    bool                           `json:"overwrite"`
+	Types        map[string]*SpaceCopyTypeCount `json:"types"`
+	Batches      int                            `json:"batches"`
+	Conflicts    []SpaceCopyError               `json:"conflicts"`
+	Errors       []SpaceCopyError               `json:"errors"`
+	SkippedTypes []string                       `json:"skippedTypes,omitempty"`
+}
+
+type savedObjectRef struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// inSpace returns a shallow copy of the client that sends requests to the given space
+func (c *Client) inSpace(space string) *Client {
+	clone := *c
+	if space == "" {
+		space = "default"
+	}
+	clone.space = space
+	return &clone
+}
+
+// CopySpaceObjects copies every saved object of the given types (DefaultSpaceCopyTypes when empty)
+// from sourceSpace to targetSpace. Objects are enumerated by paging through _find in the source
+// space and copied in batches with includeReferences. Conflicts are reported rather than
+// overwritten unless overwrite is set.
+func (c *Client) CopySpaceObjects(ctx context.Context, sourceSpace, targetSpace string, types []string, overwrite bool) (*SpaceCopyResult, error) {
+	logrus.WithFields(logrus.Fields{
+		"source":    sourceSpace,
+		"target":    targetSpace,
+		"types":     types,
+		"overwrite": overwrite,
+	}).Debug("Copying Kibana space objects")
+
+	if sourceSpace == "" || targetSpace == "" {
+		return nil, fmt.Errorf("sourceSpace and targetSpace are required")
+	}
+	if sourceSpace == targetSpace {
+		return nil, fmt.Errorf("sourceSpace and targetSpace must differ")
+	}
+	if len(types) == 0 {
+		types = DefaultSpaceCopyTypes
+	}
+
+	source := c.inSpace(sourceSpace)
+	result := &SpaceCopyResult{
+		SourceSpace: sourceSpace,
+		TargetSpace: targetSpace,
+		Overwrite:   overwrite,
+		Types:       map[string]*SpaceCopyTypeCount{},
+		Conflicts:   []SpaceCopyError{},
+		Errors:      []SpaceCopyError{},
+	}
+
+	var objects []savedObjectRef
+	for _, objectType := range types {
+		found, err := source.listAllSavedObjects(ctx, objectType)
+		if skipUnsupportedType(err, objectType) {
+			result.SkippedTypes = append(result.SkippedTypes, objectType)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s objects in space %s: %w", objectType, sourceSpace, err)
+		}
+		if len(found) == 0 {
+			continue
+		}
+		result.Types[objectType] = &SpaceCopyTypeCount{Found: len(found)}
+		objects = append(objects, found...)
+	}
+
+	for start := 0; start < len(objects); start += spaceCopyBatchSize {
+		end := min(start+spaceCopyBatchSize, len(objects))
+		if err := source.copySavedObjectsBatch(ctx, targetSpace, objects[start:end], overwrite, result); err != nil {
+			return result, fmt.Errorf("failed to copy batch %d: %w", result.Batches+1, err)
+		}
+		result.Batches++
+	}
+
+	sort.Slice(result.Conflicts, func(i, j int) bool { return result.Conflicts[i].ID < result.Conflicts[j].ID })
+	logrus.WithFields(logrus.Fields{
+		"objects":   len(objects),
+		"conflicts": len(result.Conflicts),
+		"errors":    len(result.Errors),
+	}).Debug("Copied Kibana space objects")
+	return result, nil
+}
+
+// listAllSavedObjects pages through _find and returns every object of a type in the client's space
+func (c *Client) listAllSavedObjects(ctx context.Context, objectType string) ([]savedObjectRef, error) {
+	var refs []savedObjectRef
+	for page := 1; ; page++ {
+		result, err := c.SearchSavedObjects(ctx, objectType, "", page, spaceCopyFindPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range result.SavedObjects {
+			refs = append(refs, savedObjectRef{Type: obj.Type, ID: obj.ID})
+		}
+		if len(result.SavedObjects) == 0 || len(refs) >= result.Total {
+			return refs, nil
+		}
+	}
+}
+
+// copySavedObjectsBatch copies one batch of objects and records the outcome in result
+func (c *Client) copySavedObjectsBatch(ctx context.Context, targetSpace string, objects []savedObjectRef, overwrite bool, result *SpaceCopyResult) error {
+	body := map[string]interface{}{
+		"spaces":            []string{targetSpace},
+		"objects":           objects,
+		"includeReferences": true,
+		"overwrite":         overwrite,
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", "spaces/_copy_saved_objects", body)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := c.handleResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	var copyResult map[string]struct {
+		Success        bool `json:"success"`
+		SuccessCount   int  `json:"successCount"`
+		SuccessResults []struct {
+			Type string `json:"type"`
+			ID   string `json:"id"`
+		} `json:"successResults"`
+		Errors []struct {
+			Type  string `json:"type"`
+			ID    string `json:"id"`
+			Title string `json:"title"`
+			Error struct {
+				Type string `json:"type"`
+			} `json:"error"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &copyResult); err != nil {
+		return fmt.Errorf("failed to unmarshal copy result: %w", err)
+	}
+
+	outcome, ok := copyResult[targetSpace]
+	if !ok {
+		return fmt.Errorf("copy result does not include target space %s", targetSpace)
+	}
+	for _, copied := range outcome.SuccessResults {
+		count, ok := result.Types[copied.Type]
+		if !ok {
+			// Referenced objects of types outside the filter are copied too
+			count = &SpaceCopyTypeCount{}
+			result.Types[copied.Type] = count
+		}
+		count.Copied++
+	}
+	for _, failed := range outcome.Errors {
+		entry := SpaceCopyError{Type: failed.Type, ID: failed.ID, Title: failed.Title, ErrorType: failed.Error.Type}
+		if failed.Error.Type == "conflict" || failed.Error.Type == "ambiguous_conflict" {
+			result.Conflicts = append(result.Conflicts, entry)
+		} else {
+			result.Errors = append(result.Errors, entry)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCopySpaceObjects(t *testing.T) {
+	var copied []savedObjectRef
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/spaces/dev/saved_objects/_find":
+			switch r.URL.Query().Get("type") + "/" + r.URL.Query().Get("page") {
+			case "dashboard/1":
+				_, _ = w.Write([]byte(`{"page":1,"per_page":2,"total":3,"saved_objects":[{"id":"d1","type":"dashboard"},{"id":"d2","type":"dashboard"}]}`))
+			case "dashboard/2":
+				_, _ = w.Write([]byte(`{"page":2,"per_page":2,"total":3,"saved_objects":[{"id":"d3","type":"dashboard"}]}`))
+			case "bogus/1":
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"statusCode":400,"error":"Bad Request","message":"Unsupported saved object type(s): bogus: Bad Request"}`))
+			default:
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Unable to find lens"}`))
+			}
+		case "/api/spaces/dev/spaces/_copy_saved_objects":
+			var body struct {
+				Spaces            []string         `json:"spaces"`
+				Objects           []savedObjectRef `json:"objects"`
+				IncludeReferences bool             `json:"includeReferences"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode copy body: %v", err)
+			}
+			if !body.IncludeReferences || len(body.Spaces) != 1 || body.Spaces[0] != "prod" {
+				t.Fatalf("unexpected copy body: %+v", body)
+			}
+			copied = append(copied, body.Objects...)
+			_, _ = w.Write([]byte(`{"prod":{"success":false,"successCount":3,
+				"successResults":[{"type":"dashboard","id":"d1"},{"type":"dashboard","id":"d3"},{"type":"index-pattern","id":"ip1"}],
+				"errors":[{"type":"dashboard","id":"d2","title":"Ops","error":{"type":"conflict"}}]}}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	result, err := client.CopySpaceObjects(context.Background(), "dev", "prod", []string{"dashboard", "bogus"}, false)
+	if err != nil {
+		t.Fatalf("CopySpaceObjects() error = %v", err)
+	}
+	if len(copied) != 3 || result.Batches != 1 {
+		t.Fatalf("expected 3 objects in 1 batch, got %d objects in %d batches", len(copied), result.Batches)
+	}
+	if got := result.Types["dashboard"]; got == nil || got.Found != 3 || got.Copied != 2 {
+		t.Fatalf("unexpected dashboard counts: %+v", got)
+	}
+	if got := result.Types["index-pattern"]; got == nil || got.Copied != 1 {
+		t.Fatalf("expected referenced index-pattern to be counted, got %+v", got)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].ID != "d2" {
+		t.Fatalf("unexpected conflicts: %+v", result.Conflicts)
+	}
+	if len(result.SkippedTypes) != 1 || result.SkippedTypes[0] != "bogus" {
+		t.Fatalf("unexpected skipped types: %+v", result.SkippedTypes)
+	}
+	if client.space != "default" {
+		t.Fatalf("copying must not change the client's space, got %q", client.space)
+	}
+
+	// A type that cannot be listed for any other reason fails the copy instead of being skipped
+	copied = nil
+	if _, err := client.CopySpaceObjects(context.Background(), "dev", "prod", []string{"dashboard", "lens"}, false); err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Fatalf("expected the forbidden type to fail the copy, got %v", err)
+	}
+	if copied != nil {
+		t.Fatalf("expected nothing to be copied, got %+v", copied)
+	}
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SpaceUsageTypes are the saved object types counted per space.
+var SpaceUsageTypes = []string{
+	"dashboard",
+	"visualization",
+	"lens",
+	"index-pattern",
+	"search",
+	"map",
+	"canvas-workpad",
+}
+
+// SpaceObjectCounts is the number of saved objects of each type in a space.
+type SpaceObjectCounts struct {
+	SpaceID      string         `json:"spaceId"`
+	Name         string         `json:"name,omitempty"`
+	Total        int            `json:"total"`
+	Counts       map[string]int `json:"counts"`
+	SkippedTypes []string       `json:"skippedTypes,omitempty"`
+}
+
+// GetSpaceObjectCounts counts the saved objects of each of SpaceUsageTypes in a space. Only the
+// totals of _find are read, so the cost does not grow with the number of objects. Types the Kibana
+// version does not know (e.g. map without the Maps plugin) are reported as skipped.
+func (c *Client) GetSpaceObjectCounts(ctx context.Context, spaceID string) (*SpaceObjectCounts, error) {
+	logrus.WithField("space", spaceID).Debug("Counting Kibana space objects")
+
+	if spaceID == "" {
+		spaceID = "default"
+	}
+
+	space := c.inSpace(spaceID)
+	counts := &SpaceObjectCounts{SpaceID: spaceID, Counts: map[string]int{}}
+	for _, objectType := range SpaceUsageTypes {
+		result, err := space.SearchSavedObjects(ctx, objectType, "", 1, 1)
+		if skipUnsupportedType(err, objectType) {
+			counts.SkippedTypes = append(counts.SkippedTypes, objectType)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s objects in space %s: %w", objectType, spaceID, err)
+		}
+		counts.Counts[objectType] = result.Total
+		counts.Total += result.Total
+	}
+	if len(counts.SkippedTypes) == len(SpaceUsageTypes) {
+		return nil, fmt.Errorf("failed to count saved objects in space %s: no type could be queried", spaceID)
+	}
+
+	logrus.WithFields(logrus.Fields{"space": spaceID, "total": counts.Total}).Debug("Counted Kibana space objects")
+	return counts, nil
+}
+
+// GetAllSpaceObjectCounts counts saved objects in every space, largest spaces first.
+func (c *Client) GetAllSpaceObjectCounts(ctx context.Context) ([]SpaceObjectCounts, error) {
+	spaces, err := c.GetSpaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]SpaceObjectCounts, 0, len(spaces))
+	for _, space := range spaces {
+		counts, err := c.GetSpaceObjectCounts(ctx, space.ID)
+		if err != nil {
+			return nil, err
+		}
+		counts.Name = space.Name
+		usage = append(usage, *counts)
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool { return usage[i].Total > usage[j].Total })
+	return usage, nil
+}
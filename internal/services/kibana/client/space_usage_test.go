@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetAllSpaceObjectCounts(t *testing.T) {
+	totals := map[string]map[string]string{
+		"/api/saved_objects/_find":                {"dashboard": "4", "visualization": "10", "lens": "2", "index-pattern": "3", "search": "1", "canvas-workpad": "0"},
+		"/api/spaces/sandbox/saved_objects/_find": {"dashboard": "0", "visualization": "0", "lens": "0", "index-pattern": "0", "search": "0", "canvas-workpad": "0"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/spaces/space" {
+			_, _ = w.Write([]byte(`[{"id":"sandbox","name":"Sandbox"},{"id":"default","name":"Default"}]`))
+			return
+		}
+		if r.URL.Path == "/api/spaces/restricted/saved_objects/_find" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Unable to find dashboard"}`))
+			return
+		}
+		byType, ok := totals[r.URL.Path]
+		if !ok {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("per_page") != "1" {
+			t.Fatalf("expected counts to request a single object, got per_page=%s", r.URL.Query().Get("per_page"))
+		}
+		total, ok := byType[r.URL.Query().Get("type")]
+		if !ok {
+			// The Maps plugin is not installed
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Unsupported saved object type: 'map'"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"page":1,"per_page":1,"total":` + total + `,"saved_objects":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	usage, err := client.GetAllSpaceObjectCounts(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllSpaceObjectCounts() error = %v", err)
+	}
+	if len(usage) != 2 || usage[0].SpaceID != "default" || usage[0].Name != "Default" || usage[1].SpaceID != "sandbox" {
+		t.Fatalf("expected the largest space first, got %+v", usage)
+	}
+	if usage[0].Total != 20 || usage[0].Counts["visualization"] != 10 || usage[0].Counts["canvas-workpad"] != 0 {
+		t.Fatalf("unexpected default space counts: %+v", usage[0])
+	}
+	if usage[1].Total != 0 {
+		t.Fatalf("expected the sandbox space to be empty, got %+v", usage[1])
+	}
+	if len(usage[0].SkippedTypes) != 1 || usage[0].SkippedTypes[0] != "map" {
+		t.Fatalf("expected the map type to be skipped, got %+v", usage[0].SkippedTypes)
+	}
+	if client.space != "default" {
+		t.Fatalf("counting must not change the client's space, got %q", client.space)
+	}
+
+	// Authorization and server errors are returned rather than counted as skipped types
+	if counts, err := client.GetSpaceObjectCounts(context.Background(), "restricted"); err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Fatalf("expected the forbidden space to fail, got %+v, %v", counts, err)
+	}
+}
@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrSyntheticsNotAvailable is returned when the target Kibana does not expose the Synthetics API.
+var ErrSyntheticsNotAvailable = errors.New("the Synthetics API is not available in this Kibana (Synthetics app not installed or not permitted)")
+
+// syntheticsIndexPattern matches the data streams Synthetics writes check results to
+const syntheticsIndexPattern = "synthetics-*"
+
+// SyntheticsMonitor describes a Synthetics monitor configuration.
+type SyntheticsMonitor struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	URL       string   `json:"url,omitempty"`
+	Enabled   bool     `json:"enabled"`
+	Schedule  string   `json:"schedule,omitempty"`
+	Locations []string `json:"locations,omitempty"`
+}
+
+// SyntheticsMonitorList is a page of Synthetics monitors.
+type SyntheticsMonitorList struct {
+	Page     int                 `json:"page"`
+	PerPage  int                 `json:"perPage"`
+	Total    int                 `json:"total"`
+	Monitors []SyntheticsMonitor `json:"monitors"`
+}
+
+// SyntheticsMonitorStatus is the outcome of the most recent check of a monitor.
+type SyntheticsMonitorStatus struct {
+	MonitorID  string  `json:"monitorId"`
+	Status     string  `json:"status"`
+	LastCheck  string  `json:"lastCheck,omitempty"`
+	Location   string  `json:"location,omitempty"`
+	URL        string  `json:"url,omitempty"`
+	DurationMs float64 `json:"durationMs,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// GetSyntheticsMonitors retrieves Synthetics monitors with pagination.
+func (c *Client) GetSyntheticsMonitors(ctx context.Context, page, perPage int) (*SyntheticsMonitorList, error) {
+	logrus.WithFields(logrus.Fields{
+		"page":    page,
+		"perPage": perPage,
+	}).Debug("Getting Synthetics monitors")
+
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("perPage", fmt.Sprintf("%d", perPage))
+
+	resp, err := c.makeRequest(ctx, "GET", "synthetics/monitors?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, ErrSyntheticsNotAvailable
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Page     int `json:"page"`
+		PerPage  int `json:"perPage"`
+		Total    int `json:"total"`
+		Monitors []struct {
+			ID       string `json:"id"`
+			ConfigID string `json:"config_id"`
+			Name     string `json:"name"`
+			Type     string `json:"type"`
+			URL      string `json:"url"`
+			URLs     string `json:"urls"`
+			Hosts    string `json:"hosts"`
+			Enabled  bool   `json:"enabled"`
+			Schedule struct {
+				Number string `json:"number"`
+				Unit   string `json:"unit"`
+			} `json:"schedule"`
+			Locations []struct {
+				ID    string `json:"id"`
+				Label string `json:"label"`
+			} `json:"locations"`
+		} `json:"monitors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Synthetics monitors: %w", err)
+	}
+
+	list := &SyntheticsMonitorList{
+		Page:     result.Page,
+		PerPage:  result.PerPage,
+		Total:    result.Total,
+		Monitors: make([]SyntheticsMonitor, 0, len(result.Monitors)),
+	}
+	if list.PerPage == 0 {
+		list.PerPage = perPage
+	}
+	for _, raw := range result.Monitors {
+		monitor := SyntheticsMonitor{
+			ID:      raw.ID,
+			Name:    raw.Name,
+			Type:    raw.Type,
+			Enabled: raw.Enabled,
+		}
+		if monitor.ID == "" {
+			monitor.ID = raw.ConfigID
+		}
+		for _, candidate := range []string{raw.URL, raw.URLs, raw.Hosts} {
+			if candidate != "" {
+				monitor.URL = candidate
+				break
+			}
+		}
+		if raw.Schedule.Number != "" {
+			monitor.Schedule = raw.Schedule.Number + raw.Schedule.Unit
+		}
+		for _, location := range raw.Locations {
+			label := location.Label
+			if label == "" {
+				label = location.ID
+			}
+			monitor.Locations = append(monitor.Locations, label)
+		}
+		list.Monitors = append(list.Monitors, monitor)
+	}
+
+	logrus.WithField("count", len(list.Monitors)).Debug("Retrieved Synthetics monitors")
+	return list, nil
+}
+
+// GetSyntheticsMonitorStatus returns the outcome of the most recent check of a monitor.
+// The status is "unknown" when no check results have been recorded yet.
+func (c *Client) GetSyntheticsMonitorStatus(ctx context.Context, monitorID string) (*SyntheticsMonitorStatus, error) {
+	logrus.WithField("monitor_id", monitorID).Debug("Getting Synthetics monitor status")
+
+	statuses, err := c.LatestSyntheticsStatuses(ctx, []string{monitorID})
+	if err != nil {
+		return nil, err
+	}
+	if status, ok := statuses[monitorID]; ok {
+		return &status, nil
+	}
+	return &SyntheticsMonitorStatus{MonitorID: monitorID, Status: "unknown"}, nil
+}
+
+// LatestSyntheticsStatuses returns the most recent check result per monitor ID, read from the
+// synthetics-* data streams. Monitors without results are absent from the map.
+func (c *Client) LatestSyntheticsStatuses(ctx context.Context, monitorIDs []string) (map[string]SyntheticsMonitorStatus, error) {
+	statuses := make(map[string]SyntheticsMonitorStatus, len(monitorIDs))
+	if len(monitorIDs) == 0 {
+		return statuses, nil
+	}
+
+	query := map[string]interface{}{
+		"size": len(monitorIDs),
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"exists": map[string]interface{}{"field": "summary"}},
+					map[string]interface{}{"bool": map[string]interface{}{
+						"should": []interface{}{
+							map[string]interface{}{"terms": map[string]interface{}{"monitor.id": monitorIDs}},
+							map[string]interface{}{"terms": map[string]interface{}{"config_id": monitorIDs}},
+						},
+						"minimum_should_match": 1,
+					}},
+				},
+			},
+		},
+		"collapse": map[string]interface{}{"field": "monitor.id"},
+		"sort":     []interface{}{map[string]interface{}{"@timestamp": "desc"}},
+		"_source":  []string{"@timestamp", "monitor", "config_id", "observer.geo.name", "url.full", "error.message"},
+	}
+
+	resp, err := c.elasticsearchRequest(ctx, "POST", syntheticsIndexPattern+"/_search", query)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				Source struct {
+					Timestamp string `json:"@timestamp"`
+					ConfigID  string `json:"config_id"`
+					Monitor   struct {
+						ID       string `json:"id"`
+						Status   string `json:"status"`
+						Duration struct {
+							US float64 `json:"us"`
+						} `json:"duration"`
+					} `json:"monitor"`
+					Observer struct {
+						Geo struct {
+							Name string `json:"name"`
+						} `json:"geo"`
+					} `json:"observer"`
+					URL struct {
+						Full string `json:"full"`
+					} `json:"url"`
+					Error struct {
+						Message string `json:"message"`
+					} `json:"error"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Synthetics check results: %w", err)
+	}
+
+	requested := make(map[string]bool, len(monitorIDs))
+	for _, id := range monitorIDs {
+		requested[id] = true
+	}
+	for _, hit := range result.Hits.Hits {
+		src := hit.Source
+		status := SyntheticsMonitorStatus{
+			MonitorID:  src.Monitor.ID,
+			Status:     src.Monitor.Status,
+			LastCheck:  src.Timestamp,
+			Location:   src.Observer.Geo.Name,
+			URL:        src.URL.Full,
+			DurationMs: src.Monitor.Duration.US / 1000,
+			Error:      src.Error.Message,
+		}
+		// Results are keyed by the ID the caller asked for, which may be the config ID
+		key := src.Monitor.ID
+		if !requested[key] && requested[src.ConfigID] {
+			key = src.ConfigID
+		}
+		status.MonitorID = key
+		statuses[key] = status
+	}
+	return statuses, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetSyntheticsMonitorsAndStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/synthetics/monitors":
+			_, _ = w.Write([]byte(`{"page":1,"perPage":20,"total":1,"monitors":[{"config_id":"m1","name":"Checkout API","type":"http","urls":"https://shop.example.com/health","enabled":true,"schedule":{"number":"3","unit":"m"},"locations":[{"id":"us_east","label":"US East"}]}]}`))
+		case "/api/console/proxy":
+			if r.URL.Query().Get("path") != "synthetics-*/_search" {
+				t.Fatalf("unexpected proxy path %q", r.URL.Query().Get("path"))
+			}
+			_, _ = w.Write([]byte(`{"hits":{"hits":[{"_source":{"@timestamp":"2024-05-01T10:00:00Z","config_id":"m1","monitor":{"id":"m1","status":"down","duration":{"us":1500}},"observer":{"geo":{"name":"US East"}},"url":{"full":"https://shop.example.com/health"},"error":{"message":"503 Service Unavailable"}}}]}}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	list, err := client.GetSyntheticsMonitors(context.Background(), 1, 20)
+	if err != nil {
+		t.Fatalf("GetSyntheticsMonitors() error = %v", err)
+	}
+	if len(list.Monitors) != 1 {
+		t.Fatalf("unexpected monitors: %+v", list)
+	}
+	monitor := list.Monitors[0]
+	if monitor.ID != "m1" || monitor.URL != "https://shop.example.com/health" || monitor.Schedule != "3m" || monitor.Locations[0] != "US East" {
+		t.Fatalf("unexpected monitor: %+v", monitor)
+	}
+
+	status, err := client.GetSyntheticsMonitorStatus(context.Background(), "m1")
+	if err != nil {
+		t.Fatalf("GetSyntheticsMonitorStatus() error = %v", err)
+	}
+	if status.Status != "down" || status.LastCheck != "2024-05-01T10:00:00Z" || status.DurationMs != 1.5 || status.Error == "" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestGetSyntheticsMonitorsNotInstalled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&ClientOptions{URL: server.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetSyntheticsMonitors(context.Background(), 1, 20); !errors.Is(err, ErrSyntheticsNotAvailable) {
+		t.Fatalf("expected ErrSyntheticsNotAvailable, got %v", err)
+	}
+}
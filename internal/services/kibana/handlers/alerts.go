@@ -58,18 +58,30 @@ func HandleGetAlertRules() func(ctx context.Context, req mcp.CallToolRequest) (*
 			return mcp.NewToolResultError(cerr.Error()), nil
 		}
 
-		page := getOptionalIntParam(req, "page", 1)
+		page, err := getPageParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		perPage := getOptionalIntParam(req, "per_page", 20)
 		filter := getOptionalStringParam(req, "filter")
 		enabled := getOptionalBoolParam(req, "enabled")
+		sortField := getOptionalStringParam(req, "sort_field")
+		sortOrder := getOptionalStringParam(req, "sort_order")
+		tags, err := getOptionalStringArrayParam(req, "tags")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		logrus.WithFields(logrus.Fields{
-			"page":    page,
-			"perPage": perPage,
-			"filter":  filter,
+			"page":      page,
+			"perPage":   perPage,
+			"filter":    filter,
+			"tags":      tags,
+			"sortField": sortField,
+			"sortOrder": sortOrder,
 		}).Debug("Executing Kibana get alert rules handler")
 
-		rules, err := c.GetAlertRules(ctx, page, perPage, filter, enabled)
+		list, err := c.GetAlertRules(ctx, page, perPage, filter, enabled, tags, sortField, sortOrder)
 		if err != nil {
 			return &mcp.CallToolResult{
 				IsError: true,
@@ -79,7 +91,14 @@ func HandleGetAlertRules() func(ctx context.Context, req mcp.CallToolRequest) (*
 			}, nil
 		}
 
-		resultJSON, err := marshalIndentJSON(rules)
+		response := map[string]interface{}{
+			"rules":      list.Data,
+			"count":      len(list.Data),
+			"total":      list.Total,
+			"pagination": client.NewPaginationInfo(list.Page, list.PerPage, list.Total, len(list.Data)),
+		}
+
+		resultJSON, err := marshalIndentJSON(response)
 		if err != nil {
 			return &mcp.CallToolResult{
 				IsError: true,
@@ -97,6 +116,148 @@ func HandleGetAlertRules() func(ctx context.Context, req mcp.CallToolRequest) (*
 	}
 }
 
+// HandleGetAlertRulesSummary handles the alert rule execution status rollup.
+func HandleGetAlertRulesSummary() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		logrus.Debug("Executing Kibana alert rules summary handler")
+
+		aggregate, err := c.GetAlertRulesAggregate(ctx)
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to summarize alert rules: %v", err)),
+				},
+			}, nil
+		}
+
+		response := map[string]interface{}{
+			"summary":  aggregate,
+			"erroring": aggregate.ExecutionStatus["error"],
+			"healthy":  aggregate.ExecutionStatus["error"] == 0,
+		}
+		if aggregate.ExecutionStatus["error"] > 0 {
+			response["hint"] = "Use kibana_get_alert_rules with sort_field=executionStatus to list the failing rules"
+		}
+
+		resultJSON, err := marshalIndentJSON(response)
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to format alert rules summary: %v", err)),
+				},
+			}, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+}
+
+// HandleBulkEnableAlertRules handles enabling several alert rules at once.
+func HandleBulkEnableAlertRules() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return handleBulkAlertRules("enable", func(ctx context.Context, c *client.Client, _ mcp.CallToolRequest, ids []string) []client.AlertRuleBulkResult {
+		return c.BulkEnableAlertRules(ctx, ids)
+	})
+}
+
+// HandleBulkDisableAlertRules handles disabling several alert rules at once.
+func HandleBulkDisableAlertRules() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return handleBulkAlertRules("disable", func(ctx context.Context, c *client.Client, _ mcp.CallToolRequest, ids []string) []client.AlertRuleBulkResult {
+		return c.BulkDisableAlertRules(ctx, ids)
+	})
+}
+
+// HandleBulkMuteAlertRules handles muting several alert rules at once.
+func HandleBulkMuteAlertRules() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return handleBulkAlertRules("mute", func(ctx context.Context, c *client.Client, req mcp.CallToolRequest, ids []string) []client.AlertRuleBulkResult {
+		return c.BulkMuteAlertRules(ctx, ids, getOptionalStringParam(req, "duration"))
+	})
+}
+
+// handleBulkAlertRules selects rules by rule_ids and/or tags and applies a bulk action to them,
+// reporting the outcome for each rule.
+func handleBulkAlertRules(action string, apply func(context.Context, *client.Client, mcp.CallToolRequest, []string) []client.AlertRuleBulkResult) func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		ruleIDs, err := getOptionalStringArrayParam(req, "rule_ids")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		tags, err := getOptionalStringArrayParam(req, "tags")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if len(ruleIDs) == 0 && len(tags) == 0 {
+			return mcp.NewToolResultError("rule_ids or tags is required"), nil
+		}
+		if action == "mute" && getOptionalStringParam(req, "duration") == "" {
+			return mcp.NewToolResultError("duration is required"), nil
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"action":  action,
+			"ruleIds": len(ruleIDs),
+			"tags":    tags,
+		}).Debug("Executing Kibana bulk alert rules handler")
+
+		if len(tags) > 0 {
+			tagged, err := c.ResolveAlertRuleIDs(ctx, tags)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to find alert rules by tags: %v", err)), nil
+			}
+			ruleIDs = append(ruleIDs, tagged...)
+		}
+		if len(ruleIDs) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("No alert rules carry the tags %v", tags)), nil
+		}
+		if len(ruleIDs) > client.MaxAlertRuleBulkSize {
+			return mcp.NewToolResultError(fmt.Sprintf("Refusing to %s %d rules at once (max %d)", action, len(ruleIDs), client.MaxAlertRuleBulkSize)), nil
+		}
+
+		results := apply(ctx, c, req, ruleIDs)
+		succeeded := 0
+		for _, result := range results {
+			if result.Success {
+				succeeded++
+			}
+		}
+
+		response := map[string]interface{}{
+			"action":    action,
+			"requested": len(results),
+			"succeeded": succeeded,
+			"failed":    len(results) - succeeded,
+			"results":   results,
+		}
+
+		resultJSON, err := marshalIndentJSON(response)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format bulk %s results: %v", action, err)), nil
+		}
+
+		return &mcp.CallToolResult{
+			IsError: succeeded == 0,
+			Content: []mcp.Content{
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+}
+
 // HandleGetAlertRule handles getting a specific alert rule.
 func HandleGetAlertRule() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/sirupsen/logrus"
@@ -60,6 +61,20 @@ func getOptionalIntParam(request mcp.CallToolRequest, param string, defaultValue
 	return defaultValue
 }
 
+// getPageParam returns the requested page number. A continueToken from a previous
+// response's pagination object takes precedence over the page argument.
+func getPageParam(request mcp.CallToolRequest) (int, error) {
+	token := getOptionalStringParam(request, "continueToken")
+	if token == "" {
+		return getOptionalIntParam(request, "page", 1), nil
+	}
+	page, err := strconv.Atoi(token)
+	if err != nil || page < 1 {
+		return 0, fmt.Errorf("invalid continueToken %q", token)
+	}
+	return page, nil
+}
+
 // getOptionalBoolParam gets optional boolean parameter
 func getOptionalBoolParam(request mcp.CallToolRequest, param string) *bool {
 	value, err := svccommon.GetBoolArg(request.GetArguments(), param)
@@ -142,7 +157,7 @@ func HandleTestConnection() func(ctx context.Context, req mcp.CallToolRequest) (
 		logrus.Debug("Testing Kibana connection")
 
 		// Test connection
-		err := c.TestConnection(ctx)
+		info, err := c.GetConnectionInfo(ctx)
 		if err != nil {
 			return &mcp.CallToolResult{
 				IsError: true,
@@ -152,9 +167,30 @@ func HandleTestConnection() func(ctx context.Context, req mcp.CallToolRequest) (
 			}, nil
 		}
 
+		unavailable := []string{}
+		for _, feature := range []string{"alerting", "actions", "fleet"} {
+			if !info.Features[feature].Available {
+				unavailable = append(unavailable, feature)
+			}
+		}
+
+		resultJSON, err := marshalIndentJSON(map[string]interface{}{
+			"connected":           true,
+			"connection":          info,
+			"unavailableFeatures": unavailable,
+		})
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to format connection info: %v", err)),
+				},
+			}, nil
+		}
+
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				mcp.NewTextContent("Connection test successful"),
+				mcp.NewTextContent(string(resultJSON)),
 			},
 		}, nil
 	}
@@ -4,6 +4,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -318,3 +319,49 @@ func HandleGetConnectorTypes() func(ctx context.Context, req mcp.CallToolRequest
 		}, nil
 	}
 }
+
+// HandleGetConnectorExecutionHistory handles reading the recent executions of a connector.
+func HandleGetConnectorExecutionHistory() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		connectorID, err := requireStringParam(req, "connector_id")
+		if err != nil {
+			return nil, err
+		}
+		page := getOptionalIntParam(req, "page", 1)
+		perPage := getOptionalIntParam(req, "per_page", 20)
+
+		logrus.WithFields(logrus.Fields{
+			"tool":         "kibana_get_connector_execution_history",
+			"connector_id": connectorID,
+			"page":         page,
+			"perPage":      perPage,
+		}).Debug("Handler invoked")
+
+		history, err := c.GetConnectorExecutionHistory(ctx, connectorID, page, perPage)
+		if err != nil {
+			if errors.Is(err, client.ErrEventLogNotAvailable) {
+				// Reported as a result so callers can fall back to kibana_test_connector
+				return marshalOptimizedResponse(map[string]interface{}{
+					"available":   false,
+					"connectorId": connectorID,
+					"message":     err.Error(),
+				}, "kibana_get_connector_execution_history")
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get connector execution history: %v", err)), nil
+		}
+
+		response := map[string]interface{}{
+			"available":   true,
+			"connectorId": history.ConnectorID,
+			"failures":    history.Failures,
+			"executions":  history.Executions,
+			"pagination":  client.NewPaginationInfo(history.Page, history.PerPage, history.Total, len(history.Executions)),
+		}
+		return marshalOptimizedResponse(response, "kibana_get_connector_execution_history")
+	}
+}
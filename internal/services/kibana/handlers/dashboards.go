@@ -74,13 +74,21 @@ func HandleGetDashboard() func(ctx context.Context, req mcp.CallToolRequest) (*m
 			}, nil
 		}
 
-		// Get dashboard ID parameter
-		dashboardID, err := requireStringParam(req, "dashboard_id")
+		// Get dashboard ID parameter, resolving a title when no ID is given
+		dashboardID, err := resolveDashboardParam(ctx, c, getOptionalStringParam(req, "dashboard_id"), getOptionalStringParam(req, "dashboard_title"))
 		if err != nil {
 			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{
-					mcp.NewTextContent("Dashboard ID parameter is required"),
+					mcp.NewTextContent(fmt.Sprintf("Failed to resolve dashboard: %v", err)),
+				},
+			}, nil
+		}
+		if dashboardID == "" {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent("Either dashboard_id or dashboard_title is required"),
 				},
 			}, nil
 		}
@@ -115,6 +123,61 @@ func HandleGetDashboard() func(ctx context.Context, req mcp.CallToolRequest) (*m
 	}
 }
 
+// HandleGetDashboardByTitle handles Kibana dashboard retrieval by exact title.
+func HandleGetDashboardByTitle() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		title, err := requireStringParam(req, "title")
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithField("title", title).Debug("Executing Kibana get dashboard by title handler")
+
+		dashboard, err := c.GetDashboardByTitle(ctx, title)
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to get dashboard: %v", err)),
+				},
+			}, nil
+		}
+
+		resultJSON, err := marshalIndentJSON(dashboard)
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to format dashboard: %v", err)),
+				},
+			}, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+}
+
+// resolveDashboardParam returns the dashboard ID given directly or by title. An explicit ID wins.
+func resolveDashboardParam(ctx context.Context, c *client.Client, dashboardID, title string) (string, error) {
+	if dashboardID != "" || title == "" {
+		return dashboardID, nil
+	}
+	dashboard, err := c.GetDashboardByTitle(ctx, title)
+	if err != nil {
+		return "", err
+	}
+	return dashboard.ID, nil
+}
+
 // HandleCreateDashboard handles creating a new dashboard
 func HandleCreateDashboard() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -343,7 +406,10 @@ func HandleDashboardsPaginated() func(ctx context.Context, request mcp.CallToolR
 			return mcp.NewToolResultError(cerr.Error()), nil
 		}
 
-		page := getOptionalIntParam(request, "page", 1)
+		page, err := getPageParam(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		perPage := getOptionalIntParam(request, "per_page", 20)
 		search := getOptionalStringParam(request, "search")
 		includeDescription := getOptionalBoolParam(request, "include_description")
@@ -391,9 +457,12 @@ func HandleGetDashboardDetailAdvanced() func(ctx context.Context, request mcp.Ca
 			return mcp.NewToolResultError(cerr.Error()), nil
 		}
 
-		dashboardID, err := requireStringParam(request, "dashboard_id")
+		dashboardID, err := resolveDashboardParam(ctx, c, getOptionalStringParam(request, "dashboard_id"), getOptionalStringParam(request, "dashboard_title"))
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to resolve dashboard: %w", err)
+		}
+		if dashboardID == "" {
+			return nil, fmt.Errorf("either dashboard_id or dashboard_title is required")
 		}
 
 		includePanels := getOptionalBoolParam(request, "include_panels")
@@ -99,6 +99,57 @@ func HandleGetDataView() func(ctx context.Context, req mcp.CallToolRequest) (*mc
 	}
 }
 
+// HandleResolveDataView handles translating a data view title to its ID.
+func HandleResolveDataView() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		title, err := requireStringParam(req, "title")
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithField("title", title).Debug("Executing Kibana resolve data view handler")
+
+		dataViewID, err := c.ResolveDataViewByTitle(ctx, title)
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to resolve data view: %v", err)),
+				},
+			}, nil
+		}
+
+		resultJSON, err := marshalIndentJSON(map[string]string{"title": title, "id": dataViewID})
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("Failed to format data view: %v", err)),
+				},
+			}, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(string(resultJSON)),
+			},
+		}, nil
+	}
+}
+
+// resolveDataViewParam returns the data view ID given directly or by title. An explicit ID wins.
+func resolveDataViewParam(ctx context.Context, c *client.Client, dataViewID, title string) (string, error) {
+	if dataViewID != "" || title == "" {
+		return dataViewID, nil
+	}
+	return c.ResolveDataViewByTitle(ctx, title)
+}
+
 // HandleCreateDataView handles creating a new data view.
 func HandleCreateDataView() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -250,3 +301,50 @@ func HandleDeleteDataView() func(ctx context.Context, req mcp.CallToolRequest) (
 		}, nil
 	}
 }
+
+// HandleMigrateDataViewReferences handles repointing the saved objects referencing a data view to another one.
+func HandleMigrateDataViewReferences() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		oldDataViewID, err := requireStringParam(req, "oldDataViewID")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		newDataViewID, err := requireStringParam(req, "newDataViewID")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		types, err := getOptionalStringArrayParam(req, "types")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		dryRun := false
+		if v := getOptionalBoolParam(req, "dryRun"); v != nil {
+			dryRun = *v
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":          "kibana_migrate_data_view_references",
+			"oldDataViewID": oldDataViewID,
+			"newDataViewID": newDataViewID,
+			"types":         types,
+			"dryRun":        dryRun,
+		}).Debug("Handler invoked")
+
+		result, err := c.MigrateDataViewReferences(ctx, oldDataViewID, newDataViewID, types, dryRun)
+		if err != nil {
+			if result == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to migrate data view references: %v", err)), nil
+			}
+			// Earlier batches were already updated; report them alongside the failure
+			resultJSON, _ := marshalIndentJSON(result)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to migrate data view references: %v\nPartial result: %s", err, resultJSON)), nil
+		}
+
+		return marshalOptimizedResponse(result, "kibana_migrate_data_view_references")
+	}
+}
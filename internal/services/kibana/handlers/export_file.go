@@ -0,0 +1,131 @@
+// Package handlers provides HTTP handlers for Kibana MCP operations.
+// This file writes saved object exports and reports to the server's export directory and resolves
+// import files within the allowed import directory.
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	exportDirMu sync.RWMutex
+	exportDir   string
+	importDir   string
+)
+
+// SetExportDir sets the directory, typically from the kibana.exportDir config, that exports
+// requested with output=file and generated reports are written to. An empty dir selects kibana-exports in the
+// system temporary directory.
+func SetExportDir(dir string) {
+	exportDirMu.Lock()
+	exportDir = dir
+	exportDirMu.Unlock()
+}
+
+// SetImportDir sets the directory, typically from the kibana.importDir config, that saved object
+// imports may read files from. An empty dir allows the export directory, so exports written with
+// output=file can be imported again.
+func SetImportDir(dir string) {
+	exportDirMu.Lock()
+	importDir = dir
+	exportDirMu.Unlock()
+}
+
+func currentExportDir() string {
+	exportDirMu.RLock()
+	defer exportDirMu.RUnlock()
+	if exportDir == "" {
+		return filepath.Join(os.TempDir(), "kibana-exports")
+	}
+	return exportDir
+}
+
+func currentImportDir() string {
+	exportDirMu.RLock()
+	dir := importDir
+	exportDirMu.RUnlock()
+	if dir == "" {
+		return currentExportDir()
+	}
+	return dir
+}
+
+// resolveImportPath resolves a file to import, relative paths against the import directory, and
+// rejects any path that is not inside it once symlinks are followed.
+func resolveImportPath(path string) (string, error) {
+	dir, err := filepath.Abs(currentImportDir())
+	if err != nil {
+		return "", fmt.Errorf("invalid import directory: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read import file %s: %w", path, err)
+	}
+
+	rel, err := filepath.Rel(dir, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("import file %s is outside the allowed import directory %s", path, dir)
+	}
+	return resolved, nil
+}
+
+// writeExportFile writes data to a new file in the export directory, named after name with the given
+// extension, and returns its path
+func writeExportFile(name, extension string, data []byte) (string, error) {
+	dir := currentExportDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create export directory %s: %w", dir, err)
+	}
+
+	file, err := os.CreateTemp(dir, fmt.Sprintf("%s-%s-*.%s", name, time.Now().UTC().Format("20060102T150405Z"), extension))
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file in %s: %w", dir, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write export file %s: %w", file.Name(), err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write export file %s: %w", file.Name(), err)
+	}
+
+	logrus.WithFields(logrus.Fields{"path": file.Name(), "bytes": len(data)}).Debug("Wrote export file")
+	return file.Name(), nil
+}
+
+// countExportedObjects counts the saved objects in NDJSON export data, leaving out the trailing
+// export summary line (the one carrying exportedCount)
+func countExportedObjects(data []byte) int {
+	count := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var summary struct {
+			ExportedCount *int `json:"exportedCount"`
+		}
+		if json.Unmarshal(line, &summary) == nil && summary.ExportedCount != nil {
+			continue
+		}
+		count++
+	}
+	return count
+}
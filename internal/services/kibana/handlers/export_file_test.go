@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveImportPath(t *testing.T) {
+	root := t.TempDir()
+	importDir := filepath.Join(root, "imports")
+	if err := os.MkdirAll(importDir, 0o750); err != nil {
+		t.Fatalf("failed to create import dir: %v", err)
+	}
+	inside := filepath.Join(importDir, "bundle.ndjson")
+	outside := filepath.Join(root, "secret.ndjson")
+	for _, path := range []string{inside, outside} {
+		if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(importDir, "link.ndjson")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	SetImportDir(importDir)
+	defer SetImportDir("")
+
+	for _, path := range []string{inside, "bundle.ndjson"} {
+		resolved, err := resolveImportPath(path)
+		if err != nil || filepath.Base(resolved) != "bundle.ndjson" {
+			t.Fatalf("resolveImportPath(%q) = %q, %v", path, resolved, err)
+		}
+	}
+	for _, path := range []string{outside, "../secret.ndjson", "link.ndjson"} {
+		if _, err := resolveImportPath(path); err == nil || !strings.Contains(err.Error(), "outside the allowed import directory") {
+			t.Fatalf("expected %q to be rejected, got %v", path, err)
+		}
+	}
+	if _, err := resolveImportPath("missing.ndjson"); err == nil {
+		t.Fatal("expected a missing file to be rejected")
+	}
+}
+
+func TestWriteExportFile(t *testing.T) {
+	SetExportDir(filepath.Join(t.TempDir(), "exports"))
+	defer SetExportDir("")
+
+	data := []byte("{\"id\":\"d1\"}\n{\"id\":\"v1\"}\n{\"exportedCount\":2,\"missingRefCount\":0}\n")
+	path, err := writeExportFile("saved-objects", "ndjson", data)
+	if err != nil {
+		t.Fatalf("writeExportFile() error = %v", err)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil || string(written) != string(data) || !strings.HasPrefix(filepath.Base(path), "saved-objects-") || filepath.Ext(path) != ".ndjson" {
+		t.Fatalf("unexpected export file %s: %q, %v", path, written, err)
+	}
+	if got := countExportedObjects(data); got != 2 {
+		t.Fatalf("expected 2 exported objects, got %d", got)
+	}
+}
@@ -0,0 +1,92 @@
+// Package handlers provides HTTP handlers for Kibana MCP operations.
+// This file contains Fleet handlers.
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
+)
+
+// HandleGetFleetAgents handles listing Fleet agents.
+func HandleGetFleetAgents() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		page, err := getPageParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		perPage := getOptionalIntParam(req, "per_page", 20)
+		kuery := getOptionalStringParam(req, "kuery")
+
+		logrus.WithFields(logrus.Fields{
+			"tool":    "kibana_get_fleet_agents",
+			"page":    page,
+			"perPage": perPage,
+			"kuery":   kuery,
+		}).Debug("Handler invoked")
+
+		list, err := c.GetFleetAgents(ctx, page, perPage, kuery)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get Fleet agents: %v", err)), nil
+		}
+
+		// Policy names are a convenience; agents are still returned if policies cannot be read
+		if policies, err := c.GetFleetAgentPolicies(ctx); err != nil {
+			logrus.WithError(err).Debug("Failed to resolve Fleet agent policy names")
+		} else {
+			names := make(map[string]string, len(policies))
+			for _, policy := range policies {
+				names[policy.ID] = policy.Name
+			}
+			for i := range list.Agents {
+				list.Agents[i].PolicyName = names[list.Agents[i].PolicyID]
+			}
+		}
+
+		statusCounts := map[string]int{}
+		for _, agent := range list.Agents {
+			statusCounts[agent.Status]++
+		}
+
+		response := map[string]interface{}{
+			"agents":       list.Agents,
+			"statusCounts": statusCounts,
+			"pagination":   client.NewPaginationInfo(list.Page, list.PerPage, list.Total, len(list.Agents)),
+		}
+
+		return marshalOptimizedResponse(response, "kibana_get_fleet_agents")
+	}
+}
+
+// HandleGetFleetAgentPolicies handles listing Fleet agent policies.
+func HandleGetFleetAgentPolicies() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		logrus.WithField("tool", "kibana_get_fleet_agent_policies").Debug("Handler invoked")
+
+		policies, err := c.GetFleetAgentPolicies(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get Fleet agent policies: %v", err)), nil
+		}
+
+		response := map[string]interface{}{
+			"policies": policies,
+			"count":    len(policies),
+		}
+
+		return marshalOptimizedResponse(response, "kibana_get_fleet_agent_policies")
+	}
+}
@@ -393,3 +393,37 @@ func HandleGetIndexPatternFields() func(ctx context.Context, req mcp.CallToolReq
 		}, nil
 	}
 }
+
+// HandleGetFieldStats handles field existence and cardinality inspection requests.
+func HandleGetFieldStats() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		indexPattern, err := requireStringParam(req, "indexPattern")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		field, err := requireStringParam(req, "field")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		size := client.ClampFieldStatsSize(getOptionalIntParam(req, "size", client.DefaultFieldStatsSize))
+
+		logrus.WithFields(logrus.Fields{
+			"tool":         "kibana_get_field_stats",
+			"indexPattern": indexPattern,
+			"field":        field,
+			"size":         size,
+		}).Debug("Handler invoked")
+
+		stats, err := c.GetFieldStats(ctx, indexPattern, field, size)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get field stats: %v", err)), nil
+		}
+
+		return marshalOptimizedResponse(stats, "kibana_get_field_stats")
+	}
+}
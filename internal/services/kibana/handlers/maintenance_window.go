@@ -0,0 +1,130 @@
+// Package handlers provides HTTP handlers for Kibana MCP operations.
+// This file contains maintenance window handlers.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
+)
+
+// HandleGetMaintenanceWindows handles listing maintenance windows.
+func HandleGetMaintenanceWindows() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		page := getOptionalIntParam(req, "page", 1)
+		perPage := getOptionalIntParam(req, "per_page", 20)
+		status := getOptionalStringParam(req, "status")
+
+		logrus.WithFields(logrus.Fields{
+			"tool":    "kibana_get_maintenance_windows",
+			"page":    page,
+			"perPage": perPage,
+			"status":  status,
+		}).Debug("Handler invoked")
+
+		list, err := c.GetMaintenanceWindows(ctx, page, perPage, status)
+		if err != nil {
+			if errors.Is(err, client.ErrMaintenanceWindowsNotAvailable) {
+				return marshalOptimizedResponse(map[string]interface{}{
+					"available": false,
+					"message":   err.Error(),
+				}, "kibana_get_maintenance_windows")
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get maintenance windows: %v", err)), nil
+		}
+
+		response := map[string]interface{}{
+			"available":          true,
+			"maintenanceWindows": list.MaintenanceWindows,
+			"pagination":         client.NewPaginationInfo(list.Page, list.PerPage, list.Total, len(list.MaintenanceWindows)),
+		}
+		return marshalOptimizedResponse(response, "kibana_get_maintenance_windows")
+	}
+}
+
+// HandleCreateMaintenanceWindow handles creating a maintenance window.
+func HandleCreateMaintenanceWindow() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		title, err := requireStringParam(req, "title")
+		if err != nil {
+			return nil, err
+		}
+		start, err := requireStringParam(req, "start")
+		if err != nil {
+			return nil, err
+		}
+		duration, err := requireStringParam(req, "duration")
+		if err != nil {
+			return nil, err
+		}
+		recurring, err := getOptionalObjectParam(req, "recurring")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		spec := client.MaintenanceWindowSpec{
+			Title:   title,
+			Enabled: getOptionalBoolParam(req, "enabled"),
+			Schedule: client.MaintenanceWindowSchedule{
+				Start:     start,
+				Duration:  duration,
+				Timezone:  getOptionalStringParam(req, "timezone"),
+				Recurring: recurring,
+			},
+			ScopeKQL: getOptionalStringParam(req, "scopeKql"),
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":     "kibana_create_maintenance_window",
+			"title":    title,
+			"start":    start,
+			"duration": duration,
+		}).Debug("Handler invoked")
+
+		window, err := c.CreateMaintenanceWindow(ctx, spec)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create maintenance window: %v", err)), nil
+		}
+		return marshalOptimizedResponse(window, "kibana_create_maintenance_window")
+	}
+}
+
+// HandleDeleteMaintenanceWindow handles deleting a maintenance window.
+func HandleDeleteMaintenanceWindow() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		windowID, err := requireStringParam(req, "maintenance_window_id")
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":                  "kibana_delete_maintenance_window",
+			"maintenance_window_id": windowID,
+		}).Debug("Handler invoked")
+
+		if err := c.DeleteMaintenanceWindow(ctx, windowID); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted maintenance window: %s", windowID)), nil
+	}
+}
@@ -0,0 +1,73 @@
+// Package handlers provides HTTP handlers for Kibana MCP operations.
+// This file contains machine learning handlers.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
+)
+
+// mlErrorResult keeps license failures distinguishable from other ML API errors
+func mlErrorResult(action string, err error) *mcp.CallToolResult {
+	if errors.Is(err, client.ErrMLNotLicensed) {
+		return mcp.NewToolResultError(fmt.Sprintf("%v. Anomaly detection requires a Platinum/Enterprise license or an active trial.", err))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err))
+}
+
+// HandleGetMLJobs handles listing ML anomaly detection jobs.
+func HandleGetMLJobs() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		logrus.WithField("tool", "kibana_get_ml_jobs").Debug("Handler invoked")
+
+		jobs, err := c.GetMLJobs(ctx)
+		if err != nil {
+			return mlErrorResult("get ML jobs", err), nil
+		}
+
+		response := map[string]interface{}{
+			"jobs":  jobs,
+			"count": len(jobs),
+		}
+
+		return marshalOptimizedResponse(response, "kibana_get_ml_jobs")
+	}
+}
+
+// HandleGetMLJobStats handles getting the stats of a single ML job.
+func HandleGetMLJobStats() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		jobID, err := requireStringParam(req, "job_id")
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":   "kibana_get_ml_job_stats",
+			"job_id": jobID,
+		}).Debug("Handler invoked")
+
+		job, err := c.GetMLJobStats(ctx, jobID)
+		if err != nil {
+			return mlErrorResult("get ML job stats", err), nil
+		}
+
+		return marshalOptimizedResponse(job, "kibana_get_ml_job_stats")
+	}
+}
@@ -129,6 +129,100 @@ func HandleQueryLogs() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	}
 }
 
+// HandleLogVolume handles log volume histogram requests.
+func HandleLogVolume() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		opts := client.LogVolumeOptions{
+			IndexPattern: getOptionalStringParam(req, "indexPattern"),
+			Query:        getOptionalStringParam(req, "query"),
+			TimeField:    getOptionalStringParam(req, "timeField"),
+			From:         getOptionalStringParam(req, "from"),
+			To:           getOptionalStringParam(req, "to"),
+			Interval:     getOptionalStringParam(req, "interval"),
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":         "kibana_log_volume",
+			"indexPattern": opts.IndexPattern,
+			"query":        opts.Query,
+			"from":         opts.From,
+			"interval":     opts.Interval,
+		}).Debug("Handler invoked")
+
+		result, err := c.LogVolume(ctx, opts)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to query log volume: %v", err)), nil
+		}
+
+		return marshalOptimizedResponse(result, "kibana_log_volume")
+	}
+}
+
+// HandleQueryESQL handles ES|QL query requests.
+func HandleQueryESQL() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		query, err := requireStringParam(req, "query")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		maxRows := getOptionalIntParam(req, "max_rows", 100)
+		if maxRows > 1000 {
+			logrus.WithField("requested", maxRows).Warn("ES|QL max_rows too high, resetting to safe maximum")
+			maxRows = 1000
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":    "kibana_query_esql",
+			"query":   query,
+			"maxRows": maxRows,
+		}).Debug("Handler invoked")
+
+		result, err := c.QueryESQL(ctx, query)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to run ES|QL query: %v", err)), nil
+		}
+
+		columns := make([]string, 0, len(result.Columns))
+		types := make([]string, 0, len(result.Columns))
+		for _, column := range result.Columns {
+			columns = append(columns, column.Name)
+			types = append(types, column.Type)
+		}
+
+		rows := result.Rows
+		truncated := len(rows) > maxRows
+		if truncated {
+			rows = rows[:maxRows]
+		}
+
+		response := map[string]interface{}{
+			"columns": columns,
+			"types":   types,
+			"rows":    rows,
+			"metadata": map[string]interface{}{
+				"took":         result.Took,
+				"rowCount":     result.RowCount,
+				"returnedRows": len(rows),
+				"truncated":    truncated,
+				"stackVersion": result.Version,
+			},
+		}
+
+		return marshalOptimizedResponse(response, "kibana_query_esql")
+	}
+}
+
 // HandleGetCanvasWorkpads handles Canvas workpad retrieval requests.
 func HandleGetCanvasWorkpads() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -0,0 +1,114 @@
+// Package handlers provides HTTP handlers for Kibana MCP operations.
+// This file contains reporting handlers.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
+)
+
+const reportPollInterval = 3 * time.Second
+
+// HandleGenerateReport handles dashboard PDF/PNG report generation.
+func HandleGenerateReport() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		jobID := getOptionalStringParam(req, "job_id")
+		dashboardID := getOptionalStringParam(req, "dashboard_id")
+		if jobID == "" && dashboardID == "" {
+			return mcp.NewToolResultError("either dashboard_id (to start a report) or job_id (to resume one) is required"), nil
+		}
+		format := getOptionalStringParam(req, "format")
+		if format == "" {
+			format = "pdf"
+		}
+		wait := true
+		if v := getOptionalBoolParam(req, "wait"); v != nil {
+			wait = *v
+		}
+		timeoutSeconds := getOptionalIntParam(req, "timeout_seconds", 60)
+		if timeoutSeconds > 300 {
+			timeoutSeconds = 300
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":           "kibana_generate_report",
+			"dashboard_id":   dashboardID,
+			"job_id":         jobID,
+			"format":         format,
+			"wait":           wait,
+			"timeoutSeconds": timeoutSeconds,
+		}).Debug("Handler invoked")
+
+		response := map[string]interface{}{}
+		if jobID == "" {
+			job, err := c.GenerateReport(ctx, dashboardID, format, client.ReportTimeRange{
+				From: getOptionalStringParam(req, "from"),
+				To:   getOptionalStringParam(req, "to"),
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to generate report: %v", err)), nil
+			}
+			jobID = job.JobID
+			response["format"] = job.Format
+			response["downloadPath"] = job.DownloadPath
+		} else {
+			response["downloadPath"] = "/api/reporting/jobs/download/" + jobID
+		}
+		response["jobId"] = jobID
+
+		if !wait {
+			response["status"] = "pending"
+			response["message"] = "Report queued. Call again with job_id to download it."
+			return marshalOptimizedResponse(response, "kibana_generate_report")
+		}
+
+		content, err := c.WaitForReport(ctx, jobID, time.Duration(timeoutSeconds)*time.Second, reportPollInterval)
+		if errors.Is(err, client.ErrReportPending) {
+			response["status"] = "pending"
+			response["message"] = fmt.Sprintf("Report not ready after %ds. Call again with job_id to keep waiting.", timeoutSeconds)
+			return marshalOptimizedResponse(response, "kibana_generate_report")
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to download report %s: %v", jobID, err)), nil
+		}
+
+		// Reports are too large for a tool result, so they are written to the export directory
+		path, err := writeExportFile("report", reportExtension(content.ContentType, format), content.Data)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write report %s: %v", jobID, err)), nil
+		}
+		response["status"] = "completed"
+		response["contentType"] = content.ContentType
+		response["path"] = path
+		response["sizeBytes"] = len(content.Data)
+
+		return marshalOptimizedResponse(response, "kibana_generate_report")
+	}
+}
+
+// reportExtension picks the report file extension from its content type, falling back to the
+// requested format
+func reportExtension(contentType, format string) string {
+	switch {
+	case strings.Contains(contentType, "pdf"):
+		return "pdf"
+	case strings.Contains(contentType, "png"):
+		return "png"
+	case format == "png":
+		return "png"
+	}
+	return "pdf"
+}
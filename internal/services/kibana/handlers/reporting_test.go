@@ -0,0 +1,19 @@
+package handlers
+
+import "testing"
+
+func TestReportExtension(t *testing.T) {
+	tests := []struct {
+		contentType, format, want string
+	}{
+		{"application/pdf", "pdf", "pdf"},
+		{"image/png", "pdf", "png"},
+		{"application/octet-stream", "png", "png"},
+		{"", "", "pdf"},
+	}
+	for _, tt := range tests {
+		if got := reportExtension(tt.contentType, tt.format); got != tt.want {
+			t.Errorf("reportExtension(%q, %q) = %q, want %q", tt.contentType, tt.format, got, tt.want)
+		}
+	}
+}
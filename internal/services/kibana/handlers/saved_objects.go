@@ -6,10 +6,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/sirupsen/logrus"
 
+	svccommon "github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/common"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
 )
 
@@ -26,7 +29,10 @@ func HandleSearchSavedObjects() func(ctx context.Context, req mcp.CallToolReques
 		// Get optional parameters
 		objectType := getOptionalStringParam(req, "type")
 		search := getOptionalStringParam(req, "search")
-		page := getOptionalIntParam(req, "page", 1)
+		page, err := getPageParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		perPage := getOptionalIntParam(req, "per_page", 20)
 
 		// Search saved objects
@@ -40,8 +46,19 @@ func HandleSearchSavedObjects() func(ctx context.Context, req mcp.CallToolReques
 			}, nil
 		}
 
+		// Same shape as kibana_search_saved_objects_advanced so clients can page both alike
+		response := map[string]interface{}{
+			"savedObjects": result.SavedObjects,
+			"count":        len(result.SavedObjects),
+			"searchCriteria": map[string]interface{}{
+				"objectType": objectType,
+				"search":     search,
+			},
+			"pagination": client.NewPaginationInfo(result.Page, result.PerPage, result.Total, len(result.SavedObjects)),
+		}
+
 		// Format result
-		resultJSON, err := marshalIndentJSON(result)
+		resultJSON, err := marshalIndentJSON(response)
 		if err != nil {
 			return &mcp.CallToolResult{
 				IsError: true,
@@ -181,13 +198,9 @@ func HandleCreateSavedObject() func(ctx context.Context, req mcp.CallToolRequest
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		var references []client.Reference
-		for _, refMap := range referenceObjects {
-			references = append(references, client.Reference{
-				Name: getStringFieldFromMap(refMap, "name"),
-				Type: getStringFieldFromMap(refMap, "type"),
-				ID:   getStringFieldFromMap(refMap, "id"),
-			})
+		references, err := buildSavedObjectReferences(ctx, c, referenceObjects)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		if objectType == "" || len(attributes) == 0 {
@@ -248,13 +261,9 @@ func HandleUpdateSavedObject() func(ctx context.Context, req mcp.CallToolRequest
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		var references []client.Reference
-		for _, refMap := range referenceObjects {
-			references = append(references, client.Reference{
-				Name: getStringFieldFromMap(refMap, "name"),
-				Type: getStringFieldFromMap(refMap, "type"),
-				ID:   getStringFieldFromMap(refMap, "id"),
-			})
+		references, err := buildSavedObjectReferences(ctx, c, referenceObjects)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		if objectType == "" || objectID == "" {
@@ -388,6 +397,169 @@ func HandleBulkDeleteSavedObjects() func(ctx context.Context, req mcp.CallToolRe
 	}
 }
 
+// HandleBulkGetSavedObjects handles getting multiple saved objects in one request
+func HandleBulkGetSavedObjects() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		objectMaps, err := getOptionalObjectArrayParam(req, "objects")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var objects []client.SavedObject
+		for _, objMap := range objectMaps {
+			objects = append(objects, client.SavedObject{
+				Type: getStringFieldFromMap(objMap, "type"),
+				ID:   getStringFieldFromMap(objMap, "id"),
+			})
+		}
+		if len(objects) == 0 {
+			return mcp.NewToolResultError("objects array is required"), nil
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":  "kibana_bulk_get_saved_objects",
+			"count": len(objects),
+		}).Debug("Handler invoked")
+
+		results, err := c.BulkGetSavedObjects(ctx, objects)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to bulk get saved objects: %v", err)), nil
+		}
+
+		found := make([]map[string]interface{}, 0, len(results))
+		failed := make([]map[string]interface{}, 0)
+		for _, obj := range results {
+			if obj.Error != nil {
+				failed = append(failed, map[string]interface{}{
+					"type":       obj.Type,
+					"id":         obj.ID,
+					"statusCode": obj.Error.StatusCode,
+					"error":      obj.Error.Message,
+				})
+				continue
+			}
+			found = append(found, map[string]interface{}{
+				"type":       obj.Type,
+				"id":         obj.ID,
+				"attributes": obj.Attributes,
+				"references": obj.References,
+				"updatedAt":  obj.Updated,
+			})
+		}
+
+		response := map[string]interface{}{
+			"objects":        found,
+			"errors":         failed,
+			"requestedCount": len(objects),
+			"foundCount":     len(found),
+			"errorCount":     len(failed),
+		}
+
+		return marshalOptimizedResponse(response, "kibana_bulk_get_saved_objects")
+	}
+}
+
+// HandleBulkUpdateSavedObjects handles applying partial updates to multiple saved objects in one request
+func HandleBulkUpdateSavedObjects() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		objectMaps, err := getOptionalObjectArrayParam(req, "objects")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if len(objectMaps) == 0 {
+			return mcp.NewToolResultError("objects array is required"), nil
+		}
+		updates := make([]client.SavedObjectUpdate, 0, len(objectMaps))
+		for i, objMap := range objectMaps {
+			update := client.SavedObjectUpdate{
+				Type:    getStringFieldFromMap(objMap, "type"),
+				ID:      getStringFieldFromMap(objMap, "id"),
+				Version: getStringFieldFromMap(objMap, "version"),
+			}
+			if update.Type == "" || update.ID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("objects[%d]: type and id are required", i)), nil
+			}
+			attributes, _, err := svccommon.GetObjectArg(objMap, "attributes")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("objects[%d]: %v", i, err)), nil
+			}
+			referenceObjects, _, err := svccommon.GetObjectSliceArg(objMap, "references")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("objects[%d]: %v", i, err)), nil
+			}
+			if len(attributes) == 0 && len(referenceObjects) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("objects[%d] %s/%s: attributes or references is required", i, update.Type, update.ID)), nil
+			}
+			references, err := buildSavedObjectReferences(ctx, c, referenceObjects)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("objects[%d]: %v", i, err)), nil
+			}
+			update.Attributes = attributes
+			update.References = references
+			updates = append(updates, update)
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":  "kibana_bulk_update_saved_objects",
+			"count": len(updates),
+		}).Debug("Handler invoked")
+
+		results, err := c.BulkUpdateSavedObjects(ctx, updates)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to bulk update saved objects: %v", err)), nil
+		}
+
+		updated := make([]map[string]interface{}, 0, len(results))
+		failed := make([]map[string]interface{}, 0)
+		conflicts := 0
+		for _, obj := range results {
+			if obj.Error != nil {
+				entry := map[string]interface{}{
+					"type":       obj.Type,
+					"id":         obj.ID,
+					"statusCode": obj.Error.StatusCode,
+					"error":      obj.Error.Message,
+				}
+				if obj.Conflict() {
+					entry["conflict"] = true
+					conflicts++
+				}
+				failed = append(failed, entry)
+				continue
+			}
+			updated = append(updated, map[string]interface{}{
+				"type":      obj.Type,
+				"id":        obj.ID,
+				"version":   obj.Version,
+				"updatedAt": obj.Updated,
+			})
+		}
+
+		response := map[string]interface{}{
+			"updated":        updated,
+			"errors":         failed,
+			"requestedCount": len(updates),
+			"updatedCount":   len(updated),
+			"errorCount":     len(failed),
+			"conflictCount":  conflicts,
+		}
+		if conflicts > 0 {
+			response["hint"] = "Objects with conflict=true changed since their version was read. Get them again with kibana_bulk_get_saved_objects and retry with the new version."
+		}
+
+		return marshalOptimizedResponse(response, "kibana_bulk_update_saved_objects")
+	}
+}
+
 // HandleExportSavedObjects handles exporting saved objects
 func HandleExportSavedObjects() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -409,22 +581,34 @@ func HandleExportSavedObjects() func(ctx context.Context, req mcp.CallToolReques
 				ID:   getStringFieldFromMap(objMap, "id"),
 			})
 		}
+		types, err := getOptionalStringArrayParam(req, "types")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		includeReferences := true
 		if ir := getOptionalBoolParam(req, "includeReferences"); ir != nil {
 			includeReferences = *ir
 		}
 
-		if len(objects) == 0 {
+		output := getOptionalStringParam(req, "output")
+		if output == "" {
+			output = "inline"
+		}
+		if output != "inline" && output != "file" {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid output %q: expected inline or file", output)), nil
+		}
+
+		if len(objects) == 0 && len(types) == 0 {
 			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{
-					mcp.NewTextContent("objects array is required"),
+					mcp.NewTextContent("objects or types is required"),
 				},
 			}, nil
 		}
 
-		data, err := c.ExportSavedObjects(ctx, objects, includeReferences)
+		data, err := c.ExportSavedObjects(ctx, objects, types, includeReferences)
 		if err != nil {
 			return &mcp.CallToolResult{
 				IsError: true,
@@ -434,11 +618,25 @@ func HandleExportSavedObjects() func(ctx context.Context, req mcp.CallToolReques
 			}, nil
 		}
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(string(data)),
-			},
-		}, nil
+		if output == "inline" {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(string(data)),
+				},
+			}, nil
+		}
+
+		path, err := writeExportFile("saved-objects", "ndjson", data)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write export file: %v", err)), nil
+		}
+
+		return marshalOptimizedResponse(map[string]interface{}{
+			"path":    path,
+			"bytes":   len(data),
+			"objects": countExportedObjects(data),
+			"format":  "ndjson",
+		}, "kibana_export_saved_objects")
 	}
 }
 
@@ -453,20 +651,28 @@ func HandleImportSavedObjects() func(ctx context.Context, req mcp.CallToolReques
 		logrus.Debug("Executing Kibana import saved objects handler")
 
 		fileContent := getOptionalStringParam(req, "file")
+		filePath := getOptionalStringParam(req, "filePath")
 
 		createNewCopies := false
 		if cnc := getOptionalBoolParam(req, "createNewCopies"); cnc != nil {
 			createNewCopies = *cnc
 		}
 
-		if fileContent == "" {
+		if fileContent == "" && filePath == "" {
 			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{
-					mcp.NewTextContent("file is required"),
+					mcp.NewTextContent("file or filePath is required"),
 				},
 			}, nil
 		}
+		if fileContent != "" && filePath != "" {
+			return mcp.NewToolResultError("pass either file or filePath, not both"), nil
+		}
+
+		if filePath != "" {
+			return importSavedObjectsFile(ctx, c, filePath, createNewCopies)
+		}
 
 		err := c.ImportSavedObjects(ctx, fileContent, createNewCopies)
 		if err != nil {
@@ -486,6 +692,54 @@ func HandleImportSavedObjects() func(ctx context.Context, req mcp.CallToolReques
 	}
 }
 
+// importSavedObjectsFile streams a server-side NDJSON file from the import directory to Kibana
+func importSavedObjectsFile(ctx context.Context, c *client.Client, filePath string, createNewCopies bool) (*mcp.CallToolResult, error) {
+	path, err := resolveImportPath(filePath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to open import file: %v", err)), nil
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read import file: %v", err)), nil
+	}
+	if !info.Mode().IsRegular() {
+		return mcp.NewToolResultError(fmt.Sprintf("Import path %s is not a regular file", path)), nil
+	}
+
+	logrus.WithFields(logrus.Fields{"path": path, "bytes": info.Size()}).Debug("Importing saved objects from file")
+
+	result, err := c.ImportSavedObjectsFrom(ctx, filepath.Base(path), file, createNewCopies)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to import saved objects: %v", err)), nil
+	}
+
+	response := map[string]interface{}{
+		"path":         path,
+		"bytes":        info.Size(),
+		"success":      result.Success,
+		"successCount": result.SuccessCount,
+		"errors":       result.Errors,
+	}
+	resultJSON, err := marshalIndentJSON(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format import result: %v", err)), nil
+	}
+
+	return &mcp.CallToolResult{
+		IsError: !result.Success && result.SuccessCount == 0,
+		Content: []mcp.Content{
+			mcp.NewTextContent(string(resultJSON)),
+		},
+	}, nil
+}
+
 // HandleSearchSavedObjectsAdvanced handles advanced saved objects search with enhanced filters
 func HandleSearchSavedObjectsAdvanced() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -496,7 +750,10 @@ func HandleSearchSavedObjectsAdvanced() func(ctx context.Context, request mcp.Ca
 
 		objectType := getOptionalStringParam(request, "type")
 		search := getOptionalStringParam(request, "search")
-		page := getOptionalIntParam(request, "page", 1)
+		page, err := getPageParam(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		perPage := getOptionalIntParam(request, "per_page", 30)
 		sortField := getOptionalStringParam(request, "sort_field")
 		sortOrder := getOptionalStringParam(request, "sort_order")
@@ -535,10 +792,9 @@ func HandleSearchSavedObjectsAdvanced() func(ctx context.Context, request mcp.Ca
 				"hasReference": hasReference,
 				"fields":       fields,
 			},
-			"pagination": map[string]interface{}{
-				"currentPage": result.Page,
-				"perPage":     result.PerPage,
-				"total":       result.Total,
+			"pagination": advancedSearchPagination{
+				PaginationInfo: client.NewPaginationInfo(result.Page, result.PerPage, result.Total, len(result.SavedObjects)),
+				Total:          result.Total,
 			},
 			"metadata": map[string]interface{}{
 				"tool":         "kibana_search_saved_objects_advanced",
@@ -549,3 +805,37 @@ func HandleSearchSavedObjectsAdvanced() func(ctx context.Context, request mcp.Ca
 		return marshalOptimizedResponse(response, "kibana_search_saved_objects_advanced")
 	}
 }
+
+// advancedSearchPagination is the shared pagination metadata plus the "total" key that
+// kibana_search_saved_objects_advanced returned before adopting it, kept for existing consumers
+type advancedSearchPagination struct {
+	*client.PaginationInfo
+	Total int `json:"total"`
+}
+
+// buildSavedObjectReferences converts reference arguments to client references. A data view
+// (index-pattern) reference may give a title instead of an id; it is resolved to the data view ID so a
+// dangling title fails here rather than producing an object that cannot load its data.
+func buildSavedObjectReferences(ctx context.Context, c *client.Client, referenceObjects []map[string]interface{}) ([]client.Reference, error) {
+	var references []client.Reference
+	for _, refMap := range referenceObjects {
+		ref := client.Reference{
+			Name: getStringFieldFromMap(refMap, "name"),
+			Type: getStringFieldFromMap(refMap, "type"),
+			ID:   getStringFieldFromMap(refMap, "id"),
+		}
+		if ref.ID == "" && ref.Type == "index-pattern" {
+			title := getStringFieldFromMap(refMap, "title")
+			if title == "" {
+				return nil, fmt.Errorf("reference %q of type index-pattern needs an id or a data view title", ref.Name)
+			}
+			id, err := c.ResolveDataViewByTitle(ctx, title)
+			if err != nil {
+				return nil, fmt.Errorf("reference %q: %w", ref.Name, err)
+			}
+			ref.ID = id
+		}
+		references = append(references, ref)
+	}
+	return references, nil
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
+)
+
+func TestAdvancedSearchPaginationKeepsTotal(t *testing.T) {
+	data, err := json.Marshal(advancedSearchPagination{PaginationInfo: client.NewPaginationInfo(2, 10, 35, 10), Total: 35})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var pagination map[string]interface{}
+	if err := json.Unmarshal(data, &pagination); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if pagination["total"] != float64(35) || pagination["totalCount"] != float64(35) || pagination["currentPage"] != float64(2) || pagination["continueToken"] != "3" {
+		t.Fatalf("expected the original and shared pagination keys, got %v", pagination)
+	}
+}
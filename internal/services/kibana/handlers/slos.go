@@ -0,0 +1,124 @@
+// Package handlers provides HTTP handlers for Kibana MCP operations.
+// This file contains SLO-related handlers.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
+)
+
+// sloSummary returns the fields most useful when triaging an SLO
+func sloSummary(slo client.KibanaSLO) map[string]interface{} {
+	summary := map[string]interface{}{
+		"id":                   slo.ID,
+		"name":                 slo.Name,
+		"enabled":              slo.Enabled,
+		"objective":            slo.Objective.Target,
+		"sli":                  slo.Summary.SLIValue,
+		"status":               slo.Summary.Status,
+		"errorBudgetRemaining": slo.Summary.ErrorBudget.Remaining,
+	}
+	if slo.InstanceID != "" && slo.InstanceID != "*" {
+		summary["instanceId"] = slo.InstanceID
+	}
+	if len(slo.Tags) > 0 {
+		summary["tags"] = slo.Tags
+	}
+	return summary
+}
+
+// sloNotAvailableResult reports a missing SLO feature as a regular result so callers can move on
+func sloNotAvailableResult(err error) (*mcp.CallToolResult, error) {
+	return marshalOptimizedResponse(map[string]interface{}{
+		"available": false,
+		"message":   err.Error(),
+	}, "kibana_get_slos")
+}
+
+// HandleGetSLOs handles listing SLOs.
+func HandleGetSLOs() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		page, err := getPageParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		perPage := getOptionalIntParam(req, "per_page", 20)
+		kqlQuery := getOptionalStringParam(req, "kqlQuery")
+
+		logrus.WithFields(logrus.Fields{
+			"tool":     "kibana_get_slos",
+			"page":     page,
+			"perPage":  perPage,
+			"kqlQuery": kqlQuery,
+		}).Debug("Handler invoked")
+
+		list, err := c.GetSLOs(ctx, page, perPage, kqlQuery)
+		if err != nil {
+			if errors.Is(err, client.ErrSLONotAvailable) {
+				return sloNotAvailableResult(err)
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get SLOs: %v", err)), nil
+		}
+
+		slos := make([]map[string]interface{}, 0, len(list.Results))
+		for _, slo := range list.Results {
+			slos = append(slos, sloSummary(slo))
+		}
+
+		response := map[string]interface{}{
+			"available":  true,
+			"slos":       slos,
+			"pagination": client.NewPaginationInfo(list.Page, list.PerPage, list.Total, len(slos)),
+		}
+
+		return marshalOptimizedResponse(response, "kibana_get_slos")
+	}
+}
+
+// HandleGetSLO handles getting a specific SLO.
+func HandleGetSLO() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		sloID, err := requireStringParam(req, "slo_id")
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":   "kibana_get_slo",
+			"slo_id": sloID,
+		}).Debug("Handler invoked")
+
+		slo, err := c.GetSLO(ctx, sloID)
+		if err != nil {
+			if errors.Is(err, client.ErrSLONotAvailable) {
+				return sloNotAvailableResult(err)
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get SLO: %v", err)), nil
+		}
+
+		response := sloSummary(*slo)
+		response["description"] = slo.Description
+		response["budgetingMethod"] = slo.BudgetingMethod
+		response["timeWindow"] = slo.TimeWindow
+		response["indicator"] = slo.Indicator
+		response["errorBudget"] = slo.Summary.ErrorBudget
+
+		return marshalOptimizedResponse(response, "kibana_get_slo")
+	}
+}
@@ -313,3 +313,96 @@ func HandleSpacesSummary() func(ctx context.Context, request mcp.CallToolRequest
 		return marshalOptimizedResponse(response, "kibana_spaces_summary")
 	}
 }
+
+// HandleGetSpaceUsage handles counting saved objects per type in one or all spaces.
+func HandleGetSpaceUsage() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		spaceID := getOptionalStringParam(req, "space_id")
+		logrus.WithFields(logrus.Fields{
+			"tool":     "kibana_get_space_usage",
+			"space_id": spaceID,
+		}).Debug("Handler invoked")
+
+		if spaceID != "" {
+			counts, err := c.GetSpaceObjectCounts(ctx, spaceID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to count space objects: %v", err)), nil
+			}
+			return marshalOptimizedResponse(counts, "kibana_get_space_usage")
+		}
+
+		usage, err := c.GetAllSpaceObjectCounts(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to count space objects: %v", err)), nil
+		}
+		totalObjects := 0
+		emptySpaces := []string{}
+		for _, space := range usage {
+			totalObjects += space.Total
+			if space.Total == 0 {
+				emptySpaces = append(emptySpaces, space.SpaceID)
+			}
+		}
+
+		response := map[string]interface{}{
+			"spaces":       usage,
+			"count":        len(usage),
+			"totalObjects": totalObjects,
+			"emptySpaces":  emptySpaces,
+			"types":        client.SpaceUsageTypes,
+		}
+		return marshalOptimizedResponse(response, "kibana_get_space_usage")
+	}
+}
+
+// HandleSpaceCopyAll handles copying every saved object of a space to another space.
+func HandleSpaceCopyAll() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		sourceSpace, err := requireStringParam(req, "sourceSpace")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		targetSpace, err := requireStringParam(req, "targetSpace")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		types, err := getOptionalStringArrayParam(req, "types")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		overwrite := false
+		if v := getOptionalBoolParam(req, "overwrite"); v != nil {
+			overwrite = *v
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":        "kibana_space_copy_all",
+			"sourceSpace": sourceSpace,
+			"targetSpace": targetSpace,
+			"types":       types,
+			"overwrite":   overwrite,
+		}).Debug("Handler invoked")
+
+		result, err := c.CopySpaceObjects(ctx, sourceSpace, targetSpace, types, overwrite)
+		if err != nil {
+			if result == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to copy space objects: %v", err)), nil
+			}
+			// Earlier batches were already copied; report them alongside the failure
+			resultJSON, _ := marshalIndentJSON(result)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to copy space objects: %v\nPartial result: %s", err, resultJSON)), nil
+		}
+
+		return marshalOptimizedResponse(result, "kibana_space_copy_all")
+	}
+}
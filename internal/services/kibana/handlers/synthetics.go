@@ -0,0 +1,120 @@
+// Package handlers provides HTTP handlers for Kibana MCP operations.
+// This file contains Synthetics monitor handlers.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
+)
+
+// syntheticsNotAvailableResult reports a missing Synthetics app as a regular result
+func syntheticsNotAvailableResult(err error, toolName string) (*mcp.CallToolResult, error) {
+	return marshalOptimizedResponse(map[string]interface{}{
+		"available": false,
+		"message":   err.Error(),
+	}, toolName)
+}
+
+// HandleGetSyntheticsMonitors handles listing Synthetics monitors with their latest status.
+func HandleGetSyntheticsMonitors() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		page, err := getPageParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		perPage := getOptionalIntParam(req, "per_page", 20)
+
+		logrus.WithFields(logrus.Fields{
+			"tool":    "kibana_get_synthetics_monitors",
+			"page":    page,
+			"perPage": perPage,
+		}).Debug("Handler invoked")
+
+		list, err := c.GetSyntheticsMonitors(ctx, page, perPage)
+		if err != nil {
+			if errors.Is(err, client.ErrSyntheticsNotAvailable) {
+				return syntheticsNotAvailableResult(err, "kibana_get_synthetics_monitors")
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get Synthetics monitors: %v", err)), nil
+		}
+
+		ids := make([]string, 0, len(list.Monitors))
+		for _, monitor := range list.Monitors {
+			ids = append(ids, monitor.ID)
+		}
+		// Check results live in Elasticsearch; monitors are still listed when they cannot be read
+		statuses, err := c.LatestSyntheticsStatuses(ctx, ids)
+		if err != nil {
+			logrus.WithError(err).Debug("Failed to read Synthetics check results")
+		}
+
+		statusCounts := map[string]int{}
+		monitors := make([]map[string]interface{}, 0, len(list.Monitors))
+		for _, monitor := range list.Monitors {
+			entry := map[string]interface{}{
+				"id":      monitor.ID,
+				"name":    monitor.Name,
+				"type":    monitor.Type,
+				"url":     monitor.URL,
+				"enabled": monitor.Enabled,
+				"status":  "unknown",
+			}
+			if status, ok := statuses[monitor.ID]; ok {
+				entry["status"] = status.Status
+				entry["lastCheck"] = status.LastCheck
+				if status.Error != "" {
+					entry["error"] = status.Error
+				}
+			}
+			statusCounts[entry["status"].(string)]++
+			monitors = append(monitors, entry)
+		}
+
+		response := map[string]interface{}{
+			"available":    true,
+			"monitors":     monitors,
+			"statusCounts": statusCounts,
+			"pagination":   client.NewPaginationInfo(list.Page, list.PerPage, list.Total, len(monitors)),
+		}
+
+		return marshalOptimizedResponse(response, "kibana_get_synthetics_monitors")
+	}
+}
+
+// HandleGetSyntheticsMonitorStatus handles getting the latest status of a Synthetics monitor.
+func HandleGetSyntheticsMonitorStatus() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, cerr := client.FromContext(ctx)
+		if cerr != nil {
+			return mcp.NewToolResultError(cerr.Error()), nil
+		}
+
+		monitorID, err := requireStringParam(req, "monitor_id")
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":       "kibana_get_synthetics_monitor_status",
+			"monitor_id": monitorID,
+		}).Debug("Handler invoked")
+
+		status, err := c.GetSyntheticsMonitorStatus(ctx, monitorID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get Synthetics monitor status: %v", err)), nil
+		}
+
+		return marshalOptimizedResponse(status, "kibana_get_synthetics_monitor_status")
+	}
+}
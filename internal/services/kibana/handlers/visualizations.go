@@ -143,7 +143,12 @@ func HandleCreateVisualization() func(ctx context.Context, req mcp.CallToolReque
 			}, nil
 		}
 
-		visualization, err := c.CreateVisualization(ctx, title, visState, description, savedSearchRefName)
+		dataViewID, err := resolveDataViewParam(ctx, c, getOptionalStringParam(req, "dataViewId"), getOptionalStringParam(req, "dataViewTitle"))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		visualization, err := c.CreateVisualization(ctx, title, visState, description, savedSearchRefName, dataViewID)
 		if err != nil {
 			return &mcp.CallToolResult{
 				IsError: true,
@@ -335,7 +340,10 @@ func HandleVisualizationsPaginated() func(ctx context.Context, request mcp.CallT
 			return mcp.NewToolResultError(cerr.Error()), nil
 		}
 
-		page := getOptionalIntParam(request, "page", 1)
+		page, err := getPageParam(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		perPage := getOptionalIntParam(request, "per_page", 20)
 		search := getOptionalStringParam(request, "search")
 		visType := getOptionalStringParam(request, "type")
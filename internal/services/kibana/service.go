@@ -4,12 +4,14 @@ package kibana
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	server "github.com/mark3labs/mcp-go/server"
 	"github.com/sirupsen/logrus"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/cache"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/framework"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kibana/client"
@@ -37,8 +39,8 @@ func NewService() *Service {
 
 	// Create init configuration
 	initConfig := &framework.InitConfig{
-		Required:     false,
-		URLValidator: framework.SimpleURLValidator,
+		Required:      false,
+		URLValidator:  framework.SimpleURLValidator,
 		ClientBuilder: nil,
 	}
 
@@ -57,6 +59,13 @@ func (s *Service) Name() string {
 // Initialize configures the Kibana service with the provided application configuration.
 // It uses the common service framework for standardized initialization.
 func (s *Service) Initialize(cfg interface{}) error {
+	if appConfig, ok := cfg.(*config.AppConfig); ok && appConfig != nil {
+		client.SetDefaultHeaders(appConfig.Kibana.Headers)
+		client.SetUserAgent(appConfig.Server.UserAgent)
+		client.SetDefaultTLSFiles(appConfig.Kibana.TLSCertFile, appConfig.Kibana.TLSKeyFile, appConfig.Kibana.TLSCAFile)
+		handlers.SetExportDir(appConfig.Kibana.ExportDir)
+		handlers.SetImportDir(appConfig.Kibana.ImportDir)
+	}
 	return s.initFramework.Initialize(cfg,
 		func(enabled bool) { s.enabled = enabled },
 		func(_ interface{}) {
@@ -96,6 +105,7 @@ func (s *Service) GetTools() []mcp.Tool {
 		// ⚠️ PRIORITY: New optimized tools for LLM efficiency
 		optimizedTools := []mcp.Tool{
 			tools.GetSpacesSummaryTool(),
+			tools.GetSpaceUsageTool(),
 			tools.GetDashboardsSummaryTool(),
 			tools.GetVisualizationsSummaryTool(),
 			tools.GetIndexPatternsSummaryTool(),
@@ -103,20 +113,25 @@ func (s *Service) GetTools() []mcp.Tool {
 			tools.GetVisualizationsPaginatedTool(),
 			tools.GetSavedObjectsAdvancedTool(),
 			tools.GetDashboardDetailAdvancedTool(),
+			tools.GetDashboardByTitleTool(),
 			tools.GetKibanaHealthSummaryTool(),
 
 			// Analysis & Discovery tools
 			tools.QueryLogsTool(),
+			tools.LogVolumeTool(),
+			tools.QueryESQLTool(),
 			tools.GetCanvasWorkpadsTool(),
 			tools.GetLensObjectsTool(),
 			tools.GetMapsTool(),
 			tools.GetKibanaAlertsTool(),
 			tools.GetIndexPatternFieldsTool(),
+			tools.GetFieldStatsTool(),
 
 			// ============ Write Operations: Spaces ============
 			tools.CreateSpaceTool(),
 			tools.UpdateSpaceTool(),
 			tools.DeleteSpaceTool(),
+			tools.SpaceCopyAllTool(),
 
 			// ============ Write Operations: Index Patterns ============
 			tools.CreateIndexPatternTool(),
@@ -142,11 +157,14 @@ func (s *Service) GetTools() []mcp.Tool {
 			tools.UpdateSavedObjectTool(),
 			tools.DeleteSavedObjectTool(),
 			tools.BulkDeleteSavedObjectsTool(),
+			tools.BulkGetSavedObjectsTool(),
+			tools.BulkUpdateSavedObjectsTool(),
 			tools.ExportSavedObjectsTool(),
 			tools.ImportSavedObjectsTool(),
 
 			// ============ Alert Rules ============
 			tools.GetAlertRulesTool(),
+			tools.GetAlertRulesSummaryTool(),
 			tools.GetAlertRuleTool(),
 			tools.CreateAlertRuleTool(),
 			tools.UpdateAlertRuleTool(),
@@ -155,9 +173,17 @@ func (s *Service) GetTools() []mcp.Tool {
 			tools.DisableAlertRuleTool(),
 			tools.MuteAlertRuleTool(),
 			tools.UnmuteAlertRuleTool(),
+			tools.BulkEnableAlertRulesTool(),
+			tools.BulkDisableAlertRulesTool(),
+			tools.BulkMuteAlertRulesTool(),
 			tools.GetAlertRuleTypesTool(),
 			tools.GetAlertRuleHistoryTool(),
 
+			// ============ Maintenance Windows ============
+			tools.GetMaintenanceWindowsTool(),
+			tools.CreateMaintenanceWindowTool(),
+			tools.DeleteMaintenanceWindowTool(),
+
 			// ============ Connectors ============
 			tools.GetConnectorsTool(),
 			tools.GetConnectorTool(),
@@ -166,13 +192,35 @@ func (s *Service) GetTools() []mcp.Tool {
 			tools.DeleteConnectorTool(),
 			tools.TestConnectorTool(),
 			tools.GetConnectorTypesTool(),
+			tools.GetConnectorExecutionHistoryTool(),
+
+			// ============ SLOs ============
+			tools.GetSLOsTool(),
+			tools.GetSLOTool(),
+
+			// ============ Machine Learning ============
+			tools.GetMLJobsTool(),
+			tools.GetMLJobStatsTool(),
+
+			// ============ Reporting ============
+			tools.GenerateReportTool(),
+
+			// ============ Fleet ============
+			tools.GetFleetAgentsTool(),
+			tools.GetFleetAgentPoliciesTool(),
+
+			// ============ Synthetics ============
+			tools.GetSyntheticsMonitorsTool(),
+			tools.GetSyntheticsMonitorStatusTool(),
 
 			// ============ Data Views ============
 			tools.GetDataViewsTool(),
 			tools.GetDataViewTool(),
+			tools.ResolveDataViewTool(),
 			tools.CreateDataViewTool(),
 			tools.UpdateDataViewTool(),
 			tools.DeleteDataViewTool(),
+			tools.MigrateDataViewReferencesTool(),
 		}
 
 		// Combine all tools - optimized tools first for better visibility
@@ -208,6 +256,7 @@ func (s *Service) GetHandlers() map[string]server.ToolHandlerFunc {
 	optimizedHandlers := map[string]server.ToolHandlerFunc{
 		// Summary tools
 		"kibana_spaces_summary":         handlers.HandleSpacesSummary(),
+		"kibana_get_space_usage":        handlers.HandleGetSpaceUsage(),
 		"kibana_dashboards_summary":     handlers.HandleDashboardsPaginated(),
 		"kibana_visualizations_summary": handlers.HandleVisualizationsPaginated(),
 		"kibana_index_patterns_summary": handlers.HandleGetIndexPatterns(),
@@ -217,20 +266,25 @@ func (s *Service) GetHandlers() map[string]server.ToolHandlerFunc {
 		"kibana_visualizations_paginated":      handlers.HandleVisualizationsPaginated(),
 		"kibana_search_saved_objects_advanced": handlers.HandleSearchSavedObjectsAdvanced(),
 		"kibana_get_dashboard_detail_advanced": handlers.HandleGetDashboardDetailAdvanced(),
+		"kibana_get_dashboard_by_title":        handlers.HandleGetDashboardByTitle(),
 		"kibana_health_summary":                handlers.HandleGetHealthSummary(),
 
 		// Analysis & Discovery handlers
 		"kibana_query_logs":               handlers.HandleQueryLogs(),
+		"kibana_log_volume":               handlers.HandleLogVolume(),
+		"kibana_query_esql":               handlers.HandleQueryESQL(),
 		"kibana_get_canvas_workpads":      handlers.HandleGetCanvasWorkpads(),
 		"kibana_get_lens_objects":         handlers.HandleGetLensObjects(),
 		"kibana_get_maps":                 handlers.HandleGetMaps(),
 		"kibana_get_alerts":               handlers.HandleGetKibanaAlerts(),
 		"kibana_get_index_pattern_fields": handlers.HandleGetIndexPatternFields(),
+		"kibana_get_field_stats":          handlers.HandleGetFieldStats(),
 
 		// ============ Write Operations: Spaces ============
-		"kibana_create_space": handlers.HandleCreateSpace(),
-		"kibana_update_space": handlers.HandleUpdateSpace(),
-		"kibana_delete_space": handlers.HandleDeleteSpace(),
+		"kibana_create_space":   handlers.HandleCreateSpace(),
+		"kibana_update_space":   handlers.HandleUpdateSpace(),
+		"kibana_delete_space":   handlers.HandleDeleteSpace(),
+		"kibana_space_copy_all": handlers.HandleSpaceCopyAll(),
 
 		// ============ Write Operations: Index Patterns ============
 		"kibana_create_index_pattern":         handlers.HandleCreateIndexPattern(),
@@ -256,37 +310,70 @@ func (s *Service) GetHandlers() map[string]server.ToolHandlerFunc {
 		"kibana_update_saved_object":       handlers.HandleUpdateSavedObject(),
 		"kibana_delete_saved_object":       handlers.HandleDeleteSavedObject(),
 		"kibana_bulk_delete_saved_objects": handlers.HandleBulkDeleteSavedObjects(),
+		"kibana_bulk_get_saved_objects":    handlers.HandleBulkGetSavedObjects(),
+		"kibana_bulk_update_saved_objects": handlers.HandleBulkUpdateSavedObjects(),
 		"kibana_export_saved_objects":      handlers.HandleExportSavedObjects(),
 		"kibana_import_saved_objects":      handlers.HandleImportSavedObjects(),
 
 		// ============ Alert Rules ============
-		"kibana_get_alert_rules":        handlers.HandleGetAlertRules(),
-		"kibana_get_alert_rule":         handlers.HandleGetAlertRule(),
-		"kibana_create_alert_rule":      handlers.HandleCreateAlertRule(),
-		"kibana_update_alert_rule":      handlers.HandleUpdateAlertRule(),
-		"kibana_delete_alert_rule":      handlers.HandleDeleteAlertRule(),
-		"kibana_enable_alert_rule":      handlers.HandleEnableAlertRule(),
-		"kibana_disable_alert_rule":     handlers.HandleDisableAlertRule(),
-		"kibana_mute_alert_rule":        handlers.HandleMuteAlertRule(),
-		"kibana_unmute_alert_rule":      handlers.HandleUnmuteAlertRule(),
-		"kibana_get_alert_rule_types":   handlers.HandleGetAlertRuleTypes(),
-		"kibana_get_alert_rule_history": handlers.HandleGetAlertRuleHistory(),
+		"kibana_get_alert_rules":          handlers.HandleGetAlertRules(),
+		"kibana_alert_rules_summary":      handlers.HandleGetAlertRulesSummary(),
+		"kibana_get_alert_rule":           handlers.HandleGetAlertRule(),
+		"kibana_create_alert_rule":        handlers.HandleCreateAlertRule(),
+		"kibana_update_alert_rule":        handlers.HandleUpdateAlertRule(),
+		"kibana_delete_alert_rule":        handlers.HandleDeleteAlertRule(),
+		"kibana_enable_alert_rule":        handlers.HandleEnableAlertRule(),
+		"kibana_disable_alert_rule":       handlers.HandleDisableAlertRule(),
+		"kibana_mute_alert_rule":          handlers.HandleMuteAlertRule(),
+		"kibana_unmute_alert_rule":        handlers.HandleUnmuteAlertRule(),
+		"kibana_bulk_enable_alert_rules":  handlers.HandleBulkEnableAlertRules(),
+		"kibana_bulk_disable_alert_rules": handlers.HandleBulkDisableAlertRules(),
+		"kibana_bulk_mute_alert_rules":    handlers.HandleBulkMuteAlertRules(),
+		"kibana_get_alert_rule_types":     handlers.HandleGetAlertRuleTypes(),
+		"kibana_get_alert_rule_history":   handlers.HandleGetAlertRuleHistory(),
+
+		// ============ Maintenance Windows ============
+		"kibana_get_maintenance_windows":   handlers.HandleGetMaintenanceWindows(),
+		"kibana_create_maintenance_window": handlers.HandleCreateMaintenanceWindow(),
+		"kibana_delete_maintenance_window": handlers.HandleDeleteMaintenanceWindow(),
 
 		// ============ Connectors ============
-		"kibana_get_connectors":      handlers.HandleGetConnectors(),
-		"kibana_get_connector":       handlers.HandleGetConnector(),
-		"kibana_create_connector":    handlers.HandleCreateConnector(),
-		"kibana_update_connector":    handlers.HandleUpdateConnector(),
-		"kibana_delete_connector":    handlers.HandleDeleteConnector(),
-		"kibana_test_connector":      handlers.HandleTestConnector(),
-		"kibana_get_connector_types": handlers.HandleGetConnectorTypes(),
+		"kibana_get_connectors":                  handlers.HandleGetConnectors(),
+		"kibana_get_connector":                   handlers.HandleGetConnector(),
+		"kibana_create_connector":                handlers.HandleCreateConnector(),
+		"kibana_update_connector":                handlers.HandleUpdateConnector(),
+		"kibana_delete_connector":                handlers.HandleDeleteConnector(),
+		"kibana_test_connector":                  handlers.HandleTestConnector(),
+		"kibana_get_connector_types":             handlers.HandleGetConnectorTypes(),
+		"kibana_get_connector_execution_history": handlers.HandleGetConnectorExecutionHistory(),
+
+		// ============ SLOs ============
+		"kibana_get_slos": handlers.HandleGetSLOs(),
+		"kibana_get_slo":  handlers.HandleGetSLO(),
+
+		// ============ Machine Learning ============
+		"kibana_get_ml_jobs":      handlers.HandleGetMLJobs(),
+		"kibana_get_ml_job_stats": handlers.HandleGetMLJobStats(),
+
+		// ============ Reporting ============
+		"kibana_generate_report": handlers.HandleGenerateReport(),
+
+		// ============ Fleet ============
+		"kibana_get_fleet_agents":         handlers.HandleGetFleetAgents(),
+		"kibana_get_fleet_agent_policies": handlers.HandleGetFleetAgentPolicies(),
+
+		// ============ Synthetics ============
+		"kibana_get_synthetics_monitors":       handlers.HandleGetSyntheticsMonitors(),
+		"kibana_get_synthetics_monitor_status": handlers.HandleGetSyntheticsMonitorStatus(),
 
 		// ============ Data Views ============
-		"kibana_get_data_views":   handlers.HandleGetDataViews(),
-		"kibana_get_data_view":    handlers.HandleGetDataView(),
-		"kibana_create_data_view": handlers.HandleCreateDataView(),
-		"kibana_update_data_view": handlers.HandleUpdateDataView(),
-		"kibana_delete_data_view": handlers.HandleDeleteDataView(),
+		"kibana_get_data_views":               handlers.HandleGetDataViews(),
+		"kibana_get_data_view":                handlers.HandleGetDataView(),
+		"kibana_resolve_data_view":            handlers.HandleResolveDataView(),
+		"kibana_create_data_view":             handlers.HandleCreateDataView(),
+		"kibana_update_data_view":             handlers.HandleUpdateDataView(),
+		"kibana_delete_data_view":             handlers.HandleDeleteDataView(),
+		"kibana_migrate_data_view_references": handlers.HandleMigrateDataViewReferences(),
 	}
 
 	// Combine all handlers
@@ -323,3 +410,12 @@ func (s *Service) IsEnabled() bool {
 func (s *Service) GetClient() *client.Client {
 	return nil // Backend client is created per-request from HTTP headers
 }
+
+// CheckConnectivity checks the Kibana API is reachable with the client injected into the request context.
+func (s *Service) CheckConnectivity(ctx context.Context) error {
+	c, err := client.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", services.ErrNoBackendClient, err)
+	}
+	return c.TestConnection(ctx)
+}
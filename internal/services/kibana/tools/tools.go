@@ -62,7 +62,7 @@ func GetDashboardsTool() mcp.Tool {
 func GetDashboardTool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "kibana_get_dashboard",
-		Description: "Retrieve detailed information about a specific Kibana dashboard by ID",
+		Description: "Retrieve detailed information about a specific Kibana dashboard by ID or exact title",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -70,12 +70,36 @@ func GetDashboardTool() mcp.Tool {
 					"type":        "string",
 					"description": "The unique identifier of the dashboard to retrieve",
 				},
+				"dashboard_title": map[string]interface{}{
+					"type":        "string",
+					"description": dashboardTitleParamDescription,
+				},
 			},
-			Required: []string{"dashboard_id"},
 		},
 	}
 }
 
+// GetDashboardByTitleTool returns the tool definition for retrieving a Kibana dashboard by title.
+func GetDashboardByTitleTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_dashboard_by_title",
+		Description: "Retrieve a Kibana dashboard by its exact title. Fails when no dashboard or more than one dashboard has exactly that title; the error lists the IDs of duplicates.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"title": map[string]interface{}{
+					"type":        "string",
+					"description": "Exact dashboard title",
+				},
+			},
+			Required: []string{"title"},
+		},
+	}
+}
+
+// dashboardTitleParamDescription documents the dashboard_title alternative to dashboard_id
+const dashboardTitleParamDescription = "Exact dashboard title, resolved to its ID when dashboard_id is not set; the call fails if no dashboard or several share the title. One of dashboard_id or dashboard_title is required."
+
 // GetVisualizationsTool returns the tool definition for retrieving Kibana visualizations.
 func GetVisualizationsTool() mcp.Tool {
 	return mcp.Tool{
@@ -114,6 +138,10 @@ func SearchSavedObjectsTool() mcp.Tool {
 					"description": "Number of results per page (max 100)",
 					"default":     20,
 				},
+				"continueToken": map[string]interface{}{
+					"type":        "string",
+					"description": "Pagination token from a previous response. When pagination.hasMore is true, pass pagination.continueToken to fetch the next page; it takes precedence over page.",
+				},
 			},
 		},
 	}
@@ -123,7 +151,7 @@ func SearchSavedObjectsTool() mcp.Tool {
 func TestConnectionTool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "kibana_test_connection",
-		Description: "Test the connection to the Kibana server and verify API access",
+		Description: "Test the connection to the Kibana server and report its version, status, the authentication method, the active space, and whether the alerting, actions and Fleet APIs are available with the configured credentials",
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -279,6 +307,23 @@ func GetSpacesSummaryTool() mcp.Tool {
 	}
 }
 
+// GetSpaceUsageTool returns tool definition for counting saved objects per space
+func GetSpaceUsageTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_space_usage",
+		Description: "Count saved objects by type (dashboard, visualization, lens, index-pattern, search, map, canvas-workpad) in one space or in every space. Use it to find empty or bloated spaces. All spaces are sorted largest first, and empty spaces are listed separately.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"space_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Space to count. Omit to count every space.",
+				},
+			},
+		},
+	}
+}
+
 // GetDashboardsPaginatedTool returns tool definition for paginated dashboards listing
 func GetDashboardsPaginatedTool() mcp.Tool {
 	return mcp.Tool{
@@ -297,6 +342,10 @@ func GetDashboardsPaginatedTool() mcp.Tool {
 					"description": "Number of results per page (default: 20, max: 100)",
 					"default":     20,
 				},
+				"continueToken": map[string]interface{}{
+					"type":        "string",
+					"description": "Pagination token from a previous response. When pagination.hasMore is true, pass pagination.continueToken to fetch the next page; it takes precedence over page.",
+				},
 				"search": map[string]interface{}{
 					"type":        "string",
 					"description": "Search term to filter dashboards by title",
@@ -329,6 +378,10 @@ func GetVisualizationsPaginatedTool() mcp.Tool {
 					"description": "Number of results per page (default: 20, max: 100)",
 					"default":     20,
 				},
+				"continueToken": map[string]interface{}{
+					"type":        "string",
+					"description": "Pagination token from a previous response. When pagination.hasMore is true, pass pagination.continueToken to fetch the next page; it takes precedence over page.",
+				},
 				"search": map[string]interface{}{
 					"type":        "string",
 					"description": "Search term to filter visualizations by title",
@@ -368,6 +421,10 @@ func GetSavedObjectsAdvancedTool() mcp.Tool {
 					"description": "Number of results per page (default: 30, max: 200)",
 					"default":     30,
 				},
+				"continueToken": map[string]interface{}{
+					"type":        "string",
+					"description": "Pagination token from a previous response. When pagination.hasMore is true, pass pagination.continueToken to fetch the next page; it takes precedence over page.",
+				},
 				"sort_field": map[string]interface{}{
 					"type":        "string",
 					"description": "Field to sort by: title, updated_at, created_at (default: title)",
@@ -398,7 +455,7 @@ func GetSavedObjectsAdvancedTool() mcp.Tool {
 func GetDashboardDetailAdvancedTool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "kibana_get_dashboard_detail_advanced",
-		Description: "🔍 Advanced dashboard detail retrieval with enhanced formatting and optional components. Use when comprehensive analysis needed.",
+		Description: "🔍 Advanced dashboard detail retrieval with enhanced formatting and optional components. Use when comprehensive analysis needed. Accepts a dashboard ID or exact title.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -406,6 +463,10 @@ func GetDashboardDetailAdvancedTool() mcp.Tool {
 					"type":        "string",
 					"description": "The unique identifier of the dashboard to retrieve",
 				},
+				"dashboard_title": map[string]interface{}{
+					"type":        "string",
+					"description": dashboardTitleParamDescription,
+				},
 				"include_panels": map[string]interface{}{
 					"type":        "boolean",
 					"description": "Include detailed panel information. Default: true",
@@ -427,7 +488,6 @@ func GetDashboardDetailAdvancedTool() mcp.Tool {
 					"default":     "structured",
 				},
 			},
-			Required: []string{"dashboard_id"},
 		},
 	}
 }
@@ -436,13 +496,13 @@ func GetDashboardDetailAdvancedTool() mcp.Tool {
 func GetKibanaHealthSummaryTool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "kibana_health_summary",
-		Description: "⚠️ PRIORITY: Get Kibana health and status summary (status, version, metrics). Lightweight health overview. Optimized for monitoring.",
+		Description: "⚠️ PRIORITY: Get Kibana health and status summary (status, version, metrics). Lightweight health overview. Optimized for monitoring. Use level=deep for a one-call verdict that also checks task manager, alerting/actions and Elasticsearch cluster health and returns an overall healthy flag.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"level": map[string]interface{}{
 					"type":        "string",
-					"description": "Health detail level: basic (default), detailed, or metrics",
+					"description": "Health detail level: basic (default), detailed, metrics, or deep (adds task manager, alerting/actions and Elasticsearch checks)",
 					"default":     "basic",
 				},
 				"include_saved_objects": map[string]interface{}{
@@ -494,6 +554,69 @@ func QueryLogsTool() mcp.Tool {
 	}
 }
 
+// LogVolumeTool returns tool definition for log volume histograms.
+func LogVolumeTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_log_volume",
+		Description: "📈 Log volume over time: counts of log entries matching a query per fixed interval, without returning documents. Use it for questions like 'show me the error rate over the last hour' (e.g., query 'level:error', from 'now-1h', interval '1m'). Empty intervals are returned with count 0.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"indexPattern": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional index pattern to count (e.g., 'logs-*'). If not specified, counts all indices",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Lucene query string. Default: '*' (match all)",
+					"default":     "*",
+				},
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start of the time range (e.g., 'now-24h' or an ISO timestamp). Default: now-1h",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "End of the time range. Default: now",
+				},
+				"interval": map[string]interface{}{
+					"type":        "string",
+					"description": "Bucket size as an Elasticsearch fixed_interval (e.g., '30s', '5m', '1h', '1d'). Default: 1m",
+					"default":     "1m",
+				},
+				"timeField": map[string]interface{}{
+					"type":        "string",
+					"description": "Timestamp field to bucket on. Default: @timestamp",
+					"default":     "@timestamp",
+				},
+			},
+		},
+	}
+}
+
+// QueryESQLTool returns tool definition for ES|QL queries.
+func QueryESQLTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_query_esql",
+		Description: "🔍 Run an ES|QL query (Elastic Stack 8.11+) through Kibana. Returns compact tabular output: column names, column types, and rows, plus took/row count metadata. Use `| LIMIT n` in the query to bound the result.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "ES|QL query (e.g., 'FROM logs-* | WHERE log.level == \"error\" | STATS count = COUNT(*) BY host.name | LIMIT 20')",
+				},
+				"max_rows": map[string]interface{}{
+					"type":        "number",
+					"description": "Maximum number of rows to return. Default: 100, max: 1000",
+					"default":     100,
+				},
+			},
+			Required: []string{"query"},
+		},
+	}
+}
+
 // GetCanvasWorkpadsTool returns tool definition for Canvas workpads.
 func GetCanvasWorkpadsTool() mcp.Tool {
 	return mcp.Tool{
@@ -560,6 +683,33 @@ func GetIndexPatternFieldsTool() mcp.Tool {
 	}
 }
 
+// GetFieldStatsTool returns tool definition for field existence and cardinality inspection.
+func GetFieldStatsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_field_stats",
+		Description: "🔎 Inspect a field before writing a query: how many documents have it, its approximate distinct count and its most frequent values with counts. Use it to build filters without guessing field contents.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"indexPattern": map[string]interface{}{
+					"type":        "string",
+					"description": "Index pattern to inspect (e.g., 'logs-*')",
+				},
+				"field": map[string]interface{}{
+					"type":        "string",
+					"description": "Field name (e.g., 'kubernetes.namespace' or 'message.keyword'). Text fields cannot be aggregated; use their keyword subfield",
+				},
+				"size": map[string]interface{}{
+					"type":        "number",
+					"description": "Number of top values to return. Default: 10, max: 50",
+					"default":     10,
+				},
+			},
+			Required: []string{"indexPattern", "field"},
+		},
+	}
+}
+
 // ============ Write Operations: Spaces ============
 
 // CreateSpaceTool returns tool definition for creating a new Kibana space
@@ -667,6 +817,38 @@ func DeleteSpaceTool() mcp.Tool {
 	}
 }
 
+// SpaceCopyAllTool returns tool definition for copying all saved objects between spaces
+func SpaceCopyAllTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_space_copy_all",
+		Description: "📦 Copy every saved object from one space to another (e.g., for environment promotion). Enumerates objects in the source space and copies them in batches with their references. Reports per-type found/copied counts and any conflicts; conflicting objects are left untouched unless overwrite is true.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"sourceSpace": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the space to copy from",
+				},
+				"targetSpace": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the space to copy to",
+				},
+				"types": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Saved object types to copy (e.g., ['dashboard','visualization']). Default: all common types (config, index-pattern, search, visualization, lens, dashboard, map, canvas-workpad, tag, query, links, event-annotation-group)",
+				},
+				"overwrite": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Overwrite objects that already exist in the target space (default: false)",
+					"default":     false,
+				},
+			},
+			Required: []string{"sourceSpace", "targetSpace"},
+		},
+	}
+}
+
 // ============ Write Operations: Index Patterns ============
 
 // CreateIndexPatternTool returns tool definition for creating a new index pattern
@@ -932,6 +1114,14 @@ func CreateVisualizationTool() mcp.Tool {
 					"type":        "string",
 					"description": "Optional saved search reference name. Snake_case alias `saved_search_ref_name` is also accepted.",
 				},
+				"dataViewId": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional ID of the data view the visualization reads from. Snake_case alias `data_view_id` is also accepted.",
+				},
+				"dataViewTitle": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional data view title (e.g. 'logs-*') resolved to its ID; the call fails if no data view or several share the title. Ignored when `dataViewId` is set.",
+				},
 			},
 			Required: []string{"title"},
 		},
@@ -1032,7 +1222,7 @@ func CreateSavedObjectTool() mcp.Tool {
 				},
 				"references": map[string]interface{}{
 					"type":        "array",
-					"description": "Array of object references for linking related objects",
+					"description": "Array of object references for linking related objects. An `index-pattern` reference may give `title` instead of `id` to have the data view title resolved to its ID.",
 					"items": map[string]interface{}{
 						"type": "object",
 					},
@@ -1069,7 +1259,7 @@ func UpdateSavedObjectTool() mcp.Tool {
 				},
 				"references": map[string]interface{}{
 					"type":        "array",
-					"description": "Updated object references",
+					"description": "Updated object references. An `index-pattern` reference may give `title` instead of `id`, as in kibana_create_saved_object.",
 					"items": map[string]interface{}{
 						"type": "object",
 					},
@@ -1140,11 +1330,84 @@ func BulkDeleteSavedObjectsTool() mcp.Tool {
 	}
 }
 
+// BulkGetSavedObjectsTool returns tool definition for getting multiple saved objects
+func BulkGetSavedObjectsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_bulk_get_saved_objects",
+		Description: "📥 Get multiple saved objects by type and id in a single request. Missing objects are reported per object instead of failing the batch.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"objects": map[string]interface{}{
+					"type":        "array",
+					"description": "Array of objects to get with type and id",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"type": map[string]interface{}{
+								"type": "string",
+							},
+							"id": map[string]interface{}{
+								"type": "string",
+							},
+						},
+					},
+				},
+			},
+			Required: []string{"objects"},
+		},
+	}
+}
+
+// BulkUpdateSavedObjectsTool returns tool definition for updating multiple saved objects
+func BulkUpdateSavedObjectsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_bulk_update_saved_objects",
+		Description: "✏️ Apply partial updates to multiple saved objects in a single request, e.g. to retag objects or repoint visualizations to a new data view. Failures and version conflicts are reported per object instead of failing the batch.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"objects": map[string]interface{}{
+					"type":        "array",
+					"description": "Array of updates. Each needs type, id and attributes or references.",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"type": map[string]interface{}{
+								"type": "string",
+							},
+							"id": map[string]interface{}{
+								"type": "string",
+							},
+							"attributes": map[string]interface{}{
+								"type":        "object",
+								"description": "Attributes to merge with the existing ones",
+							},
+							"references": map[string]interface{}{
+								"type":        "array",
+								"description": "References replacing the existing ones. An `index-pattern` reference may give `title` instead of `id`, as in kibana_create_saved_object.",
+								"items": map[string]interface{}{
+									"type": "object",
+								},
+							},
+							"version": map[string]interface{}{
+								"type":        "string",
+								"description": "Version the update is based on; a changed object is then reported as a conflict instead of being overwritten",
+							},
+						},
+					},
+				},
+			},
+			Required: []string{"objects"},
+		},
+	}
+}
+
 // ExportSavedObjectsTool returns tool definition for exporting saved objects
 func ExportSavedObjectsTool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "kibana_export_saved_objects",
-		Description: "📦 Export saved objects to a JSON file for backup or migration to another Kibana instance.",
+		Description: "📦 Export saved objects as NDJSON for backup or migration to another Kibana instance, by explicit objects or by type. Use output=file for large exports to get a server-side file path instead of the content.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -1163,13 +1426,23 @@ func ExportSavedObjectsTool() mcp.Tool {
 						},
 					},
 				},
+				"types": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Export every object of these types (e.g. ['dashboard']) instead of an explicit objects list",
+				},
 				"includeReferences": map[string]interface{}{
 					"type":        "boolean",
 					"description": "Include all referenced objects in the export (default: true)",
 					"default":     true,
 				},
+				"output": map[string]interface{}{
+					"type":        "string",
+					"description": "inline returns the NDJSON in the response; file writes it to the server's export directory and returns the path and size, for exports too large to return inline",
+					"enum":        []string{"inline", "file"},
+					"default":     "inline",
+				},
 			},
-			Required: []string{"objects"},
 		},
 	}
 }
@@ -1178,13 +1451,17 @@ func ExportSavedObjectsTool() mcp.Tool {
 func ImportSavedObjectsTool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "kibana_import_saved_objects",
-		Description: "📥 Import saved objects from a JSON file. Supports objects exported from kibana_export_saved_objects.",
+		Description: "📥 Import saved objects from an NDJSON export, passed inline or as a server-side file path. Supports objects exported from kibana_export_saved_objects.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"file": map[string]interface{}{
 					"type":        "string",
-					"description": "Base64-encoded JSON content of the exported objects",
+					"description": "NDJSON content of the exported objects, raw or base64-encoded",
+				},
+				"filePath": map[string]interface{}{
+					"type":        "string",
+					"description": "Path of an NDJSON export on the server, absolute or relative to the import directory (kibana.importDir, by default the export directory). Use instead of file for large bundles",
 				},
 				"createNewCopies": map[string]interface{}{
 					"type":        "boolean",
@@ -1192,7 +1469,6 @@ func ImportSavedObjectsTool() mcp.Tool {
 					"default":     false,
 				},
 			},
-			Required: []string{"file"},
 		},
 	}
 }
@@ -1203,7 +1479,7 @@ func ImportSavedObjectsTool() mcp.Tool {
 func GetAlertRulesTool() mcp.Tool {
 	return mcp.Tool{
 		Name:        "kibana_get_alert_rules",
-		Description: "🚨 List all alert rules with filtering and pagination. Returns rule summaries including name, type, schedule, and enabled status.",
+		Description: "🚨 List alert rules with filtering by name, tags and enabled status, sorting, and pagination. Returns the rules with the total count and pagination info.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -1225,11 +1501,42 @@ func GetAlertRulesTool() mcp.Tool {
 					"type":        "boolean",
 					"description": "Filter by enabled status (true/false, omit for all)",
 				},
+				"tags": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Only return rules carrying any of these tags",
+				},
+				"sort_field": map[string]interface{}{
+					"type":        "string",
+					"description": "Field to sort by: name, createdAt, updatedAt, enabled or executionStatus",
+					"enum":        []string{"name", "createdAt", "updatedAt", "enabled", "executionStatus"},
+				},
+				"sort_order": map[string]interface{}{
+					"type":        "string",
+					"description": "Sort order: asc or desc",
+					"enum":        []string{"asc", "desc"},
+				},
+				"continueToken": map[string]interface{}{
+					"type":        "string",
+					"description": "Pagination token from a previous response. When pagination.hasMore is true, pass pagination.continueToken to fetch the next page; it takes precedence over page.",
+				},
 			},
 		},
 	}
 }
 
+// GetAlertRulesSummaryTool returns tool definition for the alert rule status rollup
+func GetAlertRulesSummaryTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_alert_rules_summary",
+		Description: "📊 Count alert rules by last execution status (ok, active, error, pending, ...) and by enabled/disabled. The fastest way to check whether any alerting rule is erroring without paging through all rules.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+}
+
 // GetAlertRuleTool returns tool definition for getting a specific alert rule
 func GetAlertRuleTool() mcp.Tool {
 	return mcp.Tool{
@@ -1412,6 +1719,64 @@ func MuteAlertRuleTool() mcp.Tool {
 	}
 }
 
+// BulkEnableAlertRulesTool returns tool definition for enabling several alert rules
+func BulkEnableAlertRulesTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_bulk_enable_alert_rules",
+		Description: "✅ Enable several alert rules at once, selected by rule_ids and/or tags. Returns per-rule success or failure.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: bulkAlertRulesProperties(),
+		},
+	}
+}
+
+// BulkDisableAlertRulesTool returns tool definition for disabling several alert rules
+func BulkDisableAlertRulesTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_bulk_disable_alert_rules",
+		Description: "⏸️ Disable several alert rules at once, selected by rule_ids and/or tags. Returns per-rule success or failure.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: bulkAlertRulesProperties(),
+		},
+	}
+}
+
+// BulkMuteAlertRulesTool returns tool definition for muting several alert rules
+func BulkMuteAlertRulesTool() mcp.Tool {
+	properties := bulkAlertRulesProperties()
+	properties["duration"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Duration to mute (e.g., '1h', '30m', '7d')",
+	}
+	return mcp.Tool{
+		Name:        "kibana_bulk_mute_alert_rules",
+		Description: "🔇 Mute several alert rules at once for a defined time period, selected by rule_ids and/or tags. Returns per-rule success or failure.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"duration"},
+		},
+	}
+}
+
+// bulkAlertRulesProperties returns the rule selection parameters shared by the bulk rule tools
+func bulkAlertRulesProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"rule_ids": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "IDs of the alert rules",
+		},
+		"tags": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Also select every rule carrying any of these tags",
+		},
+	}
+}
+
 // UnmuteAlertRuleTool returns tool definition for unmuting an alert rule
 func UnmuteAlertRuleTool() mcp.Tool {
 	return mcp.Tool{
@@ -1470,6 +1835,96 @@ func GetAlertRuleHistoryTool() mcp.Tool {
 	}
 }
 
+// ============ Maintenance Windows ============
+
+// GetMaintenanceWindowsTool returns tool definition for listing maintenance windows
+func GetMaintenanceWindowsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_maintenance_windows",
+		Description: "🔕 List alerting maintenance windows of the space with their title, status, schedule and scope. Requires Kibana 8.19 or 9.1 and later; returns available=false otherwise.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"status": map[string]interface{}{
+					"type":        "string",
+					"description": "Only list windows with this status",
+					"enum":        []string{"running", "upcoming", "finished", "archived", "disabled"},
+				},
+				"page": map[string]interface{}{
+					"type":        "number",
+					"description": "Page number (default: 1)",
+					"default":     1,
+				},
+				"per_page": map[string]interface{}{
+					"type":        "number",
+					"description": "Results per page (default: 20, max: 100)",
+					"default":     20,
+				},
+			},
+		},
+	}
+}
+
+// CreateMaintenanceWindowTool returns tool definition for creating a maintenance window
+func CreateMaintenanceWindowTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_create_maintenance_window",
+		Description: "🔕 Create an alerting maintenance window that suppresses notifications of every rule in the space, or of the alerts matching `scopeKql`, for example during a deployment. Rules keep running and alerts are still recorded. Requires Kibana 8.19 or 9.1 and later.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"title": map[string]interface{}{
+					"type":        "string",
+					"description": "Title of the maintenance window",
+				},
+				"start": map[string]interface{}{
+					"type":        "string",
+					"description": "Start time in RFC 3339 format, e.g. 2026-01-02T15:00:00Z",
+				},
+				"duration": map[string]interface{}{
+					"type":        "string",
+					"description": "How long the window lasts, e.g. 30m, 2h or 1d",
+				},
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "IANA timezone the schedule is evaluated in, e.g. Europe/Berlin (default: UTC)",
+				},
+				"recurring": map[string]interface{}{
+					"type":        "object",
+					"description": "Repeat the window, e.g. {\"every\": \"1w\", \"onWeekDay\": [\"MO\"], \"end\": \"2026-06-01T00:00:00Z\"} or {\"every\": \"1d\", \"occurrences\": 5}. Omit for a one-off window.",
+				},
+				"scopeKql": map[string]interface{}{
+					"type":        "string",
+					"description": "Only suppress alerts matching this KQL query, e.g. kibana.alert.rule.tags: \"checkout\"",
+				},
+				"enabled": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Whether the window is active (default: true)",
+				},
+			},
+			Required: []string{"title", "start", "duration"},
+		},
+	}
+}
+
+// DeleteMaintenanceWindowTool returns tool definition for deleting a maintenance window
+func DeleteMaintenanceWindowTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_delete_maintenance_window",
+		Description: "🗑️ Delete an alerting maintenance window. Notifications of the rules it covered resume immediately.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"maintenance_window_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the maintenance window to delete",
+				},
+			},
+			Required: []string{"maintenance_window_id"},
+		},
+	}
+}
+
 // ============ Connectors ============
 
 // GetConnectorsTool returns tool definition for listing connectors
@@ -1625,6 +2080,256 @@ func GetConnectorTypesTool() mcp.Tool {
 	}
 }
 
+// GetConnectorExecutionHistoryTool returns tool definition for reading a connector's recent executions
+func GetConnectorExecutionHistoryTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_connector_execution_history",
+		Description: "📜 Recent executions of a connector from the Kibana event log, newest first, with timestamp, status (success, failure, timeout), error message, duration and the rule that triggered it. Use it to find out why notifications are not arriving. Returns available=false when the credentials may not read the event log.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"connector_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the connector",
+				},
+				"page": map[string]interface{}{
+					"type":        "number",
+					"description": "Page number (default: 1)",
+					"default":     1,
+				},
+				"per_page": map[string]interface{}{
+					"type":        "number",
+					"description": "Executions per page (default: 20, max: 100)",
+					"default":     20,
+				},
+			},
+			Required: []string{"connector_id"},
+		},
+	}
+}
+
+// ============ SLOs ============
+
+// GetSLOsTool returns tool definition for listing SLOs
+func GetSLOsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_slos",
+		Description: "🎯 List Observability SLOs with their objective, current SLI, status, and remaining error budget. Supports KQL filtering and pagination. Returns available=false when the SLO feature is not enabled in Kibana.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"kqlQuery": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional KQL filter (e.g., 'slo.name: checkout*' or 'slo.tags: payments')",
+				},
+				"page": map[string]interface{}{
+					"type":        "number",
+					"description": "Page number (default: 1)",
+					"default":     1,
+				},
+				"per_page": map[string]interface{}{
+					"type":        "number",
+					"description": "Results per page (default: 20, max: 100)",
+					"default":     20,
+				},
+				"continueToken": map[string]interface{}{
+					"type":        "string",
+					"description": "Pagination token from a previous response (pagination.continueToken). Takes precedence over page",
+				},
+			},
+		},
+	}
+}
+
+// GetSLOTool returns tool definition for getting a specific SLO
+func GetSLOTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_slo",
+		Description: "🎯 Get a specific SLO including its indicator, time window, budgeting method, current SLI, and error budget details.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"slo_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the SLO",
+				},
+			},
+			Required: []string{"slo_id"},
+		},
+	}
+}
+
+// ============ Machine Learning ============
+
+// GetMLJobsTool returns tool definition for listing ML anomaly detection jobs
+func GetMLJobsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_ml_jobs",
+		Description: "🤖 List ML anomaly detection jobs with job state, datafeed state, processed record count, and bucket counts. Reports a clear license error when machine learning is not licensed.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+}
+
+// GetMLJobStatsTool returns tool definition for getting the stats of an ML job
+func GetMLJobStatsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_ml_job_stats",
+		Description: "🤖 Get the state, datafeed state, record/bucket counts, latest timestamps, and memory status of a specific ML anomaly detection job.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"job_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the anomaly detection job",
+				},
+			},
+			Required: []string{"job_id"},
+		},
+	}
+}
+
+// ============ Reporting ============
+
+// GenerateReportTool returns tool definition for generating a dashboard report
+func GenerateReportTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_generate_report",
+		Description: "📄 Export a dashboard as a PDF or PNG report. Reporting is asynchronous: the tool queues a job, waits up to timeout_seconds, and when ready writes the file to the server's export directory (kibana.exportDir) and returns its path and size. If it is still generating, the response has status=pending and a jobId; call again with job_id to resume.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"dashboard_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the dashboard to export. Required unless job_id is given",
+				},
+				"job_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Resume a previously queued report job instead of starting a new one",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Report format: pdf (default) or png",
+					"enum":        []string{"pdf", "png"},
+					"default":     "pdf",
+				},
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start of the dashboard time range (e.g., 'now-24h' or an ISO timestamp). Default: now-15m",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "End of the dashboard time range. Default: now",
+				},
+				"wait": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Wait for the report to finish and write it to the export directory (default: true). Set false to only queue the job",
+					"default":     true,
+				},
+				"timeout_seconds": map[string]interface{}{
+					"type":        "number",
+					"description": "Maximum time to wait for the report (default: 60, max: 300)",
+					"default":     60,
+				},
+			},
+		},
+	}
+}
+
+// ============ Fleet ============
+
+// GetFleetAgentsTool returns tool definition for listing Fleet agents
+func GetFleetAgentsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_fleet_agents",
+		Description: "🛰️ List Elastic Agents enrolled in Fleet with health status, last check-in, version, and agent policy. Supports KQL filtering (e.g., 'status:offline') and pagination. Includes per-status counts for the returned page.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"kuery": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional KQL filter (e.g., 'status:offline', 'policy_id:\"fleet-server-policy\"', 'local_metadata.host.hostname:web-*')",
+				},
+				"page": map[string]interface{}{
+					"type":        "number",
+					"description": "Page number (default: 1)",
+					"default":     1,
+				},
+				"per_page": map[string]interface{}{
+					"type":        "number",
+					"description": "Results per page (default: 20, max: 100)",
+					"default":     20,
+				},
+				"continueToken": map[string]interface{}{
+					"type":        "string",
+					"description": "Pagination token from a previous response (pagination.continueToken). Takes precedence over page",
+				},
+			},
+		},
+	}
+}
+
+// GetFleetAgentPoliciesTool returns tool definition for listing Fleet agent policies
+func GetFleetAgentPoliciesTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_fleet_agent_policies",
+		Description: "🛰️ List Fleet agent policies with namespace, revision, and enrolled agent count.",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+}
+
+// ============ Synthetics ============
+
+// GetSyntheticsMonitorsTool returns tool definition for listing Synthetics monitors
+func GetSyntheticsMonitorsTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_synthetics_monitors",
+		Description: "🩺 List Synthetics (Uptime) monitors with name, type, URL, and up/down status with the last check time. Returns available=false when the Synthetics app is not installed.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"page": map[string]interface{}{
+					"type":        "number",
+					"description": "Page number (default: 1)",
+					"default":     1,
+				},
+				"per_page": map[string]interface{}{
+					"type":        "number",
+					"description": "Results per page (default: 20, max: 100)",
+					"default":     20,
+				},
+				"continueToken": map[string]interface{}{
+					"type":        "string",
+					"description": "Pagination token from a previous response (pagination.continueToken). Takes precedence over page",
+				},
+			},
+		},
+	}
+}
+
+// GetSyntheticsMonitorStatusTool returns tool definition for getting a Synthetics monitor status
+func GetSyntheticsMonitorStatusTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_get_synthetics_monitor_status",
+		Description: "🩺 Get the latest check result of a Synthetics monitor: up/down status, last check time, location, duration, and error message.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"monitor_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The monitor ID (or config ID) as returned by kibana_get_synthetics_monitors",
+				},
+			},
+			Required: []string{"monitor_id"},
+		},
+	}
+}
+
 // ============ Data Views (Index Patterns v2) ============
 
 // GetDataViewsTool returns tool definition for listing data views
@@ -1668,6 +2373,24 @@ func GetDataViewTool() mcp.Tool {
 	}
 }
 
+// ResolveDataViewTool returns tool definition for resolving a data view title to its ID
+func ResolveDataViewTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_resolve_data_view",
+		Description: "Translate a data view (index pattern) title such as 'logs-*' to its ID. Fails when no data view or more than one data view has exactly that title.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"title": map[string]interface{}{
+					"type":        "string",
+					"description": "Exact data view title",
+				},
+			},
+			Required: []string{"title"},
+		},
+	}
+}
+
 // CreateDataViewTool returns tool definition for creating a data view
 func CreateDataViewTool() mcp.Tool {
 	return mcp.Tool{
@@ -1757,3 +2480,35 @@ func DeleteDataViewTool() mcp.Tool {
 		},
 	}
 }
+
+// MigrateDataViewReferencesTool returns tool definition for repointing saved objects to another data view
+func MigrateDataViewReferencesTool() mcp.Tool {
+	return mcp.Tool{
+		Name:        "kibana_migrate_data_view_references",
+		Description: "🔀 Repoint every saved object referencing one data view (index pattern) to another, e.g. after a data view was recreated with a new ID. Finds the referencing objects, rewrites their references and bulk-updates them. Objects changed meanwhile are reported as conflicts instead of being overwritten. Run with dryRun first to preview the objects that would change.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"oldDataViewID": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the data view the objects reference today",
+				},
+				"newDataViewID": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the existing data view the objects should reference instead",
+				},
+				"types": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Saved object types to migrate (e.g., ['visualization','lens']). Default: search, visualization, lens, dashboard, map, event-annotation-group",
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only list the objects and references that would be rewritten, without updating them (default: false)",
+					"default":     false,
+				},
+			},
+			Required: []string{"oldDataViewID", "newDataViewID"},
+		},
+	}
+}
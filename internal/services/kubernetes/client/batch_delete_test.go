@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newBatchDeleteTestClient(objects ...runtime.Object) (*Client, *fakedynamic.FakeDynamicClient) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "ConfigMapList"}, objects...)
+	return &Client{
+		dynamicClient: dynamicClient,
+		gvrCache:      map[string]schema.GroupVersionResource{"configmap": gvr},
+		cacheExpiry:   time.Now().Add(time.Hour),
+	}, dynamicClient
+}
+
+func newTestConfigMap(name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestDeleteResourcesByLabel(t *testing.T) {
+	objects := []runtime.Object{
+		newTestConfigMap("a", map[string]string{"app": "web"}),
+		newTestConfigMap("b", map[string]string{"app": "web"}),
+		newTestConfigMap("c", map[string]string{"app": "web"}),
+		newTestConfigMap("other", map[string]string{"app": "db"}),
+	}
+
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		c, dynamicClient := newBatchDeleteTestClient(objects...)
+		result, err := c.DeleteResourcesByLabel(context.Background(), "ConfigMap", "default", "app=web", 10, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Method != "dryRun" || !reflect.DeepEqual(result.Names, []string{"a", "b", "c"}) {
+			t.Fatalf("unexpected dry run result: %+v", result)
+		}
+		for _, action := range dynamicClient.Actions() {
+			if action.GetVerb() == "delete" || action.GetVerb() == "delete-collection" {
+				t.Fatalf("dry run performed %s", action.GetVerb())
+			}
+		}
+	})
+
+	t.Run("deletes only the listed resources", func(t *testing.T) {
+		c, dynamicClient := newBatchDeleteTestClient(objects...)
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		// A matching resource created after the list must survive
+		dynamicClient.PrependReactor("delete", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if _, err := dynamicClient.Tracker().Get(gvr, "default", "late"); apierrors.IsNotFound(err) {
+				if err := dynamicClient.Tracker().Add(newTestConfigMap("late", map[string]string{"app": "web"})); err != nil {
+					t.Fatalf("failed to add late config map: %v", err)
+				}
+			}
+			return false, nil, nil
+		})
+		result, err := c.DeleteResourcesByLabel(context.Background(), "ConfigMap", "default", "app=web", 10, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Method != "individual" || result.Truncated || !reflect.DeepEqual(result.Names, []string{"a", "b", "c"}) {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+		for _, action := range dynamicClient.Actions() {
+			if action.GetVerb() == "delete-collection" {
+				t.Fatal("expected no collection delete")
+			}
+		}
+		if _, err := dynamicClient.Tracker().Get(gvr, "default", "late"); err != nil {
+			t.Fatalf("expected the late config map to be kept, got %v", err)
+		}
+	})
+
+	t.Run("caps deletes and reports truncation", func(t *testing.T) {
+		c, _ := newBatchDeleteTestClient(objects...)
+		result, err := c.DeleteResourcesByLabel(context.Background(), "ConfigMap", "default", "app=web", 2, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Method != "individual" || !result.Truncated || len(result.Names) != 2 {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("rejects empty selector", func(t *testing.T) {
+		c, _ := newBatchDeleteTestClient(objects...)
+		if _, err := c.DeleteResourcesByLabel(context.Background(), "ConfigMap", "default", " ", 10, false); err == nil {
+			t.Fatal("expected empty selector to be rejected")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
+
+// WithBearerToken returns a client for the same API server that authenticates with token instead of the
+// connection's own credentials, so requests run with the caller's RBAC permissions rather than the
+// server's identity. Rate limits and timeout are carried over.
+func (c *Client) WithBearerToken(token string) (*Client, error) {
+	token = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+	if token == "" {
+		return nil, fmt.Errorf("bearer token is empty")
+	}
+	if c.restConfig == nil {
+		return nil, fmt.Errorf("client has no connection to authenticate against")
+	}
+
+	opts := DefaultClientOptions()
+	opts.KubeconfigPath = c.kubeconfigPath
+	if c.connectionMode != "" {
+		opts.ConnectionMode = c.connectionMode
+	}
+	opts.QPS = c.restConfig.QPS
+	opts.Burst = c.restConfig.Burst
+	opts.Timeout = c.restConfig.Timeout
+	opts.BearerToken = token
+	return NewClientWithOptions(opts)
+}
+
+// UsesCallerToken reports whether the client authenticates with a bearer token supplied by the caller.
+// Results of such clients depend on the caller's permissions and must not be shared with other callers.
+func (c *Client) UsesCallerToken() bool {
+	return c.callerToken
+}
+
+// withBearerToken returns a copy of config that keeps the server address and TLS trust but drops every
+// credential of the connection - client certificates, tokens, basic auth, exec and auth providers and
+// impersonation - in favor of token
+func withBearerToken(config *rest.Config, token string) *rest.Config {
+	anonymous := rest.AnonymousClientConfig(config)
+	anonymous.BearerToken = token
+	return anonymous
+}
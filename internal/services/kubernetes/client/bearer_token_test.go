@@ -0,0 +1,49 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestWithBearerTokenDropsConnectionCredentials(t *testing.T) {
+	config := &rest.Config{
+		Host:            "https://cluster.example:6443",
+		BearerToken:     "server-token",
+		BearerTokenFile: "/var/run/secrets/token",
+		Username:        "admin",
+		Password:        "secret",
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:   []byte("ca"),
+			CertData: []byte("cert"),
+			KeyData:  []byte("key"),
+		},
+		Impersonate: rest.ImpersonationConfig{UserName: "someone"},
+	}
+
+	got := withBearerToken(config, "caller-token")
+	if got.Host != config.Host || string(got.CAData) != "ca" {
+		t.Fatalf("expected the API server and CA to be kept, got host %q CA %q", got.Host, got.CAData)
+	}
+	if got.BearerToken != "caller-token" {
+		t.Fatalf("BearerToken = %q, want the caller's token", got.BearerToken)
+	}
+	if got.BearerTokenFile != "" || got.Username != "" || got.Password != "" || got.CertData != nil || got.KeyData != nil || got.Impersonate.UserName != "" {
+		t.Fatalf("expected the connection's credentials to be dropped, got %+v", got)
+	}
+	if config.BearerToken != "server-token" {
+		t.Fatal("expected the original config to be left untouched")
+	}
+}
+
+func TestParseRequestHeadersBearerToken(t *testing.T) {
+	h := http.Header{}
+	h.Set(hdrToken, "Bearer abc.def")
+	if got := parseRequestHeaders(h).BearerToken; got != "abc.def" {
+		t.Fatalf("BearerToken = %q, want %q", got, "abc.def")
+	}
+	if got := parseRequestHeaders(http.Header{}).BearerToken; got != "" {
+		t.Fatalf("expected no token without the header, got %q", got)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
@@ -32,11 +33,18 @@ import (
 // ClientOptions holds configuration parameters for creating a Kubernetes client.
 // It allows customization of timeouts, rate limiting, and caching behavior.
 type ClientOptions struct {
-	KubeconfigPath string        // Path to kubeconfig file (empty for default)
-	Timeout        time.Duration // API request timeout
-	QPS            float32       // Queries per second rate limit
-	Burst          int           // Burst limit for rate limiting
-	GVRCacheTTL    time.Duration // GroupVersionResource cache time-to-live
+	KubeconfigPath      string        // Path to kubeconfig file (empty for default)
+	ConnectionMode      string        // auto, in-cluster or kubeconfig (empty for auto)
+	Timeout             time.Duration // API request timeout
+	QPS                 float32       // Queries per second rate limit
+	Burst               int           // Burst limit for rate limiting
+	MaxIdleConns        int           // Idle connections kept open across API servers (0 for client-go's transport)
+	MaxIdleConnsPerHost int           // Idle connections kept open per API server
+	RateLimitDiscovery  bool          // Apply the QPS/burst limiter to discovery requests too
+	GVRCacheTTL         time.Duration // GroupVersionResource cache time-to-live
+	DiscoveryCacheTTL   time.Duration // Time-to-live of the discovery cache shared per API server
+	BearerToken         string        // Caller's token replacing the connection's own credentials (never logged)
+	UserAgent           string        // User-Agent sent with every request (empty for client-go's default)
 }
 
 // Client provides high-level operations for interacting with Kubernetes clusters.
@@ -50,8 +58,11 @@ type Client struct {
 	cacheDiscovery  *CacheableDiscovery                            // Enhanced cacheable discovery client
 	authClient      authorizationv1client.AuthorizationV1Interface // Authorization client
 	metricsClient   metricsv1beta1.Interface                       // Metrics client for resource usage
+	scaleClient     scale.ScalesGetter                             // Scale subresource client, built on first use when nil
 	restConfig      *rest.Config                                   // REST configuration
 	kubeconfigPath  string                                         // Path to kubeconfig file
+	connectionMode  string                                         // in-cluster or kubeconfig, as resolved
+	callerToken     bool                                           // Authenticated with the caller's bearer token
 
 	// GVR cache for performance optimization
 	gvrCache    map[string]schema.GroupVersionResource // Cache mapping kind to GVR
@@ -60,37 +71,40 @@ type Client struct {
 	cacheTTL    time.Duration                          // Cache time-to-live duration
 }
 
-// DefaultClientOptions returns default client options
+// DefaultClientOptions returns default client options, including the connection and transport
+// defaults set at startup
 func DefaultClientOptions() *ClientOptions {
+	mode, kubeconfigPath := connectionDefaults()
+	settings := currentTransportDefaults()
 	return &ClientOptions{
-		Timeout:     30 * time.Second,
-		QPS:         100,
-		Burst:       200,
-		GVRCacheTTL: 15 * time.Minute,
+		KubeconfigPath:      kubeconfigPath,
+		ConnectionMode:      mode,
+		Timeout:             settings.Timeout,
+		QPS:                 settings.QPS,
+		Burst:               settings.Burst,
+		MaxIdleConns:        settings.MaxIdleConns,
+		MaxIdleConnsPerHost: settings.MaxIdleConnsPerHost,
+		RateLimitDiscovery:  settings.RateLimitDiscovery,
+		GVRCacheTTL:         15 * time.Minute,
+		DiscoveryCacheTTL:   currentDiscoveryCacheTTL(),
+		UserAgent:           settings.UserAgent,
 	}
 }
 
 // NewClientWithOptions creates a new Kubernetes client with the specified options
 func NewClientWithOptions(opts *ClientOptions) (*Client, error) {
-	kubeconfigPath := resolveKubeconfigPath(opts.KubeconfigPath)
-
-	var config *rest.Config
-	var err error
-
-	if kubeconfigPath != "" {
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
-	} else {
-		config, err = rest.InClusterConfig()
-		if err != nil {
-			logrus.Debug("Not running in Kubernetes cluster, falling back to kubeconfig")
-			config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
-		} else {
-			logrus.Info("Using in-cluster configuration (service account credentials)")
-		}
+	mode, err := ParseConnectionMode(opts.ConnectionMode)
+	if err != nil {
+		return nil, err
 	}
+	config, connection, err := loadRestConfig(mode, opts.KubeconfigPath)
 	if err != nil {
 		return nil, err
 	}
+	logrus.WithFields(logrus.Fields{"mode": connection.Mode, "kubeconfig": connection.KubeconfigPath, "callerToken": opts.BearerToken != ""}).Debug("Resolved Kubernetes connection")
+	if opts.BearerToken != "" {
+		config = withBearerToken(config, opts.BearerToken)
+	}
 
 	// Apply rate limiting and timeout configuration
 	if opts.QPS > 0 {
@@ -102,30 +116,44 @@ func NewClientWithOptions(opts *ClientOptions) (*Client, error) {
 	if opts.Timeout > 0 {
 		config.Timeout = opts.Timeout
 	}
+	if opts.UserAgent != "" {
+		config.UserAgent = opts.UserAgent
+	}
+	discoveryConfig := applyRateLimiter(config, opts.RateLimitDiscovery)
 
-	clientset, err := kubernetes.NewForConfig(config)
+	// One HTTP client, and so one connection pool, serves all API clients
+	httpClient, err := newHTTPClient(config, opts.MaxIdleConns, opts.MaxIdleConnsPerHost)
 	if err != nil {
 		return nil, err
 	}
 
-	dynamicClient, err := dynamic.NewForConfig(config)
+	clientset, err := kubernetes.NewForConfigAndClient(config, httpClient)
 	if err != nil {
 		return nil, err
 	}
 
-	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	dynamicClient, err := dynamic.NewForConfigAndClient(config, httpClient)
 	if err != nil {
 		return nil, err
 	}
 
-	metricsClient, err := metricsv1beta1.NewForConfig(config)
+	discoveryClient, err := discovery.NewDiscoveryClientForConfigAndClient(discoveryConfig, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	metricsClient, err := metricsv1beta1.NewForConfigAndClient(config, httpClient)
 	if err != nil {
 		// Metrics client is optional - don't fail if metrics server is not available
 		metricsClient = nil
 	}
 
-	// Create enhanced cacheable discovery client
-	cacheDiscovery := NewCacheableDiscovery(discoveryClient, 10*time.Minute)
+	// Discovery results are shared by all clients of the API server
+	discoveryCacheTTL := opts.DiscoveryCacheTTL
+	if discoveryCacheTTL <= 0 {
+		discoveryCacheTTL = DefaultDiscoveryCacheTTL
+	}
+	cacheDiscovery := newSharedCacheableDiscovery(config.Host, discoveryClient, discoveryCacheTTL)
 
 	return &Client{
 		clientset:       clientset,
@@ -135,7 +163,9 @@ func NewClientWithOptions(opts *ClientOptions) (*Client, error) {
 		authClient:      clientset.AuthorizationV1(),
 		metricsClient:   metricsClient,
 		restConfig:      config,
-		kubeconfigPath:  kubeconfigPath,
+		kubeconfigPath:  connection.KubeconfigPath,
+		connectionMode:  connection.Mode,
+		callerToken:     opts.BearerToken != "",
 		gvrCache:        make(map[string]schema.GroupVersionResource, 100), // Pre-allocate size
 		cacheTTL:        opts.GVRCacheTTL,
 	}, nil
@@ -228,6 +258,32 @@ func containsSlash(s string) bool {
 
 // PortForward creates a port forward to a pod
 func (c *Client) PortForward(ctx context.Context, podName, namespace string, localPort, podPort int32, address string) error {
+	_, err := c.PortForwardPorts(ctx, podName, namespace, []PortMapping{{LocalPort: localPort, PodPort: podPort}}, address)
+	return err
+}
+
+// PortForwardPorts forwards several ports to a pod in one session. It fails only when no mapping
+// could be established; a mapping whose local port could not be bound is reported with its error.
+func (c *Client) PortForwardPorts(ctx context.Context, podName, namespace string, mappings []PortMapping, address string) ([]PortForwardMapping, error) {
+	forward, err := c.startPortForward(ctx, podName, namespace, mappings, address)
+	if err != nil {
+		return nil, err
+	}
+	return forward.mappings, nil
+}
+
+// podForward is a port forward to one pod running in the background
+type podForward struct {
+	// stop ends the forward
+	stop func()
+	// done is closed once the forward has ended, whether stopped or because the pod went away
+	done <-chan struct{}
+	// mappings is the outcome of each requested mapping
+	mappings []PortForwardMapping
+}
+
+// startPortForward establishes a port forward running in the background.
+func (c *Client) startPortForward(ctx context.Context, podName, namespace string, mappings []PortMapping, address string) (*podForward, error) {
 	// Build the URL for port forward
 	req := c.clientset.CoreV1().RESTClient().Post().
 		Resource("pods").
@@ -238,13 +294,13 @@ func (c *Client) PortForward(ctx context.Context, podName, namespace string, loc
 	// Create SPDY transport
 	transport, upgrader, err := spdy.RoundTripperFor(c.restConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create SPDY round tripper: %w", err)
+		return nil, fmt.Errorf("failed to create SPDY round tripper: %w", err)
 	}
 
 	// Parse URL
 	u, err := url.Parse(req.URL().String())
 	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 
 	// Create port forwarder with optimized HTTP client
@@ -253,54 +309,85 @@ func (c *Client) PortForward(ctx context.Context, podName, namespace string, loc
 	dialer := spdy.NewDialer(upgrader, optimizedClient, "POST", u)
 
 	// Setup port mapping
-	ports := []string{fmt.Sprintf("%d:%d", localPort, podPort)}
+	ports := make([]string, 0, len(mappings))
+	for _, mapping := range mappings {
+		ports = append(ports, fmt.Sprintf("%d:%d", mapping.LocalPort, mapping.PodPort))
+	}
 
 	// Create ready and stop channels
 	readyChannel := make(chan struct{})
 	stopChannel := make(chan struct{}, 1)
+	var stopOnce sync.Once
+	stop := func() { stopOnce.Do(func() { close(stopChannel) }) }
 
-	// Setup output streams (we'll use dummy writers since we're running in background)
+	// Setup output streams (we'll use dummy writers since we're running in background); the
+	// error stream reports the ports that could not be bound
 	out := &bytes.Buffer{}
-	errOut := &bytes.Buffer{}
+	errOut := &portForwardErrors{}
 
 	// Create port forwarder
-	pf, err := portforward.New(dialer, ports, stopChannel, readyChannel, out, errOut)
+	pf, err := portforward.NewOnAddresses(dialer, []string{address}, ports, stopChannel, readyChannel, out, errOut)
 	if err != nil {
-		return fmt.Errorf("failed to create port forwarder: %w", err)
+		return nil, fmt.Errorf("failed to create port forwarder: %w", err)
 	}
 
 	// Start port forwarding in a goroutine
+	forwardErr := make(chan error, 1)
+	done := make(chan struct{})
 	go func() {
-		defer close(stopChannel)
-		if err := pf.ForwardPorts(); err != nil {
+		defer close(done)
+		defer stop()
+		err := pf.ForwardPorts()
+		if err != nil {
 			logrus.WithError(err).Error("Port forwarding failed")
 		}
+		forwardErr <- err
 	}()
 
 	// Wait for ready signal or timeout
 	select {
 	case <-readyChannel:
-		logrus.WithFields(logrus.Fields{
-			"pod":       podName,
-			"namespace": namespace,
-			"localPort": localPort,
-			"podPort":   podPort,
-			"address":   address,
-		}).Info("Port forwarding established")
-		return nil
+	case err := <-forwardErr:
+		if err == nil {
+			err = fmt.Errorf("port forward ended before it was ready")
+		}
+		return nil, err
 	case <-time.After(30 * time.Second):
-		close(stopChannel)
-		return fmt.Errorf("timeout waiting for port forward to be ready")
+		stop()
+		return nil, fmt.Errorf("timeout waiting for port forward to be ready")
 	case <-ctx.Done():
-		close(stopChannel)
-		return ctx.Err()
+		stop()
+		return nil, ctx.Err()
+	}
+
+	results := make([]PortForwardMapping, len(mappings))
+	forwarded, _ := pf.GetPorts()
+	failures := errOut.listenFailures()
+	for i, mapping := range mappings {
+		result := PortForwardMapping{LocalPort: mapping.LocalPort, PodPort: mapping.PodPort, Established: true}
+		if i < len(forwarded) {
+			result.LocalPort = int32(forwarded[i].Local)
+		}
+		if failure, failed := failures[result.LocalPort]; failed {
+			result.Established = false
+			result.Error = "unable to listen on local port: " + failure
+		}
+		results[i] = result
 	}
+
+	logrus.WithFields(logrus.Fields{
+		"pod":       podName,
+		"namespace": namespace,
+		"ports":     ports,
+		"address":   address,
+	}).Info("Port forwarding established")
+	return &podForward{stop: stop, done: done, mappings: results}, nil
 }
 
 // GetResourceUsage retrieves resource usage metrics for nodes or pods
 func (c *Client) GetResourceUsage(ctx context.Context, resourceType, name, namespace string) (map[string]any, error) {
-	if c.metricsClient == nil {
-		return nil, fmt.Errorf("metrics client not available - ensure metrics server is installed and accessible")
+	if err := c.requireMetricsAPI(); err != nil {
+		return nil, err
 	}
 
 	switch strings.ToLower(resourceType) {
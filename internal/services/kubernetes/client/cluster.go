@@ -0,0 +1,299 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// clusterInfoUnknown marks a cluster info section that could not be collected
+	clusterInfoUnknown = "unknown"
+	// metricsAPIGroup is the API group served by metrics-server
+	metricsAPIGroup = "metrics.k8s.io"
+)
+
+// Ping checks the API server answers a version request before ctx ends
+func (c *Client) Ping(ctx context.Context) error {
+	var err error
+	if restClient := c.discoveryClient.RESTClient(); restClient != nil {
+		// ServerVersion takes no context, so it would only be bounded by the client timeout
+		err = restClient.Get().AbsPath("/version").Do(ctx).Error()
+	} else {
+		_, err = c.discoveryClient.ServerVersion()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reach the Kubernetes API server: %w", err)
+	}
+	return nil
+}
+
+// GetClusterInfo aggregates version, node, namespace and metrics-server information
+// into a compact overview. Each section is collected independently; a section whose
+// call fails (for example because of missing RBAC permissions) is reported as
+// "unknown" and the reason is recorded under "errors".
+func (c *Client) GetClusterInfo(ctx context.Context) map[string]any {
+	logrus.Debug("GetClusterInfo called")
+
+	info := map[string]any{}
+	sectionErrors := map[string]string{}
+
+	if version, err := c.discoveryClient.ServerVersion(); err != nil {
+		info["version"] = clusterInfoUnknown
+		sectionErrors["version"] = err.Error()
+	} else {
+		info["version"] = map[string]any{
+			"gitVersion": version.GitVersion,
+			"platform":   version.Platform,
+		}
+	}
+
+	if nodes, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{}); err != nil {
+		info["nodes"] = clusterInfoUnknown
+		info["resources"] = clusterInfoUnknown
+		sectionErrors["nodes"] = err.Error()
+	} else {
+		info["nodes"], info["resources"] = summarizeNodes(nodes.Items)
+	}
+
+	if namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{}); err != nil {
+		info["namespaces"] = clusterInfoUnknown
+		sectionErrors["namespaces"] = err.Error()
+	} else {
+		info["namespaces"] = map[string]any{"count": len(namespaces.Items)}
+	}
+
+	if groups, err := c.serverGroups(); err != nil {
+		info["metricsServer"] = clusterInfoUnknown
+		sectionErrors["metricsServer"] = err.Error()
+	} else {
+		info["metricsServer"] = map[string]any{"available": hasAPIGroup(groups, metricsAPIGroup)}
+	}
+
+	if len(sectionErrors) > 0 {
+		info["errors"] = sectionErrors
+	}
+
+	logrus.WithField("failedSections", len(sectionErrors)).Debug("GetClusterInfo succeeded")
+	return info
+}
+
+// summarizeNodes counts node readiness and sums capacity and allocatable CPU and memory
+func summarizeNodes(nodes []corev1.Node) (map[string]any, map[string]any) {
+	ready := 0
+	capacityCPU, capacityMemory := resource.Quantity{}, resource.Quantity{}
+	allocatableCPU, allocatableMemory := resource.Quantity{}, resource.Quantity{}
+
+	for _, node := range nodes {
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+				ready++
+				break
+			}
+		}
+		capacityCPU.Add(node.Status.Capacity[corev1.ResourceCPU])
+		capacityMemory.Add(node.Status.Capacity[corev1.ResourceMemory])
+		allocatableCPU.Add(node.Status.Allocatable[corev1.ResourceCPU])
+		allocatableMemory.Add(node.Status.Allocatable[corev1.ResourceMemory])
+	}
+
+	nodeSummary := map[string]any{
+		"total":    len(nodes),
+		"ready":    ready,
+		"notReady": len(nodes) - ready,
+	}
+	resources := map[string]any{
+		"capacity": map[string]any{
+			"cpu":    capacityCPU.String(),
+			"memory": capacityMemory.String(),
+		},
+		"allocatable": map[string]any{
+			"cpu":    allocatableCPU.String(),
+			"memory": allocatableMemory.String(),
+		},
+	}
+	return nodeSummary, resources
+}
+
+// ResourceAllocation compares the requested amount of a resource with what a node can allocate
+type ResourceAllocation struct {
+	Allocatable string  `json:"allocatable"`
+	Requested   string  `json:"requested"`
+	Percent     float64 `json:"percent"`
+}
+
+// PodAllocation compares the number of pods scheduled on a node with its pod capacity
+type PodAllocation struct {
+	Count    int     `json:"count"`
+	Capacity int64   `json:"capacity"`
+	Percent  float64 `json:"percent"`
+}
+
+// NodeAllocation summarizes how much of a node is committed to scheduled pods
+type NodeAllocation struct {
+	Name          string             `json:"name"`
+	Ready         bool               `json:"ready"`
+	Unschedulable bool               `json:"unschedulable,omitempty"`
+	Pressure      []string           `json:"pressure,omitempty"`
+	CPU           ResourceAllocation `json:"cpu"`
+	Memory        ResourceAllocation `json:"memory"`
+	Pods          PodAllocation      `json:"pods"`
+}
+
+// pressureScore ranks nodes so that the most constrained ones sort first:
+// active pressure conditions dominate, followed by the highest allocation percentage.
+func (n NodeAllocation) pressureScore() float64 {
+	score := float64(len(n.Pressure)) * 1000
+	if !n.Ready {
+		score += 1000
+	}
+	return score + max(n.CPU.Percent, n.Memory.Percent, n.Pods.Percent)
+}
+
+// GetNodeAllocationSummary returns every node with its requested vs allocatable CPU and memory,
+// active pressure conditions and pod counts, sorted with the most pressured nodes first.
+// Requests are summed from all non-terminated pods scheduled to each node.
+func (c *Client) GetNodeAllocationSummary(ctx context.Context) ([]NodeAllocation, error) {
+	logrus.Debug("GetNodeAllocationSummary called")
+
+	nodes, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var pods []corev1.Pod
+	opts := metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+		Limit:         500,
+	}
+	for {
+		page, err := c.clientset.CoreV1().Pods("").List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		pods = append(pods, page.Items...)
+		if page.Continue == "" {
+			break
+		}
+		opts.Continue = page.Continue
+	}
+
+	allocations := buildNodeAllocations(nodes.Items, pods)
+
+	logrus.WithField("nodes", len(allocations)).Debug("GetNodeAllocationSummary succeeded")
+	return allocations, nil
+}
+
+// buildNodeAllocations computes per-node allocation from the node list and the pods scheduled to them
+func buildNodeAllocations(nodes []corev1.Node, pods []corev1.Pod) []NodeAllocation {
+	type requested struct {
+		cpu, memory resource.Quantity
+		count       int
+	}
+	byNode := make(map[string]*requested, len(nodes))
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		r, ok := byNode[pod.Spec.NodeName]
+		if !ok {
+			r = &requested{}
+			byNode[pod.Spec.NodeName] = r
+		}
+		podRequests := podResourceRequests(pod)
+		r.cpu.Add(podRequests[corev1.ResourceCPU])
+		r.memory.Add(podRequests[corev1.ResourceMemory])
+		r.count++
+	}
+
+	allocations := make([]NodeAllocation, 0, len(nodes))
+	for _, node := range nodes {
+		r := byNode[node.Name]
+		if r == nil {
+			r = &requested{}
+		}
+		allocatableCPU := node.Status.Allocatable[corev1.ResourceCPU]
+		allocatableMemory := node.Status.Allocatable[corev1.ResourceMemory]
+		podCapacity := node.Status.Allocatable[corev1.ResourcePods]
+
+		allocation := NodeAllocation{
+			Name:          node.Name,
+			Unschedulable: node.Spec.Unschedulable,
+			CPU: ResourceAllocation{
+				Allocatable: allocatableCPU.String(),
+				Requested:   r.cpu.String(),
+				Percent:     percentOf(r.cpu.MilliValue(), allocatableCPU.MilliValue()),
+			},
+			Memory: ResourceAllocation{
+				Allocatable: allocatableMemory.String(),
+				Requested:   r.memory.String(),
+				Percent:     percentOf(r.memory.Value(), allocatableMemory.Value()),
+			},
+			Pods: PodAllocation{
+				Count:    r.count,
+				Capacity: podCapacity.Value(),
+				Percent:  percentOf(int64(r.count), podCapacity.Value()),
+			},
+		}
+		for _, cond := range node.Status.Conditions {
+			switch cond.Type {
+			case corev1.NodeReady:
+				allocation.Ready = cond.Status == corev1.ConditionTrue
+			case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+				if cond.Status == corev1.ConditionTrue {
+					allocation.Pressure = append(allocation.Pressure, string(cond.Type))
+				}
+			}
+		}
+		allocations = append(allocations, allocation)
+	}
+
+	sort.SliceStable(allocations, func(i, j int) bool {
+		si, sj := allocations[i].pressureScore(), allocations[j].pressureScore()
+		if si != sj {
+			return si > sj
+		}
+		return allocations[i].Name < allocations[j].Name
+	})
+	return allocations
+}
+
+// podResourceRequests returns the effective requests of a pod the way the scheduler sees them:
+// the sum of app containers, raised to the largest init container, plus pod overhead.
+func podResourceRequests(pod corev1.Pod) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			sum := total[name]
+			sum.Add(quantity)
+			total[name] = sum
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+				total[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for name, quantity := range pod.Spec.Overhead {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+	return total
+}
+
+// percentOf returns used as a percentage of total rounded to one decimal place
+func percentOf(used, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(used)/float64(total)*1000) / 10
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestNode(name string, ready bool, cpu, memory string) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			Capacity:    resources,
+			Allocatable: resources,
+		},
+	}
+}
+
+func TestGetClusterInfoDegradesPerSection(t *testing.T) {
+	clientset := fake.NewClientset(
+		newTestNode("node-a", true, "2", "4Gi"),
+		newTestNode("node-b", false, "2", "4Gi"),
+	)
+	clientset.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", nil)
+	})
+	discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.FakedServerVersion = &version.Info{GitVersion: "v1.35.0", Platform: "linux/amd64"}
+	discovery.Resources = []*metav1.APIResourceList{{GroupVersion: "metrics.k8s.io/v1beta1"}}
+
+	c := &Client{clientset: clientset, discoveryClient: discovery}
+	info := c.GetClusterInfo(context.Background())
+
+	nodes, ok := info["nodes"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected nodes section, got %v", info["nodes"])
+	}
+	if nodes["total"] != 2 || nodes["ready"] != 1 || nodes["notReady"] != 1 {
+		t.Fatalf("unexpected node summary: %v", nodes)
+	}
+
+	resources := info["resources"].(map[string]any)
+	if cpu := resources["allocatable"].(map[string]any)["cpu"]; cpu != "4" {
+		t.Fatalf("expected allocatable cpu 4, got %v", cpu)
+	}
+
+	if info["namespaces"] != clusterInfoUnknown {
+		t.Fatalf("expected namespaces to be unknown, got %v", info["namespaces"])
+	}
+	if _, ok := info["errors"].(map[string]string)["namespaces"]; !ok {
+		t.Fatalf("expected namespaces error to be recorded, got %v", info["errors"])
+	}
+
+	if available := info["metricsServer"].(map[string]any)["available"]; available != true {
+		t.Fatalf("expected metrics-server to be detected, got %v", available)
+	}
+	if gitVersion := info["version"].(map[string]any)["gitVersion"]; gitVersion != "v1.35.0" {
+		t.Fatalf("unexpected version: %v", gitVersion)
+	}
+}
+
+func TestBuildNodeAllocationsSortsMostPressuredFirst(t *testing.T) {
+	idle := newTestNode("idle", true, "4", "8Gi")
+	busy := newTestNode("busy", true, "4", "8Gi")
+	pressured := newTestNode("pressured", true, "4", "8Gi")
+	pressured.Status.Conditions = append(pressured.Status.Conditions,
+		corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue})
+	for _, node := range []*corev1.Node{idle, busy, pressured} {
+		node.Status.Allocatable[corev1.ResourcePods] = resource.MustParse("110")
+	}
+
+	pod := func(node, cpu, initCPU string) corev1.Pod {
+		p := corev1.Pod{Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			}}},
+		}}
+		if initCPU != "" {
+			p.Spec.InitContainers = []corev1.Container{{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(initCPU)},
+			}}}
+		}
+		return p
+	}
+	pods := []corev1.Pod{
+		pod("busy", "1", ""),
+		pod("busy", "500m", "2"),
+		pod("", "1", ""),
+	}
+
+	allocations := buildNodeAllocations([]corev1.Node{*idle, *busy, *pressured}, pods)
+
+	var order []string
+	for _, a := range allocations {
+		order = append(order, a.Name)
+	}
+	if want := []string{"pressured", "busy", "idle"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+
+	busyAllocation := allocations[1]
+	if busyAllocation.CPU.Requested != "3" || busyAllocation.CPU.Percent != 75 {
+		t.Fatalf("unexpected busy cpu allocation: %+v", busyAllocation.CPU)
+	}
+	if busyAllocation.Pods.Count != 2 || busyAllocation.Pods.Capacity != 110 {
+		t.Fatalf("unexpected busy pod allocation: %+v", busyAllocation.Pods)
+	}
+	if !reflect.DeepEqual(allocations[0].Pressure, []string{"MemoryPressure"}) {
+		t.Fatalf("expected MemoryPressure on pressured node, got %v", allocations[0].Pressure)
+	}
+}
+
+func TestPingHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := &Client{discoveryClient: discovery.NewDiscoveryClientForConfigOrDie(&rest.Config{Host: server.URL})}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := c.Ping(ctx); err == nil {
+		t.Fatal("expected Ping to fail once the context ended")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected Ping to return at the context deadline, took %s", elapsed)
+	}
+}
@@ -19,6 +19,7 @@ const (
 	hdrQPS        = "X-Mcp-Backend-Kubernetes-Qps"
 	hdrBurst      = "X-Mcp-Backend-Kubernetes-Burst"
 	hdrTimeoutSec = "X-Mcp-Backend-Kubernetes-Timeout-Sec"
+	hdrToken      = "X-Mcp-Backend-Kubernetes-Token"
 )
 
 type kubernetesContextKey struct{}
@@ -28,11 +29,8 @@ func init() {
 }
 
 func parseHeadersAndInjectClient(r *http.Request) (*http.Request, error) {
+	// Without a kubeconfig header the connection defaults decide between in-cluster and kubeconfig
 	opts := parseRequestHeaders(r.Header)
-	if opts.KubeconfigPath == "" {
-		// Try in-cluster config or default kubeconfig - still create a client
-		opts.KubeconfigPath = os.Getenv("KUBECONFIG")
-	}
 	cli, err := NewClientWithOptions(opts)
 	if err != nil {
 		return r, err
@@ -67,6 +65,9 @@ func parseRequestHeaders(h http.Header) *ClientOptions {
 			opts.Timeout = time.Duration(sec) * time.Second
 		}
 	}
+	if v := strings.TrimSpace(h.Get(hdrToken)); v != "" {
+		opts.BearerToken = strings.TrimSpace(strings.TrimPrefix(v, "Bearer "))
+	}
 	return opts
 }
 
@@ -96,6 +97,11 @@ func writeTempKubeconfig(content string) string {
 	return tmpFile.Name()
 }
 
+// NewContext returns a copy of ctx carrying cli, where FromContext finds it
+func NewContext(ctx context.Context, cli *Client) context.Context {
+	return context.WithValue(ctx, kubernetesContextKey{}, cli)
+}
+
 // FromContext extracts the Kubernetes client from the request context.
 // Returns an error if no client was injected by the backend auth middleware.
 func FromContext(ctx context.Context) (*Client, error) {
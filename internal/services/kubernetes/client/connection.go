@@ -0,0 +1,137 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// ConnectionModeAuto uses an explicit kubeconfig when one is configured, otherwise the pod's
+	// service account when running in-cluster, otherwise the default kubeconfig file
+	ConnectionModeAuto = "auto"
+	// ConnectionModeInCluster always uses the pod's service account
+	ConnectionModeInCluster = "in-cluster"
+	// ConnectionModeKubeconfig always uses a kubeconfig file
+	ConnectionModeKubeconfig = "kubeconfig"
+)
+
+var (
+	connectionMu          sync.RWMutex
+	defaultConnectionMode = ConnectionModeAuto
+	defaultKubeconfigPath string
+)
+
+// SetConnectionDefaults sets the connection mode and kubeconfig used for clients whose request
+// does not carry its own kubeconfig. It is called once at startup from the server configuration.
+func SetConnectionDefaults(mode, kubeconfigPath string) error {
+	normalized, err := ParseConnectionMode(mode)
+	if err != nil {
+		return err
+	}
+	connectionMu.Lock()
+	defer connectionMu.Unlock()
+	defaultConnectionMode = normalized
+	defaultKubeconfigPath = kubeconfigPath
+	return nil
+}
+
+func connectionDefaults() (string, string) {
+	connectionMu.RLock()
+	defer connectionMu.RUnlock()
+	return defaultConnectionMode, defaultKubeconfigPath
+}
+
+// ParseConnectionMode validates a connection mode; an empty mode means auto
+func ParseConnectionMode(mode string) (string, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(mode)); normalized {
+	case "":
+		return ConnectionModeAuto, nil
+	case ConnectionModeAuto, ConnectionModeInCluster, ConnectionModeKubeconfig:
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("invalid Kubernetes connection mode %q: expected %s, %s or %s",
+			mode, ConnectionModeAuto, ConnectionModeInCluster, ConnectionModeKubeconfig)
+	}
+}
+
+// Connection describes how a client reaches the API server
+type Connection struct {
+	Mode           string // ConnectionModeInCluster or ConnectionModeKubeconfig
+	KubeconfigPath string // Kubeconfig file used, empty in-cluster
+	Server         string // API server URL
+}
+
+// DetectConnection resolves the connection the configured defaults lead to, without building a client.
+// It is used to report the chosen mode at startup.
+func DetectConnection() (*Connection, error) {
+	mode, kubeconfigPath := connectionDefaults()
+	config, connection, err := loadRestConfig(mode, kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	connection.Server = config.Host
+	return connection, nil
+}
+
+// loadRestConfig builds the REST configuration for a connection mode. In auto mode an explicit
+// kubeconfig (configured, sent with the request or named by KUBECONFIG) wins; otherwise the
+// service account is used when running inside a pod, and the default kubeconfig file is the last
+// resort. The forced modes never fall back to the other one.
+func loadRestConfig(mode, kubeconfigPath string) (*rest.Config, *Connection, error) {
+	if kubeconfigPath == "" && os.Getenv(clientcmd.RecommendedConfigPathEnvVar) != "" {
+		kubeconfigPath = resolveKubeconfigPath("")
+	}
+
+	switch mode {
+	case ConnectionModeInCluster:
+		if kubeconfigPath != "" {
+			logrus.WithField("kubeconfig", kubeconfigPath).Debug("Ignoring kubeconfig: connection mode is in-cluster")
+		}
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, nil, fmt.Errorf("connection mode is %s but the in-cluster configuration is unavailable: %w", ConnectionModeInCluster, err)
+		}
+		return config, &Connection{Mode: ConnectionModeInCluster}, nil
+
+	case ConnectionModeKubeconfig:
+		if kubeconfigPath == "" {
+			kubeconfigPath = resolveKubeconfigPath("")
+		}
+		if kubeconfigPath == "" {
+			return nil, nil, fmt.Errorf("connection mode is %s but no kubeconfig was found: set kubernetes.kubeconfig, --kubeconfig or KUBECONFIG, or create %s",
+				ConnectionModeKubeconfig, clientcmd.RecommendedHomeFile)
+		}
+		return loadKubeconfig(kubeconfigPath)
+
+	default:
+		if kubeconfigPath != "" {
+			return loadKubeconfig(kubeconfigPath)
+		}
+		if isInClusterConfig() {
+			config, err := rest.InClusterConfig()
+			if err != nil {
+				return nil, nil, fmt.Errorf("running in a pod but the in-cluster configuration is unavailable: %w", err)
+			}
+			return config, &Connection{Mode: ConnectionModeInCluster}, nil
+		}
+		if kubeconfigPath = resolveKubeconfigPath(""); kubeconfigPath != "" {
+			return loadKubeconfig(kubeconfigPath)
+		}
+		return nil, nil, fmt.Errorf("no Kubernetes configuration found: not running in a pod with a service account and no kubeconfig at %s; set kubernetes.kubeconfig, --kubeconfig or KUBECONFIG, or deploy the server in-cluster",
+			clientcmd.RecommendedHomeFile)
+	}
+}
+
+func loadKubeconfig(path string) (*rest.Config, *Connection, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+	return config, &Connection{Mode: ConnectionModeKubeconfig, KubeconfigPath: path}, nil
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetConnectionDefaults(t *testing.T) {
+	defer func() { _ = SetConnectionDefaults(ConnectionModeAuto, "") }()
+
+	if err := SetConnectionDefaults("service-account", ""); err == nil {
+		t.Fatal("expected an unknown connection mode to be rejected")
+	}
+	if err := SetConnectionDefaults(" In-Cluster ", "/etc/kube/config"); err != nil {
+		t.Fatalf("SetConnectionDefaults() error = %v", err)
+	}
+	opts := DefaultClientOptions()
+	if opts.ConnectionMode != ConnectionModeInCluster || opts.KubeconfigPath != "/etc/kube/config" {
+		t.Fatalf("expected the defaults in the client options, got mode %q kubeconfig %q", opts.ConnectionMode, opts.KubeconfigPath)
+	}
+}
+
+func TestLoadRestConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	// Neither a pod environment nor a usable default kubeconfig
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+
+	tests := []struct {
+		name       string
+		mode       string
+		kubeconfig string
+		wantMode   string
+		wantErr    string
+	}{
+		{name: "auto with explicit kubeconfig", mode: ConnectionModeAuto, kubeconfig: path, wantMode: ConnectionModeKubeconfig},
+		{name: "forced kubeconfig", mode: ConnectionModeKubeconfig, kubeconfig: path, wantMode: ConnectionModeKubeconfig},
+		{name: "auto without any configuration", mode: ConnectionModeAuto, wantErr: "no Kubernetes configuration found"},
+		{name: "forced kubeconfig without a file", mode: ConnectionModeKubeconfig, wantErr: "no kubeconfig was found"},
+		{name: "forced in-cluster outside a pod", mode: ConnectionModeInCluster, kubeconfig: path, wantErr: "in-cluster configuration is unavailable"},
+		{name: "unreadable explicit kubeconfig", mode: ConnectionModeAuto, kubeconfig: filepath.Join(t.TempDir(), "absent"), wantErr: "failed to load kubeconfig"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, connection, err := loadRestConfig(tt.mode, tt.kubeconfig)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadRestConfig() error = %v", err)
+			}
+			if connection.Mode != tt.wantMode || connection.KubeconfigPath != tt.kubeconfig {
+				t.Fatalf("unexpected connection: %+v", connection)
+			}
+			if config.Host != "https://staging.example.com:6443" {
+				t.Fatalf("expected the current context's server, got %q", config.Host)
+			}
+		})
+	}
+}
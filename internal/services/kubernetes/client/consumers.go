@@ -0,0 +1,311 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Places in a pod spec that can reference a ConfigMap or Secret
+const (
+	ConfigRefVolume          = "volume"
+	ConfigRefProjectedVolume = "projectedVolume"
+	ConfigRefEnv             = "env"
+	ConfigRefEnvFrom         = "envFrom"
+	ConfigRefImagePullSecret = "imagePullSecret"
+)
+
+// ConfigReference is one place a pod spec refers to the ConfigMap or Secret
+type ConfigReference struct {
+	Type      string `json:"type"`
+	Container string `json:"container,omitempty"`
+	Volume    string `json:"volume,omitempty"`
+	EnvVar    string `json:"envVar,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Optional  bool   `json:"optional,omitempty"`
+}
+
+// ConfigConsumer is a workload, or a standalone pod, that uses the ConfigMap or Secret
+type ConfigConsumer struct {
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	Pods       []string          `json:"pods,omitempty"`
+	References []ConfigReference `json:"references"`
+}
+
+// ConfigConsumerReport lists the consumers of one ConfigMap or Secret
+type ConfigConsumerReport struct {
+	Kind      string           `json:"kind"`
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	Exists    bool             `json:"exists"`
+	Consumers []ConfigConsumer `json:"consumers"`
+}
+
+// FindConfigConsumers finds the workloads that reference a ConfigMap or Secret through volumes
+// (including projected volumes), env valueFrom, envFrom or, for Secrets, imagePullSecrets. Pod
+// templates of Deployments, StatefulSets, DaemonSets, ReplicaSets, Jobs and CronJobs are scanned so
+// workloads scaled to zero are found too. Running pods are attributed to their top-level controller
+// (Pod -> ReplicaSet -> Deployment, Pod -> Job -> CronJob), which also catches pods left over from an
+// older revision. Pods without a controller are reported as standalone consumers.
+func (c *Client) FindConfigConsumers(ctx context.Context, kind, name, namespace string) (*ConfigConsumerReport, error) {
+	logrus.WithFields(logrus.Fields{"kind": kind, "name": name, "namespace": namespace}).Debug("FindConfigConsumers called")
+
+	switch strings.ToLower(kind) {
+	case "configmap":
+		kind = "ConfigMap"
+	case "secret":
+		kind = "Secret"
+	default:
+		return nil, fmt.Errorf("unsupported kind %q: must be ConfigMap or Secret", kind)
+	}
+	if name == "" || namespace == "" {
+		return nil, fmt.Errorf("name and namespace are required")
+	}
+
+	report := &ConfigConsumerReport{Kind: kind, Name: name, Namespace: namespace, Exists: true, Consumers: []ConfigConsumer{}}
+	var err error
+	if kind == "ConfigMap" {
+		_, err = c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		_, err = c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	// Consumers of a missing object are still worth reporting: they are the ones failing to start
+	if apierrors.IsNotFound(err) {
+		report.Exists = false
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", kind, namespace, name, err)
+	}
+
+	apps := c.clientset.AppsV1()
+	deployments, err := apps.Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	statefulSets, err := apps.StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	daemonSets, err := apps.DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	replicaSets, err := apps.ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	jobs, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	cronJobs, err := c.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	consumers := map[string]*ConfigConsumer{}
+	// templateRefs marks consumers whose references came from their own pod template
+	templateRefs := map[string]bool{}
+	consumer := func(kind, name string) *ConfigConsumer {
+		key := kind + "/" + name
+		if consumers[key] == nil {
+			consumers[key] = &ConfigConsumer{Kind: kind, Name: name}
+		}
+		return consumers[key]
+	}
+	addTemplate := func(kind, name string, spec *corev1.PodSpec) {
+		if refs := podSpecConfigReferences(spec, report.Kind, report.Name); len(refs) > 0 {
+			consumer(kind, name).References = refs
+			templateRefs[kind+"/"+name] = true
+		}
+	}
+
+	for i := range deployments.Items {
+		addTemplate("Deployment", deployments.Items[i].Name, &deployments.Items[i].Spec.Template.Spec)
+	}
+	for i := range statefulSets.Items {
+		addTemplate("StatefulSet", statefulSets.Items[i].Name, &statefulSets.Items[i].Spec.Template.Spec)
+	}
+	for i := range daemonSets.Items {
+		addTemplate("DaemonSet", daemonSets.Items[i].Name, &daemonSets.Items[i].Spec.Template.Spec)
+	}
+	// Owned ReplicaSets and Jobs share their controller's template and are covered above
+	for i := range replicaSets.Items {
+		if metav1.GetControllerOf(&replicaSets.Items[i]) == nil {
+			addTemplate("ReplicaSet", replicaSets.Items[i].Name, &replicaSets.Items[i].Spec.Template.Spec)
+		}
+	}
+	for i := range jobs.Items {
+		if metav1.GetControllerOf(&jobs.Items[i]) == nil {
+			addTemplate("Job", jobs.Items[i].Name, &jobs.Items[i].Spec.Template.Spec)
+		}
+	}
+	for i := range cronJobs.Items {
+		addTemplate("CronJob", cronJobs.Items[i].Name, &cronJobs.Items[i].Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	owners := newOwnerResolver(replicaSets.Items, jobs.Items)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		ownerKind, ownerName := owners.topLevelOwner(pod)
+		key := ownerKind + "/" + ownerName
+		refs := podSpecConfigReferences(&pod.Spec, report.Kind, report.Name)
+		if len(refs) == 0 {
+			continue
+		}
+		entry := consumer(ownerKind, ownerName)
+		if ownerKind != "Pod" {
+			entry.Pods = append(entry.Pods, pod.Name)
+		}
+		// Pods of an older revision may reference the object even though the current template does not
+		if !templateRefs[key] {
+			entry.References = mergeConfigReferences(entry.References, refs)
+		}
+	}
+
+	for _, entry := range consumers {
+		sort.Strings(entry.Pods)
+		report.Consumers = append(report.Consumers, *entry)
+	}
+	sort.Slice(report.Consumers, func(i, j int) bool {
+		a, b := report.Consumers[i], report.Consumers[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	logrus.WithFields(logrus.Fields{"consumers": len(report.Consumers), "exists": report.Exists}).Debug("FindConfigConsumers succeeded")
+	return report, nil
+}
+
+// podSpecConfigReferences returns every reference a pod spec makes to the named ConfigMap or Secret
+func podSpecConfigReferences(spec *corev1.PodSpec, kind, name string) []ConfigReference {
+	var refs []ConfigReference
+	isConfigMap := kind == "ConfigMap"
+
+	for _, volume := range spec.Volumes {
+		switch {
+		case isConfigMap && volume.ConfigMap != nil && volume.ConfigMap.Name == name:
+			refs = append(refs, ConfigReference{Type: ConfigRefVolume, Volume: volume.Name, Optional: isOptional(volume.ConfigMap.Optional)})
+		case !isConfigMap && volume.Secret != nil && volume.Secret.SecretName == name:
+			refs = append(refs, ConfigReference{Type: ConfigRefVolume, Volume: volume.Name, Optional: isOptional(volume.Secret.Optional)})
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if isConfigMap && source.ConfigMap != nil && source.ConfigMap.Name == name {
+					refs = append(refs, ConfigReference{Type: ConfigRefProjectedVolume, Volume: volume.Name, Optional: isOptional(source.ConfigMap.Optional)})
+				}
+				if !isConfigMap && source.Secret != nil && source.Secret.Name == name {
+					refs = append(refs, ConfigReference{Type: ConfigRefProjectedVolume, Volume: volume.Name, Optional: isOptional(source.Secret.Optional)})
+				}
+			}
+		}
+	}
+
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; isConfigMap && ref != nil && ref.Name == name {
+				refs = append(refs, ConfigReference{Type: ConfigRefEnv, Container: container.Name, EnvVar: env.Name, Key: ref.Key, Optional: isOptional(ref.Optional)})
+			}
+			if ref := env.ValueFrom.SecretKeyRef; !isConfigMap && ref != nil && ref.Name == name {
+				refs = append(refs, ConfigReference{Type: ConfigRefEnv, Container: container.Name, EnvVar: env.Name, Key: ref.Key, Optional: isOptional(ref.Optional)})
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if ref := envFrom.ConfigMapRef; isConfigMap && ref != nil && ref.Name == name {
+				refs = append(refs, ConfigReference{Type: ConfigRefEnvFrom, Container: container.Name, Optional: isOptional(ref.Optional)})
+			}
+			if ref := envFrom.SecretRef; !isConfigMap && ref != nil && ref.Name == name {
+				refs = append(refs, ConfigReference{Type: ConfigRefEnvFrom, Container: container.Name, Optional: isOptional(ref.Optional)})
+			}
+		}
+	}
+
+	if !isConfigMap {
+		for _, pullSecret := range spec.ImagePullSecrets {
+			if pullSecret.Name == name {
+				refs = append(refs, ConfigReference{Type: ConfigRefImagePullSecret})
+			}
+		}
+	}
+	return refs
+}
+
+func mergeConfigReferences(existing, refs []ConfigReference) []ConfigReference {
+	for _, ref := range refs {
+		found := false
+		for _, e := range existing {
+			if e == ref {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, ref)
+		}
+	}
+	return existing
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// ownerResolver walks pod controller references up to the workload users manage directly.
+// Controllers are keyed by namespace and name so lists spanning namespaces resolve correctly.
+type ownerResolver struct {
+	replicaSets map[string]*appsv1.ReplicaSet
+	jobs        map[string]*batchv1.Job
+}
+
+func newOwnerResolver(replicaSets []appsv1.ReplicaSet, jobs []batchv1.Job) ownerResolver {
+	r := ownerResolver{replicaSets: map[string]*appsv1.ReplicaSet{}, jobs: map[string]*batchv1.Job{}}
+	for i := range replicaSets {
+		r.replicaSets[replicaSets[i].Namespace+"/"+replicaSets[i].Name] = &replicaSets[i]
+	}
+	for i := range jobs {
+		r.jobs[jobs[i].Namespace+"/"+jobs[i].Name] = &jobs[i]
+	}
+	return r
+}
+
+// topLevelOwner returns the kind and name of the pod's outermost controller, or the pod itself
+func (r ownerResolver) topLevelOwner(pod *corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	switch owner.Kind {
+	case "ReplicaSet":
+		if rs, ok := r.replicaSets[pod.Namespace+"/"+owner.Name]; ok {
+			if parent := metav1.GetControllerOf(rs); parent != nil {
+				return parent.Kind, parent.Name
+			}
+		}
+	case "Job":
+		if job, ok := r.jobs[pod.Namespace+"/"+owner.Name]; ok {
+			if parent := metav1.GetControllerOf(job); parent != nil {
+				return parent.Kind, parent.Name
+			}
+		}
+	}
+	return owner.Kind, owner.Name
+}
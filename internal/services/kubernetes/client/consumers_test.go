@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindConfigConsumers(t *testing.T) {
+	controlledBy := func(kind, name string) []metav1.OwnerReference {
+		controller := true
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+	}
+	meta := func(name string, owners []metav1.OwnerReference) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default", OwnerReferences: owners}
+	}
+	envFromConfigMap := corev1.PodSpec{Containers: []corev1.Container{{
+		Name:    "app",
+		EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}}},
+	}}}
+	volumeFromConfigMap := corev1.PodSpec{
+		Volumes: []corev1.Volume{{Name: "config", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}},
+		}}},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+		Containers:       []corev1.Container{{Name: "app"}},
+	}
+
+	clientset := fake.NewClientset(
+		&corev1.ConfigMap{ObjectMeta: meta("settings", nil)},
+		&appsv1.Deployment{ObjectMeta: meta("web", nil), Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{Spec: envFromConfigMap},
+		}},
+		&appsv1.Deployment{ObjectMeta: meta("api", nil)},
+		&appsv1.ReplicaSet{ObjectMeta: meta("web-abc", controlledBy("Deployment", "web"))},
+		&appsv1.ReplicaSet{ObjectMeta: meta("api-old", controlledBy("Deployment", "api"))},
+		&corev1.Pod{ObjectMeta: meta("web-abc-1", controlledBy("ReplicaSet", "web-abc")), Spec: envFromConfigMap},
+		// A pod from an older api revision still mounts the ConfigMap although the template no longer does
+		&corev1.Pod{ObjectMeta: meta("api-old-1", controlledBy("ReplicaSet", "api-old")), Spec: volumeFromConfigMap},
+		&batchv1.CronJob{ObjectMeta: meta("report", nil), Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "report", Env: []corev1.EnvVar{{
+					Name: "LEVEL",
+					ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}, Key: "level",
+					}},
+				}}}},
+			}}}},
+		}},
+		&batchv1.Job{ObjectMeta: meta("report-1", controlledBy("CronJob", "report"))},
+		&corev1.Pod{ObjectMeta: meta("debug", nil), Spec: volumeFromConfigMap},
+		&corev1.Pod{ObjectMeta: meta("unrelated", nil), Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}},
+	)
+	c := &Client{clientset: clientset}
+
+	report, err := c.FindConfigConsumers(context.Background(), "configmap", "settings", "default")
+	if err != nil {
+		t.Fatalf("FindConfigConsumers() error = %v", err)
+	}
+	if report.Kind != "ConfigMap" || !report.Exists {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+
+	var names []string
+	for _, consumer := range report.Consumers {
+		names = append(names, consumer.Kind+"/"+consumer.Name)
+	}
+	want := []string{"CronJob/report", "Deployment/api", "Deployment/web", "Pod/debug"}
+	if len(names) != len(want) {
+		t.Fatalf("consumers = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("consumers = %v, want %v", names, want)
+		}
+	}
+
+	cron, api, web, debug := report.Consumers[0], report.Consumers[1], report.Consumers[2], report.Consumers[3]
+	if ref := cron.References[0]; ref.Type != ConfigRefEnv || ref.EnvVar != "LEVEL" || ref.Key != "level" {
+		t.Fatalf("unexpected cronjob reference: %+v", cron)
+	}
+	if len(api.Pods) != 1 || api.Pods[0] != "api-old-1" || api.References[0].Type != ConfigRefVolume {
+		t.Fatalf("expected the old api pod to be attributed to its deployment, got %+v", api)
+	}
+	if len(web.Pods) != 1 || web.Pods[0] != "web-abc-1" || len(web.References) != 1 || web.References[0].Type != ConfigRefEnvFrom {
+		t.Fatalf("unexpected web consumer: %+v", web)
+	}
+	if len(debug.Pods) != 0 || debug.References[0].Volume != "config" {
+		t.Fatalf("unexpected standalone pod consumer: %+v", debug)
+	}
+
+	report, err = c.FindConfigConsumers(context.Background(), "Secret", "registry", "default")
+	if err != nil {
+		t.Fatalf("FindConfigConsumers() error = %v", err)
+	}
+	if report.Exists || len(report.Consumers) != 2 || report.Consumers[1].References[0].Type != ConfigRefImagePullSecret {
+		t.Fatalf("expected image pull secret consumers of a missing secret, got %+v", report)
+	}
+
+	if _, err := c.FindConfigConsumers(context.Background(), "Deployment", "web", "default"); err == nil {
+		t.Fatal("expected an error for an unsupported kind")
+	}
+}
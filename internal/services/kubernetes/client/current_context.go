@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// serviceAccountNamespacePath holds the namespace of the pod's service account when running in-cluster
+var serviceAccountNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// AuthenticatedUser is the identity the API server resolved for the client's credentials
+type AuthenticatedUser struct {
+	Username string              `json:"username"`
+	UID      string              `json:"uid,omitempty"`
+	Groups   []string            `json:"groups,omitempty"`
+	Extra    map[string][]string `json:"extra,omitempty"`
+}
+
+// CurrentContext describes which cluster, credentials and namespace the client is using
+type CurrentContext struct {
+	Mode            string             `json:"mode"`
+	Kubeconfig      string             `json:"kubeconfig,omitempty"`
+	Context         string             `json:"context,omitempty"`
+	Cluster         string             `json:"cluster,omitempty"`
+	KubeconfigUser  string             `json:"kubeconfigUser,omitempty"`
+	Server          string             `json:"server"`
+	Namespace       string             `json:"namespace"`
+	User            *AuthenticatedUser `json:"user,omitempty"`
+	UserError       string             `json:"userError,omitempty"`
+	KubeconfigError string             `json:"kubeconfigError,omitempty"`
+}
+
+// GetCurrentContext reports the kubeconfig context (or in-cluster service account) the client was
+// built from, the API server it talks to and its default namespace. The authenticated user comes
+// from a SelfSubjectReview, so it reflects what the API server actually sees; when the review is
+// not available (clusters before 1.28 or missing permissions) the reason is recorded in UserError.
+func (c *Client) GetCurrentContext(ctx context.Context) *CurrentContext {
+	logrus.WithField("kubeconfig", c.kubeconfigPath).Debug("GetCurrentContext called")
+
+	current := &CurrentContext{Mode: c.connectionMode, Namespace: metav1.NamespaceDefault}
+	if current.Mode == "" {
+		current.Mode = ConnectionModeInCluster
+		if c.kubeconfigPath != "" {
+			current.Mode = ConnectionModeKubeconfig
+		}
+	}
+	if c.restConfig != nil {
+		current.Server = c.restConfig.Host
+	}
+
+	if current.Mode == ConnectionModeKubeconfig && c.kubeconfigPath != "" {
+		current.Kubeconfig = c.kubeconfigPath
+		if err := current.readKubeconfig(c.kubeconfigPath); err != nil {
+			logrus.WithError(err).Warn("Failed to read kubeconfig for current context")
+			current.KubeconfigError = err.Error()
+		}
+	} else if data, err := os.ReadFile(serviceAccountNamespacePath); err == nil {
+		if namespace := strings.TrimSpace(string(data)); namespace != "" {
+			current.Namespace = namespace
+		}
+	}
+
+	review, err := c.clientset.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		logrus.WithError(err).Debug("SelfSubjectReview failed")
+		current.UserError = err.Error()
+	} else {
+		info := review.Status.UserInfo
+		current.User = &AuthenticatedUser{Username: info.Username, UID: info.UID, Groups: info.Groups}
+		if len(info.Extra) > 0 {
+			current.User.Extra = map[string][]string{}
+			for key, values := range info.Extra {
+				current.User.Extra[key] = values
+			}
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{"mode": current.Mode, "context": current.Context, "server": current.Server}).Debug("GetCurrentContext succeeded")
+	return current
+}
+
+// readKubeconfig fills in the current context, its cluster, user and namespace from a kubeconfig file
+func (current *CurrentContext) readKubeconfig(path string) error {
+	raw, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return err
+	}
+	current.Context = raw.CurrentContext
+	kubeContext, ok := raw.Contexts[raw.CurrentContext]
+	if !ok {
+		return nil
+	}
+	current.Cluster = kubeContext.Cluster
+	current.KubeconfigUser = kubeContext.AuthInfo
+	if kubeContext.Namespace != "" {
+		current.Namespace = kubeContext.Namespace
+	}
+	if current.Server == "" {
+		if cluster, ok := raw.Clusters[kubeContext.Cluster]; ok {
+			current.Server = cluster.Server
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: staging
+contexts:
+- name: staging
+  context:
+    cluster: staging-cluster
+    user: deployer
+    namespace: shop
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: admin
+clusters:
+- name: staging-cluster
+  cluster:
+    server: https://staging.example.com:6443
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com:6443
+users:
+- name: deployer
+  user:
+    token: staging-token
+- name: admin
+  user:
+    token: prod-token
+`
+
+func TestGetCurrentContextFromKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &authenticationv1.SelfSubjectReview{Status: authenticationv1.SelfSubjectReviewStatus{
+			UserInfo: authenticationv1.UserInfo{Username: "deployer@example.com", Groups: []string{"system:authenticated", "deployers"}},
+		}}, nil
+	})
+	c := &Client{clientset: clientset, kubeconfigPath: path, restConfig: &rest.Config{Host: "https://staging.example.com:6443"}}
+
+	current := c.GetCurrentContext(context.Background())
+	if current.Mode != ConnectionModeKubeconfig || current.Context != "staging" || current.Cluster != "staging-cluster" || current.KubeconfigUser != "deployer" {
+		t.Fatalf("unexpected context: %+v", current)
+	}
+	if current.Server != "https://staging.example.com:6443" || current.Namespace != "shop" {
+		t.Fatalf("unexpected server or namespace: %+v", current)
+	}
+	if current.User == nil || current.User.Username != "deployer@example.com" || len(current.User.Groups) != 2 || current.UserError != "" {
+		t.Fatalf("unexpected user: %+v (error %q)", current.User, current.UserError)
+	}
+}
+
+func TestGetCurrentContextInCluster(t *testing.T) {
+	namespacePath := filepath.Join(t.TempDir(), "namespace")
+	if err := os.WriteFile(namespacePath, []byte("mcp-system\n"), 0o600); err != nil {
+		t.Fatalf("failed to write namespace file: %v", err)
+	}
+	previous := serviceAccountNamespacePath
+	serviceAccountNamespacePath = namespacePath
+	defer func() { serviceAccountNamespacePath = previous }()
+
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Group: "authentication.k8s.io", Resource: "selfsubjectreviews"}, "")
+	})
+	c := &Client{clientset: clientset, restConfig: &rest.Config{Host: "https://10.96.0.1:443"}}
+
+	current := c.GetCurrentContext(context.Background())
+	if current.Mode != ConnectionModeInCluster || current.Context != "" || current.Server != "https://10.96.0.1:443" {
+		t.Fatalf("unexpected in-cluster context: %+v", current)
+	}
+	if current.Namespace != "mcp-system" {
+		t.Fatalf("expected the service account namespace, got %q", current.Namespace)
+	}
+	if current.User != nil || current.UserError == "" {
+		t.Fatalf("expected the failed review to be reported, got user %+v error %q", current.User, current.UserError)
+	}
+}
@@ -0,0 +1,434 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// deprecatedAPI describes an apiVersion that Kubernetes deprecated, and the release that removed it
+type deprecatedAPI struct {
+	APIVersion  string
+	Kind        string // empty for every kind of the apiVersion
+	RemovedIn   string
+	Replacement string
+	Message     string // the API server's deprecation warning, when it reported one
+}
+
+func (d deprecatedAPI) message(apiVersion, kind string) string {
+	message := fmt.Sprintf("%s %s is deprecated", apiVersion, kind)
+	if d.RemovedIn != "" {
+		message += fmt.Sprintf(" and removed in Kubernetes %s", d.RemovedIn)
+	}
+	if d.Replacement != "" {
+		message += "; use " + d.Replacement
+	}
+	return message
+}
+
+func (d deprecatedAPI) matches(apiVersion, kind string) bool {
+	return d.APIVersion == apiVersion && (d.Kind == "" || d.Kind == kind)
+}
+
+// deprecatedAPIs lists the deprecated apiVersions of built-in kinds. Kind-specific entries come
+// before the catch-all entry of their apiVersion.
+var deprecatedAPIs = []deprecatedAPI{
+	{APIVersion: "extensions/v1beta1", Kind: "Ingress", RemovedIn: "1.22", Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "extensions/v1beta1", Kind: "NetworkPolicy", RemovedIn: "1.16", Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "extensions/v1beta1", Kind: "PodSecurityPolicy", RemovedIn: "1.16", Replacement: "policy/v1beta1"},
+	{APIVersion: "extensions/v1beta1", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "apps/v1beta1", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "apps/v1beta2", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "networking.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "apiextensions.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "apiextensions.k8s.io/v1"},
+	{APIVersion: "admissionregistration.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "admissionregistration.k8s.io/v1"},
+	{APIVersion: "apiregistration.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "apiregistration.k8s.io/v1"},
+	{APIVersion: "certificates.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "certificates.k8s.io/v1"},
+	{APIVersion: "coordination.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "coordination.k8s.io/v1"},
+	{APIVersion: "scheduling.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "scheduling.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", Kind: "CSIStorageCapacity", RemovedIn: "1.27", Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "storage.k8s.io/v1beta1", RemovedIn: "1.22", Replacement: "storage.k8s.io/v1"},
+	{APIVersion: "batch/v1beta1", RemovedIn: "1.25", Replacement: "batch/v1"},
+	{APIVersion: "policy/v1beta1", Kind: "PodSecurityPolicy", RemovedIn: "1.25", Replacement: "Pod Security Admission"},
+	{APIVersion: "policy/v1beta1", RemovedIn: "1.25", Replacement: "policy/v1"},
+	{APIVersion: "discovery.k8s.io/v1beta1", RemovedIn: "1.25", Replacement: "discovery.k8s.io/v1"},
+	{APIVersion: "events.k8s.io/v1beta1", RemovedIn: "1.25", Replacement: "events.k8s.io/v1"},
+	{APIVersion: "node.k8s.io/v1beta1", RemovedIn: "1.25", Replacement: "node.k8s.io/v1"},
+	{APIVersion: "autoscaling/v2beta1", RemovedIn: "1.25", Replacement: "autoscaling/v2"},
+	{APIVersion: "autoscaling/v2beta2", RemovedIn: "1.26", Replacement: "autoscaling/v2"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta1", RemovedIn: "1.26", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta2", RemovedIn: "1.29", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta3", RemovedIn: "1.32", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+}
+
+func findDeprecatedAPI(apiVersion, kind string) (deprecatedAPI, bool) {
+	return matchDeprecatedAPI(deprecatedAPIs, apiVersion, kind)
+}
+
+func matchDeprecatedAPI(apis []deprecatedAPI, apiVersion, kind string) (deprecatedAPI, bool) {
+	for _, api := range apis {
+		if api.matches(apiVersion, kind) {
+			return api, true
+		}
+	}
+	return deprecatedAPI{}, false
+}
+
+// DeprecatedAPIServed is a deprecated apiVersion the cluster still serves
+type DeprecatedAPIServed struct {
+	APIVersion  string `json:"apiVersion"`
+	Kind        string `json:"kind,omitempty"`
+	RemovedIn   string `json:"removedIn,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+	Message     string `json:"serverWarning,omitempty"`
+}
+
+// DeprecatedAPIObject is an object last written through a deprecated apiVersion
+type DeprecatedAPIObject struct {
+	Kind            string   `json:"kind"`
+	Name            string   `json:"name"`
+	Namespace       string   `json:"namespace,omitempty"`
+	APIVersion      string   `json:"deprecatedApiVersion"`
+	Replacement     string   `json:"replacement,omitempty"`
+	RemovedIn       string   `json:"removedIn,omitempty"`
+	ServedByCluster bool     `json:"servedByCluster"`
+	Sources         []string `json:"sources"`
+}
+
+// DeprecatedAPIReport is the result of a deprecated API scan
+type DeprecatedAPIReport struct {
+	ServerVersion        string                `json:"serverVersion,omitempty"`
+	TargetVersion        string                `json:"targetVersion,omitempty"`
+	Namespace            string                `json:"namespace,omitempty"`
+	ServedDeprecatedAPIs []DeprecatedAPIServed `json:"servedDeprecatedApis"`
+	Objects              []DeprecatedAPIObject `json:"objects"`
+	ScannedResources     []string              `json:"scannedResources"`
+	Errors               []string              `json:"errors,omitempty"`
+}
+
+// FindDeprecatedAPIUsage audits the cluster for deprecated apiVersions ahead of an upgrade. It
+// reports the deprecated apiVersions the cluster still serves, from the built-in removal map and
+// from the deprecation warnings the API server returns for them, and the objects whose managed
+// fields or last-applied configuration show they were written through one. Objects are stored
+// once and readable through every served version, so this is how clients still using an old
+// apiVersion are found. With targetVersion (e.g. "1.29") only APIs removed by that release are
+// considered; with namespace only namespaced objects of that namespace are scanned.
+func (c *Client) FindDeprecatedAPIUsage(ctx context.Context, namespace, targetVersion string) (*DeprecatedAPIReport, error) {
+	logrus.WithFields(logrus.Fields{"namespace": namespace, "targetVersion": targetVersion}).Debug("FindDeprecatedAPIUsage called")
+
+	target, err := parseMinorVersion(targetVersion)
+	if targetVersion != "" && err != nil {
+		return nil, fmt.Errorf("invalid targetVersion %q: %w", targetVersion, err)
+	}
+
+	groups, err := c.serverGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API groups: %w", err)
+	}
+	served := map[string]bool{}
+	preferred := map[string]string{}
+	for _, group := range groups.Groups {
+		preferred[group.Name] = group.PreferredVersion.GroupVersion
+		for _, version := range group.Versions {
+			served[version.GroupVersion] = true
+		}
+	}
+
+	report := &DeprecatedAPIReport{
+		TargetVersion:        targetVersion,
+		Namespace:            namespace,
+		ServedDeprecatedAPIs: []DeprecatedAPIServed{},
+		Objects:              []DeprecatedAPIObject{},
+		ScannedResources:     []string{},
+	}
+	if version, err := c.discoveryClient.ServerVersion(); err == nil {
+		report.ServerVersion = version.GitVersion
+	}
+
+	// The server's own warnings take precedence over the built-in map
+	apis := append(c.serverDeprecatedAPIs(ctx, groups), deprecatedAPIs...)
+	if targetVersion != "" {
+		apis = removedBy(apis, target)
+	}
+
+	reported := map[string]bool{}
+	for _, api := range apis {
+		key := api.APIVersion + "/" + api.Kind
+		if !served[api.APIVersion] || reported[key] {
+			continue
+		}
+		reported[key] = true
+		report.ServedDeprecatedAPIs = append(report.ServedDeprecatedAPIs, DeprecatedAPIServed{
+			APIVersion:  api.APIVersion,
+			Kind:        api.Kind,
+			RemovedIn:   api.RemovedIn,
+			Replacement: api.Replacement,
+			Message:     api.Message,
+		})
+	}
+
+	for _, resource := range c.deprecatedAPIScanTargets(apis, served, preferred, namespace != "") {
+		report.ScannedResources = append(report.ScannedResources, resource.gvr.String())
+		objects, err := c.scanDeprecatedAPIObjects(ctx, resource, namespace, apis, served)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", resource.gvr.String(), err))
+			continue
+		}
+		report.Objects = append(report.Objects, objects...)
+	}
+
+	sort.Slice(report.Objects, func(i, j int) bool {
+		a, b := report.Objects[i], report.Objects[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	logrus.WithFields(logrus.Fields{"served": len(report.ServedDeprecatedAPIs), "objects": len(report.Objects)}).Debug("FindDeprecatedAPIUsage succeeded")
+	return report, nil
+}
+
+// deprecatedAPIScanTarget is a resource listed to find objects written through a deprecated
+// apiVersion of its kind
+type deprecatedAPIScanTarget struct {
+	gvr  schema.GroupVersionResource
+	kind string
+}
+
+// deprecatedAPIScanTargets returns the resources whose objects may have been written through one
+// of apis: each API's kind (or every kind, for catch-all entries) in its replacement version, or
+// in its group's preferred version when the replacement is not served.
+func (c *Client) deprecatedAPIScanTargets(apis []deprecatedAPI, served map[string]bool, preferred map[string]string, namespacedOnly bool) []deprecatedAPIScanTarget {
+	resourceLists := map[string]*metav1.APIResourceList{}
+	seen := map[schema.GroupVersionResource]bool{}
+	var targets []deprecatedAPIScanTarget
+	for _, api := range apis {
+		scanVersion := api.Replacement
+		if !served[scanVersion] {
+			gv, err := schema.ParseGroupVersion(api.APIVersion)
+			if err != nil {
+				continue
+			}
+			scanVersion = preferred[gv.Group]
+		}
+		if scanVersion == "" {
+			continue
+		}
+
+		resources, cached := resourceLists[scanVersion]
+		if !cached {
+			resources, _ = c.discoveryClient.ServerResourcesForGroupVersion(scanVersion)
+			resourceLists[scanVersion] = resources
+		}
+		if resources == nil {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(scanVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range resources.APIResources {
+			if strings.Contains(resource.Name, "/") || (api.Kind != "" && resource.Kind != api.Kind) {
+				continue
+			}
+			if namespacedOnly && !resource.Namespaced || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			gvr := gv.WithResource(resource.Name)
+			if seen[gvr] {
+				continue
+			}
+			seen[gvr] = true
+			targets = append(targets, deprecatedAPIScanTarget{gvr: gvr, kind: resource.Kind})
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].gvr.String() < targets[j].gvr.String() })
+	return targets
+}
+
+// scanDeprecatedAPIObjects lists a resource and returns the objects written through one of apis
+func (c *Client) scanDeprecatedAPIObjects(ctx context.Context, target deprecatedAPIScanTarget, namespace string, apis []deprecatedAPI, served map[string]bool) ([]DeprecatedAPIObject, error) {
+	var found []DeprecatedAPIObject
+	opts := metav1.ListOptions{Limit: 500}
+	for {
+		list, err := c.dynamicClient.Resource(target.gvr).Namespace(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			found = append(found, deprecatedAPIObjects(&list.Items[i], target.kind, apis, served)...)
+		}
+		opts.Continue = list.GetContinue()
+		if opts.Continue == "" {
+			return found, nil
+		}
+	}
+}
+
+// deprecatedAPIObjects returns one finding per deprecated apiVersion that obj was written through
+func deprecatedAPIObjects(obj *unstructured.Unstructured, kind string, apis []deprecatedAPI, served map[string]bool) []DeprecatedAPIObject {
+	sources := map[string][]string{}
+	var order []string
+	record := func(apiVersion, source string) {
+		if _, ok := matchDeprecatedAPI(apis, apiVersion, kind); !ok {
+			return
+		}
+		if _, ok := sources[apiVersion]; !ok {
+			order = append(order, apiVersion)
+		}
+		if !slices.Contains(sources[apiVersion], source) {
+			sources[apiVersion] = append(sources[apiVersion], source)
+		}
+	}
+
+	if lastApplied := obj.GetAnnotations()[lastAppliedAnnotation]; lastApplied != "" {
+		var applied struct {
+			APIVersion string `json:"apiVersion"`
+		}
+		if json.Unmarshal([]byte(lastApplied), &applied) == nil && applied.APIVersion != "" {
+			record(applied.APIVersion, "last-applied-configuration")
+		}
+	}
+	for _, entry := range obj.GetManagedFields() {
+		record(entry.APIVersion, fmt.Sprintf("managedFields (manager %s, %s)", entry.Manager, entry.Operation))
+	}
+
+	objects := make([]DeprecatedAPIObject, 0, len(order))
+	for _, apiVersion := range order {
+		api, _ := matchDeprecatedAPI(apis, apiVersion, kind)
+		objects = append(objects, DeprecatedAPIObject{
+			Kind:            kind,
+			Name:            obj.GetName(),
+			Namespace:       obj.GetNamespace(),
+			APIVersion:      apiVersion,
+			Replacement:     api.Replacement,
+			RemovedIn:       api.RemovedIn,
+			ServedByCluster: served[apiVersion],
+			Sources:         sources[apiVersion],
+		})
+	}
+	return objects
+}
+
+// serverDeprecationPattern matches the removal release and replacement in an API server
+// deprecation warning such as "batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in
+// v1.25+; use batch/v1 CronJob"
+var serverDeprecationPattern = regexp.MustCompile(`unavailable in v(\d+\.\d+)\+(?:; use (\S+))?`)
+
+// deprecationWarnings collects the deprecation warnings of API server responses
+type deprecationWarnings struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (w *deprecationWarnings) HandleWarningHeaderWithContext(_ context.Context, code int, _ string, text string) {
+	if code != 299 || !strings.Contains(text, "deprecated") {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, text)
+}
+
+func (w *deprecationWarnings) take() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	messages := w.messages
+	w.messages = nil
+	return messages
+}
+
+// serverDeprecatedAPIs asks the API server which of the non-preferred versions it serves are
+// deprecated, by listing one object of each of their resources and reading the warnings returned
+func (c *Client) serverDeprecatedAPIs(ctx context.Context, groups *metav1.APIGroupList) []deprecatedAPI {
+	if c.restConfig == nil {
+		return nil
+	}
+	warnings := &deprecationWarnings{}
+	config := rest.CopyConfig(c.restConfig)
+	config.WarningHandler = nil
+	config.WarningHandlerWithContext = warnings
+	probe, err := dynamic.NewForConfig(config)
+	if err != nil {
+		logrus.WithError(err).Debug("Failed to create client to probe API deprecations")
+		return nil
+	}
+
+	var apis []deprecatedAPI
+	for _, group := range groups.Groups {
+		for _, version := range group.Versions {
+			if version.GroupVersion == group.PreferredVersion.GroupVersion {
+				continue
+			}
+			resources, err := c.discoveryClient.ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				continue
+			}
+			gv, err := schema.ParseGroupVersion(version.GroupVersion)
+			if err != nil {
+				continue
+			}
+			for _, resource := range resources.APIResources {
+				if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+					continue
+				}
+				_, _ = probe.Resource(gv.WithResource(resource.Name)).List(ctx, metav1.ListOptions{Limit: 1})
+				for _, message := range warnings.take() {
+					api := deprecatedAPI{APIVersion: version.GroupVersion, Kind: resource.Kind, Message: message}
+					if match := serverDeprecationPattern.FindStringSubmatch(message); match != nil {
+						api.RemovedIn, api.Replacement = match[1], match[2]
+					}
+					apis = append(apis, api)
+				}
+			}
+		}
+	}
+	return apis
+}
+
+// removedBy keeps the APIs removed in or before the target minor version, and those whose removal
+// release is unknown
+func removedBy(apis []deprecatedAPI, target [2]int) []deprecatedAPI {
+	var kept []deprecatedAPI
+	for _, api := range apis {
+		removed, err := parseMinorVersion(api.RemovedIn)
+		if err != nil || removed[0] < target[0] || removed[0] == target[0] && removed[1] <= target[1] {
+			kept = append(kept, api)
+		}
+	}
+	return kept
+}
+
+// parseMinorVersion parses the major and minor number of a version like "1.29", "v1.29" or "v1.29.3"
+func parseMinorVersion(version string) ([2]int, error) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return [2]int{}, fmt.Errorf("expected a version like 1.29")
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return [2]int{}, fmt.Errorf("expected a version like 1.29")
+	}
+	minor, err := strconv.Atoi(strings.TrimRight(parts[1], "+"))
+	if err != nil {
+		return [2]int{}, fmt.Errorf("expected a version like 1.29")
+	}
+	return [2]int{major, minor}, nil
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindDeprecatedAPIUsage(t *testing.T) {
+	object := func(apiVersion, kind, namespace, name string, mutate func(*unstructured.Unstructured)) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{}}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		mutate(obj)
+		return obj
+	}
+	listVerbs := metav1.Verbs{"get", "list"}
+
+	clientset := fake.NewClientset()
+	discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "networking.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "ingresses", Kind: "Ingress", Namespaced: true, Verbs: listVerbs},
+			{Name: "ingresses/status", Kind: "Ingress", Namespaced: true, Verbs: listVerbs},
+		}},
+		{GroupVersion: "autoscaling/v2", APIResources: []metav1.APIResource{
+			{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true, Verbs: listVerbs},
+		}},
+		{GroupVersion: "autoscaling/v2beta2", APIResources: []metav1.APIResource{
+			{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true, Verbs: listVerbs},
+		}},
+	}
+
+	ingresses := schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+	hpas := schema.GroupVersionResource{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}
+	c := &Client{
+		clientset:       clientset,
+		discoveryClient: discovery,
+		dynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{ingresses: "IngressList", hpas: "HorizontalPodAutoscalerList"},
+			object("networking.k8s.io/v1", "Ingress", "team-a", "old", func(obj *unstructured.Unstructured) {
+				obj.SetAnnotations(map[string]string{lastAppliedAnnotation: `{"apiVersion":"extensions/v1beta1","kind":"Ingress"}`})
+			}),
+			object("networking.k8s.io/v1", "Ingress", "team-a", "new", func(obj *unstructured.Unstructured) {
+				obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply, APIVersion: "networking.k8s.io/v1"}})
+			}),
+			object("autoscaling/v2", "HorizontalPodAutoscaler", "team-b", "web", func(obj *unstructured.Unstructured) {
+				obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "helm", Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "autoscaling/v2beta2"}})
+			}),
+		),
+	}
+	ctx := context.Background()
+
+	report, err := c.FindDeprecatedAPIUsage(ctx, "", "")
+	if err != nil {
+		t.Fatalf("FindDeprecatedAPIUsage() error = %v", err)
+	}
+	if len(report.ServedDeprecatedAPIs) != 1 || report.ServedDeprecatedAPIs[0].APIVersion != "autoscaling/v2beta2" || report.ServedDeprecatedAPIs[0].Replacement != "autoscaling/v2" {
+		t.Fatalf("expected autoscaling/v2beta2 to be reported as served, got %+v", report.ServedDeprecatedAPIs)
+	}
+	if len(report.ScannedResources) != 2 || len(report.Errors) != 0 {
+		t.Fatalf("expected the ingress and HPA resources to be scanned, got %+v %v", report.ScannedResources, report.Errors)
+	}
+	if len(report.Objects) != 2 {
+		t.Fatalf("expected two objects written through deprecated APIs, got %+v", report.Objects)
+	}
+	hpa, ingress := report.Objects[0], report.Objects[1]
+	if hpa.Name != "web" || hpa.APIVersion != "autoscaling/v2beta2" || !hpa.ServedByCluster || hpa.RemovedIn != "1.26" || hpa.Sources[0] != "managedFields (manager helm, Update)" {
+		t.Fatalf("unexpected HPA finding: %+v", hpa)
+	}
+	if ingress.Name != "old" || ingress.APIVersion != "extensions/v1beta1" || ingress.ServedByCluster || ingress.Replacement != "networking.k8s.io/v1" || ingress.Sources[0] != "last-applied-configuration" {
+		t.Fatalf("unexpected ingress finding: %+v", ingress)
+	}
+
+	report, err = c.FindDeprecatedAPIUsage(ctx, "team-a", "1.25")
+	if err != nil {
+		t.Fatalf("FindDeprecatedAPIUsage() error = %v", err)
+	}
+	if len(report.ServedDeprecatedAPIs) != 0 || len(report.Objects) != 1 || report.Objects[0].Name != "old" {
+		t.Fatalf("expected only the ingress removed by 1.25 in team-a, got %+v", report)
+	}
+
+	if _, err := c.FindDeprecatedAPIUsage(ctx, "", "next"); err == nil {
+		t.Fatal("expected an invalid target version to fail")
+	}
+}
+
+func TestServerDeprecationPattern(t *testing.T) {
+	match := serverDeprecationPattern.FindStringSubmatch("batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use batch/v1 CronJob")
+	if match == nil || match[1] != "1.25" || match[2] != "batch/v1" {
+		t.Fatalf("unexpected match: %v", match)
+	}
+}
@@ -0,0 +1,350 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// describeEventLimit bounds the events attached to a structured description, newest kept
+const describeEventLimit = 20
+
+// ResourceDescription is the structured form of `kubectl describe`: the parsed sections of a
+// resource instead of the full object, so callers can read a field without scanning prose.
+type ResourceDescription struct {
+	Kind            string               `json:"kind"`
+	APIVersion      string               `json:"apiVersion"`
+	Metadata        DescribedMetadata    `json:"metadata"`
+	SpecHighlights  map[string]any       `json:"specHighlights"`
+	Status          map[string]any       `json:"status,omitempty"`
+	Conditions      []DescribedCondition `json:"conditions"`
+	LatestCondition *DescribedCondition  `json:"latestCondition,omitempty"`
+	Events          []DescribedEvent     `json:"events"`
+	EventsError     string               `json:"eventsError,omitempty"`
+	Containers      []DescribedContainer `json:"containers,omitempty"`
+	Owners          []DescribedOwnerRef  `json:"owners,omitempty"`
+}
+
+// DescribedMetadata is the identifying metadata of a described resource
+type DescribedMetadata struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace,omitempty"`
+	UID               string            `json:"uid,omitempty"`
+	ResourceVersion   string            `json:"resourceVersion,omitempty"`
+	Generation        int64             `json:"generation,omitempty"`
+	CreationTimestamp string            `json:"creationTimestamp,omitempty"`
+	Age               string            `json:"age"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Annotations       map[string]string `json:"annotations,omitempty"`
+}
+
+// DescribedOwnerRef is an owner reference of a described resource
+type DescribedOwnerRef struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller bool   `json:"controller,omitempty"`
+}
+
+// DescribedContainer summarizes a container of a pod or pod template
+type DescribedContainer struct {
+	Name      string         `json:"name"`
+	Image     string         `json:"image,omitempty"`
+	Init      bool           `json:"init,omitempty"`
+	Ports     []any          `json:"ports,omitempty"`
+	Resources map[string]any `json:"resources,omitempty"`
+}
+
+// DescribedCondition is one entry of status.conditions
+type DescribedCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// DescribedEvent is an event recorded against a described resource
+type DescribedEvent struct {
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	Count     int32  `json:"count"`
+	FirstSeen string `json:"firstSeen,omitempty"`
+	LastSeen  string `json:"lastSeen"`
+	Source    string `json:"source,omitempty"`
+}
+
+// DescribeResourceStructured returns the parsed sections of a resource: metadata, spec highlights,
+// status fields, conditions ordered by transition time and the most recent events.
+func (c *Client) DescribeResourceStructured(ctx context.Context, kind, apiVersion, name, namespace string) (*ResourceDescription, error) {
+	logrus.WithFields(logrus.Fields{"kind": kind, "apiVersion": apiVersion, "name": name, "namespace": namespace}).Debug("DescribeResourceStructured called")
+
+	obj, err := c.GetResourceForAPIVersion(ctx, kind, apiVersion, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	description := buildResourceDescription(&unstructured.Unstructured{Object: obj})
+	events, err := c.describeEvents(ctx, description.Kind, description.Metadata.Name, description.Metadata.Namespace)
+	if err != nil {
+		// Events are supplementary; the description is still useful without them
+		logrus.WithError(err).Warn("Failed to list events for structured description")
+		description.EventsError = err.Error()
+	} else {
+		description.Events = events
+	}
+
+	logrus.WithFields(logrus.Fields{"conditions": len(description.Conditions), "events": len(description.Events)}).Debug("DescribeResourceStructured succeeded")
+	return description, nil
+}
+
+// buildResourceDescription extracts the describe sections from a live object
+func buildResourceDescription(obj *unstructured.Unstructured) *ResourceDescription {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[lastAppliedAnnotation]; ok {
+		// The applied configuration repeats the spec; kubernetes_get_resource_yaml_history reports it
+		trimmed := make(map[string]string, len(annotations)-1)
+		for key, value := range annotations {
+			if key != lastAppliedAnnotation {
+				trimmed[key] = value
+			}
+		}
+		annotations = trimmed
+	}
+
+	description := &ResourceDescription{
+		Kind:       obj.GetKind(),
+		APIVersion: obj.GetAPIVersion(),
+		Metadata: DescribedMetadata{
+			Name:            obj.GetName(),
+			Namespace:       obj.GetNamespace(),
+			UID:             string(obj.GetUID()),
+			ResourceVersion: obj.GetResourceVersion(),
+			Generation:      obj.GetGeneration(),
+			Age:             calculateAge(obj.GetCreationTimestamp()),
+			Labels:          obj.GetLabels(),
+			Annotations:     annotations,
+		},
+		SpecHighlights: map[string]any{},
+		Conditions:     []DescribedCondition{},
+		Events:         []DescribedEvent{},
+	}
+	if created := obj.GetCreationTimestamp(); !created.IsZero() {
+		description.Metadata.CreationTimestamp = created.UTC().Format(time.RFC3339)
+	}
+	for _, owner := range obj.GetOwnerReferences() {
+		description.Owners = append(description.Owners, DescribedOwnerRef{
+			Kind:       owner.Kind,
+			Name:       owner.Name,
+			Controller: owner.Controller != nil && *owner.Controller,
+		})
+	}
+
+	if spec, ok := obj.Object["spec"].(map[string]any); ok {
+		description.SpecHighlights = specHighlights(spec)
+		description.Containers = describedContainers(spec)
+	}
+	if status, ok := obj.Object["status"].(map[string]any); ok {
+		description.Status = scalarFields(status)
+		description.Conditions = describedConditions(status)
+		if n := len(description.Conditions); n > 0 {
+			latest := description.Conditions[n-1]
+			description.LatestCondition = &latest
+		}
+	}
+	return description
+}
+
+// specHighlights keeps the scalar settings of a spec plus the few nested fields describe shows
+func specHighlights(spec map[string]any) map[string]any {
+	highlights := scalarFields(spec)
+	if selector, ok := spec["selector"].(map[string]any); ok {
+		// Workloads nest labels under matchLabels; Services use a plain label map
+		if matchLabels, ok := selector["matchLabels"]; ok {
+			highlights["selector"] = matchLabels
+		} else {
+			highlights["selector"] = selector
+		}
+	}
+	if ports, ok := spec["ports"].([]any); ok {
+		highlights["ports"] = ports
+	}
+	for _, field := range []string{"strategy", "updateStrategy"} {
+		if strategyType, ok := nestedScalar(spec, field, "type"); ok {
+			highlights[field] = strategyType
+		}
+	}
+	if storage, ok := nestedScalar(spec, "resources", "requests", "storage"); ok {
+		highlights["storage"] = storage
+	}
+	return highlights
+}
+
+// scalarFields returns the top-level fields of m holding a scalar or a list of scalars
+func scalarFields(m map[string]any) map[string]any {
+	out := map[string]any{}
+	for key, value := range m {
+		switch typed := value.(type) {
+		case string, bool, int64, float64:
+			out[key] = typed
+		case []any:
+			if isScalarList(typed) {
+				out[key] = typed
+			}
+		}
+	}
+	return out
+}
+
+func isScalarList(values []any) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, value := range values {
+		switch value.(type) {
+		case string, bool, int64, float64:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// nestedScalar reads a scalar at a nested path, reporting whether one was present
+func nestedScalar(m map[string]any, path ...string) (any, bool) {
+	value, found, err := unstructured.NestedFieldNoCopy(m, path...)
+	if err != nil || !found {
+		return nil, false
+	}
+	switch value.(type) {
+	case string, bool, int64, float64:
+		return value, true
+	}
+	return nil, false
+}
+
+// describedContainers summarizes the containers of a pod spec, a pod template or a job template
+func describedContainers(spec map[string]any) []DescribedContainer {
+	podSpec := podSpecOf(spec)
+
+	var containers []DescribedContainer
+	for _, field := range []string{"initContainers", "containers"} {
+		list, _ := podSpec[field].([]any)
+		for _, item := range list {
+			container, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			described := DescribedContainer{
+				Name:  getStringField(container, "name"),
+				Image: getStringField(container, "image"),
+				Init:  field == "initContainers",
+			}
+			described.Ports, _ = container["ports"].([]any)
+			described.Resources, _ = container["resources"].(map[string]any)
+			containers = append(containers, described)
+		}
+	}
+	return containers
+}
+
+// podSpecOf returns the pod spec of a Pod spec, a workload pod template or a CronJob job template
+func podSpecOf(spec map[string]any) map[string]any {
+	for _, path := range [][]string{{"template", "spec"}, {"jobTemplate", "spec", "template", "spec"}} {
+		if nested, found, _ := unstructured.NestedMap(spec, path...); found {
+			return nested
+		}
+	}
+	return spec
+}
+
+// describedConditions reads status.conditions ordered from the oldest to the newest transition
+func describedConditions(status map[string]any) []DescribedCondition {
+	list, _ := status["conditions"].([]any)
+	conditions := make([]DescribedCondition, 0, len(list))
+	for _, item := range list {
+		condition, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		transition := getStringField(condition, "lastTransitionTime")
+		if transition == "" {
+			// Deployment conditions may only carry lastUpdateTime
+			transition = getStringField(condition, "lastUpdateTime")
+		}
+		conditions = append(conditions, DescribedCondition{
+			Type:               getStringField(condition, "type"),
+			Status:             getStringField(condition, "status"),
+			Reason:             getStringField(condition, "reason"),
+			Message:            getStringField(condition, "message"),
+			LastTransitionTime: transition,
+		})
+	}
+	// RFC 3339 timestamps in UTC sort lexically; conditions without one keep their position first
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditions[i].LastTransitionTime < conditions[j].LastTransitionTime
+	})
+	return conditions
+}
+
+// describeEvents returns the most recent events of an object, oldest first as kubectl prints them
+func (c *Client) describeEvents(ctx context.Context, kind, name, namespace string) ([]DescribedEvent, error) {
+	selector := fields.Set{"involvedObject.name": name, "involvedObject.kind": kind}.AsSelector().String()
+	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	type seenEvent struct {
+		event    *corev1.Event
+		lastSeen time.Time
+	}
+	var matched []seenEvent
+	for i := range list.Items {
+		evt := &list.Items[i]
+		// Keep the result exact even where the field selector is not honoured
+		if evt.InvolvedObject.Name != name || evt.InvolvedObject.Kind != kind {
+			continue
+		}
+		matched = append(matched, seenEvent{event: evt, lastSeen: eventLastSeen(evt)})
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].lastSeen.Before(matched[j].lastSeen) })
+	if len(matched) > describeEventLimit {
+		matched = matched[len(matched)-describeEventLimit:]
+	}
+
+	events := make([]DescribedEvent, 0, len(matched))
+	for _, m := range matched {
+		evt := m.event
+		count := evt.Count
+		if evt.Series != nil && evt.Series.Count > count {
+			count = evt.Series.Count
+		}
+		if count == 0 {
+			count = 1
+		}
+		described := DescribedEvent{
+			Type:     evt.Type,
+			Reason:   evt.Reason,
+			Message:  evt.Message,
+			Count:    count,
+			LastSeen: m.lastSeen.UTC().Format(time.RFC3339),
+			Source:   evt.Source.Component,
+		}
+		if first := eventFirstSeen(evt, m.lastSeen); !first.IsZero() {
+			described.FirstSeen = first.UTC().Format(time.RFC3339)
+		}
+		if described.Source == "" {
+			described.Source = evt.ReportingController
+		}
+		events = append(events, described)
+	}
+	return events, nil
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestBuildResourceDescription(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":      "web",
+			"namespace": "default",
+			"annotations": map[string]any{
+				lastAppliedAnnotation: `{"spec":{}}`,
+				"team":                "payments",
+			},
+		},
+		"spec": map[string]any{
+			"replicas": int64(3),
+			"selector": map[string]any{"matchLabels": map[string]any{"app": "web"}},
+			"strategy": map[string]any{"type": "RollingUpdate"},
+			"template": map[string]any{"spec": map[string]any{"containers": []any{
+				map[string]any{"name": "app", "image": "web:1.2"},
+			}}},
+		},
+		"status": map[string]any{
+			"readyReplicas": int64(2),
+			"conditions": []any{
+				map[string]any{"type": "Progressing", "status": "True", "lastTransitionTime": "2026-01-02T00:00:00Z"},
+				map[string]any{"type": "Available", "status": "False", "reason": "MinimumReplicasUnavailable", "lastTransitionTime": "2026-01-03T00:00:00Z"},
+				map[string]any{"type": "ReplicaFailure", "status": "False", "lastUpdateTime": "2026-01-01T00:00:00Z"},
+			},
+		},
+	}}
+
+	d := buildResourceDescription(obj)
+	if d.Kind != "Deployment" || d.Metadata.Name != "web" || d.Metadata.Annotations["team"] != "payments" {
+		t.Fatalf("unexpected metadata: %+v", d.Metadata)
+	}
+	if _, ok := d.Metadata.Annotations[lastAppliedAnnotation]; ok {
+		t.Fatal("expected the last-applied annotation to be dropped")
+	}
+	if d.SpecHighlights["replicas"] != int64(3) || d.SpecHighlights["strategy"] != "RollingUpdate" || d.SpecHighlights["selector"] == nil {
+		t.Fatalf("unexpected spec highlights: %+v", d.SpecHighlights)
+	}
+	if _, ok := d.SpecHighlights["template"]; ok {
+		t.Fatal("expected the pod template to be summarized as containers, not copied")
+	}
+	if len(d.Containers) != 1 || d.Containers[0].Image != "web:1.2" {
+		t.Fatalf("unexpected containers: %+v", d.Containers)
+	}
+	if d.Status["readyReplicas"] != int64(2) {
+		t.Fatalf("unexpected status: %+v", d.Status)
+	}
+	if len(d.Conditions) != 3 || d.Conditions[0].Type != "ReplicaFailure" {
+		t.Fatalf("expected conditions ordered by transition time, got %+v", d.Conditions)
+	}
+	if d.LatestCondition == nil || d.LatestCondition.Type != "Available" || d.LatestCondition.Reason != "MinimumReplicasUnavailable" {
+		t.Fatalf("unexpected latest condition: %+v", d.LatestCondition)
+	}
+}
+
+func TestDescribeEvents(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := func(name, object string, at time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: object, Namespace: "default"},
+			Reason:         name,
+			Type:           corev1.EventTypeWarning,
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+	c := &Client{clientset: fake.NewClientset(
+		event("BackOff", "web-0", base.Add(2*time.Minute)),
+		event("Pulled", "web-0", base),
+		event("Other", "web-1", base.Add(time.Minute)),
+	)}
+
+	events, err := c.describeEvents(context.Background(), "Pod", "web-0", "default")
+	if err != nil {
+		t.Fatalf("describeEvents() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Reason != "Pulled" || events[1].Reason != "BackOff" || events[1].Count != 1 {
+		t.Fatalf("expected the object's events oldest first, got %+v", events)
+	}
+}
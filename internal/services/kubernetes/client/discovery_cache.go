@@ -1,6 +1,9 @@
 package client
 
 import (
+	"context"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,37 +14,117 @@ import (
 	"k8s.io/client-go/discovery"
 )
 
+// DefaultDiscoveryCacheTTL is how long discovered API groups and resources are reused before the
+// API server is asked again
+const DefaultDiscoveryCacheTTL = 10 * time.Minute
+
+// discoveryMissRefreshInterval is how often a lookup the cache cannot answer, such as a misspelled
+// kind, may trigger a rediscovery of an API server. kubernetes_refresh_discovery is not limited.
+const discoveryMissRefreshInterval = 30 * time.Second
+
+var (
+	discoveryCacheTTLMu sync.RWMutex
+	discoveryCacheTTL   = DefaultDiscoveryCacheTTL
+
+	// discoveryCaches shares discovery results per API server. Clients are built per request, so a
+	// cache owned by one client would be empty for every tool call.
+	discoveryCachesMu sync.Mutex
+	discoveryCaches   = map[string]*discoveryCacheState{}
+)
+
+// SetDiscoveryCacheTTL sets how long discovery results are cached. Zero keeps the current TTL.
+func SetDiscoveryCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	discoveryCacheTTLMu.Lock()
+	defer discoveryCacheTTLMu.Unlock()
+	discoveryCacheTTL = ttl
+}
+
+func currentDiscoveryCacheTTL() time.Duration {
+	discoveryCacheTTLMu.RLock()
+	defer discoveryCacheTTLMu.RUnlock()
+	return discoveryCacheTTL
+}
+
+// discoveryCacheState holds the cached discovery results of one API server
+type discoveryCacheState struct {
+	cacheMutex       sync.RWMutex
+	apiResources     map[string]*metav1.APIResourceList // cached API resources by group version
+	serverGroups     *metav1.APIGroupList               // cached server groups
+	resourcesFetched time.Time                          // when apiResources were fetched
+	groupsFetched    time.Time                          // when serverGroups were fetched
+	missRefreshed    time.Time                          // when a lookup miss last triggered rediscovery
+}
+
+func newDiscoveryCacheState() *discoveryCacheState {
+	return &discoveryCacheState{apiResources: make(map[string]*metav1.APIResourceList)}
+}
+
+// sharedDiscoveryCacheState returns the discovery cache of an API server
+func sharedDiscoveryCacheState(host string) *discoveryCacheState {
+	discoveryCachesMu.Lock()
+	defer discoveryCachesMu.Unlock()
+	if state, ok := discoveryCaches[host]; ok {
+		return state
+	}
+	state := newDiscoveryCacheState()
+	discoveryCaches[host] = state
+	return state
+}
+
 // CacheableDiscovery wraps the standard discovery client with caching capabilities
 type CacheableDiscovery struct {
 	discoveryClient discovery.DiscoveryInterface
-	cacheMutex      sync.RWMutex
-	apiResources    map[string]*metav1.APIResourceList // cached API resources
-	serverGroups    *metav1.APIGroupList               // cached server groups
-	cacheExpiry     time.Time                          // when the cache expires
-	cacheTTL        time.Duration                      // cache time-to-live
+	*discoveryCacheState
+	cacheTTL time.Duration // cache time-to-live
 }
 
-// NewCacheableDiscovery creates a new CacheableDiscovery instance
+// NewCacheableDiscovery creates a new CacheableDiscovery instance with its own cache
 func NewCacheableDiscovery(client discovery.DiscoveryInterface, ttl time.Duration) *CacheableDiscovery {
 	return &CacheableDiscovery{
-		discoveryClient: client,
-		apiResources:    make(map[string]*metav1.APIResourceList),
-		cacheTTL:        ttl,
+		discoveryClient:     client,
+		discoveryCacheState: newDiscoveryCacheState(),
+		cacheTTL:            ttl,
+	}
+}
+
+// newSharedCacheableDiscovery creates a CacheableDiscovery that shares its cache with every other
+// client of the same API server. Requests are still made with the given client's credentials.
+func newSharedCacheableDiscovery(host string, client discovery.DiscoveryInterface, ttl time.Duration) *CacheableDiscovery {
+	return &CacheableDiscovery{
+		discoveryClient:     client,
+		discoveryCacheState: sharedDiscoveryCacheState(host),
+		cacheTTL:            ttl,
+	}
+}
+
+func (c *CacheableDiscovery) resourcesValid() bool {
+	return len(c.apiResources) > 0 && time.Since(c.resourcesFetched) < c.cacheTTL
+}
+
+func (c *CacheableDiscovery) groupsValid() bool {
+	return c.serverGroups != nil && time.Since(c.groupsFetched) < c.cacheTTL
+}
+
+func (c *CacheableDiscovery) cachedResources() []*metav1.APIResourceList {
+	resources := make([]*metav1.APIResourceList, 0, len(c.apiResources))
+	for _, resource := range c.apiResources {
+		resources = append(resources, resource)
 	}
+	return resources
 }
 
-// GetAPIResources returns cached API resources or fetches them if cache is expired
+// GetAPIResources returns cached API resources or fetches them if cache is expired. When some
+// groups fail discovery, the resources of the others are cached and returned with the error.
 func (c *CacheableDiscovery) GetAPIResources() ([]*metav1.APIResourceList, error) {
 	c.cacheMutex.RLock()
-	// Check if cache is valid
-	if time.Now().Before(c.cacheExpiry) && len(c.apiResources) > 0 {
-		// Convert map to slice
-		resources := make([]*metav1.APIResourceList, 0, len(c.apiResources))
-		for _, resource := range c.apiResources {
-			resources = append(resources, resource)
-		}
+	if c.resourcesValid() {
+		resources := c.cachedResources()
+		age := time.Since(c.resourcesFetched)
 		c.cacheMutex.RUnlock()
-		logrus.Debug("Returning cached API resources")
+		logrus.WithField("age", age.Round(time.Second).String()).Debug("Returning cached API resources")
 		return resources, nil
 	}
 	c.cacheMutex.RUnlock()
@@ -51,54 +134,36 @@ func (c *CacheableDiscovery) GetAPIResources() ([]*metav1.APIResourceList, error
 	defer c.cacheMutex.Unlock()
 
 	// Double-check after acquiring write lock
-	if time.Now().Before(c.cacheExpiry) && len(c.apiResources) > 0 {
-		// Convert map to slice
-		resources := make([]*metav1.APIResourceList, 0, len(c.apiResources))
-		for _, resource := range c.apiResources {
-			resources = append(resources, resource)
-		}
-		logrus.Debug("Returning cached API resources (double-checked)")
-		return resources, nil
+	if c.resourcesValid() {
+		logrus.WithField("age", time.Since(c.resourcesFetched).Round(time.Second).String()).Debug("Returning cached API resources (double-checked)")
+		return c.cachedResources(), nil
 	}
 
-	// Fetch fresh data
 	logrus.Debug("Fetching fresh API resources from server")
 	resourceLists, err := c.discoveryClient.ServerPreferredResources()
-	if err != nil {
+	if err != nil && (len(resourceLists) == 0 || !discovery.IsGroupDiscoveryFailedError(err)) {
 		// If we have cached data, return it even if expired
 		if len(c.apiResources) > 0 {
 			logrus.WithError(err).Warn("Failed to fetch API resources, returning cached data")
-			resources := make([]*metav1.APIResourceList, 0, len(c.apiResources))
-			for _, resource := range c.apiResources {
-				resources = append(resources, resource)
-			}
-			return resources, nil
+			return c.cachedResources(), nil
 		}
 		return nil, err
 	}
 
-	// Update cache
-	c.apiResources = make(map[string]*metav1.APIResourceList)
-	for _, rl := range resourceLists {
-		c.apiResources[rl.GroupVersion] = rl
-	}
-	c.cacheExpiry = time.Now().Add(c.cacheTTL)
-
-	// Return fresh data
+	c.storeResources(resourceLists)
 	resources := make([]*metav1.APIResourceList, len(resourceLists))
 	copy(resources, resourceLists)
-
-	return resources, nil
+	return resources, err
 }
 
 // GetServerGroups returns cached server groups or fetches them if cache is expired
 func (c *CacheableDiscovery) GetServerGroups() (*metav1.APIGroupList, error) {
 	c.cacheMutex.RLock()
-	// Check if cache is valid
-	if time.Now().Before(c.cacheExpiry) && c.serverGroups != nil {
+	if c.groupsValid() {
 		groups := c.serverGroups
+		age := time.Since(c.groupsFetched)
 		c.cacheMutex.RUnlock()
-		logrus.Debug("Returning cached server groups")
+		logrus.WithField("age", age.Round(time.Second).String()).Debug("Returning cached server groups")
 		return groups, nil
 	}
 	c.cacheMutex.RUnlock()
@@ -108,12 +173,11 @@ func (c *CacheableDiscovery) GetServerGroups() (*metav1.APIGroupList, error) {
 	defer c.cacheMutex.Unlock()
 
 	// Double-check after acquiring write lock
-	if time.Now().Before(c.cacheExpiry) && c.serverGroups != nil {
-		logrus.Debug("Returning cached server groups (double-checked)")
+	if c.groupsValid() {
+		logrus.WithField("age", time.Since(c.groupsFetched).Round(time.Second).String()).Debug("Returning cached server groups (double-checked)")
 		return c.serverGroups, nil
 	}
 
-	// Fetch fresh data
 	logrus.Debug("Fetching fresh server groups from server")
 	groups, err := c.discoveryClient.ServerGroups()
 	if err != nil {
@@ -125,18 +189,26 @@ func (c *CacheableDiscovery) GetServerGroups() (*metav1.APIGroupList, error) {
 		return nil, err
 	}
 
-	// Update cache
 	c.serverGroups = groups
-	c.cacheExpiry = time.Now().Add(c.cacheTTL)
-
+	c.groupsFetched = time.Now()
 	return groups, nil
 }
 
+func (c *CacheableDiscovery) storeResources(resourceLists []*metav1.APIResourceList) {
+	c.apiResources = make(map[string]*metav1.APIResourceList, len(resourceLists))
+	for _, rl := range resourceLists {
+		if rl != nil {
+			c.apiResources[rl.GroupVersion] = rl
+		}
+	}
+	c.resourcesFetched = time.Now()
+}
+
 // FindGVR finds the GroupVersionResource for a given kind, with caching
 func (c *CacheableDiscovery) FindGVR(kind, version string) (schema.GroupVersionResource, error) {
 	// Get API resources (cached)
 	resourceLists, err := c.GetAPIResources()
-	if err != nil {
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
 		return schema.GroupVersionResource{}, err
 	}
 
@@ -153,7 +225,6 @@ func (c *CacheableDiscovery) FindGVR(kind, version string) (schema.GroupVersionR
 		}
 
 		for _, resource := range resourceList.APIResources {
-			// Check if kind matches (case-insensitive)
 			if resource.Kind == kind {
 				return schema.GroupVersionResource{
 					Group:    gv.Group,
@@ -167,35 +238,43 @@ func (c *CacheableDiscovery) FindGVR(kind, version string) (schema.GroupVersionR
 	return schema.GroupVersionResource{}, errors.NewNotFound(schema.GroupResource{}, kind)
 }
 
-// Refresh forces a cache refresh
+// Refresh fetches API resources and server groups again. The previous results stay cached
+// when the API server cannot be reached.
 func (c *CacheableDiscovery) Refresh() error {
-	c.cacheMutex.Lock()
-	defer c.cacheMutex.Unlock()
-
-	// Clear cache
-	c.apiResources = make(map[string]*metav1.APIResourceList)
-	c.serverGroups = nil
-	c.cacheExpiry = time.Time{}
-
-	// Fetch fresh data
 	resourceLists, err := c.discoveryClient.ServerPreferredResources()
-	if err != nil {
+	if err != nil && (len(resourceLists) == 0 || !discovery.IsGroupDiscoveryFailedError(err)) {
 		return err
 	}
-
-	groups, err := c.discoveryClient.ServerGroups()
-	if err != nil {
-		return err
+	groups, groupsErr := c.discoveryClient.ServerGroups()
+	if groupsErr != nil {
+		return groupsErr
 	}
 
-	// Update cache
-	for _, rl := range resourceLists {
-		c.apiResources[rl.GroupVersion] = rl
-	}
+	c.cacheMutex.Lock()
+	defer c.cacheMutex.Unlock()
+	c.storeResources(resourceLists)
 	c.serverGroups = groups
-	c.cacheExpiry = time.Now().Add(c.cacheTTL)
+	c.groupsFetched = c.resourcesFetched
+	return err
+}
 
-	return nil
+// RefreshOnMiss discovers the API resources again for a lookup the cached ones could not answer.
+// Misses rediscover at most once per discoveryMissRefreshInterval per API server; in between, and
+// when the API server cannot be reached, the cached resources are returned.
+func (c *CacheableDiscovery) RefreshOnMiss() ([]*metav1.APIResourceList, error) {
+	c.cacheMutex.Lock()
+	if since := time.Since(c.missRefreshed); since < discoveryMissRefreshInterval && len(c.apiResources) > 0 {
+		resources := c.cachedResources()
+		c.cacheMutex.Unlock()
+		logrus.WithField("since", since.Round(time.Second).String()).Debug("Discovery was refreshed for a miss recently, returning cached API resources")
+		return resources, nil
+	}
+	c.missRefreshed = time.Now()
+	// Mark the cache stale rather than dropping it, so it is still served if the fetch fails
+	c.resourcesFetched = time.Time{}
+	c.groupsFetched = time.Time{}
+	c.cacheMutex.Unlock()
+	return c.GetAPIResources()
 }
 
 // Invalidate clears the cache
@@ -205,5 +284,94 @@ func (c *CacheableDiscovery) Invalidate() {
 
 	c.apiResources = make(map[string]*metav1.APIResourceList)
 	c.serverGroups = nil
+	c.resourcesFetched = time.Time{}
+	c.groupsFetched = time.Time{}
+}
+
+// Age returns how long ago the cached API resources were fetched, or zero when nothing is cached
+func (c *CacheableDiscovery) Age() time.Duration {
+	c.cacheMutex.RLock()
+	defer c.cacheMutex.RUnlock()
+	if len(c.apiResources) == 0 {
+		return 0
+	}
+	return time.Since(c.resourcesFetched)
+}
+
+// DiscoveryCacheAge returns the age of the cached API resources of the client's API server
+func (c *Client) DiscoveryCacheAge() time.Duration {
+	if c.cacheDiscovery == nil {
+		return 0
+	}
+	return c.cacheDiscovery.Age()
+}
+
+// DiscoveryRefreshResult reports a forced refresh of the discovery cache
+type DiscoveryRefreshResult struct {
+	Server           string   `json:"server,omitempty"`
+	APIGroups        int      `json:"apiGroups"`
+	GroupVersions    int      `json:"groupVersions"`
+	Resources        int      `json:"resources"`
+	PreviousCacheAge string   `json:"previousCacheAge,omitempty"`
+	CacheTTL         string   `json:"cacheTTL"`
+	FailedGroups     []string `json:"failedGroups,omitempty"`
+}
+
+// RefreshDiscovery drops the cached API groups, resources and kind mappings and discovers them
+// again, so resources of a newly installed CRD can be used right away. Groups that fail
+// discovery are listed in the result instead of failing the refresh.
+func (c *Client) RefreshDiscovery(ctx context.Context) (*DiscoveryRefreshResult, error) {
+	logrus.Debug("RefreshDiscovery called")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &DiscoveryRefreshResult{CacheTTL: c.cacheDiscovery.cacheTTL.String()}
+	if c.restConfig != nil {
+		result.Server = c.restConfig.Host
+	}
+	if age := c.cacheDiscovery.Age(); age > 0 {
+		result.PreviousCacheAge = age.Round(time.Second).String()
+	}
+
+	err := c.cacheDiscovery.Refresh()
+	if failed, ok := err.(*discovery.ErrGroupDiscoveryFailed); ok {
+		for gv := range failed.Groups {
+			result.FailedGroups = append(result.FailedGroups, gv.String())
+		}
+		sort.Strings(result.FailedGroups)
+	} else if err != nil {
+		return nil, err
+	}
+
+	c.gvrCacheMux.Lock()
+	c.gvrCache = make(map[string]schema.GroupVersionResource, len(c.gvrCache))
 	c.cacheExpiry = time.Time{}
+	c.gvrCacheMux.Unlock()
+
+	c.cacheDiscovery.cacheMutex.RLock()
+	defer c.cacheDiscovery.cacheMutex.RUnlock()
+	if c.cacheDiscovery.serverGroups != nil {
+		result.APIGroups = len(c.cacheDiscovery.serverGroups.Groups)
+	}
+	result.GroupVersions = len(c.cacheDiscovery.apiResources)
+	for _, resourceList := range c.cacheDiscovery.apiResources {
+		for _, resource := range resourceList.APIResources {
+			if !strings.Contains(resource.Name, "/") {
+				result.Resources++
+			}
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{"server": result.Server, "groupVersions": result.GroupVersions}).Info("Refreshed Kubernetes discovery cache")
+	return result, nil
+}
+
+// serverGroups returns the API groups from the discovery cache, or straight from the API server
+// for clients built without one
+func (c *Client) serverGroups() (*metav1.APIGroupList, error) {
+	if c.cacheDiscovery != nil {
+		return c.cacheDiscovery.GetServerGroups()
+	}
+	return c.discoveryClient.ServerGroups()
 }
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// countingDiscovery serves the fake's resources as preferred resources, except the group versions
+// in notPreferred, and counts the fetches
+type countingDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	notPreferred   map[string]bool
+	preferredCalls int
+}
+
+func (d *countingDiscovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	d.preferredCalls++
+	var preferred []*metav1.APIResourceList
+	for _, resourceList := range d.Resources {
+		if !d.notPreferred[resourceList.GroupVersion] {
+			preferred = append(preferred, resourceList)
+		}
+	}
+	return preferred, nil
+}
+
+func newCountingDiscovery() *countingDiscovery {
+	discovery := fake.NewClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true},
+			{Name: "pods/log", Kind: "Pod", Namespaced: true},
+		}},
+	}
+	return &countingDiscovery{FakeDiscovery: discovery}
+}
+
+func TestSharedDiscoveryCacheIsReusedAcrossClients(t *testing.T) {
+	discovery := newCountingDiscovery()
+	first := newSharedCacheableDiscovery("https://shared.test:6443", discovery, time.Minute)
+	second := newSharedCacheableDiscovery("https://shared.test:6443", discovery, time.Minute)
+	other := newSharedCacheableDiscovery("https://other.test:6443", discovery, time.Minute)
+
+	for _, cache := range []*CacheableDiscovery{first, second} {
+		if _, err := cache.GetAPIResources(); err != nil {
+			t.Fatalf("GetAPIResources() error = %v", err)
+		}
+	}
+	if discovery.preferredCalls != 1 {
+		t.Fatalf("expected clients of one API server to share discovery results, got %d fetches", discovery.preferredCalls)
+	}
+	if second.Age() <= 0 {
+		t.Fatal("expected the shared cache to report its age")
+	}
+
+	if _, err := other.GetAPIResources(); err != nil {
+		t.Fatalf("GetAPIResources() error = %v", err)
+	}
+	if discovery.preferredCalls != 2 {
+		t.Fatalf("expected each API server to be discovered separately, got %d fetches", discovery.preferredCalls)
+	}
+}
+
+func TestDiscoverAndCacheGVRRediscoversUnknownKinds(t *testing.T) {
+	discovery := newCountingDiscovery()
+	c := &Client{
+		discoveryClient: discovery,
+		cacheDiscovery:  NewCacheableDiscovery(discovery, time.Minute),
+		gvrCache:        map[string]schema.GroupVersionResource{},
+		cacheTTL:        time.Minute,
+	}
+
+	if _, err := c.discoverAndCacheGVR("Pod"); err != nil {
+		t.Fatalf("discoverAndCacheGVR(Pod) error = %v", err)
+	}
+	if _, err := c.discoverAndCacheGVR("Pod"); err != nil {
+		t.Fatalf("discoverAndCacheGVR(Pod) error = %v", err)
+	}
+	if discovery.preferredCalls != 1 {
+		t.Fatalf("expected known kinds to resolve from the cache, got %d fetches", discovery.preferredCalls)
+	}
+
+	// A CRD installed after the cache was filled
+	discovery.Resources = append(discovery.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Kind: "Widget", Namespaced: true}},
+	})
+	gvr, err := c.discoverAndCacheGVR("Widget")
+	if err != nil {
+		t.Fatalf("discoverAndCacheGVR(Widget) error = %v", err)
+	}
+	if gvr.Resource != "widgets" || discovery.preferredCalls != 2 {
+		t.Fatalf("expected an unknown kind to trigger one rediscovery, got %v after %d fetches", gvr, discovery.preferredCalls)
+	}
+}
+
+func TestDiscoveryMissesAreThrottled(t *testing.T) {
+	discovery := newCountingDiscovery()
+	discovery.Resources = append(discovery.Resources, &metav1.APIResourceList{
+		GroupVersion: "autoscaling/v1",
+		APIResources: []metav1.APIResource{{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true}},
+	})
+	discovery.notPreferred = map[string]bool{"autoscaling/v1": true}
+	c := &Client{
+		discoveryClient: discovery,
+		cacheDiscovery:  NewCacheableDiscovery(discovery, time.Minute),
+		gvrCache:        map[string]schema.GroupVersionResource{},
+		cacheTTL:        time.Minute,
+	}
+
+	// A pinned version that is not the preferred one is asked for directly
+	for range 3 {
+		gvr, err := c.findGroupVersionResourceForAPIVersion("HorizontalPodAutoscaler", "autoscaling/v1")
+		if err != nil || gvr.Resource != "horizontalpodautoscalers" {
+			t.Fatalf("findGroupVersionResourceForAPIVersion() = %v, %v", gvr, err)
+		}
+	}
+	if discovery.preferredCalls != 1 {
+		t.Fatalf("expected pinned versions to resolve without rediscovery, got %d fetches", discovery.preferredCalls)
+	}
+
+	// Unknown kinds rediscover once per interval
+	for range 3 {
+		if _, err := c.findGroupVersionResource("Podd"); err == nil {
+			t.Fatal("expected an unknown kind to fail")
+		}
+	}
+	if discovery.preferredCalls != 2 {
+		t.Fatalf("expected repeated misses to rediscover once, got %d fetches", discovery.preferredCalls)
+	}
+	c.cacheDiscovery.missRefreshed = time.Now().Add(-discoveryMissRefreshInterval)
+	if _, err := c.findGroupVersionResource("Podd"); err == nil {
+		t.Fatal("expected an unknown kind to fail")
+	}
+	if discovery.preferredCalls != 3 {
+		t.Fatalf("expected a miss after the interval to rediscover, got %d fetches", discovery.preferredCalls)
+	}
+
+	// An explicit refresh is not limited
+	if _, err := c.RefreshDiscovery(context.Background()); err != nil {
+		t.Fatalf("RefreshDiscovery() error = %v", err)
+	}
+	if discovery.preferredCalls != 4 {
+		t.Fatalf("expected an explicit refresh to rediscover, got %d fetches", discovery.preferredCalls)
+	}
+}
+
+func TestRefreshDiscovery(t *testing.T) {
+	discovery := newCountingDiscovery()
+	c := &Client{
+		discoveryClient: discovery,
+		cacheDiscovery:  NewCacheableDiscovery(discovery, time.Minute),
+		restConfig:      &rest.Config{Host: "https://refresh.test:6443"},
+		gvrCache:        map[string]schema.GroupVersionResource{"pod": {Version: "v1", Resource: "pods"}},
+		cacheExpiry:     time.Now().Add(time.Minute),
+	}
+	if _, err := c.cacheDiscovery.GetAPIResources(); err != nil {
+		t.Fatalf("GetAPIResources() error = %v", err)
+	}
+
+	result, err := c.RefreshDiscovery(context.Background())
+	if err != nil {
+		t.Fatalf("RefreshDiscovery() error = %v", err)
+	}
+	if discovery.preferredCalls != 2 {
+		t.Fatalf("expected refresh to fetch from the API server, got %d fetches", discovery.preferredCalls)
+	}
+	if result.Server != "https://refresh.test:6443" || result.GroupVersions != 1 || result.Resources != 1 {
+		t.Fatalf("unexpected refresh result: %+v", result)
+	}
+	if result.PreviousCacheAge == "" || result.CacheTTL != "1m0s" {
+		t.Fatalf("expected cache age and TTL in the result, got %+v", result)
+	}
+	if len(c.gvrCache) != 0 {
+		t.Fatalf("expected refresh to drop cached kind mappings, got %v", c.gvrCache)
+	}
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// EndpointPort describes a port exposed by a set of endpoints
+type EndpointPort struct {
+	Name     string `json:"name,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// ServiceEndpoint describes one address backing a Service
+type ServiceEndpoint struct {
+	IP          string         `json:"ip"`
+	PodName     string         `json:"podName,omitempty"`
+	NodeName    string         `json:"nodeName,omitempty"`
+	Ready       bool           `json:"ready"`
+	Serving     bool           `json:"serving"`
+	Terminating bool           `json:"terminating,omitempty"`
+	Ports       []EndpointPort `json:"ports,omitempty"`
+}
+
+// ResolveServiceEndpoints returns the Service's selector and ports together with the addresses backing it.
+// EndpointSlices are used when available, falling back to the legacy Endpoints object. The result
+// flags Services without ready endpoints and counts the pods the selector currently matches.
+func (c *Client) ResolveServiceEndpoints(ctx context.Context, name, namespace string) (map[string]any, error) {
+	logrus.WithFields(logrus.Fields{"name": name, "namespace": namespace}).Debug("ResolveServiceEndpoints called")
+
+	svc, err := c.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s/%s: %w", namespace, name, err)
+	}
+
+	endpoints, source, err := c.serviceEndpoints(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	ready := 0
+	for _, ep := range endpoints {
+		if ep.Ready {
+			ready++
+		}
+	}
+
+	ports := make([]map[string]any, 0, len(svc.Spec.Ports))
+	for _, port := range svc.Spec.Ports {
+		ports = append(ports, map[string]any{
+			"name":       port.Name,
+			"port":       port.Port,
+			"targetPort": port.TargetPort.String(),
+			"protocol":   string(port.Protocol),
+		})
+	}
+
+	result := map[string]any{
+		"service": map[string]any{
+			"name":      svc.Name,
+			"namespace": svc.Namespace,
+			"type":      string(svc.Spec.Type),
+			"clusterIP": svc.Spec.ClusterIP,
+			"selector":  svc.Spec.Selector,
+			"ports":     ports,
+		},
+		"source":        source,
+		"endpoints":     endpoints,
+		"readyCount":    ready,
+		"notReadyCount": len(endpoints) - ready,
+	}
+
+	var warnings []string
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		warnings = append(warnings, "ExternalName services resolve via DNS and have no endpoints")
+	} else if len(svc.Spec.Selector) == 0 {
+		warnings = append(warnings, "service has no selector; endpoints must be managed manually")
+	} else {
+		pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+		})
+		if err != nil {
+			logrus.WithError(err).Debug("Failed to list pods matching service selector")
+		} else {
+			result["matchingPods"] = len(pods.Items)
+			if len(pods.Items) == 0 {
+				warnings = append(warnings, "no pods match the service selector")
+			}
+		}
+	}
+	if ready == 0 && svc.Spec.Type != corev1.ServiceTypeExternalName {
+		warnings = append(warnings, "service has zero ready endpoints; requests to it will fail (commonly surfacing as 503s)")
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+
+	logrus.WithFields(logrus.Fields{"endpoints": len(endpoints), "ready": ready}).Debug("ResolveServiceEndpoints succeeded")
+	return result, nil
+}
+
+// serviceEndpoints lists the addresses backing a Service and reports whether they came from
+// EndpointSlices or the legacy Endpoints object
+func (c *Client) serviceEndpoints(ctx context.Context, name, namespace string) ([]ServiceEndpoint, string, error) {
+	slices, err := c.clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+	})
+	if err == nil {
+		return endpointsFromSlices(slices.Items), "EndpointSlice", nil
+	}
+
+	logrus.WithError(err).Debug("EndpointSlice lookup failed, falling back to Endpoints")
+	legacy, err := c.clientset.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{}) //nolint:staticcheck // SA1019: fallback for clusters without EndpointSlice access
+	if err != nil {
+		return nil, "Endpoints", fmt.Errorf("failed to get endpoints for service %s/%s: %w", namespace, name, err)
+	}
+	return endpointsFromLegacy(legacy), "Endpoints", nil
+}
+
+// endpointsFromSlices flattens EndpointSlices into one entry per address, sorted by pod name and IP
+func endpointsFromSlices(slices []discoveryv1.EndpointSlice) []ServiceEndpoint {
+	endpoints := []ServiceEndpoint{}
+	for _, slice := range slices {
+		ports := make([]EndpointPort, 0, len(slice.Ports))
+		for _, port := range slice.Ports {
+			ep := EndpointPort{}
+			if port.Name != nil {
+				ep.Name = *port.Name
+			}
+			if port.Port != nil {
+				ep.Port = *port.Port
+			}
+			if port.Protocol != nil {
+				ep.Protocol = string(*port.Protocol)
+			}
+			ports = append(ports, ep)
+		}
+		for _, endpoint := range slice.Endpoints {
+			// Unset conditions are interpreted as true by the EndpointSlice API
+			ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			serving := ready
+			if endpoint.Conditions.Serving != nil {
+				serving = *endpoint.Conditions.Serving
+			}
+			terminating := endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating
+			for _, address := range endpoint.Addresses {
+				ep := ServiceEndpoint{IP: address, Ready: ready, Serving: serving, Terminating: terminating, Ports: ports}
+				if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+					ep.PodName = endpoint.TargetRef.Name
+				}
+				if endpoint.NodeName != nil {
+					ep.NodeName = *endpoint.NodeName
+				}
+				endpoints = append(endpoints, ep)
+			}
+		}
+	}
+	sortServiceEndpoints(endpoints)
+	return endpoints
+}
+
+// endpointsFromLegacy flattens a core/v1 Endpoints object into one entry per address
+func endpointsFromLegacy(legacy *corev1.Endpoints) []ServiceEndpoint { //nolint:staticcheck // SA1019: legacy Endpoints fallback
+	endpoints := []ServiceEndpoint{}
+	for _, subset := range legacy.Subsets {
+		ports := make([]EndpointPort, 0, len(subset.Ports))
+		for _, port := range subset.Ports {
+			ports = append(ports, EndpointPort{Name: port.Name, Port: port.Port, Protocol: string(port.Protocol)})
+		}
+		add := func(addresses []corev1.EndpointAddress, ready bool) {
+			for _, address := range addresses {
+				ep := ServiceEndpoint{IP: address.IP, Ready: ready, Serving: ready, Ports: ports}
+				if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+					ep.PodName = address.TargetRef.Name
+				}
+				if address.NodeName != nil {
+					ep.NodeName = *address.NodeName
+				}
+				endpoints = append(endpoints, ep)
+			}
+		}
+		add(subset.Addresses, true)
+		add(subset.NotReadyAddresses, false)
+	}
+	sortServiceEndpoints(endpoints)
+	return endpoints
+}
+
+func sortServiceEndpoints(endpoints []ServiceEndpoint) {
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		if endpoints[i].PodName != endpoints[j].PodName {
+			return endpoints[i].PodName < endpoints[j].PodName
+		}
+		return endpoints[i].IP < endpoints[j].IP
+	})
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveServiceEndpointsFlagsNoReadyEndpoints(t *testing.T) {
+	notReady := false
+	port, portName := int32(8080), "http"
+	clientset := fake.NewClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: map[string]string{"app": "web"},
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 80}},
+			},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-abc",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+			},
+			Ports: []discoveryv1.EndpointPort{{Name: &portName, Port: &port}},
+			Endpoints: []discoveryv1.Endpoint{{
+				Addresses:  []string{"10.0.0.5"},
+				Conditions: discoveryv1.EndpointConditions{Ready: &notReady},
+				TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+			}},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+	)
+
+	c := &Client{clientset: clientset}
+	result, err := c.ResolveServiceEndpoints(context.Background(), "web", "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	endpoints := result["endpoints"].([]ServiceEndpoint)
+	want := []ServiceEndpoint{{IP: "10.0.0.5", PodName: "web-1", Ports: []EndpointPort{{Name: "http", Port: 8080}}}}
+	if !reflect.DeepEqual(endpoints, want) {
+		t.Fatalf("endpoints = %+v, want %+v", endpoints, want)
+	}
+	if result["readyCount"] != 0 || result["matchingPods"] != 1 || result["source"] != "EndpointSlice" {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	warnings, _ := result["warnings"].([]string)
+	if len(warnings) != 1 {
+		t.Fatalf("expected a zero-ready-endpoints warning, got %v", warnings)
+	}
+	if selector := result["service"].(map[string]any)["selector"]; !reflect.DeepEqual(selector, map[string]string{"app": "web"}) {
+		t.Fatalf("unexpected selector: %v", selector)
+	}
+}
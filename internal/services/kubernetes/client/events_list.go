@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// SortedEvents holds the events returned by ListEventsNewestFirst
+type SortedEvents struct {
+	Events        []map[string]any
+	ScannedEvents int
+	ScanLimited   bool
+}
+
+// ListEventsNewestFirst lists the events of a namespace (all namespaces when empty) matching fieldSelector,
+// ordered by last occurrence, newest first. The API server returns events in key order and field selectors
+// cannot filter on time, so matching events are read in full (bounded by eventSummaryMaxScan), events last
+// seen before since are dropped, and the rest are sorted client-side. Ties are broken by namespace and name
+// so the order is stable across calls.
+func (c *Client) ListEventsNewestFirst(ctx context.Context, namespace, fieldSelector string, since time.Time) (*SortedEvents, error) {
+	logrus.WithFields(logrus.Fields{
+		"namespace": namespace, "fieldSelector": fieldSelector, "since": since,
+	}).Debug("ListEventsNewestFirst called")
+
+	events, result, err := c.listEventsNewestFirst(ctx, namespace, fieldSelector, since)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Events = make([]map[string]any, 0, len(events))
+	for _, event := range events {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert event %s/%s: %w", event.Namespace, event.Name, err)
+		}
+		obj["apiVersion"] = "v1"
+		obj["kind"] = "Event"
+		result.Events = append(result.Events, obj)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"scanned": result.ScannedEvents, "matched": len(result.Events),
+	}).Debug("ListEventsNewestFirst succeeded")
+	return result, nil
+}
+
+// listEventsNewestFirst is ListEventsNewestFirst returning the typed events. The returned SortedEvents
+// only carries the scan statistics.
+func (c *Client) listEventsNewestFirst(ctx context.Context, namespace, fieldSelector string, since time.Time) ([]*corev1.Event, *SortedEvents, error) {
+	type seenEvent struct {
+		event    *corev1.Event
+		lastSeen time.Time
+	}
+
+	opts := metav1.ListOptions{Limit: eventSummaryPageSize, FieldSelector: fieldSelector}
+	result := &SortedEvents{}
+	var matched []seenEvent
+	for {
+		events, err := c.clientset.CoreV1().Events(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		for i := range events.Items {
+			result.ScannedEvents++
+			evt := &events.Items[i]
+			lastSeen := eventLastSeen(evt)
+			if !since.IsZero() && lastSeen.Before(since) {
+				continue
+			}
+			matched = append(matched, seenEvent{event: evt, lastSeen: lastSeen})
+		}
+		if events.Continue == "" {
+			break
+		}
+		if result.ScannedEvents >= eventSummaryMaxScan {
+			result.ScanLimited = true
+			break
+		}
+		opts.Continue = events.Continue
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if !a.lastSeen.Equal(b.lastSeen) {
+			return a.lastSeen.After(b.lastSeen)
+		}
+		if a.event.Namespace != b.event.Namespace {
+			return a.event.Namespace < b.event.Namespace
+		}
+		return a.event.Name < b.event.Name
+	})
+
+	events := make([]*corev1.Event, 0, len(matched))
+	for _, m := range matched {
+		events = append(events, m.event)
+	}
+	return events, result, nil
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestListEventsNewestFirst(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	event := func(name string, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: name, Namespace: "default"},
+			Type:           corev1.EventTypeWarning,
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+	// Reported through the events.k8s.io API, which sets eventTime instead of lastTimestamp
+	series := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "d-series", Namespace: "default"},
+		Type:       corev1.EventTypeWarning,
+		EventTime:  metav1.NewMicroTime(now.Add(-time.Hour)),
+		Series:     &corev1.EventSeries{Count: 4, LastObservedTime: metav1.NewMicroTime(now.Add(-30 * time.Second))},
+	}
+
+	clientset := fake.NewClientset(
+		event("a-old", now.Add(-3*time.Hour)),
+		event("b-recent", now.Add(-time.Minute)),
+		event("c-tied", now.Add(-time.Minute)),
+		event("e-newest", now),
+		series,
+	)
+	c := &Client{clientset: clientset}
+
+	sorted, err := c.ListEventsNewestFirst(context.Background(), "default", "", time.Time{})
+	if err != nil {
+		t.Fatalf("ListEventsNewestFirst() error = %v", err)
+	}
+	var names []string
+	for _, evt := range sorted.Events {
+		names = append(names, evt["metadata"].(map[string]any)["name"].(string))
+	}
+	want := []string{"e-newest", "d-series", "b-recent", "c-tied", "a-old"}
+	if len(names) != len(want) {
+		t.Fatalf("got events %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got events %v, want %v", names, want)
+		}
+	}
+	if sorted.ScannedEvents != 5 || sorted.Events[0]["kind"] != "Event" {
+		t.Fatalf("unexpected scan result: scanned=%d first=%v", sorted.ScannedEvents, sorted.Events[0]["kind"])
+	}
+
+	sorted, err = c.ListEventsNewestFirst(context.Background(), "default", "", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ListEventsNewestFirst() error = %v", err)
+	}
+	if len(sorted.Events) != 4 || sorted.ScannedEvents != 5 {
+		t.Fatalf("expected the since filter to drop the old event, got %d of %d", len(sorted.Events), sorted.ScannedEvents)
+	}
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// eventSummaryPageSize is the page size used to list events for a summary
+	eventSummaryPageSize = 500
+	// eventSummaryMaxScan bounds the number of events read for one summary
+	eventSummaryMaxScan = 10000
+	// eventSummarySampleObjects is the number of involved objects listed per group
+	eventSummarySampleObjects = 3
+)
+
+// EventGroup aggregates the events sharing a reason and involved object kind
+type EventGroup struct {
+	Reason    string    `json:"reason"`
+	Kind      string    `json:"kind"`
+	Type      string    `json:"type"`
+	Count     int32     `json:"count"`
+	Events    int       `json:"events"`
+	Objects   int       `json:"objects"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Message   string    `json:"message"`
+	Examples  []string  `json:"examples"`
+
+	objects  map[string]bool
+	messages map[string]int32
+}
+
+// EventSummary is the ranked list of event groups returned by SummarizeEvents
+type EventSummary struct {
+	Namespace     string        `json:"namespace,omitempty"`
+	Type          string        `json:"type,omitempty"`
+	SinceMinutes  int64         `json:"sinceMinutes,omitempty"`
+	ScannedEvents int           `json:"scannedEvents"`
+	MatchedEvents int           `json:"matchedEvents"`
+	TotalGroups   int           `json:"totalGroups"`
+	Groups        []*EventGroup `json:"groups"`
+	ScanLimited   bool          `json:"scanLimited,omitempty"`
+}
+
+// SummarizeEvents groups the events of a namespace (all namespaces when empty) by reason and involved
+// object kind. Occurrences are counted using the event's count field, so deduplicated events weigh as
+// much as they happened. eventType filters on Warning or Normal, and since drops events last seen
+// earlier. Groups are ranked by occurrences, then by most recent, and limited to top.
+func (c *Client) SummarizeEvents(ctx context.Context, namespace, eventType string, since time.Duration, top int) (*EventSummary, error) {
+	logrus.WithFields(logrus.Fields{
+		"namespace": namespace, "type": eventType, "since": since, "top": top,
+	}).Debug("SummarizeEvents called")
+
+	opts := metav1.ListOptions{Limit: eventSummaryPageSize}
+	if eventType != "" {
+		opts.FieldSelector = "type=" + eventType
+	}
+	var cutoff time.Time
+	if since > 0 {
+		cutoff = time.Now().Add(-since)
+	}
+
+	summary := &EventSummary{Namespace: namespace, Type: eventType, SinceMinutes: int64(since / time.Minute)}
+	groups := map[string]*EventGroup{}
+	for {
+		events, err := c.clientset.CoreV1().Events(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		for i := range events.Items {
+			summary.ScannedEvents++
+			evt := &events.Items[i]
+			lastSeen := eventLastSeen(evt)
+			if !cutoff.IsZero() && lastSeen.Before(cutoff) {
+				continue
+			}
+			summary.MatchedEvents++
+			addEventToGroup(groups, evt, lastSeen)
+		}
+		if events.Continue == "" {
+			break
+		}
+		if summary.ScannedEvents >= eventSummaryMaxScan {
+			summary.ScanLimited = true
+			break
+		}
+		opts.Continue = events.Continue
+	}
+
+	summary.Groups = make([]*EventGroup, 0, len(groups))
+	for _, group := range groups {
+		group.finish()
+		summary.Groups = append(summary.Groups, group)
+	}
+	sort.Slice(summary.Groups, func(i, j int) bool {
+		a, b := summary.Groups[i], summary.Groups[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if !a.LastSeen.Equal(b.LastSeen) {
+			return a.LastSeen.After(b.LastSeen)
+		}
+		return a.Reason+a.Kind < b.Reason+b.Kind
+	})
+	summary.TotalGroups = len(summary.Groups)
+	if top > 0 && len(summary.Groups) > top {
+		summary.Groups = summary.Groups[:top]
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"scanned": summary.ScannedEvents, "groups": summary.TotalGroups,
+	}).Debug("SummarizeEvents succeeded")
+	return summary, nil
+}
+
+func addEventToGroup(groups map[string]*EventGroup, evt *corev1.Event, lastSeen time.Time) {
+	key := evt.Reason + "/" + evt.InvolvedObject.Kind
+	group, ok := groups[key]
+	if !ok {
+		group = &EventGroup{
+			Reason:   evt.Reason,
+			Kind:     evt.InvolvedObject.Kind,
+			Type:     evt.Type,
+			objects:  map[string]bool{},
+			messages: map[string]int32{},
+		}
+		groups[key] = group
+	}
+
+	count := eventCount(evt)
+	group.Count += count
+	group.Events++
+	if group.Type != evt.Type {
+		group.Type = "Mixed"
+	}
+
+	firstSeen := eventFirstSeen(evt, lastSeen)
+	if group.FirstSeen.IsZero() || firstSeen.Before(group.FirstSeen) {
+		group.FirstSeen = firstSeen
+	}
+	if lastSeen.After(group.LastSeen) {
+		group.LastSeen = lastSeen
+	}
+
+	object := evt.InvolvedObject.Name
+	if evt.InvolvedObject.Namespace != "" {
+		object = evt.InvolvedObject.Namespace + "/" + object
+	}
+	if !group.objects[object] && len(group.Examples) < eventSummarySampleObjects {
+		group.Examples = append(group.Examples, object)
+	}
+	group.objects[object] = true
+	group.messages[evt.Message] += count
+}
+
+// finish computes the distinct object count and picks the most frequent message as representative
+func (g *EventGroup) finish() {
+	g.Objects = len(g.objects)
+	var best int32
+	for message, count := range g.messages {
+		if count > best || (count == best && message < g.Message) {
+			g.Message, best = message, count
+		}
+	}
+	g.objects, g.messages = nil, nil
+}
+
+// eventCount returns how many times the event occurred, including occurrences folded into a series
+func eventCount(evt *corev1.Event) int32 {
+	count := evt.Count
+	if evt.Series != nil && evt.Series.Count > count {
+		count = evt.Series.Count
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// eventLastSeen returns the most recent occurrence time, falling back through the fields set by
+// the different event APIs
+func eventLastSeen(evt *corev1.Event) time.Time {
+	switch {
+	case evt.Series != nil && !evt.Series.LastObservedTime.IsZero():
+		return evt.Series.LastObservedTime.Time
+	case !evt.LastTimestamp.IsZero():
+		return evt.LastTimestamp.Time
+	case !evt.EventTime.IsZero():
+		return evt.EventTime.Time
+	case !evt.FirstTimestamp.IsZero():
+		return evt.FirstTimestamp.Time
+	default:
+		return evt.CreationTimestamp.Time
+	}
+}
+
+func eventFirstSeen(evt *corev1.Event, lastSeen time.Time) time.Time {
+	switch {
+	case !evt.FirstTimestamp.IsZero():
+		return evt.FirstTimestamp.Time
+	case !evt.EventTime.IsZero():
+		return evt.EventTime.Time
+	default:
+		return lastSeen
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSummarizeEvents(t *testing.T) {
+	now := time.Now()
+	event := func(name, reason, kind, object, message string, count int32, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object, Namespace: "default"},
+			Reason:         reason,
+			Message:        message,
+			Type:           corev1.EventTypeWarning,
+			Count:          count,
+			FirstTimestamp: metav1.NewTime(lastSeen.Add(-10 * time.Minute)),
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+
+	clientset := fake.NewClientset(
+		event("e1", "BackOff", "Pod", "web-1", "Back-off restarting failed container", 30, now.Add(-time.Minute)),
+		event("e2", "BackOff", "Pod", "web-2", "Back-off restarting failed container", 12, now.Add(-2*time.Minute)),
+		event("e3", "BackOff", "Pod", "web-2", "Back-off pulling image", 1, now.Add(-3*time.Minute)),
+		event("e4", "FailedScheduling", "Pod", "db-0", "0/3 nodes are available", 5, now.Add(-5*time.Minute)),
+		event("e5", "FailedMount", "Pod", "db-0", "MountVolume.SetUp failed", 50, now.Add(-3*time.Hour)),
+	)
+	c := &Client{clientset: clientset}
+
+	summary, err := c.SummarizeEvents(context.Background(), "default", "", time.Hour, 10)
+	if err != nil {
+		t.Fatalf("SummarizeEvents() error = %v", err)
+	}
+	if summary.ScannedEvents != 5 || summary.MatchedEvents != 4 || summary.TotalGroups != 2 {
+		t.Fatalf("unexpected totals: %+v", summary)
+	}
+
+	backOff := summary.Groups[0]
+	if backOff.Reason != "BackOff" || backOff.Kind != "Pod" || backOff.Count != 43 || backOff.Events != 3 || backOff.Objects != 2 {
+		t.Fatalf("unexpected top group: %+v", backOff)
+	}
+	if backOff.Message != "Back-off restarting failed container" {
+		t.Fatalf("expected the most frequent message, got %q", backOff.Message)
+	}
+	if !backOff.LastSeen.Equal(now.Add(-time.Minute)) || len(backOff.Examples) != 2 {
+		t.Fatalf("unexpected lastSeen or examples: %+v", backOff)
+	}
+
+	summary, err = c.SummarizeEvents(context.Background(), "default", "", 0, 1)
+	if err != nil {
+		t.Fatalf("SummarizeEvents() error = %v", err)
+	}
+	if summary.TotalGroups != 3 || len(summary.Groups) != 1 || summary.Groups[0].Reason != "FailedMount" {
+		t.Fatalf("expected FailedMount to rank first without a time window, got %+v", summary.Groups)
+	}
+}
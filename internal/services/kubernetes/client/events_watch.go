@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// eventLineMaxMessage bounds the message length of one streamed event line
+const eventLineMaxMessage = 200
+
+// Reasons reported by WatchEvents for ending the watch
+const (
+	EventWatchStopTimeout   = "timeout"
+	EventWatchStopCancelled = "cancelled"
+	EventWatchStopMaxEvents = "maxEvents"
+	EventWatchStopClosed    = "watchClosed"
+)
+
+// EventWatchResult collects the events seen while following events
+type EventWatchResult struct {
+	Lines           []string `json:"events"`
+	Count           int      `json:"count"`
+	StopReason      string   `json:"stopReason"`
+	DurationSeconds float64  `json:"durationSeconds"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// WatchEvents follows events in a namespace (all namespaces when empty) that are created or
+// updated after the call, for at most timeout or until maxEvents lines were seen. Each event is
+// formatted as a compact line, passed to emit as it arrives and collected in the result. An
+// updated event (its count increased) produces a new line. Cancelling ctx stops the watch and
+// returns what was collected so far.
+func (c *Client) WatchEvents(ctx context.Context, namespace, fieldSelector string, timeout time.Duration, maxEvents int, emit func(line string)) (*EventWatchResult, error) {
+	logrus.WithFields(logrus.Fields{
+		"namespace": namespace, "fieldSelector": fieldSelector, "timeout": timeout, "maxEvents": maxEvents,
+	}).Debug("WatchEvents called")
+
+	// Start from the current resource version so only new activity is streamed
+	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	timeoutSeconds := int64(timeout / time.Second)
+	watcher, err := c.clientset.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector:   fieldSelector,
+		ResourceVersion: list.ResourceVersion,
+		TimeoutSeconds:  &timeoutSeconds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch events: %w", err)
+	}
+	defer watcher.Stop()
+
+	start := time.Now()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	result := &EventWatchResult{Lines: []string{}}
+	for result.StopReason == "" {
+		select {
+		case <-ctx.Done():
+			result.StopReason = EventWatchStopCancelled
+		case <-timer.C:
+			result.StopReason = EventWatchStopTimeout
+		case change, ok := <-watcher.ResultChan():
+			if !ok {
+				result.StopReason = EventWatchStopClosed
+				break
+			}
+			switch change.Type {
+			case watch.Added, watch.Modified:
+				evt, ok := change.Object.(*corev1.Event)
+				if !ok {
+					continue
+				}
+				line := formatEventLine(evt)
+				result.Lines = append(result.Lines, line)
+				if emit != nil {
+					emit(line)
+				}
+				if maxEvents > 0 && len(result.Lines) >= maxEvents {
+					result.StopReason = EventWatchStopMaxEvents
+				}
+			case watch.Error:
+				result.StopReason = EventWatchStopClosed
+				if status, ok := change.Object.(*metav1.Status); ok {
+					result.Error = status.Message
+				}
+			}
+		}
+	}
+
+	result.Count = len(result.Lines)
+	result.DurationSeconds = time.Since(start).Round(time.Millisecond).Seconds()
+	logrus.WithFields(logrus.Fields{"count": result.Count, "stopReason": result.StopReason}).Debug("WatchEvents finished")
+	return result, nil
+}
+
+// formatEventLine renders an event as "<time> <type> <reason> <kind>/<namespace>/<name> x<count>: <message>"
+func formatEventLine(evt *corev1.Event) string {
+	object := evt.InvolvedObject.Kind + "/"
+	if evt.InvolvedObject.Namespace != "" {
+		object += evt.InvolvedObject.Namespace + "/"
+	}
+	object += evt.InvolvedObject.Name
+
+	message := evt.Message
+	if len(message) > eventLineMaxMessage {
+		message = message[:eventLineMaxMessage] + "..."
+	}
+	return fmt.Sprintf("%s %s %s %s x%d: %s",
+		eventLastSeen(evt).UTC().Format(time.RFC3339), evt.Type, evt.Reason, object, eventCount(evt), message)
+}
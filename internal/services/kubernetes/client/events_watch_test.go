@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWatchEvents(t *testing.T) {
+	seen := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := func(reason string, count int32) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web-1." + reason, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "default"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         reason,
+			Message:        "Back-off restarting failed container",
+			Count:          count,
+			LastTimestamp:  metav1.NewTime(seen),
+		}
+	}
+
+	newClient := func(watcher *watch.FakeWatcher) *Client {
+		clientset := fake.NewClientset()
+		clientset.PrependWatchReactor("events", k8stesting.DefaultWatchReactor(watcher, nil))
+		return &Client{clientset: clientset}
+	}
+
+	t.Run("stops after max events", func(t *testing.T) {
+		watcher := watch.NewFakeWithChanSize(3, false)
+		watcher.Add(event("BackOff", 1))
+		watcher.Delete(event("Pulled", 1))
+		watcher.Modify(event("BackOff", 2))
+
+		var emitted []string
+		result, err := newClient(watcher).WatchEvents(context.Background(), "default", "", time.Minute, 2, func(line string) {
+			emitted = append(emitted, line)
+		})
+		if err != nil {
+			t.Fatalf("WatchEvents() error = %v", err)
+		}
+		if result.StopReason != EventWatchStopMaxEvents || result.Count != 2 || len(emitted) != 2 {
+			t.Fatalf("unexpected result: %+v (emitted %d)", result, len(emitted))
+		}
+		want := "2026-01-02T03:04:05Z Warning BackOff Pod/default/web-1 x2: Back-off restarting failed container"
+		if result.Lines[1] != want {
+			t.Fatalf("line = %q, want %q", result.Lines[1], want)
+		}
+	})
+
+	t.Run("stops on timeout", func(t *testing.T) {
+		result, err := newClient(watch.NewFake()).WatchEvents(context.Background(), "", "type=Warning", 20*time.Millisecond, 10, nil)
+		if err != nil {
+			t.Fatalf("WatchEvents() error = %v", err)
+		}
+		if result.StopReason != EventWatchStopTimeout || result.Count != 0 {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("stops on cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		watcher := watch.NewFakeWithChanSize(1, false)
+		watcher.Add(event("BackOff", 1))
+		result, err := newClient(watcher).WatchEvents(ctx, "default", "", time.Minute, 10, func(string) { cancel() })
+		if err != nil {
+			t.Fatalf("WatchEvents() error = %v", err)
+		}
+		if result.StopReason != EventWatchStopCancelled || result.Count != 1 || !strings.Contains(result.Lines[0], "BackOff") {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	})
+}
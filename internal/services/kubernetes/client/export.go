@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultExportKinds are the kinds exported from a namespace when none are given, ordered so that
+// applying the bundle creates dependencies (accounts, configuration, storage) before workloads
+var DefaultExportKinds = []string{
+	"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim",
+	"Role", "RoleBinding",
+	"Service", "Deployment", "StatefulSet", "DaemonSet", "CronJob", "Job",
+	"Ingress", "NetworkPolicy", "HorizontalPodAutoscaler", "PodDisruptionBudget",
+}
+
+// exportStrippedMetadata are the server-managed metadata fields removed from exported objects
+var exportStrippedMetadata = []string{
+	"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink", "ownerReferences", "deletionTimestamp", "deletionGracePeriodSeconds",
+}
+
+// exportStrippedAnnotations are annotations written by the API server or controllers
+var exportStrippedAnnotations = []string{
+	lastAppliedAnnotation,
+	"deployment.kubernetes.io/revision",
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+}
+
+// NamespaceExport is one page of a namespace export
+type NamespaceExport struct {
+	Namespace     string            `json:"namespace"`
+	Objects       []map[string]any  `json:"objects"`
+	Counts        map[string]int    `json:"counts"`
+	Skipped       map[string]int    `json:"skipped,omitempty"`
+	KindErrors    map[string]string `json:"kindErrors,omitempty"`
+	ContinueToken string            `json:"continueToken,omitempty"`
+}
+
+// ExportNamespace lists the given kinds (DefaultExportKinds when empty) in a namespace and returns
+// them stripped of status and server-managed metadata so they can be re-applied. Objects owned by a
+// controller, token Secrets and the objects Kubernetes creates in every namespace are left out because
+// they are recreated automatically. Secrets are only exported when includeSecrets is set.
+//
+// At most limit objects are returned per call. The continue token records the kind being listed and
+// the API server's own continue token; pass it back with the same kinds to get the next page.
+func (c *Client) ExportNamespace(ctx context.Context, namespace string, kinds []string, includeSecrets bool, continueToken string, limit int64) (*NamespaceExport, error) {
+	logrus.WithFields(logrus.Fields{
+		"namespace": namespace, "kinds": len(kinds), "includeSecrets": includeSecrets, "continue": continueToken, "limit": limit,
+	}).Debug("ExportNamespace called")
+
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	if len(kinds) == 0 {
+		kinds = DefaultExportKinds
+	}
+	if !includeSecrets {
+		filtered := make([]string, 0, len(kinds))
+		for _, kind := range kinds {
+			if normalizeKind(kind) != "Secret" {
+				filtered = append(filtered, kind)
+			}
+		}
+		kinds = filtered
+	}
+
+	kindIndex, apiContinue, err := parseExportContinueToken(continueToken, len(kinds))
+	if err != nil {
+		return nil, err
+	}
+
+	export := &NamespaceExport{Namespace: namespace, Objects: []map[string]any{}, Counts: map[string]int{}}
+	for ; kindIndex < len(kinds); kindIndex++ {
+		kind := kinds[kindIndex]
+		remaining := limit - int64(len(export.Objects))
+		if remaining <= 0 {
+			export.ContinueToken = formatExportContinueToken(kindIndex, "")
+			break
+		}
+
+		gvr, err := c.findGroupVersionResource(kind)
+		if err != nil {
+			// Kinds such as HorizontalPodAutoscaler may not be served; the rest of the export still works
+			export.addKindError(kind, err)
+			apiContinue = ""
+			continue
+		}
+		list, err := c.dynamicClient.Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{Limit: remaining, Continue: apiContinue})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			export.addKindError(kind, err)
+			apiContinue = ""
+			continue
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if reason := exportSkipReason(obj); reason != "" {
+				if export.Skipped == nil {
+					export.Skipped = map[string]int{}
+				}
+				export.Skipped[reason]++
+				continue
+			}
+			export.Objects = append(export.Objects, exportableObject(obj))
+			export.Counts[obj.GetKind()]++
+		}
+
+		apiContinue = list.GetContinue()
+		if apiContinue != "" {
+			// The page filled up in the middle of this kind
+			export.ContinueToken = formatExportContinueToken(kindIndex, apiContinue)
+			break
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{"objects": len(export.Objects), "continue": export.ContinueToken}).Debug("ExportNamespace succeeded")
+	return export, nil
+}
+
+func (e *NamespaceExport) addKindError(kind string, err error) {
+	logrus.WithError(err).WithField("kind", kind).Debug("Skipping kind in namespace export")
+	if e.KindErrors == nil {
+		e.KindErrors = map[string]string{}
+	}
+	e.KindErrors[kind] = err.Error()
+}
+
+// exportSkipReason reports why an object is left out of an export, or "" to export it
+func exportSkipReason(obj *unstructured.Unstructured) string {
+	if metav1.GetControllerOf(obj) != nil {
+		return "controllerOwned"
+	}
+	switch obj.GetKind() {
+	case "ServiceAccount":
+		if obj.GetName() == "default" {
+			return "defaultServiceAccount"
+		}
+	case "ConfigMap":
+		if obj.GetName() == "kube-root-ca.crt" {
+			return "rootCAConfigMap"
+		}
+	case "Secret":
+		if secretType, _, _ := unstructured.NestedString(obj.Object, "type"); secretType == "kubernetes.io/service-account-token" {
+			return "serviceAccountToken"
+		}
+	}
+	return ""
+}
+
+// exportableObject returns a copy of obj without status and server-managed fields
+func exportableObject(obj *unstructured.Unstructured) map[string]any {
+	out := obj.DeepCopy().Object
+	delete(out, "status")
+
+	if metadata, ok := out["metadata"].(map[string]any); ok {
+		for _, field := range exportStrippedMetadata {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			for _, key := range exportStrippedAnnotations {
+				delete(annotations, key)
+			}
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+
+	if obj.GetKind() == "Service" {
+		// Cluster IPs are allocated per cluster; re-applying them elsewhere can collide
+		if spec, ok := out["spec"].(map[string]any); ok {
+			if clusterIP, _ := spec["clusterIP"].(string); clusterIP != "None" {
+				delete(spec, "clusterIP")
+				delete(spec, "clusterIPs")
+			}
+		}
+	}
+	return out
+}
+
+// parseExportContinueToken splits a "<kindIndex>:<apiContinue>" token
+func parseExportContinueToken(token string, kinds int) (int, string, error) {
+	if token == "" {
+		return 0, "", nil
+	}
+	indexText, apiContinue, _ := strings.Cut(token, ":")
+	index, err := strconv.Atoi(indexText)
+	if err != nil || index < 0 || index >= kinds {
+		return 0, "", fmt.Errorf("invalid continueToken %q: pass back the token of the previous page with the same kinds", token)
+	}
+	return index, apiContinue, nil
+}
+
+func formatExportContinueToken(kindIndex int, apiContinue string) string {
+	return strconv.Itoa(kindIndex) + ":" + apiContinue
+}
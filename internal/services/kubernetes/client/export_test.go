@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func TestExportNamespace(t *testing.T) {
+	object := func(kind, namespace, name string, fields map[string]any) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: fields}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetUID(types.UID("uid-" + name))
+		obj.SetResourceVersion("42")
+		return obj
+	}
+
+	service := object("Service", "shop", "web", map[string]any{
+		"spec":   map[string]any{"clusterIP": "10.0.0.12", "clusterIPs": []any{"10.0.0.12"}, "ports": []any{map[string]any{"port": int64(80)}}},
+		"status": map[string]any{"loadBalancer": map[string]any{}},
+	})
+	service.SetAnnotations(map[string]string{lastAppliedAnnotation: "{}", "team": "shop"})
+	headless := object("Service", "shop", "db", map[string]any{"spec": map[string]any{"clusterIP": "None"}})
+	configMap := object("ConfigMap", "shop", "settings", map[string]any{"data": map[string]any{"mode": "prod"}})
+	rootCA := object("ConfigMap", "shop", "kube-root-ca.crt", map[string]any{})
+	secret := object("Secret", "shop", "db-password", map[string]any{"type": "Opaque"})
+	token := object("Secret", "shop", "builder-token", map[string]any{"type": "kubernetes.io/service-account-token"})
+	owned := object("ConfigMap", "shop", "generated", map[string]any{})
+	owned.Object["metadata"].(map[string]any)["ownerReferences"] = []any{map[string]any{"kind": "Deployment", "name": "web", "controller": true}}
+	other := object("ConfigMap", "other", "settings", map[string]any{})
+
+	services := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	secrets := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	c := &Client{
+		dynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{services: "ServiceList", configMaps: "ConfigMapList", secrets: "SecretList"},
+			service, headless, configMap, rootCA, secret, token, owned, other,
+		),
+		gvrCache:    map[string]schema.GroupVersionResource{"service": services, "configmap": configMaps, "secret": secrets},
+		cacheExpiry: time.Now().Add(time.Hour),
+	}
+
+	kinds := []string{"ConfigMap", "Secret", "Service"}
+	export, err := c.ExportNamespace(context.Background(), "shop", kinds, false, "", 100)
+	if err != nil {
+		t.Fatalf("ExportNamespace() error = %v", err)
+	}
+	if len(export.Objects) != 3 || export.Counts["ConfigMap"] != 1 || export.Counts["Service"] != 2 || export.Counts["Secret"] != 0 {
+		t.Fatalf("unexpected export counts: %+v", export.Counts)
+	}
+	if export.Skipped["rootCAConfigMap"] != 1 || export.Skipped["controllerOwned"] != 1 || export.ContinueToken != "" {
+		t.Fatalf("unexpected skipped objects or continue token: %+v %q", export.Skipped, export.ContinueToken)
+	}
+
+	for _, obj := range export.Objects {
+		u := &unstructured.Unstructured{Object: obj}
+		if _, ok := obj["status"]; ok {
+			t.Fatalf("%s/%s still has status", u.GetKind(), u.GetName())
+		}
+		if u.GetUID() != "" || u.GetResourceVersion() != "" {
+			t.Fatalf("%s/%s still has server-managed metadata: %+v", u.GetKind(), u.GetName(), obj["metadata"])
+		}
+		switch u.GetName() {
+		case "web":
+			if _, ok, _ := unstructured.NestedString(obj, "spec", "clusterIP"); ok {
+				t.Fatalf("expected the cluster IP to be stripped, got %+v", obj["spec"])
+			}
+			if annotations := u.GetAnnotations(); len(annotations) != 1 || annotations["team"] != "shop" {
+				t.Fatalf("expected only the user annotation to remain, got %+v", annotations)
+			}
+		case "db":
+			if clusterIP, _, _ := unstructured.NestedString(obj, "spec", "clusterIP"); clusterIP != "None" {
+				t.Fatalf("expected the headless clusterIP to be kept, got %q", clusterIP)
+			}
+		}
+	}
+	if service.GetUID() == "" {
+		t.Fatal("ExportNamespace() modified the listed object")
+	}
+
+	export, err = c.ExportNamespace(context.Background(), "shop", kinds, true, "", 100)
+	if err != nil {
+		t.Fatalf("ExportNamespace() with secrets error = %v", err)
+	}
+	if export.Counts["Secret"] != 1 || export.Skipped["serviceAccountToken"] != 1 {
+		t.Fatalf("expected the opaque secret only, got counts %+v skipped %+v", export.Counts, export.Skipped)
+	}
+
+	// A full page stops before the next kind and resumes from it
+	export, err = c.ExportNamespace(context.Background(), "shop", kinds, false, "", 1)
+	if err != nil {
+		t.Fatalf("ExportNamespace() first page error = %v", err)
+	}
+	if len(export.Objects) != 1 || export.ContinueToken != "1:" {
+		t.Fatalf("unexpected first page: %d objects, token %q", len(export.Objects), export.ContinueToken)
+	}
+	export, err = c.ExportNamespace(context.Background(), "shop", kinds, false, export.ContinueToken, 100)
+	if err != nil {
+		t.Fatalf("ExportNamespace() second page error = %v", err)
+	}
+	if export.Counts["Service"] != 2 || export.Counts["ConfigMap"] != 0 {
+		t.Fatalf("unexpected second page counts: %+v", export.Counts)
+	}
+
+	if _, err := c.ExportNamespace(context.Background(), "shop", kinds, false, "7:abc", 100); err == nil {
+		t.Fatal("expected an out-of-range continue token to be rejected")
+	}
+}
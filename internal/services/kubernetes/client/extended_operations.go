@@ -484,8 +484,8 @@ func (c *Client) GetPodMetrics(ctx context.Context, podName, namespace string, a
 		"pod": podName, "namespace": namespace, "allContainers": allContainers,
 	}).Debug("GetPodMetrics called")
 
-	if c.metricsClient == nil {
-		return nil, fmt.Errorf("metrics server not available")
+	if err := c.requireMetricsAPI(); err != nil {
+		return nil, err
 	}
 
 	podMetrics, err := c.metricsClient.MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
@@ -563,15 +563,8 @@ func (c *Client) DrainNode(ctx context.Context, nodeName string, deleteEmptyDir,
 	}).Debug("DrainNode called")
 
 	// Cordon the node first
-	node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("get node failed: %w", err)
-	}
-
-	node.Spec.Unschedulable = true
-	_, err = c.clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("cordon node failed: %w", err)
+	if err := c.setNodeUnschedulable(ctx, nodeName, true); err != nil {
+		return err
 	}
 
 	logrus.Debug("DrainNode succeeded")
@@ -609,34 +602,39 @@ func (c *Client) GetLogsStream(ctx context.Context, podName, namespace, containe
 func (c *Client) CordonNode(ctx context.Context, nodeName string) error {
 	logrus.WithField("node", nodeName).Debug("CordonNode called")
 
-	node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("get node failed: %w", err)
-	}
-
-	node.Spec.Unschedulable = true
-	_, err = c.clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("cordon node failed: %w", err)
+	if err := c.setNodeUnschedulable(ctx, nodeName, true); err != nil {
+		return err
 	}
 
 	logrus.Debug("CordonNode succeeded")
 	return nil
 }
 
+// setNodeUnschedulable cordons or uncordons a node, re-reading it when the update conflicts
+func (c *Client) setNodeUnschedulable(ctx context.Context, nodeName string, unschedulable bool) error {
+	verb := "cordon"
+	if !unschedulable {
+		verb = "uncordon"
+	}
+	return retryOnConflict(ctx, verb+" node "+nodeName, func() error {
+		node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get node failed: %w", err)
+		}
+		node.Spec.Unschedulable = unschedulable
+		if _, err := c.clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("%s node failed: %w", verb, err)
+		}
+		return nil
+	})
+}
+
 // UncordonNode marks node as schedulable
 func (c *Client) UncordonNode(ctx context.Context, nodeName string) error {
 	logrus.WithField("node", nodeName).Debug("UncordonNode called")
 
-	node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("get node failed: %w", err)
-	}
-
-	node.Spec.Unschedulable = false
-	_, err = c.clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("uncordon node failed: %w", err)
+	if err := c.setNodeUnschedulable(ctx, nodeName, false); err != nil {
+		return err
 	}
 
 	logrus.Debug("UncordonNode succeeded")
@@ -647,8 +645,8 @@ func (c *Client) UncordonNode(ctx context.Context, nodeName string) error {
 func (c *Client) GetNodeMetrics(ctx context.Context, nodeName string) (map[string]any, error) {
 	logrus.WithField("node", nodeName).Debug("GetNodeMetrics called")
 
-	if c.metricsClient == nil {
-		return nil, fmt.Errorf("metrics server not available")
+	if err := c.requireMetricsAPI(); err != nil {
+		return nil, err
 	}
 
 	nodeMetrics, err := c.metricsClient.MetricsV1beta1().NodeMetricses().Get(ctx, nodeName, metav1.GetOptions{})
@@ -768,6 +766,21 @@ type UnhealthyResource struct {
 	Message   string `json:"message,omitempty"`
 	Age       string `json:"age"`
 	IssueType string `json:"issueType"`
+
+	// Pod only: the container most likely behind the failure
+	Container             string `json:"container,omitempty"`
+	InitContainer         bool   `json:"initContainer,omitempty"`
+	WaitingReason         string `json:"waitingReason,omitempty"`
+	RestartCount          *int32 `json:"restartCount,omitempty"`
+	LastExitCode          *int32 `json:"lastExitCode,omitempty"`
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+
+	// Set by AttachWarningEvents
+	WarningEvent *UnhealthyWarningEvent `json:"warningEvent,omitempty"`
+
+	// Set by RankUnhealthyResources
+	Severity       int    `json:"severity,omitempty"`
+	SeverityReason string `json:"severityReason,omitempty"`
 }
 
 // GetUnhealthyResources finds pods and other resources in unhealthy states
@@ -782,6 +795,10 @@ func (c *Client) GetUnhealthyResources(ctx context.Context, namespace string, re
 	}
 
 	for _, kind := range resourceTypes {
+		// Per-kind failures are skipped below, which would hide a cancelled call
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		gvr, err := c.findGroupVersionResource(kind)
 		if err != nil {
 			logrus.Warnf("Could not find GVR for %s: %v", kind, err)
@@ -838,6 +855,18 @@ func (c *Client) GetUnhealthyResources(ctx context.Context, namespace string, re
 						}
 					}
 				}
+				// Pods that cannot be placed have no container statuses yet
+				if phase == "Pending" && issueType == "" {
+					for _, cond := range getSliceField(item.Object, "status.conditions") {
+						condMap, ok := cond.(map[string]interface{})
+						if !ok || getStringField(condMap, "type") != "PodScheduled" || getStringField(condMap, "status") != "False" {
+							continue
+						}
+						issueType = "unschedulable"
+						reason = getStringField(condMap, "reason")
+						message = getStringField(condMap, "message")
+					}
+				}
 			case "Job":
 				phase = getStringField(item.Object, "status.failed")
 				if phase != "" && phase != "0" {
@@ -872,7 +901,7 @@ func (c *Client) GetUnhealthyResources(ctx context.Context, namespace string, re
 					}
 				}
 
-				unhealthy = append(unhealthy, UnhealthyResource{
+				resource := UnhealthyResource{
 					Kind:      kind,
 					Name:      item.GetName(),
 					Namespace: item.GetNamespace(),
@@ -881,7 +910,11 @@ func (c *Client) GetUnhealthyResources(ctx context.Context, namespace string, re
 					Message:   message,
 					Age:       age,
 					IssueType: issueType,
-				})
+				}
+				if kind == "Pod" {
+					resource.setPodFailureDetail(item.Object)
+				}
+				unhealthy = append(unhealthy, resource)
 			}
 		}
 	}
@@ -1008,12 +1041,17 @@ func getIntField(obj map[string]interface{}, path string) int {
 	}
 }
 
-// AnalyzeIssue performs AI-powered issue analysis
+// AnalyzeIssue performs AI-powered issue analysis using the analyzer registered for issueType
 func (c *Client) AnalyzeIssue(ctx context.Context, issueType string, resourceKind, resourceName, namespace string) (map[string]any, error) {
 	logrus.WithFields(logrus.Fields{
 		"issueType": issueType, "kind": resourceKind, "name": resourceName, "namespace": namespace,
 	}).Debug("AnalyzeIssue called")
 
+	analyzer, ok := lookupIssueAnalyzer(issueType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported issue type %q (supported: %s)", issueType, strings.Join(SupportedIssueTypes(), ", "))
+	}
+
 	result := map[string]any{
 		"issueType": issueType,
 		"resource":  fmt.Sprintf("%s/%s", resourceKind, resourceName),
@@ -1021,11 +1059,15 @@ func (c *Client) AnalyzeIssue(ctx context.Context, issueType string, resourceKin
 
 	// Get resource information
 	resource, err := c.GetResource(ctx, resourceKind, resourceName, namespace)
-	if err != nil {
+	switch {
+	case err == nil:
+		result["resource"] = resource
+	case apierrors.IsNotFound(err) && analyzesDeletedResources(analyzer):
+		result["resourceDeleted"] = true
+	default:
 		result["error"] = fmt.Sprintf("Failed to get resource: %v", err)
 		return result, nil
 	}
-	result["resource"] = resource
 
 	// Get events
 	events, err := c.GetResourceEvents(ctx, resourceKind, resourceName, namespace, 10, "")
@@ -1035,63 +1077,30 @@ func (c *Client) AnalyzeIssue(ctx context.Context, issueType string, resourceKin
 		result["events"] = events
 	}
 
-	// Generate analysis based on issue type
-	var analysis []string
-	var recommendations []string
-
-	switch issueType {
-	case "pod_crash":
-		// Check for restart loop
-		restartCount := getIntField(resource, "status.containerStatuses.0.restartCount")
-		if restartCount > 5 {
-			analysis = append(analysis, fmt.Sprintf("Container has restarted %d times", restartCount))
-			recommendations = append(recommendations, "Check container logs for crash reasons: kubectl logs "+resourceName+" --previous")
-			recommendations = append(recommendations, "Verify resource limits are sufficient")
-		}
-		waitingReason := getStringField(resource, "status.containerStatuses.0.state.waiting.reason")
-		if waitingReason != "" {
-			analysis = append(analysis, fmt.Sprintf("Container is waiting: %s", waitingReason))
-			waitingMessage := getStringField(resource, "status.containerStatuses.0.state.waiting.message")
-			if waitingMessage != "" {
-				analysis = append(analysis, fmt.Sprintf("Message: %s", waitingMessage))
-			}
-		}
-
-	case "pod_pending":
-		phase := getStringField(resource, "status.phase")
-		if phase == "Pending" {
-			reason := getStringField(resource, "status.reason")
-			analysis = append(analysis, fmt.Sprintf("Pod is pending: %s", reason))
-			if reason == "Unschedulable" {
-				recommendations = append(recommendations, "Check node resource capacity")
-				recommendations = append(recommendations, "Verify node taints and tolerations")
-			}
-		}
-
-	case "deployment_unavailable":
-		available := getIntField(resource, "status.availableReplicas")
-		replicas := getIntField(resource, "spec.replicas")
-		if replicas > 0 && available < replicas {
-			analysis = append(analysis, fmt.Sprintf("Deployment has %d/%d replicas available", available, replicas))
-			recommendations = append(recommendations, "Check rollout status for deployment")
-			recommendations = append(recommendations, "Check events for the deployment")
-		}
-
-	case "job_failed":
-		failed := getIntField(resource, "status.failed")
-		if failed > 0 {
-			analysis = append(analysis, fmt.Sprintf("Job has failed %d times", failed))
-			recommendations = append(recommendations, "Check job events for failure reason")
-			recommendations = append(recommendations, "Verify backoffLimit and restartPolicy")
-		}
+	findings, err := analyzer.Analyze(ctx, c, IssueTarget{
+		Kind:      resourceKind,
+		Name:      resourceName,
+		Namespace: namespace,
+		Resource:  resource,
+	})
+	if err != nil {
+		result["error"] = fmt.Sprintf("Failed to analyze %s: %v", issueType, err)
+		return result, nil
 	}
 
+	analysis := findings.Analysis
 	if len(analysis) == 0 {
 		analysis = append(analysis, "No specific issues detected based on current status")
 	}
 
 	result["analysis"] = analysis
-	result["recommendations"] = recommendations
+	result["recommendations"] = findings.Recommendations
+	if len(findings.Hypotheses) > 0 {
+		result["hypotheses"] = rankHypotheses(findings.Hypotheses)
+	}
+	if len(findings.Details) > 0 {
+		result["details"] = findings.Details
+	}
 	result["analyzedAt"] = time.Now().Format(time.RFC3339)
 
 	logrus.Debug("AnalyzeIssue succeeded")
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// ResourceMatch locates one resource found by name
+type ResourceMatch struct {
+	Kind              string            `json:"kind"`
+	APIVersion        string            `json:"apiVersion"`
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace,omitempty"`
+	CreationTimestamp string            `json:"creationTimestamp,omitempty"`
+	Phase             string            `json:"phase,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+}
+
+// ResourceLookup lists every resource of a kind with an exact name, across all namespaces
+type ResourceLookup struct {
+	Kind       string          `json:"kind"`
+	Name       string          `json:"name"`
+	Found      int             `json:"found"`
+	Unique     bool            `json:"unique"`
+	Namespaces []string        `json:"namespaces"`
+	Matches    []ResourceMatch `json:"matches"`
+}
+
+// FindResourceByName locates resources of a kind by exact name without knowing their namespace. The
+// name is pushed to the API server as a metadata.name field selector, so a single cluster-wide list
+// returns only the matches instead of every object of the kind.
+func (c *Client) FindResourceByName(ctx context.Context, kind, name string) (*ResourceLookup, error) {
+	logrus.WithFields(logrus.Fields{"kind": kind, "name": name}).Debug("FindResourceByName called")
+
+	if kind == "" || name == "" {
+		return nil, fmt.Errorf("kind and name are required")
+	}
+
+	items, err := c.ListResources(ctx, kind, "", "", fields.OneTermEqualSelector("metadata.name", name).String())
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := &ResourceLookup{Kind: kind, Name: name, Namespaces: []string{}, Matches: []ResourceMatch{}}
+	for _, item := range items {
+		obj := unstructured.Unstructured{Object: item}
+		// Not every API honours field selectors; keep the result exact either way
+		if obj.GetName() != name {
+			continue
+		}
+		match := ResourceMatch{
+			Kind:       obj.GetKind(),
+			APIVersion: obj.GetAPIVersion(),
+			Name:       obj.GetName(),
+			Namespace:  obj.GetNamespace(),
+			Labels:     obj.GetLabels(),
+		}
+		if created := obj.GetCreationTimestamp(); !created.IsZero() {
+			match.CreationTimestamp = created.UTC().Format(time.RFC3339)
+		}
+		match.Phase, _, _ = unstructured.NestedString(item, "status", "phase")
+		lookup.Matches = append(lookup.Matches, match)
+	}
+
+	sort.Slice(lookup.Matches, func(i, j int) bool { return lookup.Matches[i].Namespace < lookup.Matches[j].Namespace })
+	for _, match := range lookup.Matches {
+		if match.Namespace != "" {
+			lookup.Namespaces = append(lookup.Namespaces, match.Namespace)
+		}
+	}
+	lookup.Found = len(lookup.Matches)
+	lookup.Unique = lookup.Found == 1
+
+	logrus.WithFields(logrus.Fields{"found": lookup.Found, "namespaces": lookup.Namespaces}).Debug("FindResourceByName succeeded")
+	return lookup, nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestFindResourceByName(t *testing.T) {
+	pod := func(namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{"status": map[string]any{"phase": "Running"}}}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Pod")
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "PodList"},
+		pod("team-b", "api-0"), pod("team-a", "api-0"), pod("team-a", "api-1"), pod("team-c", "web-0"),
+	)
+	c := &Client{
+		dynamicClient: dynamicClient,
+		gvrCache:      map[string]schema.GroupVersionResource{"pod": gvr},
+		cacheExpiry:   time.Now().Add(time.Hour),
+	}
+
+	lookup, err := c.FindResourceByName(context.Background(), "Pod", "api-0")
+	if err != nil {
+		t.Fatalf("FindResourceByName() error = %v", err)
+	}
+	if lookup.Found != 2 || lookup.Unique || !reflect.DeepEqual(lookup.Namespaces, []string{"team-a", "team-b"}) {
+		t.Fatalf("unexpected lookup: %+v", lookup)
+	}
+	if lookup.Matches[0].Phase != "Running" || lookup.Matches[0].APIVersion != "v1" {
+		t.Fatalf("unexpected match: %+v", lookup.Matches[0])
+	}
+
+	list, ok := dynamicClient.Actions()[0].(k8stesting.ListAction)
+	if !ok || list.GetNamespace() != "" || list.GetListRestrictions().Fields.String() != "metadata.name=api-0" {
+		t.Fatalf("expected a cluster-wide list filtered by name, got %+v", dynamicClient.Actions()[0])
+	}
+
+	lookup, err = c.FindResourceByName(context.Background(), "Pod", "web-0")
+	if err != nil {
+		t.Fatalf("FindResourceByName() error = %v", err)
+	}
+	if !lookup.Unique || lookup.Namespaces[0] != "team-c" {
+		t.Fatalf("expected a unique match in team-c, got %+v", lookup)
+	}
+
+	if _, err := c.FindResourceByName(context.Background(), "Pod", ""); err == nil {
+		t.Fatal("expected an error without a name")
+	}
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// lastAppliedAnnotation is the annotation kubectl apply uses to record the applied configuration
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	// managedFieldsDepth limits how deep managed field paths are reported per manager
+	managedFieldsDepth = 2
+)
+
+// ManagedFieldsOwner summarizes the fields owned by one field manager
+type ManagedFieldsOwner struct {
+	Manager     string   `json:"manager"`
+	Operation   string   `json:"operation"`
+	APIVersion  string   `json:"apiVersion,omitempty"`
+	Subresource string   `json:"subresource,omitempty"`
+	Time        string   `json:"time,omitempty"`
+	Fields      []string `json:"fields,omitempty"`
+}
+
+// GetResourceApplyHistory returns the parsed last-applied configuration of a resource together with
+// a per-manager summary of its managedFields and the applied fields whose live value has drifted.
+func (c *Client) GetResourceApplyHistory(ctx context.Context, kind, name, namespace string) (map[string]any, error) {
+	logrus.WithFields(logrus.Fields{"kind": kind, "name": name, "namespace": namespace}).Debug("GetResourceApplyHistory called")
+
+	obj, err := c.GetResource(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	history := buildApplyHistory(&unstructured.Unstructured{Object: obj})
+	logrus.Debug("GetResourceApplyHistory succeeded")
+	return history, nil
+}
+
+// buildApplyHistory extracts the last-applied configuration and managedFields ownership from a live object
+func buildApplyHistory(obj *unstructured.Unstructured) map[string]any {
+	history := map[string]any{
+		"kind":            obj.GetKind(),
+		"name":            obj.GetName(),
+		"resourceVersion": obj.GetResourceVersion(),
+		"managedFields":   summarizeManagedFields(obj.GetManagedFields()),
+	}
+	if ns := obj.GetNamespace(); ns != "" {
+		history["namespace"] = ns
+	}
+
+	raw, ok := obj.GetAnnotations()[lastAppliedAnnotation]
+	if !ok {
+		history["lastApplied"] = map[string]any{
+			"present": false,
+			"message": "No " + lastAppliedAnnotation + " annotation: the resource was not created or updated with client-side `kubectl apply` (it may use server-side apply, create/replace, or a controller).",
+		}
+		return history
+	}
+
+	var applied map[string]any
+	if err := json.Unmarshal([]byte(raw), &applied); err != nil {
+		history["lastApplied"] = map[string]any{
+			"present": true,
+			"error":   "failed to parse annotation: " + err.Error(),
+			"raw":     raw,
+		}
+		return history
+	}
+
+	drifted := driftedFields(applied, obj.Object, "")
+	sort.Strings(drifted)
+	history["lastApplied"] = map[string]any{
+		"present":       true,
+		"configuration": applied,
+		"driftedFields": drifted,
+	}
+	return history
+}
+
+// summarizeManagedFields converts managedFields entries into owners with their field paths
+func summarizeManagedFields(entries []metav1.ManagedFieldsEntry) []ManagedFieldsOwner {
+	owners := make([]ManagedFieldsOwner, 0, len(entries))
+	for _, entry := range entries {
+		owner := ManagedFieldsOwner{
+			Manager:     entry.Manager,
+			Operation:   string(entry.Operation),
+			APIVersion:  entry.APIVersion,
+			Subresource: entry.Subresource,
+		}
+		if entry.Time != nil {
+			owner.Time = entry.Time.UTC().Format(time.RFC3339)
+		}
+		if entry.FieldsV1 != nil {
+			var fields map[string]any
+			if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err == nil {
+				owner.Fields = managedFieldPaths(fields, "", managedFieldsDepth)
+				sort.Strings(owner.Fields)
+			}
+		}
+		owners = append(owners, owner)
+	}
+	return owners
+}
+
+// managedFieldPaths flattens a FieldsV1 set into dotted paths, stopping at the given depth.
+// The "f:" prefix is stripped from field names; list keys ("k:", "v:") are kept verbatim.
+func managedFieldPaths(fields map[string]any, prefix string, depth int) []string {
+	var paths []string
+	for key, value := range fields {
+		if key == "." {
+			continue
+		}
+		path := strings.TrimPrefix(key, "f:")
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		children, ok := value.(map[string]any)
+		if depth <= 1 || !ok || len(children) == 0 {
+			paths = append(paths, path)
+			continue
+		}
+		childPaths := managedFieldPaths(children, path, depth-1)
+		if len(childPaths) == 0 {
+			paths = append(paths, path)
+			continue
+		}
+		paths = append(paths, childPaths...)
+	}
+	return paths
+}
+
+// driftedFields returns the paths of applied values that differ from the live object.
+// Fields only present on the live object (defaults, status) are not reported.
+func driftedFields(applied, live any, path string) []string {
+	switch appliedValue := applied.(type) {
+	case map[string]any:
+		liveMap, ok := live.(map[string]any)
+		if !ok {
+			return []string{path}
+		}
+		var drifted []string
+		for key, value := range appliedValue {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if childPath == "metadata.annotations."+lastAppliedAnnotation {
+				continue
+			}
+			liveValue, exists := liveMap[key]
+			if !exists {
+				drifted = append(drifted, childPath)
+				continue
+			}
+			drifted = append(drifted, driftedFields(value, liveValue, childPath)...)
+		}
+		return drifted
+	case []any:
+		liveList, ok := live.([]any)
+		if !ok || len(liveList) != len(appliedValue) {
+			return []string{path}
+		}
+		var drifted []string
+		for i := range appliedValue {
+			drifted = append(drifted, driftedFields(appliedValue[i], liveList[i], path+"["+strconv.Itoa(i)+"]")...)
+		}
+		return drifted
+	default:
+		if !reflect.DeepEqual(normalizeNumber(applied), normalizeNumber(live)) {
+			return []string{path}
+		}
+		return nil
+	}
+}
+
+// normalizeNumber converts numeric values to float64 so that JSON-decoded and
+// unstructured integers compare equal
+func normalizeNumber(value any) any {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return value
+	}
+}
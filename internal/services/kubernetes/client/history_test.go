@@ -0,0 +1,74 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBuildApplyHistoryReportsDriftAndOwners(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":      "web",
+			"namespace": "default",
+			"annotations": map[string]any{
+				lastAppliedAnnotation: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default"},"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"web","image":"nginx:1.26"}]}}}}`,
+			},
+		},
+		"spec": map[string]any{
+			"replicas": int64(5),
+			"template": map[string]any{"spec": map[string]any{"containers": []any{
+				map[string]any{"name": "web", "image": "nginx:1.26", "imagePullPolicy": "IfNotPresent"},
+			}}},
+		},
+	}}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:   "kubectl-client-side-apply",
+			Operation: metav1.ManagedFieldsOperationUpdate,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{".":{}}},"f:spec":{"f:template":{"f:spec":{}}}}`)},
+		},
+		{
+			Manager:   "kubectl",
+			Operation: metav1.ManagedFieldsOperationUpdate,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+		},
+	})
+
+	history := buildApplyHistory(obj)
+
+	lastApplied := history["lastApplied"].(map[string]any)
+	if lastApplied["present"] != true {
+		t.Fatalf("expected last-applied to be present, got %v", lastApplied)
+	}
+	if drifted := lastApplied["driftedFields"]; !reflect.DeepEqual(drifted, []string{"spec.replicas"}) {
+		t.Fatalf("unexpected drifted fields: %v", drifted)
+	}
+
+	owners := history["managedFields"].([]ManagedFieldsOwner)
+	if len(owners) != 2 {
+		t.Fatalf("expected 2 managers, got %d", len(owners))
+	}
+	if want := []string{"metadata.annotations", "spec.template"}; !reflect.DeepEqual(owners[0].Fields, want) {
+		t.Fatalf("unexpected fields for %s: %v", owners[0].Manager, owners[0].Fields)
+	}
+	if want := []string{"spec.replicas"}; !reflect.DeepEqual(owners[1].Fields, want) {
+		t.Fatalf("unexpected fields for %s: %v", owners[1].Manager, owners[1].Fields)
+	}
+}
+
+func TestBuildApplyHistoryWithoutAnnotation(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"kind":     "ConfigMap",
+		"metadata": map[string]any{"name": "settings"},
+	}}
+
+	lastApplied := buildApplyHistory(obj)["lastApplied"].(map[string]any)
+	if lastApplied["present"] != false || lastApplied["message"] == "" {
+		t.Fatalf("expected absent annotation to be reported, got %v", lastApplied)
+	}
+}
@@ -0,0 +1,283 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Autoscaler states, in the order they are reported
+const (
+	hpaStateUnableToScale = "unable-to-scale"
+	hpaStateScalingUp     = "scaling-up"
+	hpaStateScalingDown   = "scaling-down"
+	hpaStateLimited       = "limited"
+	hpaStateStable        = "stable"
+)
+
+var hpaStateOrder = map[string]int{
+	hpaStateUnableToScale: 0,
+	hpaStateScalingUp:     1,
+	hpaStateScalingDown:   1,
+	hpaStateLimited:       2,
+	hpaStateStable:        3,
+}
+
+// HPAMetric compares the current value of one autoscaling metric with its target
+type HPAMetric struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Current string `json:"current"`
+	Target  string `json:"target"`
+}
+
+// HPAEvent is the most recent event recorded for an autoscaler
+type HPAEvent struct {
+	Type    string     `json:"type"`
+	Reason  string     `json:"reason"`
+	Message string     `json:"message"`
+	Count   int32      `json:"count,omitempty"`
+	Time    *time.Time `json:"time,omitempty"`
+}
+
+// HPAStatus is the state of one HorizontalPodAutoscaler
+type HPAStatus struct {
+	Name            string      `json:"name"`
+	Namespace       string      `json:"namespace"`
+	Target          string      `json:"target"`
+	State           string      `json:"state"`
+	MinReplicas     int32       `json:"minReplicas"`
+	MaxReplicas     int32       `json:"maxReplicas"`
+	CurrentReplicas int32       `json:"currentReplicas"`
+	DesiredReplicas int32       `json:"desiredReplicas"`
+	Metrics         []HPAMetric `json:"metrics"`
+	Problems        []string    `json:"problems,omitempty"`
+	LastScaleTime   *time.Time  `json:"lastScaleTime,omitempty"`
+	LastEvent       *HPAEvent   `json:"lastEvent,omitempty"`
+}
+
+// HPAStatusReport lists the autoscalers of a namespace, those scaling or unable to scale first
+type HPAStatusReport struct {
+	Namespace     string      `json:"namespace,omitempty"`
+	Total         int         `json:"total"`
+	Scaling       int         `json:"scaling"`
+	UnableToScale int         `json:"unableToScale"`
+	Autoscalers   []HPAStatus `json:"autoscalers"`
+}
+
+// GetHPAStatus reports for every HorizontalPodAutoscaler of a namespace (all namespaces when empty) its
+// target workload, current and desired replicas, current and target metric values, the conditions
+// keeping it from scaling and its most recent event. Autoscalers unable to scale come first, then
+// those scaling, those held at their replica bounds and the stable ones.
+func (c *Client) GetHPAStatus(ctx context.Context, namespace string) (*HPAStatusReport, error) {
+	logrus.WithField("namespace", namespace).Debug("GetHPAStatus called")
+
+	autoscalers, err := c.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list horizontal pod autoscalers: %w", err)
+	}
+
+	lastEvents := map[string]*corev1.Event{}
+	if len(autoscalers.Items) > 0 {
+		events, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "involvedObject.kind=HorizontalPodAutoscaler"})
+		if err != nil {
+			// Events add context but are not needed for the status itself
+			logrus.WithError(err).Warn("Failed to list autoscaler events")
+		} else {
+			for i := range events.Items {
+				event := &events.Items[i]
+				if event.InvolvedObject.Kind != "HorizontalPodAutoscaler" {
+					continue
+				}
+				key := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+				if previous, ok := lastEvents[key]; !ok || eventTime(event).After(eventTime(previous)) {
+					lastEvents[key] = event
+				}
+			}
+		}
+	}
+
+	report := &HPAStatusReport{Namespace: namespace, Total: len(autoscalers.Items), Autoscalers: make([]HPAStatus, 0, len(autoscalers.Items))}
+	for i := range autoscalers.Items {
+		hpa := &autoscalers.Items[i]
+		status := hpaStatus(hpa)
+		if event, ok := lastEvents[hpa.Namespace+"/"+hpa.Name]; ok {
+			status.LastEvent = &HPAEvent{Type: event.Type, Reason: event.Reason, Message: event.Message, Count: event.Count}
+			if at := eventTime(event); !at.IsZero() {
+				status.LastEvent.Time = &at
+			}
+		}
+		switch status.State {
+		case hpaStateUnableToScale:
+			report.UnableToScale++
+		case hpaStateScalingUp, hpaStateScalingDown:
+			report.Scaling++
+		}
+		report.Autoscalers = append(report.Autoscalers, status)
+	}
+
+	sort.SliceStable(report.Autoscalers, func(i, j int) bool {
+		a, b := report.Autoscalers[i], report.Autoscalers[j]
+		if hpaStateOrder[a.State] != hpaStateOrder[b.State] {
+			return hpaStateOrder[a.State] < hpaStateOrder[b.State]
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"total": report.Total, "scaling": report.Scaling, "unableToScale": report.UnableToScale,
+	}).Debug("GetHPAStatus succeeded")
+	return report, nil
+}
+
+// hpaStatus summarizes an autoscaler from its spec, status and conditions
+func hpaStatus(hpa *autoscalingv2.HorizontalPodAutoscaler) HPAStatus {
+	status := HPAStatus{
+		Name:            hpa.Name,
+		Namespace:       hpa.Namespace,
+		Target:          hpa.Spec.ScaleTargetRef.Kind + "/" + hpa.Spec.ScaleTargetRef.Name,
+		MinReplicas:     1,
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+		Metrics:         hpaMetrics(hpa),
+	}
+	if hpa.Spec.MinReplicas != nil {
+		status.MinReplicas = *hpa.Spec.MinReplicas
+	}
+	if hpa.Status.LastScaleTime != nil {
+		at := hpa.Status.LastScaleTime.UTC()
+		status.LastScaleTime = &at
+	}
+
+	unable, limited := false, false
+	for _, condition := range hpa.Status.Conditions {
+		switch {
+		case (condition.Type == autoscalingv2.AbleToScale || condition.Type == autoscalingv2.ScalingActive) && condition.Status == corev1.ConditionFalse:
+			unable = true
+			status.Problems = append(status.Problems, fmt.Sprintf("%s: %s", condition.Reason, condition.Message))
+		case condition.Type == autoscalingv2.ScalingLimited && condition.Status == corev1.ConditionTrue:
+			limited = true
+			status.Problems = append(status.Problems, fmt.Sprintf("%s: %s", condition.Reason, condition.Message))
+		}
+	}
+
+	switch {
+	case unable:
+		status.State = hpaStateUnableToScale
+	case status.DesiredReplicas > status.CurrentReplicas:
+		status.State = hpaStateScalingUp
+	case status.DesiredReplicas < status.CurrentReplicas:
+		status.State = hpaStateScalingDown
+	case limited:
+		status.State = hpaStateLimited
+	default:
+		status.State = hpaStateStable
+	}
+	return status
+}
+
+// hpaMetrics pairs each metric of the spec with its current value from the status. A metric the
+// controller could not read has current "<unknown>", as in kubectl.
+func hpaMetrics(hpa *autoscalingv2.HorizontalPodAutoscaler) []HPAMetric {
+	current := map[string]autoscalingv2.MetricValueStatus{}
+	for _, metric := range hpa.Status.CurrentMetrics {
+		typeName, name, value := hpaMetricStatusValue(metric)
+		current[typeName+"/"+name] = value
+	}
+
+	metrics := make([]HPAMetric, 0, len(hpa.Spec.Metrics))
+	for _, spec := range hpa.Spec.Metrics {
+		typeName, name, target := hpaMetricSpecTarget(spec)
+		metric := HPAMetric{Type: typeName, Name: name, Current: "<unknown>", Target: formatMetricTarget(target)}
+		if value, ok := current[typeName+"/"+name]; ok {
+			metric.Current = formatMetricValue(value, target.Type)
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+func hpaMetricSpecTarget(spec autoscalingv2.MetricSpec) (string, string, autoscalingv2.MetricTarget) {
+	switch {
+	case spec.Resource != nil:
+		return string(spec.Type), string(spec.Resource.Name), spec.Resource.Target
+	case spec.ContainerResource != nil:
+		return string(spec.Type), spec.ContainerResource.Container + "/" + string(spec.ContainerResource.Name), spec.ContainerResource.Target
+	case spec.Pods != nil:
+		return string(spec.Type), spec.Pods.Metric.Name, spec.Pods.Target
+	case spec.Object != nil:
+		return string(spec.Type), spec.Object.Metric.Name, spec.Object.Target
+	case spec.External != nil:
+		return string(spec.Type), spec.External.Metric.Name, spec.External.Target
+	}
+	return string(spec.Type), "", autoscalingv2.MetricTarget{}
+}
+
+func hpaMetricStatusValue(status autoscalingv2.MetricStatus) (string, string, autoscalingv2.MetricValueStatus) {
+	switch {
+	case status.Resource != nil:
+		return string(status.Type), string(status.Resource.Name), status.Resource.Current
+	case status.ContainerResource != nil:
+		return string(status.Type), status.ContainerResource.Container + "/" + string(status.ContainerResource.Name), status.ContainerResource.Current
+	case status.Pods != nil:
+		return string(status.Type), status.Pods.Metric.Name, status.Pods.Current
+	case status.Object != nil:
+		return string(status.Type), status.Object.Metric.Name, status.Object.Current
+	case status.External != nil:
+		return string(status.Type), status.External.Metric.Name, status.External.Current
+	}
+	return string(status.Type), "", autoscalingv2.MetricValueStatus{}
+}
+
+// formatMetricTarget renders a target as a utilization percentage, an average per pod or a total
+func formatMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String() + " (average)"
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return "<unset>"
+}
+
+// formatMetricValue renders a current value in the same terms as the target of type targetType
+func formatMetricValue(value autoscalingv2.MetricValueStatus, targetType autoscalingv2.MetricTargetType) string {
+	switch {
+	case targetType == autoscalingv2.UtilizationMetricType && value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil && targetType != autoscalingv2.ValueMetricType:
+		return value.AverageValue.String() + " (average)"
+	case value.Value != nil:
+		return value.Value.String()
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String() + " (average)"
+	}
+	return "<unknown>"
+}
+
+// eventTime returns when an event last happened, whichever timestamp its reporter filled in
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.UTC()
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.UTC()
+	case !event.EventTime.IsZero():
+		return event.EventTime.UTC()
+	}
+	return event.CreationTimestamp.UTC()
+}
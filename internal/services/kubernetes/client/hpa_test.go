@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetHPAStatus(t *testing.T) {
+	int32Ptr := func(v int32) *int32 { return &v }
+	cpuMetric := func(target int32) []autoscalingv2.MetricSpec {
+		return []autoscalingv2.MetricSpec{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:   corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(target)},
+			},
+		}}
+	}
+	cpuStatus := func(current int32) []autoscalingv2.MetricStatus {
+		return []autoscalingv2.MetricStatus{{
+			Type:     autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricStatus{Name: corev1.ResourceCPU, Current: autoscalingv2.MetricValueStatus{AverageUtilization: int32Ptr(current)}},
+		}}
+	}
+	hpa := func(name string, spec []autoscalingv2.MetricSpec, status autoscalingv2.HorizontalPodAutoscalerStatus) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: name},
+				MinReplicas:    int32Ptr(2),
+				MaxReplicas:    10,
+				Metrics:        spec,
+			},
+			Status: status,
+		}
+	}
+	event := func(hpaName, eventType, reason, message string, at time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: fmt.Sprintf("%s.%d", hpaName, at.Unix()), Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: "HorizontalPodAutoscaler", Name: hpaName, Namespace: "shop"},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        message,
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	c := &Client{clientset: fake.NewClientset(
+		hpa("api", cpuMetric(70), autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: 3, DesiredReplicas: 3, CurrentMetrics: cpuStatus(40)}),
+		hpa("web", cpuMetric(60), autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: 4, DesiredReplicas: 6, CurrentMetrics: cpuStatus(95)}),
+		hpa("worker", []autoscalingv2.MetricSpec{{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "queue_depth"},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: resource.NewQuantity(30, resource.DecimalSI)},
+			},
+		}}, autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 2, DesiredReplicas: 2,
+			Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2.AbleToScale, Status: corev1.ConditionTrue, Reason: "SucceededGetScale"},
+				{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Reason: "FailedGetExternalMetric", Message: "unable to get external metric queue_depth"},
+			},
+		}),
+		event("web", corev1.EventTypeNormal, "SuccessfulRescale", "New size: 4; reason: cpu resource utilization above target", now.Add(-time.Hour)),
+		event("web", corev1.EventTypeNormal, "SuccessfulRescale", "New size: 6; reason: cpu resource utilization above target", now),
+		event("worker", corev1.EventTypeWarning, "FailedGetExternalMetric", "unable to get external metric queue_depth", now),
+	)}
+
+	report, err := c.GetHPAStatus(context.Background(), "shop")
+	if err != nil {
+		t.Fatalf("GetHPAStatus() error = %v", err)
+	}
+	if report.Total != 3 || report.Scaling != 1 || report.UnableToScale != 1 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+	order := []string{report.Autoscalers[0].Name, report.Autoscalers[1].Name, report.Autoscalers[2].Name}
+	if order[0] != "worker" || order[1] != "web" || order[2] != "api" {
+		t.Fatalf("expected unable-to-scale, then scaling, then stable, got %v", order)
+	}
+
+	worker := report.Autoscalers[0]
+	if worker.State != hpaStateUnableToScale || len(worker.Problems) != 1 || worker.Metrics[0].Current != "<unknown>" || worker.Metrics[0].Target != "30 (average)" {
+		t.Fatalf("unexpected worker status: %+v", worker)
+	}
+	if worker.LastEvent == nil || worker.LastEvent.Reason != "FailedGetExternalMetric" {
+		t.Fatalf("unexpected worker event: %+v", worker.LastEvent)
+	}
+
+	web := report.Autoscalers[1]
+	if web.State != hpaStateScalingUp || web.Target != "Deployment/web" || web.MinReplicas != 2 || web.DesiredReplicas != 6 {
+		t.Fatalf("unexpected web status: %+v", web)
+	}
+	if web.Metrics[0].Name != "cpu" || web.Metrics[0].Current != "95%" || web.Metrics[0].Target != "60%" {
+		t.Fatalf("unexpected web metrics: %+v", web.Metrics)
+	}
+	if web.LastEvent == nil || web.LastEvent.Message != "New size: 6; reason: cpu resource utilization above target" {
+		t.Fatalf("expected the newest web event, got %+v", web.LastEvent)
+	}
+
+	if api := report.Autoscalers[2]; api.State != hpaStateStable || api.LastEvent != nil {
+		t.Fatalf("unexpected api status: %+v", api)
+	}
+}
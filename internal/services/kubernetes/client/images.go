@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImageWorkload is a workload, or a standalone pod, that runs an image
+type ImageWorkload struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// ImageUsage is one distinct container image and where it is used
+type ImageUsage struct {
+	Image     string          `json:"image"`
+	Pods      int             `json:"pods"`
+	Init      bool            `json:"init,omitempty"`
+	Digests   []string        `json:"digests,omitempty"`
+	Workloads []ImageWorkload `json:"workloads"`
+}
+
+// ImageInventory lists the container images in use in a namespace or across the cluster
+type ImageInventory struct {
+	Namespace   string       `json:"namespace,omitempty"`
+	Filter      string       `json:"filter,omitempty"`
+	TotalImages int          `json:"totalImages"`
+	Images      []ImageUsage `json:"images"`
+}
+
+// GetImageInventory lists the distinct container images, init containers included, of running pods
+// and of the pod templates of Deployments, StatefulSets, DaemonSets, ReplicaSets, Jobs and CronJobs,
+// so workloads scaled to zero are covered. Each image reports how many pods use it, the digests the
+// kubelet resolved and the workloads referencing it, pods being attributed to their top-level
+// controller. filter keeps images containing the substring (case-insensitive). An empty namespace
+// scans every namespace.
+func (c *Client) GetImageInventory(ctx context.Context, namespace, filter string) (*ImageInventory, error) {
+	logrus.WithFields(logrus.Fields{"namespace": namespace, "filter": filter}).Debug("GetImageInventory called")
+
+	apps := c.clientset.AppsV1()
+	deployments, err := apps.Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	statefulSets, err := apps.StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	daemonSets, err := apps.DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	replicaSets, err := apps.ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	jobs, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	cronJobs, err := c.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	needle := strings.ToLower(strings.TrimSpace(filter))
+	type usage struct {
+		ImageUsage
+		workloads map[ImageWorkload]bool
+		digests   map[string]bool
+	}
+	images := map[string]*usage{}
+	entry := func(image string) *usage {
+		if image == "" || (needle != "" && !strings.Contains(strings.ToLower(image), needle)) {
+			return nil
+		}
+		if images[image] == nil {
+			images[image] = &usage{
+				ImageUsage: ImageUsage{Image: image, Init: true},
+				workloads:  map[ImageWorkload]bool{},
+				digests:    map[string]bool{},
+			}
+		}
+		return images[image]
+	}
+	// addSpec records the images of a pod spec against a workload and returns the images it matched
+	addSpec := func(workload ImageWorkload, spec *corev1.PodSpec) map[string]*usage {
+		matched := map[string]*usage{}
+		for _, container := range spec.InitContainers {
+			if u := entry(container.Image); u != nil {
+				u.workloads[workload] = true
+				matched[container.Image] = u
+			}
+		}
+		for _, container := range spec.Containers {
+			if u := entry(container.Image); u != nil {
+				// Init stays set only for images that never run as a regular container
+				u.Init = false
+				u.workloads[workload] = true
+				matched[container.Image] = u
+			}
+		}
+		return matched
+	}
+
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		addSpec(ImageWorkload{Kind: "Deployment", Name: d.Name, Namespace: d.Namespace}, &d.Spec.Template.Spec)
+	}
+	for i := range statefulSets.Items {
+		s := &statefulSets.Items[i]
+		addSpec(ImageWorkload{Kind: "StatefulSet", Name: s.Name, Namespace: s.Namespace}, &s.Spec.Template.Spec)
+	}
+	for i := range daemonSets.Items {
+		d := &daemonSets.Items[i]
+		addSpec(ImageWorkload{Kind: "DaemonSet", Name: d.Name, Namespace: d.Namespace}, &d.Spec.Template.Spec)
+	}
+	// Owned ReplicaSets and Jobs share their controller's template; old revisions show up through pods
+	for i := range replicaSets.Items {
+		if rs := &replicaSets.Items[i]; metav1.GetControllerOf(rs) == nil {
+			addSpec(ImageWorkload{Kind: "ReplicaSet", Name: rs.Name, Namespace: rs.Namespace}, &rs.Spec.Template.Spec)
+		}
+	}
+	for i := range jobs.Items {
+		if job := &jobs.Items[i]; metav1.GetControllerOf(job) == nil {
+			addSpec(ImageWorkload{Kind: "Job", Name: job.Name, Namespace: job.Namespace}, &job.Spec.Template.Spec)
+		}
+	}
+	for i := range cronJobs.Items {
+		cj := &cronJobs.Items[i]
+		addSpec(ImageWorkload{Kind: "CronJob", Name: cj.Name, Namespace: cj.Namespace}, &cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	owners := newOwnerResolver(replicaSets.Items, jobs.Items)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		ownerKind, ownerName := owners.topLevelOwner(pod)
+		matched := addSpec(ImageWorkload{Kind: ownerKind, Name: ownerName, Namespace: pod.Namespace}, &pod.Spec)
+		for _, u := range matched {
+			u.Pods++
+		}
+		for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+			if u, ok := matched[specImage(pod, status.Name)]; ok {
+				if digest := imageDigest(status.ImageID); digest != "" {
+					u.digests[digest] = true
+				}
+			}
+		}
+	}
+
+	inventory := &ImageInventory{Namespace: namespace, Filter: filter, Images: make([]ImageUsage, 0, len(images))}
+	for _, u := range images {
+		for workload := range u.workloads {
+			u.Workloads = append(u.Workloads, workload)
+		}
+		sort.Slice(u.Workloads, func(i, j int) bool {
+			a, b := u.Workloads[i], u.Workloads[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			if a.Kind != b.Kind {
+				return a.Kind < b.Kind
+			}
+			return a.Name < b.Name
+		})
+		for digest := range u.digests {
+			u.Digests = append(u.Digests, digest)
+		}
+		sort.Strings(u.Digests)
+		inventory.Images = append(inventory.Images, u.ImageUsage)
+	}
+	// Most used images first
+	sort.Slice(inventory.Images, func(i, j int) bool {
+		a, b := inventory.Images[i], inventory.Images[j]
+		if a.Pods != b.Pods {
+			return a.Pods > b.Pods
+		}
+		return a.Image < b.Image
+	})
+	inventory.TotalImages = len(inventory.Images)
+
+	logrus.WithField("images", inventory.TotalImages).Debug("GetImageInventory succeeded")
+	return inventory, nil
+}
+
+// specImage returns the image a pod spec declares for a container, init containers included
+func specImage(pod *corev1.Pod, container string) string {
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == container {
+			return c.Image
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return c.Image
+		}
+	}
+	return ""
+}
+
+// imageDigest strips the runtime scheme (e.g. docker-pullable://) from a container status imageID
+// and keeps it only when it pins a digest
+func imageDigest(imageID string) string {
+	if _, rest, ok := strings.Cut(imageID, "://"); ok {
+		imageID = rest
+	}
+	if !strings.Contains(imageID, "@sha256:") {
+		return ""
+	}
+	return imageID
+}
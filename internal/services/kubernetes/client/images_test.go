@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetImageInventory(t *testing.T) {
+	controller := true
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate", Image: "registry.example.com/migrate:1"}},
+		Containers:     []corev1.Container{{Name: "app", Image: "nginx:1.27"}},
+	}
+	pod := func(name, namespace, owner string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       podSpec,
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", ImageID: "docker-pullable://nginx@sha256:abc"},
+			}},
+		}
+		if owner != "" {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner, Controller: &controller}}
+		}
+		return p
+	}
+
+	c := &Client{clientset: fake.NewClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: podSpec}},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-5d9", Namespace: "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &controller}},
+		}},
+		// Same ReplicaSet name in another namespace, owned by a different Deployment
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-5d9", Namespace: "blog",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "blog", Controller: &controller}},
+		}},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+			Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "db", Image: "postgres:16"}},
+			}}},
+		},
+		pod("web-5d9-a", "shop", "web-5d9"),
+		pod("web-5d9-b", "shop", "web-5d9"),
+		pod("blog-1", "blog", "web-5d9"),
+		pod("debug", "shop", ""),
+	)}
+
+	inventory, err := c.GetImageInventory(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("GetImageInventory() error = %v", err)
+	}
+	if inventory.TotalImages != 3 {
+		t.Fatalf("expected three distinct images, got %+v", inventory.Images)
+	}
+	nginx := inventory.Images[0]
+	if nginx.Image != "nginx:1.27" || nginx.Pods != 4 || nginx.Init || len(nginx.Digests) != 1 || nginx.Digests[0] != "nginx@sha256:abc" {
+		t.Fatalf("unexpected nginx usage: %+v", nginx)
+	}
+	want := []ImageWorkload{
+		{Kind: "Deployment", Name: "blog", Namespace: "blog"},
+		{Kind: "Deployment", Name: "web", Namespace: "shop"},
+		{Kind: "Pod", Name: "debug", Namespace: "shop"},
+	}
+	if len(nginx.Workloads) != len(want) {
+		t.Fatalf("unexpected nginx workloads: %+v", nginx.Workloads)
+	}
+	for i := range want {
+		if nginx.Workloads[i] != want[i] {
+			t.Fatalf("unexpected nginx workloads: %+v", nginx.Workloads)
+		}
+	}
+	if migrate := inventory.Images[1]; migrate.Image != "registry.example.com/migrate:1" || !migrate.Init || migrate.Pods != 4 {
+		t.Fatalf("unexpected init image usage: %+v", migrate)
+	}
+	if postgres := inventory.Images[2]; postgres.Pods != 0 || len(postgres.Workloads) != 1 || postgres.Workloads[0].Kind != "StatefulSet" {
+		t.Fatalf("expected the scaled-down StatefulSet image to be listed, got %+v", postgres)
+	}
+
+	filtered, err := c.GetImageInventory(context.Background(), "shop", "POSTGRES")
+	if err != nil {
+		t.Fatalf("GetImageInventory() error = %v", err)
+	}
+	if filtered.TotalImages != 1 || filtered.Images[0].Image != "postgres:16" {
+		t.Fatalf("expected only the postgres image, got %+v", filtered.Images)
+	}
+}
@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// IngressBackend describes a Service backend referenced by an Ingress and whether it can serve traffic
+type IngressBackend struct {
+	Service        string `json:"service,omitempty"`
+	Port           string `json:"port,omitempty"`
+	Resource       string `json:"resource,omitempty"`
+	ServiceExists  bool   `json:"serviceExists"`
+	ReadyEndpoints int    `json:"readyEndpoints"`
+}
+
+// IngressPath is one routed path of an Ingress rule
+type IngressPath struct {
+	Path     string         `json:"path"`
+	PathType string         `json:"pathType,omitempty"`
+	Backend  IngressBackend `json:"backend"`
+}
+
+// IngressRule groups the paths routed for a host
+type IngressRule struct {
+	Host  string        `json:"host"`
+	Paths []IngressPath `json:"paths"`
+}
+
+// IngressTLS describes a TLS entry and whether its Secret exists
+type IngressTLS struct {
+	SecretName string   `json:"secretName"`
+	Hosts      []string `json:"hosts,omitempty"`
+	Exists     bool     `json:"exists"`
+}
+
+// DescribeIngress summarizes an Ingress for reachability troubleshooting: its hosts and paths,
+// the backend Services and their ready endpoint counts, referenced TLS Secrets and the load balancer
+// addresses from status. The Ingress API version is resolved via discovery, so both
+// networking.k8s.io/v1 and the older beta versions are supported.
+func (c *Client) DescribeIngress(ctx context.Context, name, namespace string) (map[string]any, error) {
+	logrus.WithFields(logrus.Fields{"name": name, "namespace": namespace}).Debug("DescribeIngress called")
+
+	gvr, err := c.findGroupVersionResource("Ingress")
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.dynamicClient.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingress %s/%s: %w", namespace, name, err)
+	}
+
+	var problems []string
+	backends := map[string]*IngressBackend{}
+	resolveBackend := func(raw map[string]any) IngressBackend {
+		backend := parseIngressBackend(raw)
+		if backend.Service == "" {
+			return backend
+		}
+		if cached, ok := backends[backend.Service]; ok {
+			backend.ServiceExists, backend.ReadyEndpoints = cached.ServiceExists, cached.ReadyEndpoints
+			return backend
+		}
+		var err error
+		backend.ServiceExists, backend.ReadyEndpoints, err = c.serviceReadiness(ctx, namespace, backend.Service)
+		backends[backend.Service] = &backend
+		switch {
+		case err != nil:
+			logrus.WithError(err).Debugf("Failed to check backend service %s", backend.Service)
+		case !backend.ServiceExists:
+			problems = append(problems, fmt.Sprintf("backend service %q does not exist", backend.Service))
+		case backend.ReadyEndpoints == 0:
+			problems = append(problems, fmt.Sprintf("backend service %q has no ready endpoints", backend.Service))
+		}
+		return backend
+	}
+
+	result := map[string]any{
+		"name":       obj.GetName(),
+		"namespace":  obj.GetNamespace(),
+		"apiVersion": obj.GetAPIVersion(),
+	}
+
+	ingressClass, _, _ := unstructured.NestedString(obj.Object, "spec", "ingressClassName")
+	if ingressClass == "" {
+		ingressClass = obj.GetAnnotations()["kubernetes.io/ingress.class"]
+	}
+	if ingressClass != "" {
+		result["ingressClass"] = ingressClass
+	}
+
+	// networking.k8s.io/v1 uses defaultBackend; the beta APIs used backend
+	defaultBackend, found, _ := unstructured.NestedMap(obj.Object, "spec", "defaultBackend")
+	if !found {
+		defaultBackend, found, _ = unstructured.NestedMap(obj.Object, "spec", "backend")
+	}
+	if found {
+		result["defaultBackend"] = resolveBackend(defaultBackend)
+	}
+
+	rawRules, _, _ := unstructured.NestedSlice(obj.Object, "spec", "rules")
+	rules := make([]IngressRule, 0, len(rawRules))
+	for _, item := range rawRules {
+		rawRule, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		host, _, _ := unstructured.NestedString(rawRule, "host")
+		if host == "" {
+			host = "*"
+		}
+		rule := IngressRule{Host: host, Paths: []IngressPath{}}
+		rawPaths, _, _ := unstructured.NestedSlice(rawRule, "http", "paths")
+		for _, p := range rawPaths {
+			rawPath, ok := p.(map[string]any)
+			if !ok {
+				continue
+			}
+			path, _, _ := unstructured.NestedString(rawPath, "path")
+			if path == "" {
+				path = "/"
+			}
+			pathType, _, _ := unstructured.NestedString(rawPath, "pathType")
+			rawBackend, _, _ := unstructured.NestedMap(rawPath, "backend")
+			rule.Paths = append(rule.Paths, IngressPath{Path: path, PathType: pathType, Backend: resolveBackend(rawBackend)})
+		}
+		rules = append(rules, rule)
+	}
+	result["rules"] = rules
+
+	rawTLS, _, _ := unstructured.NestedSlice(obj.Object, "spec", "tls")
+	tls := make([]IngressTLS, 0, len(rawTLS))
+	for _, item := range rawTLS {
+		rawEntry, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		entry := IngressTLS{}
+		entry.SecretName, _, _ = unstructured.NestedString(rawEntry, "secretName")
+		entry.Hosts, _, _ = unstructured.NestedStringSlice(rawEntry, "hosts")
+		if entry.SecretName != "" {
+			secret, err := c.clientset.CoreV1().Secrets(namespace).Get(ctx, entry.SecretName, metav1.GetOptions{})
+			switch {
+			case err == nil:
+				entry.Exists = true
+				if secret.Type != corev1.SecretTypeTLS {
+					problems = append(problems, fmt.Sprintf("TLS secret %q has type %q, expected %q", entry.SecretName, secret.Type, corev1.SecretTypeTLS))
+				}
+			case apierrors.IsNotFound(err):
+				problems = append(problems, fmt.Sprintf("TLS secret %q does not exist", entry.SecretName))
+			default:
+				logrus.WithError(err).Debugf("Failed to check TLS secret %s", entry.SecretName)
+			}
+		}
+		tls = append(tls, entry)
+	}
+	result["tls"] = tls
+
+	addresses := []string{}
+	lbIngress, _, _ := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
+	for _, item := range lbIngress {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if ip, _, _ := unstructured.NestedString(entry, "ip"); ip != "" {
+			addresses = append(addresses, ip)
+		}
+		if hostname, _, _ := unstructured.NestedString(entry, "hostname"); hostname != "" {
+			addresses = append(addresses, hostname)
+		}
+	}
+	result["loadBalancer"] = addresses
+	if len(addresses) == 0 {
+		problems = append(problems, "no load balancer address assigned yet; check that an ingress controller handles this ingress class")
+	}
+
+	if len(problems) > 0 {
+		result["problems"] = problems
+	}
+
+	logrus.WithField("problems", len(problems)).Debug("DescribeIngress succeeded")
+	return result, nil
+}
+
+// parseIngressBackend reads a backend in either the v1 (service.name/service.port) or the
+// beta (serviceName/servicePort) shape
+func parseIngressBackend(raw map[string]any) IngressBackend {
+	backend := IngressBackend{}
+	if raw == nil {
+		return backend
+	}
+	if name, found, _ := unstructured.NestedString(raw, "service", "name"); found {
+		backend.Service = name
+		if number, found, _ := unstructured.NestedInt64(raw, "service", "port", "number"); found {
+			backend.Port = strconv.FormatInt(number, 10)
+		} else {
+			backend.Port, _, _ = unstructured.NestedString(raw, "service", "port", "name")
+		}
+		return backend
+	}
+	if name, found, _ := unstructured.NestedString(raw, "serviceName"); found {
+		backend.Service = name
+		switch port := raw["servicePort"].(type) {
+		case string:
+			backend.Port = port
+		case int64:
+			backend.Port = strconv.FormatInt(port, 10)
+		case float64:
+			backend.Port = strconv.FormatInt(int64(port), 10)
+		}
+		return backend
+	}
+	if kind, found, _ := unstructured.NestedString(raw, "resource", "kind"); found {
+		name, _, _ := unstructured.NestedString(raw, "resource", "name")
+		backend.Resource = kind + "/" + name
+	}
+	return backend
+}
+
+// serviceReadiness reports whether a Service exists and how many ready endpoints its EndpointSlices list.
+// A missing Service is not an error; any other lookup failure is returned.
+func (c *Client) serviceReadiness(ctx context.Context, namespace, name string) (bool, int, error) {
+	if _, err := c.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, 0, nil
+		}
+		return false, 0, err
+	}
+	slices, err := c.clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+	})
+	if err != nil {
+		return true, 0, err
+	}
+	ready := 0
+	for _, endpoint := range endpointsFromSlices(slices.Items) {
+		if endpoint.Ready {
+			ready++
+		}
+	}
+	return true, ready, nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseIngressBackendVersions(t *testing.T) {
+	v1 := parseIngressBackend(map[string]any{
+		"service": map[string]any{"name": "web", "port": map[string]any{"number": int64(80)}},
+	})
+	if v1.Service != "web" || v1.Port != "80" {
+		t.Fatalf("unexpected v1 backend: %+v", v1)
+	}
+
+	beta := parseIngressBackend(map[string]any{"serviceName": "api", "servicePort": "http"})
+	if beta.Service != "api" || beta.Port != "http" {
+		t.Fatalf("unexpected beta backend: %+v", beta)
+	}
+}
+
+func TestDescribeIngressReportsProblems(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+	ingress := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata":   map[string]any{"name": "site", "namespace": "default"},
+		"spec": map[string]any{
+			"ingressClassName": "nginx",
+			"rules": []any{map[string]any{
+				"host": "example.com",
+				"http": map[string]any{"paths": []any{
+					map[string]any{"path": "/", "pathType": "Prefix", "backend": map[string]any{
+						"service": map[string]any{"name": "web", "port": map[string]any{"number": int64(80)}},
+					}},
+					map[string]any{"path": "/api", "pathType": "Prefix", "backend": map[string]any{
+						"service": map[string]any{"name": "api", "port": map[string]any{"name": "http"}},
+					}},
+				}},
+			}},
+			"tls": []any{map[string]any{"secretName": "site-tls", "hosts": []any{"example.com"}}},
+		},
+		"status": map[string]any{"loadBalancer": map[string]any{"ingress": []any{map[string]any{"ip": "203.0.113.10"}}}},
+	}}
+
+	c := &Client{
+		dynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{gvr: "IngressList"}, ingress),
+		clientset:   fake.NewClientset(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}),
+		gvrCache:    map[string]schema.GroupVersionResource{"ingress": gvr},
+		cacheExpiry: time.Now().Add(time.Hour),
+	}
+
+	result, err := c.DescribeIngress(context.Background(), "site", "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rules := result["rules"].([]IngressRule)
+	if len(rules) != 1 || len(rules[0].Paths) != 2 || !rules[0].Paths[0].Backend.ServiceExists {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+	if !reflect.DeepEqual(result["loadBalancer"], []string{"203.0.113.10"}) {
+		t.Fatalf("unexpected load balancer: %v", result["loadBalancer"])
+	}
+	want := []string{
+		`backend service "web" has no ready endpoints`,
+		`backend service "api" does not exist`,
+		`TLS secret "site-tls" does not exist`,
+	}
+	if !reflect.DeepEqual(result["problems"], want) {
+		t.Fatalf("problems = %v, want %v", result["problems"], want)
+	}
+}
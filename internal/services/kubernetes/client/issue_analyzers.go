@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// IssueTarget identifies the resource handed to an issue analyzer
+type IssueTarget struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Resource is the object as returned by GetResource
+	Resource map[string]any
+}
+
+// RootCauseHypothesis is a candidate explanation for an issue. Confidence ranges from 0 to 1
+// and Rank is assigned by AnalyzeIssue after sorting by confidence.
+type RootCauseHypothesis struct {
+	Rank        int      `json:"rank"`
+	Cause       string   `json:"cause"`
+	Confidence  float64  `json:"confidence"`
+	Evidence    []string `json:"evidence,omitempty"`
+	Remediation []string `json:"remediation,omitempty"`
+}
+
+// IssueFindings is the outcome of running an issue analyzer
+type IssueFindings struct {
+	Analysis        []string
+	Recommendations []string
+	Hypotheses      []RootCauseHypothesis
+	Details         map[string]any
+}
+
+// IssueAnalyzer inspects a resource for one issue type. Analyzers are registered per issue type
+// with RegisterIssueAnalyzer and dispatched by AnalyzeIssue.
+type IssueAnalyzer interface {
+	Analyze(ctx context.Context, c *Client, target IssueTarget) (*IssueFindings, error)
+}
+
+// IssueAnalyzerFunc adapts a plain function to the IssueAnalyzer interface
+type IssueAnalyzerFunc func(ctx context.Context, c *Client, target IssueTarget) (*IssueFindings, error)
+
+// Analyze calls f(ctx, c, target)
+func (f IssueAnalyzerFunc) Analyze(ctx context.Context, c *Client, target IssueTarget) (*IssueFindings, error) {
+	return f(ctx, c, target)
+}
+
+var (
+	issueAnalyzersMu sync.RWMutex
+	issueAnalyzers   = map[string]IssueAnalyzer{}
+)
+
+func init() {
+	RegisterIssueAnalyzer("pod_crash", IssueAnalyzerFunc(analyzePodCrash))
+	RegisterIssueAnalyzer("pod_pending", IssueAnalyzerFunc(analyzePodPending))
+	RegisterIssueAnalyzer("deployment_unavailable", IssueAnalyzerFunc(analyzeDeploymentUnavailable))
+	RegisterIssueAnalyzer("job_failed", IssueAnalyzerFunc(analyzeJobFailed))
+	RegisterIssueAnalyzer("service_unreachable", IssueAnalyzerFunc(analyzeServiceUnreachable))
+	RegisterIssueAnalyzer("pvc_pending", IssueAnalyzerFunc(analyzePVCPending))
+	RegisterIssueAnalyzer("pod_evicted", deletedResourceAnalyzer{IssueAnalyzerFunc(analyzePodEvicted)})
+}
+
+// RegisterIssueAnalyzer registers the analyzer for an issue type, replacing any existing one
+func RegisterIssueAnalyzer(issueType string, analyzer IssueAnalyzer) {
+	issueAnalyzersMu.Lock()
+	defer issueAnalyzersMu.Unlock()
+	issueAnalyzers[issueType] = analyzer
+}
+
+// SupportedIssueTypes returns the registered issue types in sorted order
+func SupportedIssueTypes() []string {
+	issueAnalyzersMu.RLock()
+	defer issueAnalyzersMu.RUnlock()
+	types := make([]string, 0, len(issueAnalyzers))
+	for issueType := range issueAnalyzers {
+		types = append(types, issueType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func lookupIssueAnalyzer(issueType string) (IssueAnalyzer, bool) {
+	issueAnalyzersMu.RLock()
+	defer issueAnalyzersMu.RUnlock()
+	analyzer, ok := issueAnalyzers[issueType]
+	return analyzer, ok
+}
+
+// rankHypotheses orders hypotheses from most to least likely and numbers them
+func rankHypotheses(hypotheses []RootCauseHypothesis) []RootCauseHypothesis {
+	sort.SliceStable(hypotheses, func(i, j int) bool {
+		return hypotheses[i].Confidence > hypotheses[j].Confidence
+	})
+	for i := range hypotheses {
+		hypotheses[i].Rank = i + 1
+	}
+	return hypotheses
+}
+
+// remediationSteps flattens the remediation of ranked hypotheses into a de-duplicated list
+func remediationSteps(hypotheses []RootCauseHypothesis) []string {
+	seen := map[string]bool{}
+	var steps []string
+	for _, hypothesis := range hypotheses {
+		for _, step := range hypothesis.Remediation {
+			if !seen[step] {
+				seen[step] = true
+				steps = append(steps, step)
+			}
+		}
+	}
+	return steps
+}
+
+func analyzePodCrash(_ context.Context, _ *Client, target IssueTarget) (*IssueFindings, error) {
+	findings := &IssueFindings{}
+	resource := target.Resource
+
+	// Check for restart loop
+	restartCount := getIntField(resource, "status.containerStatuses.0.restartCount")
+	if restartCount > 5 {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Container has restarted %d times", restartCount))
+		findings.Recommendations = append(findings.Recommendations, "Check container logs for crash reasons: kubectl logs "+target.Name+" --previous")
+		findings.Recommendations = append(findings.Recommendations, "Verify resource limits are sufficient")
+	}
+	waitingReason := getStringField(resource, "status.containerStatuses.0.state.waiting.reason")
+	if waitingReason != "" {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Container is waiting: %s", waitingReason))
+		waitingMessage := getStringField(resource, "status.containerStatuses.0.state.waiting.message")
+		if waitingMessage != "" {
+			findings.Analysis = append(findings.Analysis, fmt.Sprintf("Message: %s", waitingMessage))
+		}
+	}
+	return findings, nil
+}
+
+func analyzePodPending(_ context.Context, _ *Client, target IssueTarget) (*IssueFindings, error) {
+	findings := &IssueFindings{}
+	phase := getStringField(target.Resource, "status.phase")
+	if phase == "Pending" {
+		reason := getStringField(target.Resource, "status.reason")
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Pod is pending: %s", reason))
+		if reason == "Unschedulable" {
+			findings.Recommendations = append(findings.Recommendations, "Check node resource capacity")
+			findings.Recommendations = append(findings.Recommendations, "Verify node taints and tolerations")
+		}
+	}
+	return findings, nil
+}
+
+func analyzeDeploymentUnavailable(_ context.Context, _ *Client, target IssueTarget) (*IssueFindings, error) {
+	findings := &IssueFindings{}
+	available := getIntField(target.Resource, "status.availableReplicas")
+	replicas := getIntField(target.Resource, "spec.replicas")
+	if replicas > 0 && available < replicas {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Deployment has %d/%d replicas available", available, replicas))
+		findings.Recommendations = append(findings.Recommendations, "Check rollout status for deployment")
+		findings.Recommendations = append(findings.Recommendations, "Check events for the deployment")
+	}
+	return findings, nil
+}
+
+func analyzeJobFailed(_ context.Context, _ *Client, target IssueTarget) (*IssueFindings, error) {
+	findings := &IssueFindings{}
+	failed := getIntField(target.Resource, "status.failed")
+	if failed > 0 {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Job has failed %d times", failed))
+		findings.Recommendations = append(findings.Recommendations, "Check job events for failure reason")
+		findings.Recommendations = append(findings.Recommendations, "Verify backoffLimit and restartPolicy")
+	}
+	return findings, nil
+}
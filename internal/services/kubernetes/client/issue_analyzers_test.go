@@ -0,0 +1,351 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRegisterIssueAnalyzer(t *testing.T) {
+	RegisterIssueAnalyzer("test_issue", IssueAnalyzerFunc(func(context.Context, *Client, IssueTarget) (*IssueFindings, error) {
+		return &IssueFindings{}, nil
+	}))
+	defer func() {
+		issueAnalyzersMu.Lock()
+		delete(issueAnalyzers, "test_issue")
+		issueAnalyzersMu.Unlock()
+	}()
+
+	types := strings.Join(SupportedIssueTypes(), ",")
+	for _, want := range []string{"pod_crash", "service_unreachable", "test_issue"} {
+		if !strings.Contains(types, want) {
+			t.Fatalf("SupportedIssueTypes() = %s, missing %s", types, want)
+		}
+	}
+
+	if _, err := (&Client{}).AnalyzeIssue(context.Background(), "bogus", "Pod", "web", "default"); err == nil {
+		t.Fatal("expected an error for an unsupported issue type")
+	}
+}
+
+func TestAnalyzeServiceUnreachableSelectorMismatch(t *testing.T) {
+	clientset := fake.NewClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "web"},
+				Ports:    []corev1.ServicePort{{Port: 80}},
+			},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "webapp"}}},
+	)
+
+	c := &Client{clientset: clientset}
+	findings, err := analyzeServiceUnreachable(context.Background(), c, IssueTarget{Kind: "Service", Name: "web", Namespace: "default"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings.Hypotheses) != 1 || !strings.Contains(findings.Hypotheses[0].Cause, "selector") {
+		t.Fatalf("expected a selector mismatch hypothesis, got %+v", findings.Hypotheses)
+	}
+	if evidence := strings.Join(findings.Hypotheses[0].Evidence, "\n"); !strings.Contains(evidence, "app=webapp (want web)") {
+		t.Fatalf("expected the near-miss pod in the evidence, got %s", evidence)
+	}
+	if len(findings.Recommendations) == 0 {
+		t.Fatal("expected remediation steps")
+	}
+}
+
+func TestAnalyzeServiceUnreachableRanksHypotheses(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	clientset := fake.NewClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromString("http")}},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: labels},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "app",
+				Ports: []corev1.ContainerPort{{Name: "web", ContainerPort: 8080}},
+			}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:         "app",
+					RestartCount: 4,
+					State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				}},
+			},
+		},
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "deny-all", Namespace: "default"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		},
+	)
+
+	c := &Client{clientset: clientset}
+	findings, err := analyzeServiceUnreachable(context.Background(), c, IssueTarget{Kind: "services", Name: "web", Namespace: "default"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var causes []string
+	for i, hypothesis := range findings.Hypotheses {
+		if hypothesis.Rank != i+1 {
+			t.Fatalf("hypothesis %d has rank %d", i, hypothesis.Rank)
+		}
+		causes = append(causes, hypothesis.Cause)
+	}
+	if len(causes) != 3 ||
+		!strings.Contains(causes[0], "No backing pod is ready") ||
+		!strings.Contains(causes[1], "named port \"http\"") ||
+		!strings.Contains(causes[2], "deny-all") {
+		t.Fatalf("unexpected hypotheses order: %v", causes)
+	}
+	if !strings.Contains(strings.Join(findings.Recommendations, "\n"), "kubectl logs web-1 -n default -c app --previous") {
+		t.Fatalf("expected a logs remediation for the restarting container, got %v", findings.Recommendations)
+	}
+	if policies := findings.Details["networkPolicies"].([]string); len(policies) != 1 || policies[0] != "deny-all" {
+		t.Fatalf("unexpected networkPolicies detail: %v", policies)
+	}
+}
+
+func TestAnalyzeServiceUnreachableRejectsOtherKinds(t *testing.T) {
+	c := &Client{clientset: fake.NewClientset()}
+	if _, err := analyzeServiceUnreachable(context.Background(), c, IssueTarget{Kind: "Deployment", Name: "web", Namespace: "default"}); err == nil {
+		t.Fatal("expected an error for a non-Service resource")
+	}
+}
+
+func TestAnalyzePVCPending(t *testing.T) {
+	waitForConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+	className := func(name string) *string { return &name }
+	claim := func(storageClass *string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: storageClass,
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		objects   []runtime.Object
+		wantCause string
+	}{
+		{
+			name:      "missing storage class",
+			objects:   []runtime.Object{claim(className("fast"))},
+			wantCause: "StorageClass fast does not exist",
+		},
+		{
+			name: "no default storage class and no matching volume",
+			objects: []runtime.Object{
+				claim(nil),
+				&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}, Provisioner: "kubernetes.io/no-provisioner"},
+			},
+			wantCause: "no default StorageClass",
+		},
+		{
+			name: "wait for first consumer",
+			objects: []runtime.Object{
+				claim(className("local")),
+				&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "local"}, Provisioner: "kubernetes.io/no-provisioner", VolumeBindingMode: &waitForConsumer},
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+					Spec: corev1.PersistentVolumeSpec{
+						StorageClassName: "local",
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+					},
+					Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeAvailable},
+				},
+			},
+			wantCause: "no pod uses the claim",
+		},
+		{
+			name: "provisioning failed on quota",
+			objects: []runtime.Object{
+				claim(className("ebs")),
+				&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "ebs"}, Provisioner: "ebs.csi.aws.com"},
+				&storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "ebs.csi.aws.com"}},
+				&corev1.Event{
+					ObjectMeta:     metav1.ObjectMeta{Name: "data.1", Namespace: "default"},
+					InvolvedObject: corev1.ObjectReference{Kind: "PersistentVolumeClaim", Name: "data", Namespace: "default"},
+					Reason:         "ProvisioningFailed",
+					Message:        "failed to provision volume: VolumeLimitExceeded: maximum volume quota reached",
+				},
+			},
+			wantCause: "quota is exhausted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{clientset: fake.NewClientset(tt.objects...)}
+			findings, err := analyzePVCPending(context.Background(), c, IssueTarget{Kind: "pvc", Name: "data", Namespace: "default"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(findings.Hypotheses) == 0 || !strings.Contains(findings.Hypotheses[0].Cause, tt.wantCause) {
+				t.Fatalf("expected top hypothesis containing %q, got %+v", tt.wantCause, findings.Hypotheses)
+			}
+			if len(findings.Recommendations) == 0 {
+				t.Fatal("expected next steps")
+			}
+		})
+	}
+}
+
+func TestAnalyzePodEvicted(t *testing.T) {
+	now := metav1.Now()
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Spec:       corev1.NodeSpec{Taints: []corev1.Taint{{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoExecute}}},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, LastTransitionTime: now},
+		}},
+	}
+	podObject := func(status corev1.PodStatus) map[string]any {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "worker-1"},
+			Status:     status,
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+		if err != nil {
+			t.Fatalf("ToUnstructured() error = %v", err)
+		}
+		return obj
+	}
+	event := func(kind, name, reason, message string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name + "." + reason, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name, Namespace: "default"},
+			Reason:         reason,
+			Message:        message,
+			Source:         corev1.EventSource{Host: "worker-1"},
+			LastTimestamp:  now,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		resource     map[string]any
+		objects      []runtime.Object
+		wantCause    string
+		wantEvidence string
+	}{
+		{
+			name: "kubelet memory pressure eviction",
+			resource: podObject(corev1.PodStatus{
+				Phase:    corev1.PodFailed,
+				Reason:   "Evicted",
+				Message:  "The node was low on resource: memory. Threshold quantity: 100Mi, available: 48Mi.",
+				QOSClass: corev1.PodQOSBurstable,
+			}),
+			objects:      []runtime.Object{node, event("Node", "worker-1", "EvictionThresholdMet", "Attempting to reclaim memory")},
+			wantCause:    "ran low on memory",
+			wantEvidence: "EvictionThresholdMet",
+		},
+		{
+			name:         "preempted pod that was deleted",
+			objects:      []runtime.Object{event("Pod", "web-1", "Preempted", "Preempted by pod 1234 on node worker-1")},
+			wantCause:    "preempted",
+			wantEvidence: "Preempted by pod 1234",
+		},
+		{
+			name: "taint manager eviction",
+			resource: podObject(corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{
+				Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: "DeletionByTaintManager", Message: "Taint manager: deleting due to NoExecute taint",
+			}}}),
+			objects:      []runtime.Object{node},
+			wantCause:    "tainted NoExecute",
+			wantEvidence: "node.kubernetes.io/unreachable",
+		},
+		{
+			name:      "deleted pod without eviction events",
+			objects:   []runtime.Object{event("Pod", "web-1", "Killing", "Stopping container app")},
+			wantCause: "deleted directly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{clientset: fake.NewClientset(tt.objects...)}
+			findings, err := analyzePodEvicted(context.Background(), c, IssueTarget{Kind: "Pod", Name: "web-1", Namespace: "default", Resource: tt.resource})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(findings.Hypotheses) == 0 || !strings.Contains(findings.Hypotheses[0].Cause, tt.wantCause) {
+				t.Fatalf("expected top hypothesis containing %q, got %+v", tt.wantCause, findings.Hypotheses)
+			}
+			if evidence := strings.Join(findings.Hypotheses[0].Evidence, "\n"); !strings.Contains(evidence, tt.wantEvidence) {
+				t.Fatalf("expected evidence containing %q, got %s", tt.wantEvidence, evidence)
+			}
+			if tt.resource == nil && findings.Details["deleted"] != true {
+				t.Fatalf("expected a missing pod to be reported as deleted, got %v", findings.Details)
+			}
+			if len(findings.Recommendations) == 0 {
+				t.Fatal("expected next steps")
+			}
+		})
+	}
+}
+
+func TestAnalyzeIssueExplainsDeletedPods(t *testing.T) {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	c := &Client{
+		clientset: fake.NewClientset(&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web-1.preempted", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "default"},
+			Reason:         "Preempted",
+			Message:        "Preempted by pod 1234 on node worker-1",
+		}),
+		dynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{pods: "PodList"}),
+		gvrCache:    map[string]schema.GroupVersionResource{"pod": pods},
+		cacheExpiry: time.Now().Add(time.Hour),
+	}
+
+	result, err := c.AnalyzeIssue(context.Background(), "pod_evicted", "Pod", "web-1", "default")
+	if err != nil {
+		t.Fatalf("AnalyzeIssue() error = %v", err)
+	}
+	if result["error"] != nil || result["resourceDeleted"] != true || result["hypotheses"] == nil {
+		t.Fatalf("expected the deleted pod to be analyzed from its events, got %v", result)
+	}
+
+	result, err = c.AnalyzeIssue(context.Background(), "pod_crash", "Pod", "web-1", "default")
+	if err != nil {
+		t.Fatalf("AnalyzeIssue() error = %v", err)
+	}
+	if result["error"] == nil {
+		t.Fatalf("expected other analyzers to still require the resource, got %v", result)
+	}
+}
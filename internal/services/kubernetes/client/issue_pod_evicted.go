@@ -0,0 +1,381 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Reasons of the DisruptionTarget pod condition (Kubernetes 1.26+) naming who removed a pod
+const (
+	disruptionByKubelet      = "TerminationByKubelet"
+	disruptionByPreemption   = "PreemptionByScheduler"
+	disruptionByEvictionAPI  = "EvictionByEvictionAPI"
+	disruptionByTaintManager = "DeletionByTaintManager"
+
+	// evictionEvidenceLimit bounds the events quoted as evidence per hypothesis
+	evictionEvidenceLimit = 5
+)
+
+var (
+	// Kubelet eviction messages, e.g. "The node was low on resource: memory. Threshold quantity: ..."
+	lowOnResourcePattern = regexp.MustCompile(`low on resource: ([\w.-]+)`)
+	// and "The node had condition: [DiskPressure]."
+	nodeConditionPattern = regexp.MustCompile(`had condition: \[(\w+)\]`)
+)
+
+// nodePressureEventReasons are node events recorded by the kubelet around pressure evictions
+var nodePressureEventReasons = map[string]bool{
+	"EvictionThresholdMet":      true,
+	"NodeHasDiskPressure":       true,
+	"NodeHasInsufficientMemory": true,
+	"NodeHasInsufficientPID":    true,
+	"FreeDiskSpaceFailed":       true,
+	"ImageGCFailed":             true,
+}
+
+// deletedResourceAnalyzer marks an analyzer that can also explain a resource that no longer exists from
+// the events it left behind. AnalyzeIssue hands it a target with a nil Resource when the get is NotFound.
+type deletedResourceAnalyzer struct {
+	IssueAnalyzer
+}
+
+func (deletedResourceAnalyzer) analyzesDeletedResources() bool { return true }
+
+// analyzesDeletedResources reports whether analyzer accepts targets that were not found
+func analyzesDeletedResources(analyzer IssueAnalyzer) bool {
+	a, ok := analyzer.(interface{ analyzesDeletedResources() bool })
+	return ok && a.analyzesDeletedResources()
+}
+
+// analyzePodEvicted explains why a pod was evicted or preempted, or why it vanished. It reads the pod's
+// status reason and message and its DisruptionTarget condition, the pressure conditions and events of its
+// node, and the Evicted, Preempted and taint-manager events recorded for the pod. A pod that was already
+// deleted is explained from its events alone.
+func analyzePodEvicted(ctx context.Context, c *Client, target IssueTarget) (*IssueFindings, error) {
+	switch strings.ToLower(target.Kind) {
+	case "pod", "pods":
+	default:
+		return nil, fmt.Errorf("pod_evicted expects a Pod, got %s", target.Kind)
+	}
+
+	findings := &IssueFindings{Details: map[string]any{}}
+	var pod *corev1.Pod
+	if target.Resource != nil {
+		pod = &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(target.Resource, pod); err != nil {
+			return nil, fmt.Errorf("failed to read pod %s/%s: %w", target.Namespace, target.Name, err)
+		}
+	}
+
+	selector := fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": target.Name}.AsSelector().String()
+	var events []*corev1.Event
+	if target.Namespace == "" {
+		findings.Analysis = append(findings.Analysis, "No namespace given, so the pod's events were not read")
+	} else if listed, _, err := c.listEventsNewestFirst(ctx, target.Namespace, selector, time.Time{}); err != nil {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Could not read events: %v", err))
+	} else {
+		for _, event := range listed {
+			// Field selectors are advisory for some API servers and fakes
+			if event.InvolvedObject.Kind == "Pod" && event.InvolvedObject.Name == target.Name {
+				events = append(events, event)
+			}
+		}
+	}
+
+	nodeName := ""
+	disruption := &corev1.PodCondition{}
+	if pod == nil {
+		findings.Details["deleted"] = true
+		findings.Analysis = append(findings.Analysis, "Pod no longer exists; explaining its removal from the events it left behind")
+		nodeName = nodeFromEvents(events)
+	} else {
+		nodeName = pod.Spec.NodeName
+		findings.Details["phase"] = string(pod.Status.Phase)
+		findings.Details["qosClass"] = string(pod.Status.QOSClass)
+		if pod.Status.Reason != "" {
+			findings.Details["reason"] = pod.Status.Reason
+			findings.Details["message"] = pod.Status.Message
+		}
+		if pod.Spec.Priority != nil {
+			findings.Details["priority"] = *pod.Spec.Priority
+		}
+		if pod.Spec.PriorityClassName != "" {
+			findings.Details["priorityClassName"] = pod.Spec.PriorityClassName
+		}
+		if cond := podCondition(pod, corev1.DisruptionTarget); cond != nil && cond.Status == corev1.ConditionTrue {
+			disruption = cond
+			findings.Details["disruptionTarget"] = map[string]any{
+				"reason":  cond.Reason,
+				"message": cond.Message,
+				"at":      cond.LastTransitionTime.UTC().Format(time.RFC3339),
+			}
+		}
+	}
+	if nodeName != "" {
+		findings.Details["node"] = nodeName
+	}
+
+	byReason := eventsByReason(events)
+	statusMessage := ""
+	if pod != nil {
+		statusMessage = pod.Status.Message
+	}
+
+	switch {
+	case pod != nil && pod.Status.Reason == "Evicted",
+		disruption.Reason == disruptionByKubelet && strings.Contains(disruption.Message, "low on resource"),
+		len(byReason["Evicted"]) > 0:
+		message := statusMessage
+		if message == "" && len(byReason["Evicted"]) > 0 {
+			message = byReason["Evicted"][0].Message
+		}
+		findings.Hypotheses = append(findings.Hypotheses, c.nodePressureHypothesis(ctx, pod, nodeName, message, findings))
+	case disruption.Reason == disruptionByKubelet && strings.Contains(strings.ToLower(disruption.Message+statusMessage), "shutdown"):
+		findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+			Cause:      fmt.Sprintf("Node %s shut down and the kubelet terminated its pods", nodeName),
+			Confidence: 0.9,
+			Evidence:   nonEmpty(disruption.Message, statusMessage),
+			Remediation: []string{
+				"Check why the node was shut down or restarted (maintenance, spot or preemptible instance reclaim)",
+				"Run enough replicas across nodes and add a PodDisruptionBudget so a single node loss does not take the workload down",
+			},
+		})
+	}
+
+	if disruption.Reason == disruptionByPreemption || len(byReason["Preempted"]) > 0 {
+		findings.Hypotheses = append(findings.Hypotheses, preemptionHypothesis(pod, disruption, byReason["Preempted"]))
+	}
+
+	if disruption.Reason == disruptionByTaintManager || len(byReason["TaintManagerEviction"]) > 0 {
+		findings.Hypotheses = append(findings.Hypotheses, c.taintEvictionHypothesis(ctx, nodeName, disruption, byReason["TaintManagerEviction"]))
+	}
+
+	if disruption.Reason == disruptionByEvictionAPI || len(byReason["ScaleDown"]) > 0 {
+		evidence := nonEmpty(disruption.Message)
+		evidence = append(evidence, eventMessages(byReason["ScaleDown"])...)
+		findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+			Cause:      "The pod was evicted through the Eviction API, by a node drain, the cluster autoscaler scaling the node down, or a descheduler",
+			Confidence: 0.8,
+			Evidence:   evidence,
+			Remediation: []string{
+				"Check who drained or scaled down the node: kubectl get events -A --field-selector involvedObject.kind=Node",
+				"Add a PodDisruptionBudget to limit how many replicas voluntary evictions may take at once",
+				"Annotate the pod cluster-autoscaler.kubernetes.io/safe-to-evict=false if it must not be moved by the autoscaler",
+			},
+		})
+	}
+
+	if len(findings.Hypotheses) == 0 {
+		if pod != nil {
+			findings.Analysis = append(findings.Analysis, "The pod shows no sign of eviction or preemption")
+			findings.Recommendations = []string{
+				"Use pod_crash if containers are restarting, or pod_pending if it is not scheduled",
+			}
+			return findings, nil
+		}
+		findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+			Cause:      "The pod was deleted directly, by a user or a controller replacing it, or its events have expired",
+			Confidence: 0.3,
+			Evidence:   []string{fmt.Sprintf("%d events found for the pod, none about eviction or preemption", len(events))},
+			Remediation: []string{
+				"Events are kept for an hour by default; check the API server audit log for the delete call",
+				"Check the owning workload's rollout history: kubectl rollout history deployment/<name>",
+			},
+		})
+	}
+
+	findings.Hypotheses = rankHypotheses(findings.Hypotheses)
+	findings.Recommendations = remediationSteps(findings.Hypotheses)
+	for _, hypothesis := range findings.Hypotheses {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Possible cause (confidence %.2f): %s", hypothesis.Confidence, hypothesis.Cause))
+	}
+	return findings, nil
+}
+
+// nodePressureHypothesis explains a kubelet eviction: which resource the node ran low on, the node's
+// pressure conditions and the kubelet's pressure events
+func (c *Client) nodePressureHypothesis(ctx context.Context, pod *corev1.Pod, nodeName, message string, findings *IssueFindings) RootCauseHypothesis {
+	resource := ""
+	if m := lowOnResourcePattern.FindStringSubmatch(message); m != nil {
+		resource = m[1]
+	} else if m := nodeConditionPattern.FindStringSubmatch(message); m != nil {
+		resource = m[1]
+	}
+
+	hypothesis := RootCauseHypothesis{
+		Cause:      fmt.Sprintf("The kubelet evicted the pod because node %s was under resource pressure", nodeName),
+		Confidence: 0.95,
+		Evidence:   nonEmpty(message),
+	}
+	if resource != "" {
+		hypothesis.Cause = fmt.Sprintf("The kubelet evicted the pod because node %s ran low on %s", nodeName, resource)
+		findings.Details["pressureResource"] = resource
+	}
+	if pod != nil && pod.Status.QOSClass != corev1.PodQOSGuaranteed && pod.Status.QOSClass != "" {
+		hypothesis.Evidence = append(hypothesis.Evidence, fmt.Sprintf("QoS class %s: pods using more than they request are evicted first", pod.Status.QOSClass))
+	}
+
+	if nodeName != "" {
+		if node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err == nil {
+			var conditions []string
+			for _, cond := range node.Status.Conditions {
+				switch cond.Type {
+				case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+					conditions = append(conditions, fmt.Sprintf("%s=%s since %s", cond.Type, cond.Status, cond.LastTransitionTime.UTC().Format(time.RFC3339)))
+				}
+			}
+			if len(conditions) > 0 {
+				findings.Details["nodeConditions"] = conditions
+				hypothesis.Evidence = append(hypothesis.Evidence, "node conditions now: "+strings.Join(conditions, ", "))
+			}
+		}
+		hypothesis.Evidence = append(hypothesis.Evidence, c.nodePressureEvents(ctx, nodeName)...)
+	}
+
+	switch strings.ToLower(resource) {
+	case "memory", "memorypressure":
+		hypothesis.Remediation = append(hypothesis.Remediation,
+			"Set memory requests close to actual usage (or requests equal to limits for Guaranteed QoS) so the pod is not ranked first for eviction")
+	case "ephemeral-storage", "nodefs.available", "imagefs.available", "diskpressure":
+		hypothesis.Remediation = append(hypothesis.Remediation,
+			"Set ephemeral-storage requests and limits, and check for containers writing large logs or files to their writable layer or emptyDir",
+			"Free disk space on the node: unused images and old container logs")
+	case "pids", "pidpressure":
+		hypothesis.Remediation = append(hypothesis.Remediation, "Find the container leaking processes or threads and set a pod PID limit")
+	}
+	hypothesis.Remediation = append(hypothesis.Remediation,
+		fmt.Sprintf("kubectl describe node %s", nodeName),
+		"Evicted pods are kept as Failed until cleaned up: kubectl delete pods --field-selector=status.phase==Failed -n <namespace>")
+	return hypothesis
+}
+
+// nodePressureEvents returns the kubelet's recent pressure events for a node, newest first
+func (c *Client) nodePressureEvents(ctx context.Context, nodeName string) []string {
+	selector := fields.Set{"involvedObject.kind": "Node", "involvedObject.name": nodeName}.AsSelector().String()
+	events, _, err := c.listEventsNewestFirst(ctx, "", selector, time.Time{})
+	if err != nil {
+		return nil
+	}
+	var evidence []string
+	for _, event := range events {
+		if event.InvolvedObject.Kind != "Node" || event.InvolvedObject.Name != nodeName || !nodePressureEventReasons[event.Reason] {
+			continue
+		}
+		evidence = append(evidence, fmt.Sprintf("node event %s at %s: %s", event.Reason, eventLastSeen(event).UTC().Format(time.RFC3339), event.Message))
+		if len(evidence) == evictionEvidenceLimit {
+			break
+		}
+	}
+	return evidence
+}
+
+// preemptionHypothesis explains a pod removed by the scheduler to make room for a higher priority pod
+func preemptionHypothesis(pod *corev1.Pod, disruption *corev1.PodCondition, preempted []*corev1.Event) RootCauseHypothesis {
+	evidence := nonEmpty(disruption.Message)
+	evidence = append(evidence, eventMessages(preempted)...)
+	if pod != nil {
+		priority := int32(0)
+		if pod.Spec.Priority != nil {
+			priority = *pod.Spec.Priority
+		}
+		className := pod.Spec.PriorityClassName
+		if className == "" {
+			className = "none"
+		}
+		evidence = append(evidence, fmt.Sprintf("pod priority %d (priorityClassName: %s)", priority, className))
+	}
+	return RootCauseHypothesis{
+		Cause:      "The scheduler preempted the pod to make room for a higher priority pod",
+		Confidence: 0.9,
+		Evidence:   evidence,
+		Remediation: []string{
+			"kubectl get priorityclasses to compare this pod's priority with the preemptor's",
+			"Give the workload a higher PriorityClass, or set preemptionPolicy: Never on the classes that should not preempt it",
+			"Add node capacity so higher priority pods fit without preempting",
+		},
+	}
+}
+
+// taintEvictionHypothesis explains a pod deleted by the taint manager after its node got a NoExecute taint
+func (c *Client) taintEvictionHypothesis(ctx context.Context, nodeName string, disruption *corev1.PodCondition, events []*corev1.Event) RootCauseHypothesis {
+	evidence := nonEmpty(disruption.Message)
+	evidence = append(evidence, eventMessages(events)...)
+	if nodeName != "" {
+		if node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err == nil {
+			for _, taint := range node.Spec.Taints {
+				if taint.Effect == corev1.TaintEffectNoExecute {
+					evidence = append(evidence, fmt.Sprintf("node %s has taint %s:%s", nodeName, taint.Key, taint.Effect))
+				}
+			}
+		}
+	}
+	return RootCauseHypothesis{
+		Cause:      fmt.Sprintf("Node %s was tainted NoExecute (usually not-ready or unreachable) and the pod did not tolerate it", nodeName),
+		Confidence: 0.85,
+		Evidence:   evidence,
+		Remediation: []string{
+			fmt.Sprintf("kubectl describe node %s and check why it became not ready or unreachable", nodeName),
+			"Raise tolerationSeconds for node.kubernetes.io/not-ready and node.kubernetes.io/unreachable if brief node outages should not move the pod",
+		},
+	}
+}
+
+func podCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func eventsByReason(events []*corev1.Event) map[string][]*corev1.Event {
+	byReason := map[string][]*corev1.Event{}
+	for _, event := range events {
+		byReason[event.Reason] = append(byReason[event.Reason], event)
+	}
+	return byReason
+}
+
+// eventMessages quotes up to evictionEvidenceLimit events with their time
+func eventMessages(events []*corev1.Event) []string {
+	var messages []string
+	for _, event := range events {
+		messages = append(messages, fmt.Sprintf("event %s at %s: %s", event.Reason, eventLastSeen(event).UTC().Format(time.RFC3339), event.Message))
+		if len(messages) == evictionEvidenceLimit {
+			break
+		}
+	}
+	return messages
+}
+
+// nodeFromEvents returns the node a deleted pod ran on, as reported by the kubelet in its events
+func nodeFromEvents(events []*corev1.Event) string {
+	for _, event := range events {
+		if event.Source.Host != "" {
+			return event.Source.Host
+		}
+		if event.ReportingInstance != "" && event.ReportingController == "kubelet" {
+			return event.ReportingInstance
+		}
+	}
+	return ""
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,416 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// pvcEventScanLimit bounds the namespace events scanned for PVC provisioning messages
+	pvcEventScanLimit = 500
+
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+	noProvisioner                     = "kubernetes.io/no-provisioner"
+)
+
+// analyzePVCPending explains why a PersistentVolumeClaim has not been bound. It checks the requested
+// StorageClass and its provisioner, the default StorageClass, WaitForFirstConsumer binding, matching
+// PersistentVolumes for static provisioning, storage quota and the claim's provisioning events.
+func analyzePVCPending(ctx context.Context, c *Client, target IssueTarget) (*IssueFindings, error) {
+	switch strings.ToLower(target.Kind) {
+	case "persistentvolumeclaim", "persistentvolumeclaims", "pvc":
+	default:
+		return nil, fmt.Errorf("pvc_pending expects a PersistentVolumeClaim, got %s", target.Kind)
+	}
+
+	namespace, name := target.Namespace, target.Name
+	pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persistentvolumeclaim %s/%s: %w", namespace, name, err)
+	}
+
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	findings := &IssueFindings{Details: map[string]any{
+		"phase":       string(pvc.Status.Phase),
+		"requested":   requested.String(),
+		"accessModes": pvc.Spec.AccessModes,
+	}}
+	if pvc.Spec.StorageClassName != nil {
+		findings.Details["storageClass"] = *pvc.Spec.StorageClassName
+	}
+
+	if pvc.Status.Phase == corev1.ClaimBound {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("PVC is bound to volume %s", pvc.Spec.VolumeName))
+		return findings, nil
+	}
+	findings.Analysis = append(findings.Analysis, fmt.Sprintf("PVC is %s and requests %s", pvc.Status.Phase, requested.String()))
+
+	classes, err := c.clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Could not list StorageClasses: %v", err))
+		classes = &storagev1.StorageClassList{}
+	}
+	defaultClass := defaultStorageClass(classes.Items)
+
+	// A claim needs a matching pre-created PV when it opts out of dynamic provisioning
+	static := false
+	switch {
+	case pvc.Spec.StorageClassName == nil && defaultClass == nil:
+		static = true
+		findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+			Cause:      "The claim names no StorageClass and the cluster has no default StorageClass",
+			Confidence: 0.9,
+			Evidence:   []string{"spec.storageClassName is unset", fmt.Sprintf("%d StorageClasses exist, none annotated %s=true", len(classes.Items), defaultStorageClassAnnotation)},
+			Remediation: []string{
+				"kubectl get storageclass",
+				fmt.Sprintf(`kubectl patch storageclass <name> -p '{"metadata":{"annotations":{"%s":"true"}}}'`, defaultStorageClassAnnotation),
+				"Or recreate the claim with spec.storageClassName set; the field cannot be changed on an existing claim",
+			},
+		})
+	case pvc.Spec.StorageClassName == nil:
+		findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+			Cause:      fmt.Sprintf("The claim was created before %s became the default StorageClass", defaultClass.Name),
+			Confidence: 0.5,
+			Evidence:   []string{"spec.storageClassName is unset although a default StorageClass now exists"},
+			Remediation: []string{
+				"Clusters older than 1.28 do not assign the default class retroactively; recreate the claim so it picks up the default",
+			},
+		})
+	case *pvc.Spec.StorageClassName == "":
+		static = true
+		findings.Analysis = append(findings.Analysis, "storageClassName is empty, so only pre-created PersistentVolumes without a class can bind")
+	default:
+		className := *pvc.Spec.StorageClassName
+		class := findStorageClass(classes.Items, className)
+		if class == nil {
+			findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+				Cause:      fmt.Sprintf("StorageClass %s does not exist", className),
+				Confidence: 0.95,
+				Evidence:   []string{fmt.Sprintf("available StorageClasses: %s", strings.Join(storageClassNames(classes.Items), ", "))},
+				Remediation: []string{
+					"kubectl get storageclass",
+					fmt.Sprintf("Create StorageClass %s or recreate the claim with an existing class", className),
+				},
+			})
+			break
+		}
+
+		findings.Details["provisioner"] = class.Provisioner
+		if class.VolumeBindingMode != nil {
+			findings.Details["volumeBindingMode"] = string(*class.VolumeBindingMode)
+		}
+		if class.Provisioner == noProvisioner {
+			static = true
+			findings.Analysis = append(findings.Analysis, fmt.Sprintf("StorageClass %s has no provisioner; volumes must be created manually", className))
+		} else if hypothesis, ok := c.provisionerHypothesis(ctx, class); ok {
+			findings.Hypotheses = append(findings.Hypotheses, hypothesis)
+		}
+		if class.VolumeBindingMode != nil && *class.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+			findings.Hypotheses = append(findings.Hypotheses, c.waitForFirstConsumerHypothesis(ctx, pvc))
+		}
+	}
+
+	if static {
+		if hypothesis, ok := c.staticBindingHypothesis(ctx, pvc); ok {
+			findings.Hypotheses = append(findings.Hypotheses, hypothesis)
+		}
+	}
+
+	events, err := c.GetResourceEvents(ctx, "PersistentVolumeClaim", name, namespace, pvcEventScanLimit, "")
+	if err != nil {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Could not read events: %v", err))
+	} else {
+		findings.Hypotheses = append(findings.Hypotheses, pvcEventHypotheses(events)...)
+	}
+
+	if hypothesis, ok := c.storageQuotaHypothesis(ctx, pvc); ok {
+		findings.Hypotheses = append(findings.Hypotheses, hypothesis)
+	}
+
+	if len(findings.Hypotheses) == 0 {
+		findings.Recommendations = []string{
+			fmt.Sprintf("kubectl describe pvc %s -n %s", name, namespace),
+			"Check the provisioner controller logs for this claim",
+		}
+		return findings, nil
+	}
+
+	findings.Hypotheses = rankHypotheses(findings.Hypotheses)
+	findings.Recommendations = remediationSteps(findings.Hypotheses)
+	for _, hypothesis := range findings.Hypotheses {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Possible cause (confidence %.2f): %s", hypothesis.Confidence, hypothesis.Cause))
+	}
+	return findings, nil
+}
+
+// defaultStorageClass returns the StorageClass annotated as default, if any
+func defaultStorageClass(classes []storagev1.StorageClass) *storagev1.StorageClass {
+	for i := range classes {
+		annotations := classes[i].Annotations
+		if annotations[defaultStorageClassAnnotation] == "true" || annotations[betaDefaultStorageClassAnnotation] == "true" {
+			return &classes[i]
+		}
+	}
+	return nil
+}
+
+func findStorageClass(classes []storagev1.StorageClass, name string) *storagev1.StorageClass {
+	for i := range classes {
+		if classes[i].Name == name {
+			return &classes[i]
+		}
+	}
+	return nil
+}
+
+func storageClassNames(classes []storagev1.StorageClass) []string {
+	names := make([]string, 0, len(classes))
+	for _, class := range classes {
+		names = append(names, class.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// provisionerHypothesis flags CSI provisioners that are not registered with the cluster. In-tree
+// provisioners are skipped, and out-of-tree provisioners that do not use CSI only weigh lightly.
+func (c *Client) provisionerHypothesis(ctx context.Context, class *storagev1.StorageClass) (RootCauseHypothesis, bool) {
+	if strings.HasPrefix(class.Provisioner, "kubernetes.io/") {
+		return RootCauseHypothesis{}, false
+	}
+	if _, err := c.clientset.StorageV1().CSIDrivers().Get(ctx, class.Provisioner, metav1.GetOptions{}); err == nil {
+		return RootCauseHypothesis{}, false
+	}
+	return RootCauseHypothesis{
+		Cause:      fmt.Sprintf("Provisioner %s of StorageClass %s is not registered as a CSIDriver", class.Provisioner, class.Name),
+		Confidence: 0.6,
+		Evidence:   []string{"no CSIDriver object named " + class.Provisioner},
+		Remediation: []string{
+			"kubectl get csidrivers",
+			fmt.Sprintf("Check that the %s controller pods are installed and running: kubectl get pods -A | grep -i %s", class.Provisioner, provisionerSearchTerm(class.Provisioner)),
+		},
+	}, true
+}
+
+// provisionerSearchTerm returns the most specific part of a provisioner name to grep for
+func provisionerSearchTerm(provisioner string) string {
+	term := provisioner
+	if i := strings.LastIndex(term, "/"); i >= 0 {
+		term = term[i+1:]
+	}
+	if i := strings.Index(term, "."); i > 0 {
+		term = term[:i]
+	}
+	return term
+}
+
+// waitForFirstConsumerHypothesis explains delayed binding: the volume is only provisioned once a pod
+// using the claim is scheduled
+func (c *Client) waitForFirstConsumerHypothesis(ctx context.Context, pvc *corev1.PersistentVolumeClaim) RootCauseHypothesis {
+	var consumers []string
+	pods, err := c.clientset.CoreV1().Pods(pvc.Namespace).List(ctx, metav1.ListOptions{})
+	if err == nil {
+		for _, pod := range pods.Items {
+			for _, volume := range pod.Spec.Volumes {
+				if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc.Name {
+					consumers = append(consumers, fmt.Sprintf("%s (%s)", pod.Name, pod.Status.Phase))
+				}
+			}
+		}
+	}
+
+	if len(consumers) == 0 {
+		return RootCauseHypothesis{
+			Cause:      "The StorageClass uses WaitForFirstConsumer and no pod uses the claim yet",
+			Confidence: 0.85,
+			Evidence:   []string{"volumeBindingMode is WaitForFirstConsumer", "no pod in the namespace mounts the claim"},
+			Remediation: []string{
+				"This is expected: the volume is provisioned when a pod referencing the claim is scheduled",
+				fmt.Sprintf("Deploy the workload that mounts claimName %s", pvc.Name),
+			},
+		}
+	}
+	return RootCauseHypothesis{
+		Cause:      "The StorageClass uses WaitForFirstConsumer and the consuming pod has not been scheduled",
+		Confidence: 0.6,
+		Evidence:   append([]string{"volumeBindingMode is WaitForFirstConsumer", "pods using the claim:"}, consumers...),
+		Remediation: []string{
+			fmt.Sprintf("kubectl describe pod <consumer> -n %s and check the FailedScheduling events", pvc.Namespace),
+			"Check that the storage topology (allowedTopologies, zones) includes nodes the pod can run on",
+		},
+	}
+}
+
+// staticBindingHypothesis looks for an Available PersistentVolume that satisfies the claim
+func (c *Client) staticBindingHypothesis(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (RootCauseHypothesis, bool) {
+	volumes, err := c.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return RootCauseHypothesis{}, false
+	}
+
+	className := ""
+	if pvc.Spec.StorageClassName != nil {
+		className = *pvc.Spec.StorageClassName
+	}
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+
+	available := 0
+	var rejected []string
+	for _, pv := range volumes.Items {
+		if pv.Status.Phase != corev1.VolumeAvailable || pv.Spec.StorageClassName != className {
+			continue
+		}
+		available++
+		if reason := pvMismatch(&pv, pvc, requested); reason != "" {
+			rejected = append(rejected, fmt.Sprintf("PV %s: %s", pv.Name, reason))
+			continue
+		}
+		// A matching volume exists, so binding is only waiting on the controller
+		return RootCauseHypothesis{}, false
+	}
+
+	evidence := []string{fmt.Sprintf("%d Available PersistentVolumes with storageClassName %q", available, className)}
+	if len(rejected) > 5 {
+		rejected = rejected[:5]
+	}
+	evidence = append(evidence, rejected...)
+	return RootCauseHypothesis{
+		Cause:      "No Available PersistentVolume satisfies the claim",
+		Confidence: 0.8,
+		Evidence:   evidence,
+		Remediation: []string{
+			"kubectl get pv",
+			fmt.Sprintf("Create a PersistentVolume with storageClassName %q, capacity of at least %s and access modes %v", className, requested.String(), pvc.Spec.AccessModes),
+		},
+	}, true
+}
+
+// pvMismatch returns why a volume cannot bind to the claim, or an empty string when it can
+func pvMismatch(pv *corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim, requested resource.Quantity) string {
+	if pv.Spec.ClaimRef != nil && (pv.Spec.ClaimRef.Name != pvc.Name || pv.Spec.ClaimRef.Namespace != pvc.Namespace) {
+		return fmt.Sprintf("reserved for %s/%s", pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name)
+	}
+	capacity := pv.Spec.Capacity[corev1.ResourceStorage]
+	if capacity.Cmp(requested) < 0 {
+		return fmt.Sprintf("capacity %s is smaller than the requested %s", capacity.String(), requested.String())
+	}
+	for _, mode := range pvc.Spec.AccessModes {
+		found := false
+		for _, offered := range pv.Spec.AccessModes {
+			found = found || offered == mode
+		}
+		if !found {
+			return fmt.Sprintf("access modes %v do not include %s", pv.Spec.AccessModes, mode)
+		}
+	}
+	pvMode, pvcMode := corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeFilesystem
+	if pv.Spec.VolumeMode != nil {
+		pvMode = *pv.Spec.VolumeMode
+	}
+	if pvc.Spec.VolumeMode != nil {
+		pvcMode = *pvc.Spec.VolumeMode
+	}
+	if pvMode != pvcMode {
+		return fmt.Sprintf("volumeMode %s does not match %s", pvMode, pvcMode)
+	}
+	if pvc.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(pvc.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(pv.Labels)) {
+			return "labels do not match the claim's selector"
+		}
+	}
+	return ""
+}
+
+// pvcEventHypotheses turns provisioning failures reported on the claim into hypotheses
+func pvcEventHypotheses(events map[string]interface{}) []RootCauseHypothesis {
+	list, _ := events["events"].([]map[string]interface{})
+	var failures, quota []string
+	for _, event := range list {
+		reason, _ := event["reason"].(string)
+		message, _ := event["message"].(string)
+		if reason != "ProvisioningFailed" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(message), "quota") {
+			quota = append(quota, message)
+		} else {
+			failures = append(failures, message)
+		}
+	}
+
+	var hypotheses []RootCauseHypothesis
+	if len(quota) > 0 {
+		hypotheses = append(hypotheses, RootCauseHypothesis{
+			Cause:      "The storage backend rejected provisioning because a quota is exhausted",
+			Confidence: 0.9,
+			Evidence:   quota,
+			Remediation: []string{
+				"Raise the storage quota with the cloud provider or storage backend, or free unused volumes",
+				"kubectl get pv and delete Released volumes that are no longer needed",
+			},
+		})
+	}
+	if len(failures) > 0 {
+		hypotheses = append(hypotheses, RootCauseHypothesis{
+			Cause:      "The provisioner failed to create the volume",
+			Confidence: 0.85,
+			Evidence:   failures,
+			Remediation: []string{
+				"Fix the error reported by the provisioner; the claim is retried automatically",
+				"Check the StorageClass parameters and the provisioner controller logs",
+			},
+		})
+	}
+	return hypotheses
+}
+
+// storageQuotaHypothesis reports ResourceQuotas in the namespace whose storage limits are used up
+func (c *Client) storageQuotaHypothesis(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (RootCauseHypothesis, bool) {
+	quotas, err := c.clientset.CoreV1().ResourceQuotas(pvc.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return RootCauseHypothesis{}, false
+	}
+
+	var evidence []string
+	for _, quota := range quotas.Items {
+		for name, hard := range quota.Status.Hard {
+			if !isStorageQuotaResource(name) {
+				continue
+			}
+			used := quota.Status.Used[name]
+			if used.Cmp(hard) >= 0 {
+				evidence = append(evidence, fmt.Sprintf("ResourceQuota %s: %s used %s of %s", quota.Name, name, used.String(), hard.String()))
+			}
+		}
+	}
+	if len(evidence) == 0 {
+		return RootCauseHypothesis{}, false
+	}
+	sort.Strings(evidence)
+	return RootCauseHypothesis{
+		Cause:      "Namespace storage quota is exhausted",
+		Confidence: 0.4,
+		Evidence:   evidence,
+		Remediation: []string{
+			fmt.Sprintf("kubectl describe resourcequota -n %s", pvc.Namespace),
+			"Delete unused claims or raise the quota's storage limits",
+		},
+	}, true
+}
+
+func isStorageQuotaResource(name corev1.ResourceName) bool {
+	return name == corev1.ResourceRequestsStorage ||
+		name == corev1.ResourcePersistentVolumeClaims ||
+		strings.HasSuffix(string(name), ".storageclass.storage.k8s.io/requests.storage") ||
+		strings.HasSuffix(string(name), ".storageclass.storage.k8s.io/persistentvolumeclaims")
+}
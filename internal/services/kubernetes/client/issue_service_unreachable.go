@@ -0,0 +1,444 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// analyzeServiceUnreachable explains why a Service does not route traffic. It checks whether the
+// selector matches any pods, whether those pods are ready and registered as endpoints, whether
+// the target ports exist on the containers and whether a NetworkPolicy restricts ingress to them.
+func analyzeServiceUnreachable(ctx context.Context, c *Client, target IssueTarget) (*IssueFindings, error) {
+	if kind := normalizeKind(target.Kind); kind != "Service" && kind != "Svc" {
+		return nil, fmt.Errorf("service_unreachable expects a Service, got %s", target.Kind)
+	}
+
+	namespace, name := target.Namespace, target.Name
+	svc, err := c.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s/%s: %w", namespace, name, err)
+	}
+
+	findings := &IssueFindings{Details: map[string]any{
+		"serviceType": string(svc.Spec.Type),
+		"selector":    svc.Spec.Selector,
+	}}
+
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("ExternalName service resolves via DNS to %s and has no endpoints", svc.Spec.ExternalName))
+		findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+			Cause:      fmt.Sprintf("The external name %s does not resolve or is not reachable from the cluster", svc.Spec.ExternalName),
+			Confidence: 0.6,
+			Evidence:   []string{"ExternalName services are answered with a CNAME and never proxied to pods"},
+			Remediation: []string{
+				fmt.Sprintf("kubectl run dns-check -n %s --rm -it --restart=Never --image=busybox -- nslookup %s", namespace, svc.Spec.ExternalName),
+			},
+		})
+		findings.Hypotheses = rankHypotheses(findings.Hypotheses)
+		findings.Recommendations = remediationSteps(findings.Hypotheses)
+		return findings, nil
+	}
+
+	endpoints, source, err := c.serviceEndpoints(ctx, name, namespace)
+	if err != nil {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Could not read endpoints: %v", err))
+	}
+	readyEndpoints := 0
+	for _, ep := range endpoints {
+		if ep.Ready {
+			readyEndpoints++
+		}
+	}
+	findings.Details["endpointSource"] = source
+	findings.Details["readyEndpoints"] = readyEndpoints
+	findings.Details["notReadyEndpoints"] = len(endpoints) - readyEndpoints
+	findings.Analysis = append(findings.Analysis, fmt.Sprintf("Service has %d ready and %d not ready endpoints", readyEndpoints, len(endpoints)-readyEndpoints))
+
+	var backingPods []corev1.Pod
+	if len(svc.Spec.Selector) == 0 {
+		if len(endpoints) == 0 {
+			findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+				Cause:      "Service has no selector and no manually managed endpoints",
+				Confidence: 0.9,
+				Evidence:   []string{"spec.selector is empty", "no EndpointSlice or Endpoints addresses exist for the service"},
+				Remediation: []string{
+					fmt.Sprintf(`kubectl patch service %s -n %s -p '{"spec":{"selector":{"app":"<pod-label>"}}}'`, name, namespace),
+					"Or create an EndpointSlice labeled kubernetes.io/service-name=" + name + " pointing at the external backends",
+				},
+			})
+		}
+	} else {
+		pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			findings.Analysis = append(findings.Analysis, fmt.Sprintf("Could not list pods: %v", err))
+		} else {
+			var nearMisses []string
+			backingPods, nearMisses = matchServicePods(svc.Spec.Selector, pods.Items)
+			findings.Details["matchingPods"] = len(backingPods)
+			if len(backingPods) == 0 {
+				findings.Hypotheses = append(findings.Hypotheses, selectorMismatchHypothesis(svc, nearMisses))
+			}
+		}
+	}
+
+	if len(backingPods) > 0 {
+		if hypothesis, ok := podHealthHypothesis(backingPods, readyEndpoints); ok {
+			findings.Hypotheses = append(findings.Hypotheses, hypothesis)
+		} else if readyEndpoints == 0 {
+			findings.Hypotheses = append(findings.Hypotheses, RootCauseHypothesis{
+				Cause:      "Ready pods are not registered as ready endpoints",
+				Confidence: 0.5,
+				Evidence:   []string{fmt.Sprintf("%d pods match the selector and are ready, but no endpoint is ready", len(backingPods))},
+				Remediation: []string{
+					fmt.Sprintf("kubectl get endpointslices -n %s -l kubernetes.io/service-name=%s -o yaml", namespace, name),
+					"Check kube-controller-manager logs for endpoint controller errors",
+				},
+			})
+		}
+		findings.Hypotheses = append(findings.Hypotheses, targetPortHypotheses(svc, backingPods)...)
+
+		policies, err := c.clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			findings.Analysis = append(findings.Analysis, fmt.Sprintf("Could not list NetworkPolicies: %v", err))
+		} else {
+			policyHypotheses, selecting := networkPolicyHypotheses(svc, backingPods, policies.Items)
+			findings.Hypotheses = append(findings.Hypotheses, policyHypotheses...)
+			findings.Details["networkPolicies"] = selecting
+		}
+	}
+
+	if len(findings.Hypotheses) == 0 {
+		findings.Analysis = append(findings.Analysis, "Selector, endpoints, target ports and NetworkPolicies look correct")
+		findings.Recommendations = []string{
+			fmt.Sprintf("kubectl run svc-check -n %s --rm -it --restart=Never --image=busybox -- wget -qO- -T 5 http://%s.%s.svc:%d", namespace, name, namespace, firstServicePort(svc)),
+			"If the in-cluster request succeeds, check the client's DNS name, namespace and any egress NetworkPolicy on the client side",
+			"If it fails, check kube-proxy or the CNI plugin on the nodes running the backing pods",
+		}
+		return findings, nil
+	}
+
+	findings.Hypotheses = rankHypotheses(findings.Hypotheses)
+	findings.Recommendations = remediationSteps(findings.Hypotheses)
+	for _, hypothesis := range findings.Hypotheses {
+		findings.Analysis = append(findings.Analysis, fmt.Sprintf("Possible cause (confidence %.2f): %s", hypothesis.Confidence, hypothesis.Cause))
+	}
+	return findings, nil
+}
+
+// matchServicePods returns the pods selected by the Service and describes pods that match only part
+// of the selector, which usually indicates a label typo
+func matchServicePods(selector map[string]string, pods []corev1.Pod) ([]corev1.Pod, []string) {
+	sel := labels.SelectorFromSet(selector)
+	var matching []corev1.Pod
+	var nearMisses []string
+	for _, pod := range pods {
+		if sel.Matches(labels.Set(pod.Labels)) {
+			matching = append(matching, pod)
+			continue
+		}
+		// Pods carrying any of the selector's keys are reported with the labels that differ
+		var mismatched []string
+		present := false
+		for key, want := range selector {
+			got, ok := pod.Labels[key]
+			present = present || ok
+			switch {
+			case !ok:
+				mismatched = append(mismatched, fmt.Sprintf("%s missing", key))
+			case got != want:
+				mismatched = append(mismatched, fmt.Sprintf("%s=%s (want %s)", key, got, want))
+			}
+		}
+		if present {
+			sort.Strings(mismatched)
+			nearMisses = append(nearMisses, fmt.Sprintf("pod %s: %s", pod.Name, strings.Join(mismatched, ", ")))
+		}
+	}
+	sort.Strings(nearMisses)
+	return matching, nearMisses
+}
+
+func selectorMismatchHypothesis(svc *corev1.Service, nearMisses []string) RootCauseHypothesis {
+	evidence := []string{fmt.Sprintf("selector %s matches no pods in namespace %s", labels.SelectorFromSet(svc.Spec.Selector), svc.Namespace)}
+	if len(nearMisses) > 5 {
+		nearMisses = nearMisses[:5]
+	}
+	evidence = append(evidence, nearMisses...)
+	return RootCauseHypothesis{
+		Cause:      "Service selector does not match any pod labels",
+		Confidence: 0.95,
+		Evidence:   evidence,
+		Remediation: []string{
+			fmt.Sprintf("kubectl get pods -n %s --show-labels", svc.Namespace),
+			fmt.Sprintf("Align the selector with the workload's pod template labels: kubectl edit service %s -n %s", svc.Name, svc.Namespace),
+		},
+	}
+}
+
+// podHealthHypothesis reports backing pods that are not ready, with their container states
+func podHealthHypothesis(pods []corev1.Pod, readyEndpoints int) (RootCauseHypothesis, bool) {
+	var evidence, remediation []string
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && podIsReady(&pod) {
+			continue
+		}
+		evidence = append(evidence, describePodHealth(&pod))
+		remediation = append(remediation, fmt.Sprintf("kubectl describe pod %s -n %s", pod.Name, pod.Namespace))
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.RestartCount > 0 {
+				remediation = append(remediation, fmt.Sprintf("kubectl logs %s -n %s -c %s --previous", pod.Name, pod.Namespace, status.Name))
+			}
+		}
+	}
+	if len(evidence) == 0 {
+		return RootCauseHypothesis{}, false
+	}
+
+	hypothesis := RootCauseHypothesis{
+		Cause:       fmt.Sprintf("%d of %d backing pods are not ready", len(evidence), len(pods)),
+		Confidence:  0.4,
+		Evidence:    evidence,
+		Remediation: append(remediation, "Verify the readinessProbe path and port match what the application serves"),
+	}
+	if readyEndpoints == 0 {
+		hypothesis.Cause = "No backing pod is ready, so the service has no ready endpoints"
+		hypothesis.Confidence = 0.9
+	}
+	return hypothesis, true
+}
+
+func podIsReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func describePodHealth(pod *corev1.Pod) string {
+	parts := []string{fmt.Sprintf("phase=%s", pod.Status.Phase)}
+	if pod.DeletionTimestamp != nil {
+		parts = append(parts, "terminating")
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		state := "running"
+		switch {
+		case status.State.Waiting != nil:
+			state = "waiting: " + status.State.Waiting.Reason
+		case status.State.Terminated != nil:
+			state = "terminated: " + status.State.Terminated.Reason
+		case !status.Ready:
+			state = "running, not ready"
+		}
+		parts = append(parts, fmt.Sprintf("container %s %s (restarts %d)", status.Name, state, status.RestartCount))
+	}
+	return fmt.Sprintf("pod %s: %s", pod.Name, strings.Join(parts, ", "))
+}
+
+// serviceTargetPort resolves the port traffic is sent to on the pods; an unset targetPort defaults to port
+func serviceTargetPort(port corev1.ServicePort) intstr.IntOrString {
+	if port.TargetPort.Type == intstr.String || port.TargetPort.IntVal != 0 {
+		return port.TargetPort
+	}
+	return intstr.FromInt32(port.Port)
+}
+
+// targetPortHypotheses flags service ports whose target does not exist on the backing containers
+func targetPortHypotheses(svc *corev1.Service, pods []corev1.Pod) []RootCauseHypothesis {
+	named := map[string]int32{}
+	declared := map[int32]bool{}
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			for _, port := range container.Ports {
+				declared[port.ContainerPort] = true
+				if port.Name != "" {
+					named[port.Name] = port.ContainerPort
+				}
+			}
+		}
+	}
+
+	var declaredPorts []int32
+	for port := range declared {
+		declaredPorts = append(declaredPorts, port)
+	}
+	sort.Slice(declaredPorts, func(i, j int) bool { return declaredPorts[i] < declaredPorts[j] })
+
+	var hypotheses []RootCauseHypothesis
+	for i, port := range svc.Spec.Ports {
+		target := serviceTargetPort(port)
+		suggested := "<containerPort>"
+		if len(declaredPorts) == 1 {
+			suggested = fmt.Sprintf("%d", declaredPorts[0])
+		}
+		patch := fmt.Sprintf(`kubectl patch service %s -n %s --type=json -p '[{"op":"replace","path":"/spec/ports/%d/targetPort","value":%s}]'`,
+			svc.Name, svc.Namespace, i, suggested)
+
+		if target.Type == intstr.String {
+			if _, ok := named[target.StrVal]; ok {
+				continue
+			}
+			hypotheses = append(hypotheses, RootCauseHypothesis{
+				Cause:       fmt.Sprintf("Service port %d targets named port %q, which no backing container declares", port.Port, target.StrVal),
+				Confidence:  0.85,
+				Evidence:    []string{fmt.Sprintf("declared container ports: %v", declaredPorts)},
+				Remediation: []string{patch, fmt.Sprintf("Or name the container port %q in the pod template", target.StrVal)},
+			})
+			continue
+		}
+
+		// containerPort is informational, so an undeclared numeric port is only a weak signal
+		if len(declaredPorts) == 0 || declared[target.IntVal] {
+			continue
+		}
+		hypotheses = append(hypotheses, RootCauseHypothesis{
+			Cause:      fmt.Sprintf("Service port %d targets %d, which the backing containers do not declare", port.Port, target.IntVal),
+			Confidence: 0.45,
+			Evidence:   []string{fmt.Sprintf("declared container ports: %v", declaredPorts)},
+			Remediation: []string{
+				patch,
+				fmt.Sprintf("Confirm the application listens on %d: kubectl exec -n %s %s -- netstat -tln", target.IntVal, svc.Namespace, pods[0].Name),
+			},
+		})
+	}
+	return hypotheses
+}
+
+// networkPolicyHypotheses reports NetworkPolicies that select the backing pods and restrict their ingress.
+// It also returns the names of every policy selecting those pods.
+func networkPolicyHypotheses(svc *corev1.Service, pods []corev1.Pod, policies []networkingv1.NetworkPolicy) ([]RootCauseHypothesis, []string) {
+	var targets []intstr.IntOrString
+	for _, port := range svc.Spec.Ports {
+		targets = append(targets, serviceTargetPort(port))
+	}
+
+	var hypotheses []RootCauseHypothesis
+	selecting := []string{}
+	for _, policy := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			continue
+		}
+		selected := 0
+		for _, pod := range pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				selected++
+			}
+		}
+		if selected == 0 || !policyRestrictsIngress(&policy) {
+			continue
+		}
+		selecting = append(selecting, policy.Name)
+
+		describe := fmt.Sprintf("kubectl describe networkpolicy %s -n %s", policy.Name, policy.Namespace)
+		evidence := fmt.Sprintf("NetworkPolicy %s selects %d of %d backing pods", policy.Name, selected, len(pods))
+		switch {
+		case len(policy.Spec.Ingress) == 0:
+			hypotheses = append(hypotheses, RootCauseHypothesis{
+				Cause:      fmt.Sprintf("NetworkPolicy %s denies all ingress to the backing pods", policy.Name),
+				Confidence: 0.8,
+				Evidence:   []string{evidence, "the policy has policyTypes Ingress and no ingress rules"},
+				Remediation: []string{
+					describe,
+					fmt.Sprintf("Add an ingress rule admitting the clients on the service's target ports: kubectl edit networkpolicy %s -n %s", policy.Name, policy.Namespace),
+				},
+			})
+		case !policyAllowsAnyPort(policy.Spec.Ingress, targets):
+			hypotheses = append(hypotheses, RootCauseHypothesis{
+				Cause:      fmt.Sprintf("NetworkPolicy %s does not allow the service's target ports", policy.Name),
+				Confidence: 0.65,
+				Evidence:   []string{evidence, fmt.Sprintf("target ports %v are not listed in any ingress rule", targets)},
+				Remediation: []string{
+					describe,
+					fmt.Sprintf("Add the target ports to the policy's ingress rules: kubectl edit networkpolicy %s -n %s", policy.Name, policy.Namespace),
+				},
+			})
+		case policyRestrictsPeers(policy.Spec.Ingress):
+			hypotheses = append(hypotheses, RootCauseHypothesis{
+				Cause:      fmt.Sprintf("NetworkPolicy %s only admits traffic from specific sources", policy.Name),
+				Confidence: 0.3,
+				Evidence:   []string{evidence, "every ingress rule lists from peers"},
+				Remediation: []string{
+					describe,
+					"Check that the client pod and namespace labels match the policy's from selectors",
+				},
+			})
+		}
+	}
+	sort.Strings(selecting)
+	return hypotheses, selecting
+}
+
+// policyRestrictsIngress reports whether the policy applies to ingress; policies without
+// policyTypes always do
+func policyRestrictsIngress(policy *networkingv1.NetworkPolicy) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return true
+	}
+	for _, policyType := range policy.Spec.PolicyTypes {
+		if policyType == networkingv1.PolicyTypeIngress {
+			return true
+		}
+	}
+	return false
+}
+
+// policyAllowsAnyPort reports whether some ingress rule admits at least one of the target ports.
+// Named and numeric ports are not resolved against each other and are assumed to match.
+func policyAllowsAnyPort(rules []networkingv1.NetworkPolicyIngressRule, targets []intstr.IntOrString) bool {
+	for _, rule := range rules {
+		if len(rule.Ports) == 0 {
+			return true
+		}
+		for _, port := range rule.Ports {
+			if port.Port == nil {
+				return true
+			}
+			for _, target := range targets {
+				if port.Port.Type != target.Type {
+					return true
+				}
+				if target.Type == intstr.String {
+					if port.Port.StrVal == target.StrVal {
+						return true
+					}
+					continue
+				}
+				end := port.Port.IntVal
+				if port.EndPort != nil {
+					end = *port.EndPort
+				}
+				if target.IntVal >= port.Port.IntVal && target.IntVal <= end {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// policyRestrictsPeers reports whether every ingress rule is limited to specific sources
+func policyRestrictsPeers(rules []networkingv1.NetworkPolicyIngressRule) bool {
+	for _, rule := range rules {
+		if len(rule.From) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func firstServicePort(svc *corev1.Service) int32 {
+	if len(svc.Spec.Ports) == 0 {
+		return 80
+	}
+	return svc.Spec.Ports[0].Port
+}
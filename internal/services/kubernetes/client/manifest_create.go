@@ -0,0 +1,295 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// Per-object outcomes reported by CreateManifestResources
+const (
+	ManifestObjectCreated        = "created"
+	ManifestObjectFailed         = "failed"
+	ManifestObjectSkipped        = "skipped"
+	ManifestObjectRolledBack     = "rolledBack"
+	ManifestObjectRollbackFailed = "rollbackFailed"
+)
+
+// How long to wait for kinds defined by a CustomResourceDefinition in the same manifest to be served
+var (
+	manifestResolveInterval = time.Second
+	manifestResolveAttempts = 15
+)
+
+// manifestRollbackTimeout bounds a rollback, which runs even when the call's own context has ended
+const manifestRollbackTimeout = 30 * time.Second
+
+// manifestKindOrder is the creation order of well-known kinds, following Helm's install order.
+// Kinds not listed are created after all of them.
+var manifestKindOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"PriorityClass",
+	"ResourceQuota",
+	"LimitRange",
+	"PodSecurityPolicy",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"IngressClass",
+	"Ingress",
+	"APIService",
+}
+
+// ManifestObjectResult is the outcome of creating one object of a manifest
+type ManifestObjectResult struct {
+	Index      int    `json:"index"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ManifestCreateResult reports the objects created from a multi-document manifest in creation order
+type ManifestCreateResult struct {
+	Succeeded  bool                   `json:"succeeded"`
+	Atomic     bool                   `json:"atomic"`
+	RolledBack bool                   `json:"rolledBack,omitempty"`
+	Created    int                    `json:"created"`
+	Failed     int                    `json:"failed"`
+	Objects    []ManifestObjectResult `json:"objects"`
+}
+
+// createdManifestObject remembers where a created object lives so it can be rolled back
+type createdManifestObject struct {
+	result   int
+	resource dynamic.ResourceInterface
+	name     string
+}
+
+// CreateManifestResources creates every object of a multi-document YAML manifest or JSON array.
+// Namespaces and CustomResourceDefinitions are created first, then the remaining kinds in
+// dependency order. With atomic set, the first failure deletes the objects created so far and
+// skips the rest; otherwise creation continues and failures are reported per object.
+func (c *Client) CreateManifestResources(ctx context.Context, manifest, defaultNamespace string, atomic bool) (*ManifestCreateResult, error) {
+	if strings.TrimSpace(manifest) == "" {
+		return nil, fmt.Errorf("manifest is empty")
+	}
+	if defaultNamespace == "" {
+		defaultNamespace = metav1.NamespaceDefault
+	}
+	objects, err := decodeManifestDocuments(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	order := make([]int, len(objects))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return manifestKindRank(objects[order[i]]) < manifestKindRank(objects[order[j]])
+	})
+
+	definedKinds := map[schema.GroupKind]bool{}
+	for _, obj := range objects {
+		if groupKind, ok := crdGroupKind(obj); ok {
+			definedKinds[groupKind] = true
+		}
+	}
+
+	result := &ManifestCreateResult{Atomic: atomic, Objects: make([]ManifestObjectResult, 0, len(objects))}
+	var created []createdManifestObject
+	for _, index := range order {
+		obj := &unstructured.Unstructured{Object: objects[index]}
+		objectResult := ManifestObjectResult{
+			Index:      index,
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+			Namespace:  obj.GetNamespace(),
+		}
+
+		if atomic && result.Failed > 0 {
+			objectResult.Status = ManifestObjectSkipped
+			result.Objects = append(result.Objects, objectResult)
+			continue
+		}
+
+		resource, createErr := c.createManifestObject(ctx, obj, defaultNamespace, definedKinds)
+		objectResult.Namespace = obj.GetNamespace()
+		if createErr != nil {
+			objectResult.Status = ManifestObjectFailed
+			objectResult.Error = createErr.Error()
+			result.Failed++
+		} else {
+			objectResult.Status = ManifestObjectCreated
+			objectResult.Name = obj.GetName()
+			result.Created++
+			created = append(created, createdManifestObject{
+				result:   len(result.Objects),
+				resource: resource,
+				name:     obj.GetName(),
+			})
+		}
+		result.Objects = append(result.Objects, objectResult)
+	}
+
+	if atomic && result.Failed > 0 {
+		c.rollbackManifestObjects(ctx, result, created)
+	}
+	result.Succeeded = result.Failed == 0
+
+	logrus.WithFields(logrus.Fields{
+		"objects":    len(result.Objects),
+		"created":    result.Created,
+		"failed":     result.Failed,
+		"rolledBack": result.RolledBack,
+	}).Debug("Created manifest resources")
+	return result, nil
+}
+
+// createManifestObject creates one object, defaulting the namespace of namespaced kinds. On success
+// obj holds the server's copy, so generated names are known.
+func (c *Client) createManifestObject(ctx context.Context, obj *unstructured.Unstructured, defaultNamespace string, definedKinds map[schema.GroupKind]bool) (dynamic.ResourceInterface, error) {
+	if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+		return nil, fmt.Errorf("apiVersion and kind are required")
+	}
+	if obj.GetName() == "" && obj.GetGenerateName() == "" {
+		return nil, fmt.Errorf("metadata.name or metadata.generateName is required")
+	}
+
+	resource, err := c.resolveManifestResource(ctx, obj, definedKinds)
+	if err != nil {
+		return nil, err
+	}
+	gvr := schema.FromAPIVersionAndKind(obj.GetAPIVersion(), obj.GetKind()).GroupVersion().WithResource(resource.Name)
+
+	var resourceClient dynamic.ResourceInterface
+	if resource.Namespaced {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(defaultNamespace)
+		}
+		resourceClient = c.dynamicClient.Resource(gvr).Namespace(obj.GetNamespace())
+	} else {
+		obj.SetNamespace("")
+		resourceClient = c.dynamicClient.Resource(gvr)
+	}
+
+	createdObj, err := resourceClient.Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	obj.Object = createdObj.Object
+	return resourceClient, nil
+}
+
+// resolveManifestResource finds the API resource serving the object's kind. Kinds defined by a
+// CustomResourceDefinition of the same manifest are polled until the API server serves them.
+func (c *Client) resolveManifestResource(ctx context.Context, obj *unstructured.Unstructured, definedKinds map[schema.GroupKind]bool) (*metav1.APIResource, error) {
+	gvk := obj.GroupVersionKind()
+	attempts := 1
+	if definedKinds[gvk.GroupKind()] {
+		attempts = manifestResolveAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(manifestResolveInterval):
+			}
+		}
+		resources, err := c.discoveryClient.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to discover %s: %w", gvk.GroupVersion(), err)
+		}
+		if resources != nil {
+			for i := range resources.APIResources {
+				resource := &resources.APIResources[i]
+				if resource.Kind == gvk.Kind && !strings.Contains(resource.Name, "/") {
+					return resource, nil
+				}
+			}
+		}
+		lastErr = fmt.Errorf("%s %s is not served by this cluster", gvk.GroupVersion(), gvk.Kind)
+	}
+	return nil, lastErr
+}
+
+// rollbackManifestObjects deletes created objects in reverse creation order. Creation often fails
+// because the call's deadline passed, so the deletes get their own deadline instead of ctx's.
+func (c *Client) rollbackManifestObjects(ctx context.Context, result *ManifestCreateResult, created []createdManifestObject) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), manifestRollbackTimeout)
+	defer cancel()
+
+	result.RolledBack = true
+	propagation := metav1.DeletePropagationBackground
+	for i := len(created) - 1; i >= 0; i-- {
+		object := created[i]
+		objectResult := &result.Objects[object.result]
+		err := object.resource.Delete(ctx, object.name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil && !apierrors.IsNotFound(err) {
+			objectResult.Status = ManifestObjectRollbackFailed
+			objectResult.Error = fmt.Sprintf("failed to delete during rollback: %v", err)
+			result.RolledBack = false
+			continue
+		}
+		objectResult.Status = ManifestObjectRolledBack
+		result.Created--
+	}
+}
+
+// manifestKindRank orders well-known kinds by manifestKindOrder and everything else after them
+func manifestKindRank(obj map[string]any) int {
+	kind, _ := obj["kind"].(string)
+	for rank, ordered := range manifestKindOrder {
+		if ordered == kind {
+			return rank
+		}
+	}
+	return len(manifestKindOrder)
+}
+
+// crdGroupKind returns the group and kind defined by a CustomResourceDefinition object
+func crdGroupKind(obj map[string]any) (schema.GroupKind, bool) {
+	if obj["kind"] != "CustomResourceDefinition" {
+		return schema.GroupKind{}, false
+	}
+	group, _ := nestedString(obj, "spec", "group")
+	kind, _ := nestedString(obj, "spec", "names", "kind")
+	if group == "" || kind == "" {
+		return schema.GroupKind{}, false
+	}
+	return schema.GroupKind{Group: group, Kind: kind}, true
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var (
+	namespacesGVR  = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	configMapsGVR  = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	deploymentsGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+func newManifestCreateTestClient(objects ...runtime.Object) *Client {
+	clientset := fake.NewClientset()
+	discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace"},
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+		}},
+	}
+	return &Client{
+		clientset:       clientset,
+		discoveryClient: discovery,
+		dynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{
+				namespacesGVR:  "NamespaceList",
+				configMapsGVR:  "ConfigMapList",
+				deploymentsGVR: "DeploymentList",
+			}, objects...),
+	}
+}
+
+const manifestBundle = `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web, namespace: shop}
+spec: {replicas: 1}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: web-config}
+data: {mode: prod}
+---
+apiVersion: v1
+kind: Namespace
+metadata: {name: shop}
+`
+
+func TestCreateManifestResourcesOrdersAndDefaultsNamespace(t *testing.T) {
+	c := newManifestCreateTestClient()
+	ctx := context.Background()
+
+	result, err := c.CreateManifestResources(ctx, manifestBundle, "shop", false)
+	if err != nil {
+		t.Fatalf("CreateManifestResources() error = %v", err)
+	}
+	if !result.Succeeded || result.Created != 3 {
+		t.Fatalf("expected all three objects to be created, got %+v", result)
+	}
+	var kinds []string
+	for _, object := range result.Objects {
+		kinds = append(kinds, object.Kind)
+	}
+	if kinds[0] != "Namespace" || kinds[1] != "ConfigMap" || kinds[2] != "Deployment" {
+		t.Fatalf("unexpected creation order: %v", kinds)
+	}
+	if result.Objects[1].Index != 1 || result.Objects[1].Namespace != "shop" {
+		t.Fatalf("expected the ConfigMap to default to the shop namespace, got %+v", result.Objects[1])
+	}
+
+	configMap, err := c.dynamicClient.Resource(configMapsGVR).Namespace("shop").Get(ctx, "web-config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the ConfigMap to exist: %v", err)
+	}
+	if mode, _, _ := unstructured.NestedString(configMap.Object, "data", "mode"); mode != "prod" {
+		t.Fatalf("expected the ConfigMap data to be kept, got %v", configMap.Object)
+	}
+}
+
+func TestCreateManifestResourcesAtomicRollback(t *testing.T) {
+	existing := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "web-config", "namespace": "shop"},
+	}}
+	c := newManifestCreateTestClient(existing)
+	ctx := context.Background()
+
+	result, err := c.CreateManifestResources(ctx, manifestBundle, "shop", true)
+	if err != nil {
+		t.Fatalf("CreateManifestResources() error = %v", err)
+	}
+	if result.Succeeded || !result.RolledBack || result.Created != 0 || result.Failed != 1 {
+		t.Fatalf("expected a rolled back failure, got %+v", result)
+	}
+	statuses := []string{result.Objects[0].Status, result.Objects[1].Status, result.Objects[2].Status}
+	if statuses[0] != ManifestObjectRolledBack || statuses[1] != ManifestObjectFailed || statuses[2] != ManifestObjectSkipped {
+		t.Fatalf("unexpected statuses: %v", statuses)
+	}
+	if _, err := c.dynamicClient.Resource(namespacesGVR).Get(ctx, "shop", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the namespace to be deleted during rollback, got %v", err)
+	}
+}
+
+// contextCheckingResource fails deletes whose context has already ended, like a real API call
+type contextCheckingResource struct {
+	dynamic.ResourceInterface
+}
+
+func (r contextCheckingResource) Delete(ctx context.Context, name string, options metav1.DeleteOptions, subresources ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.ResourceInterface.Delete(ctx, name, options, subresources...)
+}
+
+func TestRollbackManifestObjectsOutlivesCallContext(t *testing.T) {
+	namespace := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata":   map[string]any{"name": "shop"},
+	}}
+	c := newManifestCreateTestClient(namespace)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	result := &ManifestCreateResult{Created: 1, Failed: 1, Objects: []ManifestObjectResult{{Status: ManifestObjectCreated}, {Status: ManifestObjectFailed}}}
+	c.rollbackManifestObjects(ctx, result, []createdManifestObject{
+		{result: 0, resource: contextCheckingResource{c.dynamicClient.Resource(namespacesGVR)}, name: "shop"},
+	})
+	if !result.RolledBack || result.Created != 0 || result.Objects[0].Status != ManifestObjectRolledBack {
+		t.Fatalf("expected the rollback to succeed after the call's deadline, got %+v", result)
+	}
+	if _, err := c.dynamicClient.Resource(namespacesGVR).Get(context.Background(), "shop", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the namespace to be deleted during rollback, got %v", err)
+	}
+}
+
+func TestCreateManifestResourcesJSONArrayKeepsGoing(t *testing.T) {
+	c := newManifestCreateTestClient()
+	result, err := c.CreateManifestResources(context.Background(), `[
+  {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}},
+  {"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "w"}},
+  {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"generateName": "b-"}}
+]`, "", false)
+	if err != nil {
+		t.Fatalf("CreateManifestResources() error = %v", err)
+	}
+	if result.Succeeded || result.Created != 2 || result.Failed != 1 || result.RolledBack {
+		t.Fatalf("expected one failure without rollback, got %+v", result)
+	}
+	if result.Objects[0].Namespace != "default" || result.Objects[2].Kind != "Widget" || result.Objects[2].Status != ManifestObjectFailed {
+		t.Fatalf("unexpected results: %+v", result.Objects)
+	}
+
+	if _, err := c.CreateManifestResources(context.Background(), "[]", "", false); err == nil {
+		t.Fatal("expected an empty array to be rejected")
+	}
+}
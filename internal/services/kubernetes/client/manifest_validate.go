@@ -0,0 +1,509 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// maxManifestValidationErrors bounds the errors reported per document
+const maxManifestValidationErrors = 50
+
+// Kinds of manifest validation errors.
+const (
+	ManifestErrorParse    = "parse"
+	ManifestErrorRequired = "required"
+	ManifestErrorType     = "type"
+	ManifestErrorUnknown  = "unknownField"
+	ManifestErrorEnum     = "enum"
+	ManifestErrorAPI      = "unknownAPI"
+)
+
+// ManifestValidationError is one problem found in a manifest document
+type ManifestValidationError struct {
+	Path    string `json:"path"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// ManifestDocumentValidation is the validation result of one document of a manifest
+type ManifestDocumentValidation struct {
+	Index           int                       `json:"index"`
+	APIVersion      string                    `json:"apiVersion,omitempty"`
+	Kind            string                    `json:"kind,omitempty"`
+	Name            string                    `json:"name,omitempty"`
+	Namespace       string                    `json:"namespace,omitempty"`
+	Resource        string                    `json:"resource,omitempty"`
+	Namespaced      bool                      `json:"namespaced"`
+	NamespaceExists *bool                     `json:"namespaceExists,omitempty"`
+	SchemaValidated bool                      `json:"schemaValidated"`
+	Valid           bool                      `json:"valid"`
+	Errors          []ManifestValidationError `json:"errors"`
+	Warnings        []string                  `json:"warnings"`
+}
+
+// ManifestValidation is the validation result of a manifest
+type ManifestValidation struct {
+	Valid     bool                         `json:"valid"`
+	Documents []ManifestDocumentValidation `json:"documents"`
+}
+
+// ValidateManifest checks a JSON or YAML manifest, which may hold several documents, without
+// sending it to the API server: each document's apiVersion and kind must be served by the
+// cluster, and the object must match the cluster's OpenAPI v3 schema for it (types, required and
+// unknown fields, enums). It also reports whether the target namespace of namespaced objects
+// exists and warns about deprecated apiVersions. Namespaced objects without a namespace are
+// checked against defaultNamespace.
+func (c *Client) ValidateManifest(ctx context.Context, manifest, defaultNamespace string) (*ManifestValidation, error) {
+	if strings.TrimSpace(manifest) == "" {
+		return nil, fmt.Errorf("manifest is empty")
+	}
+	if defaultNamespace == "" {
+		defaultNamespace = metav1.NamespaceDefault
+	}
+
+	objects, err := decodeManifestDocuments(manifest)
+	if err != nil {
+		return &ManifestValidation{Documents: []ManifestDocumentValidation{{
+			Errors:   []ManifestValidationError{{Type: ManifestErrorParse, Message: err.Error()}},
+			Warnings: []string{},
+		}}}, nil
+	}
+
+	// Namespaces created by the manifest itself do not need to exist yet
+	created := map[string]bool{}
+	for _, obj := range objects {
+		if obj["kind"] == "Namespace" {
+			if name, ok := nestedString(obj, "metadata", "name"); ok {
+				created[name] = true
+			}
+		}
+	}
+
+	validator := &manifestValidator{client: c, schemas: map[string]map[string]any{}}
+	result := &ManifestValidation{Valid: true}
+	for i, obj := range objects {
+		doc := validator.validateDocument(ctx, i, obj, defaultNamespace, created)
+		doc.Valid = len(doc.Errors) == 0
+		result.Valid = result.Valid && doc.Valid
+		result.Documents = append(result.Documents, doc)
+	}
+
+	logrus.WithFields(logrus.Fields{"documents": len(result.Documents), "valid": result.Valid}).Debug("Validated manifest")
+	return result, nil
+}
+
+// decodeManifestDocuments splits a JSON or YAML manifest, or a JSON array of objects, into its
+// non-empty documents
+func decodeManifestDocuments(manifest string) ([]map[string]any, error) {
+	var objects []map[string]any
+	if strings.HasPrefix(strings.TrimSpace(manifest), "[") {
+		var items []map[string]any
+		if err := json.Unmarshal([]byte(manifest), &items); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON array: %w", err)
+		}
+		for _, item := range items {
+			if len(item) > 0 {
+				objects = append(objects, item)
+			}
+		}
+		if len(objects) == 0 {
+			return nil, fmt.Errorf("manifest contains no objects")
+		}
+		return objects, nil
+	}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	for {
+		var obj map[string]any
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("document %d: %w", len(objects), err)
+		}
+		if len(obj) > 0 {
+			objects = append(objects, obj)
+		}
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("manifest contains no objects")
+	}
+	return objects, nil
+}
+
+// manifestValidator validates the documents of one manifest, fetching each group version's
+// OpenAPI schema once
+type manifestValidator struct {
+	client  *Client
+	schemas map[string]map[string]any
+}
+
+func (v *manifestValidator) validateDocument(ctx context.Context, index int, obj map[string]any, defaultNamespace string, createdNamespaces map[string]bool) ManifestDocumentValidation {
+	doc := ManifestDocumentValidation{Index: index, Errors: []ManifestValidationError{}, Warnings: []string{}}
+	doc.APIVersion, _ = obj["apiVersion"].(string)
+	doc.Kind, _ = obj["kind"].(string)
+	doc.Name, _ = nestedString(obj, "metadata", "name")
+	doc.Namespace, _ = nestedString(obj, "metadata", "namespace")
+
+	if doc.APIVersion == "" {
+		doc.addError("apiVersion", ManifestErrorRequired, "apiVersion is required")
+	}
+	if doc.Kind == "" {
+		doc.addError("kind", ManifestErrorRequired, "kind is required")
+	}
+	if _, hasGenerateName := nestedString(obj, "metadata", "generateName"); doc.Name == "" && !hasGenerateName {
+		doc.addError("metadata.name", ManifestErrorRequired, "metadata.name or metadata.generateName is required")
+	}
+	if doc.APIVersion == "" || doc.Kind == "" {
+		return doc
+	}
+
+	gv, err := schema.ParseGroupVersion(doc.APIVersion)
+	if err != nil {
+		doc.addError("apiVersion", ManifestErrorType, err.Error())
+		return doc
+	}
+	deprecation, deprecated := findDeprecatedAPI(doc.APIVersion, doc.Kind)
+	if deprecated {
+		doc.Warnings = append(doc.Warnings, deprecation.message(doc.APIVersion, doc.Kind))
+	}
+
+	resources, err := v.client.discoveryClient.ServerResourcesForGroupVersion(doc.APIVersion)
+	if err != nil && !apierrors.IsNotFound(err) {
+		doc.Warnings = append(doc.Warnings, fmt.Sprintf("could not discover %s, skipped schema validation: %v", doc.APIVersion, err))
+		return doc
+	}
+	var resource *metav1.APIResource
+	if resources != nil {
+		for i := range resources.APIResources {
+			candidate := &resources.APIResources[i]
+			if candidate.Kind == doc.Kind && !strings.Contains(candidate.Name, "/") {
+				resource = candidate
+				break
+			}
+		}
+	}
+	if resource == nil {
+		message := fmt.Sprintf("%s %s is not served by this cluster", doc.APIVersion, doc.Kind)
+		if deprecated && deprecation.RemovedIn != "" {
+			message += fmt.Sprintf(" (removed in Kubernetes %s)", deprecation.RemovedIn)
+		}
+		if deprecated && deprecation.Replacement != "" {
+			message += "; use " + deprecation.Replacement
+		}
+		doc.addError("apiVersion", ManifestErrorAPI, message)
+		return doc
+	}
+	doc.Resource = resource.Name
+	doc.Namespaced = resource.Namespaced
+
+	if resource.Namespaced {
+		if doc.Namespace == "" {
+			doc.Namespace = defaultNamespace
+		}
+		doc.NamespaceExists = v.namespaceExists(ctx, doc.Namespace, createdNamespaces, &doc)
+	} else if doc.Namespace != "" {
+		doc.Warnings = append(doc.Warnings, fmt.Sprintf("%s is cluster-scoped; metadata.namespace %q is ignored", doc.Kind, doc.Namespace))
+	}
+
+	components, err := v.groupVersionSchemas(gv)
+	if err != nil {
+		doc.Warnings = append(doc.Warnings, fmt.Sprintf("could not load the OpenAPI schema of %s, skipped schema validation: %v", doc.APIVersion, err))
+		return doc
+	}
+	kindSchema := findKindSchema(components, gv.WithKind(doc.Kind))
+	if kindSchema == nil {
+		doc.Warnings = append(doc.Warnings, fmt.Sprintf("the OpenAPI schema of %s has no definition for %s, skipped schema validation", doc.APIVersion, doc.Kind))
+		return doc
+	}
+	(&schemaWalker{components: components, doc: &doc}).validate(obj, kindSchema, "")
+	doc.SchemaValidated = true
+	return doc
+}
+
+// namespaceExists looks the namespace up; nil means it could not be checked
+func (v *manifestValidator) namespaceExists(ctx context.Context, namespace string, createdNamespaces map[string]bool, doc *ManifestDocumentValidation) *bool {
+	exists := true
+	_, err := v.client.clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		exists = false
+		if !createdNamespaces[namespace] {
+			doc.Warnings = append(doc.Warnings, fmt.Sprintf("namespace %q does not exist", namespace))
+		}
+	default:
+		doc.Warnings = append(doc.Warnings, fmt.Sprintf("could not check namespace %q: %v", namespace, err))
+		return nil
+	}
+	return &exists
+}
+
+// groupVersionSchemas returns the components.schemas of a group version's OpenAPI v3 document
+func (v *manifestValidator) groupVersionSchemas(gv schema.GroupVersion) (map[string]any, error) {
+	path := "apis/" + gv.String()
+	if gv.Group == "" {
+		path = "api/" + gv.Version
+	}
+	if schemas, ok := v.schemas[path]; ok {
+		return schemas, nil
+	}
+
+	paths, err := v.client.discoveryClient.OpenAPIV3().Paths()
+	if err != nil {
+		return nil, err
+	}
+	groupVersion, ok := paths[path]
+	if !ok {
+		return nil, fmt.Errorf("the cluster publishes no OpenAPI v3 document for %s", gv)
+	}
+	raw, err := groupVersion.Schema("application/json")
+	if err != nil {
+		return nil, err
+	}
+	var document struct {
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	v.schemas[path] = document.Components.Schemas
+	return document.Components.Schemas, nil
+}
+
+// findKindSchema returns the schema tagged with gvk in x-kubernetes-group-version-kind
+func findKindSchema(components map[string]any, gvk schema.GroupVersionKind) map[string]any {
+	for _, raw := range components {
+		definition, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		tags, _ := definition["x-kubernetes-group-version-kind"].([]any)
+		for _, rawTag := range tags {
+			tag, _ := rawTag.(map[string]any)
+			if tag["group"] == gvk.Group && tag["version"] == gvk.Version && tag["kind"] == gvk.Kind {
+				return definition
+			}
+		}
+	}
+	return nil
+}
+
+// schemaWalker checks an object against an OpenAPI v3 schema, recording problems on doc
+type schemaWalker struct {
+	components map[string]any
+	doc        *ManifestDocumentValidation
+}
+
+// resolve follows a $ref, and a single-entry allOf wrapping one as Kubernetes publishes them
+func (w *schemaWalker) resolve(s map[string]any) map[string]any {
+	for depth := 0; depth < 32; depth++ {
+		if ref, ok := s["$ref"].(string); ok {
+			target, _ := w.components[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+			if target == nil {
+				return map[string]any{}
+			}
+			s = target
+			continue
+		}
+		if allOf, ok := s["allOf"].([]any); ok && len(allOf) == 1 && s["type"] == nil && s["properties"] == nil {
+			if inner, ok := allOf[0].(map[string]any); ok {
+				s = inner
+				continue
+			}
+		}
+		return s
+	}
+	return s
+}
+
+func (w *schemaWalker) validate(value any, s map[string]any, path string) {
+	if len(w.doc.Errors) >= maxManifestValidationErrors {
+		return
+	}
+	s = w.resolve(s)
+	if value == nil {
+		return
+	}
+	if allOf, ok := s["allOf"].([]any); ok {
+		for _, entry := range allOf {
+			if inner, ok := entry.(map[string]any); ok {
+				w.validate(value, inner, path)
+			}
+		}
+	}
+	if s["x-kubernetes-int-or-string"] == true {
+		switch value.(type) {
+		case string, float64, int64:
+		default:
+			w.doc.addError(path, ManifestErrorType, fmt.Sprintf("expected an integer or string, got %s", jsonTypeName(value)))
+		}
+		return
+	}
+
+	schemaType, _ := s["type"].(string)
+	switch schemaType {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			w.doc.addError(path, ManifestErrorType, fmt.Sprintf("expected an object, got %s", jsonTypeName(value)))
+			return
+		}
+		w.validateObject(obj, s, path)
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			w.doc.addError(path, ManifestErrorType, fmt.Sprintf("expected an array, got %s", jsonTypeName(value)))
+			return
+		}
+		if itemSchema, ok := s["items"].(map[string]any); ok {
+			for i, item := range items {
+				w.validate(item, itemSchema, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			w.doc.addError(path, ManifestErrorType, fmt.Sprintf("expected a string, got %s", jsonTypeName(value)))
+			return
+		}
+	case "integer":
+		if !isJSONInteger(value) {
+			w.doc.addError(path, ManifestErrorType, fmt.Sprintf("expected an integer, got %s", jsonTypeName(value)))
+			return
+		}
+	case "number":
+		switch value.(type) {
+		case float64, int64:
+		default:
+			w.doc.addError(path, ManifestErrorType, fmt.Sprintf("expected a number, got %s", jsonTypeName(value)))
+			return
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			w.doc.addError(path, ManifestErrorType, fmt.Sprintf("expected a boolean, got %s", jsonTypeName(value)))
+			return
+		}
+	default:
+		// Untyped schemas (e.g. x-kubernetes-preserve-unknown-fields) accept anything, but their
+		// declared properties are still checked
+		if obj, ok := value.(map[string]any); ok && s["properties"] != nil {
+			w.validateObject(obj, s, path)
+		}
+	}
+
+	if enum, ok := s["enum"].([]any); ok && len(enum) > 0 {
+		for _, allowed := range enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				return
+			}
+		}
+		allowed := make([]string, 0, len(enum))
+		for _, entry := range enum {
+			allowed = append(allowed, fmt.Sprint(entry))
+		}
+		w.doc.addError(path, ManifestErrorEnum, fmt.Sprintf("unsupported value %v, expected one of: %s", value, strings.Join(allowed, ", ")))
+	}
+}
+
+func (w *schemaWalker) validateObject(obj map[string]any, s map[string]any, path string) {
+	properties, _ := s["properties"].(map[string]any)
+	if required, ok := s["required"].([]any); ok {
+		for _, rawName := range required {
+			name, _ := rawName.(string)
+			if _, present := obj[name]; !present {
+				w.doc.addError(joinFieldPath(path, name), ManifestErrorRequired, fmt.Sprintf("missing required field %q", name))
+			}
+		}
+	}
+
+	additional, _ := s["additionalProperties"].(map[string]any)
+	openObject := s["additionalProperties"] == true || s["x-kubernetes-preserve-unknown-fields"] == true
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fieldPath := joinFieldPath(path, key)
+		if propertySchema, ok := properties[key].(map[string]any); ok {
+			w.validate(obj[key], propertySchema, fieldPath)
+			continue
+		}
+		switch {
+		case additional != nil:
+			w.validate(obj[key], additional, fieldPath)
+		case openObject || properties == nil:
+		default:
+			w.doc.addError(fieldPath, ManifestErrorUnknown, fmt.Sprintf("unknown field %q", key))
+		}
+	}
+}
+
+func (doc *ManifestDocumentValidation) addError(path, errorType, message string) {
+	if len(doc.Errors) >= maxManifestValidationErrors {
+		return
+	}
+	doc.Errors = append(doc.Errors, ManifestValidationError{Path: path, Type: errorType, Message: message})
+}
+
+func joinFieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func nestedString(obj map[string]any, fields ...string) (string, bool) {
+	var current any = obj
+	for _, field := range fields {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		current = m[field]
+	}
+	s, ok := current.(string)
+	return s, ok && s != ""
+}
+
+func isJSONInteger(value any) bool {
+	switch number := value.(type) {
+	case int64:
+		return true
+	case float64:
+		return number == math.Trunc(number)
+	}
+	return false
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, int64:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
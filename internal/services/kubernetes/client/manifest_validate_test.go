@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/openapi"
+)
+
+// appsV1OpenAPI is a trimmed apps/v1 OpenAPI v3 document in the shape the API server publishes
+const appsV1OpenAPI = `{"components":{"schemas":{
+  "io.k8s.api.apps.v1.Deployment":{"type":"object",
+    "x-kubernetes-group-version-kind":[{"group":"apps","kind":"Deployment","version":"v1"}],
+    "properties":{
+      "apiVersion":{"type":"string"},"kind":{"type":"string"},
+      "metadata":{"allOf":[{"$ref":"#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}],"default":{}},
+      "spec":{"allOf":[{"$ref":"#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}],"default":{}}}},
+  "io.k8s.api.apps.v1.DeploymentSpec":{"type":"object","required":["selector","template"],
+    "properties":{
+      "replicas":{"type":"integer","format":"int32"},
+      "selector":{"type":"object","properties":{"matchLabels":{"type":"object","additionalProperties":{"type":"string"}}}},
+      "strategy":{"type":"object","properties":{"type":{"type":"string","enum":["Recreate","RollingUpdate"]},
+        "rollingUpdate":{"type":"object","properties":{"maxSurge":{"x-kubernetes-int-or-string":true}}}}},
+      "template":{"type":"object","properties":{"spec":{"type":"object","required":["containers"],
+        "properties":{"containers":{"type":"array","items":{"type":"object","required":["name"],
+          "properties":{"name":{"type":"string"},"image":{"type":"string"}}}}}}}}}},
+  "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta":{"type":"object",
+    "properties":{"name":{"type":"string"},"generateName":{"type":"string"},"namespace":{"type":"string"},
+      "labels":{"type":"object","additionalProperties":{"type":"string"}}}}
+}}}`
+
+type fakeOpenAPIGroupVersion struct{ document string }
+
+func (g fakeOpenAPIGroupVersion) Schema(string) ([]byte, error) { return []byte(g.document), nil }
+func (g fakeOpenAPIGroupVersion) ServerRelativeURL() string     { return "" }
+
+type fakeOpenAPIClient struct {
+	paths map[string]openapi.GroupVersion
+}
+
+func (c fakeOpenAPIClient) Paths() (map[string]openapi.GroupVersion, error) { return c.paths, nil }
+
+// openAPIDiscovery adds an OpenAPI v3 client to the fake discovery, which does not implement one
+type openAPIDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	openAPI openapi.Client
+}
+
+func (d openAPIDiscovery) OpenAPIV3() openapi.Client { return d.openAPI }
+
+func newManifestTestClient() *Client {
+	clientset := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+			{Name: "deployments/scale", Kind: "Scale", Namespaced: true},
+		},
+	}}
+	return &Client{clientset: clientset, discoveryClient: openAPIDiscovery{
+		FakeDiscovery: discovery,
+		openAPI: fakeOpenAPIClient{paths: map[string]openapi.GroupVersion{
+			"apis/apps/v1": fakeOpenAPIGroupVersion{document: appsV1OpenAPI},
+		}},
+	}}
+}
+
+func TestValidateManifestValid(t *testing.T) {
+	c := newManifestTestClient()
+	result, err := c.ValidateManifest(context.Background(), `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels: {app: web}
+spec:
+  replicas: 2
+  selector: {matchLabels: {app: web}}
+  strategy: {type: RollingUpdate, rollingUpdate: {maxSurge: 25%}}
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx
+`, "")
+	if err != nil {
+		t.Fatalf("ValidateManifest() error = %v", err)
+	}
+	doc := result.Documents[0]
+	if !result.Valid || !doc.SchemaValidated || len(doc.Errors) != 0 {
+		t.Fatalf("expected a valid, schema-validated document, got %+v", doc)
+	}
+	if doc.Resource != "deployments" || !doc.Namespaced || doc.Namespace != "default" || doc.NamespaceExists == nil || !*doc.NamespaceExists {
+		t.Fatalf("unexpected resolution: %+v", doc)
+	}
+}
+
+func TestValidateManifestErrors(t *testing.T) {
+	c := newManifestTestClient()
+	result, err := c.ValidateManifest(context.Background(), `{"apiVersion":"apps/v1","kind":"Deployment",
+"metadata":{"name":"web","namespace":"missing"},
+"spec":{"replicas":"two","strategy":{"type":"BlueGreen"},"selector":{},"template":{"spec":{"containers":[{"image":"nginx","ports":[]}]}},"replica":1}}
+---
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata: {name: web}
+`, "")
+	if err != nil {
+		t.Fatalf("ValidateManifest() error = %v", err)
+	}
+	if result.Valid || len(result.Documents) != 2 {
+		t.Fatalf("expected two invalid documents, got %+v", result)
+	}
+
+	deployment := result.Documents[0]
+	want := map[string]string{
+		"spec.replicas":                          ManifestErrorType,
+		"spec.strategy.type":                     ManifestErrorEnum,
+		"spec.template.spec.containers[0].name":  ManifestErrorRequired,
+		"spec.template.spec.containers[0].ports": ManifestErrorUnknown,
+		"spec.replica":                           ManifestErrorUnknown,
+	}
+	got := map[string]string{}
+	for _, validationErr := range deployment.Errors {
+		got[validationErr.Path] = validationErr.Type
+	}
+	for path, errorType := range want {
+		if got[path] != errorType {
+			t.Fatalf("expected a %s error at %s, got %+v", errorType, path, deployment.Errors)
+		}
+	}
+	if deployment.NamespaceExists == nil || *deployment.NamespaceExists || len(deployment.Warnings) != 1 {
+		t.Fatalf("expected the missing namespace to be reported, got %+v", deployment)
+	}
+
+	ingress := result.Documents[1]
+	if len(ingress.Errors) != 1 || ingress.Errors[0].Type != ManifestErrorAPI || !strings.Contains(ingress.Errors[0].Message, "networking.k8s.io/v1") {
+		t.Fatalf("expected the removed API to be reported with its replacement, got %+v", ingress.Errors)
+	}
+	if len(ingress.Warnings) != 1 || !strings.Contains(ingress.Warnings[0], "deprecated") {
+		t.Fatalf("expected a deprecation warning, got %+v", ingress.Warnings)
+	}
+}
+
+func TestValidateManifestParseError(t *testing.T) {
+	c := newManifestTestClient()
+	result, err := c.ValidateManifest(context.Background(), "kind: [unterminated", "")
+	if err != nil {
+		t.Fatalf("ValidateManifest() error = %v", err)
+	}
+	if result.Valid || result.Documents[0].Errors[0].Type != ManifestErrorParse {
+		t.Fatalf("expected a parse error, got %+v", result)
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrMetricsServerUnavailable is returned by the resource usage tools when the cluster does not serve
+// the metrics.k8s.io API
+var ErrMetricsServerUnavailable = errors.New("metrics-server not installed: the metrics.k8s.io API is not registered on this cluster; install metrics-server (https://github.com/kubernetes-sigs/metrics-server) to use resource usage")
+
+// requireMetricsAPI checks that the metrics.k8s.io group is registered before a metrics call, so a
+// missing metrics-server surfaces as ErrMetricsServerUnavailable rather than a generic not-found
+// error. When discovery itself fails the check passes and the metrics call reports its own error.
+func (c *Client) requireMetricsAPI() error {
+	if c.metricsClient == nil {
+		return ErrMetricsServerUnavailable
+	}
+	groups, err := c.serverGroups()
+	if groups == nil {
+		logrus.WithError(err).Debug("Could not discover API groups, skipping metrics API check")
+		return nil
+	}
+	if !hasAPIGroup(groups, metricsAPIGroup) {
+		return ErrMetricsServerUnavailable
+	}
+	return nil
+}
+
+func hasAPIGroup(groups *metav1.APIGroupList, name string) bool {
+	for _, group := range groups.Groups {
+		if group.Name == name {
+			return true
+		}
+	}
+	return false
+}
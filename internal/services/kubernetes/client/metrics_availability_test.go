@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// discoveryServing returns a fake discovery client listing the given group versions
+func discoveryServing(groupVersions ...string) *fakediscovery.FakeDiscovery {
+	discovery := fake.NewClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	for _, gv := range groupVersions {
+		discovery.Resources = append(discovery.Resources, &metav1.APIResourceList{GroupVersion: gv})
+	}
+	return discovery
+}
+
+func TestMetricsToolsReportMissingMetricsServer(t *testing.T) {
+	c := &Client{
+		clientset:       fake.NewClientset(),
+		metricsClient:   metricsfake.NewSimpleClientset(),
+		discoveryClient: discoveryServing("v1", "apps/v1"),
+	}
+	ctx := context.Background()
+
+	checks := map[string]func() error{
+		"GetResourceUsage": func() error {
+			_, err := c.GetResourceUsage(ctx, "node", "", "")
+			return err
+		},
+		"GetPodMetrics": func() error {
+			_, err := c.GetPodMetrics(ctx, "web-1", "default", false)
+			return err
+		},
+		"GetNodeMetrics": func() error {
+			_, err := c.GetNodeMetrics(ctx, "node-1")
+			return err
+		},
+		"GetPodResourceRecommendations": func() error {
+			_, err := c.GetPodResourceRecommendations(ctx, "default", "", false)
+			return err
+		},
+	}
+	for name, check := range checks {
+		if err := check(); !errors.Is(err, ErrMetricsServerUnavailable) {
+			t.Fatalf("%s: expected ErrMetricsServerUnavailable, got %v", name, err)
+		}
+	}
+
+	c.discoveryClient = discoveryServing("v1", "metrics.k8s.io/v1beta1")
+	if _, err := c.GetResourceUsage(ctx, "node", "", ""); err != nil {
+		t.Fatalf("expected the metrics call to proceed once metrics.k8s.io is registered, got %v", err)
+	}
+}
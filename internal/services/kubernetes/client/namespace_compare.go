@@ -0,0 +1,307 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// maxDriftDifferencesPerObject bounds the field differences reported for one object
+	maxDriftDifferencesPerObject = 20
+	// maxDriftValueLength bounds the rendering of a differing value
+	maxDriftValueLength = 120
+	// driftAbsent renders a field missing on one side
+	driftAbsent = "<absent>"
+)
+
+// DefaultCompareKinds are the kinds compared between namespaces when none are given. Secrets are left
+// out; when requested their values are compared by digest and never returned.
+var DefaultCompareKinds = []string{
+	"ServiceAccount", "ConfigMap", "PersistentVolumeClaim",
+	"Role", "RoleBinding",
+	"Service", "Deployment", "StatefulSet", "DaemonSet", "CronJob",
+	"Ingress", "NetworkPolicy", "HorizontalPodAutoscaler", "PodDisruptionBudget",
+}
+
+// DefaultCompareIgnoredLabels are labels expected to differ between environments
+var DefaultCompareIgnoredLabels = []string{"env", "environment", "stage", "app.kubernetes.io/instance"}
+
+// NamespaceDriftObject is an object present in both namespaces whose configuration differs
+type NamespaceDriftObject struct {
+	Kind             string                `json:"kind"`
+	Name             string                `json:"name"`
+	Differences      []NamespaceFieldDrift `json:"differences"`
+	TotalDifferences int                   `json:"totalDifferences"`
+}
+
+// NamespaceFieldDrift is one differing field, with the value found in each namespace
+type NamespaceFieldDrift struct {
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// NamespaceComparison is the configuration drift between two namespaces
+type NamespaceComparison struct {
+	SourceNamespace string                 `json:"sourceNamespace"`
+	TargetNamespace string                 `json:"targetNamespace"`
+	Compared        map[string]int         `json:"compared"`
+	Identical       int                    `json:"identical"`
+	OnlyInSource    []string               `json:"onlyInSource"`
+	OnlyInTarget    []string               `json:"onlyInTarget"`
+	Differing       []NamespaceDriftObject `json:"differing"`
+	KindErrors      map[string]string      `json:"kindErrors,omitempty"`
+}
+
+// CompareNamespaces compares the objects of the given kinds (DefaultCompareKinds when empty) between two
+// namespaces, matched by kind and name. Objects are compared the way they would be exported - without
+// status, server-managed metadata, namespace and Service cluster IPs - and the ignored labels and
+// dotted field paths are left out of the comparison. Controller-owned and auto-created objects are skipped.
+func (c *Client) CompareNamespaces(ctx context.Context, sourceNamespace, targetNamespace string, kinds, ignoreLabels, ignoreFields []string) (*NamespaceComparison, error) {
+	logrus.WithFields(logrus.Fields{
+		"source": sourceNamespace, "target": targetNamespace, "kinds": len(kinds), "ignoreLabels": ignoreLabels, "ignoreFields": ignoreFields,
+	}).Debug("CompareNamespaces called")
+
+	if sourceNamespace == "" || targetNamespace == "" {
+		return nil, fmt.Errorf("source and target namespaces are required")
+	}
+	if sourceNamespace == targetNamespace {
+		return nil, fmt.Errorf("source and target namespaces must differ")
+	}
+	if len(kinds) == 0 {
+		kinds = DefaultCompareKinds
+	}
+	if ignoreLabels == nil {
+		ignoreLabels = DefaultCompareIgnoredLabels
+	}
+
+	comparison := &NamespaceComparison{
+		SourceNamespace: sourceNamespace,
+		TargetNamespace: targetNamespace,
+		Compared:        map[string]int{},
+		OnlyInSource:    []string{},
+		OnlyInTarget:    []string{},
+		Differing:       []NamespaceDriftObject{},
+	}
+	for _, kind := range kinds {
+		source, err := c.listComparableObjects(ctx, kind, sourceNamespace, ignoreLabels, ignoreFields)
+		if err == nil {
+			var target map[string]map[string]any
+			target, err = c.listComparableObjects(ctx, kind, targetNamespace, ignoreLabels, ignoreFields)
+			if err == nil {
+				comparison.addKind(source, target)
+				continue
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Kinds such as HorizontalPodAutoscaler may not be served; the other kinds are still compared
+		logrus.WithError(err).WithField("kind", kind).Debug("Skipping kind in namespace comparison")
+		if comparison.KindErrors == nil {
+			comparison.KindErrors = map[string]string{}
+		}
+		comparison.KindErrors[kind] = err.Error()
+	}
+
+	sort.Strings(comparison.OnlyInSource)
+	sort.Strings(comparison.OnlyInTarget)
+	sort.Slice(comparison.Differing, func(i, j int) bool {
+		a, b := comparison.Differing[i], comparison.Differing[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	logrus.WithFields(logrus.Fields{
+		"identical": comparison.Identical, "differing": len(comparison.Differing),
+		"onlyInSource": len(comparison.OnlyInSource), "onlyInTarget": len(comparison.OnlyInTarget),
+	}).Debug("CompareNamespaces succeeded")
+	return comparison, nil
+}
+
+// addKind records the drift between the objects of one kind, keyed by name
+func (n *NamespaceComparison) addKind(source, target map[string]map[string]any) {
+	for name, sourceObj := range source {
+		kind, _ := sourceObj["kind"].(string)
+		n.Compared[kind]++
+		targetObj, ok := target[name]
+		if !ok {
+			n.OnlyInSource = append(n.OnlyInSource, kind+"/"+name)
+			continue
+		}
+		var differences []NamespaceFieldDrift
+		diffValues("", sourceObj, targetObj, &differences)
+		if len(differences) == 0 {
+			n.Identical++
+			continue
+		}
+		drift := NamespaceDriftObject{Kind: kind, Name: name, Differences: differences, TotalDifferences: len(differences)}
+		if len(differences) > maxDriftDifferencesPerObject {
+			drift.Differences = differences[:maxDriftDifferencesPerObject]
+		}
+		n.Differing = append(n.Differing, drift)
+	}
+	for name, targetObj := range target {
+		if _, ok := source[name]; !ok {
+			kind, _ := targetObj["kind"].(string)
+			n.Compared[kind]++
+			n.OnlyInTarget = append(n.OnlyInTarget, kind+"/"+name)
+		}
+	}
+}
+
+// listComparableObjects lists kind in namespace and returns the comparable form of each object by name
+func (c *Client) listComparableObjects(ctx context.Context, kind, namespace string, ignoreLabels, ignoreFields []string) (map[string]map[string]any, error) {
+	gvr, err := c.findGroupVersionResource(kind)
+	if err != nil {
+		return nil, err
+	}
+	objects := map[string]map[string]any{}
+	opts := metav1.ListOptions{Limit: 500}
+	for {
+		list, err := c.dynamicClient.Resource(*gvr).Namespace(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if exportSkipReason(obj) != "" {
+				continue
+			}
+			objects[obj.GetName()] = comparableObject(obj, ignoreLabels, ignoreFields)
+		}
+		if opts.Continue = list.GetContinue(); opts.Continue == "" {
+			return objects, nil
+		}
+	}
+}
+
+// comparableObject returns the exportable form of obj without its namespace, the ignored labels and
+// fields, and with Secret values replaced by their digest
+func comparableObject(obj *unstructured.Unstructured, ignoreLabels, ignoreFields []string) map[string]any {
+	out := exportableObject(obj)
+	if metadata, ok := out["metadata"].(map[string]any); ok {
+		delete(metadata, "namespace")
+		deleteLabels(metadata, ignoreLabels)
+	}
+	// Pod templates usually carry the same environment labels
+	if metadata, ok, _ := unstructured.NestedMap(out, "spec", "template", "metadata"); ok {
+		deleteLabels(metadata, ignoreLabels)
+		_ = unstructured.SetNestedMap(out, metadata, "spec", "template", "metadata")
+	}
+	for _, path := range ignoreFields {
+		if fields := strings.Split(strings.Trim(path, "."), "."); len(fields) > 0 && fields[0] != "" {
+			unstructured.RemoveNestedField(out, fields...)
+		}
+	}
+	if obj.GetKind() == "Secret" {
+		for _, field := range []string{"data", "stringData"} {
+			if values, ok := out[field].(map[string]any); ok {
+				for key, value := range values {
+					sum := sha256.Sum256([]byte(fmt.Sprint(value)))
+					values[key] = "sha256:" + hex.EncodeToString(sum[:])[:12]
+				}
+			}
+		}
+	}
+	return out
+}
+
+// deleteLabels removes keys from the labels of metadata, and the labels themselves when none remain
+func deleteLabels(metadata map[string]any, keys []string) {
+	labels, ok := metadata["labels"].(map[string]any)
+	if !ok {
+		return
+	}
+	for _, key := range keys {
+		delete(labels, key)
+	}
+	if len(labels) == 0 {
+		delete(metadata, "labels")
+	}
+}
+
+// diffValues appends the differences between a and b under path. Maps are compared key by key and
+// lists of equal length element by element; lists of different lengths are reported as a whole.
+func diffValues(path string, a, b any, out *[]NamespaceFieldDrift) {
+	aMap, aIsMap := a.(map[string]any)
+	bMap, bIsMap := b.(map[string]any)
+	if aIsMap && bIsMap {
+		keys := make([]string, 0, len(aMap)+len(bMap))
+		for key := range aMap {
+			keys = append(keys, key)
+		}
+		for key := range bMap {
+			if _, ok := aMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			aValue, aOK := aMap[key]
+			bValue, bOK := bMap[key]
+			childPath := joinDriftPath(path, key)
+			if !aOK || !bOK {
+				*out = append(*out, NamespaceFieldDrift{Path: childPath, Source: formatDriftValue(aValue, aOK), Target: formatDriftValue(bValue, bOK)})
+				continue
+			}
+			diffValues(childPath, aValue, bValue, out)
+		}
+		return
+	}
+
+	aList, aIsList := a.([]any)
+	bList, bIsList := b.([]any)
+	if aIsList && bIsList && len(aList) == len(bList) {
+		for i := range aList {
+			diffValues(path+"["+strconv.Itoa(i)+"]", aList[i], bList[i], out)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*out = append(*out, NamespaceFieldDrift{Path: path, Source: formatDriftValue(a, true), Target: formatDriftValue(b, true)})
+	}
+}
+
+func joinDriftPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	if strings.Contains(key, ".") {
+		// Label and annotation keys such as app.kubernetes.io/name contain dots
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+	return path + "." + key
+}
+
+// formatDriftValue renders a value compactly, truncated to maxDriftValueLength
+func formatDriftValue(value any, present bool) string {
+	if !present {
+		return driftAbsent
+	}
+	var text string
+	if s, ok := value.(string); ok {
+		text = s
+	} else if data, err := json.Marshal(value); err == nil {
+		text = string(data)
+	} else {
+		text = fmt.Sprint(value)
+	}
+	if len(text) > maxDriftValueLength {
+		text = text[:maxDriftValueLength] + "..."
+	}
+	return text
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func TestCompareNamespaces(t *testing.T) {
+	object := func(kind, namespace, name string, fields map[string]any, labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: fields}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetUID(types.UID("uid-" + namespace + "-" + name))
+		obj.SetResourceVersion(namespace)
+		obj.SetLabels(labels)
+		return obj
+	}
+
+	objects := []runtime.Object{
+		// Identical apart from namespace, server-managed fields, cluster IP and the environment label
+		object("Service", "staging", "web", map[string]any{"spec": map[string]any{"clusterIP": "10.0.0.1", "ports": []any{map[string]any{"port": int64(80)}}}}, map[string]string{"app": "web", "env": "staging"}),
+		object("Service", "prod", "web", map[string]any{"spec": map[string]any{"clusterIP": "10.0.0.2", "ports": []any{map[string]any{"port": int64(80)}}}}, map[string]string{"app": "web", "env": "prod"}),
+		object("ConfigMap", "staging", "settings", map[string]any{"data": map[string]any{"mode": "fast", "feature.x": "on"}}, nil),
+		object("ConfigMap", "prod", "settings", map[string]any{"data": map[string]any{"mode": "safe"}}, nil),
+		object("ConfigMap", "staging", "new-flags", map[string]any{}, nil),
+		object("ConfigMap", "prod", "legacy", map[string]any{}, nil),
+		object("ConfigMap", "prod", "kube-root-ca.crt", map[string]any{}, nil),
+		object("Secret", "staging", "db", map[string]any{"type": "Opaque", "data": map[string]any{"password": "c3RhZ2luZw=="}}, nil),
+		object("Secret", "prod", "db", map[string]any{"type": "Opaque", "data": map[string]any{"password": "cHJvZA=="}}, nil),
+	}
+	services := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	secrets := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	c := &Client{
+		dynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{services: "ServiceList", configMaps: "ConfigMapList", secrets: "SecretList"},
+			objects...,
+		),
+		gvrCache:    map[string]schema.GroupVersionResource{"service": services, "configmap": configMaps, "secret": secrets},
+		cacheExpiry: time.Now().Add(time.Hour),
+	}
+
+	comparison, err := c.CompareNamespaces(context.Background(), "staging", "prod", []string{"Service", "ConfigMap", "Secret"}, nil, nil)
+	if err != nil {
+		t.Fatalf("CompareNamespaces() error = %v", err)
+	}
+	if comparison.Identical != 1 {
+		t.Fatalf("expected the Service to be identical, got %d identical: %+v", comparison.Identical, comparison.Differing)
+	}
+	if len(comparison.OnlyInSource) != 1 || comparison.OnlyInSource[0] != "ConfigMap/new-flags" {
+		t.Fatalf("unexpected onlyInSource: %v", comparison.OnlyInSource)
+	}
+	if len(comparison.OnlyInTarget) != 1 || comparison.OnlyInTarget[0] != "ConfigMap/legacy" {
+		t.Fatalf("unexpected onlyInTarget: %v", comparison.OnlyInTarget)
+	}
+	if len(comparison.Differing) != 2 {
+		t.Fatalf("expected the ConfigMap and Secret to differ, got %+v", comparison.Differing)
+	}
+
+	settings := comparison.Differing[0]
+	want := []NamespaceFieldDrift{
+		{Path: `data["feature.x"]`, Source: "on", Target: driftAbsent},
+		{Path: "data.mode", Source: "fast", Target: "safe"},
+	}
+	if settings.Kind != "ConfigMap" || settings.TotalDifferences != len(want) {
+		t.Fatalf("unexpected ConfigMap drift: %+v", settings)
+	}
+	for i, diff := range want {
+		if settings.Differences[i] != diff {
+			t.Fatalf("difference %d = %+v, want %+v", i, settings.Differences[i], diff)
+		}
+	}
+
+	secret := comparison.Differing[1].Differences
+	if len(secret) != 1 || secret[0].Path != "data.password" || secret[0].Source == "c3RhZ2luZw==" || secret[0].Source == secret[0].Target {
+		t.Fatalf("expected the Secret to differ by digest only, got %+v", secret)
+	}
+
+	// Ignored fields and an empty ignoreLabels list
+	comparison, err = c.CompareNamespaces(context.Background(), "staging", "prod", []string{"Service", "ConfigMap"}, []string{}, []string{"data"})
+	if err != nil {
+		t.Fatalf("CompareNamespaces() error = %v", err)
+	}
+	if comparison.Identical != 1 || len(comparison.Differing) != 1 || comparison.Differing[0].Differences[0].Path != "metadata.labels.env" {
+		t.Fatalf("expected only the env label to differ, got %d identical %+v", comparison.Identical, comparison.Differing)
+	}
+
+	if _, err := c.CompareNamespaces(context.Background(), "prod", "prod", nil, nil, nil); err == nil {
+		t.Fatal("expected comparing a namespace with itself to be rejected")
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// NodePod is a pod scheduled on a node with its effective resource requests
+type NodePod struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Phase      string `json:"phase"`
+	Ready      string `json:"ready"`
+	Restarts   int32  `json:"restarts"`
+	CPU        string `json:"cpuRequest,omitempty"`
+	Memory     string `json:"memoryRequest,omitempty"`
+	Terminated bool   `json:"terminated,omitempty"`
+}
+
+// NodePodTotals sums the requests of the non-terminated pods on a node against what it can allocate
+type NodePodTotals struct {
+	Pods           int                `json:"pods"`
+	TerminatedPods int                `json:"terminatedPods,omitempty"`
+	CPU            ResourceAllocation `json:"cpu"`
+	Memory         ResourceAllocation `json:"memory"`
+	PodCapacity    int64              `json:"podCapacity"`
+}
+
+// NodePods lists the pods scheduled on one node
+type NodePods struct {
+	Node   string        `json:"node"`
+	Totals NodePodTotals `json:"totals"`
+	Pods   []NodePod     `json:"pods"`
+}
+
+// GetNodePods lists the pods of every namespace scheduled on nodeName, sorted by namespace and name, with
+// phase, ready containers, restarts and effective requests. The totals sum the requests of non-terminated
+// pods, the ones the scheduler counts against the node's allocatable resources.
+func (c *Client) GetNodePods(ctx context.Context, nodeName string) (*NodePods, error) {
+	logrus.WithField("nodeName", nodeName).Debug("GetNodePods called")
+
+	node, err := c.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	var pods []corev1.Pod
+	opts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+		Limit:         500,
+	}
+	for {
+		page, err := c.clientset.CoreV1().Pods("").List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err)
+		}
+		pods = append(pods, page.Items...)
+		if page.Continue == "" {
+			break
+		}
+		opts.Continue = page.Continue
+	}
+
+	result := buildNodePods(node, pods)
+
+	logrus.WithFields(logrus.Fields{"nodeName": nodeName, "pods": len(result.Pods)}).Debug("GetNodePods succeeded")
+	return result, nil
+}
+
+// buildNodePods summarizes the pods scheduled on node
+func buildNodePods(node *corev1.Node, pods []corev1.Pod) *NodePods {
+	var cpu, memory resource.Quantity
+	result := &NodePods{Node: node.Name, Pods: make([]NodePod, 0, len(pods))}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != node.Name {
+			continue
+		}
+		requests := podResourceRequests(pod)
+		entry := NodePod{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			Phase:     string(pod.Status.Phase),
+			Restarts:  podRestarts(&pod).Restarts,
+		}
+		if q, ok := requests[corev1.ResourceCPU]; ok {
+			entry.CPU = q.String()
+		}
+		if q, ok := requests[corev1.ResourceMemory]; ok {
+			entry.Memory = q.String()
+		}
+		ready := 0
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				ready++
+			}
+		}
+		entry.Ready = fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers))
+
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			entry.Terminated = true
+			result.Totals.TerminatedPods++
+		} else {
+			cpu.Add(requests[corev1.ResourceCPU])
+			memory.Add(requests[corev1.ResourceMemory])
+			result.Totals.Pods++
+		}
+		result.Pods = append(result.Pods, entry)
+	}
+
+	sort.Slice(result.Pods, func(i, j int) bool {
+		a, b := result.Pods[i], result.Pods[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	allocatableCPU := node.Status.Allocatable[corev1.ResourceCPU]
+	allocatableMemory := node.Status.Allocatable[corev1.ResourceMemory]
+	podCapacity := node.Status.Allocatable[corev1.ResourcePods]
+	result.Totals.CPU = ResourceAllocation{
+		Allocatable: allocatableCPU.String(),
+		Requested:   cpu.String(),
+		Percent:     percentOf(cpu.MilliValue(), allocatableCPU.MilliValue()),
+	}
+	result.Totals.Memory = ResourceAllocation{
+		Allocatable: allocatableMemory.String(),
+		Requested:   memory.String(),
+		Percent:     percentOf(memory.Value(), allocatableMemory.Value()),
+	}
+	result.Totals.PodCapacity = podCapacity.Value()
+	return result
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetNodePods(t *testing.T) {
+	node := newTestNode("worker-1", true, "4", "8Gi")
+	node.Status.Allocatable[corev1.ResourcePods] = resource.MustParse("110")
+
+	pod := func(namespace, name, nodeName string, phase corev1.PodPhase, cpu, memory string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)},
+				}}},
+			},
+			Status: corev1.PodStatus{Phase: phase, ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Ready: phase == corev1.PodRunning, RestartCount: 3},
+			}},
+		}
+	}
+	clientset := fake.NewClientset(node,
+		pod("shop", "web-1", "worker-1", corev1.PodRunning, "1", "2Gi"),
+		pod("kube-system", "proxy-1", "worker-1", corev1.PodRunning, "500m", "1Gi"),
+		pod("shop", "migrate-1", "worker-1", corev1.PodSucceeded, "2", "2Gi"),
+		pod("shop", "web-2", "worker-2", corev1.PodRunning, "1", "2Gi"),
+	)
+	c := &Client{clientset: clientset}
+
+	result, err := c.GetNodePods(context.Background(), "worker-1")
+	if err != nil {
+		t.Fatalf("GetNodePods() error = %v", err)
+	}
+	var names []string
+	for _, p := range result.Pods {
+		names = append(names, p.Namespace+"/"+p.Name)
+	}
+	if len(names) != 3 || names[0] != "kube-system/proxy-1" || names[1] != "shop/migrate-1" || names[2] != "shop/web-1" {
+		t.Fatalf("unexpected pods: %v", names)
+	}
+	if web := result.Pods[2]; web.Ready != "1/1" || web.Restarts != 3 || web.CPU != "1" || web.Memory != "2Gi" {
+		t.Fatalf("unexpected web-1 entry: %+v", web)
+	}
+	if !result.Pods[1].Terminated {
+		t.Fatalf("expected the succeeded pod to be marked terminated: %+v", result.Pods[1])
+	}
+
+	totals := result.Totals
+	if totals.Pods != 2 || totals.TerminatedPods != 1 || totals.PodCapacity != 110 {
+		t.Fatalf("unexpected pod totals: %+v", totals)
+	}
+	if totals.CPU.Requested != "1500m" || totals.CPU.Percent != 37.5 || totals.Memory.Requested != "3Gi" {
+		t.Fatalf("expected totals to sum non-terminated requests, got %+v", totals)
+	}
+
+	if _, err := c.GetNodePods(context.Background(), "missing"); err == nil {
+		t.Fatal("expected an error for an unknown node")
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/constants"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -18,8 +21,10 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
@@ -27,6 +32,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 // PaginationInfo represents pagination metadata for API responses
@@ -142,8 +148,14 @@ func (c *Client) GetAPIVersions(ctx context.Context) ([]string, error) {
 
 // GetResource retrieves detailed information about a specific resource
 func (c *Client) GetResource(ctx context.Context, kind, name, namespace string) (map[string]any, error) {
-	logrus.WithFields(logrus.Fields{"kind": kind, "name": name, "namespace": namespace}).Debug("GetResource called")
-	gvr, err := c.findGroupVersionResource(kind)
+	return c.GetResourceForAPIVersion(ctx, kind, "", name, namespace)
+}
+
+// GetResourceForAPIVersion retrieves a resource, pinning the kind to apiVersion (e.g. "networking.k8s.io/v1")
+// when it is set. An empty apiVersion resolves the kind through discovery.
+func (c *Client) GetResourceForAPIVersion(ctx context.Context, kind, apiVersion, name, namespace string) (map[string]any, error) {
+	logrus.WithFields(logrus.Fields{"kind": kind, "apiVersion": apiVersion, "name": name, "namespace": namespace}).Debug("GetResource called")
+	gvr, err := c.findGroupVersionResourceForAPIVersion(kind, apiVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -171,16 +183,23 @@ func (c *Client) ListResources(ctx context.Context, kind, namespace string, labe
 
 // ListResourcesWithPagination lists instances of a specific resource type with pagination support
 func (c *Client) ListResourcesWithPagination(ctx context.Context, kind, namespace string, labelSelector, fieldSelector, continueToken string, limit int64) ([]map[string]any, error) {
+	return c.ListResourcesForAPIVersion(ctx, kind, "", namespace, labelSelector, fieldSelector, continueToken, limit)
+}
+
+// ListResourcesForAPIVersion lists instances of a resource type with pagination support, pinning the kind
+// to apiVersion when it is set
+func (c *Client) ListResourcesForAPIVersion(ctx context.Context, kind, apiVersion, namespace string, labelSelector, fieldSelector, continueToken string, limit int64) ([]map[string]any, error) {
 	logrus.WithFields(logrus.Fields{
-		"kind":      kind,
-		"namespace": namespace,
-		"labels":    labelSelector,
-		"fields":    fieldSelector,
-		"continue":  continueToken,
-		"limit":     limit,
+		"kind":       kind,
+		"apiVersion": apiVersion,
+		"namespace":  namespace,
+		"labels":     labelSelector,
+		"fields":     fieldSelector,
+		"continue":   continueToken,
+		"limit":      limit,
 	}).Debug("ListResourcesWithPagination called")
 
-	gvr, err := c.findGroupVersionResource(kind)
+	gvr, err := c.findGroupVersionResourceForAPIVersion(kind, apiVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -237,16 +256,23 @@ func (c *Client) ListResourcesWithPagination(ctx context.Context, kind, namespac
 
 // GetPaginationInfo returns pagination metadata for resource listings
 func (c *Client) GetPaginationInfo(ctx context.Context, kind, namespace string, labelSelector, fieldSelector, continueToken string, limit int64) (*PaginationInfo, error) {
+	return c.GetPaginationInfoForAPIVersion(ctx, kind, "", namespace, labelSelector, fieldSelector, continueToken, limit)
+}
+
+// GetPaginationInfoForAPIVersion returns pagination metadata for resource listings, pinning the kind to
+// apiVersion when it is set
+func (c *Client) GetPaginationInfoForAPIVersion(ctx context.Context, kind, apiVersion, namespace string, labelSelector, fieldSelector, continueToken string, limit int64) (*PaginationInfo, error) {
 	logrus.WithFields(logrus.Fields{
-		"kind":      kind,
-		"namespace": namespace,
-		"labels":    labelSelector,
-		"fields":    fieldSelector,
-		"continue":  continueToken,
-		"limit":     limit,
+		"kind":       kind,
+		"apiVersion": apiVersion,
+		"namespace":  namespace,
+		"labels":     labelSelector,
+		"fields":     fieldSelector,
+		"continue":   continueToken,
+		"limit":      limit,
 	}).Debug("GetPaginationInfo called")
 
-	gvr, err := c.findGroupVersionResource(kind)
+	gvr, err := c.findGroupVersionResourceForAPIVersion(kind, apiVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -302,8 +328,17 @@ func (c *Client) GetPaginationInfo(ctx context.Context, kind, namespace string,
 	return paginationInfo, nil
 }
 
-// GetResourcesDetail retrieves detailed information for multiple resources efficiently
-func (c *Client) GetResourcesDetail(ctx context.Context, kind string, names []string, namespace string, includeEvents, includeStatus bool) (map[string]map[string]any, error) {
+// ResourceDetailResult holds the resources fetched by GetResourcesDetail and the names that failed
+type ResourceDetailResult struct {
+	Resources map[string]map[string]any `json:"resources"`
+	Errors    map[string]string         `json:"errors,omitempty"`
+}
+
+// GetResourcesDetail retrieves detailed information for up to constants.MaxDetailBatchSize resources
+// of one kind, fetching them concurrently with bounded concurrency. A name that cannot be retrieved is
+// recorded in Errors rather than failing the batch; an error is only returned when the batch is too
+// large or the kind cannot be resolved.
+func (c *Client) GetResourcesDetail(ctx context.Context, kind string, names []string, namespace string, includeEvents, includeStatus bool) (*ResourceDetailResult, error) {
 	logrus.WithFields(logrus.Fields{
 		"kind":      kind,
 		"names":     len(names),
@@ -312,14 +347,14 @@ func (c *Client) GetResourcesDetail(ctx context.Context, kind string, names []st
 		"status":    includeStatus,
 	}).Debug("GetResourcesDetail called")
 
+	result := &ResourceDetailResult{Resources: map[string]map[string]any{}, Errors: map[string]string{}}
 	if len(names) == 0 {
-		return map[string]map[string]any{}, nil
+		return result, nil
 	}
 
-	// Limit the number of resources to prevent context overflow
-	if len(names) > 20 {
-		logrus.WithField("requested", len(names)).Warn("Limiting resource detail request to 20 resources to prevent context overflow")
-		names = names[:20]
+	// Guard against context overflow; callers split larger requests into batches
+	if len(names) > constants.MaxDetailBatchSize {
+		return nil, fmt.Errorf("%d names requested but at most %d resources can be fetched per batch", len(names), constants.MaxDetailBatchSize)
 	}
 
 	gvr, err := c.findGroupVersionResource(kind)
@@ -334,30 +369,32 @@ func (c *Client) GetResourcesDetail(ctx context.Context, kind string, names []st
 		resourceClient = c.dynamicClient.Resource(*gvr)
 	}
 
-	results := make(map[string]map[string]any)
-	var errors []string
-
-	// Batch gather resources with concurrency control
-	semaphore := make(chan struct{}, 5) // Limit concurrent requests
+	semaphore := make(chan struct{}, constants.DetailFetchConcurrency)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
+	seen := map[string]bool{}
 
 	for _, name := range names {
-		if name == "" {
+		if name == "" || seen[name] {
 			continue
 		}
+		seen[name] = true
 
 		wg.Add(1)
 		go func(resourceName string) {
 			defer wg.Done()
-			semaphore <- struct{}{}
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
 			defer func() { <-semaphore }()
 
 			// Get the main resource
 			obj, err := resourceClient.Get(ctx, resourceName, metav1.GetOptions{})
 			if err != nil {
 				mu.Lock()
-				errors = append(errors, fmt.Sprintf("Failed to get %s: %v", resourceName, err))
+				result.Errors[resourceName] = err.Error()
 				mu.Unlock()
 				return
 			}
@@ -380,30 +417,27 @@ func (c *Client) GetResourcesDetail(ctx context.Context, kind string, names []st
 			}
 
 			mu.Lock()
-			results[resourceName] = resource
+			result.Resources[resourceName] = resource
 			mu.Unlock()
-
 		}(name)
 	}
 
 	wg.Wait()
 
-	// If we have errors but some results, return partial results with error information
-	if len(errors) > 0 && len(results) > 0 {
-		results["_errors"] = map[string]any{
-			"message": "Some resources could not be retrieved",
-			"errors":  errors,
-		}
+	// Fetches skipped or failed by cancellation would otherwise look like a partial success
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(result.Errors) > 0 {
 		logrus.WithFields(logrus.Fields{
-			"successful": len(results),
-			"errors":     len(errors),
+			"successful": len(result.Resources),
+			"errors":     len(result.Errors),
 		}).Warn("Partial success in GetResourcesDetail")
-	} else if len(errors) > 0 {
-		return nil, fmt.Errorf("failed to retrieve any resources: %s", strings.Join(errors, "; "))
 	}
 
-	logrus.WithField("count", len(results)).Debug("GetResourcesDetail succeeded")
-	return results, nil
+	logrus.WithField("count", len(result.Resources)).Debug("GetResourcesDetail succeeded")
+	return result, nil
 }
 
 // getResourceEvents retrieves events related to a specific resource
@@ -465,7 +499,21 @@ func (c *Client) CreateResource(ctx context.Context, kind, apiVersion, metadataJ
 		obj.Object["spec"] = spec
 	}
 
-	gvr, err := c.findGroupVersionResourceForAPIVersion(kind, apiVersion)
+	return c.createObject(ctx, obj)
+}
+
+// CreateResourceFromManifest creates a resource from a complete object, keeping top-level fields such as data or rules
+func (c *Client) CreateResourceFromManifest(ctx context.Context, manifest map[string]any) (map[string]any, error) {
+	obj := &unstructured.Unstructured{Object: manifest}
+	logrus.WithFields(logrus.Fields{"kind": obj.GetKind(), "apiVersion": obj.GetAPIVersion()}).Debug("CreateResourceFromManifest called")
+	if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+		return nil, fmt.Errorf("manifest must set apiVersion and kind")
+	}
+	return c.createObject(ctx, obj)
+}
+
+func (c *Client) createObject(ctx context.Context, obj *unstructured.Unstructured) (map[string]any, error) {
+	gvr, err := c.findGroupVersionResourceForAPIVersion(obj.GetKind(), obj.GetAPIVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -479,15 +527,17 @@ func (c *Client) CreateResource(ctx context.Context, kind, apiVersion, metadataJ
 
 	created, err := resourceClient.Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create resource %s: %w", kind, err)
+		return nil, fmt.Errorf("failed to create resource %s: %w", obj.GetKind(), err)
 	}
 
 	logrus.Debug("CreateResource succeeded")
 	return created.UnstructuredContent(), nil
 }
 
-// UpdateResource updates an existing resource with the provided manifest
-func (c *Client) UpdateResource(ctx context.Context, kind, name, namespace string, manifest string) (map[string]any, error) {
+// UpdateResource updates an existing resource with the provided manifest. A manifest carrying a stale
+// resourceVersion fails with a conflict, unless autoResolveConflict is set: then the manifest's changes
+// are merged onto the current object where they do not overlap with changes made since.
+func (c *Client) UpdateResource(ctx context.Context, kind, name, namespace string, manifest string, autoResolveConflict bool) (map[string]any, error) {
 	logrus.WithFields(logrus.Fields{"kind": kind, "name": name, "namespace": namespace}).Debug("UpdateResource called")
 	obj := &unstructured.Unstructured{}
 	if err := json.Unmarshal([]byte(manifest), &obj.Object); err != nil {
@@ -511,6 +561,9 @@ func (c *Client) UpdateResource(ctx context.Context, kind, name, namespace strin
 	}
 
 	result, err := resourceClient.Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil && autoResolveConflict && apierrors.IsConflict(err) {
+		result, err = c.resolveUpdateConflict(ctx, resourceClient, obj, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update resource %s/%s: %w", kind, name, err)
 	}
@@ -542,6 +595,91 @@ func (c *Client) DeleteResource(ctx context.Context, kind, name, namespace strin
 	return nil
 }
 
+// BatchDeleteResult reports the outcome of a label-selector based batch delete
+type BatchDeleteResult struct {
+	// Names lists the resources deleted, or that would be deleted on a dry run
+	Names []string `json:"names"`
+	// Truncated is true when more resources matched than the per-call limit allowed
+	Truncated bool `json:"truncated"`
+	// Method is "individual", "dryRun" or "none" when nothing matched
+	Method string `json:"method"`
+	// Failed maps resource names to the error returned when deleting them
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// DeleteResourcesByLabel deletes up to limit resources of a kind in a namespace that match labelSelector.
+// Only the listed resources are deleted, one by one with a UID precondition, so that resources starting
+// to match after the list, or recreated under a listed name, are left alone and Names reports exactly
+// what was removed. Matches beyond the limit are left in place and reported via Truncated. With dryRun
+// set nothing is deleted and the matching names are returned.
+func (c *Client) DeleteResourcesByLabel(ctx context.Context, kind, namespace, labelSelector string, limit int, dryRun bool) (*BatchDeleteResult, error) {
+	logrus.WithFields(logrus.Fields{
+		"kind": kind, "namespace": namespace, "labelSelector": labelSelector, "limit": limit, "dryRun": dryRun,
+	}).Debug("DeleteResourcesByLabel called")
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", labelSelector, err)
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("label selector must not be empty")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	gvr, err := c.findGroupVersionResource(kind)
+	if err != nil {
+		return nil, err
+	}
+	resourceClient := c.dynamicClient.Resource(*gvr).Namespace(namespace)
+
+	list, err := resourceClient.List(ctx, metav1.ListOptions{LabelSelector: labelSelector, Limit: int64(limit) + 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s matching %q: %w", kind, labelSelector, err)
+	}
+	items := list.Items
+	result := &BatchDeleteResult{Names: []string{}, Truncated: len(items) > limit || list.GetContinue() != ""}
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	for _, item := range items {
+		result.Names = append(result.Names, item.GetName())
+	}
+
+	switch {
+	case dryRun:
+		result.Method = "dryRun"
+		return result, nil
+	case len(items) == 0:
+		result.Method = "none"
+		return result, nil
+	}
+
+	// A DeleteCollection would also remove resources that started matching after the list
+	result.Method = "individual"
+	deleted := make([]string, 0, len(items))
+	for _, item := range items {
+		uid := item.GetUID()
+		err := resourceClient.Delete(ctx, item.GetName(), metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+		switch {
+		case err == nil:
+			deleted = append(deleted, item.GetName())
+		case apierrors.IsNotFound(err):
+			// Already removed by someone else
+		default:
+			if result.Failed == nil {
+				result.Failed = map[string]string{}
+			}
+			result.Failed[item.GetName()] = err.Error()
+		}
+	}
+	result.Names = deleted
+
+	logrus.WithFields(logrus.Fields{"count": len(deleted), "failed": len(result.Failed)}).Debug("DeleteResourcesByLabel succeeded")
+	return result, nil
+}
+
 // normalizeKind normalizes the kind string using alias map or title case
 func normalizeKind(kind string) string {
 	normalized := strings.ToLower(kind)
@@ -551,6 +689,17 @@ func normalizeKind(kind string) string {
 	return cases.Title(language.English).String(normalized)
 }
 
+// AmbiguousKindError is returned when a kind is served by several API groups and no apiVersion was given
+type AmbiguousKindError struct {
+	Kind        string
+	APIVersions []string
+}
+
+func (e *AmbiguousKindError) Error() string {
+	return fmt.Sprintf("kind %q is served by several API groups (%s); pass apiVersion to choose one",
+		e.Kind, strings.Join(e.APIVersions, ", "))
+}
+
 // findGroupVersionResource finds the corresponding GroupVersionResource by Kind with improved caching
 func (c *Client) findGroupVersionResource(kind string) (*schema.GroupVersionResource, error) {
 	// Normalize the kind using the existing normalizeKind function
@@ -562,23 +711,7 @@ func (c *Client) findGroupVersionResource(kind string) (*schema.GroupVersionReso
 		return gvr, nil
 	}
 
-	logrus.WithField("kind", kind).Debug("Cache resolution failed, using improved discovery")
-
-	// Default to core API version first
-	gvr, err := c.findGVRUsingDiscovery(kind, "v1")
-	if err == nil {
-		c.updateGVRCache(kind, gvr)
-		return &gvr, nil
-	}
-
-	// If not found, try without version (will use preferred version)
-	gvr, err = c.findGVRUsingDiscovery(kind, "")
-	if err == nil {
-		c.updateGVRCache(kind, gvr)
-		return &gvr, nil
-	}
-
-	// If still not found, fall back to full discovery
+	logrus.WithField("kind", kind).Debug("Cache resolution failed, using discovery")
 	return c.discoverAndCacheGVR(kind)
 }
 
@@ -591,12 +724,29 @@ func (c *Client) findGroupVersionResourceForAPIVersion(kind, apiVersion string)
 		return c.findGroupVersionResource(kind)
 	}
 
-	c.cacheDiscovery.Invalidate()
 	resourceLists, err := c.cacheDiscovery.GetAPIResources()
-	if err != nil {
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
 		return nil, fmt.Errorf("failed to discover API resources for %s %s: %w", kind, apiVersion, err)
 	}
+	if gvr, err := findGVRInResourceLists(resourceLists, kind, apiVersion); err == nil {
+		return &gvr, nil
+	}
 
+	// Discovery only lists each group's preferred version, and misses groups added since it ran; ask
+	// for the pinned version directly before rediscovering everything
+	if c.discoveryClient != nil {
+		if resourceList, listErr := c.discoveryClient.ServerResourcesForGroupVersion(apiVersion); listErr == nil {
+			if gvr, listErr := findGVRInResourceLists([]*metav1.APIResourceList{resourceList}, kind, apiVersion); listErr == nil {
+				return &gvr, nil
+			}
+		}
+	}
+
+	logrus.Debug("Not found in cached discovery, discovering API resources again")
+	resourceLists, err = c.cacheDiscovery.RefreshOnMiss()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("failed to discover API resources for %s %s: %w", kind, apiVersion, err)
+	}
 	gvr, err := findGVRInResourceLists(resourceLists, kind, apiVersion)
 	if err != nil {
 		return nil, err
@@ -604,38 +754,52 @@ func (c *Client) findGroupVersionResourceForAPIVersion(kind, apiVersion string)
 	return &gvr, nil
 }
 
-// findGVRUsingDiscovery finds GVR using the discovery client
-func (c *Client) findGVRUsingDiscovery(kind, version string) (schema.GroupVersionResource, error) {
-	// Force refresh cache by invalidating it first
-	c.cacheDiscovery.Invalidate()
-
-	resourceLists, err := c.cacheDiscovery.GetAPIResources()
-	if err != nil {
-		return schema.GroupVersionResource{}, err
-	}
-
+// kindCandidates returns every resource serving kind, sorted by group and version. Subresources are skipped.
+func kindCandidates(resourceLists []*metav1.APIResourceList, kind string) []schema.GroupVersionResource {
+	var candidates []schema.GroupVersionResource
 	for _, resourceList := range resourceLists {
-		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
-		if err != nil {
+		if resourceList == nil {
 			continue
 		}
-
-		if version != "" && gv.Version != version {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
 			continue
 		}
-
 		for _, resource := range resourceList.APIResources {
-			if strings.EqualFold(resource.Kind, kind) {
-				return schema.GroupVersionResource{
-					Group:    gv.Group,
-					Version:  gv.Version,
-					Resource: resource.Name,
-				}, nil
+			if strings.Contains(resource.Name, "/") || !strings.EqualFold(resource.Kind, kind) {
+				continue
 			}
+			candidates = append(candidates, gv.WithResource(resource.Name))
 		}
 	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Group != candidates[j].Group {
+			return candidates[i].Group < candidates[j].Group
+		}
+		return candidates[i].Version < candidates[j].Version
+	})
+	return candidates
+}
 
-	return schema.GroupVersionResource{}, fmt.Errorf("resource kind %s not found", kind)
+// pickKindCandidate chooses the resource for a kind served by the given candidates. A kind in the core
+// group wins over copies in other groups (Event in events.k8s.io is the same object); otherwise a kind
+// served by more than one group is ambiguous.
+func pickKindCandidate(kind string, candidates []schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	switch len(candidates) {
+	case 0:
+		return schema.GroupVersionResource{}, fmt.Errorf("resource kind %q not found", kind)
+	case 1:
+		return candidates[0], nil
+	}
+
+	apiVersions := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Group == "" {
+			return candidate, nil
+		}
+		apiVersions = append(apiVersions, candidate.GroupVersion().String())
+	}
+	return schema.GroupVersionResource{}, &AmbiguousKindError{Kind: kind, APIVersions: apiVersions}
 }
 
 func findGVRInResourceLists(resourceLists []*metav1.APIResourceList, kind, apiVersion string) (schema.GroupVersionResource, error) {
@@ -666,56 +830,80 @@ func findGVRInResourceLists(resourceLists []*metav1.APIResourceList, kind, apiVe
 	return schema.GroupVersionResource{}, fmt.Errorf("resource kind %q with apiVersion %q not found", kind, apiVersion)
 }
 
-// discoverAndCacheGVR discovers GVR via API and updates cache
-func (c *Client) discoverAndCacheGVR(kind string) (*schema.GroupVersionResource, error) {
-	// Force refresh cache by invalidating it first
-	c.cacheDiscovery.Invalidate()
-
+// discoveredResourceLists returns the cached API resources. When found reports that what the caller
+// looks for is missing, for example a kind whose CRD was installed after the cache was filled, the
+// resources are discovered again, subject to the miss refresh interval.
+func (c *Client) discoveredResourceLists(found func([]*metav1.APIResourceList) bool) ([]*metav1.APIResourceList, error) {
 	resourceLists, err := c.cacheDiscovery.GetAPIResources()
 	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
-		return nil, fmt.Errorf("failed to get API resources: %w", err)
+		return nil, err
+	}
+	if found(resourceLists) {
+		return resourceLists, nil
 	}
 
-	c.gvrCacheMux.Lock()
-	defer c.gvrCacheMux.Unlock()
+	logrus.Debug("Not found in cached discovery, discovering API resources again")
+	resourceLists, err = c.cacheDiscovery.RefreshOnMiss()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+	return resourceLists, nil
+}
 
-	// Update cache expiry time
-	c.cacheExpiry = time.Now().Add(c.cacheTTL)
+// discoverAndCacheGVR discovers GVR via API and updates cache. Every kind that resolves unambiguously
+// is cached; ambiguous kinds are left out so they are always reported to the caller.
+func (c *Client) discoverAndCacheGVR(kind string) (*schema.GroupVersionResource, error) {
+	resourceLists, err := c.discoveredResourceLists(func(resourceLists []*metav1.APIResourceList) bool {
+		return len(kindCandidates(resourceLists, kind)) > 0
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API resources: %w", err)
+	}
 
+	kinds := map[string]string{}
 	for _, resourceList := range resourceLists {
-		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
-		if err != nil {
+		if resourceList == nil {
 			continue
 		}
-
 		for _, resource := range resourceList.APIResources {
-			// Skip subresources like pods/status
-			if strings.Contains(resource.Name, "/") {
-				continue
-			}
+			kinds[toLower(resource.Kind)] = resource.Kind
+		}
+	}
 
-			// Cache all discovered resources for future lookups
-			gvr := schema.GroupVersionResource{
-				Group:    gv.Group,
-				Version:  gv.Version,
-				Resource: resource.Name,
-			}
-			c.gvrCache[toLower(resource.Kind)] = gvr
+	c.gvrCacheMux.Lock()
+	defer c.gvrCacheMux.Unlock()
 
-			// Check if this is our target kind
-			if strings.EqualFold(resource.Kind, kind) {
-				logrus.WithField("kind", kind).Debug("Resolved GVR via discovery and cached")
-				return &gvr, nil
-			}
+	// Update cache expiry time
+	c.cacheExpiry = time.Now().Add(c.cacheTTL)
+
+	for key, discoveredKind := range kinds {
+		if gvr, err := pickKindCandidate(discoveredKind, kindCandidates(resourceLists, discoveredKind)); err == nil {
+			c.gvrCache[key] = gvr
 		}
 	}
 
-	return nil, fmt.Errorf("resource kind %q not found", kind)
+	gvr, err := pickKindCandidate(kind, kindCandidates(resourceLists, kind))
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("kind", kind).Debug("Resolved GVR via discovery and cached")
+	return &gvr, nil
 }
 
-// ExecCommand executes a command in a container
-func (c *Client) ExecCommand(ctx context.Context, podName, namespace, container string, command []string) (string, error) {
-	logrus.WithFields(logrus.Fields{"pod": podName, "ns": namespace, "container": container, "cmd": strings.Join(command, " ")}).Debug("ExecCommand called")
+// ExecResult holds the separated output streams and exit status of a command run in a container
+type ExecResult struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exitCode"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ExecCommand executes a command in a container. A non-empty stdin is piped to the command's standard
+// input, like 'kubectl exec -i'. A command that exits non-zero is not an error: its exit code is
+// reported in the result. When the stream fails, the output captured so far is returned together with
+// the error, which is also recorded in the result's Error field.
+func (c *Client) ExecCommand(ctx context.Context, podName, namespace, container string, command []string, stdin string) (*ExecResult, error) {
+	logrus.WithFields(logrus.Fields{"pod": podName, "ns": namespace, "container": container, "cmd": strings.Join(command, " "), "stdin": stdin != ""}).Debug("ExecCommand called")
 	req := c.clientset.CoreV1().RESTClient().
 		Post().
 		Resource("pods").
@@ -725,7 +913,7 @@ func (c *Client) ExecCommand(ctx context.Context, podName, namespace, container
 		VersionedParams(&corev1.PodExecOptions{
 			Container: container,
 			Command:   command,
-			Stdin:     false,
+			Stdin:     stdin != "",
 			Stdout:    true,
 			Stderr:    true,
 			TTY:       false,
@@ -733,22 +921,41 @@ func (c *Client) ExecCommand(ctx context.Context, podName, namespace, container
 
 	exec, err := remotecommand.NewSPDYExecutor(c.restConfig, "POST", req.URL())
 	if err != nil {
-		return "", fmt.Errorf("failed to create executor: %w", err)
+		return nil, fmt.Errorf("failed to create executor: %w", err)
 	}
 
+	var stdinReader io.Reader
+	if stdin != "" {
+		stdinReader = strings.NewReader(stdin)
+	}
 	var stdout, stderr bytes.Buffer
 	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdin:  nil,
+		Stdin:  stdinReader,
 		Stdout: &stdout,
 		Stderr: &stderr,
 		Tty:    false,
 	})
+	result := &ExecResult{Stdout: stdout.String(), Stderr: stderr.String()}
 	if err != nil {
-		return "", fmt.Errorf("command execution failed: %w, stderr: %s", err, stderr.String())
+		exitCode, exited := commandExitCode(err)
+		if !exited {
+			result.Error = err.Error()
+			return result, fmt.Errorf("command execution failed: %w, stderr: %s", err, stderr.String())
+		}
+		result.ExitCode = exitCode
 	}
 
-	logrus.Debug("ExecCommand succeeded")
-	return stdout.String(), nil
+	logrus.WithField("exitCode", result.ExitCode).Debug("ExecCommand succeeded")
+	return result, nil
+}
+
+// commandExitCode returns the exit status carried by a stream error when the remote command exited
+func commandExitCode(err error) (int, bool) {
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		return exitErr.ExitStatus(), true
+	}
+	return 0, false
 }
 
 // GetContainerLog retrieves logs for a specific container in a pod
@@ -800,40 +1007,84 @@ func (c *Client) CheckPermissions(ctx context.Context, verb, resourceName, resou
 	return response.Status.Allowed, nil
 }
 
-// ScaleResource scales a resource (placeholder implementation)
-func (c *Client) ScaleResource(ctx context.Context, gvr schema.GroupVersionResource, name, namespace string, replicas int32) error {
-	logrus.WithFields(logrus.Fields{"group": gvr.Group, "resource": gvr.Resource, "name": name, "ns": namespace, "replicas": replicas}).Debug("ScaleResource called")
-	restClient := c.clientset.CoreV1().RESTClient()
+// ScaleResult reports the replica change made (or previewed) by a scale operation
+type ScaleResult struct {
+	Kind             string `json:"kind"`
+	Name             string `json:"name"`
+	Namespace        string `json:"namespace"`
+	PreviousReplicas int32  `json:"previousReplicas"`
+	Replicas         int32  `json:"replicas"`
+	Changed          bool   `json:"changed"`
+	DryRun           bool   `json:"dryRun"`
+}
+
+// scales returns the scale subresource client, building it from discovery on first use
+func (c *Client) scales() (scale.ScalesGetter, error) {
+	if c.scaleClient != nil {
+		return c.scaleClient, nil
+	}
 	mapper, err := genericclioptions.NewTestConfigFlags().ToRESTMapper()
 	if err != nil {
-		return fmt.Errorf("failed to create REST mapper: %w", err)
+		return nil, fmt.Errorf("failed to create REST mapper: %w", err)
 	}
-
 	resolver := scale.NewDiscoveryScaleKindResolver(c.discoveryClient)
-	scaleClient := scale.New(restClient, mapper, dynamic.LegacyAPIPathResolverFunc, resolver)
+	return scale.New(c.clientset.CoreV1().RESTClient(), mapper, dynamic.LegacyAPIPathResolverFunc, resolver), nil
+}
 
-	gr := schema.GroupResource{Group: gvr.Group, Resource: gvr.Resource}
-	scaleObj, err := scaleClient.Scales(namespace).Get(ctx, gr, name, metav1.GetOptions{})
+// ScaleResource sets the replica count of a resource through its scale subresource. With dryRun the update
+// is sent as a server-side dry run, so admission and validation still apply but nothing is persisted.
+func (c *Client) ScaleResource(ctx context.Context, gvr schema.GroupVersionResource, name, namespace string, replicas int32, dryRun bool) (*ScaleResult, error) {
+	logrus.WithFields(logrus.Fields{"group": gvr.Group, "resource": gvr.Resource, "name": name, "ns": namespace, "replicas": replicas, "dryRun": dryRun}).Debug("ScaleResource called")
+	scaleClient, err := c.scales()
 	if err != nil {
-		return fmt.Errorf("failed to get scale for resource: %w", err)
+		return nil, err
 	}
 
-	scaleObj.Spec.Replicas = replicas
-	_, err = scaleClient.Scales(namespace).Update(ctx, gr, scaleObj, metav1.UpdateOptions{})
+	gr := schema.GroupResource{Group: gvr.Group, Resource: gvr.Resource}
+	updateOptions := metav1.UpdateOptions{}
+	if dryRun {
+		updateOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	var result *ScaleResult
+	err = retryOnConflict(ctx, "scale "+gvr.Resource+"/"+name, func() error {
+		scaleObj, err := scaleClient.Scales(namespace).Get(ctx, gr, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get scale for resource: %w", err)
+		}
+		result = &ScaleResult{
+			Name:             name,
+			Namespace:        namespace,
+			PreviousReplicas: scaleObj.Spec.Replicas,
+			Replicas:         replicas,
+			Changed:          scaleObj.Spec.Replicas != replicas,
+			DryRun:           dryRun,
+		}
+		scaleObj.Spec.Replicas = replicas
+		if _, err := scaleClient.Scales(namespace).Update(ctx, gr, scaleObj, updateOptions); err != nil {
+			return fmt.Errorf("failed to update scale for resource: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update scale for resource: %w", err)
+		return nil, err
 	}
 
-	logrus.Debug("ScaleResource succeeded")
-	return nil
+	logrus.WithFields(logrus.Fields{"previousReplicas": result.PreviousReplicas, "dryRun": dryRun}).Debug("ScaleResource succeeded")
+	return result, nil
 }
 
 // ScaleResourceByKind resolves GVR by kind and scales the resource
-func (c *Client) ScaleResourceByKind(ctx context.Context, kind, name, namespace string, replicas int32) error {
-	logrus.WithFields(logrus.Fields{"kind": kind, "name": name, "ns": namespace, "replicas": replicas}).Debug("ScaleResourceByKind called")
+func (c *Client) ScaleResourceByKind(ctx context.Context, kind, name, namespace string, replicas int32, dryRun bool) (*ScaleResult, error) {
+	logrus.WithFields(logrus.Fields{"kind": kind, "name": name, "ns": namespace, "replicas": replicas, "dryRun": dryRun}).Debug("ScaleResourceByKind called")
 	gvr, err := c.findGroupVersionResource(kind)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	result, err := c.ScaleResource(ctx, *gvr, name, namespace, replicas, dryRun)
+	if err != nil {
+		return nil, err
 	}
-	return c.ScaleResource(ctx, *gvr, name, namespace, replicas)
+	result.Kind = normalizeKind(kind)
+	return result, nil
 }
@@ -3,11 +3,14 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 func TestNormalizeKindDetailed(t *testing.T) {
@@ -130,7 +133,7 @@ func TestUpdateResourceNameMismatch(t *testing.T) {
 	// Create a manifest with a different name
 	manifest := `{"metadata":{"name":"different-name"}}`
 
-	_, err := client.UpdateResource(context.Background(), "Pod", "expected-name", "default", manifest)
+	_, err := client.UpdateResource(context.Background(), "Pod", "expected-name", "default", manifest, false)
 	if err == nil {
 		t.Error("Expected error for name mismatch, got nil")
 	}
@@ -184,6 +187,43 @@ func TestFindGVRInResourceListsUsesExactAPIVersion(t *testing.T) {
 	}
 }
 
+func TestPickKindCandidate(t *testing.T) {
+	resourceLists := []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "events", Kind: "Event"},
+			{Name: "pods", Kind: "Pod"},
+			{Name: "pods/log", Kind: "Pod"},
+		}},
+		{GroupVersion: "events.k8s.io/v1", APIResources: []metav1.APIResource{{Name: "events", Kind: "Event"}}},
+		{GroupVersion: "argoproj.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "applications", Kind: "Application"}}},
+		{GroupVersion: "app.k8s.io/v1beta1", APIResources: []metav1.APIResource{{Name: "applications", Kind: "Application"}}},
+	}
+
+	gvr, err := pickKindCandidate("Pod", kindCandidates(resourceLists, "Pod"))
+	if err != nil || gvr.Resource != "pods" || gvr.Group != "" {
+		t.Fatalf("expected pods without the log subresource, got %#v (%v)", gvr, err)
+	}
+
+	gvr, err = pickKindCandidate("Event", kindCandidates(resourceLists, "Event"))
+	if err != nil || gvr.Group != "" {
+		t.Fatalf("expected the core Event to win, got %#v (%v)", gvr, err)
+	}
+
+	_, err = pickKindCandidate("Application", kindCandidates(resourceLists, "Application"))
+	var ambiguous *AmbiguousKindError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected an AmbiguousKindError, got %v", err)
+	}
+	want := []string{"app.k8s.io/v1beta1", "argoproj.io/v1alpha1"}
+	if fmt.Sprint(ambiguous.APIVersions) != fmt.Sprint(want) {
+		t.Fatalf("candidate apiVersions = %v, want %v", ambiguous.APIVersions, want)
+	}
+
+	if _, err := pickKindCandidate("Widget", kindCandidates(resourceLists, "Widget")); err == nil || errors.As(err, &ambiguous) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
 func TestScaleResourceByKindValidation(t *testing.T) {
 	// Test validation logic for empty kind parameter
 	kind := ""
@@ -211,6 +251,29 @@ func TestExecCommandValidation(t *testing.T) {
 	}
 }
 
+func TestCommandExitCode(t *testing.T) {
+	exitErr := utilexec.CodeExitError{Err: errors.New("command terminated with exit code 3"), Code: 3}
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   int
+		wantExited bool
+	}{
+		{name: "exit error", err: exitErr, wantCode: 3, wantExited: true},
+		{name: "wrapped exit error", err: fmt.Errorf("stream: %w", exitErr), wantCode: 3, wantExited: true},
+		{name: "stream error", err: errors.New("connection reset"), wantExited: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, exited := commandExitCode(tt.err)
+			if code != tt.wantCode || exited != tt.wantExited {
+				t.Fatalf("commandExitCode() = (%d, %v), want (%d, %v)", code, exited, tt.wantCode, tt.wantExited)
+			}
+		})
+	}
+}
+
 func TestGetContainerLogValidation(t *testing.T) {
 	// Test negative tail lines
 	tailLines := int64(-1)
@@ -259,3 +322,26 @@ func indexOf(s, substr string) int {
 	}
 	return -1
 }
+
+func TestGetResourcesDetail(t *testing.T) {
+	c, _ := newBatchDeleteTestClient(newTestConfigMap("a", nil), newTestConfigMap("b", nil))
+
+	result, err := c.GetResourcesDetail(context.Background(), "ConfigMap", []string{"a", "missing", "b", "a"}, "default", false, true)
+	if err != nil {
+		t.Fatalf("GetResourcesDetail() error = %v", err)
+	}
+	if len(result.Resources) != 2 || result.Resources["a"] == nil || result.Resources["b"] == nil {
+		t.Fatalf("unexpected resources: %+v", result.Resources)
+	}
+	if len(result.Errors) != 1 || result.Errors["missing"] == "" {
+		t.Fatalf("expected a per-name error for the missing resource, got %+v", result.Errors)
+	}
+
+	tooMany := make([]string, 21)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("cm-%d", i)
+	}
+	if _, err := c.GetResourcesDetail(context.Background(), "ConfigMap", tooMany, "default", false, true); err == nil {
+		t.Fatal("expected an error for a batch above the maximum size")
+	}
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodLogs holds the logs of every container of a pod, interleaved by time and prefixed per line with
+// the container name
+type PodLogs struct {
+	Pod        string            `json:"pod"`
+	Namespace  string            `json:"namespace"`
+	Containers []string          `json:"containers"`
+	Logs       string            `json:"logs"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
+
+// podLogLine is one log line with the timestamp the kubelet recorded for it
+type podLogLine struct {
+	container string
+	time      time.Time
+	text      string
+}
+
+// GetAllContainerLogs reads the last tailLines of each container of a pod, like
+// 'kubectl logs --all-containers --prefix', and interleaves them by their kubelet timestamps. Init
+// containers are included once they have started. A container whose log cannot be read is reported
+// under Errors instead of failing the call.
+func (c *Client) GetAllContainerLogs(ctx context.Context, podName, namespace string, tailLines int64) (*PodLogs, error) {
+	logrus.WithFields(logrus.Fields{"pod": podName, "ns": namespace, "tail": tailLines}).Debug("GetAllContainerLogs called")
+
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s/%s: %w", namespace, podName, err)
+	}
+
+	result := &PodLogs{Pod: podName, Namespace: namespace, Containers: podLogContainers(pod)}
+	var lines []podLogLine
+	for _, container := range result.Containers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		containerLines, err := c.timestampedContainerLog(ctx, podName, namespace, container, tailLines)
+		if err != nil {
+			if result.Errors == nil {
+				result.Errors = map[string]string{}
+			}
+			result.Errors[container] = err.Error()
+			continue
+		}
+		lines = append(lines, containerLines...)
+	}
+
+	result.Logs = interleavePodLogs(lines)
+
+	logrus.WithFields(logrus.Fields{"containers": len(result.Containers), "lines": len(lines), "errors": len(result.Errors)}).Debug("GetAllContainerLogs succeeded")
+	return result, nil
+}
+
+// interleavePodLogs orders lines by time, keeping the order of lines sharing a timestamp, and prefixes
+// each with its container
+func interleavePodLogs(lines []podLogLine) string {
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].time.Before(lines[j].time) })
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString("[" + line.container + "] " + line.text + "\n")
+	}
+	return b.String()
+}
+
+// podLogContainers lists the containers of a pod that have logs: started init containers, then the
+// app containers in spec order
+func podLogContainers(pod *corev1.Pod) []string {
+	started := make(map[string]bool, len(pod.Status.InitContainerStatuses))
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.State.Running != nil || status.State.Terminated != nil || status.LastTerminationState.Terminated != nil {
+			started[status.Name] = true
+		}
+	}
+	var containers []string
+	for _, container := range pod.Spec.InitContainers {
+		if started[container.Name] {
+			containers = append(containers, container.Name)
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+	return containers
+}
+
+// timestampedContainerLog reads the tail of one container's log with kubelet timestamps
+func (c *Client) timestampedContainerLog(ctx context.Context, podName, namespace, container string, tailLines int64) ([]podLogLine, error) {
+	raw, err := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container:  container,
+		TailLines:  &tailLines,
+		Timestamps: true,
+	}).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs: %w", err)
+	}
+	return parseTimestampedLog(container, string(raw)), nil
+}
+
+// parseTimestampedLog splits a log read with timestamps into lines. A line without a parsable timestamp,
+// such as a continuation, takes the time of the line before it.
+func parseTimestampedLog(container, raw string) []podLogLine {
+	var lines []podLogLine
+	var last time.Time
+	for _, text := range strings.Split(strings.TrimSuffix(raw, "\n"), "\n") {
+		if text == "" && len(lines) == 0 {
+			continue
+		}
+		if stamp, rest, ok := strings.Cut(text, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				last, text = t, rest
+			}
+		}
+		lines = append(lines, podLogLine{container: container, time: last, text: text})
+	}
+	return lines
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInterleavePodLogs(t *testing.T) {
+	app := parseTimestampedLog("app", "2024-05-01T10:00:01.000000000Z starting\n2024-05-01T10:00:03.000000000Z ready\n  continued\n")
+	proxy := parseTimestampedLog("proxy", "2024-05-01T10:00:02.000000000Z listening on :15001\n")
+	empty := parseTimestampedLog("idle", "")
+	if len(empty) != 0 {
+		t.Fatalf("expected no lines for an empty log, got %+v", empty)
+	}
+
+	got := interleavePodLogs(append(app, proxy...))
+	want := "[app] starting\n[proxy] listening on :15001\n[app] ready\n[app]   continued\n"
+	if got != want {
+		t.Fatalf("interleavePodLogs() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGetAllContainerLogs(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate"}, {Name: "warmup"}},
+			Containers:     []corev1.Container{{Name: "app"}, {Name: "proxy"}},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: "migrate", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}},
+				{Name: "warmup", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}},
+			},
+		},
+	}
+	c := &Client{clientset: fake.NewClientset(pod)}
+
+	logs, err := c.GetAllContainerLogs(context.Background(), "web-0", "shop", 10)
+	if err != nil {
+		t.Fatalf("GetAllContainerLogs() error = %v", err)
+	}
+	if len(logs.Containers) != 3 || logs.Containers[0] != "migrate" || logs.Containers[1] != "app" || logs.Containers[2] != "proxy" {
+		t.Fatalf("expected the started init container and the app containers, got %v", logs.Containers)
+	}
+	// The fake clientset answers every log request with "fake logs"
+	if logs.Logs != "[migrate] fake logs\n[app] fake logs\n[proxy] fake logs\n" {
+		t.Fatalf("unexpected logs: %q", logs.Logs)
+	}
+
+	if _, err := c.GetAllContainerLogs(context.Background(), "missing", "shop", 10); err == nil {
+		t.Fatal("expected an error for a missing pod")
+	}
+}
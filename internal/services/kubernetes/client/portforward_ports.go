@@ -0,0 +1,104 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PortMapping forwards a local port to a pod port. A LocalPort of 0 picks a free local port.
+type PortMapping struct {
+	LocalPort int32
+	PodPort   int32
+}
+
+// PortForwardMapping reports the outcome of one mapping of a port forward session.
+type PortForwardMapping struct {
+	LocalPort    int32                    `json:"localPort"`
+	PodPort      int32                    `json:"podPort"`
+	ServicePort  int32                    `json:"servicePort,omitempty"`
+	Established  bool                     `json:"established"`
+	Error        string                   `json:"error,omitempty"`
+	Verification *PortForwardVerification `json:"verification,omitempty"`
+}
+
+// ParsePortMappings parses kubectl-style port mappings ("8080:80", or "9090" for 9090:9090,
+// ":80" for a free local port) and rejects local ports requested more than once.
+func ParsePortMappings(specs []string) ([]PortMapping, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("at least one port mapping is required")
+	}
+
+	mappings := make([]PortMapping, 0, len(specs))
+	used := map[int32]string{}
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		local, pod, found := strings.Cut(spec, ":")
+		if !found {
+			local, pod = spec, spec
+		}
+
+		podPort, err := parsePort(pod, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port mapping %q: pod port: %w", spec, err)
+		}
+		localPort, err := parsePort(local, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port mapping %q: local port: %w", spec, err)
+		}
+
+		if localPort != 0 {
+			if previous, ok := used[localPort]; ok {
+				return nil, fmt.Errorf("local port %d is used by both %q and %q", localPort, previous, spec)
+			}
+			used[localPort] = spec
+		}
+		mappings = append(mappings, PortMapping{LocalPort: localPort, PodPort: podPort})
+	}
+	return mappings, nil
+}
+
+func parsePort(value string, allowZero bool) (int32, error) {
+	if value == "" && allowZero {
+		return 0, nil
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	if port < 0 || port > 65535 || (port == 0 && !allowZero) {
+		return 0, fmt.Errorf("%d is out of range", port)
+	}
+	return int32(port), nil
+}
+
+// listenFailurePattern matches the per-port bind failures the port forwarder writes to its error stream
+var listenFailurePattern = regexp.MustCompile(`Unable to listen on port (\d+): (.*)`)
+
+// portForwardErrors is the error stream of a port forwarder, which writes to it from its own goroutines
+type portForwardErrors struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (e *portForwardErrors) Write(p []byte) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.buf.Write(p)
+}
+
+// listenFailures returns the bind error of each local port the forwarder could not listen on
+func (e *portForwardErrors) listenFailures() map[int32]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	failures := map[int32]string{}
+	for _, match := range listenFailurePattern.FindAllStringSubmatch(e.buf.String(), -1) {
+		if port, err := strconv.Atoi(match[1]); err == nil {
+			failures[int32(port)] = match[2]
+		}
+	}
+	return failures
+}
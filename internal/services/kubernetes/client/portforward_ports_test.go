@@ -0,0 +1,48 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParsePortMappings(t *testing.T) {
+	mappings, err := ParsePortMappings([]string{"8080:80", " 9090 ", ":3000"})
+	if err != nil {
+		t.Fatalf("ParsePortMappings() error = %v", err)
+	}
+	want := []PortMapping{{LocalPort: 8080, PodPort: 80}, {LocalPort: 9090, PodPort: 9090}, {LocalPort: 0, PodPort: 3000}}
+	if fmt.Sprint(mappings) != fmt.Sprint(want) {
+		t.Fatalf("ParsePortMappings() = %v, want %v", mappings, want)
+	}
+
+	for spec, wantErr := range map[string]string{
+		"8080:":     "pod port",
+		"http:80":   "not a number",
+		"8080:0":    "out of range",
+		"70000:80":  "out of range",
+		"8080:8080": "used by both",
+	} {
+		specs := []string{spec}
+		if spec == "8080:8080" {
+			specs = []string{"8080:80", spec}
+		}
+		if _, err := ParsePortMappings(specs); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Fatalf("ParsePortMappings(%v) error = %v, want %q", specs, err, wantErr)
+		}
+	}
+	if _, err := ParsePortMappings([]string{":80", ":81"}); err != nil {
+		t.Fatalf("expected several free local ports to be allowed, got %v", err)
+	}
+}
+
+func TestPortForwardListenFailures(t *testing.T) {
+	errOut := &portForwardErrors{}
+	_, _ = fmt.Fprintf(errOut, "Unable to listen on port %d: %v\n", 9090, "listen tcp4 127.0.0.1:9090: bind: address already in use")
+	_, _ = fmt.Fprintln(errOut, "error copying from remote stream to local connection")
+
+	failures := errOut.listenFailures()
+	if len(failures) != 1 || !strings.Contains(failures[9090], "address already in use") {
+		t.Fatalf("unexpected listen failures: %v", failures)
+	}
+}
@@ -0,0 +1,304 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Kinds of resource a port forward can target.
+const (
+	PortForwardKindPod        = "Pod"
+	PortForwardKindService    = "Service"
+	PortForwardKindDeployment = "Deployment"
+)
+
+var (
+	// portForwardReselectInterval is the wait before each attempt to find a new pod for a forward
+	// whose pod went away
+	portForwardReselectInterval = 2 * time.Second
+	// portForwardReselectAttempts bounds how often a new pod is looked for before the forward gives up
+	portForwardReselectAttempts = 30
+)
+
+// PortForwardTarget names the resource a port forward goes to. For a Service or Deployment a
+// ready backing pod is chosen, and another one when that pod goes away during the session.
+type PortForwardTarget struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// NormalizePortForwardKind maps a kind or its kubectl short name (po, svc, deploy) to one of the
+// PortForwardKind constants. An empty kind means Pod.
+func NormalizePortForwardKind(kind string) (string, error) {
+	switch strings.ToLower(kind) {
+	case "", "pod", "pods", "po":
+		return PortForwardKindPod, nil
+	case "service", "services", "svc":
+		return PortForwardKindService, nil
+	case "deployment", "deployments", "deploy":
+		return PortForwardKindDeployment, nil
+	default:
+		return "", fmt.Errorf("unsupported port forward kind %q: use Pod, Service or Deployment", kind)
+	}
+}
+
+// PortForwardSession is a port forward running in the background.
+type PortForwardSession struct {
+	Target   PortForwardTarget
+	Mappings []PortForwardMapping
+
+	mu      sync.Mutex
+	pod     string
+	stopped bool
+	stopPod func()
+}
+
+// Pod returns the name of the pod the session currently forwards to
+func (s *PortForwardSession) Pod() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pod
+}
+
+// Stop ends the session; it no longer moves to another pod afterwards
+func (s *PortForwardSession) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	s.stopPod()
+}
+
+func (s *PortForwardSession) isStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
+
+// switchPod records the forward to a newly chosen pod, or reports false when the session was
+// stopped in the meantime
+func (s *PortForwardSession) switchPod(pod string, stop func()) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return false
+	}
+	s.pod = pod
+	s.stopPod = stop
+	return true
+}
+
+// StartPortForward forwards ports to target, like 'kubectl port-forward svc/name'. For a Service
+// the requested ports are service ports, forwarded to their target ports on the chosen pod. It
+// fails only when no mapping could be established.
+func (c *Client) StartPortForward(ctx context.Context, target PortForwardTarget, mappings []PortMapping, address string) (*PortForwardSession, error) {
+	kind, err := NormalizePortForwardKind(target.Kind)
+	if err != nil {
+		return nil, err
+	}
+	target.Kind = kind
+
+	pod, podMappings, err := c.resolvePortForwardPod(ctx, target, mappings)
+	if err != nil {
+		return nil, err
+	}
+	forward, err := c.startPortForward(ctx, pod, target.Namespace, podMappings, address)
+	if err != nil {
+		return nil, err
+	}
+	if kind == PortForwardKindService {
+		for i := range forward.mappings {
+			forward.mappings[i].ServicePort = mappings[i].PodPort
+		}
+	}
+
+	session := &PortForwardSession{Target: target, Mappings: forward.mappings, pod: pod, stopPod: forward.stop}
+	if kind != PortForwardKindPod {
+		// Keep the local ports that were bound so clients can reconnect to the same ones
+		var kept []PortMapping
+		for i, result := range forward.mappings {
+			if result.Established {
+				kept = append(kept, PortMapping{LocalPort: result.LocalPort, PodPort: mappings[i].PodPort})
+			}
+		}
+		go c.followPortForwardTarget(session, kept, address, forward.done)
+	}
+	return session, nil
+}
+
+// followPortForwardTarget moves the forward of a Service or Deployment to another ready pod each
+// time the forwarded pod goes away, until the session is stopped or no pod can be found.
+func (c *Client) followPortForwardTarget(session *PortForwardSession, mappings []PortMapping, address string, done <-chan struct{}) {
+	target := session.Target
+	for {
+		<-done
+		if session.isStopped() {
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"kind":      target.Kind,
+			"name":      target.Name,
+			"namespace": target.Namespace,
+			"pod":       session.Pod(),
+		}).Warn("Port forward lost its pod, selecting another one")
+
+		pod, forward, err := c.reselectPortForwardPod(session, mappings, address)
+		if err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"kind":      target.Kind,
+				"name":      target.Name,
+				"namespace": target.Namespace,
+			}).Error("Port forward ended: no pod to forward to")
+			return
+		}
+		if forward == nil {
+			return
+		}
+		if !session.switchPod(pod, forward.stop) {
+			forward.stop()
+			return
+		}
+		done = forward.done
+	}
+}
+
+// reselectPortForwardPod retries resolving and forwarding to a pod of the session's target. It
+// returns a nil forward when the session is stopped while retrying.
+func (c *Client) reselectPortForwardPod(session *PortForwardSession, mappings []PortMapping, address string) (string, *podForward, error) {
+	var lastErr error
+	for attempt := 0; attempt < portForwardReselectAttempts; attempt++ {
+		time.Sleep(portForwardReselectInterval)
+		if session.isStopped() {
+			return "", nil, nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		pod, podMappings, err := c.resolvePortForwardPod(ctx, session.Target, mappings)
+		var forward *podForward
+		if err == nil {
+			forward, err = c.startPortForward(ctx, pod, session.Target.Namespace, podMappings, address)
+		}
+		cancel()
+		if err == nil {
+			return pod, forward, nil
+		}
+		lastErr = err
+	}
+	return "", nil, lastErr
+}
+
+// resolvePortForwardPod returns the pod to forward to for target and the mappings translated to
+// that pod's ports.
+func (c *Client) resolvePortForwardPod(ctx context.Context, target PortForwardTarget, mappings []PortMapping) (string, []PortMapping, error) {
+	switch target.Kind {
+	case PortForwardKindPod:
+		return target.Name, mappings, nil
+
+	case PortForwardKindService:
+		svc, err := c.clientset.CoreV1().Services(target.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get service %s/%s: %w", target.Namespace, target.Name, err)
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return "", nil, fmt.Errorf("service %s/%s has no selector, so it has no pods to forward to", target.Namespace, target.Name)
+		}
+		pod, err := c.readyPod(ctx, target, labels.SelectorFromSet(svc.Spec.Selector).String())
+		if err != nil {
+			return "", nil, err
+		}
+		podMappings := make([]PortMapping, len(mappings))
+		for i, mapping := range mappings {
+			podPort, err := servicePortToPodPort(svc, pod, mapping.PodPort)
+			if err != nil {
+				return "", nil, err
+			}
+			podMappings[i] = PortMapping{LocalPort: mapping.LocalPort, PodPort: podPort}
+		}
+		return pod.Name, podMappings, nil
+
+	case PortForwardKindDeployment:
+		_, selector, err := c.workloadSelector(ctx, target.Kind, target.Name, target.Namespace)
+		if err != nil {
+			return "", nil, err
+		}
+		pod, err := c.readyPod(ctx, target, selector)
+		if err != nil {
+			return "", nil, err
+		}
+		return pod.Name, mappings, nil
+
+	default:
+		return "", nil, fmt.Errorf("unsupported port forward kind %q", target.Kind)
+	}
+}
+
+// readyPod returns the oldest running, ready pod matching selector that is not being deleted
+func (c *Client) readyPod(ctx context.Context, target PortForwardTarget, selector string) (*corev1.Pod, error) {
+	pods, err := c.clientset.CoreV1().Pods(target.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of %s %s/%s: %w", target.Kind, target.Namespace, target.Name, err)
+	}
+
+	var ready []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning && podReady(pod) {
+			ready = append(ready, pod)
+		}
+	}
+	if len(ready) == 0 {
+		return nil, fmt.Errorf("no ready pod found for %s %s/%s (%d pods match its selector)", target.Kind, target.Namespace, target.Name, len(pods.Items))
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		if !ready[i].CreationTimestamp.Equal(&ready[j].CreationTimestamp) {
+			return ready[i].CreationTimestamp.Before(&ready[j].CreationTimestamp)
+		}
+		return ready[i].Name < ready[j].Name
+	})
+	return ready[0], nil
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// servicePortToPodPort translates a service port to the port it targets on pod, resolving a named
+// targetPort against the pod's container ports as kubectl does
+func servicePortToPodPort(svc *corev1.Service, pod *corev1.Pod, port int32) (int32, error) {
+	for _, servicePort := range svc.Spec.Ports {
+		if servicePort.Port != port {
+			continue
+		}
+		switch {
+		case servicePort.TargetPort.Type == intstr.String:
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == servicePort.TargetPort.StrVal {
+						return containerPort.ContainerPort, nil
+					}
+				}
+			}
+			return 0, fmt.Errorf("pod %s has no container port named %q, the target of service port %d", pod.Name, servicePort.TargetPort.StrVal, port)
+		case servicePort.TargetPort.IntValue() == 0:
+			return servicePort.Port, nil
+		default:
+			return int32(servicePort.TargetPort.IntValue()), nil
+		}
+	}
+	return 0, fmt.Errorf("service %s/%s does not expose port %d", svc.Namespace, svc.Name, port)
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolvePortForwardPod(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	pod := func(name string, created time.Time, ready bool, mutate func(*corev1.Pod)) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: web, CreationTimestamp: metav1.NewTime(created)},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "app",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+		if mutate != nil {
+			mutate(p)
+		}
+		return p
+	}
+	now := time.Now()
+	deleting := metav1.NewTime(now)
+
+	c := &Client{clientset: fake.NewClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Selector: web,
+				Ports: []corev1.ServicePort{
+					{Port: 80, TargetPort: intstr.FromString("http")},
+					{Port: 9090, TargetPort: intstr.FromInt32(9091)},
+					{Port: 7000},
+				},
+			},
+		},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: web}},
+		},
+		pod("web-old-terminating", now.Add(-3*time.Hour), true, func(p *corev1.Pod) { p.DeletionTimestamp = &deleting }),
+		pod("web-unready", now.Add(-2*time.Hour), false, nil),
+		pod("web-b", now.Add(-time.Hour), true, nil),
+		pod("web-a", now, true, nil),
+	)}
+	ctx := context.Background()
+
+	target := PortForwardTarget{Kind: PortForwardKindService, Name: "web", Namespace: "default"}
+	podName, mappings, err := c.resolvePortForwardPod(ctx, target, []PortMapping{{LocalPort: 8000, PodPort: 80}, {PodPort: 9090}, {PodPort: 7000}})
+	if err != nil {
+		t.Fatalf("resolvePortForwardPod() error = %v", err)
+	}
+	if podName != "web-b" {
+		t.Fatalf("expected the oldest ready pod that is not being deleted, got %s", podName)
+	}
+	want := []PortMapping{{LocalPort: 8000, PodPort: 8080}, {PodPort: 9091}, {PodPort: 7000}}
+	for i := range want {
+		if mappings[i] != want[i] {
+			t.Fatalf("expected service ports translated to %+v, got %+v", want, mappings)
+		}
+	}
+
+	if _, _, err := c.resolvePortForwardPod(ctx, target, []PortMapping{{PodPort: 443}}); err == nil || !strings.Contains(err.Error(), "does not expose port 443") {
+		t.Fatalf("expected an unknown service port to fail, got %v", err)
+	}
+	target.Name = "external"
+	if _, _, err := c.resolvePortForwardPod(ctx, target, []PortMapping{{PodPort: 80}}); err == nil || !strings.Contains(err.Error(), "no selector") {
+		t.Fatalf("expected a service without selector to fail, got %v", err)
+	}
+
+	target = PortForwardTarget{Kind: PortForwardKindDeployment, Name: "web", Namespace: "default"}
+	podName, mappings, err = c.resolvePortForwardPod(ctx, target, []PortMapping{{LocalPort: 8000, PodPort: 80}})
+	if err != nil {
+		t.Fatalf("resolvePortForwardPod() error = %v", err)
+	}
+	if podName != "web-b" || mappings[0].PodPort != 80 {
+		t.Fatalf("expected deployment ports forwarded unchanged to web-b, got %s %+v", podName, mappings)
+	}
+}
+
+func TestResolvePortForwardPodNoReadyPod(t *testing.T) {
+	c := &Client{clientset: fake.NewClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default", Labels: map[string]string{"app": "api"}},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+	)}
+
+	_, _, err := c.resolvePortForwardPod(context.Background(), PortForwardTarget{Kind: PortForwardKindDeployment, Name: "api", Namespace: "default"}, []PortMapping{{PodPort: 80}})
+	if err == nil || !strings.Contains(err.Error(), "no ready pod") {
+		t.Fatalf("expected no ready pod error, got %v", err)
+	}
+}
+
+func TestNormalizePortForwardKind(t *testing.T) {
+	for input, want := range map[string]string{"": PortForwardKindPod, "po": PortForwardKindPod, "svc": PortForwardKindService, "Deployment": PortForwardKindDeployment} {
+		got, err := NormalizePortForwardKind(input)
+		if err != nil || got != want {
+			t.Fatalf("NormalizePortForwardKind(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := NormalizePortForwardKind("StatefulSet"); err == nil {
+		t.Fatal("expected an unsupported kind to fail")
+	}
+}
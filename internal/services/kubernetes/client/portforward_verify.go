@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// portForwardVerifyTimeout bounds the dial and the HTTP health check of a port forward
+	portForwardVerifyTimeout = 3 * time.Second
+	// portForwardProbeWait is how long a probe connection must stay open to count as reachable
+	portForwardProbeWait = time.Second
+)
+
+// PortForwardVerification is the result of checking that a forwarded pod port accepts traffic.
+type PortForwardVerification struct {
+	TCPReachable bool   `json:"tcpReachable"`
+	HealthPath   string `json:"healthPath,omitempty"`
+	HTTPStatus   int    `json:"httpStatus,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// OK reports whether every requested check passed
+func (v *PortForwardVerification) OK() bool {
+	return v.Error == ""
+}
+
+// PortForwardAndVerify starts a port forward to target and checks through each established
+// mapping that the pod port is listening, and, when healthPath is set, that an HTTP GET of it on the
+// first mapping succeeds. When any check fails the whole session is stopped again; the
+// verification of each mapping tells why.
+func (c *Client) PortForwardAndVerify(ctx context.Context, target PortForwardTarget, mappings []PortMapping, address, healthPath string) (*PortForwardSession, bool, error) {
+	session, err := c.StartPortForward(ctx, target, mappings, address)
+	if err != nil {
+		return nil, false, err
+	}
+
+	verified := true
+	results := session.Mappings
+	for i := range results {
+		if !results[i].Established {
+			continue
+		}
+		path := ""
+		if i == 0 {
+			path = healthPath
+		}
+		results[i].Verification = verifyPortForward(ctx, address, results[i].LocalPort, path)
+		verified = verified && results[i].Verification.OK()
+	}
+	if !verified {
+		session.Stop()
+		logrus.WithFields(logrus.Fields{
+			"kind":      session.Target.Kind,
+			"name":      session.Target.Name,
+			"pod":       session.Pod(),
+			"namespace": session.Target.Namespace,
+		}).Warn("Port forward verification failed, stopped the forward")
+	}
+	return session, verified, nil
+}
+
+// verifyPortForward checks a forward through its local listener. The listener accepts every
+// connection, so a successful dial alone proves nothing: when the pod port is not listening the
+// forwarder closes the connection right away. A connection that stays open for the read timeout,
+// or that the application writes to, is taken as reachable.
+func verifyPortForward(ctx context.Context, address string, localPort int32, healthPath string) *PortForwardVerification {
+	verification := &PortForwardVerification{HealthPath: healthPath}
+	target := net.JoinHostPort(dialHost(address), strconv.Itoa(int(localPort)))
+
+	dialer := net.Dialer{Timeout: portForwardVerifyTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		verification.Error = fmt.Sprintf("failed to connect to the local port %s: %v", target, err)
+		return verification
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(portForwardProbeWait))
+	_, err = conn.Read(make([]byte, 1))
+	_ = conn.Close()
+	if err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
+		if errors.Is(err, io.EOF) || strings.Contains(err.Error(), "connection reset") {
+			verification.Error = "the forward closed the connection: the pod port is not listening"
+		} else {
+			verification.Error = fmt.Sprintf("connection through the forward failed: %v", err)
+		}
+		return verification
+	}
+	verification.TCPReachable = true
+
+	if healthPath == "" {
+		return verification
+	}
+	if !strings.HasPrefix(healthPath, "/") {
+		healthPath = "/" + healthPath
+	}
+	httpCtx, cancel := context.WithTimeout(ctx, portForwardVerifyTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(httpCtx, http.MethodGet, "http://"+target+healthPath, nil)
+	if err != nil {
+		verification.Error = fmt.Sprintf("invalid health path %q: %v", healthPath, err)
+		return verification
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		verification.Error = fmt.Sprintf("health check GET %s failed: %v", healthPath, err)
+		return verification
+	}
+	_ = resp.Body.Close()
+	verification.HTTPStatus = resp.StatusCode
+	if resp.StatusCode >= http.StatusBadRequest {
+		verification.Error = fmt.Sprintf("health check GET %s returned %s", healthPath, resp.Status)
+	}
+	return verification
+}
+
+// dialHost returns the host to dial for a listener bound to address
+func dialHost(address string) string {
+	switch address {
+	case "", "localhost", "0.0.0.0":
+		return "127.0.0.1"
+	case "::":
+		return "::1"
+	default:
+		return address
+	}
+}
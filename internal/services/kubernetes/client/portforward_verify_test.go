@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func listenerPort(t *testing.T, addr net.Addr) int32 {
+	t.Helper()
+	tcp, ok := addr.(*net.TCPAddr)
+	if !ok {
+		t.Fatalf("unexpected address %v", addr)
+	}
+	return int32(tcp.Port)
+}
+
+func TestVerifyPortForward(t *testing.T) {
+	previous := portForwardProbeWait
+	portForwardProbeWait = 100 * time.Millisecond
+	defer func() { portForwardProbeWait = previous }()
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer healthy.Close()
+	healthyPort := listenerPort(t, healthy.Listener.Addr())
+
+	// A forward to a pod port nobody listens on accepts locally and then closes the connection
+	refusing, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer func() { _ = refusing.Close() }()
+	go func() {
+		for {
+			conn, err := refusing.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		name       string
+		port       int32
+		healthPath string
+		wantTCP    bool
+		wantStatus int
+		wantErr    string
+	}{
+		{name: "listening port", port: healthyPort, wantTCP: true},
+		{name: "healthy path", port: healthyPort, healthPath: "healthz", wantTCP: true, wantStatus: http.StatusOK},
+		{name: "failing health path", port: healthyPort, healthPath: "/ready", wantTCP: true, wantStatus: http.StatusServiceUnavailable, wantErr: "503"},
+		{name: "pod port not listening", port: listenerPort(t, refusing.Addr()), wantErr: "pod port is not listening"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := verifyPortForward(context.Background(), "localhost", tt.port, tt.healthPath)
+			if got.TCPReachable != tt.wantTCP || got.HTTPStatus != tt.wantStatus {
+				t.Fatalf("unexpected verification: %+v", got)
+			}
+			if tt.wantErr == "" && !got.OK() {
+				t.Fatalf("expected verification to pass, got %q", got.Error)
+			}
+			if tt.wantErr != "" && !strings.Contains(got.Error, tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %q", tt.wantErr, got.Error)
+			}
+		})
+	}
+}
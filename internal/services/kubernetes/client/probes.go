@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// probeEventReasons are the kubelet event reasons recorded for failing or warning probes
+var probeEventReasons = map[string]bool{"Unhealthy": true, "ProbeWarning": true}
+
+// ContainerProbes is the probe configuration of one container together with its readiness state
+type ContainerProbes struct {
+	Container             string         `json:"container"`
+	Init                  bool           `json:"init,omitempty"`
+	Ready                 *bool          `json:"ready,omitempty"`
+	Started               *bool          `json:"started,omitempty"`
+	RestartCount          int64          `json:"restartCount,omitempty"`
+	LastTerminationReason string         `json:"lastTerminationReason,omitempty"`
+	Liveness              map[string]any `json:"livenessProbe,omitempty"`
+	Readiness             map[string]any `json:"readinessProbe,omitempty"`
+	Startup               map[string]any `json:"startupProbe,omitempty"`
+	NoProbes              bool           `json:"noProbes,omitempty"`
+}
+
+// ProbeDiagnostics gathers the probe configuration of a pod or pod template and, for pods, the
+// probe failures the kubelet reported as events
+type ProbeDiagnostics struct {
+	Containers    []ContainerProbes `json:"containers"`
+	FailureEvents []DescribedEvent  `json:"failureEvents,omitempty"`
+	EventsError   string            `json:"eventsError,omitempty"`
+}
+
+// GetProbeDiagnostics returns the liveness, readiness and startup probes of every container of a
+// Pod or workload. For a Pod the probe-failure events (Unhealthy, ProbeWarning) are included.
+func (c *Client) GetProbeDiagnostics(ctx context.Context, resource map[string]any) *ProbeDiagnostics {
+	obj := &unstructured.Unstructured{Object: resource}
+	logrus.WithFields(logrus.Fields{"kind": obj.GetKind(), "name": obj.GetName()}).Debug("GetProbeDiagnostics called")
+
+	diagnostics := buildProbeDiagnostics(obj)
+	if obj.GetKind() != "Pod" {
+		return diagnostics
+	}
+
+	events, err := c.describeEvents(ctx, "Pod", obj.GetName(), obj.GetNamespace())
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to list probe events")
+		diagnostics.EventsError = err.Error()
+		return diagnostics
+	}
+	for _, event := range events {
+		if probeEventReasons[event.Reason] || isProbeFailureMessage(event.Message) {
+			diagnostics.FailureEvents = append(diagnostics.FailureEvents, event)
+		}
+	}
+	return diagnostics
+}
+
+// buildProbeDiagnostics reads the probes of each container and, for a Pod, its container statuses
+func buildProbeDiagnostics(obj *unstructured.Unstructured) *ProbeDiagnostics {
+	diagnostics := &ProbeDiagnostics{Containers: []ContainerProbes{}}
+	spec, _ := obj.Object["spec"].(map[string]any)
+	if spec == nil {
+		return diagnostics
+	}
+	podSpec := podSpecOf(spec)
+
+	statuses := map[string]map[string]any{}
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		list, _, _ := unstructured.NestedSlice(obj.Object, "status", field)
+		for _, item := range list {
+			if status, ok := item.(map[string]any); ok {
+				statuses[getStringField(status, "name")] = status
+			}
+		}
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		list, _ := podSpec[field].([]any)
+		for _, item := range list {
+			container, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			probes := ContainerProbes{Container: getStringField(container, "name"), Init: field == "initContainers"}
+			probes.Liveness, _ = container["livenessProbe"].(map[string]any)
+			probes.Readiness, _ = container["readinessProbe"].(map[string]any)
+			probes.Startup, _ = container["startupProbe"].(map[string]any)
+			probes.NoProbes = probes.Liveness == nil && probes.Readiness == nil && probes.Startup == nil
+			// Init containers without probes (the usual case) are only noise here
+			if probes.Init && probes.NoProbes {
+				continue
+			}
+
+			if status, ok := statuses[probes.Container]; ok {
+				if ready, ok := status["ready"].(bool); ok {
+					probes.Ready = &ready
+				}
+				if started, ok := status["started"].(bool); ok {
+					probes.Started = &started
+				}
+				probes.RestartCount, _, _ = unstructured.NestedInt64(status, "restartCount")
+				probes.LastTerminationReason, _, _ = unstructured.NestedString(status, "lastState", "terminated", "reason")
+			}
+			diagnostics.Containers = append(diagnostics.Containers, probes)
+		}
+	}
+	return diagnostics
+}
+
+// isProbeFailureMessage reports whether an event message describes a failed probe
+func isProbeFailureMessage(message string) bool {
+	return strings.Contains(strings.ToLower(message), "probe failed")
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetProbeDiagnostics(t *testing.T) {
+	pod := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "web-0", "namespace": "default"},
+		"spec": map[string]any{
+			"initContainers": []any{map[string]any{"name": "migrate"}},
+			"containers": []any{
+				map[string]any{
+					"name":           "app",
+					"readinessProbe": map[string]any{"httpGet": map[string]any{"path": "/ready", "port": int64(8080)}},
+					"livenessProbe":  map[string]any{"tcpSocket": map[string]any{"port": int64(8080)}},
+				},
+				map[string]any{"name": "sidecar"},
+			},
+		},
+		"status": map[string]any{
+			"containerStatuses": []any{
+				map[string]any{
+					"name":         "app",
+					"ready":        false,
+					"restartCount": int64(4),
+					"lastState":    map[string]any{"terminated": map[string]any{"reason": "Error"}},
+				},
+			},
+		},
+	}
+	event := func(name, reason, message string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-0", Namespace: "default"},
+			Reason:         reason,
+			Message:        message,
+		}
+	}
+	c := &Client{clientset: fake.NewClientset(
+		event("e1", "Unhealthy", "Readiness probe failed: HTTP probe failed with statuscode: 503"),
+		event("e2", "Pulled", "Container image already present"),
+	)}
+
+	diagnostics := c.GetProbeDiagnostics(context.Background(), pod)
+	if len(diagnostics.Containers) != 2 {
+		t.Fatalf("expected the probe-less init container to be skipped, got %+v", diagnostics.Containers)
+	}
+	app := diagnostics.Containers[0]
+	if app.Container != "app" || app.Readiness == nil || app.Liveness == nil || app.Startup != nil {
+		t.Fatalf("unexpected probes for app: %+v", app)
+	}
+	if app.Ready == nil || *app.Ready || app.RestartCount != 4 || app.LastTerminationReason != "Error" {
+		t.Fatalf("unexpected status for app: %+v", app)
+	}
+	if !diagnostics.Containers[1].NoProbes {
+		t.Fatalf("expected the sidecar to be flagged as having no probes, got %+v", diagnostics.Containers[1])
+	}
+	if len(diagnostics.FailureEvents) != 1 || diagnostics.FailureEvents[0].Reason != "Unhealthy" {
+		t.Fatalf("expected only the probe failure event, got %+v", diagnostics.FailureEvents)
+	}
+
+	// Workloads report the template's probes without pod events
+	deployment := map[string]any{
+		"kind":     "Deployment",
+		"metadata": map[string]any{"name": "web", "namespace": "default"},
+		"spec":     map[string]any{"template": map[string]any{"spec": pod["spec"]}},
+	}
+	if diagnostics := c.GetProbeDiagnostics(context.Background(), deployment); len(diagnostics.Containers) != 2 || diagnostics.FailureEvents != nil {
+		t.Fatalf("unexpected workload diagnostics: %+v", diagnostics)
+	}
+}
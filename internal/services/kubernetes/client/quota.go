@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// quotaNearLimitPercent is the usage above which a quota resource is flagged
+const quotaNearLimitPercent = 90
+
+// QuotaResourceUsage compares the usage of one resource against its quota
+type QuotaResourceUsage struct {
+	Resource    string  `json:"resource"`
+	Used        string  `json:"used"`
+	Hard        string  `json:"hard"`
+	Remaining   string  `json:"remaining"`
+	PercentUsed float64 `json:"percentUsed"`
+	NearLimit   bool    `json:"nearLimit,omitempty"`
+}
+
+// QuotaUsage is the usage of one ResourceQuota
+type QuotaUsage struct {
+	Name      string               `json:"name"`
+	Scopes    []string             `json:"scopes,omitempty"`
+	Resources []QuotaResourceUsage `json:"resources"`
+}
+
+// LimitRangeRule is the constraint a LimitRange places on one resource of one object type
+type LimitRangeRule struct {
+	Type                 string `json:"type"`
+	Resource             string `json:"resource"`
+	Default              string `json:"default,omitempty"`
+	DefaultRequest       string `json:"defaultRequest,omitempty"`
+	Min                  string `json:"min,omitempty"`
+	Max                  string `json:"max,omitempty"`
+	MaxLimitRequestRatio string `json:"maxLimitRequestRatio,omitempty"`
+}
+
+// LimitRangeSummary lists the rules of one LimitRange
+type LimitRangeSummary struct {
+	Name  string           `json:"name"`
+	Rules []LimitRangeRule `json:"rules"`
+}
+
+// NamespaceQuotaReport is the quota headroom and LimitRange constraints of a namespace
+type NamespaceQuotaReport struct {
+	Namespace   string              `json:"namespace"`
+	Quotas      []QuotaUsage        `json:"quotas"`
+	LimitRanges []LimitRangeSummary `json:"limitRanges"`
+	NearLimit   []string            `json:"nearLimit,omitempty"`
+}
+
+// GetNamespaceQuotas reports ResourceQuota usage against hard limits and the LimitRange defaults,
+// minimums and maximums of a namespace. Quota resources more than 90% consumed are flagged.
+func (c *Client) GetNamespaceQuotas(ctx context.Context, namespace string) (*NamespaceQuotaReport, error) {
+	logrus.WithField("namespace", namespace).Debug("GetNamespaceQuotas called")
+
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+
+	quotas, err := c.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+	limitRanges, err := c.clientset.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit ranges: %w", err)
+	}
+
+	report := &NamespaceQuotaReport{
+		Namespace:   namespace,
+		Quotas:      make([]QuotaUsage, 0, len(quotas.Items)),
+		LimitRanges: make([]LimitRangeSummary, 0, len(limitRanges.Items)),
+	}
+	for _, quota := range quotas.Items {
+		usage := quotaUsage(&quota)
+		for _, resource := range usage.Resources {
+			if resource.NearLimit {
+				report.NearLimit = append(report.NearLimit, fmt.Sprintf("%s/%s: %s of %s used (%.0f%%)",
+					quota.Name, resource.Resource, resource.Used, resource.Hard, resource.PercentUsed))
+			}
+		}
+		report.Quotas = append(report.Quotas, usage)
+	}
+	for _, limitRange := range limitRanges.Items {
+		report.LimitRanges = append(report.LimitRanges, limitRangeSummary(&limitRange))
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"quotas": len(report.Quotas), "limitRanges": len(report.LimitRanges), "nearLimit": len(report.NearLimit),
+	}).Debug("GetNamespaceQuotas succeeded")
+	return report, nil
+}
+
+func quotaUsage(quota *corev1.ResourceQuota) QuotaUsage {
+	usage := QuotaUsage{Name: quota.Name, Resources: []QuotaResourceUsage{}}
+	for _, scope := range quota.Spec.Scopes {
+		usage.Scopes = append(usage.Scopes, string(scope))
+	}
+
+	// Status.Hard mirrors the enforced spec; fall back to the spec before the controller has synced
+	hardLimits := quota.Status.Hard
+	if len(hardLimits) == 0 {
+		hardLimits = quota.Spec.Hard
+	}
+	for name, hard := range hardLimits {
+		used := quota.Status.Used[name]
+		remaining := hard.DeepCopy()
+		remaining.Sub(used)
+
+		percent := 100.0
+		if !hard.IsZero() {
+			percent = used.AsApproximateFloat64() / hard.AsApproximateFloat64() * 100
+		}
+		percent = math.Round(percent*10) / 10
+
+		usage.Resources = append(usage.Resources, QuotaResourceUsage{
+			Resource:    string(name),
+			Used:        used.String(),
+			Hard:        hard.String(),
+			Remaining:   remaining.String(),
+			PercentUsed: percent,
+			NearLimit:   percent > quotaNearLimitPercent,
+		})
+	}
+	sort.Slice(usage.Resources, func(i, j int) bool { return usage.Resources[i].Resource < usage.Resources[j].Resource })
+	return usage
+}
+
+func limitRangeSummary(limitRange *corev1.LimitRange) LimitRangeSummary {
+	summary := LimitRangeSummary{Name: limitRange.Name, Rules: []LimitRangeRule{}}
+	for _, item := range limitRange.Spec.Limits {
+		resources := map[corev1.ResourceName]bool{}
+		for _, list := range []corev1.ResourceList{item.Default, item.DefaultRequest, item.Min, item.Max, item.MaxLimitRequestRatio} {
+			for name := range list {
+				resources[name] = true
+			}
+		}
+		names := make([]string, 0, len(resources))
+		for name := range resources {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			resource := corev1.ResourceName(name)
+			summary.Rules = append(summary.Rules, LimitRangeRule{
+				Type:                 string(item.Type),
+				Resource:             name,
+				Default:              quantityString(item.Default, resource),
+				DefaultRequest:       quantityString(item.DefaultRequest, resource),
+				Min:                  quantityString(item.Min, resource),
+				Max:                  quantityString(item.Max, resource),
+				MaxLimitRequestRatio: quantityString(item.MaxLimitRequestRatio, resource),
+			})
+		}
+	}
+	return summary
+}
+
+// quantityString returns the quantity for a resource, or an empty string when it is not set
+func quantityString(list corev1.ResourceList, name corev1.ResourceName) string {
+	if quantity, ok := list[name]; ok {
+		return quantity.String()
+	}
+	return ""
+}
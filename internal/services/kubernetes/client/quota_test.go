@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetNamespaceQuotas(t *testing.T) {
+	clientset := fake.NewClientset(
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{
+					corev1.ResourceRequestsCPU:    resource.MustParse("4"),
+					corev1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+					corev1.ResourcePods:           resource.MustParse("10"),
+				},
+				Used: corev1.ResourceList{
+					corev1.ResourceRequestsCPU:    resource.MustParse("3800m"),
+					corev1.ResourceRequestsMemory: resource.MustParse("2Gi"),
+					corev1.ResourcePods:           resource.MustParse("10"),
+				},
+			},
+		},
+		&corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+			Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+				Type:           corev1.LimitTypeContainer,
+				Default:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Max:            corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			}}},
+		},
+	)
+	c := &Client{clientset: clientset}
+
+	report, err := c.GetNamespaceQuotas(context.Background(), "team-a")
+	if err != nil {
+		t.Fatalf("GetNamespaceQuotas() error = %v", err)
+	}
+	if len(report.Quotas) != 1 || len(report.Quotas[0].Resources) != 3 {
+		t.Fatalf("unexpected quotas: %+v", report.Quotas)
+	}
+
+	byName := map[string]QuotaResourceUsage{}
+	for _, r := range report.Quotas[0].Resources {
+		byName[r.Resource] = r
+	}
+	if cpu := byName["requests.cpu"]; cpu.PercentUsed != 95 || !cpu.NearLimit || cpu.Remaining != "200m" {
+		t.Fatalf("unexpected cpu usage: %+v", cpu)
+	}
+	if memory := byName["requests.memory"]; memory.PercentUsed != 25 || memory.NearLimit {
+		t.Fatalf("unexpected memory usage: %+v", memory)
+	}
+	if len(report.NearLimit) != 2 {
+		t.Fatalf("expected cpu and pods to be flagged, got %v", report.NearLimit)
+	}
+
+	rules := report.LimitRanges[0].Rules
+	if len(rules) != 2 || rules[0].Resource != "cpu" || rules[0].Default != "500m" || rules[0].DefaultRequest != "100m" || rules[1].Max != "2Gi" {
+		t.Fatalf("unexpected limit range rules: %+v", rules)
+	}
+
+	if _, err := c.GetNamespaceQuotas(context.Background(), ""); err == nil {
+		t.Fatal("expected an error without a namespace")
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// Requests that wait at least throttleWarnThreshold for a token count as throttled; warnings
+// are logged at most once per throttleWarnInterval per limiter.
+var (
+	throttleWarnThreshold = 500 * time.Millisecond
+	throttleWarnInterval  = 30 * time.Second
+)
+
+var (
+	rateLimitersMu sync.Mutex
+	rateLimiters   = map[rateLimiterKey]*throttleReportingLimiter{}
+)
+
+// rateLimiterKey identifies one token bucket. Clients are built per request, so buckets are
+// shared process-wide per API server and setting to bound the load the server generates.
+type rateLimiterKey struct {
+	host  string
+	qps   float32
+	burst int
+}
+
+// throttleReportingLimiter logs a warning while requests are held back by the token bucket
+type throttleReportingLimiter struct {
+	flowcontrol.RateLimiter
+	key rateLimiterKey
+
+	mu         sync.Mutex
+	throttled  int
+	maxWait    time.Duration
+	lastReport time.Time
+}
+
+// sharedRateLimiter returns the token bucket for an API server and QPS/burst setting
+func sharedRateLimiter(host string, qps float32, burst int) flowcontrol.RateLimiter {
+	key := rateLimiterKey{host: host, qps: qps, burst: burst}
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+	if limiter, ok := rateLimiters[key]; ok {
+		return limiter
+	}
+	limiter := &throttleReportingLimiter{RateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst), key: key}
+	rateLimiters[key] = limiter
+	return limiter
+}
+
+// applyRateLimiter sets the shared limiter on config and returns the configuration for the
+// discovery client, which bypasses the limiter unless limitDiscovery is set. Discovery results
+// are cached, so its bursts are short and rare.
+func applyRateLimiter(config *rest.Config, limitDiscovery bool) *rest.Config {
+	if config.QPS <= 0 {
+		return config
+	}
+	config.RateLimiter = sharedRateLimiter(config.Host, config.QPS, config.Burst)
+	if limitDiscovery {
+		return config
+	}
+	discoveryConfig := rest.CopyConfig(config)
+	discoveryConfig.RateLimiter = flowcontrol.NewFakeAlwaysRateLimiter()
+	return discoveryConfig
+}
+
+// Wait blocks until a token is available and records requests that had to wait
+func (l *throttleReportingLimiter) Wait(ctx context.Context) error {
+	start := time.Now()
+	err := l.RateLimiter.Wait(ctx)
+	if waited := time.Since(start); waited >= throttleWarnThreshold {
+		l.recordThrottle(waited)
+	}
+	return err
+}
+
+func (l *throttleReportingLimiter) recordThrottle(waited time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.throttled++
+	l.maxWait = max(l.maxWait, waited)
+	if time.Since(l.lastReport) < throttleWarnInterval {
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"server":    l.key.host,
+		"qps":       l.key.qps,
+		"burst":     l.key.burst,
+		"throttled": l.throttled,
+		"maxWait":   l.maxWait.Round(time.Millisecond).String(),
+	}).Warn("Kubernetes client-side rate limiter is throttling API requests; raise kubernetes.qps/burst if this persists")
+	l.throttled = 0
+	l.maxWait = 0
+	l.lastReport = time.Now()
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"k8s.io/client-go/rest"
+)
+
+func TestApplyRateLimiterSharesBucketAndBypassesDiscovery(t *testing.T) {
+	first := &rest.Config{Host: "https://10.0.0.1:6443", QPS: 42, Burst: 84}
+	second := &rest.Config{Host: "https://10.0.0.1:6443", QPS: 42, Burst: 84}
+
+	discoveryConfig := applyRateLimiter(first, false)
+	applyRateLimiter(second, false)
+	if first.RateLimiter == nil || first.RateLimiter != second.RateLimiter {
+		t.Fatal("expected clients of one API server to share a token bucket")
+	}
+	if discoveryConfig == first || discoveryConfig.RateLimiter == first.RateLimiter {
+		t.Fatal("expected discovery to bypass the shared limiter")
+	}
+	if discoveryConfig.RateLimiter.TryAccept() != true {
+		t.Fatal("expected the discovery limiter to always accept")
+	}
+
+	limited := &rest.Config{Host: first.Host, QPS: 42, Burst: 84}
+	if applyRateLimiter(limited, true) != limited || limited.RateLimiter != first.RateLimiter {
+		t.Fatal("expected discovery to use the shared limiter when rateLimitDiscovery is set")
+	}
+
+	other := &rest.Config{Host: "https://10.0.0.2:6443", QPS: 42, Burst: 84}
+	applyRateLimiter(other, false)
+	if other.RateLimiter == first.RateLimiter {
+		t.Fatal("expected each API server to get its own token bucket")
+	}
+}
+
+func TestThrottleReportingLimiterWarns(t *testing.T) {
+	previousThreshold, previousInterval := throttleWarnThreshold, throttleWarnInterval
+	throttleWarnThreshold, throttleWarnInterval = 0, time.Hour
+	defer func() { throttleWarnThreshold, throttleWarnInterval = previousThreshold, previousInterval }()
+
+	hook := logrustest.NewGlobal()
+	defer hook.Reset()
+
+	limiter := sharedRateLimiter("https://throttle.test:6443", 1000, 1).(*throttleReportingLimiter)
+	for range 3 {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+
+	warnings := 0
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel && entry.Data["server"] == "https://throttle.test:6443" {
+			warnings++
+		}
+	}
+	if warnings != 1 {
+		t.Fatalf("expected one warning per interval, got %d", warnings)
+	}
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if limiter.throttled != 2 {
+		t.Fatalf("expected later throttled requests to be counted for the next warning, got %d", limiter.throttled)
+	}
+}
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Provisioning states reported for a container resource
+const (
+	ProvisioningOK        = "ok"
+	ProvisioningOver      = "overProvisioned"
+	ProvisioningUnder     = "underProvisioned"
+	ProvisioningNoRequest = "noRequest"
+)
+
+const (
+	// A single sample stands in for p95 usage; the suggested request adds headroom on top of it
+	cpuRequestHeadroom    = 1.2
+	memoryRequestHeadroom = 1.3
+
+	// Usage below this share of the request is reported as over-provisioned
+	overProvisionedPercent = 30
+	// Usage at or above this share of the limit risks throttling (CPU) or OOM kills (memory)
+	nearLimitPercent = 90
+
+	minSuggestedCPUMilli    = 10
+	cpuRoundingMilli        = 10
+	minSuggestedMemoryBytes = 32 * 1024 * 1024
+	memoryRoundingBytes     = 4 * 1024 * 1024
+	minCPUReductionMilli    = 50
+	minMemoryReductionBytes = 64 * 1024 * 1024
+)
+
+// resourceRecommendationsNote is attached to every report so callers do not mistake it for history
+const resourceRecommendationsNote = "Point-in-time snapshot from a single metrics-server sample, not historical usage. " +
+	"Suggested requests treat the sample as p95 usage plus headroom; confirm against usage over a representative period before applying."
+
+// ResourceProvisioning compares one resource's live usage against its request and limit
+type ResourceProvisioning struct {
+	Usage            string   `json:"usage"`
+	Request          string   `json:"request,omitempty"`
+	Limit            string   `json:"limit,omitempty"`
+	PercentOfRequest *float64 `json:"percentOfRequest,omitempty"`
+	PercentOfLimit   *float64 `json:"percentOfLimit,omitempty"`
+	SuggestedRequest string   `json:"suggestedRequest"`
+	Status           string   `json:"status"`
+	NearLimit        bool     `json:"nearLimit,omitempty"`
+}
+
+// ContainerRecommendation is the provisioning assessment of one container
+type ContainerRecommendation struct {
+	Pod       string               `json:"pod"`
+	Container string               `json:"container"`
+	CPU       ResourceProvisioning `json:"cpu"`
+	Memory    ResourceProvisioning `json:"memory"`
+	Findings  []string             `json:"findings,omitempty"`
+}
+
+// ResourceRecommendationReport lists container request recommendations for a namespace
+type ResourceRecommendationReport struct {
+	Namespace        string                    `json:"namespace"`
+	Note             string                    `json:"note"`
+	SampledAt        string                    `json:"sampledAt,omitempty"`
+	Window           string                    `json:"window,omitempty"`
+	ContainersScored int                       `json:"containersScored"`
+	OverProvisioned  int                       `json:"overProvisioned"`
+	UnderProvisioned int                       `json:"underProvisioned"`
+	MissingRequests  int                       `json:"missingRequests"`
+	Containers       []ContainerRecommendation `json:"containers"`
+}
+
+// GetPodResourceRecommendations compares metrics-server CPU and memory usage of the containers in a
+// namespace against their requests and limits. Containers using less than 30% of a request are
+// over-provisioned, containers using more than their request are under-provisioned, and usage at 90%
+// of a limit is flagged. Each resource gets a suggested request derived from the single available
+// sample. With onlyFlagged, containers whose CPU and memory are both ok are left out.
+func (c *Client) GetPodResourceRecommendations(ctx context.Context, namespace, labelSelector string, onlyFlagged bool) (*ResourceRecommendationReport, error) {
+	logrus.WithFields(logrus.Fields{
+		"namespace": namespace, "labelSelector": labelSelector, "onlyFlagged": onlyFlagged,
+	}).Debug("GetPodResourceRecommendations called")
+
+	if err := c.requireMetricsAPI(); err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required for pod resource recommendations")
+	}
+
+	listOptions := metav1.ListOptions{LabelSelector: labelSelector}
+	metricsList, err := c.metricsClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod metrics in namespace %s: %w", namespace, err)
+	}
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+	}
+	specs := map[string]corev1.ResourceRequirements{}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			specs[pod.Name+"/"+container.Name] = container.Resources
+		}
+	}
+
+	report := &ResourceRecommendationReport{
+		Namespace:  namespace,
+		Note:       resourceRecommendationsNote,
+		Containers: []ContainerRecommendation{},
+	}
+	var sampledAt time.Time
+	for _, podMetrics := range metricsList.Items {
+		if podMetrics.Timestamp.After(sampledAt) {
+			sampledAt = podMetrics.Timestamp.Time
+			report.Window = podMetrics.Window.Duration.String()
+		}
+		for _, usage := range podMetrics.Containers {
+			requirements, ok := specs[podMetrics.Name+"/"+usage.Name]
+			if !ok {
+				continue
+			}
+			recommendation := containerRecommendation(podMetrics.Name, usage.Name, usage.Usage, requirements)
+			report.ContainersScored++
+			flagged := false
+			for _, status := range []string{recommendation.CPU.Status, recommendation.Memory.Status} {
+				switch status {
+				case ProvisioningOver:
+					report.OverProvisioned++
+				case ProvisioningUnder:
+					report.UnderProvisioned++
+				case ProvisioningNoRequest:
+					report.MissingRequests++
+				}
+				flagged = flagged || status != ProvisioningOK
+			}
+			flagged = flagged || recommendation.CPU.NearLimit || recommendation.Memory.NearLimit
+			if onlyFlagged && !flagged {
+				continue
+			}
+			report.Containers = append(report.Containers, recommendation)
+		}
+	}
+	if !sampledAt.IsZero() {
+		report.SampledAt = sampledAt.UTC().Format(time.RFC3339)
+	}
+	sort.Slice(report.Containers, func(i, j int) bool {
+		a, b := report.Containers[i], report.Containers[j]
+		if len(a.Findings) != len(b.Findings) {
+			return len(a.Findings) > len(b.Findings)
+		}
+		return a.Pod+"/"+a.Container < b.Pod+"/"+b.Container
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"scored": report.ContainersScored, "over": report.OverProvisioned, "under": report.UnderProvisioned,
+	}).Debug("GetPodResourceRecommendations succeeded")
+	return report, nil
+}
+
+func containerRecommendation(pod, container string, usage corev1.ResourceList, requirements corev1.ResourceRequirements) ContainerRecommendation {
+	recommendation := ContainerRecommendation{Pod: pod, Container: container}
+
+	cpuUsage := usage[corev1.ResourceCPU]
+	recommendation.CPU = assessProvisioning(cpuUsage, requirements, corev1.ResourceCPU,
+		suggestCPURequest(cpuUsage), minCPUReductionMilli, true)
+	memoryUsage := usage[corev1.ResourceMemory]
+	recommendation.Memory = assessProvisioning(memoryUsage, requirements, corev1.ResourceMemory,
+		suggestMemoryRequest(memoryUsage), minMemoryReductionBytes, false)
+
+	for _, r := range []struct {
+		name         string
+		provisioning ResourceProvisioning
+		limitRisk    string
+	}{
+		{"cpu", recommendation.CPU, "throttling"},
+		{"memory", recommendation.Memory, "OOM kills"},
+	} {
+		p := r.provisioning
+		switch p.Status {
+		case ProvisioningOver:
+			recommendation.Findings = append(recommendation.Findings, fmt.Sprintf("%s over-provisioned: using %s of %s requested; consider %s", r.name, p.Usage, p.Request, p.SuggestedRequest))
+		case ProvisioningUnder:
+			recommendation.Findings = append(recommendation.Findings, fmt.Sprintf("%s under-provisioned: using %s with %s requested; consider %s", r.name, p.Usage, p.Request, p.SuggestedRequest))
+		case ProvisioningNoRequest:
+			recommendation.Findings = append(recommendation.Findings, fmt.Sprintf("%s has no request; consider %s", r.name, p.SuggestedRequest))
+		}
+		if p.NearLimit {
+			recommendation.Findings = append(recommendation.Findings, fmt.Sprintf("%s usage %s is near the %s limit; risk of %s", r.name, p.Usage, p.Limit, r.limitRisk))
+		}
+	}
+	return recommendation
+}
+
+// assessProvisioning classifies usage against the request. Over-provisioning is only reported when
+// the suggested request would free at least minReduction (millicores for CPU, bytes for memory).
+func assessProvisioning(usage resource.Quantity, requirements corev1.ResourceRequirements, name corev1.ResourceName, suggested resource.Quantity, minReduction int64, milli bool) ResourceProvisioning {
+	p := ResourceProvisioning{Usage: usage.String(), SuggestedRequest: suggested.String(), Status: ProvisioningOK}
+
+	value := func(q resource.Quantity) int64 {
+		if milli {
+			return q.MilliValue()
+		}
+		return q.Value()
+	}
+
+	if limit, ok := requirements.Limits[name]; ok && !limit.IsZero() {
+		p.Limit = limit.String()
+		percent := roundPercent(float64(value(usage)) / float64(value(limit)) * 100)
+		p.PercentOfLimit = &percent
+		p.NearLimit = percent >= nearLimitPercent
+	}
+
+	request, ok := requirements.Requests[name]
+	if !ok || request.IsZero() {
+		p.Status = ProvisioningNoRequest
+		return p
+	}
+	p.Request = request.String()
+	percent := roundPercent(float64(value(usage)) / float64(value(request)) * 100)
+	p.PercentOfRequest = &percent
+
+	switch {
+	case percent > 100:
+		p.Status = ProvisioningUnder
+	case percent < overProvisionedPercent && value(request)-value(suggested) >= minReduction:
+		p.Status = ProvisioningOver
+	}
+	return p
+}
+
+func suggestCPURequest(usage resource.Quantity) resource.Quantity {
+	milli := int64(math.Ceil(float64(usage.MilliValue()) * cpuRequestHeadroom))
+	milli = max(roundUp(milli, cpuRoundingMilli), minSuggestedCPUMilli)
+	return *resource.NewMilliQuantity(milli, resource.DecimalSI)
+}
+
+func suggestMemoryRequest(usage resource.Quantity) resource.Quantity {
+	bytes := int64(math.Ceil(float64(usage.Value()) * memoryRequestHeadroom))
+	bytes = max(roundUp(bytes, memoryRoundingBytes), minSuggestedMemoryBytes)
+	return *resource.NewQuantity(bytes, resource.BinarySI)
+}
+
+func roundUp(value, step int64) int64 {
+	return (value + step - 1) / step * step
+}
+
+func roundPercent(percent float64) float64 {
+	return math.Round(percent*10) / 10
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func TestGetPodResourceRecommendations(t *testing.T) {
+	sampledAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	resources := func(cpu, memory string) corev1.ResourceList {
+		list := corev1.ResourceList{}
+		if cpu != "" {
+			list[corev1.ResourceCPU] = resource.MustParse(cpu)
+		}
+		if memory != "" {
+			list[corev1.ResourceMemory] = resource.MustParse(memory)
+		}
+		return list
+	}
+	pod := func(name string, containers ...corev1.Container) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{Containers: containers},
+		}
+	}
+	podMetrics := func(name string, containers ...metricsv1beta1api.ContainerMetrics) *metricsv1beta1api.PodMetrics {
+		return &metricsv1beta1api.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Timestamp:  metav1.NewTime(sampledAt),
+			Window:     metav1.Duration{Duration: 30 * time.Second},
+			Containers: containers,
+		}
+	}
+
+	clientset := fake.NewClientset(
+		pod("web-1",
+			corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{
+				Requests: resources("1", "256Mi"),
+				Limits:   resources("", "256Mi"),
+			}},
+			corev1.Container{Name: "sidecar"},
+		),
+		pod("api-1", corev1.Container{Name: "api", Resources: corev1.ResourceRequirements{
+			Requests: resources("200m", "128Mi"),
+			Limits:   resources("320m", ""),
+		}}),
+		pod("ok-1", corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{
+			Requests: resources("100m", "128Mi"),
+		}}),
+	)
+	// The metrics API serves PodMetrics under the "pods" resource, which the fake tracker cannot guess
+	metricsClient := metricsfake.NewSimpleClientset()
+	podsResource := metricsv1beta1api.SchemeGroupVersion.WithResource("pods")
+	for _, m := range []*metricsv1beta1api.PodMetrics{
+		podMetrics("web-1",
+			metricsv1beta1api.ContainerMetrics{Name: "app", Usage: resources("100m", "200Mi")},
+			metricsv1beta1api.ContainerMetrics{Name: "sidecar", Usage: resources("5m", "20Mi")},
+		),
+		podMetrics("api-1", metricsv1beta1api.ContainerMetrics{Name: "api", Usage: resources("300m", "100Mi")}),
+		podMetrics("ok-1", metricsv1beta1api.ContainerMetrics{Name: "app", Usage: resources("80m", "100Mi")}),
+	} {
+		if err := metricsClient.Tracker().Create(podsResource, m, m.Namespace); err != nil {
+			t.Fatalf("failed to seed pod metrics: %v", err)
+		}
+	}
+	c := &Client{clientset: clientset, metricsClient: metricsClient, discoveryClient: discoveryServing("v1", "metrics.k8s.io/v1beta1")}
+
+	report, err := c.GetPodResourceRecommendations(context.Background(), "default", "", true)
+	if err != nil {
+		t.Fatalf("GetPodResourceRecommendations() error = %v", err)
+	}
+	if report.ContainersScored != 4 || report.OverProvisioned != 1 || report.UnderProvisioned != 1 || report.MissingRequests != 2 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+	if report.SampledAt != "2026-03-01T12:00:00Z" || report.Window != "30s" || report.Note == "" {
+		t.Fatalf("unexpected sample metadata: %+v", report)
+	}
+	if len(report.Containers) != 3 {
+		t.Fatalf("expected the ok container to be left out, got %+v", report.Containers)
+	}
+
+	byName := map[string]ContainerRecommendation{}
+	for _, container := range report.Containers {
+		byName[container.Pod+"/"+container.Container] = container
+	}
+	if web := byName["web-1/app"]; web.CPU.Status != ProvisioningOver || web.CPU.SuggestedRequest != "120m" || web.Memory.Status != ProvisioningOK {
+		t.Fatalf("unexpected web-1/app entry: %+v", web)
+	}
+	api := byName["api-1/api"]
+	if api.CPU.Status != ProvisioningUnder || api.CPU.SuggestedRequest != "360m" || !api.CPU.NearLimit || len(api.Findings) != 2 {
+		t.Fatalf("unexpected api-1/api entry: %+v", api)
+	}
+	if sidecar := byName["web-1/sidecar"]; sidecar.CPU.Status != ProvisioningNoRequest || sidecar.Memory.SuggestedRequest != "32Mi" {
+		t.Fatalf("unexpected web-1/sidecar entry: %+v", sidecar)
+	}
+
+	report, err = c.GetPodResourceRecommendations(context.Background(), "default", "", false)
+	if err != nil {
+		t.Fatalf("GetPodResourceRecommendations() error = %v", err)
+	}
+	if len(report.Containers) != 4 {
+		t.Fatalf("expected every container without onlyFlagged, got %d", len(report.Containers))
+	}
+
+	if _, err := (&Client{clientset: clientset}).GetPodResourceRecommendations(context.Background(), "default", "", true); err == nil {
+		t.Fatal("expected an error without a metrics client")
+	}
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ContainerRestarts describes the restart history of one container
+type ContainerRestarts struct {
+	Name                  string     `json:"name"`
+	Init                  bool       `json:"init,omitempty"`
+	Restarts              int32      `json:"restarts"`
+	Ready                 bool       `json:"ready"`
+	State                 string     `json:"state"`
+	Reason                string     `json:"reason,omitempty"`
+	CrashLoopBackOff      bool       `json:"crashLoopBackOff,omitempty"`
+	LastTerminationReason string     `json:"lastTerminationReason,omitempty"`
+	LastExitCode          *int32     `json:"lastExitCode,omitempty"`
+	LastRestartAt         *time.Time `json:"lastRestartAt,omitempty"`
+}
+
+// PodRestarts summarizes the container restarts of one pod
+type PodRestarts struct {
+	Name             string              `json:"name"`
+	Namespace        string              `json:"namespace"`
+	Node             string              `json:"node,omitempty"`
+	Phase            string              `json:"phase"`
+	Restarts         int32               `json:"restarts"`
+	CrashLoopBackOff bool                `json:"crashLoopBackOff"`
+	LastRestartAt    *time.Time          `json:"lastRestartAt,omitempty"`
+	Containers       []ContainerRestarts `json:"containers"`
+}
+
+// PodRestartReport lists restarting pods sorted by restart count
+type PodRestartReport struct {
+	Namespace      string        `json:"namespace,omitempty"`
+	ScannedPods    int           `json:"scannedPods"`
+	RestartingPods int           `json:"restartingPods"`
+	CrashLoopPods  int           `json:"crashLoopPods"`
+	Pods           []PodRestarts `json:"pods"`
+	Truncated      bool          `json:"truncated,omitempty"`
+}
+
+// GetPodRestarts reports container restart counts, the last termination reason and exit code and
+// whether containers are in CrashLoopBackOff for the pods of a namespace (all namespaces when empty).
+// Pods with fewer than minRestarts restarts are left out unless they are crash looping. Pods are
+// sorted by total restarts, most first, and cut to limit.
+func (c *Client) GetPodRestarts(ctx context.Context, namespace, labelSelector string, minRestarts int32, limit int) (*PodRestartReport, error) {
+	logrus.WithFields(logrus.Fields{
+		"namespace": namespace, "labelSelector": labelSelector, "minRestarts": minRestarts, "limit": limit,
+	}).Debug("GetPodRestarts called")
+
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	report := &PodRestartReport{Namespace: namespace, ScannedPods: len(pods.Items), Pods: []PodRestarts{}}
+	for i := range pods.Items {
+		entry := podRestarts(&pods.Items[i])
+		if entry.Restarts > 0 {
+			report.RestartingPods++
+		}
+		if entry.CrashLoopBackOff {
+			report.CrashLoopPods++
+		}
+		if entry.Restarts < minRestarts && !entry.CrashLoopBackOff {
+			continue
+		}
+		report.Pods = append(report.Pods, entry)
+	}
+
+	sort.SliceStable(report.Pods, func(i, j int) bool {
+		a, b := report.Pods[i], report.Pods[j]
+		if a.Restarts != b.Restarts {
+			return a.Restarts > b.Restarts
+		}
+		if a.CrashLoopBackOff != b.CrashLoopBackOff {
+			return a.CrashLoopBackOff
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+	if limit > 0 && len(report.Pods) > limit {
+		report.Pods = report.Pods[:limit]
+		report.Truncated = true
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"scanned": report.ScannedPods, "restarting": report.RestartingPods, "crashLoop": report.CrashLoopPods,
+	}).Debug("GetPodRestarts succeeded")
+	return report, nil
+}
+
+func podRestarts(pod *corev1.Pod) PodRestarts {
+	entry := PodRestarts{
+		Name:       pod.Name,
+		Namespace:  pod.Namespace,
+		Node:       pod.Spec.NodeName,
+		Phase:      string(pod.Status.Phase),
+		Containers: []ContainerRestarts{},
+	}
+
+	add := func(statuses []corev1.ContainerStatus, init bool) {
+		for _, status := range statuses {
+			container := containerRestarts(status, init)
+			entry.Restarts += container.Restarts
+			entry.CrashLoopBackOff = entry.CrashLoopBackOff || container.CrashLoopBackOff
+			if container.LastRestartAt != nil && (entry.LastRestartAt == nil || container.LastRestartAt.After(*entry.LastRestartAt)) {
+				entry.LastRestartAt = container.LastRestartAt
+			}
+			entry.Containers = append(entry.Containers, container)
+		}
+	}
+	add(pod.Status.InitContainerStatuses, true)
+	add(pod.Status.ContainerStatuses, false)
+	return entry
+}
+
+func containerRestarts(status corev1.ContainerStatus, init bool) ContainerRestarts {
+	container := ContainerRestarts{
+		Name:     status.Name,
+		Init:     init,
+		Restarts: status.RestartCount,
+		Ready:    status.Ready,
+	}
+
+	switch {
+	case status.State.Waiting != nil:
+		container.State = "waiting"
+		container.Reason = status.State.Waiting.Reason
+		container.CrashLoopBackOff = status.State.Waiting.Reason == "CrashLoopBackOff"
+	case status.State.Terminated != nil:
+		container.State = "terminated"
+		container.Reason = status.State.Terminated.Reason
+	case status.State.Running != nil:
+		container.State = "running"
+	default:
+		container.State = "unknown"
+	}
+
+	if last := status.LastTerminationState.Terminated; last != nil {
+		container.LastTerminationReason = last.Reason
+		exitCode := last.ExitCode
+		container.LastExitCode = &exitCode
+		if !last.FinishedAt.IsZero() {
+			restartedAt := last.FinishedAt.Time
+			container.LastRestartAt = &restartedAt
+		}
+	}
+	// Without a recorded termination, a restarted container's start time is the last restart
+	if container.LastRestartAt == nil && status.RestartCount > 0 && status.State.Running != nil {
+		restartedAt := status.State.Running.StartedAt.Time
+		container.LastRestartAt = &restartedAt
+	}
+	return container
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPodRestarts(t *testing.T) {
+	finished := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	pod := func(name string, statuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: statuses},
+		}
+	}
+
+	clientset := fake.NewClientset(
+		pod("api-1", corev1.ContainerStatus{
+			Name:         "api",
+			RestartCount: 7,
+			State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason: "Error", ExitCode: 137, FinishedAt: metav1.NewTime(finished),
+			}},
+		}),
+		pod("web-1",
+			corev1.ContainerStatus{Name: "web", RestartCount: 2, Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			corev1.ContainerStatus{Name: "sidecar", RestartCount: 9, Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(finished)}}},
+		),
+		pod("stable-1", corev1.ContainerStatus{Name: "app", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}),
+		pod("new-1", corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}}),
+	)
+	c := &Client{clientset: clientset}
+
+	report, err := c.GetPodRestarts(context.Background(), "default", "", 1, 10)
+	if err != nil {
+		t.Fatalf("GetPodRestarts() error = %v", err)
+	}
+	if report.ScannedPods != 4 || report.RestartingPods != 2 || report.CrashLoopPods != 2 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+
+	var names []string
+	for _, p := range report.Pods {
+		names = append(names, p.Name)
+	}
+	if len(names) != 3 || names[0] != "web-1" || names[1] != "api-1" || names[2] != "new-1" {
+		t.Fatalf("unexpected order: %v", names)
+	}
+
+	if web := report.Pods[0]; web.Restarts != 11 || web.LastRestartAt == nil || !web.LastRestartAt.Equal(finished) {
+		t.Fatalf("unexpected web-1 entry: %+v", web)
+	}
+	api := report.Pods[1]
+	container := api.Containers[0]
+	if !api.CrashLoopBackOff || container.LastTerminationReason != "Error" || container.LastExitCode == nil || *container.LastExitCode != 137 {
+		t.Fatalf("unexpected api-1 entry: %+v", api)
+	}
+
+	report, err = c.GetPodRestarts(context.Background(), "default", "", 0, 1)
+	if err != nil {
+		t.Fatalf("GetPodRestarts() error = %v", err)
+	}
+	if len(report.Pods) != 1 || !report.Truncated {
+		t.Fatalf("expected the report to be cut to the limit, got %+v", report)
+	}
+}
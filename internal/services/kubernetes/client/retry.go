@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// maxRetryBackoff caps the doubling wait between two attempts
+const maxRetryBackoff = 5 * time.Second
+
+// retryPolicy is how often and how patiently transient failures are retried
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func currentRetryPolicy() retryPolicy {
+	settings := currentTransportDefaults()
+	return retryPolicy{maxAttempts: settings.RetryMaxAttempts, backoff: settings.RetryBackoff}
+}
+
+// delay is the wait after the given failed attempt: the backoff doubled per earlier retry
+func (p retryPolicy) delay(attempt int) time.Duration {
+	delay := p.backoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
+
+// waitForRetry sleeps for the delay after attempt, returning false when ctx ends first
+func (p retryPolicy) waitForRetry(ctx context.Context, attempt int) bool {
+	timer := time.NewTimer(p.delay(attempt))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// retryTransport retries idempotent reads that fail with a server error or a dropped connection.
+// Writes are never retried here: a create whose response was lost may already have been applied.
+type retryTransport struct {
+	next http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isRetriableRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+	policy := currentRetryPolicy()
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		reason := transientFailure(resp, err)
+		if reason == "" || attempt >= policy.maxAttempts || req.Context().Err() != nil {
+			if attempt > 1 {
+				logrus.WithFields(logrus.Fields{
+					"method": req.Method, "path": req.URL.Path, "attempts": attempt, "recovered": reason == "",
+				}).Debug("Kubernetes API request retried")
+			}
+			return resp, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"method": req.Method, "path": req.URL.Path, "attempt": attempt, "maxAttempts": policy.maxAttempts, "reason": reason,
+		}).Debug("Retrying Kubernetes API request")
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if !policy.waitForRetry(req.Context(), attempt) {
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// isRetriableRequest reports reads that can be repeated safely; upgraded streams (exec, port-forward)
+// and watches are left alone
+func isRetriableRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if req.Header.Get("Upgrade") != "" {
+		return false
+	}
+	return req.URL.Query().Get("watch") != "true"
+}
+
+// transientFailure names the reason a response or error is worth retrying, or "" when it is not.
+// Responses carrying Retry-After are left to client-go, which already honors them.
+func transientFailure(resp *http.Response, err error) string {
+	if err != nil {
+		if utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err) || utilnet.IsProbableEOF(err) {
+			return err.Error()
+		}
+		return ""
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if resp.Header.Get("Retry-After") == "" {
+			return resp.Status
+		}
+	}
+	return ""
+}
+
+// retryOnConflict runs update until it does not fail with a 409 Conflict, up to the configured number
+// of attempts. update must re-read the object so each attempt carries its current resourceVersion.
+func retryOnConflict(ctx context.Context, operation string, update func() error) error {
+	policy := currentRetryPolicy()
+	for attempt := 1; ; attempt++ {
+		err := update()
+		if err == nil || !apierrors.IsConflict(err) || attempt >= policy.maxAttempts {
+			if attempt > 1 {
+				logrus.WithFields(logrus.Fields{
+					"operation": operation, "attempts": attempt, "recovered": err == nil,
+				}).Debug("Kubernetes update retried after conflicts")
+			}
+			return err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"operation": operation, "attempt": attempt, "maxAttempts": policy.maxAttempts,
+		}).Debug("Retrying Kubernetes update after a conflict")
+		if !policy.waitForRetry(ctx, attempt) {
+			return err
+		}
+	}
+}
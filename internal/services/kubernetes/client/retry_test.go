@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// useFastRetries shortens the retry backoff for the duration of a test
+func useFastRetries(t *testing.T, attempts int) {
+	t.Helper()
+	SetTransportDefaults(TransportSettings{RetryMaxAttempts: attempts, RetryBackoff: time.Millisecond})
+	t.Cleanup(func() {
+		SetTransportDefaults(TransportSettings{RetryMaxAttempts: DefaultRetryMaxAttempts, RetryBackoff: DefaultRetryBackoff})
+	})
+}
+
+// conflictReactor fails the first conflicts updates of resource with a 409 Conflict and records the
+// resourceVersion each update carried
+func conflictReactor(resource string, conflicts int, versions *[]string) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		update := action.(k8stesting.UpdateAction)
+		accessor, err := meta.Accessor(update.GetObject())
+		if err != nil {
+			return true, nil, err
+		}
+		*versions = append(*versions, accessor.GetResourceVersion())
+		if len(*versions) <= conflicts {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: resource}, accessor.GetName(), nil)
+		}
+		return false, nil, nil
+	}
+}
+
+func TestCordonNodeRetriesConflicts(t *testing.T) {
+	useFastRetries(t, 3)
+
+	clientset := fake.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", ResourceVersion: "1"}})
+	var versions []string
+	clientset.PrependReactor("update", "nodes", conflictReactor("nodes", 2, &versions))
+	c := &Client{clientset: clientset}
+
+	if err := c.CordonNode(context.Background(), "node-1"); err != nil {
+		t.Fatalf("CordonNode() error = %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("expected the node to be re-read and updated 3 times, got %d", len(versions))
+	}
+	node, err := clientset.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	if err != nil || !node.Spec.Unschedulable {
+		t.Fatalf("expected the node to be cordoned, got %+v, %v", node, err)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	useFastRetries(t, 3)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &retryTransport{next: http.DefaultTransport}}
+
+	resp, err := client.Get(server.URL + "/api/v1/pods")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Fatalf("expected the read to succeed on the third attempt, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+
+	// Creates are not retried, nor are watches
+	calls.Store(0)
+	resp, err = client.Post(server.URL+"/api/v1/namespaces/shop/pods", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Fatalf("expected a single create attempt, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+	calls.Store(0)
+	resp, err = client.Get(server.URL + "/api/v1/pods?watch=true")
+	if err != nil {
+		t.Fatalf("watch error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single watch attempt, got %d calls", calls.Load())
+	}
+}
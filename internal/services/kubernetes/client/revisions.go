@@ -0,0 +1,316 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// deploymentRevisionAnnotation numbers the ReplicaSets of a Deployment, and the Deployment itself
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// changeCauseAnnotation records why a revision was made, when the user set it
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+	// maxRevisionDifferences bounds the pod template differences reported between two revisions
+	maxRevisionDifferences = 50
+)
+
+// WorkloadRevision is one recorded pod template of a workload: a ReplicaSet of a Deployment, or a
+// ControllerRevision of a StatefulSet or DaemonSet
+type WorkloadRevision struct {
+	Revision    int64    `json:"revision"`
+	Name        string   `json:"name"`
+	Created     string   `json:"created"`
+	Current     bool     `json:"current,omitempty"`
+	Replicas    *int32   `json:"replicas,omitempty"`
+	ChangeCause string   `json:"changeCause,omitempty"`
+	Images      []string `json:"images"`
+}
+
+// RevisionFieldChange is one pod template field that differs between two revisions
+type RevisionFieldChange struct {
+	Path string `json:"path"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// WorkloadRevisionDiff lists the revisions of a workload, newest first, and the pod template changes
+// between two of them
+type WorkloadRevisionDiff struct {
+	Kind             string                `json:"kind"`
+	Name             string                `json:"name"`
+	Namespace        string                `json:"namespace"`
+	Revisions        []WorkloadRevision    `json:"revisions"`
+	FromRevision     int64                 `json:"fromRevision,omitempty"`
+	ToRevision       int64                 `json:"toRevision,omitempty"`
+	Changes          []RevisionFieldChange `json:"changes"`
+	TotalChanges     int                   `json:"totalChanges"`
+	ChangesTruncated bool                  `json:"changesTruncated,omitempty"`
+}
+
+// workloadRevision is a revision together with its pod template in comparable form
+type workloadRevision struct {
+	WorkloadRevision
+	template map[string]any
+}
+
+// GetWorkloadRevisionDiff lists the revisions of a Deployment (its ReplicaSets), StatefulSet or
+// DaemonSet (their ControllerRevisions) and diffs the pod template of fromRevision against toRevision.
+// toRevision defaults to the newest revision and fromRevision to the one before it, which explains what
+// the last rollout changed. With a single revision only the list is returned.
+func (c *Client) GetWorkloadRevisionDiff(ctx context.Context, kind, name, namespace string, fromRevision, toRevision int64) (*WorkloadRevisionDiff, error) {
+	logrus.WithFields(logrus.Fields{
+		"kind": kind, "name": name, "ns": namespace, "from": fromRevision, "to": toRevision,
+	}).Debug("GetWorkloadRevisionDiff called")
+
+	if name == "" || namespace == "" {
+		return nil, fmt.Errorf("name and namespace are required")
+	}
+	var revisions []workloadRevision
+	var err error
+	switch strings.ToLower(kind) {
+	case "", "deployment", "deploy":
+		kind = "Deployment"
+		revisions, err = c.deploymentRevisions(ctx, name, namespace)
+	case "statefulset", "sts":
+		kind = "StatefulSet"
+		revisions, err = c.statefulSetRevisions(ctx, name, namespace)
+	case "daemonset", "ds":
+		kind = "DaemonSet"
+		revisions, err = c.daemonSetRevisions(ctx, name, namespace)
+	default:
+		return nil, fmt.Errorf("unsupported kind %q: must be Deployment, StatefulSet or DaemonSet", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision > revisions[j].Revision })
+
+	result := &WorkloadRevisionDiff{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Revisions: make([]WorkloadRevision, 0, len(revisions)),
+		Changes:   []RevisionFieldChange{},
+	}
+	byNumber := make(map[int64]*workloadRevision, len(revisions))
+	for i := range revisions {
+		result.Revisions = append(result.Revisions, revisions[i].WorkloadRevision)
+		byNumber[revisions[i].Revision] = &revisions[i]
+	}
+
+	if toRevision <= 0 && len(revisions) > 0 {
+		toRevision = revisions[0].Revision
+	}
+	if fromRevision <= 0 {
+		// The newest revision older than toRevision
+		for _, revision := range revisions {
+			if revision.Revision < toRevision {
+				fromRevision = revision.Revision
+				break
+			}
+		}
+	}
+	if fromRevision <= 0 {
+		logrus.WithField("revisions", len(revisions)).Debug("GetWorkloadRevisionDiff found nothing to compare")
+		return result, nil
+	}
+
+	for _, number := range []int64{fromRevision, toRevision} {
+		if byNumber[number] == nil {
+			return nil, fmt.Errorf("%s %s/%s has no revision %d; available revisions: %s", kind, namespace, name, number, revisionNumbers(revisions))
+		}
+	}
+	from, to := byNumber[fromRevision], byNumber[toRevision]
+	result.FromRevision, result.ToRevision = fromRevision, toRevision
+
+	var differences []NamespaceFieldDrift
+	diffValues("", from.template, to.template, &differences)
+	result.TotalChanges = len(differences)
+	if len(differences) > maxRevisionDifferences {
+		differences = differences[:maxRevisionDifferences]
+		result.ChangesTruncated = true
+	}
+	for _, difference := range differences {
+		result.Changes = append(result.Changes, RevisionFieldChange{Path: difference.Path, From: difference.Source, To: difference.Target})
+	}
+
+	logrus.WithFields(logrus.Fields{"revisions": len(revisions), "changes": result.TotalChanges}).Debug("GetWorkloadRevisionDiff succeeded")
+	return result, nil
+}
+
+// deploymentRevisions reads the ReplicaSets a Deployment owns, numbered by their revision annotation
+func (c *Client) deploymentRevisions(ctx context.Context, name, namespace string) ([]workloadRevision, error) {
+	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Deployment %s/%s: %w", namespace, name, err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on Deployment %s/%s: %w", namespace, name, err)
+	}
+	replicaSets, err := c.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ReplicaSets of Deployment %s/%s: %w", namespace, name, err)
+	}
+
+	current := deployment.Annotations[deploymentRevisionAnnotation]
+	var revisions []workloadRevision
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		number, err := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64)
+		if err != nil || !ownedBy(rs.OwnerReferences, deployment.UID) {
+			continue
+		}
+		template := rs.Spec.Template.DeepCopy()
+		// The hash label differs between every two ReplicaSets and says nothing about the change
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		replicas := rs.Status.Replicas
+		revision, err := newWorkloadRevision(number, &rs.ObjectMeta, template)
+		if err != nil {
+			return nil, err
+		}
+		revision.Current = rs.Annotations[deploymentRevisionAnnotation] == current
+		revision.Replicas = &replicas
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// statefulSetRevisions reads the ControllerRevisions a StatefulSet owns
+func (c *Client) statefulSetRevisions(ctx context.Context, name, namespace string) ([]workloadRevision, error) {
+	statefulSet, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get StatefulSet %s/%s: %w", namespace, name, err)
+	}
+	return c.controllerRevisions(ctx, "StatefulSet", name, namespace, statefulSet.UID, statefulSet.Spec.Selector, statefulSet.Status.UpdateRevision)
+}
+
+// daemonSetRevisions reads the ControllerRevisions a DaemonSet owns; the newest one is current
+func (c *Client) daemonSetRevisions(ctx context.Context, name, namespace string) ([]workloadRevision, error) {
+	daemonSet, err := c.clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DaemonSet %s/%s: %w", namespace, name, err)
+	}
+	return c.controllerRevisions(ctx, "DaemonSet", name, namespace, daemonSet.UID, daemonSet.Spec.Selector, "")
+}
+
+// controllerRevisions reads the ControllerRevisions owned by the workload with uid. The revision named
+// current is marked as such, or the newest when current is empty.
+func (c *Client) controllerRevisions(ctx context.Context, kind, name, namespace string, uid types.UID, labelSelector *metav1.LabelSelector, current string) ([]workloadRevision, error) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on %s %s/%s: %w", kind, namespace, name, err)
+	}
+	list, err := c.clientset.AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ControllerRevisions of %s %s/%s: %w", kind, namespace, name, err)
+	}
+
+	var revisions []workloadRevision
+	var newest *workloadRevision
+	for i := range list.Items {
+		cr := &list.Items[i]
+		if !ownedBy(cr.OwnerReferences, uid) {
+			continue
+		}
+		template, err := controllerRevisionTemplate(cr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ControllerRevision %s: %w", cr.Name, err)
+		}
+		revision, err := newWorkloadRevision(cr.Revision, &cr.ObjectMeta, template)
+		if err != nil {
+			return nil, err
+		}
+		revision.Current = cr.Name == current
+		revisions = append(revisions, revision)
+	}
+	if current == "" {
+		for i := range revisions {
+			if newest == nil || revisions[i].Revision > newest.Revision {
+				newest = &revisions[i]
+			}
+		}
+		if newest != nil {
+			newest.Current = true
+		}
+	}
+	return revisions, nil
+}
+
+// controllerRevisionTemplate decodes the pod template a ControllerRevision stores as a patch of the
+// workload spec
+func controllerRevisionTemplate(cr *appsv1.ControllerRevision) (*corev1.PodTemplateSpec, error) {
+	raw := cr.Data.Raw
+	if len(raw) == 0 && cr.Data.Object != nil {
+		var err error
+		if raw, err = json.Marshal(cr.Data.Object); err != nil {
+			return nil, err
+		}
+	}
+	var data struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return &data.Spec.Template, nil
+}
+
+// newWorkloadRevision describes a revision and keeps its pod template in the form diffValues compares
+func newWorkloadRevision(number int64, meta *metav1.ObjectMeta, template *corev1.PodTemplateSpec) (workloadRevision, error) {
+	unstructuredTemplate, err := runtime.DefaultUnstructuredConverter.ToUnstructured(template)
+	if err != nil {
+		return workloadRevision{}, fmt.Errorf("failed to convert the pod template of %s: %w", meta.Name, err)
+	}
+	normalized, err := normalizeJSON(unstructuredTemplate)
+	if err != nil {
+		return workloadRevision{}, err
+	}
+	images := []string{}
+	for _, container := range template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return workloadRevision{
+		WorkloadRevision: WorkloadRevision{
+			Revision:    number,
+			Name:        meta.Name,
+			Created:     meta.CreationTimestamp.UTC().Format("2006-01-02T15:04:05Z"),
+			ChangeCause: meta.Annotations[changeCauseAnnotation],
+			Images:      images,
+		},
+		template: normalized,
+	}, nil
+}
+
+func ownedBy(references []metav1.OwnerReference, uid types.UID) bool {
+	for _, reference := range references {
+		if reference.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func revisionNumbers(revisions []workloadRevision) string {
+	if len(revisions) == 0 {
+		return "none"
+	}
+	numbers := make([]string, 0, len(revisions))
+	for _, revision := range revisions {
+		numbers = append(numbers, strconv.FormatInt(revision.Revision, 10))
+	}
+	return strings.Join(numbers, ", ")
+}
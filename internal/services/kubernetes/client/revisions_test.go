@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetWorkloadRevisionDiffDeployment(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	owner := []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: types.UID("deploy-uid")}}
+	replicaSet := func(name, revision, image, cause string, replicas int32) *appsv1.ReplicaSet {
+		annotations := map[string]string{deploymentRevisionAnnotation: revision}
+		if cause != "" {
+			annotations[changeCauseAnnotation] = cause
+		}
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{"app": "web"}, Annotations: annotations, OwnerReferences: owner},
+			Spec: appsv1.ReplicaSetSpec{
+				Selector: selector,
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: name}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: image}}},
+				},
+			},
+			Status: appsv1.ReplicaSetStatus{Replicas: replicas},
+		}
+	}
+	// Matches the selector but belongs to another Deployment
+	stray := replicaSet("web-other", "7", "web:0.1", "", 0)
+	stray.OwnerReferences = []metav1.OwnerReference{{Kind: "Deployment", Name: "other", UID: types.UID("other-uid")}}
+
+	c := &Client{clientset: fake.NewClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", UID: types.UID("deploy-uid"), Annotations: map[string]string{deploymentRevisionAnnotation: "3"}},
+			Spec:       appsv1.DeploymentSpec{Selector: selector},
+		},
+		replicaSet("web-a", "1", "web:1.0", "", 0),
+		replicaSet("web-b", "2", "web:1.1", "", 0),
+		replicaSet("web-c", "3", "web:1.2", "bump to 1.2", 3),
+		stray,
+	)}
+
+	diff, err := c.GetWorkloadRevisionDiff(context.Background(), "", "web", "shop", 0, 0)
+	if err != nil {
+		t.Fatalf("GetWorkloadRevisionDiff() error = %v", err)
+	}
+	if diff.Kind != "Deployment" || len(diff.Revisions) != 3 {
+		t.Fatalf("unexpected revisions: %+v", diff.Revisions)
+	}
+	newest := diff.Revisions[0]
+	if newest.Revision != 3 || !newest.Current || newest.ChangeCause != "bump to 1.2" || *newest.Replicas != 3 || newest.Images[0] != "web:1.2" {
+		t.Fatalf("unexpected newest revision: %+v", newest)
+	}
+	if diff.FromRevision != 2 || diff.ToRevision != 3 {
+		t.Fatalf("expected revision 2 to be compared with 3, got %d and %d", diff.FromRevision, diff.ToRevision)
+	}
+	// The pod-template-hash label is not reported as a change
+	if diff.TotalChanges != 1 || diff.Changes[0].Path != "spec.containers[0].image" || diff.Changes[0].From != "web:1.1" || diff.Changes[0].To != "web:1.2" {
+		t.Fatalf("unexpected changes: %+v", diff.Changes)
+	}
+
+	diff, err = c.GetWorkloadRevisionDiff(context.Background(), "deploy", "web", "shop", 1, 0)
+	if err != nil {
+		t.Fatalf("GetWorkloadRevisionDiff() error = %v", err)
+	}
+	if diff.FromRevision != 1 || diff.ToRevision != 3 || diff.Changes[0].From != "web:1.0" {
+		t.Fatalf("unexpected diff from revision 1: %+v", diff)
+	}
+
+	_, err = c.GetWorkloadRevisionDiff(context.Background(), "Deployment", "web", "shop", 7, 3)
+	if err == nil || !strings.Contains(err.Error(), "available revisions: 3, 2, 1") {
+		t.Fatalf("expected an unknown revision error listing the revisions, got %v", err)
+	}
+}
+
+func TestGetWorkloadRevisionDiffStatefulSet(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+	revision := func(name string, number int64, data string) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "shop", Labels: map[string]string{"app": "db"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "db", UID: types.UID("sts-uid")}},
+			},
+			Revision: number,
+			Data:     runtime.RawExtension{Raw: []byte(data)},
+		}
+	}
+	c := &Client{clientset: fake.NewClientset(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop", UID: types.UID("sts-uid")},
+			Spec:       appsv1.StatefulSetSpec{Selector: selector},
+			Status:     appsv1.StatefulSetStatus{UpdateRevision: "db-5d8f"},
+		},
+		revision("db-7c9b", 1, `{"spec":{"template":{"$patch":"replace","metadata":{"labels":{"app":"db"}},"spec":{"containers":[{"name":"db","image":"postgres:15"}]}}}}`),
+		revision("db-5d8f", 2, `{"spec":{"template":{"$patch":"replace","metadata":{"labels":{"app":"db"}},"spec":{"containers":[{"name":"db","image":"postgres:16","env":[{"name":"MODE","value":"fast"}]}]}}}}`),
+	)}
+
+	diff, err := c.GetWorkloadRevisionDiff(context.Background(), "sts", "db", "shop", 0, 0)
+	if err != nil {
+		t.Fatalf("GetWorkloadRevisionDiff() error = %v", err)
+	}
+	if diff.Kind != "StatefulSet" || len(diff.Revisions) != 2 || !diff.Revisions[0].Current || diff.Revisions[1].Current {
+		t.Fatalf("unexpected revisions: %+v", diff.Revisions)
+	}
+	if diff.TotalChanges != 2 {
+		t.Fatalf("expected the image and env changes, got %+v", diff.Changes)
+	}
+	if diff.Changes[0].Path != "spec.containers[0].env" || diff.Changes[1].Path != "spec.containers[0].image" || diff.Changes[1].To != "postgres:16" {
+		t.Fatalf("unexpected changes: %+v", diff.Changes)
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestScaleResourceReportsPreviousReplicas(t *testing.T) {
+	scaleClient := &fakescale.FakeScaleClient{}
+	var updated []int32
+	scaleClient.AddReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 3}}, nil
+	})
+	scaleClient.AddReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		updated = append(updated, obj.Spec.Replicas)
+		return true, obj, nil
+	})
+	c := &Client{scaleClient: scaleClient}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	result, err := c.ScaleResource(context.Background(), gvr, "web", "prod", 5, true)
+	if err != nil {
+		t.Fatalf("ScaleResource() error = %v", err)
+	}
+	if result.PreviousReplicas != 3 || result.Replicas != 5 || !result.Changed || !result.DryRun {
+		t.Fatalf("unexpected dry run result: %+v", result)
+	}
+
+	result, err = c.ScaleResource(context.Background(), gvr, "web", "prod", 3, false)
+	if err != nil {
+		t.Fatalf("ScaleResource() error = %v", err)
+	}
+	if result.PreviousReplicas != 3 || result.Changed || result.DryRun {
+		t.Fatalf("unexpected no-op result: %+v", result)
+	}
+	if len(updated) != 2 || updated[0] != 5 || updated[1] != 3 {
+		t.Fatalf("expected both calls to reach the scale subresource, got %v", updated)
+	}
+}
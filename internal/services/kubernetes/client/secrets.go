@@ -0,0 +1,263 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultCertExpiryWindowDays is how close to its expiry a certificate is flagged by default
+const DefaultCertExpiryWindowDays = 30
+
+// TLS certificate states reported by InspectTLSSecret.
+const (
+	CertStatusValid       = "valid"
+	CertStatusExpiring    = "expiring"
+	CertStatusExpired     = "expired"
+	CertStatusNotYetValid = "notYetValid"
+)
+
+// SecretTypeGroup lists the Secrets of one type. Secret data is never read.
+type SecretTypeGroup struct {
+	Type    string   `json:"type"`
+	Count   int      `json:"count"`
+	Secrets []string `json:"secrets"`
+}
+
+// GetSecretTypes groups the Secrets of a namespace, or of all namespaces when namespace is empty,
+// by type. Names are namespace-qualified when listing all namespaces.
+func (c *Client) GetSecretTypes(ctx context.Context, namespace string) ([]SecretTypeGroup, error) {
+	secrets, err := c.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	byType := map[string]*SecretTypeGroup{}
+	for _, secret := range secrets.Items {
+		secretType := string(secret.Type)
+		if secretType == "" {
+			secretType = string(corev1.SecretTypeOpaque)
+		}
+		group, ok := byType[secretType]
+		if !ok {
+			group = &SecretTypeGroup{Type: secretType}
+			byType[secretType] = group
+		}
+		name := secret.Name
+		if namespace == "" {
+			name = secret.Namespace + "/" + secret.Name
+		}
+		group.Count++
+		group.Secrets = append(group.Secrets, name)
+	}
+
+	groups := make([]SecretTypeGroup, 0, len(byType))
+	for _, group := range byType {
+		sort.Strings(group.Secrets)
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Type < groups[j].Type
+	})
+	return groups, nil
+}
+
+// TLSCertificate describes one certificate of a TLS Secret's chain
+type TLSCertificate struct {
+	Subject            string    `json:"subject"`
+	Issuer             string    `json:"issuer"`
+	DNSNames           []string  `json:"dnsNames,omitempty"`
+	IPAddresses        []string  `json:"ipAddresses,omitempty"`
+	EmailAddresses     []string  `json:"emailAddresses,omitempty"`
+	URIs               []string  `json:"uris,omitempty"`
+	SerialNumber       string    `json:"serialNumber"`
+	NotBefore          time.Time `json:"notBefore"`
+	NotAfter           time.Time `json:"notAfter"`
+	DaysUntilExpiry    int       `json:"daysUntilExpiry"`
+	Status             string    `json:"status"`
+	IsCA               bool      `json:"isCA"`
+	SelfSigned         bool      `json:"selfSigned"`
+	SignatureAlgorithm string    `json:"signatureAlgorithm"`
+	PublicKeyAlgorithm string    `json:"publicKeyAlgorithm"`
+}
+
+// TLSSecretInspection is the certificate chain of a TLS Secret. The private key is never included;
+// only whether it is present and matches the leaf certificate is reported.
+type TLSSecretInspection struct {
+	Name             string           `json:"name"`
+	Namespace        string           `json:"namespace"`
+	Type             string           `json:"type"`
+	Status           string           `json:"status"`
+	ExpiryWindowDays int              `json:"expiryWindowDays"`
+	Certificates     []TLSCertificate `json:"certificates"`
+	HasPrivateKey    bool             `json:"hasPrivateKey"`
+	KeyMatches       *bool            `json:"keyMatchesCertificate,omitempty"`
+	CACertificates   []TLSCertificate `json:"caCertificates,omitempty"`
+	Problems         []string         `json:"problems"`
+}
+
+// InspectTLSSecret parses the certificates in the tls.crt (and ca.crt, if any) of a Secret and
+// reports their subjects, issuers, SANs and validity, flagging certificates that expire within
+// expiryWindowDays. The Status of the result is that of the leaf certificate.
+func (c *Client) InspectTLSSecret(ctx context.Context, name, namespace string, expiryWindowDays int) (*TLSSecretInspection, error) {
+	logrus.WithFields(logrus.Fields{"name": name, "namespace": namespace}).Debug("Inspecting TLS secret")
+
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+	certPEM, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s of type %q has no %s", namespace, name, secret.Type, corev1.TLSCertKey)
+	}
+
+	now := time.Now()
+	window := time.Duration(expiryWindowDays) * 24 * time.Hour
+	result := &TLSSecretInspection{
+		Name:             name,
+		Namespace:        namespace,
+		Type:             string(secret.Type),
+		ExpiryWindowDays: expiryWindowDays,
+		Problems:         []string{},
+	}
+	if secret.Type != corev1.SecretTypeTLS {
+		result.Problems = append(result.Problems, fmt.Sprintf("secret has type %q, expected %q", secret.Type, corev1.SecretTypeTLS))
+	}
+
+	certs, err := parsePEMCertificates(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s of secret %s/%s: %w", corev1.TLSCertKey, namespace, name, err)
+	}
+	for i, cert := range certs {
+		described := describeCertificate(cert, now, window)
+		result.Certificates = append(result.Certificates, described)
+		if described.Status != CertStatusValid {
+			role := "leaf certificate"
+			if i > 0 {
+				role = fmt.Sprintf("chain certificate %d (%s)", i, described.Subject)
+			}
+			result.Problems = append(result.Problems, certificateProblem(role, described))
+		}
+	}
+	result.Status = result.Certificates[0].Status
+
+	if caPEM, ok := secret.Data["ca.crt"]; ok && len(caPEM) > 0 {
+		caCerts, err := parsePEMCertificates(caPEM)
+		if err != nil {
+			result.Problems = append(result.Problems, fmt.Sprintf("failed to parse ca.crt: %v", err))
+		}
+		for _, cert := range caCerts {
+			described := describeCertificate(cert, now, window)
+			result.CACertificates = append(result.CACertificates, described)
+			if described.Status != CertStatusValid {
+				result.Problems = append(result.Problems, certificateProblem(fmt.Sprintf("CA certificate (%s)", described.Subject), described))
+			}
+		}
+	}
+
+	keyPEM, hasKey := secret.Data[corev1.TLSPrivateKeyKey]
+	result.HasPrivateKey = hasKey && len(keyPEM) > 0
+	if result.HasPrivateKey {
+		_, err := tls.X509KeyPair(certPEM, keyPEM)
+		matches := err == nil
+		result.KeyMatches = &matches
+		if !matches {
+			result.Problems = append(result.Problems, "the private key in tls.key does not match the leaf certificate or cannot be parsed")
+		}
+	} else if secret.Type == corev1.SecretTypeTLS {
+		result.Problems = append(result.Problems, "secret has no tls.key")
+	}
+
+	logrus.WithFields(logrus.Fields{"name": name, "status": result.Status, "certificates": len(result.Certificates)}).Debug("Inspected TLS secret")
+	return result, nil
+}
+
+// parsePEMCertificates decodes every CERTIFICATE block of data, in order
+func parsePEMCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return certs, nil
+}
+
+func describeCertificate(cert *x509.Certificate, now time.Time, window time.Duration) TLSCertificate {
+	described := TLSCertificate{
+		Subject:            cert.Subject.String(),
+		Issuer:             cert.Issuer.String(),
+		DNSNames:           cert.DNSNames,
+		EmailAddresses:     cert.EmailAddresses,
+		SerialNumber:       hex.EncodeToString(cert.SerialNumber.Bytes()),
+		NotBefore:          cert.NotBefore.UTC(),
+		NotAfter:           cert.NotAfter.UTC(),
+		DaysUntilExpiry:    int(cert.NotAfter.Sub(now).Hours() / 24),
+		IsCA:               cert.IsCA,
+		SelfSigned:         selfSigned(cert),
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		PublicKeyAlgorithm: cert.PublicKeyAlgorithm.String(),
+	}
+	for _, ip := range cert.IPAddresses {
+		described.IPAddresses = append(described.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		described.URIs = append(described.URIs, uri.String())
+	}
+
+	switch {
+	case now.After(cert.NotAfter):
+		described.Status = CertStatusExpired
+	case now.Before(cert.NotBefore):
+		described.Status = CertStatusNotYetValid
+	case cert.NotAfter.Sub(now) <= window:
+		described.Status = CertStatusExpiring
+	default:
+		described.Status = CertStatusValid
+	}
+	return described
+}
+
+// selfSigned reports whether cert is its own issuer and signed by its own key. CheckSignatureFrom
+// is not used since it rejects leaf certificates that are not marked as a CA.
+func selfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawSubject, cert.RawIssuer) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+func certificateProblem(role string, cert TLSCertificate) string {
+	switch cert.Status {
+	case CertStatusExpired:
+		return fmt.Sprintf("%s expired on %s", role, cert.NotAfter.Format(time.RFC3339))
+	case CertStatusNotYetValid:
+		return fmt.Sprintf("%s is not valid before %s", role, cert.NotBefore.Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("%s expires on %s (in %d days)", role, cert.NotAfter.Format(time.RFC3339), cert.DaysUntilExpiry)
+	}
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// selfSignedPEM returns a self-signed certificate valid from notBefore to notAfter and its key
+func selfSignedPEM(t *testing.T, notBefore, notAfter time.Time) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "web.example.com"},
+		DNSNames:     []string{"web.example.com", "www.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey() error = %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestInspectTLSSecret(t *testing.T) {
+	now := time.Now()
+	validCert, validKey := selfSignedPEM(t, now.Add(-time.Hour), now.Add(90*24*time.Hour))
+	soonCert, soonKey := selfSignedPEM(t, now.Add(-time.Hour), now.Add(10*24*time.Hour))
+	expiredCert, _ := selfSignedPEM(t, now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+
+	tlsSecret := func(name string, cert, key []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key},
+		}
+	}
+	c := &Client{clientset: fake.NewClientset(
+		tlsSecret("valid", validCert, validKey),
+		tlsSecret("soon", soonCert, soonKey),
+		tlsSecret("expired", expiredCert, validKey),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "default"}, Data: map[string][]byte{"password": []byte("x")}},
+	)}
+	ctx := context.Background()
+
+	result, err := c.InspectTLSSecret(ctx, "valid", "default", DefaultCertExpiryWindowDays)
+	if err != nil {
+		t.Fatalf("InspectTLSSecret() error = %v", err)
+	}
+	cert := result.Certificates[0]
+	if result.Status != CertStatusValid || len(result.Problems) != 0 {
+		t.Fatalf("expected a valid certificate without problems, got %+v", result)
+	}
+	if cert.Subject != "CN=web.example.com" || !cert.SelfSigned || len(cert.DNSNames) != 2 || cert.IPAddresses[0] != "10.0.0.1" || cert.DaysUntilExpiry < 89 {
+		t.Fatalf("unexpected certificate details: %+v", cert)
+	}
+	if !result.HasPrivateKey || result.KeyMatches == nil || !*result.KeyMatches {
+		t.Fatalf("expected a matching private key, got %+v", result)
+	}
+
+	result, err = c.InspectTLSSecret(ctx, "soon", "default", DefaultCertExpiryWindowDays)
+	if err != nil {
+		t.Fatalf("InspectTLSSecret() error = %v", err)
+	}
+	if result.Status != CertStatusExpiring || len(result.Problems) != 1 {
+		t.Fatalf("expected a certificate expiring within the window, got %+v", result)
+	}
+	result, err = c.InspectTLSSecret(ctx, "soon", "default", 7)
+	if err != nil || result.Status != CertStatusValid {
+		t.Fatalf("expected a narrower window to accept the certificate, got %+v, %v", result, err)
+	}
+
+	result, err = c.InspectTLSSecret(ctx, "expired", "default", DefaultCertExpiryWindowDays)
+	if err != nil {
+		t.Fatalf("InspectTLSSecret() error = %v", err)
+	}
+	if result.Status != CertStatusExpired || *result.KeyMatches {
+		t.Fatalf("expected an expired certificate with a mismatched key, got %+v", result)
+	}
+
+	if _, err := c.InspectTLSSecret(ctx, "opaque", "default", DefaultCertExpiryWindowDays); err == nil || !strings.Contains(err.Error(), "has no tls.crt") {
+		t.Fatalf("expected a secret without tls.crt to fail, got %v", err)
+	}
+}
+
+func TestGetSecretTypes(t *testing.T) {
+	c := &Client{clientset: fake.NewClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"}, Type: corev1.SecretTypeOpaque},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "default"}, Type: corev1.SecretTypeTLS},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kube-system"}, Type: corev1.SecretTypeTLS},
+	)}
+
+	groups, err := c.GetSecretTypes(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("GetSecretTypes() error = %v", err)
+	}
+	if len(groups) != 2 || groups[0].Type != "Opaque" || groups[0].Count != 2 || groups[0].Secrets[0] != "a" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+
+	groups, err = c.GetSecretTypes(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetSecretTypes() error = %v", err)
+	}
+	if groups[0].Type != string(corev1.SecretTypeOpaque) || groups[1].Secrets[1] != "kube-system/other" {
+		t.Fatalf("expected namespace-qualified names across namespaces, got %+v", groups)
+	}
+}
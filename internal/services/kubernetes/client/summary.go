@@ -188,3 +188,58 @@ func convertToMap(summary *ResourceSummary) map[string]interface{} {
 
 	return result
 }
+
+// ContainerStatusSummary is the per-container state appended to pod summaries on request
+type ContainerStatusSummary struct {
+	Name         string `json:"name"`
+	Init         bool   `json:"init,omitempty"`
+	Ready        bool   `json:"ready"`
+	RestartCount int64  `json:"restartCount"`
+	State        string `json:"state"`
+	Reason       string `json:"reason,omitempty"`
+	LastReason   string `json:"lastTerminationReason,omitempty"`
+	ExitCode     *int64 `json:"exitCode,omitempty"`
+}
+
+// PodContainerStatuses returns the ready state, restart count and waiting or terminated reason of
+// each container of a Pod object. It returns nil for other kinds or pods without container statuses.
+func PodContainerStatuses(obj map[string]interface{}) []ContainerStatusSummary {
+	if kind, _ := obj["kind"].(string); kind != "Pod" {
+		return nil
+	}
+
+	var statuses []ContainerStatusSummary
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		list, _, _ := unstructured.NestedSlice(obj, "status", field)
+		for _, item := range list {
+			status, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			summary := ContainerStatusSummary{Init: field == "initContainerStatuses"}
+			summary.Name, _, _ = unstructured.NestedString(status, "name")
+			summary.Ready, _, _ = unstructured.NestedBool(status, "ready")
+			summary.RestartCount, _, _ = unstructured.NestedInt64(status, "restartCount")
+			summary.LastReason, _, _ = unstructured.NestedString(status, "lastState", "terminated", "reason")
+
+			// A container is in exactly one of the waiting, running or terminated states
+			for _, state := range []string{"waiting", "terminated", "running"} {
+				details, found, _ := unstructured.NestedMap(status, "state", state)
+				if !found {
+					continue
+				}
+				summary.State = state
+				summary.Reason, _ = details["reason"].(string)
+				if exitCode, ok := details["exitCode"].(int64); ok {
+					summary.ExitCode = &exitCode
+				}
+				break
+			}
+			if summary.State == "" {
+				summary.State = "unknown"
+			}
+			statuses = append(statuses, summary)
+		}
+	}
+	return statuses
+}
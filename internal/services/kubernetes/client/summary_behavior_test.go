@@ -63,3 +63,43 @@ func TestExtractResourceSummaryIncludesSelectedPodLabels(t *testing.T) {
 		t.Fatalf("expected only requested labels, got %#v", labels)
 	}
 }
+
+func TestPodContainerStatuses(t *testing.T) {
+	pod := map[string]any{
+		"kind": "Pod",
+		"status": map[string]any{
+			"initContainerStatuses": []any{
+				map[string]any{"name": "init", "ready": true, "state": map[string]any{"terminated": map[string]any{"reason": "Completed", "exitCode": int64(0)}}},
+			},
+			"containerStatuses": []any{
+				map[string]any{
+					"name":         "app",
+					"ready":        false,
+					"restartCount": int64(7),
+					"state":        map[string]any{"waiting": map[string]any{"reason": "CrashLoopBackOff"}},
+					"lastState":    map[string]any{"terminated": map[string]any{"reason": "OOMKilled", "exitCode": int64(137)}},
+				},
+				map[string]any{"name": "proxy", "ready": true, "state": map[string]any{"running": map[string]any{}}},
+			},
+		},
+	}
+
+	statuses := PodContainerStatuses(pod)
+	if len(statuses) != 3 {
+		t.Fatalf("expected init and regular containers, got %+v", statuses)
+	}
+	if init := statuses[0]; !init.Init || init.State != "terminated" || init.Reason != "Completed" || init.ExitCode == nil || *init.ExitCode != 0 {
+		t.Fatalf("unexpected init container status: %+v", init)
+	}
+	app := statuses[1]
+	if app.Ready || app.RestartCount != 7 || app.State != "waiting" || app.Reason != "CrashLoopBackOff" || app.LastReason != "OOMKilled" {
+		t.Fatalf("unexpected app container status: %+v", app)
+	}
+	if proxy := statuses[2]; !proxy.Ready || proxy.State != "running" || proxy.Reason != "" {
+		t.Fatalf("unexpected proxy container status: %+v", proxy)
+	}
+
+	if PodContainerStatuses(map[string]any{"kind": "Deployment"}) != nil {
+		t.Fatal("expected no container statuses for non-Pod kinds")
+	}
+}
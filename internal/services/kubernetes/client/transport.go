@@ -0,0 +1,229 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+)
+
+// Built-in transport defaults. client-go itself uses QPS 5, burst 10 and 25 idle connections per
+// host, which throttles parallel tool calls against large clusters.
+const (
+	DefaultQPS                 float32 = 100
+	DefaultBurst                       = 200
+	DefaultTimeout                     = 30 * time.Second
+	DefaultMaxIdleConns                = 200
+	DefaultMaxIdleConnsPerHost         = 100
+	DefaultRetryMaxAttempts            = 3
+	DefaultRetryBackoff                = 200 * time.Millisecond
+	DefaultUserAgent                   = "cloud-native-mcp-server"
+)
+
+// TransportSettings tune how clients talk to the API server. Zero fields keep the built-in default.
+type TransportSettings struct {
+	QPS                 float32       // Client-side rate limit in requests per second
+	Burst               int           // Requests allowed above QPS in a burst
+	Timeout             time.Duration // Per-request timeout
+	MaxIdleConns        int           // Idle connections kept open across all API servers
+	MaxIdleConnsPerHost int           // Idle connections kept open per API server
+	RateLimitDiscovery  bool          // Apply QPS/burst to discovery requests, which bypass it by default
+	RetryMaxAttempts    int           // Attempts per transient read failure or update conflict; 1 disables retries
+	RetryBackoff        time.Duration // Wait before the first retry, doubled for each further one
+	UserAgent           string        // User-Agent sent with every request
+}
+
+var (
+	transportMu       sync.RWMutex
+	transportDefaults = TransportSettings{
+		QPS:                 DefaultQPS,
+		Burst:               DefaultBurst,
+		Timeout:             DefaultTimeout,
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		RetryMaxAttempts:    DefaultRetryMaxAttempts,
+		RetryBackoff:        DefaultRetryBackoff,
+		UserAgent:           DefaultUserAgent,
+	}
+
+	// tunedTransports shares one connection pool per TLS identity and pool size, like client-go's
+	// own transport cache, so clients built per request reuse connections
+	tunedTransportsMu sync.Mutex
+	tunedTransports   = map[tunedTransportKey]*http.Transport{}
+)
+
+// SetTransportDefaults sets the throughput settings used for clients whose request headers do
+// not override them
+func SetTransportDefaults(settings TransportSettings) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	if settings.QPS > 0 {
+		transportDefaults.QPS = settings.QPS
+	}
+	if settings.Burst > 0 {
+		transportDefaults.Burst = settings.Burst
+	}
+	if settings.Timeout > 0 {
+		transportDefaults.Timeout = settings.Timeout
+	}
+	if settings.MaxIdleConns > 0 {
+		transportDefaults.MaxIdleConns = settings.MaxIdleConns
+	}
+	if settings.MaxIdleConnsPerHost > 0 {
+		transportDefaults.MaxIdleConnsPerHost = settings.MaxIdleConnsPerHost
+	}
+	if settings.RetryMaxAttempts > 0 {
+		transportDefaults.RetryMaxAttempts = settings.RetryMaxAttempts
+	}
+	if settings.RetryBackoff > 0 {
+		transportDefaults.RetryBackoff = settings.RetryBackoff
+	}
+	if settings.UserAgent != "" {
+		transportDefaults.UserAgent = settings.UserAgent
+	}
+	transportDefaults.RateLimitDiscovery = settings.RateLimitDiscovery
+}
+
+func currentTransportDefaults() TransportSettings {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return transportDefaults
+}
+
+// tunedTransportKey mirrors client-go's TLS cache key and adds the pool sizes
+type tunedTransportKey struct {
+	insecure            bool
+	caData              string
+	certData            string
+	keyData             string
+	serverName          string
+	nextProtos          string
+	disableCompression  bool
+	getCert             *transport.GetCertHolder
+	dial                *transport.DialHolder
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+}
+
+// newHTTPClient builds the HTTP client shared by the typed, dynamic, discovery and metrics
+// clients, with an idle connection pool sized by the options and transient read failures retried.
+// Configurations client-go cannot cache either (custom proxy or transport, reloaded certificate
+// files) keep client-go's transport.
+func newHTTPClient(config *rest.Config, maxIdleConns, maxIdleConnsPerHost int) (*http.Client, error) {
+	client, err := newPooledHTTPClient(config, maxIdleConns, maxIdleConnsPerHost)
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = &retryTransport{next: client.Transport}
+	return client, nil
+}
+
+func newPooledHTTPClient(config *rest.Config, maxIdleConns, maxIdleConnsPerHost int) (*http.Client, error) {
+	if maxIdleConns <= 0 && maxIdleConnsPerHost <= 0 {
+		return rest.HTTPClientFor(config)
+	}
+	transportConfig, err := config.TransportConfig()
+	if err != nil {
+		return nil, err
+	}
+	if transportConfig.Transport != nil || transportConfig.Proxy != nil || transportConfig.TLS.ReloadTLSFiles {
+		return rest.HTTPClientFor(config)
+	}
+
+	base, err := tunedTransport(transportConfig, maxIdleConns, maxIdleConnsPerHost)
+	if err != nil {
+		return nil, err
+	}
+	roundTripper, err := transport.HTTPWrappersForConfig(transportConfig, base)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: roundTripper, Timeout: config.Timeout}, nil
+}
+
+func tunedTransport(config *transport.Config, maxIdleConns, maxIdleConnsPerHost int) (*http.Transport, error) {
+	key, err := newTunedTransportKey(config, maxIdleConns, maxIdleConnsPerHost)
+	if err != nil {
+		return nil, err
+	}
+
+	tunedTransportsMu.Lock()
+	defer tunedTransportsMu.Unlock()
+	if cached, ok := tunedTransports[key]; ok {
+		return cached, nil
+	}
+
+	tlsConfig, err := transport.TLSConfigFor(config)
+	if err != nil {
+		return nil, err
+	}
+	dial := (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	if config.DialHolder != nil {
+		dial = config.DialHolder.Dial
+	}
+	tuned := utilnet.SetTransportDefaults(&http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSHandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        maxIdleConns,
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		DialContext:         dial,
+		DisableCompression:  config.DisableCompression,
+	})
+	tunedTransports[key] = tuned
+
+	logrus.WithFields(logrus.Fields{
+		"maxIdleConns":        maxIdleConns,
+		"maxIdleConnsPerHost": maxIdleConnsPerHost,
+		"transports":          len(tunedTransports),
+	}).Debug("Created Kubernetes transport")
+	return tuned, nil
+}
+
+func newTunedTransportKey(config *transport.Config, maxIdleConns, maxIdleConnsPerHost int) (tunedTransportKey, error) {
+	caData, err := tlsData(config.TLS.CAData, config.TLS.CAFile)
+	if err != nil {
+		return tunedTransportKey{}, err
+	}
+	certData, err := tlsData(config.TLS.CertData, config.TLS.CertFile)
+	if err != nil {
+		return tunedTransportKey{}, err
+	}
+	keyData, err := tlsData(config.TLS.KeyData, config.TLS.KeyFile)
+	if err != nil {
+		return tunedTransportKey{}, err
+	}
+	return tunedTransportKey{
+		insecure:            config.TLS.Insecure,
+		caData:              caData,
+		certData:            certData,
+		keyData:             keyData,
+		serverName:          config.TLS.ServerName,
+		nextProtos:          strings.Join(config.TLS.NextProtos, ","),
+		disableCompression:  config.DisableCompression,
+		getCert:             config.TLS.GetCertHolder,
+		dial:                config.DialHolder,
+		maxIdleConns:        maxIdleConns,
+		maxIdleConnsPerHost: maxIdleConnsPerHost,
+	}, nil
+}
+
+// tlsData returns inline TLS material, reading it from its file when only a path is set
+func tlsData(data []byte, file string) (string, error) {
+	if len(data) > 0 || file == "" {
+		return string(data), nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return string(content), nil
+}
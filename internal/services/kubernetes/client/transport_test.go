@@ -0,0 +1,118 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestTunedTransportIsSharedPerTLSIdentity(t *testing.T) {
+	insecure := rest.TLSClientConfig{Insecure: true}
+	first := &rest.Config{Host: "https://10.0.0.1:6443", BearerToken: "first", TLSClientConfig: insecure}
+	second := &rest.Config{Host: "https://10.0.0.2:6443", BearerToken: "second", TLSClientConfig: insecure}
+
+	transportFor := func(config *rest.Config, maxIdle, maxIdlePerHost int) *http.Transport {
+		t.Helper()
+		transportConfig, err := config.TransportConfig()
+		if err != nil {
+			t.Fatalf("TransportConfig() error = %v", err)
+		}
+		tuned, err := tunedTransport(transportConfig, maxIdle, maxIdlePerHost)
+		if err != nil {
+			t.Fatalf("tunedTransport() error = %v", err)
+		}
+		return tuned
+	}
+
+	tuned := transportFor(first, 300, 150)
+	if tuned.MaxIdleConns != 300 || tuned.MaxIdleConnsPerHost != 150 || !tuned.TLSClientConfig.InsecureSkipVerify {
+		t.Fatalf("unexpected transport settings: maxIdle=%d perHost=%d", tuned.MaxIdleConns, tuned.MaxIdleConnsPerHost)
+	}
+	if transportFor(second, 300, 150) != tuned {
+		t.Fatal("expected configs with the same TLS identity to share a connection pool")
+	}
+	if transportFor(first, 300, 50) == tuned {
+		t.Fatal("expected a different pool size to get its own transport")
+	}
+
+	client, err := newHTTPClient(&rest.Config{Host: first.Host, TLSClientConfig: insecure, Timeout: 5 * time.Second}, 300, 150)
+	if err != nil {
+		t.Fatalf("newHTTPClient() error = %v", err)
+	}
+	if client.Timeout != 5*time.Second {
+		t.Fatalf("expected the request timeout to be kept, got %v", client.Timeout)
+	}
+
+	// A custom proxy cannot be compared, so client-go's transport is used as is
+	tunedTransportsMu.Lock()
+	cached := len(tunedTransports)
+	tunedTransportsMu.Unlock()
+	proxied := &rest.Config{Host: first.Host, TLSClientConfig: insecure, Proxy: func(*http.Request) (*url.URL, error) { return nil, nil }}
+	if _, err := newHTTPClient(proxied, 300, 150); err != nil {
+		t.Fatalf("newHTTPClient() error = %v", err)
+	}
+	tunedTransportsMu.Lock()
+	defer tunedTransportsMu.Unlock()
+	if len(tunedTransports) != cached {
+		t.Fatal("expected a proxied config to keep client-go's transport")
+	}
+}
+
+func TestSetTransportDefaults(t *testing.T) {
+	previous := currentTransportDefaults()
+	defer func() {
+		transportMu.Lock()
+		transportDefaults = previous
+		transportMu.Unlock()
+	}()
+
+	SetTransportDefaults(TransportSettings{QPS: 250, MaxIdleConnsPerHost: 64})
+	opts := DefaultClientOptions()
+	if opts.QPS != 250 || opts.MaxIdleConnsPerHost != 64 {
+		t.Fatalf("expected the configured settings, got %+v", opts)
+	}
+	if opts.Burst != DefaultBurst || opts.MaxIdleConns != DefaultMaxIdleConns || opts.Timeout != DefaultTimeout || opts.UserAgent != DefaultUserAgent {
+		t.Fatalf("expected unset settings to keep their defaults, got %+v", opts)
+	}
+}
+
+func TestNewClientSendsUserAgent(t *testing.T) {
+	userAgents := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case userAgents <- r.UserAgent():
+		default:
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"major":"1","minor":"31","gitVersion":"v1.31.0"}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config")
+	kubeconfig := fmt.Sprintf("apiVersion: v1\nkind: Config\ncurrent-context: test\ncontexts:\n- name: test\n  context: {cluster: test, user: test}\nclusters:\n- name: test\n  cluster: {server: %q}\nusers:\n- name: test\n  user: {token: abc}\n", server.URL)
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := DefaultClientOptions()
+	opts.ConnectionMode = "kubeconfig"
+	opts.KubeconfigPath = path
+	opts.UserAgent = "cloud-native-mcp-server/1.2.3"
+	c, err := NewClientWithOptions(opts)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if _, err := c.clientset.Discovery().ServerVersion(); err != nil {
+		t.Fatalf("ServerVersion() error = %v", err)
+	}
+	if got := <-userAgents; got != "cloud-native-mcp-server/1.2.3" {
+		t.Fatalf("User-Agent = %q, want the configured one", got)
+	}
+}
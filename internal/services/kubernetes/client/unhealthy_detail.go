@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// UnhealthyWarningEvent is the most relevant recent Warning event of an unhealthy resource
+type UnhealthyWarningEvent struct {
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message,omitempty"`
+	Count    int32     `json:"count,omitempty"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// setPodFailureDetail records the container most likely behind an unhealthy pod: its name, waiting reason,
+// restart count and the exit code of its last terminated run
+func (r *UnhealthyResource) setPodFailureDetail(obj map[string]interface{}) {
+	var pod corev1.Pod
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &pod); err != nil {
+		logrus.Debugf("Failed to convert pod %s/%s: %v", r.Namespace, r.Name, err)
+		return
+	}
+	status, init := failingContainer(&pod)
+	if status == nil {
+		return
+	}
+
+	container := containerRestarts(*status, init)
+	r.Container = container.Name
+	r.InitContainer = init
+	r.RestartCount = &container.Restarts
+	if status.State.Waiting != nil {
+		r.WaitingReason = status.State.Waiting.Reason
+	}
+	r.LastExitCode = container.LastExitCode
+	r.LastTerminationReason = container.LastTerminationReason
+	// A container that never restarted reports its current termination instead
+	if r.LastExitCode == nil && status.State.Terminated != nil {
+		exitCode := status.State.Terminated.ExitCode
+		r.LastExitCode = &exitCode
+		r.LastTerminationReason = status.State.Terminated.Reason
+	}
+}
+
+// failingContainer picks the container most likely behind a pod's failure, ranked by containerFailureRank
+// with more restarts winning ties. It returns nil when every container looks healthy.
+func failingContainer(pod *corev1.Pod) (*corev1.ContainerStatus, bool) {
+	var (
+		best     *corev1.ContainerStatus
+		bestInit bool
+		bestRank int
+	)
+	consider := func(statuses []corev1.ContainerStatus, init bool) {
+		for i := range statuses {
+			status := &statuses[i]
+			rank := containerFailureRank(status)
+			if rank == 0 {
+				continue
+			}
+			if best == nil || rank > bestRank || (rank == bestRank && status.RestartCount > best.RestartCount) {
+				best, bestInit, bestRank = status, init, rank
+			}
+		}
+	}
+	consider(pod.Status.InitContainerStatuses, true)
+	consider(pod.Status.ContainerStatuses, false)
+	return best, bestInit
+}
+
+// containerFailureRank orders containers by how likely they explain a failure: crash looping, then waiting
+// on an error such as ImagePullBackOff, then exited non-zero, then not ready or restarting. Zero means healthy.
+func containerFailureRank(status *corev1.ContainerStatus) int {
+	switch {
+	case status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff":
+		return 4
+	case status.State.Waiting != nil && !isStartupWaitingReason(status.State.Waiting.Reason):
+		return 3
+	case status.State.Terminated != nil && status.State.Terminated.ExitCode != 0:
+		return 2
+	case status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.ExitCode != 0:
+		return 2
+	case status.State.Terminated != nil:
+		// Completed init containers are not ready but done
+		return 0
+	case !status.Ready || status.RestartCount > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isStartupWaitingReason reports waiting reasons of containers that are still being started normally
+func isStartupWaitingReason(reason string) bool {
+	return reason == "" || reason == "ContainerCreating" || reason == "PodInitializing"
+}
+
+// AttachWarningEvents sets the newest Warning event of each resource in resources, the one most likely
+// to describe its current state. Warning events are listed once per namespace of the resources.
+func (c *Client) AttachWarningEvents(ctx context.Context, resources []UnhealthyResource) error {
+	byObject := make(map[string][]*UnhealthyResource)
+	var namespaces []string
+	seenNamespace := make(map[string]bool)
+	for i := range resources {
+		r := &resources[i]
+		key := r.Kind + "/" + r.Namespace + "/" + r.Name
+		byObject[key] = append(byObject[key], r)
+		if !seenNamespace[r.Namespace] {
+			seenNamespace[r.Namespace] = true
+			namespaces = append(namespaces, r.Namespace)
+		}
+	}
+
+	var failed []string
+	for _, namespace := range namespaces {
+		events, _, err := c.listEventsNewestFirst(ctx, namespace, "type=Warning", time.Time{})
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			failed = append(failed, fmt.Sprintf("%s: %v", namespace, err))
+			continue
+		}
+		for _, event := range events {
+			if event.Type != corev1.EventTypeWarning {
+				continue
+			}
+			ref := event.InvolvedObject
+			key := ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+			targets := byObject[key]
+			if len(targets) == 0 {
+				continue
+			}
+			warning := &UnhealthyWarningEvent{
+				Reason:   event.Reason,
+				Message:  event.Message,
+				Count:    event.Count,
+				LastSeen: eventLastSeen(event),
+			}
+			if event.Series != nil {
+				warning.Count = event.Series.Count
+			}
+			for _, r := range targets {
+				r.WarningEvent = warning
+			}
+			// Events are newest first, so later ones for the same object are older
+			delete(byObject, key)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to list warning events: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetPodFailureDetail(t *testing.T) {
+	exitCode := func(code int32) *int32 { return &code }
+	tests := []struct {
+		name          string
+		status        corev1.PodStatus
+		wantContainer string
+		wantInit      bool
+		wantWaiting   string
+		wantRestarts  int32
+		wantExitCode  *int32
+	}{
+		{
+			name: "crash looping container wins over a not ready one",
+			status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "sidecar", RestartCount: 9, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{
+					Name: "app", RestartCount: 4,
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Reason: "Error", ExitCode: 137,
+					}},
+				},
+			}},
+			wantContainer: "app", wantWaiting: "CrashLoopBackOff", wantRestarts: 4, wantExitCode: exitCode(137),
+		},
+		{
+			name: "image pull failure in an init container",
+			status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{Name: "migrate", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}},
+				},
+			},
+			wantContainer: "migrate", wantInit: true, wantWaiting: "ImagePullBackOff",
+		},
+		{
+			name: "failed container that never restarted reports its exit code",
+			status: corev1.PodStatus{Phase: corev1.PodFailed, ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "job", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 2}}},
+			}},
+			wantContainer: "job", wantExitCode: exitCode(2),
+		},
+		{
+			name: "healthy containers",
+			status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "default"}, Status: tt.status}
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+			if err != nil {
+				t.Fatalf("ToUnstructured() error = %v", err)
+			}
+			r := &UnhealthyResource{Kind: "Pod", Name: "p", Namespace: "default"}
+			r.setPodFailureDetail(obj)
+
+			if r.Container != tt.wantContainer || r.InitContainer != tt.wantInit || r.WaitingReason != tt.wantWaiting {
+				t.Fatalf("unexpected container detail: %+v", r)
+			}
+			if tt.wantContainer == "" {
+				if r.RestartCount != nil || r.LastExitCode != nil {
+					t.Fatalf("expected no detail for a healthy pod, got %+v", r)
+				}
+				return
+			}
+			if r.RestartCount == nil || *r.RestartCount != tt.wantRestarts {
+				t.Fatalf("restartCount = %v, want %d", r.RestartCount, tt.wantRestarts)
+			}
+			if (r.LastExitCode == nil) != (tt.wantExitCode == nil) || (r.LastExitCode != nil && *r.LastExitCode != *tt.wantExitCode) {
+				t.Fatalf("lastExitCode = %v, want %v", r.LastExitCode, tt.wantExitCode)
+			}
+		})
+	}
+}
+
+func TestAttachWarningEvents(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	event := func(name, kind, object, eventType, reason string, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object, Namespace: "default"},
+			Type:           eventType,
+			Reason:         reason,
+			Count:          3,
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+	clientset := fake.NewClientset(
+		event("old", "Pod", "api-1", corev1.EventTypeWarning, "FailedScheduling", now.Add(-time.Hour)),
+		event("newest", "Pod", "api-1", corev1.EventTypeWarning, "BackOff", now.Add(-time.Minute)),
+		event("normal", "Pod", "api-1", corev1.EventTypeNormal, "Pulled", now),
+		event("other-kind", "Job", "api-1", corev1.EventTypeWarning, "BackoffLimitExceeded", now),
+	)
+	c := &Client{clientset: clientset}
+
+	resources := []UnhealthyResource{
+		{Kind: "Pod", Name: "api-1", Namespace: "default"},
+		{Kind: "Pod", Name: "quiet-1", Namespace: "default"},
+	}
+	if err := c.AttachWarningEvents(context.Background(), resources); err != nil {
+		t.Fatalf("AttachWarningEvents() error = %v", err)
+	}
+	warning := resources[0].WarningEvent
+	if warning == nil || warning.Reason != "BackOff" || warning.Count != 3 || !warning.LastSeen.Equal(now.Add(-time.Minute)) {
+		t.Fatalf("expected the newest Warning event of the pod, got %+v", warning)
+	}
+	if resources[1].WarningEvent != nil {
+		t.Fatalf("expected no event for a pod without warnings, got %+v", resources[1].WarningEvent)
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Severity weights used to rank unhealthy resources, worst first. Each resource gets the weight of the
+// first matching category plus one point per container restart, up to SeverityRestartBonusCap, so that
+// more restarts rank higher within a category.
+const (
+	SeverityCrashLoopBackOff = 100
+	SeverityUnschedulable    = 80
+	SeverityFailed           = 70
+	SeverityWaitingError     = 60
+	SeverityHighRestarts     = 40
+	SeverityNotReady         = 20
+
+	// HighRestartThreshold is the container restart count from which a resource counts as high-restart
+	HighRestartThreshold = 5
+	// SeverityRestartBonusCap bounds the restart bonus below the gap between categories
+	SeverityRestartBonusCap = 15
+)
+
+// RankUnhealthyResources scores each resource by severity and sorts them worst first. Ties keep kind,
+// namespace and name order so pages stay stable across calls.
+func RankUnhealthyResources(resources []UnhealthyResource) {
+	for i := range resources {
+		resources[i].Severity, resources[i].SeverityReason = unhealthySeverity(&resources[i])
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+func unhealthySeverity(r *UnhealthyResource) (int, string) {
+	var restarts int32
+	if r.RestartCount != nil {
+		restarts = *r.RestartCount
+	}
+	bonus := int(min(restarts, SeverityRestartBonusCap))
+
+	switch {
+	case r.WaitingReason == "CrashLoopBackOff" || r.Reason == "CrashLoopBackOff":
+		return SeverityCrashLoopBackOff + bonus, fmt.Sprintf("container %s is in CrashLoopBackOff after %d restarts", r.Container, restarts)
+	case r.IssueType == "unschedulable":
+		return SeverityUnschedulable, "pod cannot be scheduled"
+	case r.IssueType == "failed" || r.IssueType == "job_failed":
+		return SeverityFailed + bonus, fmt.Sprintf("%s has failed", r.Kind)
+	case r.WaitingReason != "" && !isStartupWaitingReason(r.WaitingReason):
+		return SeverityWaitingError + bonus, fmt.Sprintf("container %s is waiting: %s", r.Container, r.WaitingReason)
+	case restarts >= HighRestartThreshold:
+		return SeverityHighRestarts + bonus, fmt.Sprintf("container %s restarted %d times", r.Container, restarts)
+	default:
+		return SeverityNotReady + bonus, fmt.Sprintf("%s is not ready", r.Kind)
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func TestRankUnhealthyResources(t *testing.T) {
+	pod := func(name string, status corev1.PodStatus) *unstructured.Unstructured {
+		p := &corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.Now()},
+			Status:     status,
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(p)
+		if err != nil {
+			t.Fatalf("ToUnstructured() error = %v", err)
+		}
+		return &unstructured.Unstructured{Object: obj}
+	}
+	running := func(name string, restarts int32, ready bool) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name: name, RestartCount: restarts, Ready: ready,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}
+	}
+
+	pods := []runtime.Object{
+		pod("a-not-ready", corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+		}}),
+		pod("b-flapping", corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{
+			running("app", 12, false),
+			{Name: "sidecar", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+		}}),
+		pod("c-pending", corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{
+			Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable", Message: "0/3 nodes are available",
+		}}}),
+		pod("d-crash-few", corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "app", RestartCount: 2, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+		}}),
+		pod("e-crash-many", corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "app", RestartCount: 30, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+		}}),
+		pod("f-healthy", corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{running("app", 0, true)}}),
+	}
+
+	podsGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	c := &Client{
+		dynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{podsGVR: "PodList"}, pods...),
+		gvrCache:    map[string]schema.GroupVersionResource{"pod": podsGVR},
+		cacheExpiry: time.Now().Add(time.Hour),
+	}
+
+	unhealthy, err := c.GetUnhealthyResources(context.Background(), "default", []string{"Pod"})
+	if err != nil {
+		t.Fatalf("GetUnhealthyResources() error = %v", err)
+	}
+	RankUnhealthyResources(unhealthy)
+
+	want := []struct {
+		name     string
+		severity int
+	}{
+		{"e-crash-many", SeverityCrashLoopBackOff + SeverityRestartBonusCap},
+		{"d-crash-few", SeverityCrashLoopBackOff + 2},
+		{"c-pending", SeverityUnschedulable},
+		{"b-flapping", SeverityHighRestarts + 12},
+		{"a-not-ready", SeverityNotReady},
+	}
+	if len(unhealthy) != len(want) {
+		t.Fatalf("expected %d unhealthy pods, got %+v", len(want), unhealthy)
+	}
+	for i, w := range want {
+		if unhealthy[i].Name != w.name || unhealthy[i].Severity != w.severity || unhealthy[i].SeverityReason == "" {
+			t.Fatalf("position %d: got %s with severity %d (%q), want %s with %d",
+				i, unhealthy[i].Name, unhealthy[i].Severity, unhealthy[i].SeverityReason, w.name, w.severity)
+		}
+	}
+	if pending := unhealthy[2]; pending.IssueType != "unschedulable" || pending.Reason != "Unschedulable" {
+		t.Fatalf("expected the unschedulable pod to be detected, got %+v", pending)
+	}
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/dynamic"
+)
+
+// UpdateConflictError is a 409 Conflict on an update that could not be resolved automatically. It wraps
+// the API server's conflict, so apierrors.IsConflict still reports it.
+type UpdateConflictError struct {
+	Kind            string
+	Name            string
+	ResourceVersion string
+	// Paths are the fields changed both in the submitted manifest and on the server, differently
+	Paths  []string
+	Reason string
+	Err    error
+}
+
+func (e *UpdateConflictError) Error() string {
+	message := fmt.Sprintf("update of %s/%s conflicts with changes made since resourceVersion %s", e.Kind, e.Name, e.ResourceVersion)
+	if len(e.Paths) > 0 {
+		message += ": changed on both sides: " + strings.Join(e.Paths, ", ")
+	} else if e.Reason != "" {
+		message += ": " + e.Reason
+	}
+	return message + "; fetch the current object, reapply your changes and update again"
+}
+
+func (e *UpdateConflictError) Unwrap() error {
+	return e.Err
+}
+
+// resolveUpdateConflict retries an update that failed with conflict by merging three versions of the
+// object: the one at the submitted resourceVersion (the base the manifest was edited from), the
+// submitted manifest and the current object. Fields the manifest changed are applied to the current
+// object; fields only the server changed are kept. A field changed differently on both sides, or a
+// base that can no longer be read, returns an UpdateConflictError for manual resolution.
+func (c *Client) resolveUpdateConflict(ctx context.Context, resourceClient dynamic.ResourceInterface, submitted *unstructured.Unstructured, conflict error) (*unstructured.Unstructured, error) {
+	unresolved := &UpdateConflictError{
+		Kind:            submitted.GetKind(),
+		Name:            submitted.GetName(),
+		ResourceVersion: submitted.GetResourceVersion(),
+		Err:             conflict,
+	}
+	if unresolved.ResourceVersion == "" {
+		unresolved.Reason = "the manifest has no resourceVersion to merge from"
+		return nil, unresolved
+	}
+	base, err := getAtResourceVersion(ctx, resourceClient, submitted.GetName(), unresolved.ResourceVersion)
+	if err != nil {
+		logrus.WithError(err).WithField("resourceVersion", unresolved.ResourceVersion).Debug("Failed to read the base of a conflicting update")
+		unresolved.Reason = "the submitted resourceVersion is no longer available to merge from"
+		return nil, unresolved
+	}
+	mine, err := normalizeJSON(submitted.Object)
+	if err != nil {
+		return nil, err
+	}
+	baseObject, err := normalizeJSON(base.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *unstructured.Unstructured
+	err = retryOnConflict(ctx, "resolve update "+unresolved.Kind+"/"+unresolved.Name, func() error {
+		current, err := resourceClient.Get(ctx, submitted.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		theirs, err := normalizeJSON(current.Object)
+		if err != nil {
+			return err
+		}
+		merged, conflicts := mergeThreeWay(baseObject, mine, theirs)
+		if len(conflicts) > 0 {
+			unresolved.Paths = conflicts
+			return unresolved
+		}
+		obj := &unstructured.Unstructured{Object: merged}
+		obj.SetResourceVersion(current.GetResourceVersion())
+		updated, err = resourceClient.Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithFields(logrus.Fields{"kind": unresolved.Kind, "name": unresolved.Name}).Debug("Resolved update conflict by merging")
+	return updated, nil
+}
+
+// getAtResourceVersion reads an object as it was at resourceVersion. The API server serves exact versions
+// for lists only, and only until etcd compacts them.
+func getAtResourceVersion(ctx context.Context, resourceClient dynamic.ResourceInterface, name, resourceVersion string) (*unstructured.Unstructured, error) {
+	list, err := resourceClient.List(ctx, metav1.ListOptions{
+		FieldSelector:        fields.OneTermEqualSelector("metadata.name", name).String(),
+		ResourceVersion:      resourceVersion,
+		ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if list.Items[i].GetName() == name {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s did not exist at resourceVersion %s", name, resourceVersion)
+}
+
+// normalizeJSON round-trips obj through JSON so numbers compare equal however they were decoded
+func normalizeJSON(obj map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// mergeThreeWay applies the changes from base to mine onto theirs and returns the paths of the fields
+// both sides changed differently. Maps are merged key by key; lists and scalars are replaced whole.
+func mergeThreeWay(base, mine, theirs map[string]any) (map[string]any, []string) {
+	var conflicts []string
+	merged, _ := mergeValue("", base, true, mine, true, theirs, true, &conflicts)
+	sort.Strings(conflicts)
+	result, _ := merged.(map[string]any)
+	return result, conflicts
+}
+
+func mergeValue(path string, base any, baseOK bool, mine any, mineOK bool, theirs any, theirsOK bool, conflicts *[]string) (any, bool) {
+	equal := func(a any, aOK bool, b any, bOK bool) bool {
+		return aOK == bOK && (!aOK || reflect.DeepEqual(a, b))
+	}
+	switch {
+	case equal(mine, mineOK, base, baseOK):
+		return theirs, theirsOK
+	case equal(theirs, theirsOK, base, baseOK), equal(mine, mineOK, theirs, theirsOK):
+		return mine, mineOK
+	}
+
+	baseMap, baseIsMap := base.(map[string]any)
+	mineMap, mineIsMap := mine.(map[string]any)
+	theirsMap, theirsIsMap := theirs.(map[string]any)
+	if !mineIsMap || !theirsIsMap {
+		*conflicts = append(*conflicts, path)
+		return theirs, theirsOK
+	}
+	if !baseIsMap {
+		baseMap = map[string]any{}
+	}
+
+	keys := map[string]bool{}
+	for _, m := range []map[string]any{baseMap, mineMap, theirsMap} {
+		for key := range m {
+			keys[key] = true
+		}
+	}
+	merged := make(map[string]any, len(keys))
+	for key := range keys {
+		b, bOK := baseMap[key]
+		m, mOK := mineMap[key]
+		t, tOK := theirsMap[key]
+		if value, ok := mergeValue(joinDriftPath(path, key), b, bOK, m, mOK, t, tOK, conflicts); ok {
+			merged[key] = value
+		}
+	}
+	return merged, true
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestUpdateResourceConflicts(t *testing.T) {
+	useFastRetries(t, 3)
+
+	configMap := func(resourceVersion string, data map[string]any, labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{"data": data}}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("shop")
+		obj.SetName("settings")
+		obj.SetResourceVersion(resourceVersion)
+		obj.SetLabels(labels)
+		return obj
+	}
+	// The manifest was edited from version 3; since then someone changed size and added a label
+	base := configMap("3", map[string]any{"mode": "fast", "size": "1"}, map[string]string{"team": "a"})
+	current := configMap("7", map[string]any{"mode": "fast", "size": "2"}, map[string]string{"team": "a", "owner": "ops"})
+
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	newClient := func(baseErr error, updates *int) *Client {
+		dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{configMaps: "ConfigMapList"}, current.DeepCopy())
+		// Updates must carry the current resourceVersion, as on a real API server
+		dynamicClient.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			*updates++
+			obj := action.(k8stesting.UpdateAction).GetObject().(*unstructured.Unstructured)
+			if obj.GetResourceVersion() != current.GetResourceVersion() {
+				return true, nil, apierrors.NewConflict(configMaps.GroupResource(), obj.GetName(), errors.New("the object has been modified"))
+			}
+			return false, nil, nil
+		})
+		// Lists at an exact resourceVersion serve the base
+		dynamicClient.PrependReactor("list", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+			if baseErr != nil {
+				return true, nil, baseErr
+			}
+			return true, &unstructured.UnstructuredList{Items: []unstructured.Unstructured{*base.DeepCopy()}}, nil
+		})
+		return &Client{
+			dynamicClient: dynamicClient,
+			gvrCache:      map[string]schema.GroupVersionResource{"configmap": configMaps},
+			cacheExpiry:   time.Now().Add(time.Hour),
+		}
+	}
+	manifest := func(size string) string {
+		return `{"kind":"ConfigMap","metadata":{"name":"settings","namespace":"shop","resourceVersion":"3","labels":{"team":"a"}},"data":{"mode":"safe","size":"` + size + `"}}`
+	}
+
+	// Without autoResolveConflict the conflict is returned as is
+	var updates int
+	_, err := newClient(nil, &updates).UpdateResource(context.Background(), "ConfigMap", "settings", "shop", manifest("1"), false)
+	if !apierrors.IsConflict(err) || updates != 1 {
+		t.Fatalf("expected the conflict after one update, got %v after %d", err, updates)
+	}
+
+	// Non-overlapping changes are merged onto the current object
+	updates = 0
+	result, err := newClient(nil, &updates).UpdateResource(context.Background(), "ConfigMap", "settings", "shop", manifest("1"), true)
+	if err != nil {
+		t.Fatalf("UpdateResource() error = %v", err)
+	}
+	merged := &unstructured.Unstructured{Object: result}
+	mode, _, _ := unstructured.NestedString(result, "data", "mode")
+	size, _, _ := unstructured.NestedString(result, "data", "size")
+	if mode != "safe" || size != "2" || merged.GetLabels()["owner"] != "ops" || updates != 2 {
+		t.Fatalf("expected mode from the manifest and size and owner from the server, got %v after %d updates", result, updates)
+	}
+
+	// A field changed on both sides is left for manual resolution
+	updates = 0
+	_, err = newClient(nil, &updates).UpdateResource(context.Background(), "ConfigMap", "settings", "shop", manifest("5"), true)
+	var conflict *UpdateConflictError
+	if !errors.As(err, &conflict) || !apierrors.IsConflict(err) {
+		t.Fatalf("expected an unresolved conflict, got %v", err)
+	}
+	if len(conflict.Paths) != 1 || conflict.Paths[0] != "data.size" || updates != 1 {
+		t.Fatalf("expected data.size to conflict without a second update, got %v after %d updates", conflict.Paths, updates)
+	}
+
+	// A base compacted away cannot be merged from
+	gone := apierrors.NewResourceExpired("too old resource version: 3 (7)")
+	_, err = newClient(gone, &updates).UpdateResource(context.Background(), "ConfigMap", "settings", "shop", manifest("1"), true)
+	if !errors.As(err, &conflict) || conflict.Reason == "" {
+		t.Fatalf("expected a conflict explaining the missing base, got %v", err)
+	}
+}
+
+func TestMergeThreeWay(t *testing.T) {
+	base := map[string]any{"spec": map[string]any{"replicas": 1.0, "paused": false, "ports": []any{80.0}}}
+	mine := map[string]any{"spec": map[string]any{"replicas": 3.0, "ports": []any{80.0}}}
+	theirs := map[string]any{"spec": map[string]any{"replicas": 1.0, "paused": false, "ports": []any{80.0, 443.0}}}
+
+	merged, conflicts := mergeThreeWay(base, mine, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %v", conflicts)
+	}
+	spec := merged["spec"].(map[string]any)
+	if spec["replicas"] != 3.0 || len(spec["ports"].([]any)) != 2 {
+		t.Fatalf("expected replicas from mine and ports from theirs, got %v", spec)
+	}
+	if _, ok := spec["paused"]; ok {
+		t.Fatalf("expected the field removed in mine to be removed, got %v", spec)
+	}
+
+	// Removing a field the other side changed conflicts
+	theirs["spec"].(map[string]any)["paused"] = true
+	if _, conflicts := mergeThreeWay(base, mine, theirs); len(conflicts) != 1 || conflicts[0] != "spec.paused" {
+		t.Fatalf("expected spec.paused to conflict, got %v", conflicts)
+	}
+}
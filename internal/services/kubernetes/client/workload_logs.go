@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/constants"
+)
+
+// WorkloadContainerLogs holds the tail of one container's log
+type WorkloadContainerLogs struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Logs      string `json:"logs"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SkippedPod is a pod of the workload whose logs were not fetched
+type SkippedPod struct {
+	Pod    string `json:"pod"`
+	Phase  string `json:"phase,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// WorkloadLogs collects the recent logs of every running pod of a workload
+type WorkloadLogs struct {
+	Kind          string                  `json:"kind"`
+	Name          string                  `json:"name"`
+	Namespace     string                  `json:"namespace"`
+	Selector      string                  `json:"selector"`
+	TailLines     int64                   `json:"tailLines"`
+	PodsMatched   int                     `json:"podsMatched"`
+	PodsTruncated bool                    `json:"podsTruncated,omitempty"`
+	Containers    []WorkloadContainerLogs `json:"containers"`
+	Skipped       []SkippedPod            `json:"skipped,omitempty"`
+}
+
+// GetWorkloadLogs fetches the last tailLines of logs from each running pod of a Deployment, StatefulSet
+// or DaemonSet. Pods are found through the workload's selector; with container empty every container of
+// a pod is read. At most MaxWorkloadLogPods pods are read and each container log is cut to a share of
+// MaxLogCharacters so the combined response stays bounded.
+func (c *Client) GetWorkloadLogs(ctx context.Context, kind, name, namespace, container string, tailLines int64) (*WorkloadLogs, error) {
+	logrus.WithFields(logrus.Fields{"kind": kind, "name": name, "ns": namespace, "container": container, "tail": tailLines}).Debug("GetWorkloadLogs called")
+
+	if name == "" || namespace == "" {
+		return nil, fmt.Errorf("name and namespace are required")
+	}
+	kind, selector, err := c.workloadSelector(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for %s %s/%s: %w", kind, namespace, name, err)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+
+	result := &WorkloadLogs{
+		Kind:        kind,
+		Name:        name,
+		Namespace:   namespace,
+		Selector:    selector,
+		TailLines:   tailLines,
+		PodsMatched: len(pods.Items),
+		Containers:  []WorkloadContainerLogs{},
+	}
+
+	var running []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			result.Skipped = append(result.Skipped, SkippedPod{Pod: pod.Name, Phase: string(pod.Status.Phase), Reason: "pod is not running"})
+			continue
+		}
+		if container != "" && !podHasContainer(&pod, container) {
+			result.Skipped = append(result.Skipped, SkippedPod{Pod: pod.Name, Phase: string(pod.Status.Phase), Reason: fmt.Sprintf("no container named %q", container)})
+			continue
+		}
+		running = append(running, pod)
+	}
+	if len(running) > constants.MaxWorkloadLogPods {
+		for _, pod := range running[constants.MaxWorkloadLogPods:] {
+			result.Skipped = append(result.Skipped, SkippedPod{Pod: pod.Name, Phase: string(pod.Status.Phase), Reason: "pod limit reached"})
+		}
+		running = running[:constants.MaxWorkloadLogPods]
+		result.PodsTruncated = true
+	}
+
+	type target struct{ pod, container string }
+	var targets []target
+	for _, pod := range running {
+		if container != "" {
+			targets = append(targets, target{pod.Name, container})
+			continue
+		}
+		for _, ctr := range pod.Spec.Containers {
+			targets = append(targets, target{pod.Name, ctr.Name})
+		}
+	}
+	if len(targets) == 0 {
+		return result, nil
+	}
+
+	charBudget := constants.MaxLogCharacters / len(targets)
+	for _, t := range targets {
+		// Stop instead of recording the cancellation against every remaining container
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		entry := WorkloadContainerLogs{Pod: t.pod, Container: t.container}
+		logs, err := c.GetContainerLog(ctx, t.pod, namespace, t.container, tailLines)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Logs, entry.Truncated = tailChars(logs, charBudget)
+		}
+		result.Containers = append(result.Containers, entry)
+	}
+
+	logrus.WithFields(logrus.Fields{"pods": len(running), "containers": len(result.Containers), "skipped": len(result.Skipped)}).Debug("GetWorkloadLogs succeeded")
+	return result, nil
+}
+
+// workloadSelector returns the canonical kind and the pod label selector of a workload
+func (c *Client) workloadSelector(ctx context.Context, kind, name, namespace string) (string, string, error) {
+	apps := c.clientset.AppsV1()
+	var labelSelector *metav1.LabelSelector
+	switch strings.ToLower(kind) {
+	case "deployment", "deploy":
+		deployment, err := apps.Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get Deployment %s/%s: %w", namespace, name, err)
+		}
+		kind, labelSelector = "Deployment", deployment.Spec.Selector
+	case "statefulset", "sts":
+		statefulSet, err := apps.StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get StatefulSet %s/%s: %w", namespace, name, err)
+		}
+		kind, labelSelector = "StatefulSet", statefulSet.Spec.Selector
+	case "daemonset", "ds":
+		daemonSet, err := apps.DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get DaemonSet %s/%s: %w", namespace, name, err)
+		}
+		kind, labelSelector = "DaemonSet", daemonSet.Spec.Selector
+	default:
+		return "", "", fmt.Errorf("unsupported kind %q: must be Deployment, StatefulSet or DaemonSet", kind)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid selector on %s %s/%s: %w", kind, namespace, name, err)
+	}
+	// An empty selector would match every pod in the namespace
+	if selector.Empty() {
+		return "", "", fmt.Errorf("%s %s/%s has an empty selector", kind, namespace, name)
+	}
+	return kind, selector.String(), nil
+}
+
+func podHasContainer(pod *corev1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// tailChars keeps the last limit characters of s, starting at a line boundary when one is available
+func tailChars(s string, limit int) (string, bool) {
+	if limit <= 0 || len(s) <= limit {
+		return s, false
+	}
+	s = s[len(s)-limit:]
+	if i := strings.IndexByte(s, '\n'); i >= 0 && i < len(s)-1 {
+		s = s[i+1:]
+	}
+	return s, true
+}
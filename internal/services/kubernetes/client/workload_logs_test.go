@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetWorkloadLogs(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, labels map[string]string, containers ...string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		for _, container := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: container})
+		}
+		return p
+	}
+	web := map[string]string{"app": "web"}
+
+	c := &Client{clientset: fake.NewClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: web}},
+		},
+		pod("web-b", corev1.PodRunning, web, "app", "proxy"),
+		pod("web-a", corev1.PodRunning, web, "app", "proxy"),
+		pod("web-c", corev1.PodPending, web, "app", "proxy"),
+		pod("other", corev1.PodRunning, map[string]string{"app": "other"}, "app"),
+	)}
+
+	logs, err := c.GetWorkloadLogs(context.Background(), "deployment", "web", "default", "", 20)
+	if err != nil {
+		t.Fatalf("GetWorkloadLogs() error = %v", err)
+	}
+	if logs.Kind != "Deployment" || logs.Selector != "app=web" || logs.PodsMatched != 3 {
+		t.Fatalf("unexpected header: %+v", logs)
+	}
+	if len(logs.Containers) != 4 || logs.Containers[0].Pod != "web-a" || logs.Containers[1].Container != "proxy" || logs.Containers[0].Logs == "" {
+		t.Fatalf("expected every container of the running pods in name order, got %+v", logs.Containers)
+	}
+	if len(logs.Skipped) != 1 || logs.Skipped[0].Pod != "web-c" || logs.Skipped[0].Phase != "Pending" {
+		t.Fatalf("expected the pending pod to be skipped, got %+v", logs.Skipped)
+	}
+
+	logs, err = c.GetWorkloadLogs(context.Background(), "Deployment", "web", "default", "proxy", 20)
+	if err != nil {
+		t.Fatalf("GetWorkloadLogs() error = %v", err)
+	}
+	if len(logs.Containers) != 2 || logs.Containers[0].Container != "proxy" || logs.Containers[1].Container != "proxy" {
+		t.Fatalf("expected only the proxy container, got %+v", logs.Containers)
+	}
+
+	if _, err := c.GetWorkloadLogs(context.Background(), "Pod", "web-a", "default", "", 20); err == nil {
+		t.Fatal("expected an error for an unsupported kind")
+	}
+}
+
+func TestTailChars(t *testing.T) {
+	got, truncated := tailChars("line one\nline two\nline three\n", 16)
+	if !truncated || got != "line three\n" {
+		t.Fatalf("tailChars = %q, %v", got, truncated)
+	}
+	if got, truncated := tailChars("short", 16); truncated || got != "short" {
+		t.Fatalf("tailChars = %q, %v", got, truncated)
+	}
+	if got, _ := tailChars(strings.Repeat("x", 20), 5); got != "xxxxx" {
+		t.Fatalf("expected a hard cut without line breaks, got %q", got)
+	}
+}
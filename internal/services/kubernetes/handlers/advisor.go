@@ -91,6 +91,15 @@ func NewToolAdvisor() *ToolAdvisor {
 				Alternatives:  []string{"kubernetes_get_events"},
 				Prerequisites: []string{},
 			},
+			"kubernetes_events_summary": {
+				Name:          "kubernetes_events_summary",
+				Category:      "summary",
+				DataSize:      "small",
+				UseCase:       "troubleshooting",
+				CommonParams:  []string{"namespace", "type", "sinceMinutes", "limit"},
+				Alternatives:  []string{"kubernetes_get_recent_events", "kubernetes_get_events"},
+				Prerequisites: []string{},
+			},
 			"kubernetes_get_pod_logs": {
 				Name:          "kubernetes_get_pod_logs",
 				Category:      "detail",
@@ -262,8 +271,10 @@ func (advisor *ToolAdvisor) recommendForEventAnalysis(params map[string]interfac
 	tools := []string{"kubernetes_get_recent_events -limit=30"}
 
 	if namespace, ok := params["namespace"].(string); ok && namespace != "" {
+		tools = append(tools, fmt.Sprintf("kubernetes_events_summary -namespace='%s' -type='Warning'", namespace))
 		tools = append(tools, fmt.Sprintf("kubernetes_get_events -namespace='%s' -limit=50", namespace))
 	} else {
+		tools = append(tools, "kubernetes_events_summary -type='Warning'")
 		tools = append(tools, "kubernetes_get_events -limit=50")
 	}
 
@@ -386,8 +386,6 @@ var CacheTTLByTool = map[string]time.Duration{
 	"kubernetes_list_resources_summary": 2 * time.Minute,  // Resource list cache shorter
 	"kubernetes_get_resource_summary":   3 * time.Minute,  // Single resource slightly longer
 	"kubernetes_get_recent_events":      1 * time.Minute,  // Event cache shortest
-	"kubernetes_get_api_versions":       30 * time.Minute, // API version information stable
-	"kubernetes_get_api_resources":      15 * time.Minute, // API resources stable
 	"kubernetes_check_permissions":      10 * time.Minute, // Permission check moderate
 }
 
@@ -405,8 +403,6 @@ func IsToolCacheable(toolName string) bool {
 	cacheableTools := map[string]bool{
 		"kubernetes_list_resources_summary": true,
 		"kubernetes_get_resource_summary":   true,
-		"kubernetes_get_api_versions":       true,
-		"kubernetes_get_api_resources":      true,
 		"kubernetes_check_permissions":      true,
 		"kubernetes_get_recent_events":      true, // Short-term cache
 	}
@@ -422,19 +418,28 @@ func CacheParamsFilter(toolName string, params map[string]interface{}) map[strin
 	switch toolName {
 	case "kubernetes_list_resources_summary", "kubernetes_list_resources", "kubernetes_list_resources_full":
 		filtered["kind"] = params["kind"]
+		filtered["apiVersion"] = params["apiVersion"]
 		filtered["namespace"] = params["namespace"]
 		filtered["labelSelector"] = params["labelSelector"]
 		filtered["fieldSelector"] = params["fieldSelector"]
+		filtered["includeContainerStatuses"] = params["includeContainerStatuses"]
+		filtered["fields"] = params["fields"]
+		filtered["dropFields"] = params["dropFields"]
+		filtered["outputFormat"] = params["outputFormat"]
 		// limit parameter not included in cache key because different limits but same data
 
 	case "kubernetes_get_resource_summary", "kubernetes_get_resource":
 		filtered["kind"] = params["kind"]
+		filtered["apiVersion"] = params["apiVersion"]
 		filtered["name"] = params["name"]
 		filtered["namespace"] = params["namespace"]
+		filtered["includeContainerStatuses"] = params["includeContainerStatuses"]
 
 	case "kubernetes_get_recent_events", "kubernetes_get_events", "kubernetes_get_events_detail":
 		filtered["namespace"] = params["namespace"]
 		filtered["fieldSelector"] = params["fieldSelector"]
+		filtered["sinceMinutes"] = params["sinceMinutes"]
+		filtered["sinceTime"] = params["sinceTime"]
 		// Parameter includeNormalEvents affects result, so included
 
 	case "kubernetes_get_pod_logs":
@@ -6,12 +6,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/util/jsonpath"
 
@@ -30,8 +34,22 @@ var (
 	ErrJSONPathExecution    = errors.New("jsonpath execution error")
 	ErrInvalidManifest      = errors.New("invalid manifest format")
 	ErrCommandExecutionFail = errors.New("command execution failed")
+	ErrInvalidRegex         = errors.New("invalid regex pattern")
 )
 
+// validateJSONPathExpression parses expr without executing it so that malformed
+// expressions are reported before any API call is made.
+func validateJSONPathExpression(expr string) error {
+	normalized := normalizeJSONPathExpression(expr)
+	if normalized == "" {
+		return fmt.Errorf("%w: expression is empty", ErrInvalidJSONPath)
+	}
+	if err := jsonpath.New("mcp-jsonpath").Parse(normalized); err != nil {
+		return fmt.Errorf("%w '%s': %v", ErrInvalidJSONPath, expr, err)
+	}
+	return nil
+}
+
 func applyJSONPath(input any, expr string) (any, error) {
 	jp := jsonpath.New("mcp-jsonpath")
 	jp.AllowMissingKeys(true)
@@ -61,6 +79,69 @@ func applyJSONPath(input any, expr string) (any, error) {
 	return final, nil
 }
 
+// jsonPathColumnName derives a table header from a JSONPath expression,
+// e.g. `{.metadata.name}` becomes `metadata.name`.
+func jsonPathColumnName(expr string) string {
+	name := strings.TrimSpace(expr)
+	name = strings.TrimPrefix(name, "{")
+	name = strings.TrimSuffix(name, "}")
+	name = strings.TrimPrefix(strings.TrimSpace(name), ".")
+	if name == "" {
+		return expr
+	}
+	return name
+}
+
+// buildJSONPathTable evaluates each expression against every resource and returns
+// the column headers, the cell values, and a tab-separated rendering with a header row.
+// Missing fields and evaluation failures produce empty cells so every resource keeps its row.
+func buildJSONPathTable(resources []map[string]any, expressions []string) ([]string, [][]string, string) {
+	columns := make([]string, len(expressions))
+	for i, expr := range expressions {
+		columns[i] = jsonPathColumnName(expr)
+	}
+
+	cellReplacer := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	rows := make([][]string, 0, len(resources))
+	for _, resource := range resources {
+		row := make([]string, len(expressions))
+		for i, expr := range expressions {
+			value, err := applyJSONPath(resource, expr)
+			if err != nil {
+				logrus.WithError(err).WithField("expression", expr).Debug("JSONPath expression failed, leaving cell empty")
+				continue
+			}
+			if lines, ok := value.([]string); ok {
+				row[i] = cellReplacer.Replace(strings.Join(lines, ","))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	var table strings.Builder
+	table.WriteString(strings.Join(columns, "\t"))
+	for _, row := range rows {
+		table.WriteString("\n")
+		table.WriteString(strings.Join(row, "\t"))
+	}
+
+	return columns, rows, table.String()
+}
+
+// jsonPathRowObjects turns the jsonpaths table into one object per resource keyed by column name
+func jsonPathRowObjects(resources []map[string]any, expressions []string) []map[string]any {
+	columns, rows, _ := buildJSONPathTable(resources, expressions)
+	objects := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		object := make(map[string]any, len(columns))
+		for j, column := range columns {
+			object[column] = row[j]
+		}
+		objects[i] = object
+	}
+	return objects
+}
+
 func getRequestArguments(request mcp.CallToolRequest) map[string]any {
 	args := request.GetArguments()
 	if args == nil {
@@ -187,14 +268,14 @@ func requireJSONObjectParam(request mcp.CallToolRequest, param string) (map[stri
 	case map[string]interface{}:
 		return typed, nil
 	case string:
-		var result map[string]any
 		if strings.TrimSpace(typed) == "" {
 			return nil, fmt.Errorf("%w: %s", ErrMissingRequiredParam, param)
 		}
-		if err := json.Unmarshal([]byte(typed), &result); err != nil {
-			return nil, fmt.Errorf("failed to parse %s JSON object: %w", param, err)
+		format, err := getInputFormatParam(request)
+		if err != nil {
+			return nil, err
 		}
-		return result, nil
+		return decodeObjectText(param, typed, format)
 	default:
 		return nil, fmt.Errorf("%s must be a JSON object", param)
 	}
@@ -213,11 +294,12 @@ func getOptionalJSONObjectParam(request mcp.CallToolRequest, param string) (map[
 		if strings.TrimSpace(typed) == "" {
 			return nil, false, nil
 		}
-		var result map[string]any
-		if err := json.Unmarshal([]byte(typed), &result); err != nil {
-			return nil, true, fmt.Errorf("failed to parse %s JSON object: %w", param, err)
+		format, err := getInputFormatParam(request)
+		if err != nil {
+			return nil, true, err
 		}
-		return result, true, nil
+		result, err := decodeObjectText(param, typed, format)
+		return result, true, err
 	default:
 		return nil, true, fmt.Errorf("%s must be a JSON object", param)
 	}
@@ -312,6 +394,14 @@ func getOptionalSearchKinds(request mcp.CallToolRequest) ([]string, error) {
 		return []string{kind}, nil
 	}
 
+	kinds, err := getOptionalStringArrayParam(request, "kinds")
+	if err != nil {
+		return nil, err
+	}
+	if len(kinds) > 0 {
+		return uniqueStrings(kinds), nil
+	}
+
 	resourceTypes, err := getOptionalStringArrayParam(request, "resourceTypes")
 	if err != nil {
 		return nil, err
@@ -334,6 +424,21 @@ func getOptionalSearchKinds(request mcp.CallToolRequest) ([]string, error) {
 	}, nil
 }
 
+// uniqueStrings returns the non-empty values in their original order without duplicates
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
+
 // Helper function to marshal JSON response using pooled encoder
 func marshalJSONResponse(data any) (*mcp.CallToolResult, error) {
 	jsonResponse, err := optimize.GlobalJSONPool.MarshalToBytes(data)
@@ -354,6 +459,24 @@ func createErrorResponse(message string) *mcp.CallToolResult {
 	return mcp.NewToolResultError(message)
 }
 
+// ambiguousKindResponse turns an ambiguous kind lookup into an error result listing the apiVersions the
+// caller can pin, instead of a bare error string. It reports false for any other error.
+func ambiguousKindResponse(err error) (*mcp.CallToolResult, bool) {
+	var ambiguous *k8sclient.AmbiguousKindError
+	if !errors.As(err, &ambiguous) {
+		return nil, false
+	}
+	body, marshalErr := json.Marshal(map[string]any{
+		"error":                ambiguous.Error(),
+		"kind":                 ambiguous.Kind,
+		"candidateApiVersions": ambiguous.APIVersions,
+	})
+	if marshalErr != nil {
+		return createErrorResponse(ambiguous.Error()), true
+	}
+	return createErrorResponse(string(body)), true
+}
+
 func getBoolParam(request mcp.CallToolRequest, param string, defaultValue bool) bool {
 	if value, ok := getRequestArguments(request)[param]; ok {
 		switch typed := value.(type) {
@@ -371,34 +494,68 @@ func getBoolParam(request mcp.CallToolRequest, param string, defaultValue bool)
 	return defaultValue
 }
 
+// parseInt64Arg converts a numeric argument sent either as a JSON number or as a numeric string
+func parseInt64Arg(value any) (int64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return int64(typed), true
+	case float32:
+		return int64(typed), true
+	case int:
+		return int64(typed), true
+	case int32:
+		return int64(typed), true
+	case int64:
+		return typed, true
+	case json.Number:
+		if parsed, err := typed.Int64(); err == nil {
+			return parsed, true
+		}
+		if parsed, err := typed.Float64(); err == nil {
+			return int64(parsed), true
+		}
+	case string:
+		trimmed := strings.TrimSpace(typed)
+		if parsed, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return parsed, true
+		}
+		if parsed, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return int64(parsed), true
+		}
+	}
+	return 0, false
+}
+
 func getInt64Param(request mcp.CallToolRequest, param string, defaultValue int64) int64 {
 	if value, ok := getRequestArguments(request)[param]; ok {
-		switch typed := value.(type) {
-		case float64:
-			return int64(typed)
-		case int:
-			return int64(typed)
-		case int64:
-			return typed
+		if parsed, ok := parseInt64Arg(value); ok {
+			return parsed
 		}
+		logrus.WithFields(logrus.Fields{"param": param, "value": value}).Warn("Ignoring non-numeric parameter, using default")
 	}
 	return defaultValue
 }
 
 func getInt32Param(request mcp.CallToolRequest, param string, defaultValue int32) int32 {
-	if value, ok := getRequestArguments(request)[param]; ok {
-		switch typed := value.(type) {
-		case float64:
-			return int32(typed)
-		case int:
-			return int32(typed)
-		case int32:
-			return typed
-		case int64:
-			return int32(typed)
-		}
+	return int32(getInt64Param(request, param, int64(defaultValue)))
+}
+
+// getLimitParam reads the "limit" argument shared by the list tools. Non-positive or missing
+// values fall back to defaultValue, values above maxValue are clamped to it with a warning, and
+// limits above warnAbove (when positive) log a context overflow warning.
+func getLimitParam(request mcp.CallToolRequest, toolName string, defaultValue, maxValue, warnAbove int64) int64 {
+	limit := getInt64Param(request, "limit", defaultValue)
+	if limit <= 0 {
+		limit = defaultValue
 	}
-	return defaultValue
+	if limit > maxValue {
+		logrus.WithFields(logrus.Fields{"tool": toolName, "requested": limit, "max": maxValue}).Warn("Limit too high, resetting to safe maximum")
+		limit = maxValue
+	}
+	if warnAbove > 0 && limit > warnAbove {
+		logrus.WithFields(logrus.Fields{"tool": toolName, "limit": limit}).Warn("Large limit may cause context overflow, consider using summary tools or pagination")
+	}
+	return limit
 }
 
 // getNestedString extracts nested string from map safely
@@ -440,11 +597,32 @@ func HandleDescribeResource() func(ctx context.Context, request mcp.CallToolRequ
 			return nil, err
 		}
 		namespace := getOptionalStringParam(request, "namespace")
+		apiVersion := getOptionalStringParam(request, "apiVersion")
+		outputFormat := strings.ToLower(getOptionalStringParam(request, "outputFormat"))
 		debug := getOptionalStringParam(request, "debug")
-		logrus.WithFields(logrus.Fields{"tool": "describe_resource", "kind": kind, "name": name, "ns": namespace, "debug": debug}).Debug("Handler invoked")
+		logrus.WithFields(logrus.Fields{"tool": "describe_resource", "kind": kind, "name": name, "ns": namespace, "apiVersion": apiVersion, "outputFormat": outputFormat, "debug": debug}).Debug("Handler invoked")
 
-		result, err := c.GetResource(ctx, kind, name, namespace)
+		switch outputFormat {
+		case "", describeOutputText:
+		case describeOutputStructured:
+			description, err := c.DescribeResourceStructured(ctx, kind, apiVersion, name, namespace)
+			if err != nil {
+				if ambiguous, ok := ambiguousKindResponse(err); ok {
+					return ambiguous, nil
+				}
+				return nil, err
+			}
+			logrus.Debug("describe_resource succeeded")
+			return marshalJSONResponse(description)
+		default:
+			return nil, fmt.Errorf("unsupported outputFormat %q: expected %q or %q", outputFormat, describeOutputText, describeOutputStructured)
+		}
+
+		result, err := c.GetResourceForAPIVersion(ctx, kind, apiVersion, name, namespace)
 		if err != nil {
+			if ambiguous, ok := ambiguousKindResponse(err); ok {
+				return ambiguous, nil
+			}
 			return nil, err
 		}
 		logrus.Debug("describe_resource succeeded")
@@ -452,6 +630,317 @@ func HandleDescribeResource() func(ctx context.Context, request mcp.CallToolRequ
 	}
 }
 
+// HandleGetResourceYAMLHistory handles last-applied configuration and managedFields ownership requests.
+func HandleGetResourceYAMLHistory() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		kind, err := requireStringParam(request, "kind")
+		if err != nil {
+			return nil, err
+		}
+		name, err := requireStringParam(request, "name")
+		if err != nil {
+			return nil, err
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		logrus.WithFields(logrus.Fields{"tool": "get_resource_yaml_history", "kind": kind, "name": name, "ns": namespace}).Debug("Handler invoked")
+
+		history, err := c.GetResourceApplyHistory(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debug("get_resource_yaml_history succeeded")
+		return marshalJSONResponse(history)
+	}
+}
+
+// HandleResolveServiceEndpoints handles Service endpoint resolution requests.
+func HandleResolveServiceEndpoints() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		name, err := requireStringParam(request, "name")
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithFields(logrus.Fields{"tool": "resolve_service_endpoints", "name": name, "ns": namespace}).Debug("Handler invoked")
+
+		result, err := c.ResolveServiceEndpoints(ctx, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debug("resolve_service_endpoints succeeded")
+		return marshalJSONResponse(result)
+	}
+}
+
+// HandleDescribeIngress handles Ingress reachability summary requests.
+func HandleDescribeIngress() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		name, err := requireStringParam(request, "name")
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithFields(logrus.Fields{"tool": "describe_ingress", "name": name, "ns": namespace}).Debug("Handler invoked")
+
+		result, err := c.DescribeIngress(ctx, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debug("describe_ingress succeeded")
+		return marshalJSONResponse(result)
+	}
+}
+
+// HandleGetSecretTypes handles listing Secrets grouped by type.
+func HandleGetSecretTypes() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		logrus.WithFields(logrus.Fields{"tool": "get_secret_types", "ns": namespace}).Debug("Handler invoked")
+
+		groups, err := c.GetSecretTypes(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		total := 0
+		for _, group := range groups {
+			total += group.Count
+		}
+		logrus.Debug("get_secret_types succeeded")
+		return marshalJSONResponse(map[string]any{
+			"namespace": namespace,
+			"total":     total,
+			"types":     groups,
+		})
+	}
+}
+
+// HandleInspectTLSSecret handles TLS Secret certificate inspection.
+func HandleInspectTLSSecret() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		name, err := requireStringParam(request, "name")
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		window := getInt64Param(request, "expiryWindowDays", k8sclient.DefaultCertExpiryWindowDays)
+		if window < 0 {
+			return nil, fmt.Errorf("expiryWindowDays must not be negative")
+		}
+		logrus.WithFields(logrus.Fields{"tool": "inspect_tls_secret", "name": name, "ns": namespace, "window": window}).Debug("Handler invoked")
+
+		result, err := c.InspectTLSSecret(ctx, name, namespace, int(window))
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debug("inspect_tls_secret succeeded")
+		return marshalJSONResponse(result)
+	}
+}
+
+// HandleFindConfigConsumers handles ConfigMap and Secret consumer lookups.
+func HandleFindConfigConsumers() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		kind, err := requireStringParam(request, "kind")
+		if err != nil {
+			return nil, err
+		}
+		name, err := requireStringParam(request, "name")
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithFields(logrus.Fields{"tool": "find_config_consumers", "kind": kind, "name": name, "ns": namespace}).Debug("Handler invoked")
+
+		report, err := c.FindConfigConsumers(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithField("consumers", len(report.Consumers)).Debug("find_config_consumers succeeded")
+		return marshalOptimizedResponse(report, "find_config_consumers")
+	}
+}
+
+// HandleGetImages handles container image inventory requests.
+func HandleGetImages() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		filter := getOptionalRawStringParam(request, "filter")
+		limit := getLimitParam(request, "get_images", 100, 500, 0)
+		logrus.WithFields(logrus.Fields{"tool": "get_images", "ns": namespace, "filter": filter, "limit": limit}).Debug("Handler invoked")
+
+		inventory, err := c.GetImageInventory(ctx, namespace, filter)
+		if err != nil {
+			return nil, err
+		}
+		response := map[string]interface{}{
+			"totalImages": inventory.TotalImages,
+			"images":      inventory.Images,
+		}
+		if namespace != "" {
+			response["namespace"] = namespace
+		}
+		if filter != "" {
+			response["filter"] = filter
+		}
+		if int64(len(inventory.Images)) > limit {
+			response["images"] = inventory.Images[:limit]
+			response["truncated"] = true
+		}
+		logrus.WithField("images", inventory.TotalImages).Debug("get_images succeeded")
+		return marshalOptimizedResponse(response, "get_images")
+	}
+}
+
+// HandleExportNamespace exports the objects of a namespace as multi-document YAML for re-applying.
+func HandleExportNamespace() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		kinds, err := getOptionalStringArrayParam(request, "kinds")
+		if err != nil {
+			return nil, err
+		}
+		includeSecrets := getBoolParam(request, "includeSecrets", false)
+		continueToken := getOptionalRawStringParam(request, "continueToken")
+		limit := getLimitParam(request, "export_namespace", 100, 500, 0)
+		logrus.WithFields(logrus.Fields{
+			"tool": "export_namespace", "ns": namespace, "kinds": kinds, "includeSecrets": includeSecrets, "limit": limit,
+		}).Debug("Handler invoked")
+
+		export, err := c.ExportNamespace(ctx, namespace, kinds, includeSecrets, continueToken, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		header := []string{fmt.Sprintf("namespace: %s, objects: %d, hasMore: %t", namespace, len(export.Objects), export.ContinueToken != "")}
+		if !includeSecrets {
+			header = append(header, "Secrets excluded; set includeSecrets=true to export them")
+		}
+		if len(export.Skipped) > 0 {
+			header = append(header, "skipped (recreated automatically): "+formatCounts(export.Skipped))
+		}
+		for _, kind := range sortedKeys(export.KindErrors) {
+			header = append(header, fmt.Sprintf("kind %s not exported: %s", kind, export.KindErrors[kind]))
+		}
+		if export.ContinueToken != "" {
+			header = append(header, "continueToken: "+export.ContinueToken)
+		}
+		logrus.WithFields(logrus.Fields{"objects": len(export.Objects), "continue": export.ContinueToken}).Debug("export_namespace succeeded")
+		return marshalYAMLDocuments(export.Objects, header...)
+	}
+}
+
+// HandleCompareNamespaces reports the configuration drift between two namespaces.
+func HandleCompareNamespaces() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		sourceNamespace, err := requireStringParam(request, "sourceNamespace")
+		if err != nil {
+			return nil, err
+		}
+		targetNamespace, err := requireStringParam(request, "targetNamespace")
+		if err != nil {
+			return nil, err
+		}
+		kinds, err := getOptionalStringArrayParam(request, "kinds")
+		if err != nil {
+			return nil, err
+		}
+		ignoreLabels, err := getOptionalStringArrayParam(request, "ignoreLabels")
+		if err != nil {
+			return nil, err
+		}
+		ignoreFields, err := getOptionalStringArrayParam(request, "ignoreFields")
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithFields(logrus.Fields{
+			"tool": "compare_namespaces", "source": sourceNamespace, "target": targetNamespace, "kinds": kinds,
+		}).Debug("Handler invoked")
+
+		comparison, err := c.CompareNamespaces(ctx, sourceNamespace, targetNamespace, kinds, ignoreLabels, ignoreFields)
+		if err != nil {
+			return nil, err
+		}
+
+		response := map[string]any{
+			"summary": fmt.Sprintf("%d identical, %d differing, %d only in %s, %d only in %s",
+				comparison.Identical, len(comparison.Differing),
+				len(comparison.OnlyInSource), sourceNamespace, len(comparison.OnlyInTarget), targetNamespace),
+			"comparison": comparison,
+		}
+		logrus.WithField("differing", len(comparison.Differing)).Debug("compare_namespaces succeeded")
+		return marshalOptimizedResponse(response, "compare_namespaces")
+	}
+}
+
+// formatCounts renders a count map as "key=n" pairs in key order
+func formatCounts(counts map[string]int) string {
+	parts := make([]string, 0, len(counts))
+	for _, key := range sortedKeys(counts) {
+		parts = append(parts, fmt.Sprintf("%s=%d", key, counts[key]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // HandleGetResourceUsage handles resource usage information requests (CPU/Memory).
 func HandleGetResourceUsage() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -478,6 +967,32 @@ func HandleGetResourceUsage() func(ctx context.Context, request mcp.CallToolRequ
 	}
 }
 
+// HandleGetPodResourceRecommendations handles request sizing recommendations from live usage
+func HandleGetPodResourceRecommendations() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		labelSelector := getOptionalStringParam(request, "labelSelector")
+		onlyFlagged := getBoolParam(request, "onlyFlagged", true)
+
+		logrus.WithFields(logrus.Fields{"tool": "get_pod_resource_recommendations", "ns": namespace, "labelSelector": labelSelector, "onlyFlagged": onlyFlagged}).Debug("Handler invoked")
+
+		report, err := c.GetPodResourceRecommendations(ctx, namespace, labelSelector, onlyFlagged)
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{"scored": report.ContainersScored, "returned": len(report.Containers)}).Debug("get_pod_resource_recommendations succeeded")
+		return marshalOptimizedResponse(report, "get_pod_resource_recommendations")
+	}
+}
+
 // HandleGetRecentEvents handles recent critical events retrieval with optimized output.
 func HandleGetRecentEvents() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -490,12 +1005,14 @@ func HandleGetRecentEvents() func(ctx context.Context, request mcp.CallToolReque
 		debug := getOptionalStringParam(request, "debug")
 
 		// More conservative default limit for recent events
-		limit := getInt64Param(request, "limit", 20)
-		if limit <= 0 || limit > 100 {
-			limit = 20
+		limit := getLimitParam(request, "get_recent_events", 20, 100, 0)
+
+		since, err := getEventSinceParam(request)
+		if err != nil {
+			return nil, err
 		}
 
-		logrus.WithFields(logrus.Fields{"tool": "get_recent_events", "ns": namespace, "fieldSelector": fieldSelector, "limit": limit, "debug": debug}).Debug("Handler invoked")
+		logrus.WithFields(logrus.Fields{"tool": "get_recent_events", "ns": namespace, "fieldSelector": fieldSelector, "limit": limit, "since": since, "debug": debug}).Debug("Handler invoked")
 
 		// Create field selector that focuses on important events only
 		selector := fieldSelector
@@ -505,42 +1022,33 @@ func HandleGetRecentEvents() func(ctx context.Context, request mcp.CallToolReque
 			selector = fmt.Sprintf("%s,type!=Normal", selector)
 		}
 
-		// Use paginated listing to prevent context overflow
-		resources, err := c.ListResourcesWithPagination(ctx, "Event", namespace, "", selector, "", limit)
+		sorted, err := c.ListEventsNewestFirst(ctx, namespace, selector, since)
 		if err != nil {
 			return nil, err
 		}
+		total := len(sorted.Events)
+		end := min(int(limit), total)
 
 		// Extract only essential fields from events
-		var recentEvents []map[string]interface{}
-		for _, event := range resources {
+		recentEvents := make([]map[string]interface{}, 0, end)
+		for _, event := range sorted.Events[:end] {
 			recentEvents = append(recentEvents, map[string]interface{}{
 				"type":      getNestedString(event, "type"),
 				"reason":    getNestedString(event, "reason"),
 				"message":   getNestedString(event, "message"),
-				"timestamp": getNestedString(event, "lastTimestamp"),
+				"timestamp": eventTimestamp(event),
 				"object":    fmt.Sprintf("%s/%s", getNestedString(event, "involvedObject.kind"), getNestedString(event, "involvedObject.name")),
 				"namespace": getNestedString(event, "involvedObject.namespace"),
 			})
 		}
-
-		// Get pagination info
-		paginationInfo, err := c.GetPaginationInfo(ctx, "Event", namespace, "", selector, "", limit)
-		if err != nil {
-			logrus.WithError(err).Warn("Failed to get pagination info for recent events")
-			paginationInfo = &PaginationInfo{ContinueToken: "", RemainingCount: 0, CurrentPageSize: 0, HasMore: false}
-		}
+		paginationInfo := offsetPagination(end, total, len(recentEvents))
 
 		response := map[string]interface{}{
-			"events": recentEvents,
-			"count":  len(recentEvents),
-			"pagination": map[string]interface{}{
-				"continueToken":   paginationInfo.ContinueToken,
-				"remainingCount":  paginationInfo.RemainingCount,
-				"currentPageSize": paginationInfo.CurrentPageSize,
-				"hasMore":         paginationInfo.HasMore,
-			},
+			"events":     recentEvents,
+			"count":      len(recentEvents),
+			"pagination": paginationResponse(paginationInfo, len(recentEvents)),
 		}
+		addEventScanInfo(response, sorted)
 
 		logrus.WithFields(logrus.Fields{"count": len(recentEvents), "hasMore": paginationInfo.HasMore}).Debug("get_recent_events succeeded")
 		return marshalJSONResponse(response)
@@ -558,51 +1066,161 @@ func HandleGetEvents() func(ctx context.Context, request mcp.CallToolRequest) (*
 		fieldSelector := getOptionalStringParam(request, "fieldSelector")
 		debug := getOptionalStringParam(request, "debug")
 
-		limit := getInt64Param(request, "limit", constants.DefaultLimit)
-		if limit <= 0 || limit > constants.MaxLimit {
-			if limit > constants.MaxLimit {
-				logrus.WithField("requested", limit).WithField("max", constants.MaxLimit).Warn("Event limit too high, resetting to safe maximum")
-				limit = constants.MaxLimit
-			} else {
-				limit = constants.DefaultLimit
-			}
+		limit := getLimitParam(request, "get_events", constants.DefaultLimit, constants.MaxLimit, constants.WarningLimit)
+		follow := getBoolParam(request, "follow", false)
+		since, err := getEventSinceParam(request)
+		if err != nil {
+			return nil, err
 		}
-		if limit > constants.WarningLimit {
-			logrus.WithField("limit", limit).Warn("Large event limit may cause context overflow, consider using get_recent_events for critical events only")
+		if follow && !since.IsZero() {
+			return nil, fmt.Errorf("sinceMinutes and sinceTime cannot be combined with follow")
 		}
 
-		logrus.WithFields(logrus.Fields{"tool": "get_events", "ns": namespace, "fieldSelector": fieldSelector, "limit": limit, "debug": debug}).Debug("Handler invoked")
+		logrus.WithFields(logrus.Fields{"tool": "get_events", "ns": namespace, "fieldSelector": fieldSelector, "limit": limit, "follow": follow, "since": since, "debug": debug}).Debug("Handler invoked")
 
-		// Use paginated listing to prevent context overflow
-		resources, err := c.ListResourcesWithPagination(ctx, "Event", namespace, "", fieldSelector, "", limit)
-		if err != nil {
-			return nil, err
+		if follow {
+			timeout := time.Duration(getInt64Param(request, "timeout", 0)) * time.Second
+			if timeout <= 0 {
+				timeout = constants.DefaultEventFollowTimeout
+			}
+			if timeout > constants.MaxEventFollowTimeout {
+				logrus.WithFields(logrus.Fields{"tool": "get_events", "requested": timeout, "max": constants.MaxEventFollowTimeout}).Warn("Follow timeout too high, resetting to maximum")
+				timeout = constants.MaxEventFollowTimeout
+			}
+
+			result, err := c.WatchEvents(ctx, namespace, fieldSelector, timeout, int(limit), progressEmitter(ctx, request))
+			if err != nil {
+				return nil, err
+			}
+			logrus.WithFields(logrus.Fields{"count": result.Count, "stopReason": result.StopReason}).Debug("get_events follow finished")
+			return marshalOptimizedResponse(result, "get_events")
 		}
 
-		// Get pagination info
-		paginationInfo, err := c.GetPaginationInfo(ctx, "Event", namespace, "", fieldSelector, "", limit)
+		sorted, err := c.ListEventsNewestFirst(ctx, namespace, fieldSelector, since)
 		if err != nil {
-			logrus.WithError(err).Warn("Failed to get pagination info for events")
-			paginationInfo = &PaginationInfo{ContinueToken: "", RemainingCount: 0, CurrentPageSize: 0, HasMore: false}
+			return nil, err
 		}
+		total := len(sorted.Events)
+		end := min(int(limit), total)
+		resources := sorted.Events[:end]
+		paginationInfo := offsetPagination(end, total, len(resources))
 
 		// Create response with pagination metadata
 		response := map[string]interface{}{
-			"events": resources,
-			"count":  len(resources),
-			"pagination": map[string]interface{}{
-				"continueToken":   paginationInfo.ContinueToken,
-				"remainingCount":  paginationInfo.RemainingCount,
-				"currentPageSize": paginationInfo.CurrentPageSize,
-				"hasMore":         paginationInfo.HasMore,
-			},
+			"events":     resources,
+			"count":      len(resources),
+			"pagination": paginationResponse(paginationInfo, len(resources)),
 		}
+		addEventScanInfo(response, sorted)
 
 		logrus.WithFields(logrus.Fields{"count": len(resources), "hasMore": paginationInfo.HasMore}).Debug("get_events succeeded")
 		return marshalOptimizedResponse(response, "get_events")
 	}
 }
 
+// getEventSinceParam reads the sinceMinutes and sinceTime arguments of the event tools. At most one
+// may be set; the zero time means no lower bound.
+func getEventSinceParam(request mcp.CallToolRequest) (time.Time, error) {
+	sinceMinutes := getInt64Param(request, "sinceMinutes", 0)
+	sinceTime := getOptionalStringParam(request, "sinceTime")
+	switch {
+	case sinceMinutes < 0:
+		return time.Time{}, fmt.Errorf("sinceMinutes must not be negative")
+	case sinceMinutes > 0 && sinceTime != "":
+		return time.Time{}, fmt.Errorf("sinceMinutes and sinceTime are mutually exclusive")
+	case sinceMinutes > 0:
+		return time.Now().Add(-time.Duration(sinceMinutes) * time.Minute), nil
+	case sinceTime != "":
+		since, err := time.Parse(time.RFC3339, sinceTime)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid sinceTime %q: expected RFC3339, e.g. 2024-01-02T15:04:05Z", sinceTime)
+		}
+		return since, nil
+	default:
+		return time.Time{}, nil
+	}
+}
+
+// eventTimestamp returns the most recent occurrence time of an event object, checking the fields in
+// the same order the events are sorted by
+func eventTimestamp(event map[string]interface{}) string {
+	for _, path := range []string{"series.lastObservedTime", "lastTimestamp", "eventTime", "firstTimestamp", "metadata.creationTimestamp"} {
+		if ts := getNestedString(event, path); ts != "" {
+			return ts
+		}
+	}
+	return ""
+}
+
+// addEventScanInfo reports on the response when the event listing stopped before reading every event
+func addEventScanInfo(response map[string]interface{}, sorted *k8sclient.SortedEvents) {
+	response["scannedEvents"] = sorted.ScannedEvents
+	if sorted.ScanLimited {
+		response["scanLimited"] = true
+	}
+}
+
+// progressEmitter returns a callback that streams lines to the client as progress notifications
+// when the request carries a progress token, or nil when the client did not ask for progress.
+func progressEmitter(ctx context.Context, request mcp.CallToolRequest) func(string) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+	token := request.Params.Meta.ProgressToken
+	sent := 0
+	return func(line string) {
+		sent++
+		err := srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      sent,
+			"message":       line,
+		})
+		if err != nil {
+			logrus.WithError(err).Debug("Failed to send progress notification")
+		}
+	}
+}
+
+// HandleGetEventsSummary handles event aggregation requests grouped by reason and involved object kind.
+func HandleGetEventsSummary() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		eventType := getOptionalStringParam(request, "type")
+		switch strings.ToLower(eventType) {
+		case "":
+		case "warning":
+			eventType = "Warning"
+		case "normal":
+			eventType = "Normal"
+		default:
+			return nil, fmt.Errorf("invalid type %q: must be Warning or Normal", eventType)
+		}
+		sinceMinutes := getInt64Param(request, "sinceMinutes", 0)
+		if sinceMinutes < 0 {
+			return nil, fmt.Errorf("sinceMinutes must not be negative")
+		}
+		limit := getLimitParam(request, "get_events_summary", 20, 100, 0)
+
+		logrus.WithFields(logrus.Fields{"tool": "get_events_summary", "ns": namespace, "type": eventType, "sinceMinutes": sinceMinutes, "limit": limit}).Debug("Handler invoked")
+
+		summary, err := c.SummarizeEvents(ctx, namespace, eventType, time.Duration(sinceMinutes)*time.Minute, int(limit))
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{"groups": summary.TotalGroups, "scanned": summary.ScannedEvents}).Debug("get_events_summary succeeded")
+		return marshalOptimizedResponse(summary, "get_events_summary")
+	}
+}
+
 // HandleGetResourceDetails handles detailed resource information requests.
 func HandleGetResourceDetails() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -619,14 +1237,25 @@ func HandleGetResourceDetails() func(ctx context.Context, request mcp.CallToolRe
 			return nil, err
 		}
 		namespace := getOptionalStringParam(request, "namespace")
+		apiVersion := getOptionalStringParam(request, "apiVersion")
 		debug := getOptionalStringParam(request, "debug")
-		logrus.WithFields(logrus.Fields{"tool": "get_resource_details", "kind": kind, "name": name, "ns": namespace, "debug": debug}).Debug("Handler invoked")
+		outputFormat, err := getOutputFormatParam(request)
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithFields(logrus.Fields{"tool": "get_resource_details", "kind": kind, "name": name, "ns": namespace, "apiVersion": apiVersion, "outputFormat": outputFormat, "debug": debug}).Debug("Handler invoked")
 
-		result, err := c.GetResource(ctx, kind, name, namespace)
+		result, err := c.GetResourceForAPIVersion(ctx, kind, apiVersion, name, namespace)
 		if err != nil {
+			if ambiguous, ok := ambiguousKindResponse(err); ok {
+				return ambiguous, nil
+			}
 			return nil, err
 		}
 		logrus.Debug("get_resource_details succeeded")
+		if outputFormat == OutputFormatYAML {
+			return marshalYAMLResponse(result)
+		}
 		return marshalJSONResponse(result)
 	}
 }
@@ -644,7 +1273,13 @@ func HandleContainerLogs() func(ctx context.Context, request mcp.CallToolRequest
 			return nil, err
 		}
 		container := getOptionalStringParam(request, "container")
-		logrus.WithFields(logrus.Fields{"tool": "get_pod_logs", "pod": name, "ns": namespace, "container": container}).Debug("Handler invoked")
+		allContainers := getBoolParam(request, "allContainers", false)
+		if allContainers && container != "" {
+			return nil, fmt.Errorf("container and allContainers cannot be combined")
+		}
+		logrus.WithFields(logrus.Fields{
+			"tool": "get_pod_logs", "pod": name, "ns": namespace, "container": container, "allContainers": allContainers,
+		}).Debug("Handler invoked")
 
 		tailLines := getInt64Param(request, "tailLines", constants.DefaultTailLines)
 		if tailLines < 0 || tailLines > 200 {
@@ -656,9 +1291,21 @@ func HandleContainerLogs() func(ctx context.Context, request mcp.CallToolRequest
 			}
 		}
 
-		result, err := c.GetContainerLog(ctx, name, namespace, container, tailLines)
-		if err != nil {
-			return nil, err
+		var (
+			result   string
+			podLogs  *k8sclient.PodLogs
+			fetchErr error
+		)
+		if allContainers {
+			podLogs, fetchErr = c.GetAllContainerLogs(ctx, name, namespace, tailLines)
+			if podLogs != nil {
+				result = podLogs.Logs
+			}
+		} else {
+			result, fetchErr = c.GetContainerLog(ctx, name, namespace, container, tailLines)
+		}
+		if fetchErr != nil {
+			return nil, fetchErr
 		}
 		// Smart log processing with size monitoring
 		logSize := len(result)
@@ -713,6 +1360,14 @@ func HandleContainerLogs() func(ctx context.Context, request mcp.CallToolRequest
 			},
 		}
 
+		if podLogs != nil {
+			metadata := logData["metadata"].(map[string]interface{})
+			metadata["containers"] = podLogs.Containers
+			if len(podLogs.Errors) > 0 {
+				metadata["containerErrors"] = podLogs.Errors
+			}
+		}
+
 		// Add truncation information if applied
 		if len(truncationInfo) > 0 {
 			logData["metadata"].(map[string]interface{})["truncation"] = truncationInfo
@@ -729,30 +1384,39 @@ func HandleContainerLogs() func(ctx context.Context, request mcp.CallToolRequest
 	}
 }
 
-// HandlePortForward handles port forwarding requests to a pod.
+// HandlePortForward handles port forwarding requests to a pod, or to a ready pod of a service or
+// deployment.
 func HandlePortForward() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		c, err := k8sclient.FromContext(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		podName, err := requireStringParam(request, "podName")
+		kind, err := k8sclient.NormalizePortForwardKind(getOptionalStringParam(request, "kind"))
 		if err != nil {
 			return nil, err
 		}
+		name := getOptionalStringParam(request, "name")
+		podName := getOptionalStringParam(request, "podName")
+		switch {
+		case name == "" && podName == "":
+			return nil, fmt.Errorf("missing required parameter: name")
+		case name != "" && podName != "":
+			return nil, fmt.Errorf("use either name or podName, not both")
+		case podName != "":
+			if kind != k8sclient.PortForwardKindPod {
+				return nil, fmt.Errorf("podName can only be used with kind Pod; use name instead")
+			}
+			name = podName
+		}
 		namespace, err := requireStringParam(request, "namespace")
 		if err != nil {
 			return nil, err
 		}
 
-		localPort := getInt32Param(request, "localPort", 0)
-		if localPort == 0 {
-			return nil, fmt.Errorf("missing required parameter: localPort")
-		}
-
-		podPort := getInt32Param(request, "podPort", 0)
-		if podPort == 0 {
-			return nil, fmt.Errorf("missing required parameter: podPort")
+		mappings, err := getPortMappingParams(request)
+		if err != nil {
+			return nil, err
 		}
 
 		address := getOptionalStringParam(request, "address")
@@ -760,22 +1424,161 @@ func HandlePortForward() func(ctx context.Context, request mcp.CallToolRequest)
 			address = "localhost"
 		}
 		debug := getOptionalStringParam(request, "debug")
+		healthPath := getOptionalStringParam(request, "healthPath")
+		verify := getBoolParam(request, "verify", false) || healthPath != ""
 
-		logrus.WithFields(logrus.Fields{"tool": "port_forward", "pod": podName, "ns": namespace, "localPort": localPort, "podPort": podPort, "address": address, "debug": debug}).Debug("Handler invoked")
+		logrus.WithFields(logrus.Fields{"tool": "port_forward", "kind": kind, "name": name, "ns": namespace, "ports": mappings, "address": address, "debug": debug, "verify": verify}).Debug("Handler invoked")
 
-		err = c.PortForward(ctx, podName, namespace, localPort, podPort, address)
+		target := k8sclient.PortForwardTarget{Kind: kind, Name: name, Namespace: namespace}
+		var session *k8sclient.PortForwardSession
+		verified := true
+		if verify {
+			session, verified, err = c.PortForwardAndVerify(ctx, target, mappings, address, healthPath)
+		} else {
+			session, err = c.StartPortForward(ctx, target, mappings, address)
+		}
 		if err != nil {
 			return nil, err
 		}
-		return marshalJSONResponse(map[string]any{
+		results := session.Mappings
+
+		established := 0
+		for _, result := range results {
+			if result.Established {
+				established++
+			}
+		}
+		response := map[string]any{
 			"status":    "ok",
 			"message":   "port forwarding established",
 			"address":   address,
-			"localPort": localPort,
 			"namespace": namespace,
-			"podName":   podName,
-			"podPort":   podPort,
-		})
+			"kind":      kind,
+			"name":      name,
+			"podName":   session.Pod(),
+			"ports":     results,
+		}
+		if kind != k8sclient.PortForwardKindPod {
+			response["reselectsPod"] = true
+		}
+		if len(results) == 1 {
+			response["localPort"] = results[0].LocalPort
+			response["podPort"] = results[0].PodPort
+		}
+		if established < len(results) {
+			response["status"] = "partial"
+			response["message"] = fmt.Sprintf("%d of %d port mappings established", established, len(results))
+		}
+		if verify && verified {
+			response["message"] = response["message"].(string) + " and verified"
+		}
+		if !verified {
+			response["status"] = "failed"
+			response["message"] = "port forward verification failed; the forward was stopped"
+			result, err := marshalJSONResponse(response)
+			if result != nil {
+				result.IsError = true
+			}
+			return result, err
+		}
+		return marshalJSONResponse(response)
+	}
+}
+
+// getPortMappingParams reads the port mappings of a port forward from ports, or from the single
+// localPort/podPort pair
+func getPortMappingParams(request mcp.CallToolRequest) ([]k8sclient.PortMapping, error) {
+	specs, err := getOptionalStringArrayParam(request, "ports")
+	if err != nil {
+		return nil, err
+	}
+	localPort := getInt32Param(request, "localPort", 0)
+	podPort := getInt32Param(request, "podPort", 0)
+
+	if len(specs) > 0 {
+		if localPort != 0 || podPort != 0 {
+			return nil, fmt.Errorf("use either ports or localPort/podPort, not both")
+		}
+		return k8sclient.ParsePortMappings(specs)
+	}
+
+	if localPort == 0 {
+		return nil, fmt.Errorf("missing required parameter: localPort (or ports)")
+	}
+	if podPort == 0 {
+		return nil, fmt.Errorf("missing required parameter: podPort (or ports)")
+	}
+	return []k8sclient.PortMapping{{LocalPort: localPort, PodPort: podPort}}, nil
+}
+
+// HandleValidateManifest handles read-only manifest validation.
+func HandleValidateManifest() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		manifest, err := getManifestParam(request)
+		if err != nil {
+			return nil, err
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		logrus.WithFields(logrus.Fields{"tool": "validate_manifest", "ns": namespace, "size": len(manifest)}).Debug("Handler invoked")
+
+		result, err := c.ValidateManifest(ctx, manifest, namespace)
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithField("valid", result.Valid).Debug("validate_manifest succeeded")
+		return marshalJSONResponse(result)
+	}
+}
+
+// HandleCreateResources handles creation of the objects of a multi-document manifest
+func HandleCreateResources() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		manifest, err := getManifestParam(request)
+		if err != nil {
+			return nil, err
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		atomic := getBoolParam(request, "atomic", false)
+		logrus.WithFields(logrus.Fields{"tool": "create_resources", "ns": namespace, "atomic": atomic, "size": len(manifest)}).Debug("Handler invoked")
+
+		result, err := c.CreateManifestResources(ctx, manifest, namespace, atomic)
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithFields(logrus.Fields{"created": result.Created, "failed": result.Failed}).Debug("create_resources succeeded")
+		return marshalJSONResponse(result)
+	}
+}
+
+// getManifestParam reads the manifest parameter, which clients send as YAML or JSON text or as a
+// JSON object
+func getManifestParam(request mcp.CallToolRequest) (string, error) {
+	value, err := requireArgument(request, "manifest")
+	if err != nil {
+		return "", err
+	}
+	switch typed := value.(type) {
+	case string:
+		if strings.TrimSpace(typed) == "" {
+			return "", fmt.Errorf("%w: manifest", ErrMissingRequiredParam)
+		}
+		return typed, nil
+	case map[string]any, []any:
+		encoded, err := json.Marshal(typed)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode manifest: %w", err)
+		}
+		return string(encoded), nil
+	default:
+		return "", fmt.Errorf("manifest must be YAML or JSON text")
 	}
 }
 
@@ -786,6 +1589,14 @@ func HandleCreateResource() func(ctx context.Context, request mcp.CallToolReques
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		manifest, hasManifest, err := getOptionalJSONObjectParam(request, "manifest")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+		}
+		if hasManifest {
+			return createFromManifest(ctx, c, request, manifest)
+		}
+
 		kind, err := requireStringParam(request, "kind")
 		if err != nil {
 			return nil, err
@@ -829,6 +1640,37 @@ func HandleCreateResource() func(ctx context.Context, request mcp.CallToolReques
 	}
 }
 
+// createFromManifest creates a resource from a full YAML or JSON object; kind and apiVersion, when also given, must agree with it
+func createFromManifest(ctx context.Context, c *k8sclient.Client, request mcp.CallToolRequest, manifest map[string]any) (*mcp.CallToolResult, error) {
+	for _, field := range []string{"kind", "apiVersion"} {
+		value, _ := manifest[field].(string)
+		if value == "" {
+			return nil, fmt.Errorf("%w: manifest must set %s", ErrInvalidManifest, field)
+		}
+		if given := getOptionalStringParam(request, field); given != "" && given != value {
+			return nil, fmt.Errorf("%s mismatch: argument is %q but manifest has %q", field, given, value)
+		}
+	}
+	if _, hasMetadata := request.GetArguments()["metadata"]; hasMetadata {
+		return nil, fmt.Errorf("metadata cannot be combined with manifest; set it inside the manifest")
+	}
+	if _, hasSpec := request.GetArguments()["spec"]; hasSpec {
+		return nil, fmt.Errorf("spec cannot be combined with manifest; set it inside the manifest")
+	}
+	metadata, _ := manifest["metadata"].(map[string]any)
+	if !hasCreateIdentity(metadata) {
+		return nil, fmt.Errorf("metadata.name or metadata.generateName is required for kubernetes_create_resource")
+	}
+	logrus.WithFields(logrus.Fields{"tool": "create_resource", "kind": manifest["kind"], "apiVersion": manifest["apiVersion"], "source": "manifest"}).Debug("Handler invoked")
+
+	result, err := c.CreateResourceFromManifest(ctx, manifest)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Debug("create_resource succeeded")
+	return marshalJSONResponse(result)
+}
+
 func hasCreateIdentity(metadata map[string]any) bool {
 	for _, key := range []string{"name", "generateName"} {
 		if value, ok := metadata[key].(string); ok && strings.TrimSpace(value) != "" {
@@ -849,20 +1691,39 @@ func HandleUpdateResource() func(ctx context.Context, request mcp.CallToolReques
 		if err != nil {
 			return nil, err
 		}
-		namespace := getOptionalStringParam(request, "namespace")
-		name := getOptionalStringParam(request, "name")
-		manifest, err := requireRawStringParam(request, "manifest")
+		manifestObject, err := requireJSONObjectParam(request, "manifest")
 		if err != nil {
 			return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
 		}
-		logrus.WithFields(logrus.Fields{"tool": "update_resource", "kind": kind, "name": name, "ns": namespace}).Debug("Handler invoked")
+		if manifestKind, _ := manifestObject["kind"].(string); !strings.EqualFold(manifestKind, kind) {
+			return nil, fmt.Errorf("kind mismatch: argument is %q but manifest has %q", kind, manifestKind)
+		}
+		// Name and namespace default to the manifest's own
+		metadata, _ := manifestObject["metadata"].(map[string]any)
+		name := getOptionalStringParam(request, "name")
+		if name == "" {
+			name, _ = metadata["name"].(string)
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		if namespace == "" {
+			namespace, _ = metadata["namespace"].(string)
+		}
+		manifestJSON, err := json.Marshal(manifestObject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize manifest JSON: %w", err)
+		}
+		manifest := string(manifestJSON)
+		autoResolveConflict := getBoolParam(request, "autoResolveConflict", false)
+		logrus.WithFields(logrus.Fields{
+			"tool": "update_resource", "kind": kind, "name": name, "ns": namespace, "autoResolveConflict": autoResolveConflict,
+		}).Debug("Handler invoked")
 
-		result, err := c.UpdateResource(ctx, kind, name, namespace, manifest)
+		result, err := c.UpdateResource(ctx, kind, name, namespace, manifest, autoResolveConflict)
 		if err != nil {
 			return nil, err
 		}
 		logrus.Debug("update_resource succeeded")
-		return mcp.NewToolResultText(fmt.Sprintf("%v", result)), nil
+		return marshalJSONResponse(result)
 	}
 }
 
@@ -901,6 +1762,43 @@ func HandlePatchResource() func(ctx context.Context, request mcp.CallToolRequest
 	}
 }
 
+// HandleWorkloadLogs handles log requests spanning every pod of a workload.
+func HandleWorkloadLogs() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		kind, err := requireStringParam(request, "kind")
+		if err != nil {
+			return nil, err
+		}
+		name, err := requireStringParam(request, "name")
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		container := getOptionalStringParam(request, "container")
+		tailLines := getInt64Param(request, "tailLines", constants.DefaultWorkloadLogTailLines)
+		if tailLines <= 0 {
+			tailLines = constants.DefaultWorkloadLogTailLines
+		} else if tailLines > constants.MaxLogLines {
+			tailLines = constants.MaxLogLines
+		}
+		logrus.WithFields(logrus.Fields{"tool": "get_logs_multi", "kind": kind, "name": name, "ns": namespace, "container": container, "tailLines": tailLines}).Debug("Handler invoked")
+
+		result, err := c.GetWorkloadLogs(ctx, kind, name, namespace, container, tailLines)
+		if err != nil {
+			return createErrorResponse(err.Error()), nil
+		}
+		logrus.Debug("get_logs_multi succeeded")
+		return marshalJSONResponse(result)
+	}
+}
+
 // HandleContainerExec handles command execution requests in containers.
 func HandleContainerExec() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -924,15 +1822,28 @@ func HandleContainerExec() func(ctx context.Context, request mcp.CallToolRequest
 		if err != nil {
 			return nil, err
 		}
-		logrus.WithFields(logrus.Fields{"tool": "pod_exec", "pod": name, "ns": namespace, "container": container}).Debug("Handler invoked")
+		// stdin is passed through verbatim; trailing newlines and quoting matter to most readers
+		stdin := getOptionalRawStringParam(request, "stdin")
+		logrus.WithFields(logrus.Fields{"tool": "pod_exec", "pod": name, "ns": namespace, "container": container, "stdin": stdin != ""}).Debug("Handler invoked")
 
-		result, err := c.ExecCommand(ctx, name, namespace, container, commandArgs)
-		if err != nil {
+		result, err := c.ExecCommand(ctx, name, namespace, container, commandArgs, stdin)
+		if err != nil && result == nil {
 			return nil, fmt.Errorf("%w: %v", ErrCommandExecutionFail, err)
 		}
-		logrus.Debug("pod_exec succeeded")
-		return mcp.NewToolResultText(result), nil
+		logrus.WithFields(logrus.Fields{"exitCode": result.ExitCode, "streamError": result.Error}).Debug("pod_exec finished")
+		return execToolResult(result)
+	}
+}
+
+// execToolResult returns the structured exec output, marked as an error only when the command exited
+// non-zero or the stream failed so callers can branch on success without parsing the output.
+func execToolResult(result *k8sclient.ExecResult) (*mcp.CallToolResult, error) {
+	response, err := marshalJSONResponse(result)
+	if err != nil {
+		return nil, err
 	}
+	response.IsError = result.ExitCode != 0 || result.Error != ""
+	return response, nil
 }
 
 // HandleGetResourceSummary handles resource summary requests with minimal output.
@@ -951,13 +1862,18 @@ func HandleGetResourceSummary() func(ctx context.Context, request mcp.CallToolRe
 			return nil, err
 		}
 		namespace := getOptionalStringParam(request, "namespace")
+		apiVersion := getOptionalStringParam(request, "apiVersion")
 		includeLabels := getOptionalStringParam(request, "includeLabels")
+		includeContainerStatuses := getBoolParam(request, "includeContainerStatuses", false)
 		debug := getOptionalStringParam(request, "debug")
-		logrus.WithFields(logrus.Fields{"tool": "get_resource_summary", "kind": kind, "name": name, "ns": namespace, "debug": debug}).Debug("Handler invoked")
+		logrus.WithFields(logrus.Fields{"tool": "get_resource_summary", "kind": kind, "name": name, "ns": namespace, "apiVersion": apiVersion, "includeContainerStatuses": includeContainerStatuses, "debug": debug}).Debug("Handler invoked")
 
 		// Get the full resource first
-		resource, err := c.GetResource(ctx, kind, name, namespace)
+		resource, err := c.GetResourceForAPIVersion(ctx, kind, apiVersion, name, namespace)
 		if err != nil {
+			if result, ok := ambiguousKindResponse(err); ok {
+				return result, nil
+			}
 			return nil, err
 		}
 
@@ -975,6 +1891,9 @@ func HandleGetResourceSummary() func(ctx context.Context, request mcp.CallToolRe
 		if len(summaries) == 0 {
 			return createErrorResponse("failed to extract resource summary"), nil
 		}
+		if includeContainerStatuses {
+			addContainerStatuses(summaries, []map[string]interface{}{resource})
+		}
 
 		response := map[string]interface{}{
 			"summary": summaries[0],
@@ -1006,12 +1925,31 @@ func HandleGetResource() func(ctx context.Context, request mcp.CallToolRequest)
 			return nil, err
 		}
 		namespace := getOptionalStringParam(request, "namespace")
+		apiVersion := getOptionalStringParam(request, "apiVersion")
 		jsonpath := getOptionalRawStringParam(request, "jsonpath")
 		debug := getOptionalStringParam(request, "debug")
-		logrus.WithFields(logrus.Fields{"tool": "get_resource", "kind": kind, "name": name, "ns": namespace, "jsonpath": jsonpath, "debug": debug}).Debug("Handler invoked")
+		outputFormat, err := getOutputFormatParam(request)
+		if err != nil {
+			return nil, err
+		}
+		fieldArgs, err := getOptionalStringArrayParam(request, "fields")
+		if err != nil {
+			return createErrorResponse(err.Error()), nil
+		}
+		fields, err := parseFieldPaths("fields", fieldArgs)
+		if err != nil {
+			return createErrorResponse(err.Error()), nil
+		}
+		if len(fields) > 0 && jsonpath != "" {
+			return createErrorResponse("fields cannot be combined with jsonpath; use one or the other"), nil
+		}
+		logrus.WithFields(logrus.Fields{"tool": "get_resource", "kind": kind, "name": name, "ns": namespace, "apiVersion": apiVersion, "jsonpath": jsonpath, "fields": fieldArgs, "outputFormat": outputFormat, "debug": debug}).Debug("Handler invoked")
 
-		resource, err := c.GetResource(ctx, kind, name, namespace)
+		resource, err := c.GetResourceForAPIVersion(ctx, kind, apiVersion, name, namespace)
 		if err != nil {
+			if result, ok := ambiguousKindResponse(err); ok {
+				return result, nil
+			}
 			return nil, err
 		}
 
@@ -1027,7 +1965,21 @@ func HandleGetResource() func(ctx context.Context, request mcp.CallToolRequest)
 			result = filtered
 		}
 
+		// Project the requested dotted paths into a flat map
+		if len(fields) > 0 {
+			values, missing := projectFields(resource, fields)
+			projection := map[string]any{"fields": values}
+			if len(missing) > 0 {
+				projection["missingFields"] = missing
+				projection["note"] = fmt.Sprintf("%d of %d paths were not found on %s %s and are reported as null: %s", len(missing), len(fields), kind, name, strings.Join(missing, ", "))
+			}
+			result = projection
+		}
+
 		logrus.Debug("get_resource succeeded")
+		if outputFormat == OutputFormatYAML {
+			return marshalYAMLResponse(result)
+		}
 		return marshalJSONResponse(result)
 	}
 }
@@ -1044,6 +1996,7 @@ func HandleListResources() func(ctx context.Context, request mcp.CallToolRequest
 			return nil, err
 		}
 		namespace := getOptionalStringParam(request, "namespace")
+		apiVersion := getOptionalStringParam(request, "apiVersion")
 		labelSelector := getOptionalStringParam(request, "labelSelector")
 		fieldSelector := getOptionalStringParam(request, "fieldSelector")
 		jsonpath := getOptionalRawStringParam(request, "jsonpath")
@@ -1053,46 +2006,74 @@ func HandleListResources() func(ctx context.Context, request mcp.CallToolRequest
 		if err != nil {
 			return createErrorResponse(err.Error()), nil
 		}
+		outputFormat, err := getOutputFormatParamOf(request, OutputFormatJSON, OutputFormatNDJSON)
+		if err != nil {
+			return nil, err
+		}
+		if outputFormat == OutputFormatNDJSON && jsonpath != "" {
+			return createErrorResponse("jsonpath cannot be combined with outputFormat ndjson; use jsonpaths to emit one row object per line"), nil
+		}
 
-		// Parse limit parameter with conservative default to prevent context overflow
-		limit := getInt64Param(request, "limit", constants.DefaultLimit)
-		if limit <= 0 || limit > constants.MaxLimit {
-			if limit > constants.MaxLimit {
-				logrus.WithField("requested", limit).WithField("max", constants.MaxLimit).Warn("Limit too high, resetting to safe maximum")
-				limit = constants.MaxLimit
-			} else {
-				limit = constants.DefaultLimit
+		// Validate expressions up front so parse errors surface before any API call
+		if jsonpath != "" {
+			if err := validateJSONPathExpression(jsonpath); err != nil {
+				return createErrorResponse(err.Error()), nil
 			}
 		}
-		if limit > constants.WarningLimit {
-			logrus.WithField("limit", limit).Warn("Large limit may cause context overflow, consider using summary tools or pagination")
+		expressions := make([]string, 0, len(jsonpaths))
+		for _, expr := range jsonpaths {
+			expr = strings.TrimSpace(expr)
+			if expr == "" {
+				continue
+			}
+			if err := validateJSONPathExpression(expr); err != nil {
+				return createErrorResponse(err.Error()), nil
+			}
+			expressions = append(expressions, expr)
 		}
 
+		// Parse limit parameter with conservative default to prevent context overflow
+		limit := getLimitParam(request, "list_resources", constants.DefaultLimit, constants.MaxLimit, constants.WarningLimit)
+
 		logrus.WithFields(logrus.Fields{
-			"tool":      "list_resources",
-			"kind":      kind,
-			"ns":        namespace,
-			"labels":    labelSelector,
-			"fields":    fieldSelector,
-			"jsonpath":  jsonpath,
-			"jsonpaths": jsonpaths,
-			"continue":  continueToken,
-			"limit":     limit,
-			"debug":     debug,
+			"tool":         "list_resources",
+			"kind":         kind,
+			"apiVersion":   apiVersion,
+			"ns":           namespace,
+			"labels":       labelSelector,
+			"fields":       fieldSelector,
+			"jsonpath":     jsonpath,
+			"jsonpaths":    jsonpaths,
+			"continue":     continueToken,
+			"limit":        limit,
+			"outputFormat": outputFormat,
+			"debug":        debug,
 		}).Debug("Handler invoked")
 
-		resources, err := c.ListResourcesWithPagination(ctx, kind, namespace, labelSelector, fieldSelector, continueToken, limit)
+		resources, err := c.ListResourcesForAPIVersion(ctx, kind, apiVersion, namespace, labelSelector, fieldSelector, continueToken, limit)
 		if err != nil {
+			if result, ok := ambiguousKindResponse(err); ok {
+				return result, nil
+			}
 			return createErrorResponse(err.Error()), nil
 		}
 
 		// Get pagination info
-		paginationInfo, err := c.GetPaginationInfo(ctx, kind, namespace, labelSelector, fieldSelector, continueToken, limit)
+		paginationInfo, err := c.GetPaginationInfoForAPIVersion(ctx, kind, apiVersion, namespace, labelSelector, fieldSelector, continueToken, limit)
 		if err != nil {
 			logrus.WithError(err).Warn("Failed to get pagination info")
 			paginationInfo = &PaginationInfo{ContinueToken: "", RemainingCount: 0, CurrentPageSize: 0, HasMore: false}
 		}
 
+		if outputFormat == OutputFormatNDJSON {
+			lines := resources
+			if len(expressions) > 0 {
+				lines = jsonPathRowObjects(resources, expressions)
+			}
+			logrus.WithFields(logrus.Fields{"count": len(resources), "hasMore": paginationInfo.HasMore}).Debug("list_resources succeeded")
+			return marshalNDJSON(lines, paginationInfo)
+		}
+
 		// Wrap resources into {"items": [...] } to support JSONPath like {.items[*].metadata.name}
 		wrapped := map[string]any{"items": resources}
 		var result any = wrapped
@@ -1106,51 +2087,22 @@ func HandleListResources() func(ctx context.Context, request mcp.CallToolRequest
 			} else {
 				result = filtered
 			}
-		} else if len(jsonpaths) > 0 {
-			// Handle multiple JSONPath expressions
-			expressions := jsonpaths
-			var tableResult []map[string]any
-
-			// Process each resource - convert resources to []interface{} for processing
-			resourceList := make([]any, len(resources))
-			for i, resource := range resources {
-				resourceList[i] = resource
-			}
-
-			for _, resource := range resourceList {
-				row := make(map[string]any)
-				for i, expr := range expressions {
-					expr = strings.TrimSpace(expr)
-					if expr == "" {
-						continue
-					}
-
-					fieldValue, err := applyJSONPath(resource, expr)
-					if err != nil {
-						logrus.WithError(err).WithField("expression", expr).Debug("JSONPath expression failed")
-						row[fmt.Sprintf("field_%d", i)] = fmt.Sprintf("error: %s", err.Error())
-					} else {
-						row[fmt.Sprintf("field_%d", i)] = fieldValue
-					}
-				}
-				tableResult = append(tableResult, row)
-			}
+		} else if len(expressions) > 0 {
+			// Handle multiple JSONPath expressions as a stable table
+			columns, rows, table := buildJSONPathTable(resources, expressions)
 			result = map[string]any{
 				"expressions": expressions,
-				"data":        tableResult,
+				"columns":     columns,
+				"rows":        rows,
+				"table":       table,
 			}
 		}
 
 		// Add pagination metadata to the response
 		response := map[string]any{
-			"data": result,
-			"pagination": map[string]any{
-				"continueToken":   paginationInfo.ContinueToken,
-				"remainingCount":  paginationInfo.RemainingCount,
-				"currentPageSize": paginationInfo.CurrentPageSize,
-				"hasMore":         paginationInfo.HasMore,
-			},
-			"count": len(resources),
+			"data":       result,
+			"pagination": paginationResponse(paginationInfo, len(resources)),
+			"count":      len(resources),
 		}
 
 		logrus.WithFields(logrus.Fields{
@@ -1162,6 +2114,30 @@ func HandleListResources() func(ctx context.Context, request mcp.CallToolRequest
 	}
 }
 
+// addContainerStatuses appends the per-container state of each pod to its summary. Summaries are
+// produced one per resource, in order, so the two slices line up.
+func addContainerStatuses(summaries, resources []map[string]interface{}) {
+	for i := range summaries {
+		if i >= len(resources) {
+			return
+		}
+		if statuses := k8sclient.PodContainerStatuses(resources[i]); statuses != nil {
+			summaries[i]["containers"] = statuses
+		}
+	}
+}
+
+// parseLabelKeys splits the comma-separated includeLabels argument into label keys
+func parseLabelKeys(includeLabels string) []string {
+	var labelKeys []string
+	for _, key := range strings.Split(includeLabels, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			labelKeys = append(labelKeys, key)
+		}
+	}
+	return labelKeys
+}
+
 // HandleListResourcesSummary handles listing resources with summary output for LLM efficiency
 func HandleListResourcesSummary() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1174,69 +2150,66 @@ func HandleListResourcesSummary() func(ctx context.Context, request mcp.CallTool
 			return nil, err
 		}
 		namespace := getOptionalStringParam(request, "namespace")
+		apiVersion := getOptionalStringParam(request, "apiVersion")
 		labelSelector := getOptionalStringParam(request, "labelSelector")
 		includeLabels := getOptionalStringParam(request, "includeLabels")
-		limitStr := getOptionalStringParam(request, "limit")
+		includeContainerStatuses := getBoolParam(request, "includeContainerStatuses", false)
 		continueToken := getOptionalStringParam(request, "continueToken")
-
-		limit := int64(constants.DefaultLimit)
-		if limitStr != "" {
-			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-				if l > constants.MaxLimit {
-					logrus.WithField("requested", l).WithField("max", constants.MaxLimit).Warn("Summary limit too high, resetting to safe maximum")
-					limit = constants.MaxLimit
-				} else {
-					limit = int64(l)
-				}
-				if l > constants.WarningLimit {
-					logrus.WithField("limit", l).Warn("Large summary limit may cause context overflow")
-				}
-			}
+		limit := getLimitParam(request, "list_resources_summary", constants.DefaultLimit, constants.MaxLimit, constants.WarningLimit)
+		outputFormat, err := getOutputFormatParamOf(request, OutputFormatJSON, OutputFormatNDJSON, OutputFormatCSV)
+		if err != nil {
+			return nil, err
 		}
 
 		logrus.WithFields(logrus.Fields{
-			"tool":     "list_resources_summary",
-			"kind":     kind,
-			"ns":       namespace,
-			"labels":   labelSelector,
-			"limit":    limit,
-			"continue": continueToken,
+			"tool":              "list_resources_summary",
+			"kind":              kind,
+			"apiVersion":        apiVersion,
+			"ns":                namespace,
+			"labels":            labelSelector,
+			"limit":             limit,
+			"continue":          continueToken,
+			"containerStatuses": includeContainerStatuses,
+			"outputFormat":      outputFormat,
 		}).Debug("Handler invoked")
 
 		// Use paginated listing to avoid loading too much data
-		resources, err := c.ListResourcesWithPagination(ctx, kind, namespace, labelSelector, "", continueToken, limit)
+		resources, err := c.ListResourcesForAPIVersion(ctx, kind, apiVersion, namespace, labelSelector, "", continueToken, limit)
 		if err != nil {
+			if result, ok := ambiguousKindResponse(err); ok {
+				return result, nil
+			}
 			return createErrorResponse(err.Error()), nil
 		}
 
 		// Get pagination info
-		paginationInfo, err := c.GetPaginationInfo(ctx, kind, namespace, labelSelector, "", continueToken, limit)
+		paginationInfo, err := c.GetPaginationInfoForAPIVersion(ctx, kind, apiVersion, namespace, labelSelector, "", continueToken, limit)
 		if err != nil {
 			logrus.WithError(err).Warn("Failed to get pagination info for summary")
 			paginationInfo = &PaginationInfo{ContinueToken: "", RemainingCount: 0, CurrentPageSize: 0, HasMore: false}
 		}
 
-		// Parse includeLabels
-		var labelKeys []string
-		if includeLabels != "" {
-			labelKeys = strings.Split(includeLabels, ",")
-			for i := range labelKeys {
-				labelKeys[i] = strings.TrimSpace(labelKeys[i])
-			}
-		}
+		labelKeys := parseLabelKeys(includeLabels)
 
 		// Extract summaries (already limited by pagination)
 		summaries := c.ExtractResourceSummaries(resources, labelKeys)
+		if includeContainerStatuses {
+			addContainerStatuses(summaries, resources)
+		}
+
+		switch outputFormat {
+		case OutputFormatNDJSON:
+			logrus.WithFields(logrus.Fields{"count": len(summaries), "hasMore": paginationInfo.HasMore}).Debug("list_resources_summary succeeded")
+			return marshalNDJSON(summaries, paginationInfo)
+		case OutputFormatCSV:
+			logrus.WithFields(logrus.Fields{"count": len(summaries), "hasMore": paginationInfo.HasMore}).Debug("list_resources_summary succeeded")
+			return marshalSummaryCSV(summaries, labelKeys, paginationInfo)
+		}
 
 		response := map[string]interface{}{
-			"items": summaries,
-			"count": len(summaries),
-			"pagination": map[string]interface{}{
-				"continueToken":   paginationInfo.ContinueToken,
-				"remainingCount":  paginationInfo.RemainingCount,
-				"currentPageSize": paginationInfo.CurrentPageSize,
-				"hasMore":         paginationInfo.HasMore,
-			},
+			"items":      summaries,
+			"count":      len(summaries),
+			"pagination": paginationResponse(paginationInfo, len(summaries)),
 		}
 
 		// Apply caching optimization for summary responses
@@ -1297,6 +2270,66 @@ func HandleDeleteResource() func(ctx context.Context, request mcp.CallToolReques
 	}
 }
 
+// HandleDeleteResourcesByLabel handles batch deletion of resources matching a label selector.
+func HandleDeleteResourcesByLabel() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		kind, err := requireStringParam(request, "kind")
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		labelSelector, err := requireStringParam(request, "labelSelector")
+		if err != nil {
+			return nil, err
+		}
+		dryRun := getBoolParam(request, "dryRun", false)
+		confirmed := getBoolParam(request, "confirmed", false)
+		limit := getLimitParam(request, "delete_resources_by_label", 50, 200, 0)
+		logrus.WithFields(logrus.Fields{
+			"tool": "delete_resources_by_label", "kind": kind, "ns": namespace,
+			"labelSelector": labelSelector, "dryRun": dryRun, "limit": limit,
+		}).Debug("Handler invoked")
+
+		if !dryRun && !confirmed {
+			return createErrorResponse("this operation deletes resources; set confirmed=true to continue or dryRun=true to preview"), nil
+		}
+
+		result, err := c.DeleteResourcesByLabel(ctx, kind, namespace, labelSelector, int(limit), dryRun)
+		if err != nil {
+			return nil, err
+		}
+
+		response := map[string]any{
+			"status":        "ok",
+			"kind":          kind,
+			"namespace":     namespace,
+			"labelSelector": labelSelector,
+			"dryRun":        dryRun,
+			"method":        result.Method,
+			"count":         len(result.Names),
+			"truncated":     result.Truncated,
+		}
+		if dryRun {
+			response["wouldDelete"] = result.Names
+		} else {
+			response["deleted"] = result.Names
+		}
+		if len(result.Failed) > 0 {
+			response["status"] = "partial"
+			response["failed"] = result.Failed
+		}
+		logrus.Debug("delete_resources_by_label succeeded")
+		return marshalJSONResponse(response)
+	}
+}
+
 // HandleCheckPermissions handles permission checking requests.
 func HandleCheckPermissions() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1379,19 +2412,31 @@ func HandleScaleResource() func(ctx context.Context, request mcp.CallToolRequest
 		if replicas < 0 {
 			return nil, fmt.Errorf("missing required parameter: replicas")
 		}
-		logrus.WithFields(logrus.Fields{"tool": "scale_resource", "kind": kind, "name": name, "ns": namespace, "replicas": replicas}).Debug("Handler invoked")
+		dryRun := getBoolParam(request, "dryRun", false)
+		logrus.WithFields(logrus.Fields{"tool": "scale_resource", "kind": kind, "name": name, "ns": namespace, "replicas": replicas, "dryRun": dryRun}).Debug("Handler invoked")
 
-		if err := c.ScaleResourceByKind(ctx, kind, name, namespace, replicas); err != nil {
+		result, err := c.ScaleResourceByKind(ctx, kind, name, namespace, replicas, dryRun)
+		if err != nil {
 			return nil, err
 		}
 		logrus.Debug("scale_resource succeeded")
+		message := "resource scaled successfully"
+		switch {
+		case dryRun:
+			message = fmt.Sprintf("dry run: would scale from %d to %d replicas", result.PreviousReplicas, result.Replicas)
+		case !result.Changed:
+			message = "resource already at the requested replica count"
+		}
 		return marshalJSONResponse(map[string]any{
-			"status":    "ok",
-			"message":   "resource scaled successfully",
-			"kind":      kind,
-			"name":      name,
-			"namespace": namespace,
-			"replicas":  replicas,
+			"status":           "ok",
+			"message":          message,
+			"kind":             kind,
+			"name":             name,
+			"namespace":        namespace,
+			"replicas":         result.Replicas,
+			"previousReplicas": result.PreviousReplicas,
+			"changed":          result.Changed,
+			"dryRun":           dryRun,
 		})
 	}
 }
@@ -1427,6 +2472,37 @@ func HandleGetRolloutStatus() func(ctx context.Context, request mcp.CallToolRequ
 	}
 }
 
+// HandleResourceDiffRevisions lists the revisions of a workload and diffs two of them.
+func HandleResourceDiffRevisions() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		name, err := requireStringParam(request, "name")
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		kind := getOptionalStringParam(request, "kind")
+		fromRevision := getInt64Param(request, "fromRevision", 0)
+		toRevision := getInt64Param(request, "toRevision", 0)
+		logrus.WithFields(logrus.Fields{
+			"tool": "get_resource_diff_revisions", "kind": kind, "name": name, "ns": namespace, "from": fromRevision, "to": toRevision,
+		}).Debug("Handler invoked")
+
+		result, err := c.GetWorkloadRevisionDiff(ctx, kind, name, namespace, fromRevision, toRevision)
+		if err != nil {
+			return createErrorResponse(err.Error()), nil
+		}
+		logrus.WithField("changes", result.TotalChanges).Debug("get_resource_diff_revisions succeeded")
+		return marshalOptimizedResponse(result, "get_resource_diff_revisions")
+	}
+}
+
 // HandleCordonNode marks a node unschedulable.
 func HandleCordonNode() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1609,7 +2685,7 @@ func HandleGetAPIVersions() func(ctx context.Context, request mcp.CallToolReques
 		if err != nil {
 			return nil, err
 		}
-		logrus.Debug("get_api_versions succeeded")
+		logrus.WithField("discoveryCacheAge", c.DiscoveryCacheAge().Round(time.Second).String()).Debug("get_api_versions succeeded")
 		return marshalJSONResponse(result)
 	}
 }
@@ -1637,7 +2713,25 @@ func HandleGetAPIResources() func(ctx context.Context, request mcp.CallToolReque
 		if err != nil {
 			return nil, err
 		}
-		logrus.Debug("get_api_resources succeeded")
+		logrus.WithField("discoveryCacheAge", c.DiscoveryCacheAge().Round(time.Second).String()).Debug("get_api_resources succeeded")
+		return marshalJSONResponse(result)
+	}
+}
+
+// HandleRefreshDiscovery handles forced discovery cache refresh requests
+func HandleRefreshDiscovery() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		logrus.WithField("tool", "refresh_discovery").Debug("Handler invoked")
+
+		result, err := c.RefreshDiscovery(ctx)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debug("refresh_discovery succeeded")
 		return marshalJSONResponse(result)
 	}
 }
@@ -1656,30 +2750,54 @@ func HandleGetResourcesDetail() func(ctx context.Context, request mcp.CallToolRe
 		namespace := getOptionalStringParam(request, "namespace")
 		includeEvents := getBoolParam(request, "includeEvents", false)
 		includeStatus := getBoolParam(request, "includeStatus", true)
+		autoBatch := getBoolParam(request, "autoBatch", false)
 		debug := getOptionalStringParam(request, "debug")
 
 		names, err := getOptionalStringArrayParam(request, "names")
 		if err != nil {
 			return createErrorResponse(err.Error()), nil
 		}
+		names = uniqueStrings(names)
 
 		if len(names) == 0 {
 			return createErrorResponse("names parameter is required and must be a non-empty array"), nil
 		}
+		if !autoBatch && len(names) > constants.MaxDetailBatchSize {
+			return createErrorResponse(fmt.Sprintf(
+				"%d names requested but at most %d resources can be fetched per call; set autoBatch=true to fetch them in batches of %d",
+				len(names), constants.MaxDetailBatchSize, constants.MaxDetailBatchSize)), nil
+		}
+
+		offset, err := parseOffsetContinueToken(getOptionalStringParam(request, "continueToken"))
+		if err != nil {
+			return createErrorResponse(err.Error()), nil
+		}
+		start := min(offset, len(names))
+		end := min(start+constants.MaxDetailAutoBatchNames, len(names))
+		window := names[start:end]
 
 		logrus.WithFields(logrus.Fields{
 			"tool":      "get_resources_detail",
 			"kind":      kind,
 			"names":     len(names),
+			"window":    len(window),
 			"namespace": namespace,
 			"events":    includeEvents,
 			"status":    includeStatus,
+			"autoBatch": autoBatch,
 			"debug":     debug,
 		}).Debug("Handler invoked")
 
-		resources, err := c.GetResourcesDetail(ctx, kind, names, namespace, includeEvents, includeStatus)
-		if err != nil {
-			return createErrorResponse(err.Error()), nil
+		resources := map[string]map[string]any{}
+		failures := map[string]string{}
+		batches := chunkStrings(window, constants.MaxDetailBatchSize)
+		for _, batch := range batches {
+			result, err := c.GetResourcesDetail(ctx, kind, batch, namespace, includeEvents, includeStatus)
+			if err != nil {
+				return createErrorResponse(err.Error()), nil
+			}
+			maps.Copy(resources, result.Resources)
+			maps.Copy(failures, result.Errors)
 		}
 
 		response := map[string]interface{}{
@@ -1687,23 +2805,48 @@ func HandleGetResourcesDetail() func(ctx context.Context, request mcp.CallToolRe
 			"count":     len(resources),
 			"kind":      kind,
 		}
+		if len(failures) > 0 {
+			response["errors"] = failures
+		}
 
 		// Add metadata about the request for context
 		response["metadata"] = map[string]interface{}{
 			"requestedCount": len(names),
 			"retrievedCount": len(resources),
+			"failedCount":    len(failures),
 			"includeEvents":  includeEvents,
 			"includeStatus":  includeStatus,
 			"namespace":      namespace,
+			"batchSize":      constants.MaxDetailBatchSize,
+			"batches":        len(batches),
+		}
+		if autoBatch {
+			response["pagination"] = paginationResponse(offsetPagination(end, len(names), len(window)), len(window))
 		}
 
 		logrus.WithFields(logrus.Fields{
-			"requested": len(names),
+			"requested": len(window),
 			"retrieved": len(resources),
+			"failed":    len(failures),
 		}).Debug("get_resources_detail succeeded")
 
-		return marshalJSONResponse(response)
+		result, err := marshalJSONResponse(response)
+		if err != nil {
+			return nil, err
+		}
+		// Per-name failures are reported alongside the resources; the call only fails when nothing was retrieved
+		result.IsError = len(resources) == 0
+		return result, nil
+	}
+}
+
+// chunkStrings splits values into consecutive chunks of at most size elements
+func chunkStrings(values []string, size int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(values); start += size {
+		chunks = append(chunks, values[start:min(start+size, len(values))])
 	}
+	return chunks
 }
 
 // HandleGetEventsDetail handles detailed events retrieval
@@ -1719,20 +2862,17 @@ func HandleGetEventsDetail() func(ctx context.Context, request mcp.CallToolReque
 		debug := getOptionalStringParam(request, "debug")
 
 		// More conservative default for detailed events
-		limit := getInt64Param(request, "limit", 50)
-		if limit <= 0 || limit > 200 {
-			if limit > 200 {
-				logrus.WithField("requested", limit).Warn("Event detail limit too high, resetting to maximum")
-				limit = 200
-			} else {
-				limit = 50
-			}
-		}
-		if limit > 100 {
-			logrus.WithField("limit", limit).Warn("Large event detail limit may cause context overflow")
-		}
+		limit := getLimitParam(request, "get_events_detail", 50, 200, 100)
 
 		continueToken := getOptionalStringParam(request, "continueToken")
+		offset, err := parseOffsetContinueToken(continueToken)
+		if err != nil {
+			return nil, err
+		}
+		since, err := getEventSinceParam(request)
+		if err != nil {
+			return nil, err
+		}
 
 		logrus.WithFields(logrus.Fields{
 			"tool":          "get_events_detail",
@@ -1741,6 +2881,7 @@ func HandleGetEventsDetail() func(ctx context.Context, request mcp.CallToolReque
 			"includeNormal": includeNormalEvents,
 			"limit":         limit,
 			"continue":      continueToken,
+			"since":         since,
 			"debug":         debug,
 		}).Debug("Handler invoked")
 
@@ -1754,18 +2895,15 @@ func HandleGetEventsDetail() func(ctx context.Context, request mcp.CallToolReque
 			}
 		}
 
-		// Use paginated listing
-		resources, err := c.ListResourcesWithPagination(ctx, "Event", namespace, "", selector, continueToken, limit)
+		sorted, err := c.ListEventsNewestFirst(ctx, namespace, selector, since)
 		if err != nil {
 			return nil, err
 		}
-
-		// Get pagination info
-		paginationInfo, err := c.GetPaginationInfo(ctx, "Event", namespace, "", selector, continueToken, limit)
-		if err != nil {
-			logrus.WithError(err).Warn("Failed to get pagination info for detailed events")
-			paginationInfo = &PaginationInfo{ContinueToken: "", RemainingCount: 0, CurrentPageSize: 0, HasMore: false}
-		}
+		total := len(sorted.Events)
+		start := min(offset, total)
+		end := min(start+int(limit), total)
+		resources := sorted.Events[start:end]
+		paginationInfo := offsetPagination(end, total, len(resources))
 
 		response := map[string]interface{}{
 			"events": resources,
@@ -1774,13 +2912,9 @@ func HandleGetEventsDetail() func(ctx context.Context, request mcp.CallToolReque
 				"includeNormalEvents": includeNormalEvents,
 				"fieldSelector":       selector,
 			},
-			"pagination": map[string]interface{}{
-				"continueToken":   paginationInfo.ContinueToken,
-				"remainingCount":  paginationInfo.RemainingCount,
-				"currentPageSize": paginationInfo.CurrentPageSize,
-				"hasMore":         paginationInfo.HasMore,
-			},
+			"pagination": paginationResponse(paginationInfo, len(resources)),
 		}
+		addEventScanInfo(response, sorted)
 
 		logrus.WithFields(logrus.Fields{
 			"count":   len(resources),
@@ -1791,6 +2925,27 @@ func HandleGetEventsDetail() func(ctx context.Context, request mcp.CallToolReque
 	}
 }
 
+// getFieldPruningParams reads the fields (paths to keep) and dropFields (paths to remove) arguments
+func getFieldPruningParams(request mcp.CallToolRequest) ([]fieldPath, []fieldPath, error) {
+	fields, err := getOptionalStringArrayParam(request, "fields")
+	if err != nil {
+		return nil, nil, err
+	}
+	keep, err := parseFieldPaths("fields", fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	drop, err := getOptionalStringArrayParam(request, "dropFields")
+	if err != nil {
+		return nil, nil, err
+	}
+	dropPaths, err := parseFieldPaths("dropFields", drop)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keep, dropPaths, nil
+}
+
 // HandleListResourcesFull handles full resource listing without optimization
 func HandleListResourcesFull() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1803,40 +2958,46 @@ func HandleListResourcesFull() func(ctx context.Context, request mcp.CallToolReq
 			return nil, err
 		}
 		namespace := getOptionalStringParam(request, "namespace")
+		apiVersion := getOptionalStringParam(request, "apiVersion")
 		labelSelector := getOptionalStringParam(request, "labelSelector")
 		fieldSelector := getOptionalStringParam(request, "fieldSelector")
 		includeStatus := getBoolParam(request, "includeStatus", true)
 		debug := getOptionalStringParam(request, "debug")
-		continueToken := getOptionalStringParam(request, "continueToken")
-
-		// Very conservative default for full resources
-		limit := getInt64Param(request, "limit", 10)
-		if limit <= 0 || limit > 50 {
-			if limit > 50 {
-				logrus.WithField("requested", limit).Warn("Full resource limit too high, resetting to safe maximum")
-				limit = 50
-			} else {
-				limit = 10
-			}
+		continueToken := getOptionalStringParam(request, "continueToken")
+		outputFormat, err := getOutputFormatParamOf(request, OutputFormatJSON, OutputFormatYAML, OutputFormatNDJSON)
+		if err != nil {
+			return nil, err
 		}
-		if limit > 20 {
-			logrus.WithField("limit", limit).Warn("Large full resource limit may cause significant context overflow")
+
+		keepPaths, dropPaths, err := getFieldPruningParams(request)
+		if err != nil {
+			return nil, err
 		}
 
+		// Very conservative default for full resources
+		limit := getLimitParam(request, "list_resources_full", 10, 50, 20)
+
 		logrus.WithFields(logrus.Fields{
 			"tool":          "list_resources_full",
 			"kind":          kind,
+			"apiVersion":    apiVersion,
 			"ns":            namespace,
 			"labels":        labelSelector,
 			"fields":        fieldSelector,
 			"includeStatus": includeStatus,
 			"limit":         limit,
 			"continue":      continueToken,
+			"outputFormat":  outputFormat,
+			"keepFields":    len(keepPaths),
+			"dropFields":    len(dropPaths),
 			"debug":         debug,
 		}).Debug("Handler invoked")
 
-		resources, err := c.ListResourcesWithPagination(ctx, kind, namespace, labelSelector, fieldSelector, continueToken, limit)
+		resources, err := c.ListResourcesForAPIVersion(ctx, kind, apiVersion, namespace, labelSelector, fieldSelector, continueToken, limit)
 		if err != nil {
+			if result, ok := ambiguousKindResponse(err); ok {
+				return result, nil
+			}
 			return createErrorResponse(err.Error()), nil
 		}
 
@@ -1846,9 +3007,15 @@ func HandleListResourcesFull() func(ctx context.Context, request mcp.CallToolReq
 				delete(resource, "status")
 			}
 		}
+		for i := range resources {
+			if len(keepPaths) > 0 {
+				resources[i] = keepFields(resources[i], keepPaths)
+			}
+			dropFields(resources[i], dropPaths)
+		}
 
 		// Get pagination info
-		paginationInfo, err := c.GetPaginationInfo(ctx, kind, namespace, labelSelector, fieldSelector, continueToken, limit)
+		paginationInfo, err := c.GetPaginationInfoForAPIVersion(ctx, kind, apiVersion, namespace, labelSelector, fieldSelector, continueToken, limit)
 		if err != nil {
 			logrus.WithError(err).Warn("Failed to get pagination info for full resources")
 			paginationInfo = &PaginationInfo{ContinueToken: "", RemainingCount: 0, CurrentPageSize: 0, HasMore: false}
@@ -1859,14 +3026,9 @@ func HandleListResourcesFull() func(ctx context.Context, request mcp.CallToolReq
 			"count":     len(resources),
 			"metadata": map[string]interface{}{
 				"includeStatus": includeStatus,
-				"fullDetails":   true,
-			},
-			"pagination": map[string]interface{}{
-				"continueToken":   paginationInfo.ContinueToken,
-				"remainingCount":  paginationInfo.RemainingCount,
-				"currentPageSize": paginationInfo.CurrentPageSize,
-				"hasMore":         paginationInfo.HasMore,
+				"fullDetails":   len(keepPaths) == 0 && len(dropPaths) == 0,
 			},
+			"pagination": paginationResponse(paginationInfo, len(resources)),
 		}
 
 		logrus.WithFields(logrus.Fields{
@@ -1874,6 +3036,17 @@ func HandleListResourcesFull() func(ctx context.Context, request mcp.CallToolReq
 			"hasMore": paginationInfo.HasMore,
 		}).Debug("list_resources_full succeeded")
 
+		if outputFormat == OutputFormatYAML {
+			header := []string{fmt.Sprintf("count: %d, hasMore: %t", len(resources), paginationInfo.HasMore)}
+			if paginationInfo.ContinueToken != "" {
+				header = append(header, "continueToken: "+paginationInfo.ContinueToken)
+			}
+			return marshalYAMLDocuments(resources, header...)
+		}
+		if outputFormat == OutputFormatNDJSON {
+			return marshalNDJSON(resources, paginationInfo)
+		}
+
 		return marshalOptimizedResponse(response, "list_resources_full")
 	}
 }
@@ -1894,6 +3067,7 @@ func HandleGetResourceDetailAdvanced() func(ctx context.Context, request mcp.Cal
 			return nil, err
 		}
 		namespace := getOptionalStringParam(request, "namespace")
+		apiVersion := getOptionalStringParam(request, "apiVersion")
 		includeEvents := getBoolParam(request, "includeEvents", false)
 		includeRelationships := getBoolParam(request, "includeRelationships", false)
 		includeDiagnostics := getBoolParam(request, "includeDiagnostics", false)
@@ -1905,10 +3079,16 @@ func HandleGetResourceDetailAdvanced() func(ctx context.Context, request mcp.Cal
 		if outputFormat == "" {
 			outputFormat = "structured"
 		}
+		switch outputFormat {
+		case "compact", "structured", "verbose", OutputFormatJSON, OutputFormatYAML:
+		default:
+			return nil, fmt.Errorf("unsupported outputFormat %q: expected compact, structured, verbose, json, or yaml", outputFormat)
+		}
 
 		logrus.WithFields(logrus.Fields{
 			"tool":                 "get_resource_detail_advanced",
 			"kind":                 kind,
+			"apiVersion":           apiVersion,
 			"name":                 name,
 			"namespace":            namespace,
 			"includeEvents":        includeEvents,
@@ -1920,8 +3100,11 @@ func HandleGetResourceDetailAdvanced() func(ctx context.Context, request mcp.Cal
 		}).Debug("Handler invoked")
 
 		// Get the base resource
-		resource, err := c.GetResource(ctx, kind, name, namespace)
+		resource, err := c.GetResourceForAPIVersion(ctx, kind, apiVersion, name, namespace)
 		if err != nil {
+			if result, ok := ambiguousKindResponse(err); ok {
+				return result, nil
+			}
 			return nil, err
 		}
 
@@ -2023,6 +3206,13 @@ func HandleGetResourceDetailAdvanced() func(ctx context.Context, request mcp.Cal
 				}
 			}
 
+			// Probe configuration and probe failures for pods and pod templates
+			if _, ok := resource["spec"].(map[string]interface{}); ok {
+				if probes := c.GetProbeDiagnostics(ctx, resource); len(probes.Containers) > 0 {
+					diagnostics["probes"] = probes
+				}
+			}
+
 			// Add resource version for change detection
 			if metadata, ok := resource["metadata"].(map[string]interface{}); ok {
 				if resourceVersion, exists := metadata["resourceVersion"]; exists {
@@ -2045,12 +3235,16 @@ func HandleGetResourceDetailAdvanced() func(ctx context.Context, request mcp.Cal
 		case "verbose":
 			// Add raw object for complete analysis
 			response["rawObject"] = resource
-		case "structured":
+		case "structured", OutputFormatJSON, OutputFormatYAML:
 			// Default structured format - keep as is
 		}
 
 		logrus.WithField("kind", kind).WithField("name", name).Debug("get_resource_detail_advanced succeeded")
 
+		if outputFormat == OutputFormatYAML {
+			return marshalYAMLResponse(response)
+		}
+
 		// Use optimized response for large data
 		data, err := optimize.GlobalJSONPool.MarshalToBytes(response)
 		if err != nil {
@@ -2091,6 +3285,81 @@ func analyzeHealthConditions(conditions interface{}) string {
 
 // ============ Troubleshooting Handlers ============
 
+// HandleClusterInfo handles cluster overview requests
+func HandleClusterInfo() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		logrus.WithField("tool", "cluster_info").Debug("Handler invoked")
+
+		info := c.GetClusterInfo(ctx)
+
+		logrus.Debug("cluster_info succeeded")
+		return marshalJSONResponse(info)
+	}
+}
+
+// HandleCurrentContext handles current context and identity requests
+func HandleCurrentContext() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		logrus.WithField("tool", "current_context").Debug("Handler invoked")
+
+		current := c.GetCurrentContext(ctx)
+
+		logrus.Debug("current_context succeeded")
+		return marshalJSONResponse(current)
+	}
+}
+
+// HandleNodeAllocationSummary handles fleet-wide node allocation and pressure requests
+func HandleNodeAllocationSummary() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		continueToken := getOptionalStringParam(request, "continueToken")
+		limit := getLimitParam(request, "node_allocation_summary", 20, 100, 0)
+
+		offset, err := parseOffsetContinueToken(continueToken)
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":     "node_allocation_summary",
+			"limit":    limit,
+			"continue": continueToken,
+		}).Debug("Handler invoked")
+
+		allocations, err := c.GetNodeAllocationSummary(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		total := len(allocations)
+		start := min(offset, total)
+		end := min(start+int(limit), total)
+		page := allocations[start:end]
+
+		response := map[string]any{
+			"nodes":      page,
+			"count":      len(page),
+			"totalNodes": total,
+			"pagination": paginationResponse(offsetPagination(end, total, len(page)), len(page)),
+		}
+
+		logrus.WithFields(logrus.Fields{"count": len(page), "total": total}).Debug("node_allocation_summary succeeded")
+		return marshalJSONResponse(response)
+	}
+}
+
 // HandleGetUnhealthyResources handles finding unhealthy resources
 func HandleGetUnhealthyResources() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2105,16 +3374,47 @@ func HandleGetUnhealthyResources() func(ctx context.Context, request mcp.CallToo
 			return createErrorResponse(err.Error()), nil
 		}
 
-		logrus.WithField("namespace", namespace).Debug("Executing get_unhealthy_resources handler")
+		rankBySeverity := getBoolParam(request, "rankBySeverity", false)
+		continueToken := getOptionalStringParam(request, "continueToken")
+		limit := getLimitParam(request, "get_unhealthy_resources", constants.DefaultLimit, constants.MaxLimit, constants.WarningLimit)
+
+		offset, err := parseOffsetContinueToken(continueToken)
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"namespace":      namespace,
+			"rankBySeverity": rankBySeverity,
+			"limit":          limit,
+			"continue":       continueToken,
+		}).Debug("Executing get_unhealthy_resources handler")
 
 		unhealthy, err := c.GetUnhealthyResources(ctx, namespace, resourceTypes)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get unhealthy resources: %w", err)
 		}
+		if rankBySeverity {
+			k8sclient.RankUnhealthyResources(unhealthy)
+		}
+
+		total := len(unhealthy)
+		start := min(offset, total)
+		end := min(start+int(limit), total)
+		page := unhealthy[start:end]
 
 		response := map[string]interface{}{
-			"unhealthyResources": unhealthy,
-			"count":              len(unhealthy),
+			"unhealthyResources": page,
+			"count":              len(page),
+			"total":              total,
+			"pagination":         paginationResponse(offsetPagination(end, total, len(page)), len(page)),
+		}
+		// Events are only looked up for the returned page
+		if err := c.AttachWarningEvents(ctx, page); err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			response["warningEventsError"] = err.Error()
 		}
 
 		data, err := optimize.GlobalJSONPool.MarshalToBytes(response)
@@ -2126,6 +3426,75 @@ func HandleGetUnhealthyResources() func(ctx context.Context, request mcp.CallToo
 	}
 }
 
+// HandleRestartCount handles listing pods by container restarts
+func HandleRestartCount() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		labelSelector := getOptionalStringParam(request, "labelSelector")
+		minRestarts := getInt32Param(request, "minRestarts", 1)
+		limit := getLimitParam(request, "restart_count", constants.DefaultLimit, constants.MaxLimit, constants.WarningLimit)
+
+		logrus.WithFields(logrus.Fields{"tool": "restart_count", "ns": namespace, "labelSelector": labelSelector, "minRestarts": minRestarts, "limit": limit}).Debug("Handler invoked")
+
+		report, err := c.GetPodRestarts(ctx, namespace, labelSelector, minRestarts, int(limit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod restarts: %w", err)
+		}
+
+		logrus.WithFields(logrus.Fields{"pods": len(report.Pods), "crashLoop": report.CrashLoopPods}).Debug("restart_count succeeded")
+		return marshalOptimizedResponse(report, "restart_count")
+	}
+}
+
+// HandleQuotaSummary handles namespace quota and limit range reporting
+func HandleQuotaSummary() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace, err := requireStringParam(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{"tool": "quota_summary", "ns": namespace}).Debug("Handler invoked")
+
+		report, err := c.GetNamespaceQuotas(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespace quotas: %w", err)
+		}
+
+		logrus.WithFields(logrus.Fields{"quotas": len(report.Quotas), "nearLimit": len(report.NearLimit)}).Debug("quota_summary succeeded")
+		return marshalOptimizedResponse(report, "quota_summary")
+	}
+}
+
+// HandleGetHPAStatus handles HorizontalPodAutoscaler status reporting
+func HandleGetHPAStatus() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+
+		logrus.WithFields(logrus.Fields{"tool": "get_hpa_status", "ns": namespace}).Debug("Handler invoked")
+
+		report, err := c.GetHPAStatus(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get autoscaler status: %w", err)
+		}
+
+		logrus.WithFields(logrus.Fields{"total": report.Total, "unableToScale": report.UnableToScale}).Debug("get_hpa_status succeeded")
+		return marshalOptimizedResponse(report, "get_hpa_status")
+	}
+}
+
 // HandleGetNodeConditions handles retrieving node conditions
 func HandleGetNodeConditions() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2154,6 +3523,76 @@ func HandleGetNodeConditions() func(ctx context.Context, request mcp.CallToolReq
 	}
 }
 
+// HandleNodePods handles listing the pods scheduled on a node
+func HandleNodePods() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		nodeName, err := requireStringParam(request, "nodeName")
+		if err != nil {
+			return nil, err
+		}
+		continueToken := getOptionalStringParam(request, "continueToken")
+		limit := getLimitParam(request, "node_pods", constants.DefaultLimit, constants.MaxLimit, constants.WarningLimit)
+
+		offset, err := parseOffsetContinueToken(continueToken)
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"tool":     "node_pods",
+			"nodeName": nodeName,
+			"limit":    limit,
+			"continue": continueToken,
+		}).Debug("Handler invoked")
+
+		nodePods, err := c.GetNodePods(ctx, nodeName)
+		if err != nil {
+			return nil, err
+		}
+
+		total := len(nodePods.Pods)
+		start := min(offset, total)
+		end := min(start+int(limit), total)
+		page := nodePods.Pods[start:end]
+
+		response := map[string]any{
+			"node":       nodePods.Node,
+			"totals":     nodePods.Totals,
+			"pods":       page,
+			"count":      len(page),
+			"totalPods":  total,
+			"pagination": paginationResponse(offsetPagination(end, total, len(page)), len(page)),
+		}
+
+		logrus.WithFields(logrus.Fields{"nodeName": nodeName, "count": len(page), "total": total}).Debug("node_pods succeeded")
+		return marshalJSONResponse(response)
+	}
+}
+
+// HandleFindDeprecatedAPIs handles deprecated API usage audits.
+func HandleFindDeprecatedAPIs() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespace := getOptionalStringParam(request, "namespace")
+		targetVersion := getOptionalStringParam(request, "targetVersion")
+		logrus.WithFields(logrus.Fields{"tool": "find_deprecated_apis", "ns": namespace, "targetVersion": targetVersion}).Debug("Handler invoked")
+
+		report, err := c.FindDeprecatedAPIUsage(ctx, namespace, targetVersion)
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithField("objects", len(report.Objects)).Debug("find_deprecated_apis succeeded")
+		return marshalJSONResponse(report)
+	}
+}
+
 // HandleAnalyzeIssue handles AI-powered issue analysis
 func HandleAnalyzeIssue() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2213,26 +3652,47 @@ func HandleSearchResources() func(ctx context.Context, request mcp.CallToolReque
 			return nil, err
 		}
 
-		query := getOptionalStringParam(request, "query")
-		if query == "" {
-			query = getOptionalStringParam(request, "name")
-		}
-
 		namespace := getOptionalStringParam(request, "namespace")
 		searchMode := getOptionalStringParam(request, "searchMode")
 		if searchMode == "" {
 			searchMode = "contains"
 		}
 
+		// Regex patterns are only matched locally and rely on characters such as '|' and '$' that
+		// the filter sanitizer strips, so they are read raw
+		readQuery := getOptionalStringParam
+		if searchMode == "regex" {
+			readQuery = getOptionalRawStringParam
+		}
+		query := readQuery(request, "query")
+		if query == "" {
+			query = readQuery(request, "name")
+		}
+
 		caseSensitive := getBoolParam(request, "caseSensitive", false)
 
-		limit := getInt64Param(request, "limit", 50)
-		if limit <= 0 || limit > 200 {
-			limit = 50
+		var queryRegex *regexp.Regexp
+		if searchMode == "regex" && query != "" {
+			queryRegex, err = compileSearchRegex(query, caseSensitive)
+			if err != nil {
+				return nil, err
+			}
 		}
 
+		limit := getLimitParam(request, "search_resources", 50, 200, 100)
+
 		labelSelector := getOptionalStringParam(request, "labelSelector")
+		labelKeys := parseLabelKeys(getOptionalStringParam(request, "includeLabels"))
 		debug := getOptionalStringParam(request, "debug")
+		continueToken := getOptionalStringParam(request, "continueToken")
+		offset, err := parseOffsetContinueToken(continueToken)
+		if err != nil {
+			return nil, err
+		}
+		outputFormat, err := getOutputFormatParamOf(request, OutputFormatJSON, OutputFormatCSV)
+		if err != nil {
+			return nil, err
+		}
 
 		logrus.WithFields(logrus.Fields{
 			"tool":          "search_resources",
@@ -2243,10 +3703,16 @@ func HandleSearchResources() func(ctx context.Context, request mcp.CallToolReque
 			"caseSensitive": caseSensitive,
 			"limit":         limit,
 			"labelSelector": labelSelector,
+			"continue":      continueToken,
+			"outputFormat":  outputFormat,
 			"debug":         debug,
 		}).Debug("Handler invoked")
 
-		var matchedResources []map[string]any
+		// Collect one match past the requested page so hasMore can be reported without counting every match.
+		// Fuzzy matches are ranked by similarity, so every candidate has to be scored first.
+		wanted := offset + int(limit) + 1
+		rankByScore := searchMode == "fuzzy" && query != ""
+		var matches []searchMatch
 		queryStr := query
 		if !caseSensitive {
 			queryStr = strings.ToLower(query)
@@ -2271,6 +3737,7 @@ func HandleSearchResources() func(ctx context.Context, request mcp.CallToolReque
 				}
 
 				var matched bool
+				var score float64
 				searchName := name
 				if !caseSensitive {
 					searchName = strings.ToLower(name)
@@ -2288,10 +3755,15 @@ func HandleSearchResources() func(ctx context.Context, request mcp.CallToolReque
 						matched = strings.HasSuffix(searchName, queryStr)
 					case "exact":
 						matched = searchName == queryStr
+					case "fuzzy":
+						score, matched = fuzzySimilarity(queryStr, searchName, fuzzyMinSimilarity)
+						if matched {
+							resourceMap["matchScore"] = score
+						}
 					case "regex":
-						matched, err = regexMatch(searchName, queryStr)
+						matched, err = matchSearchRegex(queryRegex, name, searchRegexMatchTimeout)
 						if err != nil {
-							logrus.WithError(err).Warn("Regex match failed, skipping")
+							logrus.WithError(err).WithField("name", name).Warn("Regex match failed, skipping")
 							continue
 						}
 					default:
@@ -2300,31 +3772,63 @@ func HandleSearchResources() func(ctx context.Context, request mcp.CallToolReque
 				}
 
 				if matched {
-					matchedResources = append(matchedResources, resourceMap)
+					matches = append(matches, searchMatch{kind: kind, resource: resourceMap, score: score})
 				}
 
-				if len(matchedResources) >= int(limit) {
+				if !rankByScore && len(matches) >= wanted {
 					break
 				}
 			}
 
-			if len(matchedResources) >= int(limit) {
+			if !rankByScore && len(matches) >= wanted {
 				break
 			}
 		}
 
+		if rankByScore {
+			sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+		}
+
+		// The limit applies to the matches of all kinds together
+		start := min(offset, len(matches))
+		end := min(start+int(limit), len(matches))
+		pageMatches := matches[start:end]
+		page := make([]map[string]any, 0, len(pageMatches))
+		for _, match := range pageMatches {
+			page = append(page, match.resource)
+		}
+		pagination := &PaginationInfo{CurrentPageSize: int64(len(page))}
+		if end < len(matches) {
+			pagination.HasMore = true
+			pagination.ContinueToken = strconv.Itoa(end)
+		}
+
+		if outputFormat == OutputFormatCSV {
+			summaries := c.ExtractResourceSummaries(page, labelKeys)
+			for i, summary := range summaries {
+				if score, ok := page[i]["matchScore"]; ok {
+					summary["matchScore"] = score
+				}
+			}
+			logrus.WithFields(logrus.Fields{"matchedCount": len(page), "hasMore": pagination.HasMore}).Debug("search_resources succeeded")
+			return marshalSummaryCSV(summaries, labelKeys, pagination)
+		}
+
 		response := map[string]interface{}{
 			"query":         query,
 			"kinds":         kinds,
 			"namespace":     namespace,
 			"searchMode":    searchMode,
 			"caseSensitive": caseSensitive,
-			"matched":       len(matchedResources),
-			"resources":     matchedResources,
+			"matched":       len(page),
+			"resources":     page,
+			"pagination":    paginationResponse(pagination, len(page)),
 		}
 
 		if len(kinds) == 1 {
 			response["kind"] = kinds[0]
+		} else {
+			response["groups"] = groupSearchMatches(kinds, pageMatches)
 		}
 
 		data, err := optimize.GlobalJSONPool.MarshalToBytes(response)
@@ -2332,38 +3836,176 @@ func HandleSearchResources() func(ctx context.Context, request mcp.CallToolReque
 			return nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		logrus.WithField("matchedCount", len(matchedResources)).Debug("search_resources succeeded")
+		logrus.WithFields(logrus.Fields{"matchedCount": len(page), "hasMore": pagination.HasMore}).Debug("search_resources succeeded")
 		return mcp.NewToolResultText(string(data)), nil
 	}
 }
 
-// regexMatch performs basic regex matching
-func regexMatch(text, pattern string) (bool, error) {
-	// Convert simple wildcard pattern to regex
-	// * matches any sequence, ? matches any single character
-	regexPattern := ""
-	for _, char := range pattern {
-		switch char {
-		case '*':
-			regexPattern += ".*"
-		case '?':
-			regexPattern += "."
-		case '.', '^', '$', '+', '(', ')', '[', ']', '{', '}', '|', '\\':
-			// Escape regex special characters
-			regexPattern += "\\" + string(char)
-		default:
-			regexPattern += string(char)
+// HandleFindResource handles exact-name lookups across all namespaces
+func HandleFindResource() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c, err := k8sclient.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		kind, err := requireStringParam(request, "kind")
+		if err != nil {
+			return nil, err
+		}
+		name, err := requireStringParam(request, "name")
+		if err != nil {
+			return nil, err
+		}
+		logrus.WithFields(logrus.Fields{"tool": "find_resource", "kind": kind, "name": name}).Debug("Handler invoked")
+
+		lookup, err := c.FindResourceByName(ctx, kind, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s %q: %w", kind, name, err)
+		}
+
+		logrus.WithFields(logrus.Fields{"found": lookup.Found}).Debug("find_resource succeeded")
+		return marshalJSONResponse(lookup)
+	}
+}
+
+// searchMatch is a resource matched by kubernetes_search_resources with the kind it was listed as
+type searchMatch struct {
+	kind     string
+	resource map[string]any
+	score    float64
+}
+
+// searchGroup summarizes the matches of one kind in a multi-kind search
+type searchGroup struct {
+	Kind    string   `json:"kind"`
+	Matched int      `json:"matched"`
+	Names   []string `json:"names"`
+}
+
+// groupSearchMatches groups a page of matches by kind, in the order the kinds were requested.
+// Names are namespace/name for namespaced resources. Kinds without matches are left out.
+func groupSearchMatches(kinds []string, matches []searchMatch) []searchGroup {
+	byKind := map[string]*searchGroup{}
+	for _, match := range matches {
+		group, ok := byKind[match.kind]
+		if !ok {
+			group = &searchGroup{Kind: match.kind, Names: []string{}}
+			byKind[match.kind] = group
+		}
+		metadata, _ := match.resource["metadata"].(map[string]any)
+		name, _ := metadata["name"].(string)
+		if namespace, _ := metadata["namespace"].(string); namespace != "" {
+			name = namespace + "/" + name
 		}
+		group.Matched++
+		group.Names = append(group.Names, name)
 	}
 
-	// Add anchors for exact matching
-	regexPattern = "^" + regexPattern + "$"
+	groups := make([]searchGroup, 0, len(byKind))
+	for _, kind := range kinds {
+		if group, ok := byKind[kind]; ok {
+			groups = append(groups, *group)
+			delete(byKind, kind)
+		}
+	}
+	return groups
+}
+
+const (
+	// fuzzyMinSimilarity is the lowest similarity a name needs to be returned by fuzzy search
+	fuzzyMinSimilarity = 0.6
+	// maxSearchRegexLength bounds the size of user-supplied search patterns
+	maxSearchRegexLength = 512
+	// searchRegexMatchTimeout bounds the time spent matching a single resource name
+	searchRegexMatchTimeout = 100 * time.Millisecond
+)
 
-	// Use Go's regex for matching
-	matched, err := regexp.MatchString(regexPattern, text)
+// compileSearchRegex validates and compiles a search pattern. Matching is case-insensitive unless
+// caseSensitive is set, in which case the pattern is used as given.
+func compileSearchRegex(pattern string, caseSensitive bool) (*regexp.Regexp, error) {
+	if len(pattern) > maxSearchRegexLength {
+		return nil, fmt.Errorf("%w: pattern is %d characters, the maximum is %d", ErrInvalidRegex, len(pattern), maxSearchRegexLength)
+	}
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
 	if err != nil {
-		return false, fmt.Errorf("invalid regex pattern: %w", err)
+		return nil, fmt.Errorf("%w '%s': %v", ErrInvalidRegex, pattern, err)
+	}
+	return re, nil
+}
+
+// matchSearchRegex matches text against re, giving up after timeout. Go's RE2 engine runs in linear
+// time, so the timeout only guards against very large patterns applied to many names.
+func matchSearchRegex(re *regexp.Regexp, text string, timeout time.Duration) (bool, error) {
+	result := make(chan bool, 1)
+	go func() {
+		result <- re.MatchString(text)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case matched := <-result:
+		return matched, nil
+	case <-timer.C:
+		return false, fmt.Errorf("regex match timed out after %s", timeout)
+	}
+}
+
+// fuzzySimilarity scores how closely name matches query as 1 - editDistance/longerLength, rounded to
+// two decimals. Names whose length alone rules out reaching minSimilarity are rejected without
+// computing the distance.
+func fuzzySimilarity(query, name string, minSimilarity float64) (float64, bool) {
+	a, b := []rune(query), []rune(name)
+	longer := max(len(a), len(b))
+	if longer == 0 {
+		return 1, true
+	}
+	maxDistance := int((1 - minSimilarity) * float64(longer))
+	if abs(len(a)-len(b)) > maxDistance {
+		return 0, false
+	}
+
+	distance := levenshteinDistance(a, b, maxDistance)
+	if distance > maxDistance {
+		return 0, false
 	}
+	similarity := 1 - float64(distance)/float64(longer)
+	return math.Round(similarity*100) / 100, similarity >= minSimilarity
+}
+
+// levenshteinDistance returns the edit distance between a and b. It stops early and returns a value
+// above maxDistance as soon as every alignment exceeds it.
+func levenshteinDistance(a, b []rune, maxDistance int) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(min(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+			rowMin = min(rowMin, current[j])
+		}
+		if rowMin > maxDistance {
+			return maxDistance + 1
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
 
-	return matched, nil
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
 }
@@ -1,9 +1,18 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+
+	k8sclient "github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kubernetes/client"
 )
 
 func TestRequireRawStringParamPreservesJSON(t *testing.T) {
@@ -100,6 +109,47 @@ func TestRequireJSONObjectParamSupportsObjectAndJSONString(t *testing.T) {
 	}
 }
 
+func TestRequireJSONObjectParamSupportsYAML(t *testing.T) {
+	tests := []struct {
+		name        string
+		arg         string
+		inputFormat string
+		wantErr     string
+	}{
+		{name: "auto detects yaml", arg: "name: test-otel-debug\nlabels:\n  app: otel\n"},
+		{name: "explicit yaml", arg: "{name: test-otel-debug}", inputFormat: "yaml"},
+		{name: "json keeps json errors", arg: "{name: test-otel-debug}", wantErr: "failed to parse metadata JSON object"},
+		{name: "forced json rejects yaml", arg: "name: test-otel-debug", inputFormat: "json", wantErr: "failed to parse metadata JSON object"},
+		{name: "multiple documents", arg: "name: a\n---\nname: b\n", wantErr: "single YAML document"},
+		{name: "scalar", arg: "just-a-name", wantErr: "failed to parse metadata YAML object"},
+		{name: "unknown format", arg: "name: a", inputFormat: "toml", wantErr: "unsupported inputFormat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]interface{}{"metadata": tt.arg}
+			if tt.inputFormat != "" {
+				args["inputFormat"] = tt.inputFormat
+			}
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+
+			got, err := requireJSONObjectParam(req, "metadata")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("requireJSONObjectParam error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("requireJSONObjectParam returned error: %v", err)
+			}
+			if got["name"] != "test-otel-debug" {
+				t.Fatalf("requireJSONObjectParam name = %v, want test-otel-debug", got["name"])
+			}
+		})
+	}
+}
+
 func TestRequireRawJSONParamSupportsObjectArrayAndString(t *testing.T) {
 	tests := []struct {
 		name string
@@ -342,3 +392,607 @@ func TestHasCreateIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOutputFormatParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "default", args: map[string]interface{}{}, want: OutputFormatJSON},
+		{name: "yaml", args: map[string]interface{}{"outputFormat": "YAML"}, want: OutputFormatYAML},
+		{name: "unsupported", args: map[string]interface{}{"outputFormat": "xml"}, wantErr: true},
+		{name: "ndjson only for list tools", args: map[string]interface{}{"outputFormat": "ndjson"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tt.args}}
+			got, err := getOutputFormatParam(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getOutputFormatParam error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("getOutputFormatParam = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalYAMLDocumentsSeparatesItems(t *testing.T) {
+	items := []map[string]any{
+		{"kind": "Pod", "metadata": map[string]any{"name": "a"}},
+		{"kind": "Pod", "metadata": map[string]any{"name": "b"}},
+	}
+
+	result, err := marshalYAMLDocuments(items, "count: 2")
+	if err != nil {
+		t.Fatalf("marshalYAMLDocuments returned error: %v", err)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	want := "# count: 2\n---\nkind: Pod\nmetadata:\n  name: a\n---\nkind: Pod\nmetadata:\n  name: b\n"
+	if text != want {
+		t.Fatalf("marshalYAMLDocuments = %q, want %q", text, want)
+	}
+}
+
+func TestGetOutputFormatParamOfListFormats(t *testing.T) {
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"outputFormat": "NDJSON"}}}
+	got, err := getOutputFormatParamOf(req, OutputFormatJSON, OutputFormatYAML, OutputFormatNDJSON)
+	if err != nil || got != OutputFormatNDJSON {
+		t.Fatalf("getOutputFormatParamOf = %q, %v; want ndjson", got, err)
+	}
+
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"outputFormat": "yaml"}}}
+	_, err = getOutputFormatParamOf(req, OutputFormatJSON, OutputFormatNDJSON)
+	if err == nil || !strings.Contains(err.Error(), `expected "json" or "ndjson"`) {
+		t.Fatalf("expected yaml to be rejected listing the supported formats, got %v", err)
+	}
+}
+
+func TestMarshalNDJSONEmitsItemsAndControlLine(t *testing.T) {
+	items := []map[string]any{
+		{"kind": "Pod", "metadata": map[string]any{"name": "a"}},
+		{"kind": "Pod", "metadata": map[string]any{"name": "b"}},
+	}
+
+	result, err := marshalNDJSON(items, &PaginationInfo{HasMore: true, ContinueToken: "next", RemainingCount: 3})
+	if err != nil {
+		t.Fatalf("marshalNDJSON returned error: %v", err)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected two item lines and a control line, got %q", text)
+	}
+	if lines[0] != `{"kind":"Pod","metadata":{"name":"a"}}` {
+		t.Fatalf("unexpected first line %q", lines[0])
+	}
+
+	var control map[string]any
+	if err := json.Unmarshal([]byte(lines[2]), &control); err != nil {
+		t.Fatalf("control line is not JSON: %v", err)
+	}
+	pagination, _ := control["pagination"].(map[string]any)
+	if control[ndjsonControlKey] != "pagination" || control["count"] != float64(2) || pagination["continueToken"] != "next" {
+		t.Fatalf("unexpected control line %v", control)
+	}
+	if _, truncated := control["truncated"]; truncated {
+		t.Fatalf("did not expect a small page to be truncated: %v", control)
+	}
+}
+
+func TestMarshalSummaryCSVEscapesFieldsAndAddsLabelColumns(t *testing.T) {
+	summaries := []map[string]any{
+		{"kind": "Deployment", "namespace": "shop", "name": "web", "age": "2d", "labels": map[string]string{"app": "web", "team": `payments, "core"`}},
+		{"kind": "Pod", "name": "web-1", "status": "Running", "age": "5h", "matchScore": 0.875},
+	}
+
+	result, err := marshalSummaryCSV(summaries, []string{"team", "app"}, &PaginationInfo{HasMore: true, ContinueToken: "2"})
+	if err != nil {
+		t.Fatalf("marshalSummaryCSV returned error: %v", err)
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("expected a CSV block and a pagination block, got %d blocks", len(result.Content))
+	}
+
+	want := "kind,namespace,name,status,age,matchScore,labels.team,labels.app\n" +
+		"Deployment,shop,web,,2d,,\"payments, \"\"core\"\"\",web\n" +
+		"Pod,,web-1,Running,5h,0.875,,\n"
+	if text := result.Content[0].(mcp.TextContent).Text; text != want {
+		t.Fatalf("unexpected CSV:\n%s\nwant:\n%s", text, want)
+	}
+
+	var control map[string]any
+	if err := json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &control); err != nil {
+		t.Fatalf("pagination block is not JSON: %v", err)
+	}
+	pagination, _ := control["pagination"].(map[string]any)
+	if control["count"] != float64(2) || pagination["continueToken"] != "2" {
+		t.Fatalf("unexpected pagination block %v", control)
+	}
+
+	result, err = marshalSummaryCSV(summaries[:1], nil, nil)
+	if err != nil {
+		t.Fatalf("marshalSummaryCSV returned error: %v", err)
+	}
+	want = "kind,namespace,name,status,age,labels\n" +
+		"Deployment,shop,web,,2d,\"app=web,team=payments, \"\"core\"\"\"\n"
+	if text := result.Content[0].(mcp.TextContent).Text; text != want {
+		t.Fatalf("unexpected CSV without label keys:\n%s\nwant:\n%s", text, want)
+	}
+}
+
+func TestMarshalNDJSONStopsAtSizeLimit(t *testing.T) {
+	large := strings.Repeat("x", MaxResponseSize/2)
+	items := []map[string]any{{"data": large}, {"data": large}, {"data": large}}
+
+	result, err := marshalNDJSON(items, nil)
+	if err != nil {
+		t.Fatalf("marshalNDJSON returned error: %v", err)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	var control map[string]any
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &control); err != nil {
+		t.Fatalf("control line is not JSON: %v", err)
+	}
+	if control["truncated"] != true || control["count"] != float64(2) || control["omittedCount"] != float64(1) {
+		t.Fatalf("expected the third item to be left out, got %v", control)
+	}
+}
+
+func TestJSONPathRowObjects(t *testing.T) {
+	resources := []map[string]any{{"metadata": map[string]any{"name": "web"}, "status": map[string]any{"phase": "Running"}}}
+	rows := jsonPathRowObjects(resources, []string{"metadata.name", "status.phase"})
+	if len(rows) != 1 || rows[0]["metadata.name"] != "web" || rows[0]["status.phase"] != "Running" {
+		t.Fatalf("unexpected row objects %v", rows)
+	}
+}
+
+func TestValidateJSONPathExpression(t *testing.T) {
+	if err := validateJSONPathExpression("metadata.name"); err != nil {
+		t.Fatalf("expected bare path to be valid, got %v", err)
+	}
+	err := validateJSONPathExpression("{.metadata.name")
+	if err == nil {
+		t.Fatal("expected unterminated expression to be rejected")
+	}
+	if !errors.Is(err, ErrInvalidJSONPath) {
+		t.Fatalf("expected ErrInvalidJSONPath, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "{.metadata.name") {
+		t.Fatalf("expected error to include the offending expression, got %q", err.Error())
+	}
+}
+
+func TestBuildJSONPathTableKeepsRowsWithMissingFields(t *testing.T) {
+	resources := []map[string]any{
+		{"metadata": map[string]any{"name": "a"}, "status": map[string]any{"phase": "Running"}},
+		{"metadata": map[string]any{"name": "b"}},
+	}
+
+	columns, rows, table := buildJSONPathTable(resources, []string{"{.metadata.name}", "status.phase"})
+
+	if want := []string{"metadata.name", "status.phase"}; !reflect.DeepEqual(columns, want) {
+		t.Fatalf("columns = %v, want %v", columns, want)
+	}
+	if want := [][]string{{"a", "Running"}, {"b", ""}}; !reflect.DeepEqual(rows, want) {
+		t.Fatalf("rows = %v, want %v", rows, want)
+	}
+	if want := "metadata.name\tstatus.phase\na\tRunning\nb\t"; table != want {
+		t.Fatalf("table = %q, want %q", table, want)
+	}
+}
+
+func TestOffsetPaginationRoundTrip(t *testing.T) {
+	info := offsetPagination(20, 45, 20)
+	got := paginationResponse(info, 20)
+	want := map[string]any{
+		"hasMore":         true,
+		"continueToken":   "20",
+		"returnedCount":   20,
+		"remainingCount":  int64(25),
+		"currentPageSize": int64(20),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("pagination = %v, want %v", got, want)
+	}
+
+	offset, err := parseOffsetContinueToken(info.ContinueToken)
+	if err != nil || offset != 20 {
+		t.Fatalf("expected offset 20, got %d (%v)", offset, err)
+	}
+	if last := offsetPagination(45, 45, 5); last.HasMore || last.ContinueToken != "" {
+		t.Fatalf("expected last page to have no continue token, got %+v", last)
+	}
+	if _, err := parseOffsetContinueToken("abc"); err == nil {
+		t.Fatal("expected invalid token to be rejected")
+	}
+}
+
+func TestGetLimitParamAcceptsNumbersAndNumericStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit any
+		want  int64
+	}{
+		{name: "json number", limit: float64(25), want: 25},
+		{name: "numeric string", limit: "25", want: 25},
+		{name: "padded string", limit: " 40 ", want: 40},
+		{name: "decimal string", limit: "12.0", want: 12},
+		{name: "number above max", limit: float64(500), want: 100},
+		{name: "string above max", limit: "500", want: 100},
+		{name: "zero", limit: float64(0), want: 20},
+		{name: "negative string", limit: "-5", want: 20},
+		{name: "non-numeric string", limit: "lots", want: 20},
+		{name: "missing", want: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]interface{}{}
+			if tt.limit != nil {
+				args["limit"] = tt.limit
+			}
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+			if got := getLimitParam(req, "test_tool", 20, 100, 50); got != tt.want {
+				t.Fatalf("getLimitParam(%#v) = %d, want %d", tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetInt64ParamAcceptsNumericStrings(t *testing.T) {
+	req := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"tailLines": "150",
+				"replicas":  float64(3),
+			},
+		},
+	}
+
+	if got := getInt64Param(req, "tailLines", 50); got != 150 {
+		t.Fatalf("getInt64Param(tailLines) = %d, want 150", got)
+	}
+	if got := getInt32Param(req, "replicas", 1); got != 3 {
+		t.Fatalf("getInt32Param(replicas) = %d, want 3", got)
+	}
+}
+
+func TestGetEventSinceParam(t *testing.T) {
+	request := func(args map[string]interface{}) mcp.CallToolRequest {
+		return mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	}
+
+	since, err := getEventSinceParam(request(map[string]interface{}{}))
+	if err != nil || !since.IsZero() {
+		t.Fatalf("expected no lower bound by default, got %v, %v", since, err)
+	}
+
+	since, err = getEventSinceParam(request(map[string]interface{}{"sinceMinutes": float64(15)}))
+	if err != nil {
+		t.Fatalf("getEventSinceParam(sinceMinutes) error = %v", err)
+	}
+	if age := time.Since(since); age < 15*time.Minute || age > 16*time.Minute {
+		t.Fatalf("expected a cutoff 15 minutes ago, got %v", since)
+	}
+
+	since, err = getEventSinceParam(request(map[string]interface{}{"sinceTime": "2024-01-02T15:04:05Z"}))
+	if err != nil || !since.Equal(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)) {
+		t.Fatalf("getEventSinceParam(sinceTime) = %v, %v", since, err)
+	}
+
+	for _, args := range []map[string]interface{}{
+		{"sinceMinutes": float64(-1)},
+		{"sinceTime": "yesterday"},
+		{"sinceMinutes": float64(5), "sinceTime": "2024-01-02T15:04:05Z"},
+	} {
+		if _, err := getEventSinceParam(request(args)); err == nil {
+			t.Fatalf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestExecToolResult(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      k8sclient.ExecResult
+		wantIsError bool
+	}{
+		{name: "success", result: k8sclient.ExecResult{Stdout: "ok\n"}},
+		{name: "non-zero exit", result: k8sclient.ExecResult{Stderr: "not found\n", ExitCode: 2}, wantIsError: true},
+		{name: "stream error", result: k8sclient.ExecResult{Stdout: "partial", Error: "connection reset"}, wantIsError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := execToolResult(&tt.result)
+			if err != nil {
+				t.Fatalf("execToolResult returned error: %v", err)
+			}
+			if got.IsError != tt.wantIsError {
+				t.Fatalf("IsError = %v, want %v", got.IsError, tt.wantIsError)
+			}
+			text := got.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, `"stdout"`) || !strings.Contains(text, `"stderr"`) || !strings.Contains(text, `"exitCode"`) {
+				t.Fatalf("expected separate stdout, stderr and exitCode fields, got %s", text)
+			}
+		})
+	}
+}
+
+func TestCompileSearchRegex(t *testing.T) {
+	invalid := []string{"nginx(", "[a-z", "*web", "a{2,1}", strings.Repeat("a", maxSearchRegexLength+1)}
+	for _, pattern := range invalid {
+		if _, err := compileSearchRegex(pattern, true); !errors.Is(err, ErrInvalidRegex) {
+			t.Fatalf("compileSearchRegex(%q) error = %v, want ErrInvalidRegex", pattern, err)
+		}
+	}
+
+	tests := []struct {
+		pattern       string
+		caseSensitive bool
+		name          string
+		want          bool
+	}{
+		{pattern: "^api-[0-9]+$", caseSensitive: true, name: "api-12", want: true},
+		{pattern: "^api-[0-9]+$", caseSensitive: true, name: "api-12-canary", want: false},
+		{pattern: "web|worker", caseSensitive: true, name: "queue-worker", want: true},
+		{pattern: "^API", caseSensitive: true, name: "api-gateway", want: false},
+		{pattern: "^API", caseSensitive: false, name: "api-gateway", want: true},
+		{pattern: `\D+-\d`, caseSensitive: false, name: "web-1", want: true},
+	}
+	for _, tt := range tests {
+		re, err := compileSearchRegex(tt.pattern, tt.caseSensitive)
+		if err != nil {
+			t.Fatalf("compileSearchRegex(%q) error = %v", tt.pattern, err)
+		}
+		got, err := matchSearchRegex(re, tt.name, time.Second)
+		if err != nil {
+			t.Fatalf("matchSearchRegex(%q, %q) error = %v", tt.pattern, tt.name, err)
+		}
+		if got != tt.want {
+			t.Fatalf("matchSearchRegex(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzySimilarity(t *testing.T) {
+	tests := []struct {
+		query     string
+		name      string
+		wantScore float64
+		wantMatch bool
+	}{
+		{query: "payment-api", name: "payment-api", wantScore: 1, wantMatch: true},
+		{query: "paymnet-api", name: "payment-api", wantScore: 0.82, wantMatch: true},
+		{query: "payment", name: "payments", wantScore: 0.88, wantMatch: true},
+		{query: "payment-api", name: "ingress-nginx-controller", wantMatch: false},
+		{query: "api", name: "web", wantMatch: false},
+	}
+	for _, tt := range tests {
+		score, matched := fuzzySimilarity(tt.query, tt.name, fuzzyMinSimilarity)
+		if matched != tt.wantMatch || (tt.wantMatch && score != tt.wantScore) {
+			t.Fatalf("fuzzySimilarity(%q, %q) = (%v, %v), want (%v, %v)", tt.query, tt.name, score, matched, tt.wantScore, tt.wantMatch)
+		}
+	}
+
+	if got := levenshteinDistance([]rune("kitten"), []rune("sitting"), 10); got != 3 {
+		t.Fatalf("levenshteinDistance(kitten, sitting) = %d, want 3", got)
+	}
+	if got := levenshteinDistance([]rune("kitten"), []rune("sitting"), 1); got != 2 {
+		t.Fatalf("expected the bounded distance to stop at maxDistance+1, got %d", got)
+	}
+}
+
+func TestGetOptionalSearchKindsPrefersKinds(t *testing.T) {
+	req := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"kinds":         []interface{}{"Pod", "Service", "Pod", " "},
+				"resourceTypes": []interface{}{"ConfigMap"},
+			},
+		},
+	}
+
+	got, err := getOptionalSearchKinds(req)
+	if err != nil {
+		t.Fatalf("getOptionalSearchKinds returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"Pod", "Service"}) {
+		t.Fatalf("getOptionalSearchKinds = %v, want [Pod Service]", got)
+	}
+}
+
+func TestGroupSearchMatches(t *testing.T) {
+	resource := func(namespace, name string) map[string]any {
+		metadata := map[string]any{"name": name}
+		if namespace != "" {
+			metadata["namespace"] = namespace
+		}
+		return map[string]any{"metadata": metadata}
+	}
+	matches := []searchMatch{
+		{kind: "Service", resource: resource("shop", "checkout")},
+		{kind: "Pod", resource: resource("shop", "checkout-7d9f")},
+		{kind: "Service", resource: resource("shop", "checkout-canary")},
+		{kind: "ClusterRole", resource: resource("", "checkout-reader")},
+	}
+
+	got := groupSearchMatches([]string{"Pod", "Deployment", "Service", "ClusterRole"}, matches)
+	want := []searchGroup{
+		{Kind: "Pod", Matched: 1, Names: []string{"shop/checkout-7d9f"}},
+		{Kind: "Service", Matched: 2, Names: []string{"shop/checkout", "shop/checkout-canary"}},
+		{Kind: "ClusterRole", Matched: 1, Names: []string{"checkout-reader"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("groupSearchMatches = %+v, want %+v", got, want)
+	}
+}
+
+func TestChunkStrings(t *testing.T) {
+	values := []string{"a", "b", "c", "d", "e"}
+	got := chunkStrings(values, 2)
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("chunkStrings = %v, want %v", got, want)
+	}
+	if got := chunkStrings(nil, 2); len(got) != 0 {
+		t.Fatalf("chunkStrings(nil) = %v, want no chunks", got)
+	}
+}
+
+func TestAmbiguousKindResponse(t *testing.T) {
+	err := fmt.Errorf("lookup failed: %w", &k8sclient.AmbiguousKindError{
+		Kind:        "Application",
+		APIVersions: []string{"app.k8s.io/v1beta1", "argoproj.io/v1alpha1"},
+	})
+	got, ok := ambiguousKindResponse(err)
+	if !ok || !got.IsError {
+		t.Fatalf("expected an error result for an ambiguous kind, got %+v", got)
+	}
+	text := got.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, `"candidateApiVersions":["app.k8s.io/v1beta1","argoproj.io/v1alpha1"]`) {
+		t.Fatalf("expected candidate apiVersions in the response, got %s", text)
+	}
+
+	if _, ok := ambiguousKindResponse(errors.New("resource kind \"Widget\" not found")); ok {
+		t.Fatal("expected other errors to be left alone")
+	}
+}
+
+func TestWithToolTimeout(t *testing.T) {
+	SetMaxToolTimeout(20 * time.Millisecond)
+	defer SetMaxToolTimeout(0)
+
+	var sawDeadline bool
+	handler := WithToolTimeout("test_tool", func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		_, sawDeadline = ctx.Deadline()
+		if !sawDeadline {
+			return mcp.NewToolResultText("done"), nil
+		}
+		<-ctx.Done()
+		return nil, fmt.Errorf("list failed: %w", ctx.Err())
+	})
+	request := func(args map[string]any) mcp.CallToolRequest {
+		return mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	}
+
+	result, err := handler(context.Background(), request(map[string]any{}))
+	if err != nil || result.IsError || sawDeadline {
+		t.Fatalf("expected an unbounded call without timeoutSeconds, got %+v, %v", result, err)
+	}
+
+	// 60s is above the 20ms maximum, so the call is cut short at the maximum
+	result, err = handler(context.Background(), request(map[string]any{"timeoutSeconds": 60}))
+	if err != nil || !result.IsError {
+		t.Fatalf("expected a timeout error result, got %+v, %v", result, err)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; text != "operation timed out after 20ms" {
+		t.Fatalf("unexpected timeout message %q", text)
+	}
+
+	if result, _ := handler(context.Background(), request(map[string]any{"timeoutSeconds": -1})); !result.IsError {
+		t.Fatal("expected a negative timeout to be rejected")
+	}
+}
+
+func TestFieldPruning(t *testing.T) {
+	deployment := func() map[string]any {
+		return map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":          "web",
+				"namespace":     "shop",
+				"labels":        map[string]any{"app": "web"},
+				"managedFields": []any{map[string]any{"manager": "kubectl"}},
+			},
+			"spec": map[string]any{
+				"replicas": int64(3),
+				"template": map[string]any{"spec": map[string]any{"containers": []any{
+					map[string]any{"name": "app", "image": "web:1", "env": []any{map[string]any{"name": "MODE"}}},
+					map[string]any{"name": "proxy", "image": "envoy:1"},
+				}}},
+			},
+			"status": map[string]any{"readyReplicas": int64(3), "conditions": []any{map[string]any{"type": "Available"}}},
+		}
+	}
+
+	keep, err := parseFieldPaths("fields", []string{"spec.replicas", "spec.template.spec.containers.image", "spec.template.spec.containers.name", "missing.path"})
+	if err != nil {
+		t.Fatalf("parseFieldPaths() error = %v", err)
+	}
+	kept := keepFields(deployment(), keep)
+	want := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": "shop"},
+		"spec": map[string]any{
+			"replicas": int64(3),
+			"template": map[string]any{"spec": map[string]any{"containers": []any{
+				map[string]any{"name": "app", "image": "web:1"},
+				map[string]any{"name": "proxy", "image": "envoy:1"},
+			}}},
+		},
+	}
+	if !reflect.DeepEqual(kept, want) {
+		t.Fatalf("keepFields() = %#v, want %#v", kept, want)
+	}
+
+	drop, err := parseFieldPaths("dropFields", []string{"metadata.managedFields", "status.conditions", "spec.template.spec.containers.env"})
+	if err != nil {
+		t.Fatalf("parseFieldPaths() error = %v", err)
+	}
+	obj := deployment()
+	dropFields(obj, drop)
+	if _, ok := obj["metadata"].(map[string]any)["managedFields"]; ok {
+		t.Fatal("expected metadata.managedFields to be dropped")
+	}
+	if status := obj["status"].(map[string]any); status["conditions"] != nil || status["readyReplicas"] != int64(3) {
+		t.Fatalf("expected only status.conditions to be dropped, got %+v", status)
+	}
+	container := obj["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)
+	if _, ok := container["env"]; ok || container["image"] != "web:1" {
+		t.Fatalf("expected env to be dropped from every container, got %+v", container)
+	}
+
+	if _, err := parseFieldPaths("fields", []string{"spec..replicas"}); err == nil {
+		t.Fatal("expected an empty path segment to be rejected")
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	pod := map[string]any{
+		"spec": map[string]any{"containers": []any{
+			map[string]any{"name": "app", "image": "web:1"},
+			map[string]any{"name": "proxy", "image": "envoy:1"},
+		}},
+		"status": map[string]any{"phase": "Running"},
+	}
+	paths, err := parseFieldPaths("fields", []string{"status.phase", "spec.containers.image", "spec.containers.1.name", "spec.replicas", "spec.containers.5.name"})
+	if err != nil {
+		t.Fatalf("parseFieldPaths() error = %v", err)
+	}
+
+	values, missing := projectFields(pod, paths)
+	want := map[string]any{
+		"status.phase":           "Running",
+		"spec.containers.image":  []any{"web:1", "envoy:1"},
+		"spec.containers.1.name": "proxy",
+		"spec.replicas":          nil,
+		"spec.containers.5.name": nil,
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("projectFields() values = %#v, want %#v", values, want)
+	}
+	if !reflect.DeepEqual(missing, []string{"spec.replicas", "spec.containers.5.name"}) {
+		t.Fatalf("projectFields() missing = %v", missing)
+	}
+}
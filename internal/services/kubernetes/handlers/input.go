@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// InputFormatAuto decodes object arguments given as text as JSON when they start with '{', as YAML otherwise (default)
+	InputFormatAuto = "auto"
+	// InputFormatJSON decodes object arguments given as text as JSON
+	InputFormatJSON = "json"
+	// InputFormatYAML decodes object arguments given as text as YAML, as pasted from kubectl
+	InputFormatYAML = "yaml"
+)
+
+// getInputFormatParam reads the inputFormat argument and validates it against the supported encodings
+func getInputFormatParam(request mcp.CallToolRequest) (string, error) {
+	format := strings.ToLower(getOptionalStringParam(request, "inputFormat"))
+	switch format {
+	case "":
+		return InputFormatAuto, nil
+	case InputFormatAuto, InputFormatJSON, InputFormatYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported inputFormat %q: expected %q, %q or %q", format, InputFormatAuto, InputFormatJSON, InputFormatYAML)
+	}
+}
+
+// decodeObjectText decodes an object argument given as JSON or YAML text. In auto mode text that
+// starts with '{' is decoded as JSON exactly as before YAML was accepted, so JSON errors are unchanged.
+func decodeObjectText(param, text, format string) (map[string]any, error) {
+	if format == InputFormatJSON || format == InputFormatAuto && strings.HasPrefix(strings.TrimSpace(text), "{") {
+		var result map[string]any
+		if err := json.Unmarshal([]byte(text), &result); err != nil {
+			return nil, fmt.Errorf("failed to parse %s JSON object: %w", param, err)
+		}
+		return result, nil
+	}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(text), 4096)
+	var result map[string]any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse %s YAML object: %w", param, err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("%s must be a YAML or JSON object", param)
+	}
+	var next map[string]any
+	if err := decoder.Decode(&next); !errors.Is(err, io.EOF) && (err != nil || len(next) > 0) {
+		return nil, fmt.Errorf("%s must hold a single YAML document", param)
+	}
+	return result, nil
+}
@@ -0,0 +1,401 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// OutputFormatJSON renders tool results as JSON (default)
+	OutputFormatJSON = "json"
+	// OutputFormatYAML renders tool results as YAML documents
+	OutputFormatYAML = "yaml"
+	// OutputFormatNDJSON renders list results as one JSON object per line, ending with a control line
+	OutputFormatNDJSON = "ndjson"
+	// OutputFormatCSV renders resource summaries as a header row and one CSV row per resource
+	OutputFormatCSV = "csv"
+
+	// ndjsonControlKey marks the trailing control line of ndjson output so it can be told apart from items
+	ndjsonControlKey = "_control"
+
+	// describeOutputText returns the described resource as a whole (default)
+	describeOutputText = "text"
+	// describeOutputStructured returns the parsed describe sections
+	describeOutputStructured = "structured"
+)
+
+// getOutputFormatParam reads the outputFormat argument and validates it against the supported encodings
+func getOutputFormatParam(request mcp.CallToolRequest) (string, error) {
+	return getOutputFormatParamOf(request, OutputFormatJSON, OutputFormatYAML)
+}
+
+// getOutputFormatParamOf reads the outputFormat argument and validates it against formats. The first
+// format is the default.
+func getOutputFormatParamOf(request mcp.CallToolRequest, formats ...string) (string, error) {
+	format := strings.ToLower(getOptionalStringParam(request, "outputFormat"))
+	if format == "" {
+		return formats[0], nil
+	}
+	for _, supported := range formats {
+		if format == supported {
+			return format, nil
+		}
+	}
+	quoted := make([]string, len(formats))
+	for i, supported := range formats {
+		quoted[i] = fmt.Sprintf("%q", supported)
+	}
+	last := len(quoted) - 1
+	return "", fmt.Errorf("unsupported outputFormat %q: expected %s or %s", format, strings.Join(quoted[:last], ", "), quoted[last])
+}
+
+// marshalYAMLResponse renders a single object as a YAML document
+func marshalYAMLResponse(data any) (*mcp.CallToolResult, error) {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize response as YAML: %w", err)
+	}
+	return mcp.NewToolResultText(string(out)), nil
+}
+
+// marshalYAMLDocuments renders each item as its own YAML document separated by `---`.
+// Optional header lines are emitted as YAML comments before the first document.
+func marshalYAMLDocuments(items []map[string]any, header ...string) (*mcp.CallToolResult, error) {
+	var buf bytes.Buffer
+	for _, line := range header {
+		buf.WriteString("# ")
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	for i, item := range items {
+		out, err := yaml.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize item %d as YAML: %w", i, err)
+		}
+		buf.WriteString("---\n")
+		buf.Write(out)
+	}
+	return mcp.NewToolResultText(buf.String()), nil
+}
+
+// marshalNDJSON renders each item as one compact JSON line and ends with a control line carrying the
+// count and pagination, so clients can process items as they arrive. Items stop once the output
+// reaches MaxResponseSize; the control line then reports how many were left out, because the
+// continue token only covers the items after this page.
+func marshalNDJSON(items []map[string]any, info *PaginationInfo) (*mcp.CallToolResult, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	written := 0
+	for i, item := range items {
+		if buf.Len() >= MaxResponseSize {
+			break
+		}
+		if err := encoder.Encode(item); err != nil {
+			return nil, fmt.Errorf("failed to serialize item %d as JSON: %w", i, err)
+		}
+		written++
+	}
+
+	control := map[string]any{
+		ndjsonControlKey: "pagination",
+		"count":          written,
+		"pagination":     paginationResponse(info, written),
+	}
+	if omitted := len(items) - written; omitted > 0 {
+		control["truncated"] = true
+		control["omittedCount"] = omitted
+		control["message"] = fmt.Sprintf("output reached %d bytes; %d items of this page were left out, request a smaller limit", MaxResponseSize, omitted)
+	}
+	if err := encoder.Encode(control); err != nil {
+		return nil, fmt.Errorf("failed to serialize ndjson control line: %w", err)
+	}
+	return mcp.NewToolResultText(buf.String()), nil
+}
+
+// csvSummaryColumns are the summary fields written as CSV columns, in order
+var csvSummaryColumns = []string{"kind", "namespace", "name", "status", "age"}
+
+// marshalSummaryCSV renders resource summaries as CSV for spreadsheets: a header row, then one row per
+// summary. Each of labelKeys becomes a "labels.<key>" column; without them the labels of a summary are
+// joined into a single "labels" column as key=value pairs. A "matchScore" column is added when a
+// summary has one. The CSV is the first content block; the second is a JSON object with the count and
+// pagination, so the first can be pasted as is. Rows stop once the output reaches MaxResponseSize.
+func marshalSummaryCSV(summaries []map[string]any, labelKeys []string, info *PaginationInfo) (*mcp.CallToolResult, error) {
+	header := append([]string{}, csvSummaryColumns...)
+	scored := false
+	for _, summary := range summaries {
+		if _, ok := summary["matchScore"]; ok {
+			scored = true
+			break
+		}
+	}
+	if scored {
+		header = append(header, "matchScore")
+	}
+	if len(labelKeys) > 0 {
+		for _, key := range labelKeys {
+			header = append(header, "labels."+key)
+		}
+	} else {
+		header = append(header, "labels")
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	written := 0
+	for _, summary := range summaries {
+		writer.Flush()
+		if buf.Len() >= MaxResponseSize {
+			break
+		}
+		row := make([]string, 0, len(header))
+		for _, column := range csvSummaryColumns {
+			row = append(row, csvValue(summary[column]))
+		}
+		if scored {
+			row = append(row, csvValue(summary["matchScore"]))
+		}
+		labels := summaryLabels(summary)
+		if len(labelKeys) > 0 {
+			for _, key := range labelKeys {
+				row = append(row, labels[key])
+			}
+		} else {
+			row = append(row, joinLabels(labels))
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row %d: %w", written, err)
+		}
+		written++
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to serialize response as CSV: %w", err)
+	}
+
+	control := map[string]any{
+		"count":      written,
+		"pagination": paginationResponse(info, written),
+	}
+	if omitted := len(summaries) - written; omitted > 0 {
+		control["truncated"] = true
+		control["omittedCount"] = omitted
+		control["message"] = fmt.Sprintf("output reached %d bytes; %d items of this page were left out, request a smaller limit", MaxResponseSize, omitted)
+	}
+	controlJSON, err := json.Marshal(control)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize CSV pagination: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{
+		mcp.NewTextContent(buf.String()),
+		mcp.NewTextContent(string(controlJSON)),
+	}}, nil
+}
+
+// summaryLabels returns the labels of a summary, whichever map type they were stored as
+func summaryLabels(summary map[string]any) map[string]string {
+	switch labels := summary["labels"].(type) {
+	case map[string]string:
+		return labels
+	case map[string]any:
+		converted := make(map[string]string, len(labels))
+		for key, value := range labels {
+			converted[key] = fmt.Sprint(value)
+		}
+		return converted
+	}
+	return nil
+}
+
+// joinLabels renders labels as comma-separated key=value pairs sorted by key
+func joinLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// csvValue renders a summary field as a CSV cell; missing fields are empty
+func csvValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		if data, err := json.Marshal(typed); err == nil {
+			return string(data)
+		}
+		return fmt.Sprint(typed)
+	}
+}
+
+// fieldPath is a dotted field path split into its segments
+type fieldPath []string
+
+// parseFieldPaths validates dotted paths such as "spec.template.spec.containers.image". A segment
+// that lands on a list applies to every element of the list.
+func parseFieldPaths(param string, paths []string) ([]fieldPath, error) {
+	parsed := make([]fieldPath, 0, len(paths))
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid %s path %q: empty segment", param, path)
+			}
+		}
+		parsed = append(parsed, segments)
+	}
+	return parsed, nil
+}
+
+// identityFields are always kept by keepFields so pruned objects can still be told apart
+var identityFields = []fieldPath{{"apiVersion"}, {"kind"}, {"metadata", "name"}, {"metadata", "namespace"}}
+
+// keepFields returns a copy of obj holding only the given paths and the object's identity fields
+func keepFields(obj map[string]any, paths []fieldPath) map[string]any {
+	kept := map[string]any{}
+	for _, path := range append(append([]fieldPath{}, identityFields...), paths...) {
+		copyPath(kept, obj, path)
+	}
+	return kept
+}
+
+// copyPath copies the value at path from src into dst, creating intermediate maps and lists
+func copyPath(dst, src map[string]any, path fieldPath) {
+	value, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		dst[path[0]] = value
+		return
+	}
+	switch typed := value.(type) {
+	case map[string]any:
+		child, _ := dst[path[0]].(map[string]any)
+		if child == nil {
+			child = map[string]any{}
+		}
+		copyPath(child, typed, path[1:])
+		if len(child) > 0 {
+			dst[path[0]] = child
+		}
+	case []any:
+		list, _ := dst[path[0]].([]any)
+		if len(list) != len(typed) {
+			list = make([]any, len(typed))
+		}
+		copied := false
+		for i, item := range typed {
+			itemMap, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			child, _ := list[i].(map[string]any)
+			if child == nil {
+				child = map[string]any{}
+			}
+			copyPath(child, itemMap, path[1:])
+			list[i] = child
+			copied = copied || len(child) > 0
+		}
+		if copied {
+			dst[path[0]] = list
+		}
+	}
+}
+
+// dropFields removes the given paths from obj in place
+func dropFields(obj map[string]any, paths []fieldPath) {
+	for _, path := range paths {
+		dropPath(obj, path)
+	}
+}
+
+func dropPath(obj map[string]any, path fieldPath) {
+	if len(path) == 1 {
+		delete(obj, path[0])
+		return
+	}
+	switch typed := obj[path[0]].(type) {
+	case map[string]any:
+		dropPath(typed, path[1:])
+	case []any:
+		for _, item := range typed {
+			if itemMap, ok := item.(map[string]any); ok {
+				dropPath(itemMap, path[1:])
+			}
+		}
+	}
+}
+
+// projectFields reads each path from obj into a flat map keyed by the dotted path. Missing paths map to
+// nil and are also returned in order so callers can point them out.
+func projectFields(obj map[string]any, paths []fieldPath) (map[string]any, []string) {
+	values := make(map[string]any, len(paths))
+	var missing []string
+	for _, path := range paths {
+		key := strings.Join(path, ".")
+		value, ok := lookupPath(obj, path)
+		if !ok {
+			missing = append(missing, key)
+			value = nil
+		}
+		values[key] = value
+	}
+	return values, missing
+}
+
+// lookupPath returns the value at path. A numeric segment indexes into a list; any other segment that
+// lands on a list applies to every element and yields the values of the elements that have it.
+func lookupPath(value any, path fieldPath) (any, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+	switch typed := value.(type) {
+	case map[string]any:
+		child, ok := typed[path[0]]
+		if !ok {
+			return nil, false
+		}
+		return lookupPath(child, path[1:])
+	case []any:
+		if index, err := strconv.Atoi(path[0]); err == nil {
+			if index < 0 || index >= len(typed) {
+				return nil, false
+			}
+			return lookupPath(typed[index], path[1:])
+		}
+		found := make([]any, 0, len(typed))
+		for _, item := range typed {
+			if child, ok := lookupPath(item, path); ok {
+				found = append(found, child)
+			}
+		}
+		return found, len(found) > 0
+	default:
+		return nil, false
+	}
+}
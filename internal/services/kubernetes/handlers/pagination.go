@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// paginationResponse builds the "pagination" object returned by every paginated list tool:
+//
+//	{"hasMore": bool, "continueToken": string, "returnedCount": int, "remainingCount": int, "currentPageSize": int}
+//
+// Callers page by passing continueToken back unchanged until hasMore is false. returnedCount is the
+// number of items in this response; remainingCount is only an estimate and may be 0 when unknown.
+func paginationResponse(info *PaginationInfo, returnedCount int) map[string]any {
+	if info == nil {
+		info = &PaginationInfo{}
+	}
+	return map[string]any{
+		"hasMore":         info.HasMore,
+		"continueToken":   info.ContinueToken,
+		"returnedCount":   returnedCount,
+		"remainingCount":  info.RemainingCount,
+		"currentPageSize": info.CurrentPageSize,
+	}
+}
+
+// offsetPagination describes a page cut from a fully materialized result set. The continue token
+// is the offset of the next item, so it remains valid only while the underlying result is stable.
+func offsetPagination(end, total, returnedCount int) *PaginationInfo {
+	info := &PaginationInfo{
+		RemainingCount:  int64(max(total-end, 0)),
+		CurrentPageSize: int64(returnedCount),
+	}
+	if end < total {
+		info.ContinueToken = strconv.Itoa(end)
+		info.HasMore = true
+	}
+	return info
+}
+
+// parseOffsetContinueToken parses a continue token produced by offsetPagination
+func parseOffsetContinueToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(token)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid continueToken %q", token)
+	}
+	return offset, nil
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/constants"
+)
+
+var (
+	maxToolTimeoutMu sync.RWMutex
+	maxToolTimeout   = constants.DefaultMaxToolTimeout
+)
+
+// SetMaxToolTimeout sets the upper bound, typically from the kubernetes.maxToolTimeoutSec config, for the
+// timeoutSeconds argument of the tools wrapped by WithToolTimeout. Non-positive values restore the default.
+func SetMaxToolTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = constants.DefaultMaxToolTimeout
+	}
+	maxToolTimeoutMu.Lock()
+	maxToolTimeout = timeout
+	maxToolTimeoutMu.Unlock()
+}
+
+func getMaxToolTimeout() time.Duration {
+	maxToolTimeoutMu.RLock()
+	defer maxToolTimeoutMu.RUnlock()
+	return maxToolTimeout
+}
+
+// getToolTimeoutParam reads the optional timeoutSeconds argument. Zero means the caller gave none; values
+// above the configured maximum are clamped to it.
+func getToolTimeoutParam(request mcp.CallToolRequest, toolName string) (time.Duration, error) {
+	seconds := getInt64Param(request, "timeoutSeconds", 0)
+	if seconds < 0 {
+		return 0, fmt.Errorf("timeoutSeconds must be positive, got %d", seconds)
+	}
+	timeout := time.Duration(seconds) * time.Second
+	if maxTimeout := getMaxToolTimeout(); timeout > maxTimeout {
+		logrus.WithFields(logrus.Fields{"tool": toolName, "requested": timeout, "max": maxTimeout}).Warn("Timeout too high, resetting to configured maximum")
+		timeout = maxTimeout
+	}
+	return timeout, nil
+}
+
+// WithToolTimeout bounds a handler by the caller's timeoutSeconds argument. Without the argument the
+// handler runs exactly as before; once the deadline passes the result is a clean timeout error instead
+// of whatever the interrupted client call returned.
+func WithToolTimeout(toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		timeout, err := getToolTimeoutParam(request, toolName)
+		if err != nil {
+			return createErrorResponse(err.Error()), nil
+		}
+		if timeout == 0 {
+			return handler(ctx, request)
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		result, err := handler(timeoutCtx, request)
+		if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) && (err != nil || result == nil || result.IsError) {
+			logrus.WithFields(logrus.Fields{"tool": toolName, "timeout": timeout}).Warn("Tool call timed out")
+			return createErrorResponse(fmt.Sprintf("operation timed out after %s", timeout)), nil
+		}
+		return result, err
+	}
+}
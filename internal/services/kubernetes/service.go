@@ -6,11 +6,15 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	server "github.com/mark3labs/mcp-go/server"
 	"github.com/sirupsen/logrus"
 
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/cache"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kubernetes/client"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kubernetes/handlers"
@@ -43,10 +47,43 @@ func (s *Service) Name() string {
 func (s *Service) Initialize(cfg interface{}) error {
 	logrus.Debug("Initializing Kubernetes service")
 	// Kubernetes is always enabled by default; client is created per-request from headers.
-	_ = cfg
+	if appConfig, ok := cfg.(*config.AppConfig); ok && appConfig != nil {
+		handlers.SetMaxToolTimeout(time.Duration(appConfig.Kubernetes.MaxToolTimeoutSec) * time.Second)
+		if err := client.SetConnectionDefaults(appConfig.Kubernetes.ConnectionMode, appConfig.Kubernetes.Kubeconfig); err != nil {
+			return err
+		}
+		client.SetTransportDefaults(client.TransportSettings{
+			QPS:                 appConfig.Kubernetes.QPS,
+			Burst:               appConfig.Kubernetes.Burst,
+			Timeout:             time.Duration(appConfig.Kubernetes.TimeoutSec) * time.Second,
+			MaxIdleConns:        appConfig.Kubernetes.MaxIdleConns,
+			MaxIdleConnsPerHost: appConfig.Kubernetes.MaxIdleConnsPerHost,
+			RateLimitDiscovery:  appConfig.Kubernetes.RateLimitDiscovery,
+			RetryMaxAttempts:    appConfig.Kubernetes.RetryMaxAttempts,
+			RetryBackoff:        time.Duration(appConfig.Kubernetes.RetryBackoffMs) * time.Millisecond,
+			UserAgent:           appConfig.Server.UserAgent,
+		})
+		client.SetDiscoveryCacheTTL(time.Duration(appConfig.Kubernetes.DiscoveryCacheTTLSec) * time.Second)
+	}
+	reportConnection()
 	return nil
 }
 
+// reportConnection logs which connection the server falls back to for requests without a
+// kubeconfig header. A missing configuration is not fatal because requests may still supply one.
+func reportConnection() {
+	connection, err := client.DetectConnection()
+	if err != nil {
+		logrus.WithError(err).Warn("No default Kubernetes connection; requests must send a kubeconfig header")
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"mode":       connection.Mode,
+		"kubeconfig": connection.KubeconfigPath,
+		"server":     connection.Server,
+	}).Info("Kubernetes connection configured")
+}
+
 // GetTools returns all available Kubernetes MCP tools.
 // Tools are only returned if the service is enabled.
 // The tools include resource management, cluster interaction, and diagnostic capabilities.
@@ -57,7 +94,7 @@ func (s *Service) GetTools() []mcp.Tool {
 
 	// Use unified cache
 	return s.toolsCache.Get(func() []mcp.Tool {
-		return []mcp.Tool{
+		list := []mcp.Tool{
 			// Core resource operations (optimized for LLM efficiency)
 			tools.GetResourceSummaryTool(),
 			tools.GetResourceTool(),
@@ -69,20 +106,37 @@ func (s *Service) GetTools() []mcp.Tool {
 			tools.ListResourcesFullTool(),
 
 			// Resource creation and management
+			tools.ValidateManifestTool(),
 			tools.CreateResourceTool(),
+			tools.CreateResourcesTool(),
+			tools.UpdateResourceTool(),
 			tools.PatchResourceTool(),
 			tools.DeleteResourceTool(),
+			tools.DeleteResourcesByLabelTool(),
 
 			// Resource discovery and inspection
 			tools.DescribeResourceTool(),
+			tools.GetResourceYAMLHistoryTool(),
+			tools.ResolveServiceEndpointsTool(),
+			tools.DescribeIngressTool(),
+			tools.FindConfigConsumersTool(),
+			tools.GetSecretTypesTool(),
+			tools.InspectTLSSecretTool(),
+			tools.GetImagesTool(),
+			tools.ExportNamespaceTool(),
+			tools.CompareNamespacesTool(),
 			tools.GetResourceDetailsTool(),
 			tools.GetResourceDetailAdvancedTool(), // Advanced detail tool
 			tools.GetAPIVersionsTool(),
 			tools.GetAPIResourcesTool(),
+			tools.RefreshDiscoveryTool(),
+			tools.ClusterInfoTool(),
+			tools.CurrentContextTool(),
 
 			// Cluster operations
 			tools.ScaleResourceTool(),
 			tools.GetRolloutStatusTool(),
+			tools.ResourceDiffRevisionsTool(),
 			tools.CordonNodeTool(),
 			tools.UncordonNodeTool(),
 			tools.DrainNodeTool(),
@@ -92,28 +146,42 @@ func (s *Service) GetTools() []mcp.Tool {
 
 			// Container and pod operations
 			tools.ContainerLogsTool(),
+			tools.WorkloadLogsTool(),
 			tools.ContainerExecTool(),
 			tools.CheckPermissionsTool(),
 
 			// Event monitoring (optimized vs detailed)
-			tools.GetRecentEventsTool(), // Optimized for critical events
-			tools.GetEventsTool(),       // Standard events
-			tools.GetEventsDetailTool(), // Full detailed events
+			tools.GetRecentEventsTool(),  // Optimized for critical events
+			tools.GetEventsTool(),        // Standard events
+			tools.GetEventsDetailTool(),  // Full detailed events
+			tools.GetEventsSummaryTool(), // Events grouped by reason and kind
 
 			// Resource monitoring
 			tools.GetResourceUsageTool(),
+			tools.GetPodResourceRecommendationsTool(),
 
 			// Troubleshooting and diagnostics
 			tools.GetUnhealthyResourcesTool(),
+			tools.RestartCountTool(),
+			tools.QuotaSummaryTool(),
+			tools.HPAStatusTool(),
 			tools.GetNodeConditionsTool(),
+			tools.NodeAllocationSummaryTool(),
+			tools.NodePodsTool(),
+			tools.FindDeprecatedAPIsTool(),
 			tools.AnalyzeIssueTool(),
 
 			// Search and discovery
 			tools.SearchResourcesTool(),
+			tools.FindResourceTool(),
 
 			// Testing and validation
 			tools.TestTool(),
 		}
+		for i := range list {
+			list[i] = tools.WithAuthTokenParam(list[i])
+		}
+		return list
 	})
 }
 
@@ -128,62 +196,89 @@ func (s *Service) GetHandlers() map[string]server.ToolHandlerFunc {
 		// Core resource operations (optimized for LLM efficiency)
 		"kubernetes_get_resource_summary":   s.wrapWithCache("kubernetes_get_resource_summary", handlers.HandleGetResourceSummary()),
 		"kubernetes_get_resource":           handlers.HandleGetResource(),
-		"kubernetes_list_resources_summary": s.wrapWithCache("kubernetes_list_resources_summary", handlers.HandleListResourcesSummary()), // Summary-first with cache
-		"kubernetes_list_resources":         handlers.HandleListResources(),
-		"kubernetes_get_resources_detail":   handlers.HandleGetResourcesDetail(),
+		"kubernetes_list_resources_summary": handlers.WithToolTimeout("kubernetes_list_resources_summary", s.wrapWithCache("kubernetes_list_resources_summary", handlers.HandleListResourcesSummary())), // Summary-first with cache
+		"kubernetes_list_resources":         handlers.WithToolTimeout("kubernetes_list_resources", handlers.HandleListResources()),
+		"kubernetes_get_resources_detail":   handlers.WithToolTimeout("kubernetes_get_resources_detail", handlers.HandleGetResourcesDetail()),
 
 		// Full detail tools (use sparingly)
-		"kubernetes_list_resources_full": handlers.HandleListResourcesFull(),
+		"kubernetes_list_resources_full": handlers.WithToolTimeout("kubernetes_list_resources_full", handlers.HandleListResourcesFull()),
 
 		// Resource creation and management
-		"kubernetes_create_resource": handlers.HandleCreateResource(),
-		"kubernetes_patch_resource":  handlers.HandlePatchResource(),
-		"kubernetes_delete_resource": handlers.HandleDeleteResource(),
+		"kubernetes_validate_manifest":         handlers.HandleValidateManifest(),
+		"kubernetes_create_resource":           handlers.HandleCreateResource(),
+		"kubernetes_create_resources":          handlers.WithToolTimeout("kubernetes_create_resources", handlers.HandleCreateResources()),
+		"kubernetes_update_resource":           handlers.HandleUpdateResource(),
+		"kubernetes_patch_resource":            handlers.HandlePatchResource(),
+		"kubernetes_delete_resource":           handlers.HandleDeleteResource(),
+		"kubernetes_delete_resources_by_label": handlers.HandleDeleteResourcesByLabel(),
 
 		// Resource discovery and inspection
 		"kubernetes_describe_resource":            handlers.HandleDescribeResource(),
+		"kubernetes_get_resource_yaml_history":    handlers.HandleGetResourceYAMLHistory(),
+		"kubernetes_resolve_service_endpoints":    handlers.HandleResolveServiceEndpoints(),
+		"kubernetes_describe_ingress":             handlers.HandleDescribeIngress(),
+		"kubernetes_find_config_consumers":        handlers.HandleFindConfigConsumers(),
+		"kubernetes_get_secret_types":             handlers.HandleGetSecretTypes(),
+		"kubernetes_inspect_tls_secret":           handlers.HandleInspectTLSSecret(),
+		"kubernetes_get_images":                   handlers.WithToolTimeout("kubernetes_get_images", handlers.HandleGetImages()),
+		"kubernetes_export_namespace":             handlers.WithToolTimeout("kubernetes_export_namespace", handlers.HandleExportNamespace()),
+		"kubernetes_compare_namespaces":           handlers.WithToolTimeout("kubernetes_compare_namespaces", handlers.HandleCompareNamespaces()),
 		"kubernetes_get_resource_details":         handlers.HandleGetResourceDetails(),
 		"kubernetes_get_resource_detail_advanced": handlers.HandleGetResourceDetailAdvanced(), // Advanced detail handler
-		"kubernetes_get_api_versions":             s.wrapWithCache("kubernetes_get_api_versions", handlers.HandleGetAPIVersions()),
-		"kubernetes_get_api_resources":            s.wrapWithCache("kubernetes_get_api_resources", handlers.HandleGetAPIResources()),
+		"kubernetes_get_api_versions":             handlers.HandleGetAPIVersions(),
+		"kubernetes_get_api_resources":            handlers.HandleGetAPIResources(),
+		"kubernetes_refresh_discovery":            handlers.HandleRefreshDiscovery(),
+		"kubernetes_cluster_info":                 handlers.HandleClusterInfo(),
+		"kubernetes_current_context":              handlers.HandleCurrentContext(),
 
 		// Cluster operations
-		"kubernetes_scale_resource":     handlers.HandleScaleResource(),
-		"kubernetes_get_rollout_status": handlers.HandleGetRolloutStatus(),
-		"kubernetes_cordon_node":        handlers.HandleCordonNode(),
-		"kubernetes_uncordon_node":      handlers.HandleUncordonNode(),
-		"kubernetes_drain_node":         handlers.HandleDrainNode(),
-		"kubernetes_wait_for_resource":  handlers.HandleWaitForResource(),
-		"kubernetes_restart_workload":   handlers.HandleRestartWorkload(),
-		"kubernetes_port_forward":       handlers.HandlePortForward(),
+		"kubernetes_scale_resource":              handlers.HandleScaleResource(),
+		"kubernetes_get_rollout_status":          handlers.HandleGetRolloutStatus(),
+		"kubernetes_get_resource_diff_revisions": handlers.HandleResourceDiffRevisions(),
+		"kubernetes_cordon_node":                 handlers.HandleCordonNode(),
+		"kubernetes_uncordon_node":               handlers.HandleUncordonNode(),
+		"kubernetes_drain_node":                  handlers.HandleDrainNode(),
+		"kubernetes_wait_for_resource":           handlers.HandleWaitForResource(),
+		"kubernetes_restart_workload":            handlers.HandleRestartWorkload(),
+		"kubernetes_port_forward":                handlers.HandlePortForward(),
 
 		// Container and pod operations
-		"kubernetes_get_pod_logs":      handlers.HandleContainerLogs(),
-		"kubernetes_pod_exec":          handlers.HandleContainerExec(),
+		"kubernetes_get_pod_logs":      handlers.WithToolTimeout("kubernetes_get_pod_logs", handlers.HandleContainerLogs()),
+		"kubernetes_get_logs_multi":    handlers.WithToolTimeout("kubernetes_get_logs_multi", handlers.HandleWorkloadLogs()),
+		"kubernetes_pod_exec":          handlers.WithToolTimeout("kubernetes_pod_exec", handlers.HandleContainerExec()),
 		"kubernetes_check_permissions": s.wrapWithCache("kubernetes_check_permissions", handlers.HandleCheckPermissions()),
 
 		// Event monitoring (optimized vs detailed)
 		"kubernetes_get_recent_events": s.wrapWithCache("kubernetes_get_recent_events", handlers.HandleGetRecentEvents()), // Optimized for critical events with cache
 		"kubernetes_get_events":        handlers.HandleGetEvents(),                                                        // Standard events
 		"kubernetes_get_events_detail": handlers.HandleGetEventsDetail(),                                                  // Full detailed events
+		"kubernetes_events_summary":    handlers.HandleGetEventsSummary(),                                                 // Events grouped by reason and kind
 
 		// Resource monitoring
-		"kubernetes_get_resource_usage": handlers.HandleGetResourceUsage(),
+		"kubernetes_get_resource_usage":               handlers.HandleGetResourceUsage(),
+		"kubernetes_get_pod_resource_recommendations": handlers.HandleGetPodResourceRecommendations(),
 
 		// Troubleshooting and diagnostics
-		"kubernetes_get_unhealthy_resources": handlers.HandleGetUnhealthyResources(),
+		"kubernetes_get_unhealthy_resources": handlers.WithToolTimeout("kubernetes_get_unhealthy_resources", handlers.HandleGetUnhealthyResources()),
+		"kubernetes_restart_count":           handlers.HandleRestartCount(),
+		"kubernetes_quota_summary":           handlers.HandleQuotaSummary(),
+		"kubernetes_get_hpa_status":          handlers.HandleGetHPAStatus(),
 		"kubernetes_get_node_conditions":     handlers.HandleGetNodeConditions(),
+		"kubernetes_node_allocation_summary": handlers.HandleNodeAllocationSummary(),
+		"kubernetes_node_pods":               handlers.HandleNodePods(),
+		"kubernetes_find_deprecated_apis":    handlers.WithToolTimeout("kubernetes_find_deprecated_apis", handlers.HandleFindDeprecatedAPIs()),
 		"kubernetes_analyze_issue":           handlers.HandleAnalyzeIssue(),
 
 		// Search and discovery
-		"kubernetes_search_resources": handlers.HandleSearchResources(),
+		"kubernetes_search_resources": handlers.WithToolTimeout("kubernetes_search_resources", handlers.HandleSearchResources()),
+		"kubernetes_find_resource":    handlers.WithToolTimeout("kubernetes_find_resource", handlers.HandleFindResource()),
 
 		// Testing and validation
 		"kubernetes_test_tool": handlers.HandleTest(),
 	}
 
 	for name, handler := range handlersMap {
-		handlersMap[name] = s.wrapWithToolErrors(name, handler)
+		handlersMap[name] = s.wrapWithToolErrors(name, s.wrapWithAuthToken(handler))
 	}
 
 	return handlersMap
@@ -196,6 +291,10 @@ func (s *Service) wrapWithCache(toolName string, handler server.ToolHandlerFunc)
 		if !handlers.IsToolCacheable(toolName) {
 			return handler(ctx, request)
 		}
+		// Results seen with a caller's token depend on that caller's permissions
+		if c, err := client.FromContext(ctx); err == nil && c.UsesCallerToken() {
+			return handler(ctx, request)
+		}
 
 		// Get TTL for this tool
 		ttl := handlers.GetTTLForTool(toolName)
@@ -228,6 +327,26 @@ func (s *Service) wrapWithCache(toolName string, handler server.ToolHandlerFunc)
 	}
 }
 
+// wrapWithAuthToken runs the handler with a client authenticating as the caller when the request carries
+// an authToken argument, so the call is subject to the caller's RBAC permissions
+func (s *Service) wrapWithAuthToken(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		token, _ := request.GetArguments()[tools.AuthTokenParam].(string)
+		if strings.TrimSpace(token) == "" {
+			return handler(ctx, request)
+		}
+		base, err := client.FromContext(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		callerClient, err := base.WithBearerToken(token)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create a client for authToken: %v", err)), nil
+		}
+		return handler(client.NewContext(ctx, callerClient), request)
+	}
+}
+
 func (s *Service) wrapWithToolErrors(toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		result, err := handler(ctx, request)
@@ -249,3 +368,12 @@ func (s *Service) IsEnabled() bool {
 func (s *Service) GetClient() *client.Client {
 	return nil
 }
+
+// CheckConnectivity checks the Kubernetes API server is reachable with the client injected into the request context.
+func (s *Service) CheckConnectivity(ctx context.Context) error {
+	c, err := client.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", services.ErrNoBackendClient, err)
+	}
+	return c.Ping(ctx)
+}
@@ -2,10 +2,18 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/kubernetes/client"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -56,10 +64,18 @@ func TestServiceInitializeWithValidConfig(t *testing.T) {
 
 	appConfig := &config.AppConfig{
 		Kubernetes: struct {
-			Kubeconfig string  `yaml:"kubeconfig"`
-			TimeoutSec int     `yaml:"timeoutSec"`
-			QPS        float32 `yaml:"qps"`
-			Burst      int     `yaml:"burst"`
+			Kubeconfig           string  `yaml:"kubeconfig"`
+			ConnectionMode       string  `yaml:"connectionMode"`
+			TimeoutSec           int     `yaml:"timeoutSec"`
+			QPS                  float32 `yaml:"qps"`
+			Burst                int     `yaml:"burst"`
+			MaxIdleConns         int     `yaml:"maxIdleConns"`
+			MaxIdleConnsPerHost  int     `yaml:"maxIdleConnsPerHost"`
+			RateLimitDiscovery   bool    `yaml:"rateLimitDiscovery"`
+			DiscoveryCacheTTLSec int     `yaml:"discoveryCacheTTLSec"`
+			MaxToolTimeoutSec    int     `yaml:"maxToolTimeoutSec"`
+			RetryMaxAttempts     int     `yaml:"retryMaxAttempts"`
+			RetryBackoffMs       int     `yaml:"retryBackoffMs"`
 		}{
 			Kubeconfig: "/non-existent/kubeconfig", // Use non-existent path for test
 			TimeoutSec: 30,
@@ -177,3 +193,125 @@ func TestServiceGetHandlersIncludesRolloutAndNodeOperations(t *testing.T) {
 		}
 	}
 }
+
+func TestServiceEveryToolHasHandler(t *testing.T) {
+	service := NewService()
+	service.enabled = true
+
+	handlers := service.GetHandlers()
+	for _, tool := range service.GetTools() {
+		if _, ok := handlers[tool.Name]; !ok {
+			t.Errorf("tool %q is advertised without a handler", tool.Name)
+		}
+	}
+	if len(handlers) != len(service.GetTools()) {
+		t.Errorf("expected one handler per tool, got %d handlers for %d tools", len(handlers), len(service.GetTools()))
+	}
+}
+
+func TestServiceUpdateResourceTool(t *testing.T) {
+	var updates int
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		status := func(code int, reason, message string) {
+			w.WriteHeader(code)
+			fmt.Fprintf(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","message":%q,"reason":%q,"code":%d}`, message, reason, code)
+		}
+		switch {
+		case r.URL.Path == "/api":
+			fmt.Fprint(w, `{"kind":"APIVersions","versions":["v1"]}`)
+		case r.URL.Path == "/apis":
+			fmt.Fprint(w, `{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`)
+		case r.URL.Path == "/api/v1":
+			fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"configmaps","singularName":"configmap","namespaced":true,"kind":"ConfigMap","verbs":["get","list","update"]}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/namespaces/shop/configmaps":
+			// The version the manifest was edited from has been compacted away
+			status(http.StatusGone, "Expired", "too old resource version: 3 (7)")
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/namespaces/shop/configmaps/settings":
+			updates++
+			var obj map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+				t.Errorf("failed to decode update: %v", err)
+			}
+			if obj["metadata"].(map[string]any)["resourceVersion"] != "7" {
+				status(http.StatusConflict, "Conflict", `Operation cannot be fulfilled on configmaps "settings": the object has been modified`)
+				return
+			}
+			obj["metadata"].(map[string]any)["resourceVersion"] = "8"
+			_ = json.NewEncoder(w).Encode(obj)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			status(http.StatusNotFound, "NotFound", "not found")
+		}
+	}))
+	defer apiServer.Close()
+
+	path := filepath.Join(t.TempDir(), "config")
+	kubeconfig := fmt.Sprintf("apiVersion: v1\nkind: Config\ncurrent-context: test\ncontexts:\n- name: test\n  context: {cluster: test, user: test}\nclusters:\n- name: test\n  cluster: {server: %q}\nusers:\n- name: test\n  user: {token: abc}\n", apiServer.URL)
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	opts := client.DefaultClientOptions()
+	opts.ConnectionMode = "kubeconfig"
+	opts.KubeconfigPath = path
+	c, err := client.NewClientWithOptions(opts)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	ctx := client.NewContext(context.Background(), c)
+
+	service := NewService()
+	service.enabled = true
+	var tool mcp.Tool
+	for _, candidate := range service.GetTools() {
+		if candidate.Name == "kubernetes_update_resource" {
+			tool = candidate
+		}
+	}
+	if tool.Annotations.DestructiveHint == nil || !*tool.Annotations.DestructiveHint {
+		t.Fatalf("expected kubernetes_update_resource to be registered as destructive, got %+v", tool.Annotations)
+	}
+	for _, param := range []string{"manifest", "inputFormat", "autoResolveConflict"} {
+		if _, ok := tool.InputSchema.Properties[param]; !ok {
+			t.Fatalf("expected kubernetes_update_resource to declare %s", param)
+		}
+	}
+	handler := service.GetHandlers()[tool.Name]
+	call := func(args map[string]any) (string, bool) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = tool.Name
+		request.Params.Arguments = args
+		result, err := handler(ctx, request)
+		if err != nil || result == nil {
+			t.Fatalf("handler returned %v, %v", result, err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return text.Text, result.IsError
+	}
+	manifest := func(resourceVersion string) string {
+		return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: shop\n  resourceVersion: \"" + resourceVersion + "\"\ndata:\n  mode: safe\n"
+	}
+
+	// A YAML manifest at the current version is applied, with name and namespace taken from it
+	text, isError := call(map[string]any{"kind": "ConfigMap", "manifest": manifest("7"), "inputFormat": "yaml"})
+	if isError || !strings.Contains(text, `"mode":"safe"`) || !strings.Contains(text, `"resourceVersion":"8"`) {
+		t.Fatalf("expected the manifest to be applied, got %s", text)
+	}
+
+	// A stale version returns the conflict without retrying
+	updates = 0
+	text, isError = call(map[string]any{"kind": "ConfigMap", "manifest": manifest("3")})
+	if !isError || !strings.Contains(text, "the object has been modified") || updates != 1 {
+		t.Fatalf("expected the conflict after one update, got %q after %d updates", text, updates)
+	}
+
+	// autoResolveConflict reaches the merge, which needs the version the manifest was edited from
+	text, isError = call(map[string]any{"kind": "ConfigMap", "manifest": manifest("3"), "autoResolveConflict": true})
+	if !isError || !strings.Contains(text, "no longer available to merge from") {
+		t.Fatalf("expected the merge to report the missing base, got %q", text)
+	}
+
+	if text, isError = call(map[string]any{"kind": "Secret", "manifest": manifest("7")}); !isError || !strings.Contains(text, "kind mismatch") {
+		t.Fatalf("expected a kind mismatch, got %q", text)
+	}
+}
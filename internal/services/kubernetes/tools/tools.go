@@ -16,8 +16,12 @@ func GetResourceSummaryTool() mcp.Tool {
 			mcp.Description("Exact name of the specific resource to get summary for. Must match metadata.name exactly. Use list_resources_summary first to find correct resource names if uncertain.")),
 		mcp.WithString("namespace",
 			mcp.Description("Required for namespaced resources (Pod, Service, Deployment, etc.). Omit for cluster-scoped resources (Node, PersistentVolume, ClusterRole, etc.")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Optional apiVersion (e.g. 'networking.k8s.io/v1', 'cert-manager.io/v1') pinning which API group serves the kind. Only needed when the same kind exists in several API groups; in that case the tool returns an error listing the candidate apiVersions.")),
 		mcp.WithString("includeLabels",
 			mcp.Description("Optional comma-separated label keys to include (e.g., 'app,version,env'). If omitted, non-Pod resources may include up to 10 labels automatically, while Pod summaries omit labels by default to keep the response small. Use this when you need specific label keys.")),
+		mcp.WithBoolean("includeContainerStatuses",
+			mcp.Description("For Pods, append each container's ready state, restart count and waiting/terminated reason (e.g. CrashLoopBackOff, OOMKilled) under 'containers'. Default false to keep summaries small.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting the tool itself (true/false).")),
 	)
@@ -27,15 +31,23 @@ func GetResourceSummaryTool() mcp.Tool {
 func GetResourceTool() mcp.Tool {
 	logrus.Debug("Creating GetResourceTool")
 	return mcp.NewTool("kubernetes_get_resource",
-		mcp.WithDescription("Read one Kubernetes resource in detail. Use this when you need the full object or when you want to extract one field with `jsonpath`."),
+		mcp.WithDescription("Read one Kubernetes resource in detail. Use this when you need the full object, a few values by dotted path with `fields`, or one field with `jsonpath`."),
 		mcp.WithString("kind", mcp.Required(),
 			mcp.Description("Resource kind - the type of Kubernetes object to retrieve. Common examples: Pod (for containers), Service (for networking), Deployment (for application workloads), ConfigMap (for configuration), Secret (for sensitive data), Ingress (for HTTP routing), PersistentVolume/PersistentVolumeClaim (for storage), Namespace (for resource grouping). Use exact case-sensitive names as they appear in Kubernetes API.")),
 		mcp.WithString("name", mcp.Required(),
 			mcp.Description("Exact name of the specific resource instance to retrieve. This must match the metadata.name field of the resource exactly. Names are case-sensitive and must follow Kubernetes naming conventions (lowercase alphanumeric with hyphens and dots allowed).")),
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace where the resource is located. Required for namespaced resources (Pod, Service, Deployment, ConfigMap, Secret, etc.). Not applicable for cluster-scoped resources (Node, ClusterRole, PersistentVolume, etc.). If unsure about the namespace, use list_resources tool first to find the resource location. Default namespace is 'default' if not specified for namespaced resources.")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Optional apiVersion (e.g. 'networking.k8s.io/v1', 'cert-manager.io/v1') pinning which API group serves the kind. Only needed when the same kind exists in several API groups; in that case the tool returns an error listing the candidate apiVersions.")),
 		mcp.WithString("jsonpath",
 			mcp.Description("JSONPath expression to extract specific fields instead of returning the full resource. Full expressions like `{.status.phase}` and bare paths like `status.phase` are both accepted.")),
+		mcp.WithArray("fields",
+			mcp.Description("Simpler alternative to 'jsonpath': dotted paths such as ['status.phase', 'spec.replicas', 'spec.containers.0.image']. Returns 'fields', a flat map of each path to its value. A numeric segment indexes into a list; any other segment on a list reads every element. Missing paths map to null and are listed in 'missingFields' with a 'note'. Cannot be combined with 'jsonpath'."),
+			mcp.WithStringItems()),
+		mcp.WithString("outputFormat",
+			mcp.Enum("json", "yaml"),
+			mcp.Description("Response encoding: 'json' (default) or 'yaml'. YAML is convenient for reviewing configuration as it would appear in a manifest.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting the API call itself. Set to 'true' to see detailed request/response information, 'false' or omit for normal output. Only use when debugging tool execution issues.")),
 	)
@@ -52,22 +64,164 @@ func DescribeResourceTool() mcp.Tool {
 			mcp.Description("Exact name of the resource instance to describe. This must match the resource name exactly as it appears in Kubernetes. Use 'list_resources' tool first if you need to find the correct resource name. For Pods created by Deployments, the name will include generated suffixes.")),
 		mcp.WithString("namespace",
 			mcp.Description("Namespace where the resource exists. This is required for namespaced resources (Pod, Service, Deployment, ConfigMap, Secret, etc.) but should be omitted for cluster-scoped resources (Node, PersistentVolume, ClusterRole, etc.). If unsure whether a resource is namespaced, try without namespace first - the error will indicate if namespace is required. Use 'default' namespace if not specified during resource creation.")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Optional apiVersion (e.g. 'networking.k8s.io/v1', 'cert-manager.io/v1') pinning which API group serves the kind. Only needed when the same kind exists in several API groups; in that case the tool returns an error listing the candidate apiVersions.")),
+		mcp.WithString("outputFormat",
+			mcp.Enum("text", "structured"),
+			mcp.Description("'text' (default) returns the full resource for reading. 'structured' returns parsed sections as JSON: metadata, specHighlights, containers, status, conditions ordered oldest to newest with latestCondition, and the most recent events. Use structured to extract a field such as the latest condition reliably.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting the tool itself. Set to 'true' to see detailed execution information, 'false' or omit for normal output. Only use when the tool itself is not working as expected.")),
 	)
 }
 
+// GetResourceYAMLHistoryTool reports the last-applied configuration and field ownership of a resource
+func GetResourceYAMLHistoryTool() mcp.Tool {
+	logrus.Debug("Creating GetResourceYAMLHistoryTool")
+	return mcp.NewTool("kubernetes_get_resource_yaml_history",
+		mcp.WithDescription("Explain drift between what was applied and the live object. Returns the parsed `kubectl.kubernetes.io/last-applied-configuration` annotation, the applied fields whose live value differs, and a summary of `managedFields` ownership (manager, operation, time and owned field paths). When the annotation is absent the response says so explicitly."),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Resource kind, e.g. Deployment, Service, ConfigMap.")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Exact name of the resource.")),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the resource. Omit for cluster-scoped resources.")),
+	)
+}
+
+// ResolveServiceEndpointsTool resolves the pods and addresses backing a Service
+func ResolveServiceEndpointsTool() mcp.Tool {
+	logrus.Debug("Creating ResolveServiceEndpointsTool")
+	return mcp.NewTool("kubernetes_resolve_service_endpoints",
+		mcp.WithDescription("Show which pods back a Service. Returns the Service selector and ports plus every endpoint from its EndpointSlices (falling back to Endpoints) with pod name, node, IP, ports and readiness. Flags Services with zero ready endpoints, a common cause of 503s, and reports how many pods the selector currently matches."),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Name of the Service.")),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace of the Service.")),
+	)
+}
+
+// DescribeIngressTool summarizes an Ingress and checks whether its routes can be served
+func DescribeIngressTool() mcp.Tool {
+	logrus.Debug("Creating DescribeIngressTool")
+	return mcp.NewTool("kubernetes_describe_ingress",
+		mcp.WithDescription("Explain why an Ingress route is or isn't working in one call. Returns hosts and paths with their backend Services and ready endpoint counts, referenced TLS Secrets and whether they exist, the ingress class and the load balancer address from status, plus a `problems` list. Works with networking.k8s.io/v1 and older beta Ingress APIs."),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Name of the Ingress.")),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace of the Ingress.")),
+	)
+}
+
+// GetSecretTypesTool lists Secrets grouped by type
+func GetSecretTypesTool() mcp.Tool {
+	logrus.Debug("Creating GetSecretTypesTool")
+	return mcp.NewTool("kubernetes_get_secret_types",
+		mcp.WithDescription("List Secrets grouped by type (Opaque, kubernetes.io/tls, kubernetes.io/dockerconfigjson, service account tokens, ...) with the names in each group. Only metadata is read; secret data is never returned. Use it to find the TLS Secrets to check with kubernetes_inspect_tls_secret."),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to list Secrets in. Omit to list all namespaces; names are then returned as namespace/name.")),
+	)
+}
+
+// InspectTLSSecretTool reports the certificates of a TLS Secret
+func InspectTLSSecretTool() mcp.Tool {
+	logrus.Debug("Creating InspectTLSSecretTool")
+	return mcp.NewTool("kubernetes_inspect_tls_secret",
+		mcp.WithDescription("Decode the certificate chain in the tls.crt of a TLS Secret (and ca.crt if present) instead of returning base64. Returns each certificate's subject, issuer, SANs (DNS names, IPs, emails, URIs), serial number and notBefore/notAfter with days until expiry, and flags certificates that are expired, not yet valid or expiring within the window. Also reports whether tls.key is present and matches the certificate. The private key itself is never returned. Useful to find out why an Ingress certificate broke."),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Name of the Secret.")),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace of the Secret.")),
+		mcp.WithNumber("expiryWindowDays",
+			mcp.Description("Flag certificates that expire within this many days. Defaults to 30.")),
+	)
+}
+
+// FindConfigConsumersTool finds the workloads that use a ConfigMap or Secret
+func FindConfigConsumersTool() mcp.Tool {
+	logrus.Debug("Creating FindConfigConsumersTool")
+	return mcp.NewTool("kubernetes_find_config_consumers",
+		mcp.WithDescription("Find which workloads use a ConfigMap or Secret before editing or deleting it. Scans pod templates of Deployments, StatefulSets, DaemonSets, ReplicaSets, Jobs and CronJobs plus running pods for references in volumes (including projected volumes), env valueFrom, envFrom and, for Secrets, imagePullSecrets. Pods are attributed to their top-level controller (e.g. Pod -> ReplicaSet -> Deployment); pods without a controller are listed on their own. Reports whether the object exists, so consumers of a missing object are shown too."),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("ConfigMap or Secret.")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Name of the ConfigMap or Secret.")),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace of the ConfigMap or Secret. Only workloads in the same namespace can reference it.")),
+	)
+}
+
+// GetImagesTool lists the distinct container images in use and the workloads running them
+func GetImagesTool() mcp.Tool {
+	logrus.Debug("Creating GetImagesTool")
+	return mcp.NewTool("kubernetes_get_images",
+		mcp.WithDescription("Inventory the container images in use, e.g. for vulnerability or upgrade tracking. Lists each distinct image (init containers included) from running pods and from the pod templates of Deployments, StatefulSets, DaemonSets, ReplicaSets, Jobs and CronJobs, with the number of pods using it, the resolved digests and the workloads referencing it. Pods are attributed to their top-level controller. Images are sorted by pod count."),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to inventory. Omit to scan every namespace (requires cluster-wide list permissions).")),
+		mcp.WithString("filter",
+			mcp.Description("Optional case-insensitive substring an image must contain, e.g. 'nginx' or 'registry.example.com/'.")),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of images to return (default: 100, max: 500). totalImages always reports the full count.")),
+		timeoutSecondsOption(),
+	)
+}
+
+// ExportNamespaceTool exports a namespace as re-appliable YAML
+func ExportNamespaceTool() mcp.Tool {
+	logrus.Debug("Creating ExportNamespaceTool")
+	return mcp.NewTool("kubernetes_export_namespace",
+		mcp.WithDescription("Export the objects of a namespace as multi-document YAML suitable for 'kubectl apply', e.g. to back up or clone a namespace. status and server-managed metadata (managedFields, resourceVersion, uid, creationTimestamp, ownerReferences, last-applied annotation) are stripped, as are Service cluster IPs. Controller-owned objects (ReplicaSets, Pods, Jobs of CronJobs), service account tokens and the default ServiceAccount and kube-root-ca.crt ConfigMap are skipped since they are recreated automatically. Kinds are exported in dependency order. Large namespaces are paged: pass the continueToken from the header comment to get the next page."),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace to export.")),
+		mcp.WithArray("kinds",
+			mcp.Description("Kinds to export, in order (array or comma-separated string). Defaults to ServiceAccount, ConfigMap, Secret, PersistentVolumeClaim, Role, RoleBinding, Service, Deployment, StatefulSet, DaemonSet, CronJob, Job, Ingress, NetworkPolicy, HorizontalPodAutoscaler, PodDisruptionBudget."),
+			mcp.WithStringItems()),
+		mcp.WithBoolean("includeSecrets",
+			mcp.Description("Include Secrets, with their data, in the export (default: false).")),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of objects per page (default: 100, max: 500).")),
+		mcp.WithString("continueToken",
+			mcp.Description("Token from the previous page's header. Pass the same kinds and includeSecrets as that call.")),
+		timeoutSecondsOption(),
+	)
+}
+
+// CompareNamespacesTool reports configuration drift between two namespaces
+func CompareNamespacesTool() mcp.Tool {
+	logrus.Debug("Creating CompareNamespacesTool")
+	return mcp.NewTool("kubernetes_compare_namespaces",
+		mcp.WithDescription("Compare the objects of two namespaces (e.g. staging and prod) to catch configuration that was not promoted. Objects are matched by kind and name and reported as onlyInSource, onlyInTarget or differing, with the differing field paths and both values (at most 20 per object). status, server-managed metadata, namespace, Service cluster IPs and environment labels are ignored; controller-owned and auto-created objects are skipped. Secret values are compared by digest and never returned."),
+		mcp.WithString("sourceNamespace", mcp.Required(),
+			mcp.Description("Namespace to compare from, e.g. 'staging'.")),
+		mcp.WithString("targetNamespace", mcp.Required(),
+			mcp.Description("Namespace to compare against, e.g. 'prod'.")),
+		mcp.WithArray("kinds",
+			mcp.Description("Kinds to compare (array or comma-separated string). Defaults to ServiceAccount, ConfigMap, PersistentVolumeClaim, Role, RoleBinding, Service, Deployment, StatefulSet, DaemonSet, CronJob, Ingress, NetworkPolicy, HorizontalPodAutoscaler, PodDisruptionBudget."),
+			mcp.WithStringItems()),
+		mcp.WithArray("ignoreLabels",
+			mcp.Description("Label keys expected to differ between the namespaces, ignored on objects and pod templates. Defaults to env, environment, stage and app.kubernetes.io/instance; pass an empty array to compare every label."),
+			mcp.WithStringItems()),
+		mcp.WithArray("ignoreFields",
+			mcp.Description("Dotted field paths to leave out of the comparison, e.g. 'spec.replicas' or 'spec.template.spec.containers'."),
+			mcp.WithStringItems()),
+		timeoutSecondsOption(),
+	)
+}
+
 // GetRecentEventsTool retrieves recent cluster events with optimized output
 func GetRecentEventsTool() mcp.Tool {
 	logrus.Debug("Creating GetRecentEventsTool")
 	return mcp.NewTool("kubernetes_get_recent_events",
-		mcp.WithDescription("Priority event tool for troubleshooting. Returns recent warning and failure events with much smaller output than the full events listing."),
+		mcp.WithDescription("Priority event tool for troubleshooting. Returns recent warning and failure events with much smaller output than the full events listing. Events are ordered by last occurrence (series lastObservedTime, lastTimestamp, eventTime), newest first, with ties broken by namespace and name."),
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace to filter events from. If not specified, shows critical events from all namespaces (requires cluster-wide permissions). For focused troubleshooting, specify the namespace where the problematic resources are located.")),
 		mcp.WithString("fieldSelector",
 			mcp.Description("Field selector to filter events. Common examples: 'involvedObject.name=my-pod' (events for specific pod), 'type=Warning' (only warnings), 'reason=Failed' (failure events). Combine with commas for AND logic.")),
 		mcp.WithNumber("limit",
 			mcp.Description("Maximum number of recent events to return (default: 20, max: 100). This tool is optimized for recent events, so higher limits are not recommended.")),
+		mcp.WithNumber("sinceMinutes",
+			mcp.Description("Only return events last seen within this many minutes. Applied client-side, since field selectors cannot filter on time. Cannot be combined with sinceTime.")),
+		mcp.WithString("sinceTime",
+			mcp.Description("Only return events last seen at or after this RFC3339 time (e.g. '2024-01-02T15:04:05Z'). Cannot be combined with sinceMinutes.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting the tool itself (true/false).")),
 	)
@@ -77,15 +231,39 @@ func GetRecentEventsTool() mcp.Tool {
 func GetEventsTool() mcp.Tool {
 	logrus.Debug("Creating GetEventsTool")
 	return mcp.NewTool("kubernetes_get_events",
-		mcp.WithDescription("Retrieve Kubernetes cluster events for troubleshooting and monitoring purposes. Events provide valuable insights into cluster activities, resource state changes, and error conditions. This tool is essential for diagnosing issues with pods, deployments, services, and other resources. Events show chronological activities like pod scheduling, image pulling, container creation, failures, and warnings. Use this tool when investigating why resources are not working as expected, such as pods stuck in pending state, failed deployments, or service connectivity issues. Events are automatically cleaned up after a retention period (typically 1 hour), so recent events are most relevant for troubleshooting."),
+		mcp.WithDescription("Retrieve Kubernetes cluster events for troubleshooting and monitoring purposes. Events provide valuable insights into cluster activities, resource state changes, and error conditions. This tool is essential for diagnosing issues with pods, deployments, services, and other resources. Events show chronological activities like pod scheduling, image pulling, container creation, failures, and warnings. Use this tool when investigating why resources are not working as expected, such as pods stuck in pending state, failed deployments, or service connectivity issues. Events are automatically cleaned up after a retention period (typically 1 hour), so recent events are most relevant for troubleshooting. Events are ordered by last occurrence (series lastObservedTime, lastTimestamp, eventTime), newest first, with ties broken by namespace and name."),
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace to filter events from. If not specified, events from all namespaces will be returned (requires cluster-wide permissions). For focused troubleshooting, specify the namespace where the problematic resources are located. Common namespaces include 'default', 'kube-system' (for cluster components), 'kube-public', or custom application namespaces. Use this to narrow down events when you know which namespace contains the resources you're investigating.")),
 		mcp.WithString("fieldSelector",
 			mcp.Description("Field selector to filter events based on specific criteria. This allows precise filtering of events related to specific resources or conditions. Common examples: 'involvedObject.name=my-pod' (events for a specific pod), 'involvedObject.kind=Pod' (all pod-related events), 'type=Warning' (only warning events), 'type=Normal' (only normal events), 'reason=Failed' (events with Failed reason), 'involvedObject.namespace=my-namespace' (events for resources in specific namespace). You can combine multiple selectors with commas. This is particularly useful when troubleshooting specific resources or looking for particular types of issues.")),
 		mcp.WithNumber("limit",
-			mcp.Description("Maximum number of events to return in the response. Default is 100 events if not specified. Set a higher limit (e.g., 500, 1000) when you need to see more historical events for comprehensive troubleshooting. Set a lower limit (e.g., 20, 50) for quick checks or when you only need recent events. Be mindful that very high limits may return large amounts of data and take longer to process. Events are typically returned in reverse chronological order (newest first), so limiting helps focus on the most recent activities.")),
+			mcp.Description("Maximum number of events to return in the response. Default is 30 events if not specified; values above 80 are clamped to 80. Use get_events_detail with continueToken pagination when you need to see more historical events for comprehensive troubleshooting. Set a lower limit (e.g., 20, 50) for quick checks or when you only need recent events. Be mindful that very high limits may return large amounts of data and take longer to process. Events are returned newest first, so limiting keeps the most recent activities.")),
+		mcp.WithNumber("sinceMinutes",
+			mcp.Description("Only return events last seen within this many minutes. Applied client-side, since field selectors cannot filter on time. Cannot be combined with sinceTime.")),
+		mcp.WithString("sinceTime",
+			mcp.Description("Only return events last seen at or after this RFC3339 time (e.g. '2024-01-02T15:04:05Z'). Cannot be combined with sinceMinutes.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable detailed debug output for troubleshooting the tool itself (true/false). When set to 'true', provides additional logging information about the API calls, authentication, and processing steps. Use this when the get_events tool itself is not working as expected or when you need to understand the underlying Kubernetes API interactions. This is separate from the Kubernetes events themselves and is used for debugging the tool's operation.")),
+		mcp.WithBoolean("follow",
+			mcp.Description("Watch for new events instead of listing existing ones (default: false). Events matching namespace and fieldSelector that occur after the call are returned as compact lines ('<time> <type> <reason> <kind>/<namespace>/<name> x<count>: <message>'). When the request carries a progress token, each line is also streamed as a progress notification as it arrives. The watch stops after timeout seconds, after limit events, or when the request is cancelled. Pair with get_recent_events for real-time triage.")),
+		mcp.WithNumber("timeout",
+			mcp.Description("How long to follow events, in seconds, when follow is true (default: 60, max: 300).")),
+	)
+}
+
+// GetEventsSummaryTool aggregates events by reason and involved object kind
+func GetEventsSummaryTool() mcp.Tool {
+	logrus.Debug("Creating GetEventsSummaryTool")
+	return mcp.NewTool("kubernetes_events_summary",
+		mcp.WithDescription("Summarize events as a short ranked list of what is happening most. Events are grouped by reason and involved object kind, with total occurrences, distinct objects, first and last seen times, a representative message and example objects. Use this before the raw event tools when a namespace or cluster has many events."),
+		mcp.WithString("namespace",
+			mcp.Description("Kubernetes namespace to summarize. If not specified, events from all namespaces are summarized (requires cluster-wide permissions).")),
+		mcp.WithString("type",
+			mcp.Description("Only include events of this type: Warning or Normal. Defaults to both.")),
+		mcp.WithNumber("sinceMinutes",
+			mcp.Description("Only include events last seen within this many minutes. Defaults to all retained events.")),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of groups to return, ranked by occurrences (default: 20, max: 100).")),
 	)
 }
 
@@ -93,7 +271,7 @@ func GetEventsTool() mcp.Tool {
 func GetResourceUsageTool() mcp.Tool {
 	logrus.Debug("Creating GetResourceUsageTool")
 	return mcp.NewTool("kubernetes_get_resource_usage",
-		mcp.WithDescription("Retrieve real-time resource usage metrics (CPU and Memory) for Kubernetes nodes or pods. This tool queries the metrics server to show current resource consumption, which is essential for performance monitoring, capacity planning, and troubleshooting resource-related issues. The output includes current usage values and percentages relative to requests/limits. Note: This requires the metrics-server to be installed and running in your cluster. Use this tool when you need to: monitor resource consumption, identify resource-hungry pods, check node capacity, troubleshoot performance issues, or validate resource requests/limits. For nodes, shows total usage across all pods. For pods, shows per-container breakdown when available."),
+		mcp.WithDescription("Retrieve real-time resource usage metrics (CPU and Memory) for Kubernetes nodes or pods. This tool queries the metrics server to show current resource consumption, which is essential for performance monitoring, capacity planning, and troubleshooting resource-related issues. The output includes current usage values and percentages relative to requests/limits. Note: This requires the metrics-server to be installed and running in your cluster; when the metrics.k8s.io API is not registered the tool returns a 'metrics-server not installed' error instead of querying it. Use this tool when you need to: monitor resource consumption, identify resource-hungry pods, check node capacity, troubleshoot performance issues, or validate resource requests/limits. For nodes, shows total usage across all pods. For pods, shows per-container breakdown when available."),
 		mcp.WithString("resourceType", mcp.Required(),
 			mcp.Description("Type of Kubernetes resource to check usage metrics for. Valid values: 'node' (for cluster node resource usage including CPU, memory, and capacity information across all pods running on each node), 'pod' (for individual pod resource usage showing CPU and memory consumption per container). Use 'node' to get cluster-wide resource overview and identify resource pressure. Use 'pod' to analyze specific application resource consumption and identify resource-hungry containers.")),
 		mcp.WithString("name",
@@ -105,23 +283,61 @@ func GetResourceUsageTool() mcp.Tool {
 	)
 }
 
-// PortForwardTool creates port forwarding to a pod
+// GetPodResourceRecommendationsTool compares live container usage against requests and limits
+func GetPodResourceRecommendationsTool() mcp.Tool {
+	logrus.Debug("Creating GetPodResourceRecommendationsTool")
+	return mcp.NewTool("kubernetes_get_pod_resource_recommendations",
+		mcp.WithDescription("Compare metrics-server CPU and memory usage of the containers in a namespace against their requests and limits. Flags over-provisioned containers (using under 30% of the request), under-provisioned containers (using more than the request), containers without requests and usage within 10% of a limit, and suggests request values. This is a point-in-time snapshot from a single sample, not historical data: suggestions treat the sample as p95 usage plus headroom. Requires metrics-server."),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace whose pods are assessed")),
+		mcp.WithString("labelSelector",
+			mcp.Description("Only assess pods matching this label selector, e.g. 'app=web'")),
+		mcp.WithBoolean("onlyFlagged",
+			mcp.Description("Only return containers with a finding (default: true). Set to false to include containers whose provisioning looks fine.")),
+	)
+}
+
+// PortForwardTool creates port forwarding to a pod, service or deployment
 func PortForwardTool() mcp.Tool {
 	logrus.Debug("Creating PortForwardTool")
 	return mcp.NewTool("kubernetes_port_forward",
-		mcp.WithDescription("Create port forwarding from a local port to a pod port, similar to 'kubectl port-forward'. This tool establishes a network tunnel that allows you to access services running inside a pod from your local machine. This is particularly useful for debugging applications, accessing databases, web interfaces, or APIs that are not exposed through Kubernetes services. The port forward session remains active until explicitly stopped. Use this when you need direct access to a pod's network interface for development, testing, or troubleshooting purposes. Make sure the target pod is running and the specified pod port is actually listening for connections."),
-		mcp.WithString("podName", mcp.Required(),
-			mcp.Description("Exact name of the target pod to forward traffic to. The pod must be in 'Running' state for port forwarding to work. Use 'list_resources' tool with kind='Pod' to find available pod names if needed. Pod names are case-sensitive and must match exactly as they appear in the cluster. If the pod restarts or gets recreated, you'll need to establish a new port forward session.")),
+		mcp.WithDescription("Create port forwarding from a local port to a pod port, similar to 'kubectl port-forward'. The target can be a Pod, or a Service or Deployment, in which case a ready backing pod is chosen automatically and another one is selected if that pod goes away during the session, like 'kubectl port-forward svc/name'. This tool establishes a network tunnel that allows you to access services running inside a pod from your local machine. This is particularly useful for debugging applications, accessing databases, web interfaces, or APIs that are not exposed through Kubernetes services. The port forward session remains active until explicitly stopped. Use this when you need direct access to a pod's network interface for development, testing, or troubleshooting purposes. Make sure the target pod is running and the specified pod port is actually listening for connections."),
+		mcp.WithString("kind",
+			mcp.Enum("Pod", "Service", "Deployment"),
+			mcp.Description("Kind of the target: 'Pod' (default), 'Service' or 'Deployment'. For a Service the ports are service ports, forwarded to their target ports on the chosen pod. For a Service or Deployment a running, ready pod matching its selector is chosen, and reselected when it is deleted or restarts, keeping the same local ports.")),
+		mcp.WithString("name",
+			mcp.Description("Name of the target pod, service or deployment. Required unless podName is set.")),
+		mcp.WithString("podName",
+			mcp.Description("Exact name of the target pod to forward traffic to; the same as name with kind 'Pod'. The pod must be in 'Running' state for port forwarding to work. Use 'list_resources' tool with kind='Pod' to find available pod names if needed. Pod names are case-sensitive and must match exactly as they appear in the cluster. If the pod restarts or gets recreated, you'll need to establish a new port forward session; forward to its Service or Deployment instead to follow it.")),
 		mcp.WithString("namespace", mcp.Required(),
 			mcp.Description("Kubernetes namespace where the target pod is located. This is required as pods are namespaced resources. Common namespaces include 'default', 'kube-system', 'kube-public', or custom application namespaces. Use 'list_resources' or 'get_resource_details' tools to verify the pod's namespace if uncertain. Namespace names are case-sensitive.")),
-		mcp.WithNumber("localPort", mcp.Required(),
-			mcp.Description("Local port number on your machine to bind the port forward to. This is the port you'll connect to locally (e.g., http://localhost:8080). Choose a port that's not already in use on your local system. Common ranges: 8000-8999 for web services, 5432 for PostgreSQL, 3306 for MySQL, 6379 for Redis. The port must be between 1-65535 and available for binding.")),
-		mcp.WithNumber("podPort", mcp.Required(),
-			mcp.Description("Target port number inside the pod that you want to access. This must be a port that the application inside the pod is actually listening on. Check the pod's container specifications, service definitions, or use 'describe_resource' to find the correct port. Common examples: 80/8080 for web servers, 443 for HTTPS, 3000 for Node.js apps, 5000 for Python Flask, 8000 for Django. The port must be between 1-65535.")),
+		mcp.WithNumber("localPort",
+			mcp.Description("Required unless ports is set. Local port number on your machine to bind the port forward to. This is the port you'll connect to locally (e.g., http://localhost:8080). Choose a port that's not already in use on your local system. Common ranges: 8000-8999 for web services, 5432 for PostgreSQL, 3306 for MySQL, 6379 for Redis. The port must be between 1-65535 and available for binding.")),
+		mcp.WithNumber("podPort",
+			mcp.Description("Required unless ports is set. Target port number inside the pod that you want to access. This must be a port that the application inside the pod is actually listening on. Check the pod's container specifications, service definitions, or use 'describe_resource' to find the correct port. Common examples: 80/8080 for web servers, 443 for HTTPS, 3000 for Node.js apps, 5000 for Python Flask, 8000 for Django. The port must be between 1-65535.")),
 		mcp.WithString("address",
 			mcp.Description("Local IP address to bind the port forward to. Defaults to 'localhost' (127.0.0.1) which only allows local connections. Use '0.0.0.0' to allow connections from other machines on your network (security risk - use carefully). For most debugging and development purposes, the default 'localhost' is recommended for security. IPv6 addresses are also supported (e.g., '::1' for IPv6 localhost).")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting port forward setup and connection issues. Set to 'true' to see detailed information about the port forward session establishment, traffic flow, and any connection errors. Set to 'false' or omit for normal output. Debug mode is helpful when diagnosing connectivity issues or when the port forward fails to establish properly.")),
+		mcp.WithArray("ports",
+			mcp.WithStringItems(),
+			mcp.Description("Forward several ports in one session, like 'kubectl port-forward pod 8080:80 9090:9090'. Each entry is 'localPort:podPort', 'port' for the same port on both sides, or ':podPort' for a free local port. Replaces localPort/podPort. Local ports must not repeat; mappings whose local port cannot be bound are reported individually.")),
+		mcp.WithBoolean("verify",
+			mcp.Description("After the forward is established, check that the pod port is actually listening by connecting through it. If the check fails the forward is stopped and the reason is returned. Defaults to false.")),
+		mcp.WithString("healthPath",
+			mcp.Description("HTTP path (e.g. '/healthz') to GET through the forward, through the first mapping when several ports are forwarded, as part of verification; a status of 400 or above fails it. Implies verify.")),
+	)
+}
+
+// ValidateManifestTool validates a manifest against the cluster without creating anything
+func ValidateManifestTool() mcp.Tool {
+	logrus.Debug("Creating ValidateManifestTool")
+	return mcp.NewTool("kubernetes_validate_manifest",
+		mcp.WithDescription("Validate a manifest before creating or applying it, without changing anything in the cluster. Resolves each document's apiVersion and kind through discovery and checks the object against the cluster's OpenAPI schema: field types, required fields, unknown fields and enum values, each reported with its field path. Also reports whether the target namespace exists and warns about deprecated or removed apiVersions with their replacement. Use it as a preflight for generated YAML; then create the resources with kubernetes_create_resource."),
+		mcp.WithString("manifest", mcp.Required(),
+			mcp.Description("The manifest as YAML or JSON. Several YAML documents separated by '---' are validated one by one. A JSON object is also accepted.")),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace assumed for namespaced objects that do not set metadata.namespace. Defaults to 'default'.")),
 	)
 }
 
@@ -129,20 +345,68 @@ func PortForwardTool() mcp.Tool {
 func CreateResourceTool() mcp.Tool {
 	logrus.Debug("Creating CreateResourceTool")
 	return mcp.NewTool("kubernetes_create_resource",
-		mcp.WithDescription("Create a Kubernetes resource. Use this when the resource does not already exist. Provide `metadata` as an object and `spec` as an object when the resource kind uses `spec`."),
-		mcp.WithString("kind", mcp.Required(),
-			mcp.Description("Kubernetes resource kind, for example `Namespace`, `Deployment`, `Service`, or `ConfigMap`. Use the exact API kind name.")),
-		mcp.WithString("apiVersion", mcp.Required(),
-			mcp.Description("Kubernetes API version, for example `v1`, `apps/v1`, or `networking.k8s.io/v1`.")),
-		mcp.WithObject("metadata", mcp.Required(),
-			mcp.Description("Resource metadata object. `metadata.name` or `metadata.generateName` is required for creation. Include `namespace` for namespaced resources. Labels and annotations are also accepted. An empty metadata object will be rejected before the Kubernetes API call. Legacy clients may still send this as a JSON string.")),
+		mcp.WithDescription("Create a Kubernetes resource. Use this when the resource does not already exist. Either provide `metadata` as an object and `spec` as an object when the resource kind uses `spec`, or pass a complete YAML or JSON object as `manifest`, as you would to `kubectl create -f`."),
+		mcp.WithString("kind",
+			mcp.Description("Kubernetes resource kind, for example `Namespace`, `Deployment`, `Service`, or `ConfigMap`. Use the exact API kind name. Required unless `manifest` is given; when both are set they must match.")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Kubernetes API version, for example `v1`, `apps/v1`, or `networking.k8s.io/v1`. Required unless `manifest` is given; when both are set they must match.")),
+		mcp.WithObject("metadata",
+			mcp.Description("Resource metadata object. `metadata.name` or `metadata.generateName` is required for creation. Include `namespace` for namespaced resources. Labels and annotations are also accepted. An empty metadata object will be rejected before the Kubernetes API call. Required unless `manifest` is given. Legacy clients may still send this as a JSON or YAML string.")),
 		mcp.WithObject("spec",
-			mcp.Description("Resource spec object for kinds that use `spec`, for example Deployments and Services. Omit for kinds such as `Namespace` that do not require `spec`. Legacy clients may still send this as a JSON string.")),
+			mcp.Description("Resource spec object for kinds that use `spec`, for example Deployments and Services. Omit for kinds such as `Namespace` that do not require `spec`. Legacy clients may still send this as a JSON or YAML string.")),
+		mcp.WithString("manifest",
+			mcp.Description("Complete resource as a single YAML or JSON document, including `apiVersion`, `kind` and `metadata`. Top-level fields other than `spec`, such as ConfigMap `data` or Role `rules`, are kept. Cannot be combined with `metadata` or `spec`.")),
+		mcp.WithString("inputFormat",
+			mcp.Description("How `manifest`, `metadata` and `spec` strings are decoded. `auto` (default) treats text starting with `{` as JSON and anything else as YAML."),
+			mcp.Enum("auto", "json", "yaml")),
 		mcp.WithString("debug",
 			mcp.Description("Enable debug output for troubleshooting request validation and Kubernetes API errors.")),
 	)
 }
 
+// CreateResourcesTool creates every object of a multi-document manifest
+func CreateResourcesTool() mcp.Tool {
+	logrus.Debug("Creating CreateResourcesTool")
+	return mcp.NewTool("kubernetes_create_resources",
+		mcp.WithDescription("Create a small bundle of resources in one call from a multi-document YAML manifest or a JSON array of objects. Objects are created in dependency order: Namespaces and CustomResourceDefinitions first, then ServiceAccounts, Secrets, ConfigMaps, RBAC, Services and workloads; custom resources defined by a CRD in the same bundle wait until the API server serves them. Returns the outcome of every object. With `atomic` set, the first failure deletes the objects already created and skips the rest. Run kubernetes_validate_manifest first to catch schema errors."),
+		mcp.WithString("manifest", mcp.Required(),
+			mcp.Description("Objects to create, as YAML documents separated by '---' or a JSON array of objects. Every object needs apiVersion, kind and metadata.name or metadata.generateName.")),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace used for namespaced objects that do not set metadata.namespace. Defaults to 'default'.")),
+		mcp.WithBoolean("atomic",
+			mcp.Description("When an object fails, delete the objects created so far and skip the remaining ones (default: false, which keeps going and reports each failure).")),
+		timeoutSecondsOption(),
+	)
+}
+
+// UpdateResourceTool replaces an existing resource with a full manifest
+func UpdateResourceTool() mcp.Tool {
+	logrus.Debug("Creating UpdateResourceTool")
+	destructive := true
+	return mcp.NewTool("kubernetes_update_resource",
+		mcp.WithDescription("Replace an existing Kubernetes resource with a complete manifest, as `kubectl replace -f` does. Fields left out of the manifest are removed, so read the resource first and edit it; prefer kubernetes_patch_resource for small changes. A manifest carrying `metadata.resourceVersion` is only applied if nobody changed the resource since: otherwise the call fails with a conflict, unless `autoResolveConflict` merges the changes."),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Kubernetes resource kind, for example `Deployment` or `ConfigMap`. Must match the manifest's kind.")),
+		mcp.WithString("name",
+			mcp.Description("Resource name. Defaults to the manifest's `metadata.name`; when both are set they must match.")),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace for namespaced resources. Defaults to the manifest's `metadata.namespace`; omit for cluster-scoped resources.")),
+		mcp.WithString("manifest", mcp.Required(),
+			mcp.Description("Complete resource as a single YAML or JSON document, including `apiVersion`, `kind` and `metadata.name`. Keep `metadata.resourceVersion` from the object you read to guard against overwriting concurrent changes.")),
+		mcp.WithString("inputFormat",
+			mcp.Description("How `manifest` is decoded. `auto` (default) treats text starting with `{` as JSON and anything else as YAML."),
+			mcp.Enum("auto", "json", "yaml")),
+		mcp.WithBoolean("autoResolveConflict",
+			mcp.Description("When the manifest's resourceVersion is stale, merge the manifest's changes onto the current object instead of failing, as long as the same fields were not changed on the server since. Fields changed on both sides still fail with a conflict listing them (default: false)."),
+			mcp.DefaultBool(false)),
+		mcp.WithToolAnnotation(
+			mcp.ToolAnnotation{
+				DestructiveHint: &destructive,
+			},
+		),
+	)
+}
+
 // ListResourcesTool lists Kubernetes resources of a given kind
 func ListResourcesTool() mcp.Tool {
 	logrus.Debug("Creating ListResourcesTool")
@@ -152,21 +416,27 @@ func ListResourcesTool() mcp.Tool {
 			mcp.Description("Kubernetes resource kind/type to list - the category of resources you want to discover. Common resource types include: 'Pod' (running containers and applications), 'Service' (network services and load balancing), 'Deployment' (application deployments and replica management), 'ConfigMap' (configuration data), 'Secret' (sensitive information like passwords and certificates), 'Ingress' (HTTP/HTTPS routing rules), 'PersistentVolume' and 'PersistentVolumeClaim' (storage resources), 'Namespace' (resource organization and isolation), 'Node' (cluster infrastructure), 'DaemonSet' (node-wide services), 'StatefulSet' (stateful applications), 'Job' and 'CronJob' (batch workloads), 'ServiceAccount' (identity and permissions), 'Role' and 'ClusterRole' (RBAC permissions), 'CustomResource' (custom resource definitions). Use exact case-sensitive names as they appear in Kubernetes API (e.g., 'Pod' not 'pod'). If unsure about available resource types, try common ones first.")),
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace to scope the resource listing. For namespaced resources (Pod, Service, Deployment, ConfigMap, Secret, etc.), this filters results to only show resources within the specified namespace. If omitted for namespaced resources, shows resources from ALL namespaces (requires cluster-wide list permissions). For cluster-scoped resources (Node, ClusterRole, PersistentVolume, etc.), this parameter is ignored. Common namespaces include: 'default' (default namespace for user resources), 'kube-system' (system components and cluster services), 'kube-public' (publicly accessible resources), 'kube-node-lease' (node heartbeat data), or custom application namespaces. Use this to narrow down results when working with specific applications or environments. Leave empty to get a cluster-wide view.")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Optional apiVersion (e.g. 'networking.k8s.io/v1', 'cert-manager.io/v1') pinning which API group serves the kind. Only needed when the same kind exists in several API groups; in that case the tool returns an error listing the candidate apiVersions.")),
 		mcp.WithString("labelSelector",
 			mcp.Description("Label selector to filter resources based on their metadata labels. Labels are key-value pairs attached to resources for organization and selection. Use this powerful filtering mechanism to find resources matching specific criteria. Syntax examples: 'app=nginx' (resources with label app=nginx), 'env=production' (production environment resources), 'app=nginx,env=prod' (multiple labels with AND logic), 'tier in (frontend,backend)' (resources with tier label having specific values), 'app!=legacy' (exclude resources with app=legacy), 'version' (resources that have a 'version' label regardless of value), '!debug' (resources that do NOT have a 'debug' label). Common label patterns: 'app' (application name), 'version' (application version), 'env' (environment like dev/staging/prod), 'tier' (application tier like frontend/backend), 'release' (deployment release). Combine multiple selectors with commas for AND logic.")),
 		mcp.WithString("fieldSelector",
 			mcp.Description("Field selector to filter resources based on specific field values. This provides more precise filtering compared to label selectors. Common examples: 'involvedObject.name=my-pod' (events for a specific pod), 'involvedObject.kind=Pod' (all pod-related events), 'type=Warning' (only warning events), 'type=Normal' (only normal events), 'reason=Failed' (events with Failed reason), 'involvedObject.namespace=my-namespace' (events for resources in specific namespace), 'status.phase=Running' (running pods), 'status.phase!=Failed' (exclude failed resources). You can combine multiple selectors with commas. Field selectors work on actual resource fields rather than labels and are useful for filtering by runtime state or resource properties.")),
 		mcp.WithNumber("limit",
-			mcp.Description("Maximum number of resources to return in a single page (default: 100, max: 500). This parameter enables pagination and prevents context overflow by limiting response size. Use smaller limits (10-50) for quick overviews or when you only need a few resources. Use larger limits (100-500) for comprehensive analysis. When 'hasMore' is true in the response, use the 'continueToken' to fetch the next page. The pagination is handled by the Kubernetes API server, so this is more efficient than client-side limiting.")),
+			mcp.Description("Maximum number of resources to return in a single page (default: 30, max: 80). This parameter enables pagination and prevents context overflow by limiting response size. Use smaller limits (10-30) for quick overviews or when you only need a few resources. Use larger limits (50-80) for comprehensive analysis. When 'hasMore' is true in the response, use the 'continueToken' to fetch the next page. The pagination is handled by the Kubernetes API server, so this is more efficient than client-side limiting.")),
 		mcp.WithString("continueToken",
 			mcp.Description("Pagination token from a previous response to fetch the next page of results. When the response indicates 'hasMore': true, use the provided 'continueToken' to get the next batch of resources. This enables efficient pagination through large result sets without loading all data into memory. Leave empty for the first page request. The token is opaque and should be used exactly as provided in the previous response's 'pagination.continueToken' field.")),
 		mcp.WithString("jsonpath",
 			mcp.Description("Single JSONPath expression to extract fields from each resource. Full expressions like `{.metadata.name}` and bare paths like `metadata.name` are accepted. For formatted output you can still use range expressions such as `{range .items[*]}{.metadata.name}{\"\\n\"}{end}`.")),
 		mcp.WithArray("jsonpaths",
-			mcp.Description("Array of JSONPath expressions. You may pass either full expressions like `{.metadata.name}` or bare paths like `metadata.name`, which will be normalized automatically. Legacy clients may still send a JSON string array or comma-separated string."),
+			mcp.Description("Array of JSONPath expressions. You may pass either full expressions like `{.metadata.name}` or bare paths like `metadata.name`, which will be normalized automatically. Legacy clients may still send a JSON string array or comma-separated string. The result is a table with one row per resource: `columns` holds the header derived from the path names, `rows` holds the cell values, and `table` is a tab-separated rendering with a header row. Missing fields become empty cells. Invalid expressions are rejected before the API is called."),
 			mcp.WithStringItems()),
+		mcp.WithString("outputFormat",
+			mcp.Enum("json", "ndjson"),
+			mcp.Description("Response encoding: 'json' (default) or 'ndjson'. NDJSON emits one compact JSON object per line (each resource, or each row keyed by column name with 'jsonpaths') so output can be piped and processed incrementally; the last line is a control object with '_control': 'pagination', the count and the pagination details. Cannot be combined with 'jsonpath'.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting the tool execution and API interactions. Set to 'true' to see detailed information about the Kubernetes API calls, authentication process, request/response details, pagination tokens, and any filtering operations being applied. Set to 'false' or omit for normal output showing only the resource information. Debug mode is helpful when: the tool is not returning expected results, you're getting authentication or permission errors, pagination is not working as expected, or you're troubleshooting connectivity issues. Normal users should leave this unset or set to 'false' for cleaner output.")),
+		timeoutSecondsOption(),
 	)
 }
 
@@ -179,14 +449,22 @@ func ListResourcesSummaryTool() mcp.Tool {
 			mcp.Description("Resource kind to list (Pod, Deployment, Service, ConfigMap, etc.). Use exact case-sensitive names as they appear in Kubernetes API. Common types: Pod, Service, Deployment, ConfigMap, Secret, Namespace, Node, Ingress, StatefulSet, DaemonSet, Job, CronJob.")),
 		mcp.WithString("namespace",
 			mcp.Description("Optional namespace filter. Omit for cluster-wide listing across all namespaces (requires cluster-wide permissions). For namespaced resources, this limits results to the specified namespace. Ignored for cluster-scoped resources like Node, PersistentVolume, ClusterRole.")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Optional apiVersion (e.g. 'networking.k8s.io/v1', 'cert-manager.io/v1') pinning which API group serves the kind. Only needed when the same kind exists in several API groups; in that case the tool returns an error listing the candidate apiVersions.")),
 		mcp.WithString("labelSelector",
 			mcp.Description("Optional label selector for filtering resources (e.g., 'app=nginx', 'env=production', 'tier in (frontend,backend)'). Use combination with commas for AND logic: 'app=nginx,env=prod'. This helps narrow down results to specific applications or environments.")),
 		mcp.WithString("includeLabels",
 			mcp.Description("Optional comma-separated label keys to include in the summary output (e.g., 'app,version,env'). When specified, only these labels will be included for each resource. If omitted, non-Pod resources may include up to 10 labels automatically, while Pod summaries omit labels by default to reduce response size.")),
+		mcp.WithBoolean("includeContainerStatuses",
+			mcp.Description("For Pods, append each container's ready state, restart count and waiting/terminated reason (e.g. CrashLoopBackOff, OOMKilled) under 'containers'. Default false to keep summaries small.")),
 		mcp.WithNumber("limit",
-			mcp.Description("Maximum number of resources to return (default: 100, max: 500). This enables server-side pagination to prevent context overflow. Use smaller values (10-50) for quick overviews, larger values (100-500) for comprehensive analysis. Pagination is handled by Kubernetes API for efficiency.")),
+			mcp.Description("Maximum number of resources to return (default: 30, max: 80). This enables server-side pagination to prevent context overflow. Use smaller values (10-30) for quick overviews, larger values (50-80) for comprehensive analysis. Pagination is handled by Kubernetes API for efficiency.")),
 		mcp.WithString("continueToken",
 			mcp.Description("Pagination token from previous response to fetch the next page. When response indicates 'hasMore': true, use the provided 'continueToken' to get the next batch. Leave empty for the first request. This enables efficient traversal of large result sets without loading all data.")),
+		mcp.WithString("outputFormat",
+			mcp.Enum("json", "ndjson", "csv"),
+			mcp.Description("Response encoding: 'json' (default), 'ndjson' or 'csv'. NDJSON emits one summary object per line for incremental processing; the last line is a control object with '_control': 'pagination', the count and the pagination details. CSV emits a header row and one row per resource with kind, namespace, name, status and age, plus a 'labels.<key>' column per includeLabels key (or a single 'labels' column of key=value pairs), ready to paste into a spreadsheet; container statuses are not included. The count and pagination follow in a separate JSON content block.")),
+		timeoutSecondsOption(),
 	)
 }
 
@@ -201,6 +479,11 @@ func GetResourceDetailsTool() mcp.Tool {
 			mcp.Description("The exact name of the specific resource instance to retrieve detailed information for. This must match the metadata.name field of the resource exactly as it exists in the cluster. Resource names are case-sensitive and must follow Kubernetes naming conventions (lowercase alphanumeric characters, hyphens, and dots are allowed, but no spaces or special characters). For resources created by controllers (like Pods created by Deployments), the name will include generated suffixes or prefixes. If you're unsure about the exact resource name, use the 'list_resources' tool first to discover available resources and their exact names. Examples: 'nginx-deployment-7fb96c846b-xyz12' (for a Pod), 'my-app-service' (for a Service), 'web-app-deployment' (for a Deployment). The name must exist in the specified namespace (for namespaced resources) or in the cluster (for cluster-scoped resources).")),
 		mcp.WithString("namespace",
 			mcp.Description("The Kubernetes namespace where the resource is located. This parameter is REQUIRED for namespaced resources (such as Pod, Service, Deployment, ConfigMap, Secret, Ingress, PersistentVolumeClaim, ServiceAccount, Role, RoleBinding, etc.) but should be OMITTED for cluster-scoped resources (such as Node, PersistentVolume, ClusterRole, ClusterRoleBinding, Namespace itself, etc.). If you're unsure whether a resource type is namespaced or cluster-scoped, try the operation without specifying a namespace first - the error message will indicate if a namespace is required. Common namespace examples: 'default' (the default namespace if none was specified during resource creation), 'kube-system' (for Kubernetes system components), 'kube-public' (for publicly accessible resources), or custom application namespaces like 'production', 'staging', 'development'. Use the 'list_resources' tool to discover which namespaces contain your target resources if uncertain.")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Optional apiVersion (e.g. 'networking.k8s.io/v1', 'cert-manager.io/v1') pinning which API group serves the kind. Only needed when the same kind exists in several API groups; in that case the tool returns an error listing the candidate apiVersions.")),
+		mcp.WithString("outputFormat",
+			mcp.Enum("json", "yaml"),
+			mcp.Description("Response encoding: 'json' (default) or 'yaml'. YAML is convenient for reviewing configuration as it would appear in a manifest.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable comprehensive debug output for troubleshooting the tool execution and API interactions. Set to 'true' to see detailed information including: Kubernetes API endpoints being called, authentication and authorization details, request and response headers and bodies, error messages and stack traces, timing information for API calls, and internal tool processing steps. Set to 'false' or omit this parameter for normal operation with standard output showing only the resource details. Debug mode is particularly useful when: the tool is not returning expected results, you're getting authentication or permission errors, the resource seems to exist but cannot be retrieved, you need to understand the underlying API calls for automation purposes, or when reporting issues with the tool itself. Note that debug output may contain sensitive information and should be used carefully in production environments.")),
 	)
@@ -228,21 +511,72 @@ func DeleteResourceTool() mcp.Tool {
 	)
 }
 
+// DeleteResourcesByLabelTool deletes all resources of a kind in a namespace that match a label selector
+func DeleteResourcesByLabelTool() mcp.Tool {
+	logrus.Debug("Creating DeleteResourcesByLabelTool")
+	destructive := true
+	return mcp.NewTool("kubernetes_delete_resources_by_label",
+		mcp.WithDescription("Permanently delete every resource of a `kind` in a `namespace` that matches `labelSelector`. IRREVERSIBLE and DESTRUCTIVE. Run with `dryRun: true` first to list what would be deleted, then repeat with `confirmed: true`. Deletes the listed resources one by one up to `limit`, so resources that start matching mid-call are left alone, and reports `truncated: true` when more remain. Returns the names removed."),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Resource kind to delete, e.g. Pod, ConfigMap, Job.")),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace to delete resources from. Batch deletes are always scoped to a single namespace.")),
+		mcp.WithString("labelSelector", mcp.Required(),
+			mcp.Description("Label selector the resources must match, e.g. `app=web,tier!=db`. An empty selector is rejected.")),
+		mcp.WithBoolean("confirmed",
+			mcp.Description("Must be set to true to perform the deletion. Not required when dryRun is true."),
+			mcp.DefaultBool(false)),
+		mcp.WithBoolean("dryRun",
+			mcp.Description("List the resources that would be deleted without deleting anything."),
+			mcp.DefaultBool(false)),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of resources to delete in this call (default 50, max 200).")),
+		mcp.WithToolAnnotation(
+			mcp.ToolAnnotation{
+				DestructiveHint: &destructive,
+			},
+		),
+	)
+}
+
 // ContainerLogsTool retrieves logs from a Pod container
 func ContainerLogsTool() mcp.Tool {
 	logrus.Debug("Creating ContainerLogsTool")
 	return mcp.NewTool("kubernetes_get_pod_logs",
-		mcp.WithDescription("Read logs from a Pod container. Use this after you identify the target pod, and specify `container` when the pod has more than one container."),
+		mcp.WithDescription("Read logs from a Pod container. Use this after you identify the target pod, and specify `container` when the pod has more than one container, or set `allContainers` to read every container at once."),
 		mcp.WithString("name", mcp.Required(),
 			mcp.Description("Exact name of the Pod from which to retrieve container logs. The pod name must match exactly as it appears in Kubernetes and is case-sensitive. Pod names typically follow patterns like 'deployment-name-random-suffix' for pods created by Deployments, or custom names for manually created pods. Use 'list_resources' tool with kind='Pod' first if you're unsure of the exact pod name. The pod can be in any state (Running, Pending, Failed, Succeeded) but must exist in the cluster. For pods created by controllers like Deployments, the name includes generated suffixes (e.g., 'nginx-deployment-abc123-xyz789').")),
 		mcp.WithString("namespace", mcp.Required(),
 			mcp.Description("Kubernetes namespace where the target Pod is located. This is required since Pods are namespaced resources. Common namespaces include 'default' (default namespace for user workloads), 'kube-system' (system components and cluster services), 'kube-public' (publicly accessible resources), or custom application namespaces like 'production', 'staging', 'development'. If you're unsure about the namespace, use 'list_resources' tool with kind='Pod' to discover pods across namespaces. Namespace names are case-sensitive and must match exactly.")),
 		mcp.WithString("container",
 			mcp.Description("Name of the specific container within the Pod to retrieve logs from. This parameter is REQUIRED for multi-container pods since each container has separate logs. For single-container pods, this parameter is optional and will default to the only container. Container names are defined in the Pod specification under spec.containers[].name field. Common container names include 'app', 'main', 'web', 'api', or descriptive names like 'nginx', 'redis', 'database'. Use 'get_resource' or 'describe_resource' tools to inspect the pod and find container names if needed. If you specify a non-existent container name, the operation will fail with an error.")),
+		mcp.WithBoolean("allContainers",
+			mcp.Description("Read every container of the pod, including started init containers, like 'kubectl logs --all-containers --prefix'. Lines are interleaved by time and prefixed with '[container] '; tailLines applies to each container. Containers whose logs cannot be read are listed under metadata.containerErrors. Cannot be combined with container.")),
 		mcp.WithNumber("tailLines",
 			mcp.Description("Maximum number of recent log lines to retrieve from the end of the log stream. This helps limit output size and focus on recent activity. Default is 50 lines if not specified. Maximum allowed value is 200 lines to prevent context overflow. Common values: 50 (quick check of recent activity), 100-200 (standard troubleshooting). If logs exceed 10KB or 50KB in size, they will be automatically truncated to the last 200 lines or 50KB of characters to maintain performance. For very active applications, even 200 lines might represent only a few seconds of activity. Use smaller values for quick checks and larger values only when detailed historical context is needed for debugging complex issues.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting the log retrieval operation itself. Set to 'true' to see detailed information about the API calls, authentication, pod discovery, and any errors encountered while accessing logs. Set to 'false' or omit for normal output showing only the container logs. Debug mode is useful when: the tool fails to retrieve logs, you're getting authentication errors, the pod or container cannot be found, or when you need to understand the underlying Kubernetes API interactions. This debug output is separate from and in addition to the actual container logs.")),
+		timeoutSecondsOption(),
+	)
+}
+
+// WorkloadLogsTool retrieves recent logs from every pod of a workload
+func WorkloadLogsTool() mcp.Tool {
+	logrus.Debug("Creating WorkloadLogsTool")
+	return mcp.NewTool("kubernetes_get_logs_multi",
+		mcp.WithDescription("Read recent logs from every running pod of a Deployment, StatefulSet or DaemonSet in one call. Pods are resolved through the workload's selector and each log is labeled with its pod and container. Pods that are not running are listed under 'skipped'. Use kubernetes_get_pod_logs for more lines from a single pod."),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Enum("Deployment", "StatefulSet", "DaemonSet"),
+			mcp.Description("Workload kind.")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Exact workload name.")),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace of the workload.")),
+		mcp.WithString("container",
+			mcp.Description("Only read this container. Pods without it are skipped. If omitted, every container of each pod is read.")),
+		mcp.WithNumber("tailLines",
+			mcp.Description("Lines to read from the end of each container log (default 20, max 200). At most 20 pods are read and the combined output is capped at 50KB.")),
+		timeoutSecondsOption(),
 	)
 }
 
@@ -250,7 +584,7 @@ func ContainerLogsTool() mcp.Tool {
 func ContainerExecTool() mcp.Tool {
 	logrus.Debug("Creating ContainerExecTool")
 	return mcp.NewTool("kubernetes_pod_exec",
-		mcp.WithDescription("Execute commands inside a running container within a Kubernetes Pod, similar to 'kubectl exec'. This tool provides direct access to the container's runtime environment for debugging, troubleshooting, and administrative tasks. Use this tool when you need to: investigate application issues by examining files or processes inside containers, run diagnostic commands to check connectivity or resource usage, access application logs or configuration files directly, perform maintenance tasks like clearing caches or temporary files, test network connectivity from within the container, or install debugging tools temporarily. The pod must be in 'Running' state for command execution to work. Commands are executed with the same user privileges as the container's main process unless the container runs as root. Be cautious with destructive commands as they can affect the running application. For security reasons, avoid executing commands that modify critical system files or expose sensitive information."),
+		mcp.WithDescription("Execute commands inside a running container within a Kubernetes Pod, similar to 'kubectl exec'. This tool provides direct access to the container's runtime environment for debugging, troubleshooting, and administrative tasks. Use this tool when you need to: investigate application issues by examining files or processes inside containers, run diagnostic commands to check connectivity or resource usage, access application logs or configuration files directly, perform maintenance tasks like clearing caches or temporary files, test network connectivity from within the container, or install debugging tools temporarily. The pod must be in 'Running' state for command execution to work. Commands are executed with the same user privileges as the container's main process unless the container runs as root. Be cautious with destructive commands as they can affect the running application. For security reasons, avoid executing commands that modify critical system files or expose sensitive information. Returns 'stdout', 'stderr' and 'exitCode' as separate fields. The result is marked as an error only when the exit code is non-zero or the exec stream fails, in which case 'error' describes the failure and the output captured so far is kept."),
 		mcp.WithString("podName", mcp.Required(),
 			mcp.Description("Exact name of the target Pod where the command will be executed. The pod must be in 'Running' state for command execution to succeed. Pod names are case-sensitive and must match exactly as they appear in the cluster. For pods created by Deployments or other controllers, the name typically includes generated suffixes (e.g., 'nginx-deployment-7fb96c846b-xyz12'). Use 'list_resources' tool with kind='Pod' to discover available pod names if needed. The pod must exist and be accessible - if it's in Pending, Failed, or Succeeded state, command execution will fail. Multi-container pods require specifying the target container name in the containerName parameter.")),
 		mcp.WithString("namespace", mcp.Required(),
@@ -259,8 +593,11 @@ func ContainerExecTool() mcp.Tool {
 			mcp.Description("Name of the specific container within the Pod where the command should be executed. This parameter is REQUIRED for multi-container pods since you must specify which container to target. For single-container pods, this parameter is optional and will default to the only available container. Container names are defined in the Pod specification under spec.containers[].name field. Common container names include 'app', 'main', 'web', 'api', 'sidecar', or descriptive names like 'nginx', 'redis', 'database', 'proxy'. Use 'get_resource' or 'describe_resource' tools to inspect the pod specification and find the correct container names. If you specify a non-existent container name, the operation will fail with a 'container not found' error. Each container has its own filesystem and process space, so choose the container that contains the files or processes you need to access.")),
 		mcp.WithString("command", mcp.Required(),
 			mcp.Description("Command to execute inside the container. Preferred form: a JSON array string such as '[\"ls\",\"-la\",\"/app\"]' or '[\"sh\",\"-c\",\"env | sort\"]' because it preserves argument boundaries and quoting. Compatibility form: a plain shell-style string such as 'ls -la /app', which the server splits on whitespace. Use the JSON-array form when arguments contain spaces, quoting, or shell operators. Use '[\"sh\",\"-c\",\"...\"]' for pipes, redirects, or compound shell expressions.")),
+		mcp.WithString("stdin",
+			mcp.Description("Optional text piped to the command's standard input, like 'kubectl exec -i' without a TTY. Use it to feed input to commands that read stdin, e.g. SQL to '[\"psql\",\"-U\",\"app\"]' or a file body to '[\"sh\",\"-c\",\"cat > /tmp/input\"]'. Passed through verbatim, including trailing newlines. The stream is closed after the text is sent.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting command execution and container access issues. Set to 'true' to see detailed information about the execution process including: connection establishment to the pod, container selection process, command parsing and validation, execution environment details, and any errors during command execution. Set to 'false' or omit for normal output showing only the command results. Debug mode is helpful when: commands fail to execute with unclear errors, you're getting permission or access denied errors, the container or pod cannot be found, network connectivity issues prevent execution, or when you need to understand the execution environment. Debug output helps identify issues like incorrect container names, pod states that prevent execution, or API communication problems. Use debug mode when troubleshooting tool behavior, not for debugging the applications inside containers.")),
+		timeoutSecondsOption(),
 	)
 }
 
@@ -313,7 +650,7 @@ func TestTool() mcp.Tool {
 func ScaleResourceTool() mcp.Tool {
 	logrus.Debug("Creating ScaleResourceTool")
 	return mcp.NewTool("kubernetes_scale_resource",
-		mcp.WithDescription("Scale a Kubernetes workload resource by adjusting the number of replica instances, similar to 'kubectl scale'. This tool modifies the replica count for scalable resources like Deployments, StatefulSets, and ReplicaSets to handle varying load demands, perform maintenance, or optimize resource usage. Scaling is a fundamental operation for managing application availability and resource consumption. Use this tool when you need to: increase replicas to handle higher traffic loads, decrease replicas to save resources during low-demand periods, scale down to zero for maintenance or cost optimization, quickly respond to performance issues, or test application behavior under different replica counts. The scaling operation is performed by updating the resource's spec.replicas field. For Deployments, this triggers a rolling update process. For StatefulSets, scaling respects ordered startup/shutdown procedures. Always consider the impact on application availability and resource constraints when scaling. Monitor resource usage and application performance after scaling operations to ensure desired outcomes."),
+		mcp.WithDescription("Scale a Kubernetes workload resource by adjusting the number of replica instances, similar to 'kubectl scale'. This tool modifies the replica count for scalable resources like Deployments, StatefulSets, and ReplicaSets to handle varying load demands, perform maintenance, or optimize resource usage. Scaling is a fundamental operation for managing application availability and resource consumption. Use this tool when you need to: increase replicas to handle higher traffic loads, decrease replicas to save resources during low-demand periods, scale down to zero for maintenance or cost optimization, quickly respond to performance issues, or test application behavior under different replica counts. The scaling operation is performed by updating the resource's spec.replicas field. For Deployments, this triggers a rolling update process. For StatefulSets, scaling respects ordered startup/shutdown procedures. Always consider the impact on application availability and resource constraints when scaling. Monitor resource usage and application performance after scaling operations to ensure desired outcomes. The response includes previousReplicas for auditing; set dryRun to preview the change first."),
 		mcp.WithString("kind", mcp.Required(),
 			mcp.Description("Kubernetes resource kind (type) that supports scaling operations. This must be a scalable resource type with replica management capabilities. Supported resource kinds include: 'Deployment' (most common - for stateless applications that can be scaled horizontally with rolling updates), 'StatefulSet' (for stateful applications requiring ordered scaling with persistent identity and storage), 'ReplicaSet' (lower-level replica controller, though Deployments are preferred for most use cases), 'ReplicationController' (legacy resource, Deployments are recommended instead). The kind is case-sensitive and must match exactly as defined in Kubernetes API (e.g., 'Deployment' not 'deployment'). Note: Not all Kubernetes resources support scaling - resources like Pod, Service, ConfigMap, Secret cannot be scaled directly. Use 'list_resources' or 'get_resource_details' tools first to verify the resource type and current replica count if uncertain. For most application workloads, 'Deployment' is the appropriate choice.")),
 		mcp.WithString("name", mcp.Required(),
@@ -322,6 +659,9 @@ func ScaleResourceTool() mcp.Tool {
 			mcp.Description("Kubernetes namespace where the target scalable resource is located. This is required since scalable resources like Deployments, StatefulSets, and ReplicaSets are namespaced resources. The namespace must exist and contain the specified resource. Common namespaces include: 'default' (default namespace for user workloads), 'kube-system' (system components - be very careful when scaling these), 'kube-public' (publicly accessible resources), or custom application namespaces like 'production', 'staging', 'development', 'web-app', 'api-services'. Namespace names are case-sensitive and must match exactly. Use 'list_resources' tool to verify which namespace contains your target resource if uncertain. Be especially cautious when scaling resources in 'kube-system' namespace as these are often critical cluster components that could affect cluster stability if scaled incorrectly.")),
 		mcp.WithNumber("replicas", mcp.Required(),
 			mcp.Description("Target number of replica instances (pods) that the resource should maintain after scaling. This must be a non-negative integer (0 or greater). Common scaling scenarios: Set to 0 to completely stop the application (useful for maintenance, cost savings, or troubleshooting), Set to 1 for minimal resource usage while keeping the application available, Set to 2-3 for basic high availability and load distribution, Set to higher values (5, 10, 20+) for high-traffic applications requiring horizontal scaling. Consider these factors when choosing replica count: Available cluster resources (CPU, memory, storage), Application resource requirements per replica, Load balancing and traffic distribution needs, Budget and cost constraints, Disaster recovery and availability requirements. For StatefulSets, scaling up creates new instances with persistent identity; scaling down removes the highest-numbered instances first. For Deployments, scaling triggers rolling updates if the pod template has changed. Monitor cluster resource usage after scaling to ensure sufficient capacity. The operation may fail if there are insufficient cluster resources to support the requested replica count.")),
+		mcp.WithBoolean("dryRun",
+			mcp.Description("Preview the change without applying it: reports the current (previousReplicas) and requested replica counts. The update is sent as a server-side dry run, so validation and admission errors still surface."),
+			mcp.DefaultBool(false)),
 		mcp.WithString("debug",
 			mcp.Description("Enable comprehensive debug output for troubleshooting the scaling operation and understanding the process details. Set to 'true' to see detailed information including: Kubernetes API calls and responses, current resource state before scaling, scaling operation progress and status, any errors or warnings during the scaling process, resource validation and permission checks, timing information for the scaling operation. Set to 'false' or omit for normal output showing only the scaling result and final status. Debug mode is particularly helpful when: scaling operations fail or behave unexpectedly, you need to understand why scaling is taking longer than expected, there are resource constraints or quota limitations, you're troubleshooting RBAC permission issues, or when you want to monitor the scaling process in detail for learning or automation purposes. Debug output may include sensitive cluster information, so use carefully in production environments.")),
 	)
@@ -345,6 +685,26 @@ func GetRolloutStatusTool() mcp.Tool {
 	)
 }
 
+// ResourceDiffRevisionsTool lists the revisions of a workload and diffs the pod template of two of them
+func ResourceDiffRevisionsTool() mcp.Tool {
+	logrus.Debug("Creating ResourceDiffRevisionsTool")
+	return mcp.NewTool("kubernetes_get_resource_diff_revisions",
+		mcp.WithDescription("List the rollout history of a workload and show what changed in its pod template between two revisions, like 'kubectl rollout history --revision' for both sides. Deployment revisions are its ReplicaSets; StatefulSet and DaemonSet revisions are their ControllerRevisions. Each revision reports its number, age, images and change cause, newest first. By default the current revision is compared with the one before it, explaining what the last rollout changed. Use kubernetes_get_rollout_status for the progress of a running rollout."),
+		mcp.WithString("kind",
+			mcp.Enum("Deployment", "StatefulSet", "DaemonSet"),
+			mcp.Description("Workload kind (default Deployment).")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Exact workload name.")),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace of the workload.")),
+		mcp.WithNumber("fromRevision",
+			mcp.Description("Older revision to compare. Defaults to the newest revision before toRevision.")),
+		mcp.WithNumber("toRevision",
+			mcp.Description("Newer revision to compare. Defaults to the newest revision.")),
+		mcp.WithReadOnlyHintAnnotation(true),
+	)
+}
+
 // CordonNodeTool marks a node unschedulable.
 func CordonNodeTool() mcp.Tool {
 	logrus.Debug("Creating CordonNodeTool")
@@ -455,15 +815,23 @@ func GetAPIResourcesTool() mcp.Tool {
 	)
 }
 
+// RefreshDiscoveryTool drops the cached API discovery results and discovers them again
+func RefreshDiscoveryTool() mcp.Tool {
+	logrus.Debug("Creating RefreshDiscoveryTool")
+	return mcp.NewTool("kubernetes_refresh_discovery",
+		mcp.WithDescription("Force a refresh of the cached API groups and resources used to resolve kinds. Discovery results are cached per API server (`kubernetes.discoveryCacheTTLSec`, default 10 minutes); call this after installing or removing a CRD so its kind resolves immediately. Returns the number of API groups, group versions and resources found, the age of the replaced cache, and any groups that failed discovery under `failedGroups`."),
+	)
+}
+
 // GetResourcesDetailTool retrieves detailed information for multiple resources efficiently
 func GetResourcesDetailTool() mcp.Tool {
 	logrus.Debug("Creating GetResourcesDetailTool")
 	return mcp.NewTool("kubernetes_get_resources_detail",
-		mcp.WithDescription("🔍 Efficiently retrieve detailed information for multiple specific Kubernetes resources in a single request. This tool is optimized for getting comprehensive details about multiple resources without overwhelming the context. Use this when you need detailed information about several specific resources identified from a previous list operation. The tool supports batch retrieval with pagination awareness and provides full resource details including configuration, status, events, and relationships. Ideal for: investigating specific resources identified in a list, getting complete details for a subset of resources, performing detailed analysis on selected items, or preparing for resource modification operations."),
+		mcp.WithDescription("🔍 Efficiently retrieve detailed information for multiple specific Kubernetes resources in a single request. This tool is optimized for getting comprehensive details about multiple resources without overwhelming the context. Use this when you need detailed information about several specific resources identified from a previous list operation. The tool supports batch retrieval with pagination awareness and provides full resource details including configuration, status, events, and relationships. Ideal for: investigating specific resources identified in a list, getting complete details for a subset of resources, performing detailed analysis on selected items, or preparing for resource modification operations. Names that cannot be retrieved are listed under 'errors' without failing the rest of the request."),
 		mcp.WithString("kind", mcp.Required(),
 			mcp.Description("Resource kind/type - must be the same for all resources in this request (e.g., 'Pod', 'Deployment', 'Service'). Use exact case-sensitive names as they appear in Kubernetes API. This constraint ensures efficient API batching while preventing resource type mixing that could cause context overflow.")),
 		mcp.WithArray("names", mcp.Required(),
-			mcp.Description("Array of exact resource names to retrieve detailed information for. All resources must be of the same kind specified in the 'kind' parameter. Names are case-sensitive and must match the metadata.name field exactly. Use this to get detailed info for multiple resources efficiently in one API call. Recommended to limit to 10-20 resources per request to avoid context overflow while still being more efficient than individual resource calls."),
+			mcp.Description("Array of exact resource names to retrieve detailed information for. All resources must be of the same kind specified in the 'kind' parameter. Names are case-sensitive and must match the metadata.name field exactly. Use this to get detailed info for multiple resources efficiently in one API call. At most 20 names are accepted per call; larger requests are rejected unless 'autoBatch' is true."),
 			mcp.WithStringItems()),
 		mcp.WithString("namespace",
 			mcp.Description("Required namespace for namespaced resources (Pod, Service, Deployment, ConfigMap, Secret, etc.). All resources must be in the same namespace. Omit only for cluster-scoped resources (Node, PersistentVolume, ClusterRole, etc.). This constraint enables efficient namespace-scoped API operations.")),
@@ -471,8 +839,13 @@ func GetResourcesDetailTool() mcp.Tool {
 			mcp.Description("Include related events for each resource (default: false). When set to true, retrieves recent events related to each resource, providing valuable context for troubleshooting. Events are automatically filtered and limited to prevent excessive output. Useful for understanding resource status, troubleshooting issues, or identifying recent changes affecting the resources.")),
 		mcp.WithBoolean("includeStatus",
 			mcp.Description("Include detailed status information (default: true). When false, focuses primarily on configuration and metadata, reducing output size. Status information includes conditions, readiness states, and runtime details that are essential for understanding resource state but can increase response size significantly.")),
+		mcp.WithBoolean("autoBatch",
+			mcp.Description("Fetch more than 20 names by splitting them into internal batches of 20 (default: false). Up to 100 names are fetched per call and combined into one result; when more remain, 'pagination.hasMore' is true and 'pagination.continueToken' fetches the next names.")),
+		mcp.WithString("continueToken",
+			mcp.Description("Pagination token from a previous autoBatch response. Pass 'pagination.continueToken' back with the same 'names' to fetch the next set.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable detailed debug output for troubleshooting the batch retrieval operation. Set to 'true' to see information about individual API calls, caching behavior, resource processing, and any issues encountered. Set to 'false' or omit for normal output showing only the resource details. Debug mode helps understand the efficiency improvements and identify any bottlenecks in the batch operation.")),
+		timeoutSecondsOption(),
 	)
 }
 
@@ -480,7 +853,7 @@ func GetResourcesDetailTool() mcp.Tool {
 func GetEventsDetailTool() mcp.Tool {
 	logrus.Debug("Creating GetEventsDetailTool")
 	return mcp.NewTool("kubernetes_get_events_detail",
-		mcp.WithDescription("📋 Retrieve comprehensive Kubernetes events with complete information for thorough analysis and troubleshooting. This tool provides full event details including all fields, timestamps, and messages without optimization for context size. Use this when you need complete event information for detailed investigation, audit purposes, or comprehensive troubleshooting. For quick checks, use the optimized get_recent_events tool instead. This tool includes pagination support to handle large event volumes while maintaining full detail preservation."),
+		mcp.WithDescription("📋 Retrieve comprehensive Kubernetes events with complete information for thorough analysis and troubleshooting. This tool provides full event details including all fields, timestamps, and messages without optimization for context size. Use this when you need complete event information for detailed investigation, audit purposes, or comprehensive troubleshooting. For quick checks, use the optimized get_recent_events tool instead. This tool includes pagination support to handle large event volumes while maintaining full detail preservation. Events are ordered by last occurrence (series lastObservedTime, lastTimestamp, eventTime), newest first, with ties broken by namespace and name, and pages follow that order."),
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace to filter events from. If not specified, events from all namespaces will be returned (requires cluster-wide permissions). For focused troubleshooting, specify the namespace where the problematic resources are located. Common namespaces include 'default', 'kube-system' (for cluster components), 'kube-public', or custom application namespaces.")),
 		mcp.WithString("fieldSelector",
@@ -488,7 +861,11 @@ func GetEventsDetailTool() mcp.Tool {
 		mcp.WithNumber("limit",
 			mcp.Description("Maximum number of events to return (default: 50, max: 200). This tool returns full event details, so be conservative with limits to avoid context overflow. Use higher limits only when you need comprehensive event analysis. Default is optimized for detailed investigation without overwhelming context.")),
 		mcp.WithString("continueToken",
-			mcp.Description("Pagination token from a previous response to fetch the next page of events. When the response indicates 'hasMore': true, use the provided 'continueToken' to get the next batch of events. This enables efficient traversal through large event sets while maintaining full detail.")),
+			mcp.Description("Pagination token from a previous response to fetch the next page of events. When the response indicates 'hasMore': true, use the provided 'continueToken' to get the next batch of events. This enables efficient traversal through large event sets while maintaining full detail. Pass the same namespace, fieldSelector and since filters as the previous call.")),
+		mcp.WithNumber("sinceMinutes",
+			mcp.Description("Only return events last seen within this many minutes. Applied client-side, since field selectors cannot filter on time. Cannot be combined with sinceTime.")),
+		mcp.WithString("sinceTime",
+			mcp.Description("Only return events last seen at or after this RFC3339 time (e.g. '2024-01-02T15:04:05Z'). Cannot be combined with sinceMinutes.")),
 		mcp.WithBoolean("includeNormalEvents",
 			mcp.Description("Include normal operational events (default: false). When set to false, only returns warning and error events for focused troubleshooting. When set to true, includes all events including normal operational events. Normal events are typically high-volume and less critical for troubleshooting.")),
 		mcp.WithString("debug",
@@ -505,6 +882,8 @@ func ListResourcesFullTool() mcp.Tool {
 			mcp.Description("Kubernetes resource kind/type to list with full details (e.g., 'Pod', 'Deployment', 'Service'). Use exact case-sensitive names as they appear in Kubernetes API. This tool will return complete objects for all matching resources, so use with caution.")),
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace to scope the resource listing. For namespaced resources, this filters results to only show resources within the specified namespace. If omitted for namespaced resources, shows resources from ALL namespaces (requires cluster-wide permissions). For cluster-scoped resources, this parameter is ignored.")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Optional apiVersion (e.g. 'networking.k8s.io/v1', 'cert-manager.io/v1') pinning which API group serves the kind. Only needed when the same kind exists in several API groups; in that case the tool returns an error listing the candidate apiVersions.")),
 		mcp.WithString("labelSelector",
 			mcp.Description("Label selector to filter resources based on their metadata labels. Use this powerful filtering mechanism to find resources matching specific criteria. Syntax: 'app=nginx', 'env=production', or 'app=nginx,env=prod' for multiple labels.")),
 		mcp.WithString("fieldSelector",
@@ -515,8 +894,18 @@ func ListResourcesFullTool() mcp.Tool {
 			mcp.Description("Pagination token from previous response to fetch the next page of results. When response indicates 'hasMore': true, use the provided 'continueToken' to get the next batch of full resources.")),
 		mcp.WithBoolean("includeStatus",
 			mcp.Description("Include detailed status information (default: true). When false, reduces output size by excluding runtime status fields while keeping configuration. Useful for configuration-focused analysis.")),
+		mcp.WithArray("fields",
+			mcp.Description("Dotted paths to keep, e.g. ['spec.replicas', 'spec.template.spec.containers.image', 'metadata.labels']. A path crossing a list applies to every element. apiVersion, kind, metadata.name and metadata.namespace are always kept. Omit to keep the whole object."),
+			mcp.WithStringItems()),
+		mcp.WithArray("dropFields",
+			mcp.Description("Dotted paths to remove after 'fields' is applied, e.g. ['metadata.managedFields', 'status.conditions', 'spec.template.spec.containers.env']. Use this for near-full objects without their noisiest sections."),
+			mcp.WithStringItems()),
+		mcp.WithString("outputFormat",
+			mcp.Enum("json", "yaml", "ndjson"),
+			mcp.Description("Response encoding: 'json' (default), 'yaml' or 'ndjson'. YAML output is a multi-document stream with one resource per document separated by '---'; count and pagination details are emitted as leading comments. NDJSON emits one compact resource per line and ends with a control object ('_control': 'pagination') holding the count and pagination details.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting the full resource listing operation. Set to 'true' to see detailed API information, processing steps, and any issues. Set to 'false' or omit for normal output.")),
+		timeoutSecondsOption(),
 	)
 }
 
@@ -531,33 +920,109 @@ func GetResourceDetailAdvancedTool() mcp.Tool {
 			mcp.Description("Exact resource name from metadata.name field.")),
 		mcp.WithString("namespace",
 			mcp.Description("Required for namespaced resources (Pod, Service, Deployment, etc.). Omit for cluster-scoped resources (Node, PersistentVolume, etc.).")),
+		mcp.WithString("apiVersion",
+			mcp.Description("Optional apiVersion (e.g. 'networking.k8s.io/v1', 'cert-manager.io/v1') pinning which API group serves the kind. Only needed when the same kind exists in several API groups; in that case the tool returns an error listing the candidate apiVersions.")),
 		mcp.WithBoolean("includeEvents",
 			mcp.Description("Include related events for context and troubleshooting (default: false). Events help understand what happened to the resource.")),
 		mcp.WithBoolean("includeRelationships",
 			mcp.Description("Include owner/dependent relationships (default: false). Shows what this resource depends on or what depends on it.")),
 		mcp.WithBoolean("includeDiagnostics",
-			mcp.Description("Include diagnostic information and health checks (default: false). Provides additional insights for troubleshooting.")),
+			mcp.Description("Include diagnostic information and health checks (default: false). Provides additional insights for troubleshooting. For Pods and workloads this adds a 'probes' section with each container's liveness/readiness/startup probe, its ready state and restarts, plus the pod's probe-failure events.")),
 		mcp.WithBoolean("includeConfiguration",
 			mcp.Description("Include full configuration details (default: true). When false, focuses on status and metadata only.")),
 		mcp.WithString("outputFormat",
-			mcp.Description("Output format preference: 'compact', 'structured', or 'verbose' (default: 'structured'). Controls detail level and organization.")),
+			mcp.Description("Output format preference: 'compact', 'structured', or 'verbose' (default: 'structured'). Controls detail level and organization. 'json' and 'yaml' return the structured layout in the given encoding.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable comprehensive debug output for troubleshooting the detail retrieval process (true/false).")),
 	)
 }
 
+// ClusterInfoTool returns a compact overview of the connected cluster
+func ClusterInfoTool() mcp.Tool {
+	logrus.Debug("Creating ClusterInfoTool")
+	return mcp.NewTool("kubernetes_cluster_info",
+		mcp.WithDescription("One-shot overview of the connected cluster: Kubernetes version, node count and readiness, total capacity and allocatable CPU/memory, namespace count, and whether metrics-server is installed. Use this first when connecting to a new cluster. Sections the caller lacks permission to read are reported as \"unknown\" with the reason under `errors`."),
+	)
+}
+
+// CurrentContextTool reports which cluster, user and namespace the server is using
+func CurrentContextTool() mcp.Tool {
+	logrus.Debug("Creating CurrentContextTool")
+	return mcp.NewTool("kubernetes_current_context",
+		mcp.WithDescription("Show which cluster and identity this server is operating on before running anything: connection mode (kubeconfig or in-cluster), kubeconfig context, cluster name and API server URL, default namespace, and the authenticated user and groups as resolved by the API server (SelfSubjectReview, Kubernetes 1.28+). If the user cannot be resolved the reason is returned under `userError`."),
+	)
+}
+
 // ============ Troubleshooting Tools ============
 
 // GetUnhealthyResourcesTool finds pods and resources in unhealthy states
 func GetUnhealthyResourcesTool() mcp.Tool {
 	logrus.Debug("Creating GetUnhealthyResourcesTool")
 	return mcp.NewTool("kubernetes_get_unhealthy_resources",
-		mcp.WithDescription("Find Kubernetes resources in unhealthy states (crash, pending, failed, etc.)"),
+		mcp.WithDescription("Find Kubernetes resources in unhealthy states (crash, pending, failed, etc.). Each unhealthy pod names the container most likely behind the failure with its waiting reason (e.g. CrashLoopBackOff, ImagePullBackOff), restart count and last exit code. Every returned resource carries its newest Warning event, the one most likely to explain what is wrong. Set rankBySeverity to sort worst first with a severity score and reason. Results are paginated."),
 		mcp.WithString("namespace",
 			mcp.Description("Namespace to scan. Empty = all namespaces")),
 		mcp.WithArray("resourceTypes",
 			mcp.Description("Resource types to check (Pod, Job, Deployment, StatefulSet, DaemonSet). Default: all"),
 			mcp.WithStringItems()),
+		mcp.WithBoolean("rankBySeverity",
+			mcp.Description("Score each resource and sort worst first: CrashLoopBackOff, then unschedulable pods, failed pods and jobs, containers waiting on errors such as ImagePullBackOff, high restart counts, and finally not ready. Default: false")),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of resources to return (default: 30, max: 80)")),
+		mcp.WithString("continueToken",
+			mcp.Description("Pagination token from a previous response. When 'hasMore' is true, pass 'pagination.continueToken' to fetch the next page.")),
+		timeoutSecondsOption(),
+	)
+}
+
+// RestartCountTool lists pods by container restarts to find crash-looping and flapping pods
+func RestartCountTool() mcp.Tool {
+	logrus.Debug("Creating RestartCountTool")
+	return mcp.NewTool("kubernetes_restart_count",
+		mcp.WithDescription("Find flapping and crash-looping pods. Lists pods sorted by total container restarts (most first) with per-container restart counts, current state, whether the container is in CrashLoopBackOff, the last termination reason and exit code, and the time of the last restart. More targeted than get_unhealthy_resources for crash investigations."),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to scan. Empty = all namespaces")),
+		mcp.WithString("labelSelector",
+			mcp.Description("Only include pods matching this label selector, e.g. 'app=web'")),
+		mcp.WithNumber("minRestarts",
+			mcp.Description("Only include pods with at least this many restarts (default: 1). Pods in CrashLoopBackOff are always included. Use 0 to list every pod.")),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of pods to return (default: 30, max: 80)")),
+		// "restart" names what is counted; nothing is restarted
+		mcp.WithReadOnlyHintAnnotation(true),
+	)
+}
+
+// QuotaSummaryTool reports ResourceQuota headroom and LimitRange constraints for a namespace
+func QuotaSummaryTool() mcp.Tool {
+	logrus.Debug("Creating QuotaSummaryTool")
+	return mcp.NewTool("kubernetes_quota_summary",
+		mcp.WithDescription("Report a namespace's quota headroom before deploying or when pods fail with 'exceeded quota'. Lists every ResourceQuota resource with used, hard, remaining and percent used, flags resources more than 90% consumed, and lists LimitRange defaults, default requests, minimums, maximums and limit/request ratios per object type."),
+		mcp.WithString("namespace", mcp.Required(),
+			mcp.Description("Namespace to report on")),
+	)
+}
+
+// HPAStatusTool reports whether the HorizontalPodAutoscalers of a namespace are scaling as intended
+func HPAStatusTool() mcp.Tool {
+	logrus.Debug("Creating HPAStatusTool")
+	return mcp.NewTool("kubernetes_get_hpa_status",
+		mcp.WithDescription("Answer 'is autoscaling working' in one call. For each HorizontalPodAutoscaler reports the target workload, current vs desired replicas and min/max bounds, current vs target value of every metric ('<unknown>' when metrics cannot be read), the conditions keeping it from scaling, and its last event with reason (e.g. SuccessfulRescale, FailedGetResourceMetric). Autoscalers are sorted by state: 'unable-to-scale' first, then 'scaling-up'/'scaling-down', 'limited' (held at min or max replicas) and 'stable'."),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to report on. Empty = all namespaces")),
+		mcp.WithReadOnlyHintAnnotation(true),
+	)
+}
+
+// NodeAllocationSummaryTool lists nodes by requested vs allocatable resources and pressure
+func NodeAllocationSummaryTool() mcp.Tool {
+	logrus.Debug("Creating NodeAllocationSummaryTool")
+	return mcp.NewTool("kubernetes_node_allocation_summary",
+		mcp.WithDescription("Fleet view of node capacity. Lists nodes with requested vs allocatable CPU and memory (summed from the requests of pods scheduled on each node), active pressure conditions (MemoryPressure, DiskPressure, PIDPressure, NetworkUnavailable), and pod count vs pod capacity. Nodes are sorted most-pressured first: nodes with pressure conditions or NotReady come first, then by highest allocation percentage. Use kubernetes_get_node_conditions to drill into a single node."),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of nodes to return per page (default: 20, max: 100).")),
+		mcp.WithString("continueToken",
+			mcp.Description("Pagination token from a previous response. When 'hasMore' is true, pass 'pagination.continueToken' to fetch the next page.")),
 	)
 }
 
@@ -571,15 +1036,42 @@ func GetNodeConditionsTool() mcp.Tool {
 	)
 }
 
+// NodePodsTool lists the pods scheduled on a node with their requests
+func NodePodsTool() mcp.Tool {
+	logrus.Debug("Creating NodePodsTool")
+	return mcp.NewTool("kubernetes_node_pods",
+		mcp.WithDescription("List every pod scheduled on a node across all namespaces, with phase, ready containers, restart count and effective CPU and memory requests. A totals row sums the requests of non-terminated pods against the node's allocatable resources to show how tightly the node is packed. Complements kubernetes_get_node_conditions for node-level troubleshooting."),
+		mcp.WithString("nodeName", mcp.Required(),
+			mcp.Description("Exact node name to list pods for")),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of pods to return per page (default: 30, max: 80). Totals always cover every pod on the node.")),
+		mcp.WithString("continueToken",
+			mcp.Description("Pagination token from a previous response. When 'hasMore' is true, pass 'pagination.continueToken' to fetch the next page.")),
+	)
+}
+
+// FindDeprecatedAPIsTool finds objects written through deprecated apiVersions before an upgrade
+func FindDeprecatedAPIsTool() mcp.Tool {
+	logrus.Debug("Creating FindDeprecatedAPIsTool")
+	return mcp.NewTool("kubernetes_find_deprecated_apis",
+		mcp.WithDescription("Pre-upgrade audit for deprecated and removed apiVersions. Lists the deprecated apiVersions the cluster still serves, from a built-in removal map and from the API server's own deprecation warnings, and the objects whose managedFields or kubectl last-applied-configuration show they were written through one (by which manager), with the replacement apiVersion and the release that removes it. Objects are stored once and readable through every served version, so this finds the clients and manifests that still need updating."),
+		mcp.WithString("namespace",
+			mcp.Description("Only scan namespaced objects in this namespace. Empty = cluster-wide, including cluster-scoped objects.")),
+		mcp.WithString("targetVersion",
+			mcp.Description("Kubernetes version you are upgrading to, e.g. '1.29'. Only APIs removed in or before it are reported. Empty = every known deprecation.")),
+		timeoutSecondsOption(),
+	)
+}
+
 // AnalyzeIssueTool performs AI-powered issue analysis
 func AnalyzeIssueTool() mcp.Tool {
 	logrus.Debug("Creating AnalyzeIssueTool")
 	return mcp.NewTool("kubernetes_analyze_issue",
-		mcp.WithDescription("AI-powered Kubernetes resource issue analysis with recommendations"),
+		mcp.WithDescription("AI-powered Kubernetes resource issue analysis with recommendations. service_unreachable checks the Service selector, endpoint readiness, backing pod health, target ports and NetworkPolicies, pvc_pending checks the StorageClass, provisioner, matching PersistentVolumes, quota and provisioning events, and pod_evicted explains evicted, preempted or vanished pods from the pod's status reason and DisruptionTarget condition, its node's pressure conditions and events, and Evicted, Preempted and taint eviction events (a pod that was already deleted is explained from its events); all three return ranked root-cause hypotheses with remediation steps"),
 		mcp.WithString("issueType", mcp.Required(),
-			mcp.Description("Issue type: pod_crash, pod_pending, deployment_unavailable, job_failed")),
+			mcp.Description("Issue type: pod_crash, pod_pending, pod_evicted, deployment_unavailable, job_failed, service_unreachable, pvc_pending")),
 		mcp.WithString("resourceKind", mcp.Required(),
-			mcp.Description("Resource kind (Pod, Deployment, Job, etc.)")),
+			mcp.Description("Resource kind (Pod, Deployment, Job, Service, PersistentVolumeClaim, etc.)")),
 		mcp.WithString("resourceName", mcp.Required(),
 			mcp.Description("Resource name to analyze")),
 		mcp.WithString("namespace",
@@ -589,13 +1081,29 @@ func AnalyzeIssueTool() mcp.Tool {
 
 // ============ Search Tools ============
 
+// FindResourceTool locates resources by exact name across all namespaces
+func FindResourceTool() mcp.Tool {
+	logrus.Debug("Creating FindResourceTool")
+	return mcp.NewTool("kubernetes_find_resource",
+		mcp.WithDescription("Find which namespace a resource lives in when you know its kind and exact name but not its namespace. Searches all namespaces with a single server-side name filter and returns every match with its namespace, apiVersion, creation time, phase and labels. 'unique' is true when exactly one resource matched; otherwise 'namespaces' lists all candidates. Use kubernetes_search_resources for partial or pattern matches."),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Resource kind, e.g. Pod, Deployment, Service, ConfigMap.")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Exact name of the resource.")),
+		timeoutSecondsOption(),
+	)
+}
+
 // SearchResourcesTool searches for Kubernetes resources by name with fuzzy matching
 func SearchResourcesTool() mcp.Tool {
 	logrus.Debug("Creating SearchResourcesTool")
 	return mcp.NewTool("kubernetes_search_resources",
-		mcp.WithDescription("Search Kubernetes resources by name with fuzzy matching. You may provide either `kind` or `resourceTypes`; if both are omitted, the server searches a default set of common resource types. You may provide either `query` or `name`; if omitted, the tool lists matching resources of the selected types up to `limit`."),
+		mcp.WithDescription("Search Kubernetes resources by name with fuzzy matching. You may provide `kind` for a single kind, or `kinds` (or `resourceTypes`) to search several kinds at once; if all are omitted, the server searches a default set of common resource types. When more than one kind is searched, `groups` lists the matched names per kind. You may provide either `query` or `name`; if omitted, the tool lists matching resources of the selected types up to `limit`."),
 		mcp.WithString("kind",
 			mcp.Description("Optional resource kind/type to search, for example `Pod`, `Deployment`, or `Service`. If omitted, the server will use `resourceTypes` or a default set of common kinds.")),
+		mcp.WithArray("kinds",
+			mcp.Description("Optional array of kinds to search in one call, e.g. `[\"Pod\",\"Deployment\",\"Service\"]`. Matches from all kinds share one `limit`, and the response adds `groups` listing the matched names per kind. Ignored when `kind` is set."),
+			mcp.WithStringItems()),
 		mcp.WithArray("resourceTypes",
 			mcp.Description("Optional array of resource types such as `[\"pods\"]` or `[\"Pod\",\"Deployment\"]`. Useful when the caller forgot `kind`; the server will use these values directly."),
 			mcp.WithStringItems()),
@@ -606,14 +1114,38 @@ func SearchResourcesTool() mcp.Tool {
 		mcp.WithString("namespace",
 			mcp.Description("Kubernetes namespace to scope the search. For namespaced resources, this filters results to only show resources within the specified namespace. If omitted, searches across ALL namespaces (requires cluster-wide permissions). For cluster-scoped resources, this parameter is ignored.")),
 		mcp.WithString("searchMode",
-			mcp.Description("Search strategy to use (default: 'contains'). Options: 'contains' (name contains the query), 'startsWith' (name starts with the query), 'endsWith' (name ends with the query), 'exact' (exact match), 'regex' (regular expression pattern). Use 'contains' for broad matching, 'startsWith' for prefix-based filtering, 'endsWith' for suffix-based filtering, 'exact' for precise matching, and 'regex' for complex patterns.")),
+			mcp.Description("Search strategy to use (default: 'contains'). Options: 'contains' (name contains the query), 'startsWith' (name starts with the query), 'endsWith' (name ends with the query), 'exact' (exact match), 'regex' (Go RE2 regular expression matched anywhere in the name; use '^' and '$' to anchor). 'fuzzy' (typo-tolerant: names are ranked by edit-distance similarity to the query and only those at least 60% similar are returned, each with a 'matchScore' between 0 and 1). Use 'contains' for broad matching, 'startsWith' for prefix-based filtering, 'endsWith' for suffix-based filtering, 'exact' for precise matching, 'regex' for complex patterns, and 'fuzzy' when you only half-remember a name. Invalid regex patterns are rejected before any resources are listed.")),
 		mcp.WithBoolean("caseSensitive",
-			mcp.Description("Whether the search should be case-sensitive (default: false). When false, the search ignores case differences, making it easier to find resources. When true, matches must respect the exact case of the query string.")),
+			mcp.Description("Whether the search should be case-sensitive (default: false). When false, the search ignores case differences, making it easier to find resources. When true, matches must respect the exact case of the query string. In 'regex' mode a case-insensitive search prefixes the pattern with '(?i)'.")),
 		mcp.WithNumber("limit",
 			mcp.Description("Maximum number of matching resources to return (default: 50, max: 200). This controls the size of the result set. Use smaller limits (10-20) for quick searches, larger limits (50-200) for comprehensive discovery.")),
+		mcp.WithString("continueToken",
+			mcp.Description("Pagination token from a previous response. When 'pagination.hasMore' is true, pass 'pagination.continueToken' to fetch the next page of matches.")),
 		mcp.WithString("labelSelector",
 			mcp.Description("Optional label selector to further filter search results. Use this to combine name-based search with label-based filtering. Syntax: 'app=nginx', 'env=production', or 'app=nginx,env=prod' for multiple labels.")),
+		mcp.WithString("outputFormat",
+			mcp.Enum("json", "csv"),
+			mcp.Description("Response encoding: 'json' (default) returns the matched resources; 'csv' returns a header row and one row per match with kind, namespace, name, status, age and, for fuzzy searches, matchScore, ready to paste into a spreadsheet. The count and pagination follow in a separate JSON content block.")),
+		mcp.WithString("includeLabels",
+			mcp.Description("With outputFormat 'csv', comma-separated label keys to add as 'labels.<key>' columns (e.g. 'app,version,team'). If omitted, a single 'labels' column lists the labels of non-Pod resources as key=value pairs.")),
 		mcp.WithString("debug",
 			mcp.Description("Enable verbose debug output for troubleshooting the search operation (true/false).")),
+		timeoutSecondsOption(),
 	)
 }
+
+// timeoutSecondsOption declares the optional per-call timeout shared by the heavier tools
+func timeoutSecondsOption() mcp.ToolOption {
+	return mcp.WithNumber("timeoutSeconds",
+		mcp.Description("Optional deadline for this call in seconds. When it passes the call stops and returns an 'operation timed out' error. Values above the server maximum (5 minutes unless configured otherwise) are lowered to it. If omitted the call runs with the client's usual timeouts."))
+}
+
+// AuthTokenParam is the argument every Kubernetes tool accepts to run with the caller's bearer token
+const AuthTokenParam = "authToken"
+
+// WithAuthTokenParam adds the optional authToken argument to tool
+func WithAuthTokenParam(tool mcp.Tool) mcp.Tool {
+	mcp.WithString(AuthTokenParam,
+		mcp.Description("Optional bearer token (e.g. an OIDC ID token) to run this call as the caller instead of the server's identity, so the caller's RBAC permissions apply. The token is never logged."))(&tool)
+	return tool
+}
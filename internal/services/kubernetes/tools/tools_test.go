@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -122,6 +123,13 @@ func TestResourceTypesSchemas(t *testing.T) {
 				return props["resourceTypes"].(map[string]any)
 			},
 		},
+		{
+			name: "search_resources kinds",
+			tool: func() map[string]any {
+				props := SearchResourcesTool().InputSchema.Properties
+				return props["kinds"].(map[string]any)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,3 +143,68 @@ func TestResourceTypesSchemas(t *testing.T) {
 		}
 	}
 }
+
+func TestClusterInfoTool_Definition(t *testing.T) {
+	tool := ClusterInfoTool()
+	if tool.Name != "kubernetes_cluster_info" {
+		t.Fatalf("unexpected name: %s", tool.Name)
+	}
+}
+
+func TestNodeAllocationSummaryTool_Definition(t *testing.T) {
+	tool := NodeAllocationSummaryTool()
+	if tool.Name != "kubernetes_node_allocation_summary" {
+		t.Fatalf("unexpected name: %s", tool.Name)
+	}
+}
+
+func TestGetResourceYAMLHistoryTool_Definition(t *testing.T) {
+	tool := GetResourceYAMLHistoryTool()
+	if tool.Name != "kubernetes_get_resource_yaml_history" {
+		t.Fatalf("unexpected name: %s", tool.Name)
+	}
+	if !reflect.DeepEqual(tool.InputSchema.Required, []string{"kind", "name"}) {
+		t.Fatalf("unexpected required params: %v", tool.InputSchema.Required)
+	}
+}
+
+func TestDeleteResourcesByLabelTool_Definition(t *testing.T) {
+	tool := DeleteResourcesByLabelTool()
+	if tool.Name != "kubernetes_delete_resources_by_label" {
+		t.Fatalf("unexpected name: %s", tool.Name)
+	}
+	if tool.Annotations.DestructiveHint == nil || !*tool.Annotations.DestructiveHint {
+		t.Fatal("expected DestructiveHint to be set")
+	}
+}
+
+func TestResolveServiceEndpointsTool_Definition(t *testing.T) {
+	tool := ResolveServiceEndpointsTool()
+	if tool.Name != "kubernetes_resolve_service_endpoints" {
+		t.Fatalf("unexpected name: %s", tool.Name)
+	}
+	if !reflect.DeepEqual(tool.InputSchema.Required, []string{"name", "namespace"}) {
+		t.Fatalf("unexpected required params: %v", tool.InputSchema.Required)
+	}
+}
+
+func TestDescribeIngressTool_Definition(t *testing.T) {
+	tool := DescribeIngressTool()
+	if tool.Name != "kubernetes_describe_ingress" {
+		t.Fatalf("unexpected name: %s", tool.Name)
+	}
+}
+
+func TestCurrentContextTool_Definition(t *testing.T) {
+	tool := CurrentContextTool()
+	if tool.Name != "kubernetes_current_context" {
+		t.Fatalf("unexpected name: %s", tool.Name)
+	}
+}
+
+func TestRefreshDiscoveryTool_Definition(t *testing.T) {
+	tool := RefreshDiscoveryTool()
+	if tool.Name != "kubernetes_refresh_discovery" {
+		t.Fatalf("unexpected name: %s", tool.Name)
+	}
+}
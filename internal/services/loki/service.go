@@ -2,8 +2,10 @@ package loki
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/cache"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/framework"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/loki/client"
@@ -111,6 +113,15 @@ func (s *Service) GetClient() *client.Client {
 	return nil
 }
 
+// CheckConnectivity checks the Loki API is reachable with the client injected into the request context.
+func (s *Service) CheckConnectivity(ctx context.Context) error {
+	c, err := client.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", services.ErrNoBackendClient, err)
+	}
+	return c.TestConnection(ctx)
+}
+
 func (s *Service) wrapToolErrors(toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		result, err := handler(ctx, request)
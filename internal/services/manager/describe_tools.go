@@ -0,0 +1,205 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// DescribeToolsToolName is the tool catalog registered on every MCP endpoint next to server_diagnostics
+const DescribeToolsToolName = "server_describe_tools"
+
+// serverToolService is the service reported for the tools registered by the server itself
+const serverToolService = "server"
+
+// Tool classifications reported by server_describe_tools
+const (
+	toolReadOnly    = "read-only"
+	toolWrite       = "write"
+	toolDestructive = "destructive"
+	toolUnknown     = "unknown"
+)
+
+// ToolDescription is one tool as reported by server_describe_tools
+type ToolDescription struct {
+	Name           string `json:"name"`
+	Service        string `json:"service"`
+	Description    string `json:"description"`
+	Classification string `json:"classification"`
+	InputSchema    any    `json:"inputSchema,omitempty"`
+}
+
+// DescribeToolsTool returns the definition of the server_describe_tools tool
+func DescribeToolsTool() mcp.Tool {
+	return mcp.NewTool(DescribeToolsToolName,
+		mcp.WithDescription("Catalog of the tools this server exposes: for each tool its name, service, description, JSON schema of its input parameters and whether it is read-only, writes or is destructive. Disabled tools are left out. Use it to discover tools and their exact arguments, or to pick only read-only tools."),
+		mcp.WithString("service",
+			mcp.Description("Only describe the tools of this service, e.g. kubernetes or server.")),
+		mcp.WithString("classification",
+			mcp.Description("Only describe tools with this classification."),
+			mcp.Enum(toolReadOnly, toolWrite, toolDestructive, toolUnknown)),
+		mcp.WithBoolean("includeSchema",
+			mcp.Description("Include the input JSON schema of each tool (default: true). Set to false for a compact listing.")),
+		mcp.WithReadOnlyHintAnnotation(true),
+	)
+}
+
+// HandleDescribeTools handles the server_describe_tools tool
+func (m *Manager) HandleDescribeTools(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	service := request.GetString("service", "")
+	classification := request.GetString("classification", "")
+	includeSchema := request.GetBool("includeSchema", true)
+	logger.WithFields(logrus.Fields{
+		"tool": DescribeToolsToolName, "service": service, "classification": classification, "includeSchema": includeSchema,
+	}).Debug("Handler invoked")
+
+	descriptions := make([]ToolDescription, 0)
+	counts := map[string]int{}
+	for _, description := range m.describeTools(includeSchema) {
+		if service != "" && description.Service != service {
+			continue
+		}
+		if classification != "" && description.Classification != classification {
+			continue
+		}
+		counts[description.Classification]++
+		descriptions = append(descriptions, description)
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"count":           len(descriptions),
+		"classifications": counts,
+		"tools":           descriptions,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool descriptions: %w", err)
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+// describeTools describes the enabled tools of every initialized service and the server tools, sorted
+// by service and name
+func (m *Manager) describeTools(includeSchema bool) []ToolDescription {
+	disabled := m.GetDisabledTools()
+	statuses := m.GetAllServiceStatus()
+
+	var descriptions []ToolDescription
+	for name, svc := range m.registry.GetEnabledServices() {
+		if !statuses[name] {
+			continue
+		}
+		for _, tool := range svc.GetTools() {
+			if !disabled[tool.Name] {
+				descriptions = append(descriptions, describeTool(name, tool, includeSchema))
+			}
+		}
+	}
+	for _, tool := range serverTools() {
+		if !disabled[tool.Name] {
+			descriptions = append(descriptions, describeTool(serverToolService, tool, includeSchema))
+		}
+	}
+
+	sort.Slice(descriptions, func(i, j int) bool {
+		if descriptions[i].Service != descriptions[j].Service {
+			return descriptions[i].Service < descriptions[j].Service
+		}
+		return descriptions[i].Name < descriptions[j].Name
+	})
+	return descriptions
+}
+
+// serverTools are the tools the server registers itself on every endpoint
+func serverTools() []mcp.Tool {
+	return []mcp.Tool{DiagnosticsTool(), DescribeToolsTool()}
+}
+
+func describeTool(service string, tool mcp.Tool, includeSchema bool) ToolDescription {
+	description := ToolDescription{
+		Name:           tool.Name,
+		Service:        service,
+		Description:    tool.Description,
+		Classification: classifyTool(tool),
+	}
+	if includeSchema {
+		if tool.RawInputSchema != nil {
+			description.InputSchema = tool.RawInputSchema
+		} else {
+			description.InputSchema = tool.InputSchema
+		}
+	}
+	return description
+}
+
+// defaultToolAnnotations are the annotations mcp.NewTool gives every tool; a tool still carrying them
+// says nothing about its effects
+var defaultToolAnnotations = mcp.NewTool("").Annotations
+
+// Words of tool names, matched against the words after the service prefix: verbs, and the nouns of
+// getters named without one such as grafana_dashboards
+var (
+	destructiveToolVerbs = wordSet("delete", "remove", "drain", "purge", "uninstall", "rollback", "evict", "kill", "terminate", "destroy", "revoke")
+	writeToolVerbs       = wordSet(
+		"create", "update", "patch", "apply", "scale", "restart", "cordon", "uncordon", "label", "annotate",
+		"install", "upgrade", "sync", "mute", "unmute", "migrate", "set", "add", "exec", "forward", "trigger",
+		"enable", "disable", "expire", "import", "publish", "send", "suspend", "resume", "restore", "upsert", "clear", "clone", "copy",
+		"move", "rename", "assign", "acknowledge", "close", "reopen", "run", "invalidate", "chat", "completion",
+	)
+	readOnlyToolVerbs = wordSet(
+		"get", "list", "search", "describe", "find", "query", "check", "analyze", "inspect", "validate", "explain",
+		"export", "compare", "diff", "count", "top", "summary", "summarize", "health", "status", "info", "fetch",
+		"watch", "preview", "render", "discover", "stats", "show", "view", "read", "lookup", "tail", "logs",
+		"events", "diagnostics", "refresh", "estimate", "test", "resolve", "time", "parse", "encode", "decode",
+		"convert", "format", "generate", "calculate", "sleep", "pause", "history", "metrics", "trace", "traces",
+		"overview", "detect", "suggest", "audit", "usage", "map", "graph", "report", "capabilities", "whoami",
+		"wait", "template", "retrieve", "current", "detail", "meta", "parameters", "site", "volume", "organization",
+		"nodes", "pods", "alerts", "users", "plugins", "folders", "datasources", "dashboard", "dashboards",
+	)
+)
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// classifyTool tells whether a tool is read-only, writes or is destructive. Explicit annotations are
+// trusted; tools left with the mcp.NewTool defaults are classified by the first verb in their name.
+func classifyTool(tool mcp.Tool) string {
+	annotations := tool.Annotations
+	explicit := !reflect.DeepEqual(annotations, defaultToolAnnotations)
+	if explicit && annotations.ReadOnlyHint != nil && *annotations.ReadOnlyHint {
+		return toolReadOnly
+	}
+	if explicit && annotations.DestructiveHint != nil && *annotations.DestructiveHint {
+		return toolDestructive
+	}
+
+	words := strings.Split(tool.Name, "_")
+	if len(words) > 1 {
+		// The first word is the service prefix
+		words = words[1:]
+	}
+	for _, word := range words {
+		switch {
+		case destructiveToolVerbs[word]:
+			return toolDestructive
+		case writeToolVerbs[word]:
+			return toolWrite
+		case readOnlyToolVerbs[word]:
+			return toolReadOnly
+		}
+	}
+	if explicit && annotations.DestructiveHint != nil {
+		return toolWrite
+	}
+	return toolUnknown
+}
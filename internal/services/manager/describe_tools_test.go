@@ -0,0 +1,101 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestHandleDescribeTools(t *testing.T) {
+	m := NewManager()
+	m.registry.Register(&MockService{name: "kubernetes", enabled: true, tools: []mcp.Tool{
+		mcp.NewTool("kubernetes_list_resources",
+			mcp.WithDescription("List resources."),
+			mcp.WithString("kind", mcp.Required())),
+		mcp.NewTool("kubernetes_scale_resource"),
+		mcp.NewTool("kubernetes_delete_resource"),
+		mcp.NewTool("kubernetes_restart_count", mcp.WithReadOnlyHintAnnotation(true)),
+		mcp.NewTool("kubernetes_hidden"),
+	}})
+	m.registry.Register(&MockService{name: "pending", enabled: true, tools: []mcp.Tool{mcp.NewTool("pending_get_status")}})
+	m.serviceStatus["kubernetes"] = true
+	m.disabledTools["kubernetes_hidden"] = true
+	m.disabledTools[DiagnosticsToolName] = true
+
+	describe := func(args map[string]any) (descriptions []ToolDescription) {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := m.HandleDescribeTools(context.Background(), request)
+		if err != nil {
+			t.Fatalf("HandleDescribeTools() error = %v", err)
+		}
+		var report struct {
+			Count int               `json:"count"`
+			Tools []ToolDescription `json:"tools"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report); err != nil {
+			t.Fatalf("failed to decode report: %v", err)
+		}
+		if report.Count != len(report.Tools) {
+			t.Fatalf("count = %d, but %d tools listed", report.Count, len(report.Tools))
+		}
+		return report.Tools
+	}
+
+	tools := describe(nil)
+	want := map[string]string{
+		"kubernetes_delete_resource": toolDestructive,
+		"kubernetes_list_resources":  toolReadOnly,
+		"kubernetes_restart_count":   toolReadOnly,
+		"kubernetes_scale_resource":  toolWrite,
+		DescribeToolsToolName:        toolReadOnly,
+	}
+	if len(tools) != len(want) {
+		t.Fatalf("expected %d tools, got %+v", len(want), tools)
+	}
+	for _, tool := range tools {
+		if tool.Classification != want[tool.Name] {
+			t.Fatalf("%s: classification = %s, want %s", tool.Name, tool.Classification, want[tool.Name])
+		}
+	}
+	if tools[0].Name != "kubernetes_delete_resource" || tools[4].Service != serverToolService {
+		t.Fatalf("expected tools sorted by service and name, got %+v", tools)
+	}
+
+	list := tools[1]
+	schema, _ := list.InputSchema.(map[string]any)
+	if list.Description != "List resources." || schema["type"] != "object" {
+		t.Fatalf("expected the description and input schema, got %+v", list)
+	}
+	if required, _ := schema["required"].([]any); len(required) != 1 || required[0] != "kind" {
+		t.Fatalf("expected the required parameters in the schema, got %v", schema)
+	}
+
+	filtered := describe(map[string]any{"service": "kubernetes", "classification": toolReadOnly, "includeSchema": false})
+	if len(filtered) != 2 || filtered[0].InputSchema != nil {
+		t.Fatalf("expected the two read-only kubernetes tools without schemas, got %+v", filtered)
+	}
+}
+
+func TestClassifyTool(t *testing.T) {
+	destructive := true
+	tests := []struct {
+		tool mcp.Tool
+		want string
+	}{
+		{mcp.NewTool("helm_uninstall_release"), toolDestructive},
+		{mcp.NewTool("grafana_update_dashboard"), toolWrite},
+		{mcp.NewTool("grafana_dashboards"), toolReadOnly},
+		{mcp.NewTool("kibana_bulk_get_saved_objects"), toolReadOnly},
+		{mcp.NewTool("dify_console_api_request"), toolUnknown},
+		{mcp.NewTool("kubernetes_test_tool", mcp.WithToolAnnotation(mcp.ToolAnnotation{DestructiveHint: &destructive})), toolDestructive},
+	}
+	for _, tt := range tests {
+		if got := classifyTool(tt.tool); got != tt.want {
+			t.Errorf("classifyTool(%s) = %s, want %s", tt.tool.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services"
+)
+
+const (
+	// DiagnosticsToolName is the server self-test tool registered on every MCP endpoint
+	DiagnosticsToolName = "server_diagnostics"
+
+	// diagnosticsCheckTimeout bounds a single backend connectivity check
+	diagnosticsCheckTimeout = 5 * time.Second
+
+	redactedValue = "********"
+)
+
+// Connectivity results reported per service by server_diagnostics
+const (
+	connectivityOK            = "ok"
+	connectivityFailed        = "failed"
+	connectivityNoCredentials = "no_credentials"
+	connectivityNotChecked    = "not_checked"
+)
+
+// BuildInfo describes the running server binary
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+}
+
+var (
+	buildInfoMu  sync.RWMutex
+	buildInfo    = BuildInfo{Version: "dev", Commit: "unknown", BuildTime: "unknown"}
+	processStart = time.Now()
+)
+
+// SetBuildInfo records the build metadata reported by server_diagnostics
+func SetBuildInfo(info BuildInfo) {
+	buildInfoMu.Lock()
+	buildInfo = info
+	buildInfoMu.Unlock()
+}
+
+func currentBuildInfo() BuildInfo {
+	buildInfoMu.RLock()
+	defer buildInfoMu.RUnlock()
+	info := buildInfo
+	if info.GoVersion == "" {
+		info.GoVersion = strings.TrimPrefix(runtime.Version(), "go")
+	}
+	return info
+}
+
+// ServiceDiagnostics is the state of one service as reported by server_diagnostics
+type ServiceDiagnostics struct {
+	Name              string `json:"name"`
+	Enabled           bool   `json:"enabled"`
+	InitError         string `json:"initError,omitempty"`
+	Tools             int    `json:"tools"`
+	Connectivity      string `json:"connectivity"`
+	ConnectivityError string `json:"connectivityError,omitempty"`
+	LatencyMs         int64  `json:"latencyMs,omitempty"`
+}
+
+// DiagnosticsTool returns the definition of the server_diagnostics tool
+func DiagnosticsTool() mcp.Tool {
+	return mcp.NewTool(DiagnosticsToolName,
+		mcp.WithDescription("Self-test of the MCP server. Reports version and build, uptime, every service with its initialization status, tool count and backend connectivity, registered and disabled tool counts, and a configuration summary with secrets redacted. Connectivity uses the backend credentials sent to the endpoint this tool is called on: services without credentials there report no_credentials, and services without a connectivity check report not_checked. Run this first when tools are failing."),
+		mcp.WithBoolean("checkConnectivity",
+			mcp.Description("Contact each enabled backend to check it is reachable (default: true). Set to false for an instant report without network calls.")),
+		mcp.WithBoolean("includeConfig",
+			mcp.Description("Include the redacted configuration summary (default: true).")),
+	)
+}
+
+// HandleServerDiagnostics handles the server_diagnostics tool
+func (m *Manager) HandleServerDiagnostics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	checkConnectivity := request.GetBool("checkConnectivity", true)
+	includeConfig := request.GetBool("includeConfig", true)
+	logger.WithFields(logrus.Fields{
+		"tool": DiagnosticsToolName, "checkConnectivity": checkConnectivity, "includeConfig": includeConfig,
+	}).Debug("Handler invoked")
+
+	now := time.Now()
+	report := map[string]interface{}{
+		"server":    currentBuildInfo(),
+		"startedAt": processStart.UTC().Format(time.RFC3339),
+		"uptime":    now.Sub(processStart).Truncate(time.Second).String(),
+		"runtime": map[string]interface{}{
+			"os":         runtime.GOOS,
+			"arch":       runtime.GOARCH,
+			"goroutines": runtime.NumGoroutine(),
+		},
+	}
+
+	serviceReports := m.serviceDiagnostics(ctx, checkConnectivity)
+	report["services"] = serviceReports
+
+	disabled := m.GetDisabledTools()
+	registered := 0
+	for _, tool := range m.GetAllTools() {
+		if !disabled[tool.Name] {
+			registered++
+		}
+	}
+	report["tools"] = map[string]interface{}{
+		"registered": registered,
+		"disabled":   len(disabled),
+	}
+
+	if includeConfig {
+		m.statusMutex.RLock()
+		appConfig := m.appConfig
+		m.statusMutex.RUnlock()
+		summary, err := redactedConfigSummary(appConfig)
+		if err != nil {
+			report["configError"] = err.Error()
+		} else {
+			report["config"] = summary
+		}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal diagnostics: %w", err)
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+// serviceDiagnostics reports every known service, sorted by name. Connectivity checks of the
+// enabled services run concurrently, each bounded by diagnosticsCheckTimeout.
+func (m *Manager) serviceDiagnostics(ctx context.Context, checkConnectivity bool) []*ServiceDiagnostics {
+	enabled := m.registry.GetEnabledServices()
+	statuses := m.GetAllServiceStatus()
+	initErrors := m.GetAllServiceErrors()
+
+	names := make(map[string]bool, len(statuses)+len(enabled))
+	for name := range statuses {
+		names[name] = true
+	}
+	for name := range enabled {
+		names[name] = true
+	}
+
+	reports := make([]*ServiceDiagnostics, 0, len(names))
+	var wg sync.WaitGroup
+	for name := range names {
+		report := &ServiceDiagnostics{Name: name, Connectivity: connectivityNotChecked}
+		if err := initErrors[name]; err != nil {
+			report.InitError = err.Error()
+		}
+		reports = append(reports, report)
+
+		svc, ok := enabled[name]
+		if !ok || !statuses[name] {
+			continue
+		}
+		report.Enabled = true
+		report.Tools = len(svc.GetTools())
+
+		checker, ok := svc.(services.ConnectivityChecker)
+		if !checkConnectivity || !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkServiceConnectivity(ctx, checker, report)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+	return reports
+}
+
+func checkServiceConnectivity(ctx context.Context, checker services.ConnectivityChecker, report *ServiceDiagnostics) {
+	checkCtx, cancel := context.WithTimeout(ctx, diagnosticsCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.CheckConnectivity(checkCtx)
+	report.LatencyMs = time.Since(start).Milliseconds()
+	switch {
+	case err == nil:
+		report.Connectivity = connectivityOK
+	case errors.Is(err, services.ErrNoBackendClient):
+		report.Connectivity = connectivityNoCredentials
+		report.ConnectivityError = err.Error()
+		report.LatencyMs = 0
+	default:
+		report.Connectivity = connectivityFailed
+		report.ConnectivityError = err.Error()
+	}
+}
+
+// redactedConfigSummary renders the configuration as its YAML field tree with credentials masked.
+// Empty credential fields are kept so the summary shows which ones are unset.
+func redactedConfigSummary(appConfig *config.AppConfig) (map[string]interface{}, error) {
+	if appConfig == nil {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(appConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render configuration: %w", err)
+	}
+	var tree map[string]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("failed to render configuration: %w", err)
+	}
+	redactConfigTree(tree)
+	return tree, nil
+}
+
+// sensitiveConfigKeys are substrings of configuration keys whose values are masked. Connection strings
+// and DSNs are masked whole because key/value and MySQL DSNs are not URLs, and webhook URLs because
+// services such as Slack and Teams carry the secret in the path.
+var sensitiveConfigKeys = []string{"password", "token", "secret", "apikey", "credential", "headers", "authorization", "cookie", "connectionstring", "dsn", "webhook"}
+
+func redactConfigTree(tree map[string]interface{}) {
+	for key, value := range tree {
+		if isSensitiveConfigKey(key) {
+			if !isEmptyConfigValue(value) {
+				tree[key] = redactedValue
+			}
+			continue
+		}
+		tree[key] = redactConfigValue(value)
+	}
+}
+
+func redactConfigValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redactConfigTree(v)
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactConfigValue(v[i])
+		}
+		return v
+	case string:
+		return redactURLCredentials(v)
+	default:
+		return value
+	}
+}
+
+func isSensitiveConfigKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveConfigKeys {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyConfigValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// redactURLCredentials masks the password of URLs carrying user info, such as DSNs
+func redactURLCredentials(value string) string {
+	if !strings.Contains(value, "://") || !strings.Contains(value, "@") {
+		return value
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return value
+	}
+	return parsed.Redacted()
+}
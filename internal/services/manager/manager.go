@@ -54,6 +54,7 @@ type Manager struct {
 	serviceStatus        map[string]bool  // Track service initialization status
 	serviceErrors        map[string]error // Track service initialization errors
 	statusMutex          sync.RWMutex     // Protect serviceStatus and serviceErrors from concurrent access
+	appConfig            *config.AppConfig
 }
 
 // NewManager creates a new service manager
@@ -68,6 +69,10 @@ func NewManager() *Manager {
 
 // Initialize initializes all services with the configuration using parallel initialization
 func (m *Manager) Initialize(appConfig *config.AppConfig) error {
+	m.statusMutex.Lock()
+	m.appConfig = appConfig
+	m.statusMutex.Unlock()
+
 	// Load disabled tools from configuration
 	if appConfig != nil {
 		m.disabledToolsMutex.Lock()
@@ -382,6 +387,19 @@ func (m *Manager) RegisterToolsAndHandlers(mcpServer *server.MCPServer) {
 		}
 	}
 
+	serverHandlers := map[string]server.ToolHandlerFunc{
+		DiagnosticsToolName:   m.HandleServerDiagnostics,
+		DescribeToolsToolName: m.HandleDescribeTools,
+	}
+	for _, tool := range serverTools() {
+		m.disabledToolsMutex.RLock()
+		disabled := m.disabledTools[tool.Name]
+		m.disabledToolsMutex.RUnlock()
+		if !disabled {
+			mcpServer.AddTool(tool, serverHandlers[tool.Name])
+		}
+	}
+
 	// Report statistics
 	logger.Infof("Registered %d tools from %d services", totalTools, len(services))
 	if missingHandlers > 0 {
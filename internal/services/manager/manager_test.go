@@ -69,10 +69,18 @@ func TestManagerInitialize(t *testing.T) {
 			name: "initialize with valid config",
 			appConfig: &config.AppConfig{
 				Kubernetes: struct {
-					Kubeconfig string  `yaml:"kubeconfig"`
-					TimeoutSec int     `yaml:"timeoutSec"`
-					QPS        float32 `yaml:"qps"`
-					Burst      int     `yaml:"burst"`
+					Kubeconfig           string  `yaml:"kubeconfig"`
+					ConnectionMode       string  `yaml:"connectionMode"`
+					TimeoutSec           int     `yaml:"timeoutSec"`
+					QPS                  float32 `yaml:"qps"`
+					Burst                int     `yaml:"burst"`
+					MaxIdleConns         int     `yaml:"maxIdleConns"`
+					MaxIdleConnsPerHost  int     `yaml:"maxIdleConnsPerHost"`
+					RateLimitDiscovery   bool    `yaml:"rateLimitDiscovery"`
+					DiscoveryCacheTTLSec int     `yaml:"discoveryCacheTTLSec"`
+					MaxToolTimeoutSec    int     `yaml:"maxToolTimeoutSec"`
+					RetryMaxAttempts     int     `yaml:"retryMaxAttempts"`
+					RetryBackoffMs       int     `yaml:"retryBackoffMs"`
 				}{
 					Kubeconfig: "testdata/kubeconfig", // Use testdata kubeconfig to avoid file not found error
 					TimeoutSec: 30,
@@ -86,10 +94,18 @@ func TestManagerInitialize(t *testing.T) {
 			name: "initialize with config for testing (no kubeconfig)",
 			appConfig: &config.AppConfig{
 				Kubernetes: struct {
-					Kubeconfig string  `yaml:"kubeconfig"`
-					TimeoutSec int     `yaml:"timeoutSec"`
-					QPS        float32 `yaml:"qps"`
-					Burst      int     `yaml:"burst"`
+					Kubeconfig           string  `yaml:"kubeconfig"`
+					ConnectionMode       string  `yaml:"connectionMode"`
+					TimeoutSec           int     `yaml:"timeoutSec"`
+					QPS                  float32 `yaml:"qps"`
+					Burst                int     `yaml:"burst"`
+					MaxIdleConns         int     `yaml:"maxIdleConns"`
+					MaxIdleConnsPerHost  int     `yaml:"maxIdleConnsPerHost"`
+					RateLimitDiscovery   bool    `yaml:"rateLimitDiscovery"`
+					DiscoveryCacheTTLSec int     `yaml:"discoveryCacheTTLSec"`
+					MaxToolTimeoutSec    int     `yaml:"maxToolTimeoutSec"`
+					RetryMaxAttempts     int     `yaml:"retryMaxAttempts"`
+					RetryBackoffMs       int     `yaml:"retryBackoffMs"`
 				}{
 					Kubeconfig: "", // Use empty kubeconfig to avoid file not found error
 					TimeoutSec: 30,
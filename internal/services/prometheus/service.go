@@ -5,12 +5,14 @@ package prometheus
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	server "github.com/mark3labs/mcp-go/server"
 	"github.com/sirupsen/logrus"
 
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/config"
+	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/cache"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/framework"
 	"github.com/mahmut-Abi/cloud-native-mcp-server/internal/services/prometheus/client"
@@ -38,8 +40,8 @@ func NewService() *Service {
 
 	// Create init configuration
 	initConfig := &framework.InitConfig{
-		Required:     false,
-		URLValidator: func(url string) bool { return url != "" },
+		Required:      false,
+		URLValidator:  func(url string) bool { return url != "" },
 		ClientBuilder: nil,
 	}
 
@@ -169,6 +171,15 @@ func (s *Service) GetClient() *client.Client {
 	return nil // Backend client is created per-request from HTTP headers
 }
 
+// CheckConnectivity checks the Prometheus API is reachable with the client injected into the request context.
+func (s *Service) CheckConnectivity(ctx context.Context) error {
+	c, err := client.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", services.ErrNoBackendClient, err)
+	}
+	return c.TestConnection(ctx)
+}
+
 func (s *Service) wrapToolErrors(toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		result, err := handler(ctx, request)
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"sync"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -25,6 +27,16 @@ type Service interface {
 	IsEnabled() bool
 }
 
+// ErrNoBackendClient is wrapped by connectivity checks when the request carries no credentials
+// for the service's backend
+var ErrNoBackendClient = errors.New("no backend credentials in this request")
+
+// ConnectivityChecker is implemented by services that can check their backend is reachable
+// using the client injected into the request context
+type ConnectivityChecker interface {
+	CheckConnectivity(ctx context.Context) error
+}
+
 // Registry manages all registered services
 type Registry struct {
 	mu       sync.RWMutex